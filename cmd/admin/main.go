@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/storage"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
+)
+
+const usage = `Usage: admin <command> [flags]
+
+Commands:
+  seed         Store sample categories and transactions
+  export       Write every transaction and exchange rate to a JSON archive
+  import       Load a JSON archive, or a CSV file of transactions with -csv
+  fetch-rates  Fetch and store the Treasury rates covering a date range
+  purge        Permanently remove transactions deleted before the retention period
+  migrate      Apply, revert or list database migrations (up | down [-steps N] | status)
+
+Run admin <command> -h for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command, args := os.Args[1], os.Args[2:]
+
+	// Share the server's configuration so every command targets the same database and Treasury endpoint
+	_ = godotenv.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if command == "migrate" {
+		storage.RunMigrateCommand("admin", &cfg.Database, args)
+		return
+	}
+
+	run, known := map[string]func(*config.Config, *storage.Storage, []string){
+		"seed":        runSeed,
+		"export":      runExport,
+		"import":      runImport,
+		"fetch-rates": runFetchRates,
+		"purge":       runPurge,
+	}[command]
+	if !known {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+
+	store, err := storage.NewStorage(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer store.Close()
+
+	run(cfg, store, args)
+}
+
+// runExport implements `admin export [-out FILE]`
+func runExport(_ *config.Config, store *storage.Storage, args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	out := flags.String("out", "", "File to write the archive to (default stdout)")
+	_ = flags.Parse(args)
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", *out, err)
+		}
+		defer file.Close()
+		w = file
+	}
+	summary, err := usecases.NewExportDatasetUseCase(store.TransactionRepository, store.ExchangeRateRepository, store.ConversionHistoryRepository).Execute(w)
+	if err != nil {
+		log.Fatalf("Export failed: %v", err)
+	}
+	if *out != "" {
+		fmt.Printf("Exported %d transactions, %d exchange rates and %d conversions to %s\n",
+			summary.Transactions, summary.ExchangeRates, summary.Conversions, *out)
+	}
+}
+
+// runImport implements `admin import -in FILE [-strategy skip|overwrite|fail] [-csv]`
+func runImport(_ *config.Config, store *storage.Storage, args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	in := flags.String("in", "", "Archive or CSV file to import (required)")
+	strategy := flags.String("strategy", dto.ConflictSkip, "How archive records with existing IDs are handled: skip, overwrite or fail")
+	csv := flags.Bool("csv", false, "Read a CSV file of transactions instead of a JSON archive")
+	_ = flags.Parse(args)
+
+	if *in == "" {
+		fmt.Fprintln(os.Stderr, "-in is required")
+		flags.Usage()
+		os.Exit(2)
+	}
+	file, err := os.Open(*in)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", *in, err)
+	}
+	defer file.Close()
+
+	validator := validation.NewValidator()
+	if *csv {
+		result, err := usecases.NewImportTransactionsUseCase(store.TransactionRepository, nil, validator).
+			WithCategories(store.CategoryRepository).
+			Execute(file)
+		if err != nil {
+			log.Fatalf("Import failed: %v", err)
+		}
+		fmt.Printf("Imported %d of %d rows\n", result.Imported, result.Rows)
+		for _, rowError := range result.Errors {
+			fmt.Printf("  line %d: %s\n", rowError.Line, strings.Join(rowError.Messages, "; "))
+		}
+		return
+	}
+
+	var archive dto.DatasetArchive
+	if err := json.NewDecoder(file).Decode(&archive); err != nil {
+		log.Fatalf("Failed to parse archive %s: %v", *in, err)
+	}
+	result, err := usecases.NewImportDatasetUseCase(store.TransactionRepository, store.ExchangeRateRepository, store.ConversionHistoryRepository, nil, validator).
+		WithUnitOfWork(store.UnitOfWork).
+		Execute(&dto.ImportArchiveRequest{Archive: &archive, Strategy: *strategy})
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+	fmt.Printf("Transactions: %d created, %d overwritten, %d skipped\n",
+		result.Transactions.Created, result.Transactions.Overwritten, result.Transactions.Skipped)
+	fmt.Printf("Exchange rates: %d created, %d overwritten, %d skipped\n",
+		result.ExchangeRates.Created, result.ExchangeRates.Overwritten, result.ExchangeRates.Skipped)
+	fmt.Printf("Conversions: %d created, %d overwritten, %d skipped\n",
+		result.Conversions.Created, result.Conversions.Overwritten, result.Conversions.Skipped)
+}
+
+// runFetchRates implements `admin fetch-rates -from DATE -to DATE [-currencies EUR,GBP]`
+func runFetchRates(cfg *config.Config, store *storage.Storage, args []string) {
+	flags := flag.NewFlagSet("fetch-rates", flag.ExitOnError)
+	fromFlag := flags.String("from", "", "First day of the range, YYYY-MM-DD (required)")
+	toFlag := flags.String("to", time.Now().UTC().Format("2006-01-02"), "Last day of the range, YYYY-MM-DD")
+	currenciesFlag := flags.String("currencies", "", "Comma-separated currencies to fetch (default every Treasury currency)")
+	_ = flags.Parse(args)
+
+	from, err := time.Parse("2006-01-02", *fromFlag)
+	if err != nil {
+		log.Fatalf("Invalid -from %q, expected YYYY-MM-DD", *fromFlag)
+	}
+	to, err := time.Parse("2006-01-02", *toFlag)
+	if err != nil {
+		log.Fatalf("Invalid -to %q, expected YYYY-MM-DD", *toFlag)
+	}
+
+	rateWindow, err := entities.NewRateWindow(cfg.Conversion.LookbackMonths)
+	if err != nil {
+		log.Fatalf("Invalid CONVERSION_LOOKBACK_MONTHS: %v", err)
+	}
+	treasuryCurrencies, err := external.LoadTreasuryCurrencies(cfg.Treasury.CurrencyMapFile)
+	if err != nil {
+		log.Fatalf("Invalid TREASURY_CURRENCY_MAP_FILE: %v", err)
+	}
+
+	currencies := treasuryCurrencies.Codes()
+	if *currenciesFlag != "" {
+		currencies = nil
+		for _, raw := range strings.Split(*currenciesFlag, ",") {
+			code, err := entities.NewCurrencyCode(strings.TrimSpace(raw))
+			if err != nil {
+				log.Fatalf("Invalid currency %q: %v", raw, err)
+			}
+			if _, mapped := treasuryCurrencies[code]; !mapped {
+				log.Fatalf("Currency %s has no Treasury rates", code)
+			}
+			currencies = append(currencies, code)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	treasury := external.NewTreasuryAPIClientWithCurrencies(&cfg.Treasury, rateWindow, treasuryCurrencies)
+	result, err := usecases.NewBackfillRatesUseCase(store.ExchangeRateRepository, treasury).
+		WithRateWindow(rateWindow).
+		Execute(ctx, currencies, from, to)
+	if result != nil {
+		fmt.Printf("Currencies: %d  Dates: %d  Stored: %d  Cached: %d  Failed: %d\n",
+			result.Currencies, result.Dates, result.Stored, result.Cached, result.Failed)
+		for lookup, message := range result.Errors {
+			fmt.Printf("  %s: %s\n", lookup, message)
+		}
+	}
+	if err != nil {
+		log.Fatalf("Rate fetch failed: %v", err)
+	}
+}
+
+// runPurge implements `admin purge [-days N]`
+func runPurge(_ *config.Config, store *storage.Storage, args []string) {
+	flags := flag.NewFlagSet("purge", flag.ExitOnError)
+	days := flags.Int("days", 30, "Keep transactions deleted within this many days; 0 empties the trash")
+	_ = flags.Parse(args)
+
+	result, err := usecases.NewPurgeTransactionsUseCase(store.TransactionRepository).
+		Execute(time.Duration(*days) * 24 * time.Hour)
+	if err != nil {
+		log.Fatalf("Purge failed: %v", err)
+	}
+	fmt.Printf("Purged %d transactions deleted before %s\n", result.Purged, result.Cutoff.Format(time.RFC3339))
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/storage"
+)
+
+// sampleCategories are stored by seed; sample transactions are spread over them
+var sampleCategories = []entities.Category{
+	{Name: "Travel", Description: "Flights, hotels and ground transport"},
+	{Name: "Office", Description: "Furniture and supplies"},
+	{Name: "Meals", Description: "Team lunches and client dinners"},
+	{Name: "Software", Description: "Subscriptions and licenses"},
+}
+
+// sampleDescriptions are the descriptions of seeded transactions, by category name
+var sampleDescriptions = map[string][]string{
+	"Travel":   {"Flight to Lisbon", "Hotel two nights", "Airport taxi", "Train ticket"},
+	"Office":   {"Standing desk", "Printer paper", "Monitor arm", "Whiteboard markers"},
+	"Meals":    {"Team lunch", "Client dinner", "Coffee beans"},
+	"Software": {"IDE license", "Cloud storage plan", "Design tool seat"},
+}
+
+// runSeed implements `admin seed [-transactions N] [-days N]`
+// Seeded transactions carry a seed:<n> external ID, so running it again only adds what is missing
+func runSeed(_ *config.Config, store *storage.Storage, args []string) {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	count := flags.Int("transactions", 50, "Number of sample transactions")
+	days := flags.Int("days", 180, "Spread the purchase dates over this many past days")
+	_ = flags.Parse(args)
+
+	if *count < 0 || *days < 1 {
+		log.Fatal("-transactions cannot be negative and -days must be at least 1")
+	}
+
+	categoriesCreated := 0
+	for _, sample := range sampleCategories {
+		existing, err := store.CategoryRepository.GetByName(sample.Name)
+		if err != nil {
+			log.Fatalf("Failed to look up category %q: %v", sample.Name, err)
+		}
+		if existing != nil {
+			continue
+		}
+		category := sample
+		category.ID = uuid.New()
+		if err := store.CategoryRepository.Save(&category); err != nil {
+			log.Fatalf("Failed to store category %q: %v", sample.Name, err)
+		}
+		categoriesCreated++
+	}
+
+	// A fixed seed makes the sample data the same on every machine
+	random := rand.New(rand.NewSource(1))
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	transactionsCreated := 0
+	for i := 1; i <= *count; i++ {
+		category := sampleCategories[random.Intn(len(sampleCategories))].Name
+		descriptions := sampleDescriptions[category]
+		externalID := fmt.Sprintf("seed:%d", i)
+		transaction := &entities.Transaction{
+			ID:          uuid.New(),
+			Description: descriptions[random.Intn(len(descriptions))],
+			Date:        today.AddDate(0, 0, -random.Intn(*days)),
+			Amount:      entities.Money(500 + random.Intn(150000)),
+			Currency:    entities.USD,
+			Category:    category,
+			ExternalID:  &externalID,
+		}
+
+		existing, err := store.TransactionRepository.GetByExternalID(externalID)
+		if err != nil {
+			log.Fatalf("Failed to look up transaction %s: %v", externalID, err)
+		}
+		if existing != nil {
+			continue
+		}
+		if err := store.TransactionRepository.Save(transaction); err != nil {
+			log.Fatalf("Failed to store transaction %s: %v", externalID, err)
+		}
+		transactionsCreated++
+	}
+
+	fmt.Printf("Seeded %d categories and %d transactions\n", categoriesCreated, transactionsCreated)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+)
+
+// Operations selected by the -op flag
+const (
+	opRotate  = "rotate"
+	opEncrypt = "encrypt"
+)
+
+func main() {
+	op := flag.String("op", opRotate, "Operation: rotate re-encrypts DB_PATH under a new key; encrypt writes an encrypted copy of a plaintext DB_PATH")
+	newKeyFile := flag.String("new-key-file", "", "File holding the new key for rotate (default DB_NEW_ENCRYPTION_KEY)")
+	out := flag.String("out", "", "Destination of the encrypted copy for encrypt")
+	flag.Parse()
+
+	// Share the server's configuration so the tool targets the same database file and key source
+	_ = godotenv.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	key, err := database.ResolveEncryptionKey(cfg.Database.EncryptionKey, cfg.Database.EncryptionKeyFile)
+	if err != nil {
+		log.Fatalf("Failed to load encryption key: %v", err)
+	}
+	if key == "" {
+		log.Fatal("DB_ENCRYPTION_KEY or DB_ENCRYPTION_KEY_FILE must be set")
+	}
+
+	switch *op {
+	case opRotate:
+		newKey, err := database.ResolveEncryptionKey(os.Getenv("DB_NEW_ENCRYPTION_KEY"), *newKeyFile)
+		if err != nil {
+			log.Fatalf("Failed to load new encryption key: %v", err)
+		}
+		if err := database.RekeySQLite(cfg.Database.Path, key, newKey); err != nil {
+			log.Fatalf("Key rotation failed: %v", err)
+		}
+		fmt.Printf("Rotated the encryption key of %s; update DB_ENCRYPTION_KEY or DB_ENCRYPTION_KEY_FILE before restarting the server\n", cfg.Database.Path)
+
+	case opEncrypt:
+		if *out == "" {
+			log.Fatal("-out is required for encrypt")
+		}
+		if err := database.EncryptSQLite(cfg.Database.Path, *out, key); err != nil {
+			log.Fatalf("Encryption failed: %v", err)
+		}
+		fmt.Printf("Wrote an encrypted copy of %s to %s; point DB_PATH at it and remove the plaintext file\n", cfg.Database.Path, *out)
+
+	default:
+		fmt.Fprintf(os.Stderr, "invalid operation %q, expected rotate or encrypt\n", *op)
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Operation names understood by the -mix flag
+const (
+	opCreate  = "create"
+	opList    = "list"
+	opConvert = "convert"
+)
+
+// options holds the command line configuration for a load test run
+type options struct {
+	target      string
+	concurrency int
+	duration    time.Duration
+	mix         map[string]int
+	currency    string
+	timeout     time.Duration
+}
+
+// result records the outcome of a single request
+type result struct {
+	operation string
+	latency   time.Duration
+	status    int
+	err       error
+}
+
+// stats aggregates results for a single operation
+type stats struct {
+	latencies []time.Duration
+	errors    int
+	byStatus  map[int]int
+}
+
+func main() {
+	opts, err := parseFlags()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	client := &http.Client{Timeout: opts.timeout}
+
+	// Seed one transaction so list/convert traffic has something to work with
+	seedID, err := createTransaction(client, opts.target)
+	if err != nil {
+		log.Fatalf("Failed to seed transaction against %s: %v", opts.target, err)
+	}
+
+	log.Printf("Running load test against %s for %s with %d workers (mix %s)",
+		opts.target, opts.duration, opts.concurrency, formatMix(opts.mix))
+
+	results := run(client, opts, seedID)
+	report(os.Stdout, results, opts.duration)
+}
+
+// parseFlags reads and validates command line flags
+func parseFlags() (*options, error) {
+	target := flag.String("target", "http://localhost:8080", "Base URL of the API instance under test")
+	concurrency := flag.Int("concurrency", 10, "Number of concurrent workers")
+	duration := flag.Duration("duration", 30*time.Second, "How long to generate traffic")
+	mix := flag.String("mix", "create=40,list=40,convert=20", "Relative weights of create/list/convert requests")
+	currency := flag.String("currency", "EUR", "Target currency for convert requests")
+	timeout := flag.Duration("timeout", 10*time.Second, "Per-request timeout")
+	flag.Parse()
+
+	if *concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1")
+	}
+	if *duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive")
+	}
+
+	weights, err := parseMix(*mix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &options{
+		target:      strings.TrimRight(*target, "/"),
+		concurrency: *concurrency,
+		duration:    *duration,
+		mix:         weights,
+		currency:    strings.ToUpper(*currency),
+		timeout:     *timeout,
+	}, nil
+}
+
+// parseMix parses a weight specification such as "create=40,list=40,convert=20"
+func parseMix(spec string) (map[string]int, error) {
+	weights := make(map[string]int)
+	total := 0
+
+	for _, part := range strings.Split(spec, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			return nil, fmt.Errorf("invalid mix entry %q, expected name=weight", part)
+		}
+
+		switch name {
+		case opCreate, opList, opConvert:
+		default:
+			return nil, fmt.Errorf("unknown operation %q in mix", name)
+		}
+
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q for %s", value, name)
+		}
+
+		weights[name] = weight
+		total += weight
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("mix must contain at least one positive weight")
+	}
+
+	return weights, nil
+}
+
+// formatMix renders the mix in a stable order for logging
+func formatMix(mix map[string]int) string {
+	parts := make([]string, 0, len(mix))
+	for _, name := range []string{opCreate, opList, opConvert} {
+		if weight, ok := mix[name]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%d", name, weight))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// pickOperation chooses an operation according to the configured weights
+func pickOperation(rng *rand.Rand, mix map[string]int) string {
+	total := 0
+	for _, weight := range mix {
+		total += weight
+	}
+
+	n := rng.Intn(total)
+	for _, name := range []string{opCreate, opList, opConvert} {
+		n -= mix[name]
+		if n < 0 {
+			return name
+		}
+	}
+
+	return opList
+}
+
+// run starts the workers and collects their results until the duration elapses
+func run(client *http.Client, opts *options, seedID string) []result {
+	deadline := time.Now().Add(opts.duration)
+	resultsCh := make(chan result, opts.concurrency*4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.concurrency; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewSource(time.Now().UnixNano() + int64(worker)))
+			for time.Now().Before(deadline) {
+				resultsCh <- execute(client, opts, pickOperation(rng, opts.mix), seedID)
+			}
+		}(i)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	var results []result
+	for r := range resultsCh {
+		results = append(results, r)
+	}
+
+	return results
+}
+
+// execute performs a single request for the given operation
+func execute(client *http.Client, opts *options, operation, seedID string) result {
+	var req *http.Request
+	var err error
+
+	switch operation {
+	case opCreate:
+		req, err = http.NewRequest(http.MethodPost, opts.target+"/api/v1/transactions", bytes.NewReader(createPayload()))
+	case opList:
+		req, err = http.NewRequest(http.MethodGet, opts.target+"/api/v1/transactions?page=1&size=20", nil)
+	case opConvert:
+		body := fmt.Sprintf(`{"target_currency":%q}`, opts.currency)
+		req, err = http.NewRequest(http.MethodPost, opts.target+"/api/v1/transactions/"+seedID+"/convert", strings.NewReader(body))
+	}
+	if err != nil {
+		return result{operation: operation, err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return result{operation: operation, latency: latency, err: err}
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return result{operation: operation, latency: latency, status: resp.StatusCode}
+}
+
+// createPayload builds a request body for POST /transactions
+func createPayload() []byte {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"description": "Load test purchase",
+		"date":        time.Now().UTC().AddDate(0, -1, 0).Format(time.RFC3339),
+		"amount":      25.50,
+	})
+	return payload
+}
+
+// createTransaction creates the seed transaction and returns its ID
+func createTransaction(client *http.Client, target string) (string, error) {
+	resp, err := client.Post(target+"/api/v1/transactions", "application/json", bytes.NewReader(createPayload()))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return created.ID, nil
+}
+
+// report prints throughput, status breakdown and latency percentiles per operation
+func report(w io.Writer, results []result, duration time.Duration) {
+	byOperation := make(map[string]*stats)
+	for _, r := range results {
+		s, ok := byOperation[r.operation]
+		if !ok {
+			s = &stats{byStatus: make(map[int]int)}
+			byOperation[r.operation] = s
+		}
+
+		if r.err != nil {
+			s.errors++
+			continue
+		}
+		s.latencies = append(s.latencies, r.latency)
+		s.byStatus[r.status]++
+	}
+
+	fmt.Fprintf(w, "\nTotal requests: %d (%.1f req/s)\n\n", len(results), float64(len(results))/duration.Seconds())
+	fmt.Fprintf(w, "%-8s %8s %8s %10s %10s %10s %10s %10s  %s\n",
+		"op", "count", "errors", "p50", "p90", "p95", "p99", "max", "status")
+
+	for _, name := range []string{opCreate, opList, opConvert} {
+		s, ok := byOperation[name]
+		if !ok {
+			continue
+		}
+
+		sort.Slice(s.latencies, func(i, j int) bool { return s.latencies[i] < s.latencies[j] })
+
+		fmt.Fprintf(w, "%-8s %8d %8d %10s %10s %10s %10s %10s  %s\n",
+			name,
+			len(s.latencies)+s.errors,
+			s.errors,
+			percentile(s.latencies, 50),
+			percentile(s.latencies, 90),
+			percentile(s.latencies, 95),
+			percentile(s.latencies, 99),
+			percentile(s.latencies, 100),
+			formatStatuses(s.byStatus),
+		)
+	}
+}
+
+// percentile returns the p-th percentile of sorted latencies using nearest-rank
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1].Round(time.Microsecond)
+}
+
+// formatStatuses renders status code counts as "200:10 404:1"
+func formatStatuses(byStatus map[int]int) string {
+	codes := make([]int, 0, len(byStatus))
+	for code := range byStatus {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	parts := make([]string, len(codes))
+	for i, code := range codes {
+		parts[i] = fmt.Sprintf("%d:%d", code, byStatus[code])
+	}
+	return strings.Join(parts, " ")
+}
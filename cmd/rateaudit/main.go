@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/joho/godotenv"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/storage"
+)
+
+// Report formats understood by the -format flag
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+func main() {
+	sample := flag.Int("sample", 100, "Number of stored conversion records to audit")
+	format := flag.String("format", formatText, "Report format: text or json")
+	strict := flag.Bool("strict", false, "Exit with status 1 when any mismatch is found")
+	flag.Parse()
+
+	if *format != formatText && *format != formatJSON {
+		fmt.Fprintf(os.Stderr, "invalid format %q, expected text or json\n", *format)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Share the server's configuration so the audit reads the same database and Treasury endpoint
+	_ = godotenv.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	store, err := storage.NewStorage(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer store.Close()
+
+	rateWindow, err := entities.NewRateWindow(cfg.Conversion.LookbackMonths)
+	if err != nil {
+		log.Fatalf("Invalid CONVERSION_LOOKBACK_MONTHS: %v", err)
+	}
+
+	treasuryCurrencies, err := external.LoadTreasuryCurrencies(cfg.Treasury.CurrencyMapFile)
+	if err != nil {
+		log.Fatalf("Invalid TREASURY_CURRENCY_MAP_FILE: %v", err)
+	}
+
+	audit := usecases.NewAuditConversionRatesUseCase(store.ConversionRecordRepository,
+		external.NewTreasuryAPIClientWithCurrencies(&cfg.Treasury, rateWindow, treasuryCurrencies))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := audit.Execute(ctx, *sample)
+	if err != nil {
+		log.Fatalf("Rate audit failed: %v", err)
+	}
+
+	if *format == formatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	} else {
+		writeText(os.Stdout, report)
+	}
+
+	if *strict && report.Mismatched > 0 {
+		os.Exit(1)
+	}
+}
+
+// writeText prints a human-readable reconciliation report
+func writeText(w io.Writer, report *dto.RateAuditReport) {
+	fmt.Fprintf(w, "Rate audit against %s at %s\n", report.Provider, report.GeneratedAt.Format("2006-01-02 15:04:05 MST"))
+	fmt.Fprintf(w, "Sampled: %d  Matched: %d  Mismatched: %d  Unavailable: %d\n",
+		report.Sampled, report.Matched, report.Mismatched, report.Unavailable)
+
+	if len(report.Entries) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%-36s %-11s %-4s %-11s %10s %-11s %10s %-11s %12s %12s\n",
+		"record", "outcome", "ccy", "purchased", "stored", "effective", "source", "effective", "stored_amt", "source_amt")
+
+	for _, entry := range report.Entries {
+		if entry.Outcome == dto.AuditUnavailable {
+			fmt.Fprintf(w, "%-36s %-11s %-4s %-11s %10.4f %-11s  %s\n",
+				entry.RecordID, entry.Outcome, entry.TargetCurrency, entry.TransactionDate,
+				entry.StoredRate, entry.StoredEffectiveDate, entry.Error)
+			continue
+		}
+
+		fmt.Fprintf(w, "%-36s %-11s %-4s %-11s %10.4f %-11s %10.4f %-11s %12.2f %12.2f\n",
+			entry.RecordID, entry.Outcome, entry.TargetCurrency, entry.TransactionDate,
+			entry.StoredRate, entry.StoredEffectiveDate,
+			entry.AuthoritativeRate, entry.AuthoritativeEffectiveDate,
+			entry.StoredConvertedAmount, entry.AuthoritativeConvertedAmount)
+	}
+}
@@ -1,69 +1,351 @@
 package main
 
 import (
+	"context"
+	"io"
 	"log"
+	"log/slog"
+	"net"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
-	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/auth"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/broker"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/cache"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/email"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/events"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/graphql"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/grpc"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/handlers"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/openapi"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/scheduler"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/storage"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/errortracking"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/lifecycle"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
 )
 
+// version is reported by /health; override at build time with -ldflags "-X main.version=..."
+var version = "1.0.0"
+
 func main() {
+	startedAt := time.Now()
+
 	// Load .env file (ignore error if file doesn't exist - for production flexibility)
 	_ = godotenv.Load()
 
-	// Load configuration
-	cfg := config.LoadConfig()
+	// Load configuration, failing fast on any invalid key
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// `server migrate up|down|status` manages the database schema instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		storage.RunMigrateCommand("server", &cfg.Database, os.Args[2:])
+		return
+	}
 
 	// Initialize structured logger
 	appLogger := logger.NewLogger(logger.LoggerConfig{
 		Level:  cfg.Logger.Level,
 		Format: cfg.Logger.Format,
+		Export: logger.ExportConfig{
+			Target:      cfg.Logger.Export.Target,
+			Endpoint:    cfg.Logger.Export.Endpoint,
+			ServiceName: cfg.Logger.Export.ServiceName,
+		},
 	})
+	defer func() {
+		if err := appLogger.Close(); err != nil {
+			log.Printf("Error flushing log export: %v", err)
+		}
+	}()
+
+	// Route package-level slog calls (e.g. from use cases) through the same handler
+	slog.SetDefault(appLogger.Logger)
 
 	appLogger.Info("Starting Purchase Transaction API",
-		"version", "1.0.0",
+		"version", version,
 		"environment", os.Getenv("ENVIRONMENT"),
 		"log_level", cfg.Logger.Level,
 	)
 
-	// Initialize database
-	db, err := database.NewSQLiteDB(cfg.Database.Path)
+	// Background jobs and components are stopped once the listeners have drained, in reverse order of registration
+	if cfg.Server.DrainTimeoutSecs < 1 || cfg.Server.ShutdownTimeoutSecs < 1 {
+		log.Fatalf("Invalid shutdown configuration: SHUTDOWN_DRAIN_TIMEOUT_SECONDS and SHUTDOWN_WORKER_TIMEOUT_SECONDS must be at least 1")
+	}
+	lifecycleManager := lifecycle.NewManager(time.Duration(cfg.Server.ShutdownTimeoutSecs) * time.Second)
+
+	// Report panics, 5xx errors and Treasury outages to Sentry when a DSN is configured; queued events are sent last on shutdown
+	release := cfg.Sentry.Release
+	if release == "" {
+		release = version
+	}
+	errorReporter, err := errortracking.NewReporter(errortracking.Config{
+		DSN:         cfg.Sentry.DSN,
+		Environment: cfg.Sentry.Environment,
+		Release:     release,
+	}, "purchase-transaction-api/"+version)
+	if err != nil {
+		log.Fatalf("Invalid SENTRY_DSN: %v", err)
+	}
+	if errorReporter != nil {
+		lifecycleManager.OnShutdown("error reporting", lifecycle.CloseHook(errorReporter))
+		appLogger.Info("Error reporting enabled", "environment", cfg.Sentry.Environment, "release", release)
+	}
+
+	// SIGHUP re-reads LOG_LEVEL, e.g. after editing the CONFIG_FILE
+	lifecycleManager.Go("log level reload", func(ctx context.Context) { reloadLogLevelOnHangup(ctx, appLogger) })
+
+	// Initialize storage and repositories for the configured driver
+	store, err := storage.NewStorage(&cfg.Database)
 	if err != nil {
 		appLogger.LogError(err, "Failed to initialize database")
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
-	defer func() {
-		if err := db.Close(); err != nil {
-			appLogger.LogError(err, "Error closing database")
-		}
-	}()
+	lifecycleManager.OnShutdown("database", lifecycle.CloseHook(store))
+
+	appLogger.Info("Database initialized successfully", "driver", store.Driver, "path", cfg.Database.Path, "replicas", len(cfg.Database.ReplicaDSNs))
+
+	transactionRepo := store.TransactionRepository
+	exchangeRateRepo := store.ExchangeRateRepository
+	quoteRepo := store.QuoteRepository
+	conversionRecordRepo := store.ConversionRecordRepository
+	conversionBatchRepo := store.ConversionBatchRepository
+	budgetRepo := store.BudgetRepository
+	categoryRepo := store.CategoryRepository
+	reportRepo := store.ReportRepository
+	rateSubscriptionRepo := store.RateSubscriptionRepository
+	apiTokenRepo := store.APITokenRepository
 
-	appLogger.Info("Database initialized successfully", "path", cfg.Database.Path)
+	// Activity recorder feeds counts that are not persisted (conversions, Treasury failures) into the digest
+	recorder := activity.NewRecorder()
+
+	// Keep hot rate lookups out of the database and the Treasury API (in-process LRU or shared Redis)
+	rateCache, rateCacheBackend, err := cache.NewRateCacheFromConfig(&cfg.RateCache)
+	if err != nil {
+		appLogger.LogError(err, "Invalid rate cache configuration")
+		log.Fatalf("Invalid rate cache configuration: %v", err)
+	}
+	if redisBackend, ok := rateCacheBackend.(*cache.RedisBackend); ok {
+		lifecycleManager.OnShutdown("redis", lifecycle.CloseHook(redisBackend))
+	}
+	if rateCache != nil {
+		appLogger.Info("Rate cache enabled", "backend", cfg.RateCache.Backend, "ttl_seconds", cfg.RateCache.TTLSeconds)
+	}
 
-	// Initialize repositories
-	transactionRepo := database.NewTransactionRepository(db.GetDB())
-	exchangeRateRepo := database.NewExchangeRateRepository(db.GetDB())
+	// Rates apply to purchases made within the lookback window after they take effect
+	rateWindow, err := entities.NewRateWindow(cfg.Conversion.LookbackMonths)
+	if err != nil {
+		log.Fatalf("Invalid CONVERSION_LOOKBACK_MONTHS: %v", err)
+	}
+	staleWindow, err := entities.NewRateWindow(cfg.Conversion.StaleMaxMonths)
+	if err != nil {
+		log.Fatalf("Invalid CONVERSION_STALE_MAX_MONTHS: %v", err)
+	}
+	rounding, err := entities.ParseRoundingMode(cfg.Conversion.Rounding)
+	if err != nil {
+		log.Fatalf("Invalid CONVERSION_ROUNDING: %v", err)
+	}
 
 	// Initialize external services
-	treasuryService := external.NewTreasuryAPIClient(&cfg.Treasury)
+	treasuryCurrencies, err := external.LoadTreasuryCurrencies(cfg.Treasury.CurrencyMapFile)
+	if err != nil {
+		log.Fatalf("Invalid TREASURY_CURRENCY_MAP_FILE: %v", err)
+	}
+	treasuryClient := external.NewTreasuryAPIClientWithCurrencies(&cfg.Treasury, rateWindow, treasuryCurrencies)
+
+	// Fail Treasury calls fast while the API is down instead of waiting for every timeout
+	var treasuryBreaker *external.CircuitBreaker
+	if cfg.Treasury.BreakerEnabled {
+		treasuryBreaker = external.NewTreasuryCircuitBreaker(&cfg.Treasury)
+		treasuryClient = external.NewCircuitBreakerTreasuryService(treasuryClient, treasuryBreaker)
+	}
+	treasuryClient = external.NewReportingTreasuryService(treasuryClient, errorReporter)
+
+	// Fetch each rate from the configured providers in turn, so a currency the Treasury has no rate for falls back to the next
+	rateProvider, err := external.NewConfiguredRateProvider(&cfg.Providers, treasuryClient, rateWindow)
+	if err != nil {
+		log.Fatalf("Invalid rate provider configuration: %v", err)
+	}
+	if rateCache != nil {
+		rateProvider = cache.NewCachingTreasuryService(rateProvider, rateCache, rateWindow)
+	}
+	treasuryService := external.NewInstrumentedTreasuryService(rateProvider, recorder)
+	appLogger.Info("Rate providers configured", "order", strings.Join(cfg.Providers.Order, ","))
 	appLogger.Info("External services initialized")
 
-	// Initialize validator
-	validator := validator.New()
+	// Initialize validator with custom tags (e.g. currency)
+	validator := validation.NewValidator()
+
+	// Initialize conversion margin policy (global and per API key)
+	margins, err := usecases.NewMarginPolicy(cfg.Conversion.MarginBps, cfg.Conversion.MarginBpsByAPIKey)
+	if err != nil {
+		appLogger.LogError(err, "Invalid conversion margin configuration")
+		log.Fatalf("Invalid conversion margin configuration: %v", err)
+	}
+
+	// Supersede stored conversions when a rate closer to their transaction date is ingested
+	var refreshConversionsUseCase *usecases.RefreshConversionsUseCase
+	if cfg.Conversion.RefreshEnabled {
+		refreshConversionsUseCase = usecases.NewRefreshConversionsUseCase(conversionRecordRepo, events.NewLogPublisher(appLogger)).
+			WithRateWindow(rateWindow).
+			WithRounding(rounding)
+		lifecycleManager.OnShutdown("conversion refresh", lifecycle.WaitHook(refreshConversionsUseCase.Wait))
+		appLogger.Info("Conversion refresh enabled")
+	}
+
+	// Rate lookups are cached and counted, and stored rates refresh conversions; rates written by units of work
+	// go through the same decorators once their transaction commits
+	decorateExchangeRates := func(repo repositories.ExchangeRateRepository) repositories.ExchangeRateRepository {
+		if rateCache != nil {
+			repo = cache.NewCachingExchangeRateRepository(repo, rateCache)
+		}
+		// Count local rate lookups that hit or miss the cache, reported by the admin cache endpoint
+		repo = storage.NewInstrumentedExchangeRateRepository(repo, recorder)
+		if refreshConversionsUseCase != nil {
+			repo = usecases.NewNotifyingExchangeRateRepository(repo, refreshConversionsUseCase)
+		}
+		return repo
+	}
+	exchangeRateRepo = decorateExchangeRates(exchangeRateRepo)
+	unitOfWork := storage.NewDecoratedUnitOfWork(store.UnitOfWork, decorateExchangeRates)
+
+	// Database growth is measured against an optional soft quota
+	quotaBytes := int64(cfg.Database.QuotaMB) * 1024 * 1024
+	monitorDatabaseUseCase := usecases.NewMonitorDatabaseUseCase(store, quotaBytes, cfg.Database.QuotaWarnPercent, cfg.Database.QuotaBlockImports)
+
+	// Use cases publish domain events on an in-process bus; subscribers (budgets, webhooks, logging) handle them in the background
+	eventBus := events.NewBus()
+	lifecycleManager.OnShutdown("event handlers", lifecycle.WaitHook(eventBus.Wait))
+
+	// With a message broker configured, every event is also stored in the outbox and relayed to the broker
+	var eventPublisher services.EventPublisher = eventBus
+	messageBroker, err := broker.NewPublisherFromConfig(&cfg.Broker)
+	if err != nil {
+		appLogger.LogError(err, "Invalid event broker configuration")
+		log.Fatalf("Invalid event broker configuration: %v", err)
+	}
+	if messageBroker != nil {
+		if cfg.Broker.RelayIntervalSeconds < 1 {
+			log.Fatalf("Invalid event broker configuration: EVENT_OUTBOX_RELAY_INTERVAL_SECONDS must be at least 1")
+		}
+		if closer, ok := messageBroker.(io.Closer); ok {
+			lifecycleManager.OnShutdown("event broker", lifecycle.CloseHook(closer))
+		}
+		eventPublisher = usecases.NewOutboxEventPublisher(eventBus, store.OutboxRepository, cfg.Broker.Topic)
+		appLogger.Info("Event broker enabled", "broker", cfg.Broker.Type, "topic", cfg.Broker.Topic)
+	}
 
 	// Initialize use cases with logger context
-	createTransactionUseCase := usecases.NewCreateTransactionUseCase(transactionRepo, validator)
 	getTransactionUseCase := usecases.NewGetTransactionUseCase(transactionRepo)
-	listTransactionsUseCase := usecases.NewListTransactionsUseCase(transactionRepo, validator)
-	convertTransactionUseCase := usecases.NewConvertTransactionUseCase(transactionRepo, exchangeRateRepo, treasuryService, validator)
+	convertTransactionUseCase := usecases.NewConvertTransactionUseCase(transactionRepo, exchangeRateRepo, quoteRepo, treasuryService, margins, validator).
+		WithEventPublisher(eventPublisher).
+		WithConversionHistory(store.ConversionHistoryRepository).
+		WithUnitOfWork(unitOfWork).
+		WithRateWindow(rateWindow).
+		WithRounding(rounding).
+		WithCrossRates(cfg.Conversion.CrossRates).
+		WithStaleFallback(cfg.Conversion.StaleFallback, staleWindow)
+	listConversionsUseCase := usecases.NewListConversionsUseCase(transactionRepo, store.ConversionHistoryRepository)
+
+	// Compare category spend with budgets whenever a transaction is stored, logging (and optionally emailing) crossed thresholds
+	budgetAlerts := events.NewBudgetLogPublisher(appLogger)
+	if len(cfg.Budget.AlertRecipients) > 0 && cfg.Digest.SMTP.Host != "" {
+		budgetAlerts = email.NewBudgetAlertNotifier(email.NewSMTPSender(&cfg.Digest.SMTP), cfg.Budget.AlertRecipients, budgetAlerts)
+		appLogger.Info("Budget alert emails enabled", "recipients", len(cfg.Budget.AlertRecipients))
+	}
+	evaluateBudgetsUseCase := usecases.NewEvaluateBudgetsUseCase(budgetRepo, transactionRepo, convertTransactionUseCase, budgetAlerts)
+	eventBus.Subscribe(entities.EventTransactionCreated, evaluateBudgetsUseCase.OnTransactionCreated)
+
+	// Send transaction.created and transaction.converted to registered webhooks, signed and retried with backoff
+	if cfg.Webhook.TimeoutSeconds < 1 || cfg.Webhook.RetryIntervalSec < 1 {
+		log.Fatalf("Invalid webhook configuration: WEBHOOK_TIMEOUT_SECONDS and WEBHOOK_RETRY_INTERVAL_SECONDS must be at least 1")
+	}
+	webhookTimeout := time.Duration(cfg.Webhook.TimeoutSeconds) * time.Second
+	dispatchWebhooksUseCase := usecases.NewDispatchWebhooksUseCase(
+		store.WebhookRepository,
+		external.NewWebhookSender(&cfg.Webhook),
+		cfg.Webhook.MaxAttempts,
+		time.Duration(cfg.Webhook.RetryBaseSeconds)*time.Second,
+		2*webhookTimeout,
+	)
+	eventBus.Subscribe(entities.EventTransactionCreated, dispatchWebhooksUseCase.OnTransactionEvent)
+	eventBus.Subscribe(entities.EventTransactionConverted, dispatchWebhooksUseCase.OnTransactionEvent)
+
+	eventBus.Subscribe(entities.EventTransactionCreated, events.NewEventLogHandler(appLogger))
+	eventBus.Subscribe(entities.EventTransactionConverted, events.NewEventLogHandler(appLogger))
+
+	// Transaction changes commit together with their outbox messages, so none is lost if the process stops in between
+	if messageBroker != nil {
+		transactionRepo = usecases.NewOutboxTransactionRepository(transactionRepo, unitOfWork, eventBus, cfg.Broker.Topic, clock.System())
+	} else {
+		transactionRepo = usecases.NewPublishingTransactionRepository(transactionRepo, eventBus, clock.System())
+	}
+
+	idempotencyWindow := time.Duration(cfg.Idempotency.WindowHours) * time.Hour
+	createTransactionUseCase := usecases.NewCreateTransactionUseCase(transactionRepo, validator).
+		WithCategories(categoryRepo).
+		WithCurrencies(convertTransactionUseCase).
+		WithIdempotency(store.IdempotencyKeyRepository, idempotencyWindow)
+	listTransactionsUseCase := usecases.NewListTransactionsUseCase(transactionRepo, convertTransactionUseCase, validator).
+		WithMargins(margins)
+	suggestDescriptionsUseCase := usecases.NewSuggestDescriptionsUseCase(transactionRepo, validator)
+	restoreTransactionUseCase := usecases.NewRestoreTransactionUseCase(transactionRepo)
+	deleteTransactionUseCase := usecases.NewDeleteTransactionUseCase(transactionRepo)
+	importTransactionsUseCase := usecases.NewImportTransactionsUseCase(transactionRepo, monitorDatabaseUseCase, validator).
+		WithCategories(categoryRepo)
+	updateTransactionCategoryUseCase := usecases.NewUpdateTransactionCategoryUseCase(transactionRepo, categoryRepo, validator)
+	convertAmountUseCase := usecases.NewConvertAmountUseCase(convertTransactionUseCase, convertTransactionUseCase, margins, validator)
+	quoteTTL := time.Duration(cfg.Quote.TTLMinutes) * time.Minute
+	getExchangeRateUseCase := usecases.NewGetExchangeRateUseCase(convertTransactionUseCase, margins, validator)
+	createQuoteUseCase := usecases.NewCreateQuoteUseCase(quoteRepo, convertTransactionUseCase, quoteTTL, validator)
+	exportDatasetUseCase := usecases.NewExportDatasetUseCase(transactionRepo, exchangeRateRepo, store.ConversionHistoryRepository)
+	importDatasetUseCase := usecases.NewImportDatasetUseCase(transactionRepo, exchangeRateRepo, store.ConversionHistoryRepository, monitorDatabaseUseCase, validator).
+		WithUnitOfWork(unitOfWork)
+	batchConversionUseCase := usecases.NewBatchConversionUseCase(
+		transactionRepo,
+		conversionBatchRepo,
+		conversionRecordRepo,
+		convertTransactionUseCase,
+		cfg.Conversion.BatchConcurrency,
+		validator,
+	)
+	getCurrencyUseCase := usecases.NewGetCurrencyUseCase(treasuryService)
+	manageBudgetsUseCase := usecases.NewManageBudgetsUseCase(budgetRepo, convertTransactionUseCase, validator)
+	manageCategoriesUseCase := usecases.NewManageCategoriesUseCase(categoryRepo, transactionRepo, budgetRepo, validator).
+		WithUnitOfWork(unitOfWork)
+	summarizeSpendingUseCase := usecases.NewSummarizeSpendingUseCase(reportRepo, convertTransactionUseCase, validator)
+	rateFreshFor := time.Duration(cfg.RateSync.FreshDays) * 24 * time.Hour
+	manageRateSubscriptionsUseCase := usecases.NewManageRateSubscriptionsUseCase(rateSubscriptionRepo, exchangeRateRepo, convertTransactionUseCase, rateFreshFor)
+	manageWebhooksUseCase := usecases.NewManageWebhooksUseCase(store.WebhookRepository, validator)
+	auditLogUseCase := usecases.NewAuditLogUseCase(store.AuditLogRepository)
+	manageAPITokensUseCase := usecases.NewManageAPITokensUseCase(apiTokenRepo, validator)
+	manageRateCacheUseCase := usecases.NewManageRateCacheUseCase(exchangeRateRepo, recorder, startedAt)
+	healthDependencies := []usecases.HealthDependency{{Name: "database", Pinger: store}}
+	if redisBackend, ok := rateCacheBackend.(*cache.RedisBackend); ok {
+		healthDependencies = append(healthDependencies, usecases.HealthDependency{Name: "redis", Pinger: redisBackend})
+	}
+	checkHealthUseCase := usecases.NewCheckHealthUseCase(version, startedAt, healthDependencies...)
+	if treasuryBreaker != nil {
+		checkHealthUseCase.WithCircuitBreaker("treasury", treasuryBreaker)
+	}
 
 	appLogger.Info("Use cases initialized")
 
@@ -73,11 +355,250 @@ func main() {
 		getTransactionUseCase,
 		listTransactionsUseCase,
 		convertTransactionUseCase,
+		suggestDescriptionsUseCase,
+		restoreTransactionUseCase,
+		deleteTransactionUseCase,
+		importTransactionsUseCase,
+		updateTransactionCategoryUseCase,
+		auditLogUseCase,
+		listConversionsUseCase,
 	)
+	currencyHandler := handlers.NewCurrencyHandler(getCurrencyUseCase)
+	conversionHandler := handlers.NewConversionHandler(convertAmountUseCase, createQuoteUseCase, getExchangeRateUseCase)
+	budgetHandler := handlers.NewBudgetHandler(manageBudgetsUseCase)
+	categoryHandler := handlers.NewCategoryHandler(manageCategoriesUseCase)
+	reportHandler := handlers.NewReportHandler(summarizeSpendingUseCase)
+	graphqlHandler := handlers.NewGraphQLHandler(graphql.NewExecutor(getTransactionUseCase, listTransactionsUseCase, convertTransactionUseCase))
+	rateSubscriptionHandler := handlers.NewRateSubscriptionHandler(manageRateSubscriptionsUseCase)
+	webhookHandler := handlers.NewWebhookHandler(manageWebhooksUseCase)
+	auditHandler := handlers.NewAuditHandler(auditLogUseCase)
+	adminHandler := handlers.NewAdminHandler(exportDatasetUseCase, importDatasetUseCase, batchConversionUseCase, monitorDatabaseUseCase, manageRateCacheUseCase).
+		WithLogLevel(appLogger)
+	apiTokenHandler := handlers.NewAPITokenHandler(manageAPITokensUseCase)
+	healthHandler := handlers.NewHealthHandler(checkHealthUseCase)
+	metricsHandler := handlers.NewMetricsHandler(monitorDatabaseUseCase, recorder, startedAt)
+
+	// Initialize per-route rate limit profiles
+	var limiter *middleware.RateLimiter
+	if len(cfg.RateLimit.Profiles) > 0 {
+		limiter, err = middleware.NewRateLimiter(cfg.RateLimit.Profiles)
+		if err != nil {
+			appLogger.LogError(err, "Invalid rate limit configuration")
+			log.Fatalf("Invalid rate limit configuration: %v", err)
+		}
+		appLogger.Info("Rate limiting enabled", "profiles", cfg.RateLimit.Profiles)
+	}
+
+	// Bound how long requests may run; conversions get longer since they may wait on the Treasury API
+	timeoutProfiles := make(map[string]time.Duration, len(cfg.Server.RequestTimeoutSecsByProfile))
+	for profile, seconds := range cfg.Server.RequestTimeoutSecsByProfile {
+		timeoutProfiles[profile] = time.Duration(seconds) * time.Second
+	}
+	requestTimeouts := middleware.NewRequestTimeouts(time.Duration(cfg.Server.RequestTimeoutSecs)*time.Second, timeoutProfiles)
+
+	// Seed the bootstrap admin token so the first tokens can be issued through the API
+	if cfg.Auth.BootstrapToken != "" {
+		if err := manageAPITokensUseCase.EnsureBootstrapToken(cfg.Auth.BootstrapToken); err != nil {
+			appLogger.LogError(err, "Failed to store bootstrap API token")
+			log.Fatalf("Failed to store bootstrap API token: %v", err)
+		}
+	}
+
+	// Require API tokens on /api/v1 once enabled; without one the routes stay open
+	var tokenAuth *middleware.TokenAuth
+	if cfg.Auth.RequireTokens {
+		admins, err := manageAPITokensUseCase.CountActiveAdmins()
+		if err != nil {
+			log.Fatalf("Failed to count admin API tokens: %v", err)
+		}
+		if admins == 0 {
+			log.Fatalf("API_TOKENS_REQUIRED is set but no active admin token exists; set API_BOOTSTRAP_TOKEN")
+		}
+		tokenAuth = middleware.NewTokenAuth(manageAPITokensUseCase)
+		appLogger.Info("API token authentication enabled", "admin_tokens", admins)
+	}
+
+	// Accept JWT bearer tokens once an algorithm is configured; this also requires authentication on /api/v1
+	jwtVerifier, err := auth.NewJWTVerifier(&cfg.Auth.JWT)
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+	if jwtVerifier != nil {
+		if tokenAuth == nil {
+			tokenAuth = middleware.NewTokenAuth(manageAPITokensUseCase)
+		}
+		tokenAuth.WithBearer(jwtVerifier)
+		appLogger.Info("JWT bearer authentication enabled",
+			"algorithm", cfg.Auth.JWT.Algorithm,
+			"issuer", cfg.Auth.JWT.Issuer,
+			"audience", cfg.Auth.JWT.Audience,
+		)
+	}
+	if tokenAuth == nil {
+		appLogger.Warn("Admin routes are disabled; set API_TOKENS_REQUIRED or JWT_ALGORITHM to enable them")
+	}
+
+	// Optionally check requests and responses against the published OpenAPI contract
+	spec, err := openapi.Load()
+	if err != nil {
+		log.Fatalf("Failed to load OpenAPI contract: %v", err)
+	}
+	contractValidator, err := middleware.NewContractValidator(spec, cfg.Server.ContractValidation)
+	if err != nil {
+		log.Fatalf("Invalid OPENAPI_VALIDATION: %v", err)
+	}
+	if contractValidator != nil {
+		appLogger.Info("OpenAPI contract validation enabled", "mode", cfg.Server.ContractValidation)
+	}
+
+	// Log request and response bodies for debugging client integrations; only emitted at DEBUG level
+	var payloadLogger *middleware.PayloadLogger
+	if cfg.Logger.Payloads {
+		payloadLogger = middleware.NewPayloadLogger(appLogger, cfg.Logger.PayloadMaxBytes, cfg.Logger.RedactFields)
+		appLogger.Info("Payload logging enabled", "max_bytes", cfg.Logger.PayloadMaxBytes, "level", cfg.Logger.Level)
+	}
+
+	// Announce the v1 removal timeline on every v1 response once a deprecation date is configured
+	v1Deprecation, err := middleware.ParseDeprecationPolicy(cfg.Deprecation.V1DeprecatedAt, cfg.Deprecation.V1SunsetAt, cfg.Deprecation.V1Link)
+	if err != nil {
+		log.Fatalf("Invalid API v1 deprecation configuration: %v", err)
+	}
+	if v1Deprecation != nil {
+		appLogger.Info("API v1 marked as deprecated",
+			"deprecated_at", v1Deprecation.DeprecatedAt,
+			"sunset_at", v1Deprecation.SunsetAt,
+		)
+	}
 
 	// Initialize router with logger
-	router := http.NewRouter(transactionHandler, appLogger)
-	ginRouter := router.SetupRoutes()
+	router := http.NewRouter(transactionHandler, currencyHandler, conversionHandler, budgetHandler, categoryHandler, reportHandler, graphqlHandler, rateSubscriptionHandler, webhookHandler, auditHandler, adminHandler, apiTokenHandler, healthHandler, metricsHandler, recorder, limiter, appLogger).
+		WithTokenAuth(tokenAuth).
+		WithContractValidator(contractValidator).
+		WithPayloadLogger(payloadLogger).
+		WithRequestTimeouts(requestTimeouts).
+		WithErrorReporter(errorReporter).
+		WithV1Deprecation(v1Deprecation)
+
+	// Start the scheduled email digest when recipients and an SMTP server are configured
+	if len(cfg.Digest.Recipients) > 0 && cfg.Digest.SMTP.Host != "" {
+		renderer, err := email.NewDigestRenderer(cfg.Digest.TemplatePath)
+		if err != nil {
+			appLogger.LogError(err, "Failed to load digest template")
+			log.Fatalf("Failed to load digest template: %v", err)
+		}
+
+		sendDigestUseCase := usecases.NewSendDigestUseCase(
+			transactionRepo,
+			recorder,
+			renderer,
+			email.NewSMTPSender(&cfg.Digest.SMTP),
+			cfg.Digest.Recipients,
+		)
+		lifecycleManager.Go("email digest", scheduler.NewDigestJob(sendDigestUseCase, cfg.Digest.Period, cfg.Digest.HourUTC, appLogger).Run)
+
+		appLogger.Info("Email digest enabled",
+			"period", cfg.Digest.Period,
+			"hour_utc", cfg.Digest.HourUTC,
+			"recipients", len(cfg.Digest.Recipients),
+		)
+	}
+
+	// Record database size and row counts, alerting as the soft quota is approached
+	monitorInterval := time.Duration(cfg.Database.MonitorIntervalMins) * time.Minute
+	lifecycleManager.Go("database monitor", scheduler.NewDatabaseMonitorJob(monitorDatabaseUseCase, monitorInterval, appLogger).Run)
+
+	// Move old transactions to cold storage when a retention period is configured
+	if cfg.Database.ArchiveAfterYears > 0 {
+		if cfg.Database.ArchiveIntervalHours < 1 {
+			log.Fatalf("Invalid DB_ARCHIVE_INTERVAL_HOURS %d: must be at least 1", cfg.Database.ArchiveIntervalHours)
+		}
+
+		archiveTransactionsUseCase := usecases.NewArchiveTransactionsUseCase(transactionRepo, cfg.Database.ArchiveAfterYears, cfg.Database.ArchiveBatchSize)
+		archiveInterval := time.Duration(cfg.Database.ArchiveIntervalHours) * time.Hour
+		lifecycleManager.Go("transaction archiving", scheduler.NewArchiveJob(archiveTransactionsUseCase, archiveInterval, appLogger).Run)
+
+		appLogger.Info("Transaction archiving enabled",
+			"after_years", cfg.Database.ArchiveAfterYears,
+			"interval_hours", cfg.Database.ArchiveIntervalHours,
+		)
+	}
+
+	// Import purchases from connected bank accounts when any connection is configured
+	if len(cfg.Bank.Connections) > 0 {
+		if cfg.Bank.SyncIntervalMins < 1 {
+			log.Fatalf("Invalid BANK_SYNC_INTERVAL_MINUTES %d: must be at least 1", cfg.Bank.SyncIntervalMins)
+		}
+
+		connections := make([]dto.BankConnection, 0, len(cfg.Bank.Connections))
+		for name, accessToken := range cfg.Bank.Connections {
+			lookbackDays, ok := cfg.Bank.LookbackDaysByConnection[name]
+			if !ok {
+				lookbackDays = cfg.Bank.LookbackDays
+			}
+			connections = append(connections, dto.BankConnection{
+				Name:         name,
+				AccessToken:  accessToken,
+				AccountIDs:   cfg.Bank.AccountsByConnection[name],
+				LookbackDays: lookbackDays,
+			})
+		}
+		sort.Slice(connections, func(i, j int) bool { return connections[i].Name < connections[j].Name })
+
+		ingestBankTransactionsUseCase := usecases.NewIngestBankTransactionsUseCase(transactionRepo, external.NewBankAggregatorClient(&cfg.Bank))
+		syncInterval := time.Duration(cfg.Bank.SyncIntervalMins) * time.Minute
+		lifecycleManager.Go("bank sync", scheduler.NewBankSyncJob(ingestBankTransactionsUseCase, connections, syncInterval, appLogger).Run)
+
+		appLogger.Info("Bank sync enabled",
+			"connections", len(connections),
+			"interval_minutes", cfg.Bank.SyncIntervalMins,
+		)
+	}
+
+	// Keep the rates of subscribed currencies fresh, most out-of-date first
+	if cfg.RateSync.IntervalMins < 1 {
+		log.Fatalf("Invalid RATE_SYNC_INTERVAL_MINUTES %d: must be at least 1", cfg.RateSync.IntervalMins)
+	}
+	syncSubscribedRatesUseCase := usecases.NewSyncSubscribedRatesUseCase(rateSubscriptionRepo, exchangeRateRepo, treasuryService, rateFreshFor, cfg.RateSync.MaxPerRun).
+		WithRateWindow(rateWindow)
+	rateSyncInterval := time.Duration(cfg.RateSync.IntervalMins) * time.Minute
+	lifecycleManager.Go("rate sync", scheduler.NewRateSyncJob(syncSubscribedRatesUseCase, rateSyncInterval, appLogger).Run)
+
+	// Retry failed webhook deliveries once their backoff has elapsed
+	webhookRetryInterval := time.Duration(cfg.Webhook.RetryIntervalSec) * time.Second
+	lifecycleManager.Go("webhook delivery", scheduler.NewWebhookDeliveryJob(dispatchWebhooksUseCase, webhookRetryInterval, appLogger).Run)
+
+	// Publish stored events to the message broker, retrying with backoff while it is unavailable
+	if messageBroker != nil {
+		relayOutboxUseCase := usecases.NewRelayOutboxUseCase(store.OutboxRepository, messageBroker, time.Duration(cfg.Broker.RetentionHours)*time.Hour)
+		relayInterval := time.Duration(cfg.Broker.RelayIntervalSeconds) * time.Second
+		lifecycleManager.Go("outbox relay", scheduler.NewOutboxRelayJob(relayOutboxUseCase, relayInterval, appLogger).Run)
+	}
+
+	// Store the latest rates of the configured currencies on a cron schedule so conversions rarely call the Treasury
+	if len(cfg.Prefetch.Currencies) > 0 {
+		schedule, err := scheduler.ParseCron(cfg.Prefetch.Schedule)
+		if err != nil {
+			log.Fatalf("Invalid RATE_PREFETCH_SCHEDULE: %v", err)
+		}
+
+		prefetchCurrencies := make([]entities.CurrencyCode, 0, len(cfg.Prefetch.Currencies))
+		for _, currency := range cfg.Prefetch.Currencies {
+			code, err := entities.NewCurrencyCode(currency)
+			if err != nil || !treasuryService.SupportsCurrency(code) {
+				log.Fatalf("Invalid RATE_PREFETCH_CURRENCIES entry %q: not a supported currency", currency)
+			}
+			prefetchCurrencies = append(prefetchCurrencies, code)
+		}
+
+		prefetchRatesUseCase := usecases.NewPrefetchRatesUseCase(exchangeRateRepo, treasuryService, prefetchCurrencies).
+			WithRateWindow(rateWindow)
+		lifecycleManager.Go("rate prefetch", scheduler.NewRatePrefetchJob(prefetchRatesUseCase, schedule, appLogger).Run)
+
+		appLogger.Info("Rate prefetch enabled",
+			"currencies", cfg.Prefetch.Currencies,
+			"schedule", schedule.String(),
+		)
+	}
 
 	// Get port from environment or use default
 	port := os.Getenv("PORT")
@@ -85,19 +606,80 @@ func main() {
 		port = cfg.Server.Port[1:] // Remove ':' from config
 	}
 
-	// Initialize and start server
-	server := http.NewServer(ginRouter, port)
+	// Initialize and start server; ops routes move to their own listener when ADMIN_ADDR is set
+	var server *http.Server
+	publicEndpoints := []string{
+		"GET  /",
+		"POST /api/v1/transactions",
+		"GET  /api/v1/transactions",
+		"GET  /api/v1/transactions/descriptions",
+		"GET  /api/v1/transactions/:id",
+		"POST /api/v1/transactions/:id/convert",
+		"POST /api/v1/transactions/:id/restore",
+		"GET  /api/v1/currencies/:code",
+		"POST /api/v1/convert",
+		"POST /api/v1/quotes",
+	}
+	adminEndpoints := []string{"GET  /health", "GET  /metrics"}
+	if tokenAuth != nil {
+		adminEndpoints = append(adminEndpoints,
+			"GET  /api/v1/admin/export",
+			"POST /api/v1/admin/import",
+			"GET  /api/v1/admin/database",
+			"POST /api/v1/admin/conversions",
+			"GET  /api/v1/admin/conversions/:id",
+			"GET  /api/v1/admin/conversions/:id/records",
+			"PUT  /api/v1/admin/loglevel",
+			"POST /api/v1/admin/tokens",
+		)
+	}
+
+	if cfg.Server.AdminAddr != "" {
+		if host, _, err := net.SplitHostPort(cfg.Server.AdminAddr); err != nil {
+			log.Fatalf("Invalid ADMIN_ADDR %q: %v", cfg.Server.AdminAddr, err)
+		} else if host == "" || host == "0.0.0.0" || host == "::" {
+			appLogger.Warn("Ops listener is bound to all interfaces", "admin_addr", cfg.Server.AdminAddr)
+		}
+
+		server = http.NewServer(router.SetupPublicRoutes(), port).WithOpsListener(router.SetupOpsRoutes(), cfg.Server.AdminAddr)
+		appLogger.Info("Ops listener configured",
+			"admin_addr", cfg.Server.AdminAddr,
+			"endpoints", append(adminEndpoints, "GET  /debug/pprof/"),
+		)
+	} else {
+		server = http.NewServer(router.SetupRoutes(), port)
+		publicEndpoints = append(publicEndpoints, adminEndpoints...)
+	}
+
+	server.WithReusePort(cfg.Server.ReusePort).
+		WithDrainTimeout(time.Duration(cfg.Server.DrainTimeoutSecs) * time.Second).
+		WithRequestTimeouts(requestTimeouts.Longest()).
+		WithLifecycle(lifecycleManager)
+
+	// Serve the transaction service over gRPC for internal consumers, with the same credentials as the REST API
+	if cfg.Server.GRPCAddr != "" {
+		grpcServer := grpc.NewServer(createTransactionUseCase, getTransactionUseCase, listTransactionsUseCase, convertTransactionUseCase).
+			WithAuditLog(auditLogUseCase).
+			WithInterceptors(grpc.LoggingInterceptor(appLogger), grpc.ErrorReportingInterceptor(errorReporter))
+		if tokenAuth != nil {
+			var bearer grpc.BearerAuthenticator
+			if jwtVerifier != nil {
+				bearer = jwtVerifier
+			}
+			grpcServer.WithInterceptors(grpc.AuthInterceptor(manageAPITokensUseCase, bearer))
+		}
+
+		server.WithGRPCListener(grpcServer, cfg.Server.GRPCAddr)
+		appLogger.Info("gRPC listener configured",
+			"grpc_addr", cfg.Server.GRPCAddr,
+			"service", grpc.ServiceName,
+		)
+	}
 
 	appLogger.Info("Purchase Transaction API starting",
 		"port", port,
-		"endpoints", []string{
-			"GET  /health",
-			"GET  /",
-			"POST /api/v1/transactions",
-			"GET  /api/v1/transactions",
-			"GET  /api/v1/transactions/:id",
-			"POST /api/v1/transactions/:id/convert",
-		},
+		"reuse_port", cfg.Server.ReusePort,
+		"endpoints", publicEndpoints,
 	)
 
 	if err := server.Start(); err != nil {
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// reloadLogLevelOnHangup applies the configured LOG_LEVEL to appLogger on every SIGHUP until ctx is done
+// The environment of a running process cannot change, so a new level has to come from the CONFIG_FILE
+// An invalid configuration is logged and leaves the level unchanged
+func reloadLogLevelOnHangup(ctx context.Context, appLogger *logger.Logger) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			cfg, err := config.Load()
+			if err != nil {
+				appLogger.LogError(err, "Ignoring SIGHUP: configuration is invalid")
+				continue
+			}
+			previous, err := appLogger.SetLevel(cfg.Logger.Level)
+			if err != nil {
+				appLogger.LogError(err, "Ignoring SIGHUP: log level is invalid")
+				continue
+			}
+			appLogger.Warn("Log level reloaded", "from", previous, "to", appLogger.Level())
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// CreateAPITokenRequest represents the input for issuing an API token
+type CreateAPITokenRequest struct {
+	Name      string           `json:"name" validate:"required,max=100"`
+	Role      entities.APIRole `json:"role" validate:"required,oneof=read write admin"`
+	ExpiresAt *time.Time       `json:"expires_at"` // Omit for a token that never expires
+}
+
+// RotateAPITokenRequest represents the input for replacing a token with a new secret
+type RotateAPITokenRequest struct {
+	ExpiresAt          *time.Time `json:"expires_at"`                                                // Expiry of the new token; defaults to the old token's
+	GracePeriodMinutes int        `json:"grace_period_minutes" validate:"omitempty,min=0,max=10080"` // Keep the old secret working this long; 0 revokes it at once
+}
+
+// APITokenResponse represents a stored API token; the secret is never included
+type APITokenResponse struct {
+	ID           uuid.UUID        `json:"id"`
+	Name         string           `json:"name"`
+	Prefix       string           `json:"prefix"`
+	Role         entities.APIRole `json:"role"`
+	Status       string           `json:"status"` // active, expired or revoked
+	ExpiresAt    *time.Time       `json:"expires_at,omitempty"`
+	RevokedAt    *time.Time       `json:"revoked_at,omitempty"`
+	LastUsedAt   *time.Time       `json:"last_used_at,omitempty"`
+	ReplacedByID *uuid.UUID       `json:"replaced_by_id,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+}
+
+// IssuedAPITokenResponse represents a newly created or rotated token together with its secret
+// The secret is only returned here and cannot be retrieved again
+type IssuedAPITokenResponse struct {
+	APITokenResponse
+	Token string `json:"token"`
+}
+
+// ListAPITokensResponse represents every API token
+type ListAPITokensResponse struct {
+	Data []APITokenResponse `json:"data"`
+}
+
+// NewAPITokenResponse converts an APIToken entity to APITokenResponse
+func NewAPITokenResponse(token *entities.APIToken, now time.Time) *APITokenResponse {
+	return &APITokenResponse{
+		ID:           token.ID,
+		Name:         token.Name,
+		Prefix:       token.Prefix,
+		Role:         token.Role,
+		Status:       token.Status(now),
+		ExpiresAt:    token.ExpiresAt,
+		RevokedAt:    token.RevokedAt,
+		LastUsedAt:   token.LastUsedAt,
+		ReplacedByID: token.ReplacedByID,
+		CreatedAt:    token.CreatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// ArchiveSchemaVersion is the version written to exported archives
+// Version 2 added the conversion history; version 1 archives, which have none, are still accepted on import
+const (
+	ArchiveSchemaVersion    = 2
+	MinArchiveSchemaVersion = 1
+)
+
+// Conflict strategies applied when an imported record already exists
+const (
+	ConflictSkip      = "skip"
+	ConflictOverwrite = "overwrite"
+	ConflictFail      = "fail"
+)
+
+// DatasetArchive is a versioned snapshot of the whole dataset used for environment cloning
+type DatasetArchive struct {
+	SchemaVersion int                     `json:"schema_version" validate:"required"`
+	ExportedAt    time.Time               `json:"exported_at"`
+	Transactions  []entities.Transaction  `json:"transactions"`
+	ExchangeRates []entities.ExchangeRate `json:"exchange_rates"`
+	Conversions   []entities.Conversion   `json:"conversions"`
+}
+
+// ExportSummary reports what an export wrote to the archive
+type ExportSummary struct {
+	SchemaVersion int
+	Transactions  int
+	ExchangeRates int
+	Conversions   int
+}
+
+// ImportArchiveRequest represents a request to import a dataset archive
+type ImportArchiveRequest struct {
+	Archive  *DatasetArchive `json:"archive" validate:"required"`
+	Strategy string          `json:"strategy" validate:"omitempty,oneof=skip overwrite fail"`
+}
+
+// ImportCounts reports what happened to the records of one kind during an import
+type ImportCounts struct {
+	Created     int `json:"created"`
+	Overwritten int `json:"overwritten"`
+	Skipped     int `json:"skipped"`
+}
+
+// ImportArchiveResponse summarizes the outcome of an archive import
+type ImportArchiveResponse struct {
+	SchemaVersion int          `json:"schema_version"`
+	Strategy      string       `json:"strategy"`
+	Transactions  ImportCounts `json:"transactions"`
+	ExchangeRates ImportCounts `json:"exchange_rates"`
+	Conversions   ImportCounts `json:"conversions"`
+}
@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// AuditEntry describes a change to record in the audit trail
+type AuditEntry struct {
+	EntityType string
+	EntityID   uuid.UUID
+	Action     string
+	Actor      string      // Who made the change; empty for anonymous callers
+	RequestID  string      // Request that made the change
+	Changes    interface{} // Values written, marshaled to JSON; nil when the action carries none
+}
+
+// ListAuditLogsRequest represents the filters and pagination of an audit trail listing
+type ListAuditLogsRequest struct {
+	EntityID *uuid.UUID
+	From     *time.Time // Recorded on or after this date
+	To       *time.Time // Recorded on or before this date, inclusive
+	Page     int
+	Size     int
+}
+
+// AuditLogResponse represents one audit trail entry
+type AuditLogResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uuid.UUID       `json:"entity_id"`
+	Action     string          `json:"action"`
+	Actor      string          `json:"actor"`
+	RequestID  string          `json:"request_id,omitempty"`
+	Changes    json.RawMessage `json:"changes,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// ListAuditLogsResponse represents a page of the audit trail, most recent first
+type ListAuditLogsResponse struct {
+	Data       []AuditLogResponse `json:"data"`
+	Page       int                `json:"page"`
+	Size       int                `json:"size"`
+	Total      int64              `json:"total"`
+	TotalPages int                `json:"total_pages"`
+}
+
+// NewListAuditLogsResponse creates a ListAuditLogsResponse with pagination metadata
+func NewListAuditLogsResponse(entries []entities.AuditLog, page, size int, total int64) *ListAuditLogsResponse {
+	data := make([]AuditLogResponse, len(entries))
+	for i, entry := range entries {
+		data[i] = AuditLogResponse{
+			ID:         entry.ID,
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Action:     entry.Action,
+			Actor:      entry.Actor,
+			RequestID:  entry.RequestID,
+			CreatedAt:  entry.CreatedAt,
+		}
+		if entry.Changes != "" {
+			data[i].Changes = json.RawMessage(entry.Changes)
+		}
+	}
+
+	totalPages := int((total + int64(size) - 1) / int64(size))
+
+	return &ListAuditLogsResponse{
+		Data:       data,
+		Page:       page,
+		Size:       size,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
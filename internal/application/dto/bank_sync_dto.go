@@ -0,0 +1,19 @@
+package dto
+
+// BankConnection is a connected bank login whose transactions are imported
+type BankConnection struct {
+	Name         string   // Label used in logs, e.g. "corporate-card"
+	AccessToken  string   // Aggregator access token for the login
+	AccountIDs   []string // Accounts to import; empty imports every account of the login
+	LookbackDays int      // How many days back each sync looks for new or late-posted transactions
+}
+
+// BankSyncResult summarizes one sync of a connection
+type BankSyncResult struct {
+	Connection string `json:"connection"`
+	Fetched    int    `json:"fetched"`
+	Created    int    `json:"created"`
+	Duplicates int    `json:"duplicates"` // Already imported, including transactions deleted since
+	Skipped    int    `json:"skipped"`    // Pending, credits, other accounts or non-USD
+	Failed     int    `json:"failed"`
+}
@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// batchDateLayout is the date format accepted for batch conversion ranges
+const batchDateLayout = "2006-01-02"
+
+// StartBatchConversionRequest represents the input for converting every transaction in a date range
+type StartBatchConversionRequest struct {
+	TargetCurrency entities.CurrencyCode `json:"target_currency" validate:"required,currency"`
+	From           time.Time             `json:"from" validate:"required"`
+	To             time.Time             `json:"to" validate:"required"` // Inclusive
+}
+
+// StartBatchConversionHTTPRequest represents the JSON body of POST /admin/conversions
+type StartBatchConversionHTTPRequest struct {
+	TargetCurrency string `json:"target_currency" binding:"required"`
+	From           string `json:"from" binding:"required"` // YYYY-MM-DD
+	To             string `json:"to" binding:"required"`   // YYYY-MM-DD, inclusive
+}
+
+// ToStartBatchConversionRequest parses the dates and normalizes the target currency
+func (req *StartBatchConversionHTTPRequest) ToStartBatchConversionRequest() (*StartBatchConversionRequest, error) {
+	targetCurrency, err := entities.NewCurrencyCode(req.TargetCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := time.Parse(batchDateLayout, req.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date %q: expected YYYY-MM-DD", req.From)
+	}
+	to, err := time.Parse(batchDateLayout, req.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date %q: expected YYYY-MM-DD", req.To)
+	}
+
+	return &StartBatchConversionRequest{
+		TargetCurrency: targetCurrency,
+		From:           from,
+		To:             to,
+	}, nil
+}
+
+// ConversionBatchResponse represents the status and progress of a batch conversion run
+type ConversionBatchResponse struct {
+	ID              uuid.UUID                      `json:"id"`
+	TargetCurrency  entities.CurrencyCode          `json:"target_currency"`
+	From            string                         `json:"from"`
+	To              string                         `json:"to"`
+	Status          entities.ConversionBatchStatus `json:"status"`
+	Total           int                            `json:"total"`
+	Processed       int                            `json:"processed"`
+	Succeeded       int                            `json:"succeeded"`
+	Failed          int                            `json:"failed"`
+	ProgressPercent float64                        `json:"progress_percent"`
+	Error           string                         `json:"error,omitempty"`
+	CreatedAt       time.Time                      `json:"created_at"`
+	StartedAt       *time.Time                     `json:"started_at,omitempty"`
+	CompletedAt     *time.Time                     `json:"completed_at,omitempty"`
+}
+
+// NewConversionBatchResponse creates a ConversionBatchResponse from a batch
+func NewConversionBatchResponse(batch *entities.ConversionBatch) *ConversionBatchResponse {
+	progress := 0.0
+	if batch.Total > 0 {
+		progress = float64(batch.Processed) * 100 / float64(batch.Total)
+	} else if batch.Status == entities.BatchStatusCompleted {
+		progress = 100
+	}
+
+	return &ConversionBatchResponse{
+		ID:              batch.ID,
+		TargetCurrency:  batch.TargetCurrency,
+		From:            batch.FromDate.Format(batchDateLayout),
+		To:              batch.ToDate.Format(batchDateLayout),
+		Status:          batch.Status,
+		Total:           batch.Total,
+		Processed:       batch.Processed,
+		Succeeded:       batch.Succeeded,
+		Failed:          batch.Failed,
+		ProgressPercent: progress,
+		Error:           batch.Error,
+		CreatedAt:       batch.CreatedAt,
+		StartedAt:       batch.StartedAt,
+		CompletedAt:     batch.CompletedAt,
+	}
+}
+
+// ConversionRecordResponse represents a persisted conversion record
+type ConversionRecordResponse struct {
+	ID              uuid.UUID             `json:"id"`
+	TransactionID   uuid.UUID             `json:"transaction_id"`
+	TransactionDate time.Time             `json:"transaction_date"`
+	OriginalAmount  float64               `json:"original_amount"`
+	TargetCurrency  entities.CurrencyCode `json:"target_currency"`
+	ExchangeRate    float64               `json:"exchange_rate"`
+	EffectiveDate   time.Time             `json:"effective_date"`
+	ConvertedAmount float64               `json:"converted_amount"`
+	CreatedAt       time.Time             `json:"created_at"`
+}
+
+// ListConversionRecordsResponse represents a page of conversion records
+type ListConversionRecordsResponse struct {
+	Data       []ConversionRecordResponse `json:"data"`
+	Page       int                        `json:"page"`
+	Size       int                        `json:"size"`
+	Total      int64                      `json:"total"`
+	TotalPages int                        `json:"total_pages"`
+}
+
+// NewListConversionRecordsResponse creates a ListConversionRecordsResponse with pagination metadata
+func NewListConversionRecordsResponse(records []entities.ConversionRecord, page, size int, total int64) *ListConversionRecordsResponse {
+	data := make([]ConversionRecordResponse, len(records))
+	for i, record := range records {
+		data[i] = ConversionRecordResponse{
+			ID:              record.ID,
+			TransactionID:   record.TransactionID,
+			TransactionDate: record.TransactionDate,
+			OriginalAmount:  record.OriginalAmount.Dollars(),
+			TargetCurrency:  record.TargetCurrency,
+			ExchangeRate:    record.ExchangeRate,
+			EffectiveDate:   record.EffectiveDate,
+			ConvertedAmount: record.ConvertedAmount.Dollars(),
+			CreatedAt:       record.CreatedAt,
+		}
+	}
+
+	totalPages := int((total + int64(size) - 1) / int64(size))
+
+	return &ListConversionRecordsResponse{
+		Data:       data,
+		Page:       page,
+		Size:       size,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// BudgetRequest represents the input for creating or replacing a budget
+type BudgetRequest struct {
+	Category   string                `json:"category" validate:"required,max=50"`
+	Period     entities.BudgetPeriod `json:"period" validate:"required,oneof=weekly monthly yearly"`
+	Limit      float64               `json:"limit" validate:"required,gt=0,money"`
+	Currency   entities.CurrencyCode `json:"currency" validate:"omitempty,currency"`                     // Defaults to USD
+	Thresholds []int                 `json:"thresholds" validate:"omitempty,max=10,dive,min=1,max=1000"` // Alert percentages; defaults to 80 and 100
+}
+
+// BudgetResponse represents a budget
+type BudgetResponse struct {
+	ID         uuid.UUID             `json:"id"`
+	Category   string                `json:"category"`
+	Period     entities.BudgetPeriod `json:"period"`
+	Limit      float64               `json:"limit"`
+	Currency   entities.CurrencyCode `json:"currency"`
+	Thresholds []int                 `json:"thresholds"`
+	CreatedAt  time.Time             `json:"created_at"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+}
+
+// ListBudgetsResponse represents every configured budget
+type ListBudgetsResponse struct {
+	Data []BudgetResponse `json:"data"`
+}
+
+// ToEntity converts BudgetRequest to a Budget entity with the given ID
+func (req *BudgetRequest) ToEntity(id uuid.UUID) *entities.Budget {
+	currency := req.Currency
+	if currency == "" {
+		currency = entities.USD
+	}
+
+	return &entities.Budget{
+		ID:         id,
+		Category:   req.Category,
+		Period:     req.Period,
+		Limit:      entities.NewMoney(req.Limit),
+		Currency:   currency,
+		Thresholds: entities.NormalizeThresholds(req.Thresholds),
+	}
+}
+
+// NewBudgetResponse converts Budget entity to BudgetResponse
+func NewBudgetResponse(budget *entities.Budget) *BudgetResponse {
+	return &BudgetResponse{
+		ID:         budget.ID,
+		Category:   budget.Category,
+		Period:     budget.Period,
+		Limit:      budget.Limit.Dollars(),
+		Currency:   budget.Currency,
+		Thresholds: budget.Thresholds,
+		CreatedAt:  budget.CreatedAt,
+		UpdatedAt:  budget.UpdatedAt,
+	}
+}
+
+// NewListBudgetsResponse converts budgets to a list response
+func NewListBudgetsResponse(budgets []entities.Budget) *ListBudgetsResponse {
+	data := make([]BudgetResponse, len(budgets))
+	for i := range budgets {
+		data[i] = *NewBudgetResponse(&budgets[i])
+	}
+	return &ListBudgetsResponse{Data: data}
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// CategoryRequest represents the input for creating or replacing a category
+type CategoryRequest struct {
+	Name        string `json:"name" validate:"required,max=50"`
+	Description string `json:"description" validate:"max=255"`
+}
+
+// CategoryResponse represents a category
+type CategoryResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ListCategoriesResponse represents every category
+type ListCategoriesResponse struct {
+	Data []CategoryResponse `json:"data"`
+}
+
+// ToEntity converts CategoryRequest to a Category entity with the given ID
+func (req *CategoryRequest) ToEntity(id uuid.UUID) *entities.Category {
+	return &entities.Category{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+// NewCategoryResponse converts Category entity to CategoryResponse
+func NewCategoryResponse(category *entities.Category) *CategoryResponse {
+	return &CategoryResponse{
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
+
+// NewListCategoriesResponse converts categories to a list response
+func NewListCategoriesResponse(categories []entities.Category) *ListCategoriesResponse {
+	data := make([]CategoryResponse, len(categories))
+	for i := range categories {
+		data[i] = *NewCategoryResponse(&categories[i])
+	}
+	return &ListCategoriesResponse{Data: data}
+}
@@ -0,0 +1,196 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// ConvertAmountRequest represents the input for converting an arbitrary USD amount
+type ConvertAmountRequest struct {
+	Amount         float64               `json:"amount" validate:"required,gt=0,money"`
+	TargetCurrency entities.CurrencyCode `json:"target_currency" validate:"required,currency"`
+	Date           time.Time             `json:"date" validate:"required"`
+	QuoteID        *uuid.UUID            `json:"quote_id"`
+	APIKey         string                `json:"-"` // Caller's API key, selects the margin
+}
+
+// ConvertAmountHTTPRequest represents the JSON body of POST /convert
+type ConvertAmountHTTPRequest struct {
+	Amount         float64   `json:"amount" binding:"required,gt=0"`
+	TargetCurrency string    `json:"target_currency" binding:"required,currency"`
+	Date           time.Time `json:"date" binding:"required"`
+	QuoteID        string    `json:"quote_id"`
+}
+
+// ConvertAmountResponse represents the result of a standalone amount conversion
+type ConvertAmountResponse struct {
+	Amount          float64               `json:"amount"`
+	Date            time.Time             `json:"date"`
+	TargetCurrency  entities.CurrencyCode `json:"target_currency"`
+	RawExchangeRate float64               `json:"raw_exchange_rate"`
+	MarginBps       int                   `json:"margin_bps"`
+	ExchangeRate    float64               `json:"exchange_rate"` // Final rate after margin
+	ConvertedAmount float64               `json:"converted_amount"`
+	EffectiveDate   time.Time             `json:"effective_date"`
+	QuoteID         *uuid.UUID            `json:"quote_id,omitempty"`
+}
+
+// GetExchangeRateRequest represents the input for previewing the rate a conversion would use
+type GetExchangeRateRequest struct {
+	From   entities.CurrencyCode `validate:"required,currency"`
+	To     entities.CurrencyCode `validate:"required,currency"`
+	Date   time.Time             `validate:"required"`
+	APIKey string                // Caller's API key, selects the margin
+}
+
+// ExchangeRateResponse represents the rate a conversion on Date would use
+type ExchangeRateResponse struct {
+	From            entities.CurrencyCode `json:"from"`
+	To              entities.CurrencyCode `json:"to"`
+	Date            time.Time             `json:"date"`
+	RawExchangeRate float64               `json:"raw_exchange_rate"`
+	MarginBps       int                   `json:"margin_bps"`
+	ExchangeRate    float64               `json:"exchange_rate"` // Final rate after margin
+	EffectiveDate   time.Time             `json:"effective_date"`
+}
+
+// CreateQuoteRequest represents the input for locking a rate quote
+type CreateQuoteRequest struct {
+	TargetCurrency entities.CurrencyCode `json:"target_currency" validate:"required,currency"`
+	Date           time.Time             `json:"date"` // Defaults to now
+}
+
+// CreateQuoteHTTPRequest represents the JSON body of POST /quotes
+type CreateQuoteHTTPRequest struct {
+	TargetCurrency string    `json:"target_currency" binding:"required,currency"`
+	Date           time.Time `json:"date"`
+}
+
+// QuoteResponse represents a locked rate quote returned to clients
+type QuoteResponse struct {
+	QuoteID        uuid.UUID             `json:"quote_id"`
+	TargetCurrency entities.CurrencyCode `json:"target_currency"`
+	ExchangeRate   float64               `json:"exchange_rate"`
+	EffectiveDate  time.Time             `json:"effective_date"`
+	Date           time.Time             `json:"date"`
+	ExpiresAt      time.Time             `json:"expires_at"`
+}
+
+// ErrInvalidQuoteID is returned when a quote_id is not a valid UUID
+var ErrInvalidQuoteID = errors.New("invalid quote_id: must be a valid UUID")
+
+// ParseQuoteID parses an optional quote ID, returning nil when it is empty
+func ParseQuoteID(raw string) (*uuid.UUID, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	quoteID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, ErrInvalidQuoteID
+	}
+
+	return &quoteID, nil
+}
+
+// NewQuoteResponse creates a QuoteResponse from a rate quote
+func NewQuoteResponse(quote *entities.RateQuote) *QuoteResponse {
+	return &QuoteResponse{
+		QuoteID:        quote.ID,
+		TargetCurrency: quote.ToCurrency,
+		ExchangeRate:   quote.Rate,
+		EffectiveDate:  quote.EffectiveDate,
+		Date:           quote.QuotedDate,
+		ExpiresAt:      quote.ExpiresAt,
+	}
+}
+
+// ToCreateQuoteRequest normalizes the target currency and builds the use case request
+func (req *CreateQuoteHTTPRequest) ToCreateQuoteRequest() (*CreateQuoteRequest, error) {
+	targetCurrency, err := entities.NewCurrencyCode(req.TargetCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateQuoteRequest{
+		TargetCurrency: targetCurrency,
+		Date:           req.Date,
+	}, nil
+}
+
+// ToConvertAmountRequest normalizes the target currency and builds the use case request
+func (req *ConvertAmountHTTPRequest) ToConvertAmountRequest() (*ConvertAmountRequest, error) {
+	quoteID, err := ParseQuoteID(req.QuoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetCurrency, err := entities.NewCurrencyCode(req.TargetCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConvertAmountRequest{
+		Amount:         req.Amount,
+		TargetCurrency: targetCurrency,
+		Date:           req.Date,
+		QuoteID:        quoteID,
+	}, nil
+}
+
+// ConversionResponse represents one conversion in a transaction's history
+type ConversionResponse struct {
+	ID              uuid.UUID             `json:"id"`
+	TargetCurrency  entities.CurrencyCode `json:"target_currency"`
+	RawExchangeRate float64               `json:"raw_exchange_rate"`
+	MarginBps       int                   `json:"margin_bps"`
+	ExchangeRate    float64               `json:"exchange_rate"` // Final rate after margin
+	EffectiveDate   time.Time             `json:"effective_date"`
+	ConvertedAmount float64               `json:"converted_amount"`
+	QuoteID         *uuid.UUID            `json:"quote_id,omitempty"`
+	ConvertedAt     time.Time             `json:"converted_at"`
+}
+
+// ListConversionsResponse represents a page of a transaction's conversion history
+type ListConversionsResponse struct {
+	TransactionID uuid.UUID            `json:"transaction_id"`
+	Data          []ConversionResponse `json:"data"`
+	Page          int                  `json:"page"`
+	Size          int                  `json:"size"`
+	Total         int64                `json:"total"`
+	TotalPages    int                  `json:"total_pages"`
+}
+
+// NewListConversionsResponse creates a ListConversionsResponse with pagination metadata
+func NewListConversionsResponse(transactionID uuid.UUID, conversions []entities.Conversion, page, size int, total int64) *ListConversionsResponse {
+	data := make([]ConversionResponse, len(conversions))
+	for i, conversion := range conversions {
+		data[i] = ConversionResponse{
+			ID:              conversion.ID,
+			TargetCurrency:  conversion.TargetCurrency,
+			RawExchangeRate: conversion.RawExchangeRate,
+			MarginBps:       conversion.MarginBps,
+			ExchangeRate:    conversion.ExchangeRate,
+			EffectiveDate:   conversion.EffectiveDate,
+			ConvertedAmount: conversion.ConvertedAmount.Dollars(),
+			QuoteID:         conversion.QuoteID,
+			ConvertedAt:     conversion.CreatedAt,
+		}
+	}
+
+	totalPages := int((total + int64(size) - 1) / int64(size))
+
+	return &ListConversionsResponse{
+		TransactionID: transactionID,
+		Data:          data,
+		Page:          page,
+		Size:          size,
+		Total:         total,
+		TotalPages:    totalPages,
+	}
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// CurrencyResponse represents currency metadata returned to clients
+type CurrencyResponse struct {
+	XMLName             xml.Name              `json:"-" xml:"currency"`
+	Code                entities.CurrencyCode `json:"code" xml:"code"`
+	Name                string                `json:"name" xml:"name"`
+	Symbol              string                `json:"symbol" xml:"symbol"`
+	MinorUnits          int                   `json:"minor_units" xml:"minor_units"`
+	ConversionSupported bool                  `json:"conversion_supported" xml:"conversion_supported"`
+	Provider            string                `json:"provider,omitempty" xml:"provider,omitempty"`
+}
+
+// NewCurrencyResponse creates a CurrencyResponse from currency metadata and provider coverage
+func NewCurrencyResponse(info entities.CurrencyInfo, provider string) *CurrencyResponse {
+	return &CurrencyResponse{
+		Code:                info.Code,
+		Name:                info.Name,
+		Symbol:              info.Symbol,
+		MinorUnits:          info.MinorUnits,
+		ConversionSupported: provider != "",
+		Provider:            provider,
+	}
+}
+
+// CSV renders the currency as a header and a single row
+func (r *CurrencyResponse) CSV() [][]string {
+	return [][]string{
+		{"code", "name", "symbol", "minor_units", "conversion_supported", "provider"},
+		{string(r.Code), r.Name, r.Symbol, strconv.Itoa(r.MinorUnits), strconv.FormatBool(r.ConversionSupported), r.Provider},
+	}
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// Database soft quota states
+const (
+	QuotaDisabled = "disabled" // No quota configured
+	QuotaOK       = "ok"
+	QuotaWarning  = "warning"  // Usage reached the warning threshold
+	QuotaExceeded = "exceeded" // Usage reached the quota
+)
+
+// DatabaseStats is a point-in-time measurement of the database
+type DatabaseStats struct {
+	SizeBytes int64
+	RowCounts map[string]int64 // Table name -> rows, including soft-deleted ones
+}
+
+// ConnectionPoolStats is a point-in-time view of a database connection pool
+type ConnectionPoolStats struct {
+	Pool              string  `json:"pool"`     // primary, or replica-1, replica-2, ...
+	MaxOpen           int     `json:"max_open"` // Zero is unlimited
+	Open              int     `json:"open"`     // In use plus idle
+	InUse             int     `json:"in_use"`
+	Idle              int     `json:"idle"`
+	WaitCount         int64   `json:"wait_count"`   // Queries that waited for a free connection since startup
+	WaitSeconds       float64 `json:"wait_seconds"` // Total time spent waiting since startup
+	MaxIdleClosed     int64   `json:"max_idle_closed"`
+	MaxIdleTimeClosed int64   `json:"max_idle_time_closed"`
+	MaxLifetimeClosed int64   `json:"max_lifetime_closed"`
+}
+
+// DatabaseUsageResponse reports database growth against the configured soft quota
+type DatabaseUsageResponse struct {
+	SizeBytes      int64            `json:"size_bytes"`
+	RowCounts      map[string]int64 `json:"row_counts"`
+	QuotaBytes     int64            `json:"quota_bytes,omitempty"`
+	UsagePercent   float64          `json:"usage_percent,omitempty"`
+	QuotaStatus    string           `json:"quota_status"`
+	ImportsBlocked bool             `json:"imports_blocked"`
+	CheckedAt      time.Time        `json:"checked_at"`
+
+	Pools []ConnectionPoolStats `json:"pools,omitempty"` // Empty for the memory driver
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// Digest periods supported by the scheduled report
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// DigestReport summarizes activity over a reporting period for the email digest
+type DigestReport struct {
+	Period           string    `json:"period"`
+	From             time.Time `json:"from"`
+	To               time.Time `json:"to"`
+	NewTransactions  int64     `json:"new_transactions"`
+	TotalSpend       float64   `json:"total_spend"`
+	Conversions      int64     `json:"conversions"`
+	TreasuryFailures int64     `json:"treasury_failures"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// EventMessage is the JSON form of a domain event, posted to webhooks and published to the message broker
+type EventMessage struct {
+	ID         uuid.UUID       `json:"id"` // The event ID; the same on every delivery, so consumers can drop duplicates
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// TransactionDeletedData is the data of a transaction.deleted message
+type TransactionDeletedData struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// NewEventMessage encodes a domain event with the API representation of its data:
+// the transaction for transaction.created and transaction.updated, its ID for transaction.deleted
+// and the conversion response for transaction.converted
+func NewEventMessage(event entities.DomainEvent) (*EventMessage, error) {
+	var data interface{}
+	switch e := event.(type) {
+	case entities.TransactionCreatedEvent:
+		data = NewGetTransactionResponse(&e.Transaction)
+	case entities.TransactionUpdatedEvent:
+		data = NewGetTransactionResponse(&e.Transaction)
+	case entities.TransactionDeletedEvent:
+		data = TransactionDeletedData{ID: e.TransactionID}
+	case entities.TransactionConvertedEvent:
+		data = NewConvertTransactionResponseFromEvent(e)
+	default:
+		return nil, fmt.Errorf("unsupported event %s", event.EventName())
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event data: %w", event.EventName(), err)
+	}
+
+	return &EventMessage{
+		ID:         event.EventID(),
+		Event:      event.EventName(),
+		OccurredAt: event.EventTime(),
+		Data:       encoded,
+	}, nil
+}
+
+// OutboxRelayResult summarizes one run of the outbox relay
+type OutboxRelayResult struct {
+	Published int   `json:"published"`
+	Failed    int   `json:"failed"`  // A run stops at the first message the broker does not accept
+	Pending   int64 `json:"pending"` // Messages left waiting, counted when a run fails
+	Purged    int64 `json:"purged"`  // Published messages removed after the retention period
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// Health statuses for the service and each dependency
+const (
+	HealthStatusHealthy   = "healthy"
+	HealthStatusUnhealthy = "unhealthy"
+	DependencyStatusUp    = "up"
+	DependencyStatusDown  = "down"
+)
+
+// HealthResponse represents the service health report
+type HealthResponse struct {
+	Status        string                      `json:"status"`
+	Service       string                      `json:"service"`
+	Version       string                      `json:"version"`
+	Timestamp     time.Time                   `json:"timestamp"`
+	UptimeSeconds int64                       `json:"uptime_seconds"`
+	Dependencies  map[string]DependencyHealth `json:"dependencies"`
+
+	CircuitBreakers map[string]CircuitBreakerHealth `json:"circuit_breakers,omitempty"`
+}
+
+// DependencyHealth reports whether a dependency answered and how long it took
+type DependencyHealth struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// CircuitBreakerHealth reports the state of a circuit breaker guarding an external service
+// An open breaker does not make the service unhealthy; only calls through it fail fast
+type CircuitBreakerHealth struct {
+	State               string     `json:"state"` // closed, open or half_open
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"` // When an open breaker lets a trial call through
+}
@@ -0,0 +1,206 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// JSONAPIDocument is a JSON:API top-level document (https://jsonapi.org/format/1.1/)
+type JSONAPIDocument struct {
+	Data   any               `json:"data,omitempty"`
+	Errors []JSONAPIError    `json:"errors,omitempty"`
+	Meta   map[string]any    `json:"meta,omitempty"`
+	Links  map[string]string `json:"links,omitempty"`
+}
+
+// JSONAPIResource is a resource object
+type JSONAPIResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    any                            `json:"attributes"`
+	Relationships map[string]JSONAPIRelationship `json:"relationships,omitempty"`
+	Links         map[string]string              `json:"links,omitempty"`
+}
+
+// JSONAPIRelationship links a resource to a single related resource
+type JSONAPIRelationship struct {
+	Data  JSONAPIResourceIdentifier `json:"data"`
+	Links map[string]string         `json:"links,omitempty"`
+}
+
+// JSONAPIResourceIdentifier identifies a resource without its attributes
+type JSONAPIResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// JSONAPIError is an error object
+type JSONAPIError struct {
+	Status string              `json:"status"`
+	Title  string              `json:"title"`
+	Detail string              `json:"detail,omitempty"`
+	Source *JSONAPIErrorSource `json:"source,omitempty"`
+}
+
+// JSONAPIErrorSource points at the query parameter that caused an error
+type JSONAPIErrorSource struct {
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// transactionAttributes are the attributes of a "transactions" resource
+type transactionAttributes struct {
+	Description     string                `json:"description"`
+	Date            time.Time             `json:"date"`
+	Amount          float64               `json:"amount"`
+	SourceCurrency  entities.CurrencyCode `json:"source_currency"` // Named apart from the currency relationship of converted resources
+	Category        string                `json:"category,omitempty"`
+	Tags            []string              `json:"tags,omitempty"`
+	ConvertedAmount *float64              `json:"converted_amount,omitempty"`
+	ExchangeRate    *float64              `json:"exchange_rate,omitempty"`
+	EffectiveDate   *time.Time            `json:"effective_date,omitempty"`
+	ConversionError string                `json:"conversion_error,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+	Version         int64                 `json:"version,omitempty"`
+	ArchivedAt      *time.Time            `json:"archived_at,omitempty"`
+	DeletedAt       *time.Time            `json:"deleted_at,omitempty"`
+}
+
+// JSONAPI represents the transaction as a single "transactions" resource
+func (r *GetTransactionResponse) JSONAPI() JSONAPIDocument {
+	return JSONAPIDocument{Data: r.jsonAPIResource(r.jsonAPIAttributes())}
+}
+
+func (r *GetTransactionResponse) jsonAPIAttributes() transactionAttributes {
+	return transactionAttributes{
+		Description:    r.Description,
+		Date:           r.Date,
+		Amount:         r.Amount,
+		SourceCurrency: r.Currency,
+		Category:       r.Category,
+		Tags:           r.Tags,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+		Version:        r.Version,
+		ArchivedAt:     r.ArchivedAt,
+		DeletedAt:      r.DeletedAt,
+	}
+}
+
+func (r *GetTransactionResponse) jsonAPIResource(attributes transactionAttributes) JSONAPIResource {
+	return JSONAPIResource{
+		Type:       "transactions",
+		ID:         r.ID.String(),
+		Attributes: attributes,
+		Links:      map[string]string{"self": "/api/v1/transactions/" + r.ID.String()},
+	}
+}
+
+// JSONAPI represents the converted transaction as a "transactions" resource related to the target currency
+func (r *GetConvertedTransactionResponse) JSONAPI() JSONAPIDocument {
+	attributes := r.jsonAPIAttributes()
+	attributes.ConvertedAmount = r.ConvertedAmount
+	attributes.ExchangeRate = r.ExchangeRate
+	attributes.EffectiveDate = r.EffectiveDate
+
+	resource := r.jsonAPIResource(attributes)
+	resource.Relationships = map[string]JSONAPIRelationship{
+		"currency": currencyRelationship(r.Currency),
+	}
+	return JSONAPIDocument{Data: resource, Meta: map[string]any{"margin_bps": r.MarginBps}}
+}
+
+// JSONAPI represents the page as a collection of "transactions" resources
+// Converted items relate to the target currency; pagination links are added by the HTTP layer
+func (r *ListTransactionsResponse) JSONAPI() JSONAPIDocument {
+	resources := make([]JSONAPIResource, len(r.Data))
+	for i, item := range r.Data {
+		attributes := item.jsonAPIAttributes()
+		attributes.ConvertedAmount = item.ConvertedAmount
+		attributes.ExchangeRate = item.ExchangeRate
+		attributes.EffectiveDate = item.EffectiveDate
+		attributes.ConversionError = item.ConversionError
+
+		resource := item.jsonAPIResource(attributes)
+
+		if r.Currency != "" {
+			resource.Relationships = map[string]JSONAPIRelationship{
+				"currency": currencyRelationship(r.Currency),
+			}
+		}
+		resources[i] = resource
+	}
+
+	meta := map[string]any{"size": r.Size}
+	if !r.IsCursorPage() {
+		meta["page"] = r.Page
+		meta["total"] = r.Total
+		meta["total_pages"] = r.TotalPages
+	}
+	if r.NextCursor != "" {
+		meta["next_cursor"] = r.NextCursor
+	}
+	if r.Currency != "" {
+		meta["currency"] = r.Currency
+	}
+
+	return JSONAPIDocument{Data: resources, Meta: meta}
+}
+
+// Pagination reports the current page and the number of pages for pagination links
+func (r *ListTransactionsResponse) Pagination() (page, totalPages int) {
+	return r.Page, r.TotalPages
+}
+
+// CursorPagination reports the cursor of the next page, and whether this page was itself continued from a cursor
+func (r *ListTransactionsResponse) CursorPagination() (nextCursor string, isCursorPage bool) {
+	return r.NextCursor, r.IsCursorPage()
+}
+
+// JSONAPI represents the page like a cursor page of the v1 list
+func (r *TransactionPageResponse) JSONAPI() JSONAPIDocument {
+	return r.list().JSONAPI()
+}
+
+// CursorPagination reports the cursor of the next page; every page is a cursor page
+func (r *TransactionPageResponse) CursorPagination() (nextCursor string, isCursorPage bool) {
+	return r.NextCursor, true
+}
+
+// JSONAPI represents the suggestions as "description-suggestions" resources identified by description
+func (r *SuggestDescriptionsResponse) JSONAPI() JSONAPIDocument {
+	resources := make([]JSONAPIResource, len(r.Data))
+	for i, suggestion := range r.Data {
+		resources[i] = JSONAPIResource{
+			Type:       "description-suggestions",
+			ID:         suggestion.Description,
+			Attributes: map[string]any{"count": suggestion.Count},
+		}
+	}
+	return JSONAPIDocument{Data: resources, Meta: map[string]any{"prefix": r.Prefix}}
+}
+
+// JSONAPI represents the currency as a "currencies" resource identified by its code
+func (r *CurrencyResponse) JSONAPI() JSONAPIDocument {
+	return JSONAPIDocument{Data: JSONAPIResource{
+		Type: "currencies",
+		ID:   string(r.Code),
+		Attributes: map[string]any{
+			"name":                 r.Name,
+			"symbol":               r.Symbol,
+			"minor_units":          r.MinorUnits,
+			"conversion_supported": r.ConversionSupported,
+			"provider":             r.Provider,
+		},
+		Links: map[string]string{"self": "/api/v1/currencies/" + string(r.Code)},
+	}}
+}
+
+// currencyRelationship relates a resource to a "currencies" resource
+func currencyRelationship(code entities.CurrencyCode) JSONAPIRelationship {
+	return JSONAPIRelationship{
+		Data:  JSONAPIResourceIdentifier{Type: "currencies", ID: string(code)},
+		Links: map[string]string{"related": "/api/v1/currencies/" + string(code)},
+	}
+}
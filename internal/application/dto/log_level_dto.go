@@ -0,0 +1,12 @@
+package dto
+
+// LogLevelRequest represents a request to change the log level of the running server
+type LogLevelRequest struct {
+	Level string `json:"level" binding:"required"` // DEBUG, INFO, WARN or ERROR, ignoring case
+}
+
+// LogLevelResponse reports the log level in effect
+type LogLevelResponse struct {
+	Level    string `json:"level"`
+	Previous string `json:"previous,omitempty"` // Level replaced by a change
+}
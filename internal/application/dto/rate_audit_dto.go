@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// Rate audit outcomes for a sampled conversion record
+const (
+	AuditMatched     = "matched"
+	AuditMismatched  = "mismatched"
+	AuditUnavailable = "unavailable" // The authoritative source could not be queried for the record
+)
+
+// RateAuditEntry compares one stored conversion record with the authoritative rate
+type RateAuditEntry struct {
+	RecordID                     uuid.UUID             `json:"record_id"`
+	TransactionID                uuid.UUID             `json:"transaction_id"`
+	TargetCurrency               entities.CurrencyCode `json:"target_currency"`
+	TransactionDate              string                `json:"transaction_date"`
+	Outcome                      string                `json:"outcome"`
+	StoredRate                   float64               `json:"stored_rate"`
+	StoredEffectiveDate          string                `json:"stored_effective_date"`
+	StoredConvertedAmount        float64               `json:"stored_converted_amount"`
+	AuthoritativeRate            float64               `json:"authoritative_rate,omitempty"`
+	AuthoritativeEffectiveDate   string                `json:"authoritative_effective_date,omitempty"`
+	AuthoritativeConvertedAmount float64               `json:"authoritative_converted_amount,omitempty"`
+	Error                        string                `json:"error,omitempty"`
+}
+
+// RateAuditReport is the reconciliation report produced by a rate-accuracy audit
+type RateAuditReport struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	Provider    string           `json:"provider"`
+	Sampled     int              `json:"sampled"`
+	Matched     int              `json:"matched"`
+	Mismatched  int              `json:"mismatched"`
+	Unavailable int              `json:"unavailable"`
+	Entries     []RateAuditEntry `json:"entries"` // Mismatched and unavailable records only
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// RateCacheStats counts cached rates and the local lookups that hit or missed them
+type RateCacheStats struct {
+	CachedRates int64   `json:"cached_rates"`
+	Hits        int64   `json:"hits"`
+	Misses      int64   `json:"misses"`
+	HitRatio    float64 `json:"hit_ratio"` // Hits over all lookups; 0 when there were none
+}
+
+// CurrencyRateCache reports the cached rates and lookups of one currency
+type CurrencyRateCache struct {
+	Currency            entities.CurrencyCode `json:"currency"`
+	OldestEffectiveDate *time.Time            `json:"oldest_effective_date,omitempty"`
+	NewestEffectiveDate *time.Time            `json:"newest_effective_date,omitempty"`
+	RateCacheStats
+}
+
+// RateCacheResponse represents the state of the local exchange rate cache
+// Hits and misses are counted per instance since StatsSince
+type RateCacheResponse struct {
+	StatsSince time.Time           `json:"stats_since"`
+	Totals     RateCacheStats      `json:"totals"`
+	Currencies []CurrencyRateCache `json:"currencies"`
+}
+
+// EvictRateCacheRequest selects the cached rates to evict
+type EvictRateCacheRequest struct {
+	Currency      string // Empty evicts every currency, which requires All
+	EffectiveDate string // Optional YYYY-MM-DD; requires Currency
+	All           bool
+}
+
+// EvictRateCacheResponse reports how many cached rates were evicted
+type EvictRateCacheResponse struct {
+	Currency      entities.CurrencyCode `json:"currency,omitempty"`
+	EffectiveDate string                `json:"effective_date,omitempty"`
+	Evicted       int64                 `json:"evicted"`
+}
+
+// NewRateCacheStats computes the hit ratio of the counts
+func NewRateCacheStats(cachedRates, hits, misses int64) RateCacheStats {
+	stats := RateCacheStats{CachedRates: cachedRates, Hits: hits, Misses: misses}
+	if lookups := hits + misses; lookups > 0 {
+		stats.HitRatio = float64(hits) / float64(lookups)
+	}
+	return stats
+}
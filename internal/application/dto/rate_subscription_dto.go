@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// SubscribeRatesRequest represents the currencies a client wants kept fresh
+type SubscribeRatesRequest struct {
+	Currencies []string `json:"currencies" binding:"required,min=1,max=50,dive,required"`
+}
+
+// RateSubscriptionResponse reports a subscription and the freshness of the newest cached rate
+type RateSubscriptionResponse struct {
+	Currency      entities.CurrencyCode `json:"currency"`
+	Status        string                `json:"status"` // fresh, stale or missing
+	ExchangeRate  *float64              `json:"exchange_rate,omitempty"`
+	EffectiveDate *time.Time            `json:"effective_date,omitempty"`
+	AgeDays       *int                  `json:"age_days,omitempty"`
+	ValidUntil    *time.Time            `json:"valid_until,omitempty"` // Last purchase date the cached rate can convert
+	LastSyncedAt  *time.Time            `json:"last_synced_at,omitempty"`
+	LastError     string                `json:"last_error,omitempty"`
+	SubscribedAt  time.Time             `json:"subscribed_at"`
+}
+
+// ListRateSubscriptionsResponse represents a client's subscriptions
+type ListRateSubscriptionsResponse struct {
+	Data         []RateSubscriptionResponse `json:"data"`
+	FreshForDays int                        `json:"fresh_for_days"`
+}
+
+// NewRateSubscriptionResponse combines a subscription with the newest cached rate usable at now within window
+func NewRateSubscriptionResponse(subscription entities.RateSubscription, latest *entities.ExchangeRate, now time.Time, freshFor time.Duration, window entities.RateWindow) RateSubscriptionResponse {
+	response := RateSubscriptionResponse{
+		Currency:     subscription.Currency,
+		Status:       entities.RateFreshness(latest, now, freshFor, window),
+		LastSyncedAt: subscription.LastSyncedAt,
+		LastError:    subscription.LastError,
+		SubscribedAt: subscription.CreatedAt,
+	}
+
+	if response.Status != entities.RateMissing {
+		rate := latest.Rate
+		effectiveDate := latest.EffectiveDate
+		ageDays := int(now.Sub(latest.EffectiveDate).Hours() / 24)
+		validUntil := window.End(latest.EffectiveDate)
+
+		response.ExchangeRate = &rate
+		response.EffectiveDate = &effectiveDate
+		response.AgeDays = &ageDays
+		response.ValidUntil = &validUntil
+	}
+
+	return response
+}
+
+// RateSyncResult summarizes one run of the subscribed rate sync
+type RateSyncResult struct {
+	Subscribed int `json:"subscribed"` // Distinct subscribed currencies
+	Checked    int `json:"checked"`    // Currencies fetched from the provider this run
+	Refreshed  int `json:"refreshed"`  // Checked currencies for which a newer rate was stored
+	Fresh      int `json:"fresh"`      // Skipped because the cached rate is still fresh
+	Deferred   int `json:"deferred"`   // Left for the next run by the per-run limit
+	Failed     int `json:"failed"`
+}
+
+// RatePrefetchResult summarizes one run of the scheduled rate prefetch
+type RatePrefetchResult struct {
+	Currencies int               `json:"currencies"` // Configured currencies
+	Stored     int               `json:"stored"`     // Currencies for which a newer rate was stored
+	UpToDate   int               `json:"up_to_date"` // Currencies whose newest rate was already stored
+	Failed     int               `json:"failed"`
+	Errors     map[string]string `json:"errors,omitempty"` // Currency -> error of the failed fetches
+}
+
+// RateBackfillResult summarizes one backfill of provider rates over a date range
+type RateBackfillResult struct {
+	Currencies int               `json:"currencies"` // Currencies backfilled
+	Dates      int               `json:"dates"`      // Dates looked up per currency
+	Stored     int               `json:"stored"`     // Rates fetched and stored
+	Cached     int               `json:"cached"`     // Lookups already answered by a stored rate
+	Failed     int               `json:"failed"`
+	Errors     map[string]string `json:"errors,omitempty"` // "<currency> <date>" -> error of the failed lookups
+}
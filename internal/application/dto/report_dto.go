@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// SummaryReportRequest represents the input for a spending summary
+// Dates are purchase dates and both bounds are inclusive; unset fields take the use case defaults
+type SummaryReportRequest struct {
+	From     *time.Time               `json:"from"`
+	To       *time.Time               `json:"to"`
+	GroupBy  entities.SummaryGrouping `json:"group_by" validate:"omitempty,oneof=day month year"`
+	Currency entities.CurrencyCode    `json:"currency" validate:"omitempty,currency"` // Optional conversion target; amounts are USD otherwise
+}
+
+// PeriodSummaryResponse represents the aggregated spend of one period, in dollars
+type PeriodSummaryResponse struct {
+	Period  string  `json:"period,omitempty"`
+	Count   int64   `json:"count"`
+	Total   float64 `json:"total"`
+	Average float64 `json:"average"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+}
+
+// SummaryReportResponse represents a spending summary broken down by period
+// ExchangeRate and EffectiveDate are set when the amounts were converted from USD
+type SummaryReportResponse struct {
+	From          string                   `json:"from"`
+	To            string                   `json:"to"`
+	GroupBy       entities.SummaryGrouping `json:"group_by"`
+	Currency      entities.CurrencyCode    `json:"currency"`
+	ExchangeRate  *float64                 `json:"exchange_rate,omitempty"`
+	EffectiveDate *time.Time               `json:"effective_date,omitempty"`
+	Totals        PeriodSummaryResponse    `json:"totals"`
+	Periods       []PeriodSummaryResponse  `json:"periods"`
+}
+
+// NewPeriodSummaryResponse converts a PeriodSummary to its dollar representation
+func NewPeriodSummaryResponse(summary entities.PeriodSummary) PeriodSummaryResponse {
+	return PeriodSummaryResponse{
+		Period:  summary.Period,
+		Count:   summary.Count,
+		Total:   summary.Total.Dollars(),
+		Average: summary.Average.Dollars(),
+		Min:     summary.Min.Dollars(),
+		Max:     summary.Max.Dollars(),
+	}
+}
+
+// NewSummaryReportResponse builds a USD summary report for the inclusive range [from, to]
+func NewSummaryReportResponse(from, to time.Time, groupBy entities.SummaryGrouping, summaries []entities.PeriodSummary) *SummaryReportResponse {
+	periods := make([]PeriodSummaryResponse, len(summaries))
+	for i, summary := range summaries {
+		periods[i] = NewPeriodSummaryResponse(summary)
+	}
+
+	return &SummaryReportResponse{
+		From:     from.Format(time.DateOnly),
+		To:       to.Format(time.DateOnly),
+		GroupBy:  groupBy,
+		Currency: entities.USD,
+		Totals:   NewPeriodSummaryResponse(entities.CombineSummaries(summaries)),
+		Periods:  periods,
+	}
+}
+
+// ApplyConversion converts every amount of a USD report built from summaries at rate
+func (r *SummaryReportResponse) ApplyConversion(summaries []entities.PeriodSummary, rate *entities.ExchangeRate) {
+	for i, summary := range summaries {
+		r.Periods[i] = NewPeriodSummaryResponse(summary.Converted(rate))
+	}
+	r.Totals = NewPeriodSummaryResponse(entities.CombineSummaries(summaries).Converted(rate))
+
+	exchangeRate := rate.Rate
+	effectiveDate := rate.EffectiveDate
+	r.Currency = rate.ToCurrency
+	r.ExchangeRate = &exchangeRate
+	r.EffectiveDate = &effectiveDate
+}
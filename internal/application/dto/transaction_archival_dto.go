@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// ArchivalResult summarizes one run moving old transactions into cold storage
+type ArchivalResult struct {
+	Cutoff   time.Time `json:"cutoff"`   // Transactions dated before this were due
+	Archived int64     `json:"archived"` // Transactions moved this run
+}
+
+// PurgeResult summarizes one run permanently removing transactions from the trash
+type PurgeResult struct {
+	Cutoff time.Time `json:"cutoff"` // Transactions deleted before this were due
+	Purged int64     `json:"purged"` // Transactions removed this run
+}
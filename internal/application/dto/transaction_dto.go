@@ -1,9 +1,14 @@
 package dto
 
 import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
 )
 
@@ -11,67 +16,269 @@ import (
 type CreateTransactionRequest struct {
 	Description string    `json:"description" validate:"required,max=50"`
 	Date        time.Time `json:"date" validate:"required"`
-	Amount      float64   `json:"amount" validate:"required,gt=0"`
+	Amount      float64   `json:"amount" validate:"required,gt=0,money"`
+	Category    string    `json:"category,omitempty" validate:"max=50"`                   // Optional name of an existing category, tracked by budgets
+	Tags        []string  `json:"tags,omitempty" validate:"omitempty,max=10,dive,max=30"` // Optional free-form labels
+
+	// Currency the amount was paid in, USD when omitted; others need cross-rate conversion enabled
+	Currency entities.CurrencyCode `json:"currency,omitempty" validate:"omitempty,currency"`
+}
+
+// UpdateTransactionCategoryRequest represents the input for recategorizing a transaction
+// Omitted fields are left unchanged; an empty category or tag list clears it
+type UpdateTransactionCategoryRequest struct {
+	Category *string   `json:"category" validate:"omitempty,max=50"`
+	Tags     *[]string `json:"tags" validate:"omitempty,max=10,dive,max=30"`
+
+	// ExpectedVersion rejects the update with a conflict unless the transaction is still at this version
+	ExpectedVersion *int64 `json:"expected_version" validate:"omitempty,min=1"`
 }
 
 // CreateTransactionResponse represents the response after creating a transaction
 type CreateTransactionResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Description string    `json:"description"`
-	Date        time.Time `json:"date"`
-	Amount      float64   `json:"amount"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          uuid.UUID             `json:"id"`
+	Description string                `json:"description"`
+	Date        time.Time             `json:"date"`
+	Amount      float64               `json:"amount"`
+	Currency    entities.CurrencyCode `json:"currency"`
+	Category    string                `json:"category,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
 }
 
 // GetTransactionResponse represents the response for retrieving a transaction
 type GetTransactionResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Description string    `json:"description"`
-	Date        time.Time `json:"date"`
-	Amount      float64   `json:"amount"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	XMLName     xml.Name              `json:"-" xml:"transaction"`
+	ID          uuid.UUID             `json:"id" xml:"id"`
+	Description string                `json:"description" xml:"description"`
+	Date        time.Time             `json:"date" xml:"date"`
+	Amount      float64               `json:"amount" xml:"amount"`
+	Currency    entities.CurrencyCode `json:"currency" xml:"currency"` // Currency of Amount; converted responses report it as source_currency
+	Category    string                `json:"category,omitempty" xml:"category,omitempty"`
+	Tags        []string              `json:"tags,omitempty" xml:"tag,omitempty"`
+	CreatedAt   time.Time             `json:"created_at" xml:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at" xml:"updated_at"`
+	Version     int64                 `json:"version,omitempty" xml:"version,omitempty"`         // Pass as expected_version to update; archived transactions have none
+	ArchivedAt  *time.Time            `json:"archived_at,omitempty" xml:"archived_at,omitempty"` // Set when read from cold storage
+	DeletedAt   *time.Time            `json:"deleted_at,omitempty" xml:"deleted_at,omitempty"`   // Set when read from the trash
+}
+
+// GetConvertedTransactionResponse represents a transaction read with its amount converted inline
+type GetConvertedTransactionResponse struct {
+	ListTransactionItem
+	SourceCurrency entities.CurrencyCode `json:"source_currency" xml:"source_currency"` // Currency the amount was paid in
+	Currency       entities.CurrencyCode `json:"currency" xml:"currency"`               // Currency converted to
+	MarginBps      int                   `json:"margin_bps" xml:"margin_bps"`
 }
 
 // ListTransactionsRequest represents the input for listing transactions with pagination
 type ListTransactionsRequest struct {
-	Page int `json:"page" validate:"min=1" default:"1"`
-	Size int `json:"size" validate:"min=1,max=100" default:"20"`
+	Page            int                   `json:"page" validate:"min=1" default:"1"`
+	Size            int                   `json:"size" validate:"min=1,max=100" default:"20"`
+	Currency        entities.CurrencyCode `json:"currency" validate:"omitempty,currency"` // Optional conversion target
+	APIKey          string                `json:"-"`                                      // Caller's API key, selects the margin of converted items
+	Trash           bool                  `json:"trash"`                                  // List soft-deleted transactions instead
+	IncludeArchived bool                  `json:"include_archived"`                       // Append archived transactions after active ones
+
+	// Cursor continues a listing after the position returned as next_cursor instead of jumping to Page
+	Cursor *entities.TransactionCursor `json:"-"`
+	// ByCursor lists uncounted cursor pages from the start when Cursor is nil, as API v2 does
+	ByCursor bool `json:"-"`
+
+	// Optional filters; dates are purchase dates and both bounds are inclusive
+	DateFrom            *time.Time `json:"date_from"`
+	DateTo              *time.Time `json:"date_to"`
+	MinAmount           *float64   `json:"min_amount" validate:"omitempty,gt=0,money"`
+	MaxAmount           *float64   `json:"max_amount" validate:"omitempty,gt=0,money"`
+	DescriptionContains string     `json:"description_contains" validate:"max=50"`
+	Category            string     `json:"category" validate:"max=50"`
+	Tag                 string     `json:"tag" validate:"max=30"`
+}
+
+// Filter converts the request filters to a repository filter
+// DateTo is inclusive, so the filter ends at the start of the following day
+func (r *ListTransactionsRequest) Filter() entities.TransactionFilter {
+	filter := entities.TransactionFilter{
+		DateFrom:            r.DateFrom,
+		DescriptionContains: r.DescriptionContains,
+		Category:            r.Category,
+		Tag:                 r.Tag,
+	}
+	if r.DateTo != nil {
+		dayAfter := r.DateTo.AddDate(0, 0, 1)
+		filter.DateTo = &dayAfter
+	}
+	if r.MinAmount != nil {
+		minAmount := entities.NewMoney(*r.MinAmount)
+		filter.MinAmount = &minAmount
+	}
+	if r.MaxAmount != nil {
+		maxAmount := entities.NewMoney(*r.MaxAmount)
+		filter.MaxAmount = &maxAmount
+	}
+	return filter
+}
+
+// ListTransactionItem represents a transaction in a list, optionally with conversion applied
+type ListTransactionItem struct {
+	GetTransactionResponse
+	ConvertedAmount *float64   `json:"converted_amount,omitempty" xml:"converted_amount,omitempty"`
+	ExchangeRate    *float64   `json:"exchange_rate,omitempty" xml:"exchange_rate,omitempty"`
+	EffectiveDate   *time.Time `json:"effective_date,omitempty" xml:"effective_date,omitempty"`
+	ConversionError string     `json:"conversion_error,omitempty" xml:"conversion_error,omitempty"`
 }
 
 // ListTransactionsResponse represents the response for listing transactions
 type ListTransactionsResponse struct {
-	Data       []GetTransactionResponse `json:"data"`
-	Page       int                      `json:"page"`
-	Size       int                      `json:"size"`
-	Total      int64                    `json:"total"`
-	TotalPages int                      `json:"total_pages"`
+	XMLName    xml.Name              `json:"-" xml:"transaction_list"`
+	Data       []ListTransactionItem `json:"data" xml:"transactions>transaction"`
+	Currency   entities.CurrencyCode `json:"currency,omitempty" xml:"currency,omitempty"`
+	Page       int                   `json:"page" xml:"page"`
+	Size       int                   `json:"size" xml:"size"`
+	Total      int64                 `json:"total" xml:"total"`
+	TotalPages int                   `json:"total_pages" xml:"total_pages"`
+	NextCursor string                `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"` // Continues after this page; empty on the last one
+}
+
+// SuggestDescriptionsRequest represents the input for description autocomplete
+type SuggestDescriptionsRequest struct {
+	Prefix string `json:"prefix" validate:"required,max=50"`
+	Limit  int    `json:"limit" validate:"min=1,max=50" default:"10"`
+}
+
+// SuggestDescriptionsResponse represents matching descriptions ordered by frequency
+type SuggestDescriptionsResponse struct {
+	XMLName xml.Name                         `json:"-" xml:"description_suggestions"`
+	Prefix  string                           `json:"prefix" xml:"prefix"`
+	Data    []entities.DescriptionSuggestion `json:"data" xml:"suggestions>suggestion"`
 }
 
 // ConvertTransactionRequest represents the input for currency conversion
 type ConvertTransactionRequest struct {
 	TransactionID  uuid.UUID             `json:"transaction_id" validate:"required"`
-	TargetCurrency entities.CurrencyCode `json:"target_currency" validate:"required"`
+	TargetCurrency entities.CurrencyCode `json:"target_currency" validate:"required,currency"`
+	Mode           string                `json:"mode" validate:"omitempty,oneof=strict interpolate stale"`
+	QuoteID        *uuid.UUID            `json:"quote_id"` // Use exactly the rate locked by this quote
+	APIKey         string                `json:"-"`        // Caller's API key, selects the margin
+}
+
+// Conversion modes controlling what happens when no rate exists within the lookback window before the date
+const (
+	ConversionModeStrict      = "strict"      // Fail the conversion (default)
+	ConversionModeInterpolate = "interpolate" // Interpolate between the nearest surrounding rates
+	ConversionModeStale       = "stale"       // Use the nearest older rate and flag the conversion as stale
+)
+
+// ConvertTransactionHTTPRequest represents the JSON body of POST /transactions/:id/convert
+type ConvertTransactionHTTPRequest struct {
+	TargetCurrency string `json:"target_currency" binding:"required,currency"`
+	Mode           string `json:"mode"`
+	QuoteID        string `json:"quote_id"`
 }
 
 // ConvertTransactionResponse represents the response after currency conversion
 type ConvertTransactionResponse struct {
 	Transaction     GetTransactionResponse `json:"transaction"`
+	SourceCurrency  entities.CurrencyCode  `json:"source_currency"` // Currency the transaction amount was paid in
 	TargetCurrency  entities.CurrencyCode  `json:"target_currency"`
-	ExchangeRate    float64                `json:"exchange_rate"`
+	RawExchangeRate float64                `json:"raw_exchange_rate"`
+	MarginBps       int                    `json:"margin_bps"`
+	ExchangeRate    float64                `json:"exchange_rate"` // Final rate after margin
 	ConvertedAmount float64                `json:"converted_amount"`
 	EffectiveDate   time.Time              `json:"effective_date"`
+	Interpolated    bool                   `json:"interpolated,omitempty"`
+	RateBounds      []time.Time            `json:"rate_bounds,omitempty"`
+	RateStale       bool                   `json:"rate_stale,omitempty"` // The rate took effect before the lookback window; see EffectiveDate
+	QuoteID         *uuid.UUID             `json:"quote_id,omitempty"`
+}
+
+// ConvertTransactionsBatchRequest represents the input for converting several transactions to one currency
+type ConvertTransactionsBatchRequest struct {
+	TransactionIDs []uuid.UUID           `json:"transaction_ids" validate:"required,min=1,max=100,unique,dive,required"`
+	TargetCurrency entities.CurrencyCode `json:"target_currency" validate:"required,currency"`
+	APIKey         string                `json:"-"` // Caller's API key, selects the margin
 }
 
-// ToEntity converts CreateTransactionRequest to Transaction entity
-func (req *CreateTransactionRequest) ToEntity() *entities.Transaction {
+// ConvertTransactionsBatchHTTPRequest represents the JSON body of POST /transactions/convert-batch
+type ConvertTransactionsBatchHTTPRequest struct {
+	TransactionIDs []string `json:"transaction_ids" binding:"required"`
+	TargetCurrency string   `json:"target_currency" binding:"required"`
+}
+
+// ConvertTransactionsBatchItem is the conversion of one requested transaction, or why it failed
+type ConvertTransactionsBatchItem struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	*ConvertTransactionResponse
+	Error string `json:"error,omitempty"`
+}
+
+// ConvertTransactionsBatchResponse represents the conversions in the order the IDs were requested
+type ConvertTransactionsBatchResponse struct {
+	TargetCurrency entities.CurrencyCode          `json:"target_currency"`
+	Converted      int                            `json:"converted"`
+	Failed         int                            `json:"failed"`
+	Data           []ConvertTransactionsBatchItem `json:"data"`
+}
+
+// ToEntity converts CreateTransactionRequest to a Transaction entity created at the current time of clk
+func (req *CreateTransactionRequest) ToEntity(clk clock.Clock) *entities.Transaction {
+	currency := req.Currency
+	if currency == "" {
+		currency = entities.USD
+	}
+
 	return &entities.Transaction{
 		ID:          uuid.New(),
 		Description: req.Description,
 		Date:        req.Date,
 		Amount:      entities.NewMoney(req.Amount),
-		CreatedAt:   time.Now(),
+		Currency:    currency,
+		Category:    strings.TrimSpace(req.Category),
+		Tags:        entities.NormalizeTags(req.Tags),
+		CreatedAt:   clk.Now(),
+	}
+}
+
+// ToConvertRequest normalizes the target currency and builds the use case request
+func (req *ConvertTransactionHTTPRequest) ToConvertRequest(transactionID uuid.UUID) (*ConvertTransactionRequest, error) {
+	quoteID, err := ParseQuoteID(req.QuoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetCurrency, err := entities.NewCurrencyCode(req.TargetCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConvertTransactionRequest{
+		TransactionID:  transactionID,
+		TargetCurrency: targetCurrency,
+		Mode:           strings.ToLower(strings.TrimSpace(req.Mode)),
+		QuoteID:        quoteID,
+	}, nil
+}
+
+// ToBatchRequest parses the transaction IDs and normalizes the target currency
+func (req *ConvertTransactionsBatchHTTPRequest) ToBatchRequest() (*ConvertTransactionsBatchRequest, error) {
+	transactionIDs := make([]uuid.UUID, len(req.TransactionIDs))
+	for i, raw := range req.TransactionIDs {
+		transactionID, err := uuid.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction ID %q: must be a valid UUID", raw)
+		}
+		transactionIDs[i] = transactionID
+	}
+
+	targetCurrency, err := entities.NewCurrencyCode(req.TargetCurrency)
+	if err != nil {
+		return nil, err
 	}
+
+	return &ConvertTransactionsBatchRequest{
+		TransactionIDs: transactionIDs,
+		TargetCurrency: targetCurrency,
+	}, nil
 }
 
 // FromEntity converts Transaction entity to CreateTransactionResponse
@@ -81,27 +288,41 @@ func NewCreateTransactionResponse(transaction *entities.Transaction) *CreateTran
 		Description: transaction.Description,
 		Date:        transaction.Date,
 		Amount:      transaction.Amount.Dollars(),
+		Currency:    transaction.SourceCurrency(),
+		Category:    transaction.Category,
+		Tags:        transaction.Tags,
 		CreatedAt:   transaction.CreatedAt,
 	}
 }
 
 // FromEntity converts Transaction entity to GetTransactionResponse
 func NewGetTransactionResponse(transaction *entities.Transaction) *GetTransactionResponse {
+	var deletedAt *time.Time
+	if transaction.IsDeleted() {
+		deletedAt = transaction.DeletedAt
+	}
+
 	return &GetTransactionResponse{
 		ID:          transaction.ID,
 		Description: transaction.Description,
 		Date:        transaction.Date,
 		Amount:      transaction.Amount.Dollars(),
+		Currency:    transaction.SourceCurrency(),
+		Category:    transaction.Category,
+		Tags:        transaction.Tags,
 		CreatedAt:   transaction.CreatedAt,
 		UpdatedAt:   transaction.UpdatedAt,
+		Version:     transaction.Version,
+		ArchivedAt:  transaction.ArchivedAt,
+		DeletedAt:   deletedAt,
 	}
 }
 
 // NewListTransactionsResponse creates a paginated response for listing transactions
 func NewListTransactionsResponse(transactions []entities.Transaction, page, size int, total int64) *ListTransactionsResponse {
-	responses := make([]GetTransactionResponse, len(transactions))
+	responses := make([]ListTransactionItem, len(transactions))
 	for i, tx := range transactions {
-		responses[i] = *NewGetTransactionResponse(&tx)
+		responses[i] = ListTransactionItem{GetTransactionResponse: *NewGetTransactionResponse(&tx)}
 	}
 
 	totalPages := int((total + int64(size) - 1) / int64(size)) // Ceiling division
@@ -115,13 +336,179 @@ func NewListTransactionsResponse(transactions []entities.Transaction, page, size
 	}
 }
 
+// NewCursorListTransactionsResponse creates the response for a page continued from a cursor
+// Cursor pages are not counted, so Page, Total and TotalPages stay zero
+func NewCursorListTransactionsResponse(transactions []entities.Transaction, size int, nextCursor string) *ListTransactionsResponse {
+	response := NewListTransactionsResponse(transactions, 0, size, 0)
+	response.NextCursor = nextCursor
+	return response
+}
+
+// IsCursorPage reports whether the response was continued from a cursor rather than requested by page number
+func (r *ListTransactionsResponse) IsCursorPage() bool {
+	return r.Page == 0
+}
+
+// TransactionPageResponse is a page of transactions continued by cursor, the list representation of API v2
+// Pages are not numbered or counted; NextCursor is empty on the last one
+type TransactionPageResponse struct {
+	XMLName    xml.Name              `json:"-" xml:"transaction_page"`
+	Data       []ListTransactionItem `json:"data" xml:"transactions>transaction"`
+	Currency   entities.CurrencyCode `json:"currency,omitempty" xml:"currency,omitempty"`
+	Size       int                   `json:"size" xml:"size"`
+	NextCursor string                `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
+}
+
+// NewTransactionPageResponse drops the page numbers and totals from a cursor page of the list
+func NewTransactionPageResponse(list *ListTransactionsResponse) *TransactionPageResponse {
+	return &TransactionPageResponse{
+		Data:       list.Data,
+		Currency:   list.Currency,
+		Size:       list.Size,
+		NextCursor: list.NextCursor,
+	}
+}
+
+// list returns the page as a cursor page of the v1 list, which shares its CSV and JSON:API forms
+func (r *TransactionPageResponse) list() *ListTransactionsResponse {
+	return &ListTransactionsResponse{Data: r.Data, Currency: r.Currency, Size: r.Size, NextCursor: r.NextCursor}
+}
+
+// ApplyConversion fills in the converted amount and rate used for a list item
+func (item *ListTransactionItem) ApplyConversion(convertedTx *entities.ConvertedTransaction) {
+	convertedAmount := convertedTx.ConvertedAmount.Dollars()
+	exchangeRate := convertedTx.ExchangeRate
+	effectiveDate := convertedTx.EffectiveDate
+
+	item.ConvertedAmount = &convertedAmount
+	item.ExchangeRate = &exchangeRate
+	item.EffectiveDate = &effectiveDate
+}
+
+// NewGetConvertedTransactionResponse flattens a conversion into the transaction it converts
+func NewGetConvertedTransactionResponse(converted *ConvertTransactionResponse) *GetConvertedTransactionResponse {
+	convertedAmount := converted.ConvertedAmount
+	exchangeRate := converted.ExchangeRate
+	effectiveDate := converted.EffectiveDate
+
+	return &GetConvertedTransactionResponse{
+		ListTransactionItem: ListTransactionItem{
+			GetTransactionResponse: converted.Transaction,
+			ConvertedAmount:        &convertedAmount,
+			ExchangeRate:           &exchangeRate,
+			EffectiveDate:          &effectiveDate,
+		},
+		SourceCurrency: converted.SourceCurrency,
+		Currency:       converted.TargetCurrency,
+		MarginBps:      converted.MarginBps,
+	}
+}
+
 // NewConvertTransactionResponse converts ConvertedTransaction entity to response
 func NewConvertTransactionResponse(convertedTx *entities.ConvertedTransaction) *ConvertTransactionResponse {
 	return &ConvertTransactionResponse{
 		Transaction:     *NewGetTransactionResponse(&convertedTx.Transaction),
+		SourceCurrency:  convertedTx.Transaction.SourceCurrency(),
 		TargetCurrency:  convertedTx.TargetCurrency,
+		RawExchangeRate: convertedTx.ExchangeRate,
 		ExchangeRate:    convertedTx.ExchangeRate,
 		ConvertedAmount: convertedTx.ConvertedAmount.Dollars(),
 		EffectiveDate:   convertedTx.EffectiveDate,
+		Interpolated:    convertedTx.Interpolated,
+		RateBounds:      convertedTx.RateBounds,
+		RateStale:       convertedTx.RateStale,
 	}
 }
+
+// NewConvertTransactionResponseFromEvent creates the conversion response announced by a TransactionConvertedEvent
+func NewConvertTransactionResponseFromEvent(event entities.TransactionConvertedEvent) *ConvertTransactionResponse {
+	response := NewConvertTransactionResponse(&event.Conversion)
+	response.RawExchangeRate = event.RawExchangeRate
+	response.MarginBps = event.MarginBps
+	response.QuoteID = event.QuoteID
+	return response
+}
+
+// CSV renders the transaction as a header and a single row
+func (r *GetTransactionResponse) CSV() [][]string {
+	return [][]string{transactionCSVHeader(false), r.csvRow()}
+}
+
+// transactionCSVHeader names the transaction columns
+// Converted tables have a target currency column, so the transaction's own currency is source_currency there
+func transactionCSVHeader(converted bool) []string {
+	currency := "currency"
+	if converted {
+		currency = "source_currency"
+	}
+	return []string{"id", "description", "date", "amount", currency, "category", "created_at", "updated_at"}
+}
+
+func (r *GetTransactionResponse) csvRow() []string {
+	return []string{
+		r.ID.String(),
+		r.Description,
+		r.Date.Format(time.RFC3339),
+		formatCSVFloat(r.Amount),
+		string(r.Currency),
+		r.Category,
+		r.CreatedAt.Format(time.RFC3339),
+		r.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// CSV renders the transaction as a single row followed by the currency and conversion columns
+func (r *GetConvertedTransactionResponse) CSV() [][]string {
+	header := append(transactionCSVHeader(true), "currency", "converted_amount", "exchange_rate", "effective_date")
+	row := append(r.csvRow(), string(r.Currency), formatCSVFloat(*r.ConvertedAmount),
+		formatCSVFloat(*r.ExchangeRate), r.EffectiveDate.Format(time.DateOnly))
+	return [][]string{header, row}
+}
+
+// CSV renders one row per transaction; converted lists add the currency and conversion columns
+// Pagination is not part of the table
+func (r *ListTransactionsResponse) CSV() [][]string {
+	header := transactionCSVHeader(r.Currency != "")
+	if r.Currency != "" {
+		header = append(header, "currency", "converted_amount", "exchange_rate", "effective_date", "conversion_error")
+	}
+
+	records := [][]string{header}
+	for _, item := range r.Data {
+		row := item.csvRow()
+		if r.Currency != "" {
+			var convertedAmount, exchangeRate, effectiveDate string
+			if item.ConvertedAmount != nil {
+				convertedAmount = formatCSVFloat(*item.ConvertedAmount)
+			}
+			if item.ExchangeRate != nil {
+				exchangeRate = formatCSVFloat(*item.ExchangeRate)
+			}
+			if item.EffectiveDate != nil {
+				effectiveDate = item.EffectiveDate.Format(time.DateOnly)
+			}
+			row = append(row, string(r.Currency), convertedAmount, exchangeRate, effectiveDate, item.ConversionError)
+		}
+		records = append(records, row)
+	}
+	return records
+}
+
+// CSV renders the page like the v1 list
+func (r *TransactionPageResponse) CSV() [][]string {
+	return r.list().CSV()
+}
+
+// CSV renders one row per suggested description
+func (r *SuggestDescriptionsResponse) CSV() [][]string {
+	records := [][]string{{"description", "count"}}
+	for _, suggestion := range r.Data {
+		records = append(records, []string{suggestion.Description, strconv.FormatInt(suggestion.Count, 10)})
+	}
+	return records
+}
+
+// formatCSVFloat writes the shortest decimal that round-trips, matching the JSON representation
+func formatCSVFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
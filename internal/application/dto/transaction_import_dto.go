@@ -0,0 +1,15 @@
+package dto
+
+// ImportTransactionsResponse reports a CSV import: how many rows were stored and why the others were rejected
+type ImportTransactionsResponse struct {
+	Rows     int              `json:"rows"`     // Data rows in the file, not counting the header
+	Imported int              `json:"imported"` // Rows stored as new transactions
+	Rejected int              `json:"rejected"` // Rows that failed validation and were not stored
+	Errors   []ImportRowError `json:"errors"`   // One entry per rejected row, in file order
+}
+
+// ImportRowError lists the problems of one rejected row
+type ImportRowError struct {
+	Line     int      `json:"line"` // Line in the file, the header being line 1
+	Messages []string `json:"messages"`
+}
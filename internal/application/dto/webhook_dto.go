@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// CreateWebhookRequest represents the input for registering a webhook
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,max=2048"`
+	Events []string `json:"events" validate:"required,min=1,max=10,dive,required"` // transaction.created, transaction.converted
+}
+
+// WebhookResponse represents a webhook; the secret is only returned when the webhook is created
+type WebhookResponse struct {
+	ID        uuid.UUID `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListWebhooksResponse represents every registered webhook
+type ListWebhooksResponse struct {
+	Data []WebhookResponse `json:"data"`
+}
+
+// WebhookDeliveryResponse reports one event sent to a webhook
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	EventID        uuid.UUID  `json:"event_id"`
+	Event          string     `json:"event"`
+	Status         string     `json:"status"` // pending, succeeded or failed
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ListWebhookDeliveriesResponse represents the most recent deliveries of a webhook
+type ListWebhookDeliveriesResponse struct {
+	Data []WebhookDeliveryResponse `json:"data"`
+}
+
+// WebhookDeliveryResult summarizes one run of the webhook delivery retries
+type WebhookDeliveryResult struct {
+	Attempted int `json:"attempted"`
+	Succeeded int `json:"succeeded"`
+	Retrying  int `json:"retrying"` // Failed this time and scheduled again
+	Failed    int `json:"failed"`   // Failed for the last time
+}
+
+// ToEntity converts CreateWebhookRequest to a Webhook entity with the given ID and secret
+func (req *CreateWebhookRequest) ToEntity(id uuid.UUID, secret string) *entities.Webhook {
+	return &entities.Webhook{
+		ID:     id,
+		URL:    req.URL,
+		Events: req.Events,
+		Secret: secret,
+	}
+}
+
+// NewWebhookResponse converts Webhook entity to WebhookResponse without its secret
+func NewWebhookResponse(webhook *entities.Webhook) *WebhookResponse {
+	return &WebhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+// NewListWebhooksResponse converts webhooks to ListWebhooksResponse
+func NewListWebhooksResponse(webhooks []entities.Webhook) *ListWebhooksResponse {
+	response := &ListWebhooksResponse{Data: make([]WebhookResponse, 0, len(webhooks))}
+	for i := range webhooks {
+		response.Data = append(response.Data, *NewWebhookResponse(&webhooks[i]))
+	}
+	return response
+}
+
+// NewListWebhookDeliveriesResponse converts deliveries to ListWebhookDeliveriesResponse
+func NewListWebhookDeliveriesResponse(deliveries []entities.WebhookDelivery) *ListWebhookDeliveriesResponse {
+	response := &ListWebhookDeliveriesResponse{Data: make([]WebhookDeliveryResponse, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		response.Data = append(response.Data, WebhookDeliveryResponse{
+			ID:             delivery.ID,
+			EventID:        delivery.EventID,
+			Event:          delivery.Event,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			LastStatusCode: delivery.LastStatusCode,
+			LastError:      delivery.LastError,
+			DeliveredAt:    delivery.DeliveredAt,
+			CreatedAt:      delivery.CreatedAt,
+		})
+	}
+	return response
+}
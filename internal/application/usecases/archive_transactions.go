@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// ArchiveTransactionsUseCase moves transactions older than a retention period out of the hot table
+type ArchiveTransactionsUseCase struct {
+	transactionRepo repositories.TransactionRepository
+	afterYears      int
+	batchSize       int
+	clock           clock.Clock
+}
+
+// NewArchiveTransactionsUseCase creates a new instance of ArchiveTransactionsUseCase
+// Transactions with a purchase date more than afterYears years ago are archived batchSize at a time
+func NewArchiveTransactionsUseCase(transactionRepo repositories.TransactionRepository, afterYears, batchSize int) *ArchiveTransactionsUseCase {
+	return &ArchiveTransactionsUseCase{
+		transactionRepo: transactionRepo,
+		afterYears:      afterYears,
+		batchSize:       batchSize,
+		clock:           clock.System(),
+	}
+}
+
+// WithClock sets the clock that decides which transactions are old enough to archive
+func (uc *ArchiveTransactionsUseCase) WithClock(clk clock.Clock) *ArchiveTransactionsUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Execute archives every due transaction, one batch per database transaction, until none are left
+// Batches already moved stay archived when ctx is cancelled or a later batch fails
+func (uc *ArchiveTransactionsUseCase) Execute(ctx context.Context) (*dto.ArchivalResult, error) {
+	if uc.afterYears < 1 {
+		return nil, fmt.Errorf("archive retention must be at least 1 year, got %d", uc.afterYears)
+	}
+
+	today := uc.clock.Now().UTC().Truncate(24 * time.Hour)
+	result := &dto.ArchivalResult{Cutoff: today.AddDate(-uc.afterYears, 0, 0)}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		moved, err := uc.transactionRepo.ArchiveDatedBefore(result.Cutoff, uc.batchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to archive transactions: %w", err)
+		}
+
+		result.Archived += moved
+		if moved == 0 || (uc.batchSize > 0 && moved < int64(uc.batchSize)) {
+			return result, nil
+		}
+	}
+}
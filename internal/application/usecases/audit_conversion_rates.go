@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// rateAuditTolerance absorbs float noise when comparing stored and authoritative rates
+const rateAuditTolerance = 1e-9
+
+// AuditConversionRatesUseCase checks a sample of stored conversions against freshly fetched Treasury rates
+// It bypasses the local rate cache so a corrected or revised source rate is always noticed
+type AuditConversionRatesUseCase struct {
+	recordRepo      repositories.ConversionRecordRepository
+	treasuryService services.RateProvider
+	clock           clock.Clock
+}
+
+// NewAuditConversionRatesUseCase creates a new instance of AuditConversionRatesUseCase
+func NewAuditConversionRatesUseCase(
+	recordRepo repositories.ConversionRecordRepository,
+	treasuryService services.RateProvider,
+) *AuditConversionRatesUseCase {
+	return &AuditConversionRatesUseCase{
+		recordRepo:      recordRepo,
+		treasuryService: treasuryService,
+		clock:           clock.System(),
+	}
+}
+
+// WithClock sets the clock reports are dated by
+func (uc *AuditConversionRatesUseCase) WithClock(clk clock.Clock) *AuditConversionRatesUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Execute samples up to sampleSize current conversion records and reconciles each with the source
+func (uc *AuditConversionRatesUseCase) Execute(ctx context.Context, sampleSize int) (*dto.RateAuditReport, error) {
+	if sampleSize < 1 {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: sample size must be at least 1")
+	}
+
+	records, err := uc.recordRepo.Sample(sampleSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample conversion records: %w", err)
+	}
+
+	report := &dto.RateAuditReport{
+		GeneratedAt: uc.clock.Now().UTC(),
+		Provider:    uc.treasuryService.ProviderName(),
+		Sampled:     len(records),
+		Entries:     make([]dto.RateAuditEntry, 0),
+	}
+
+	// Records sharing a currency and purchase date resolve to the same source rate, so fetch it once
+	type rateKey struct {
+		currency entities.CurrencyCode
+		date     string
+	}
+	type rateResult struct {
+		rate *entities.ExchangeRate
+		err  error
+	}
+	fetched := make(map[rateKey]rateResult)
+
+	for i := range records {
+		record := &records[i]
+
+		key := rateKey{currency: record.TargetCurrency, date: record.TransactionDate.Format("2006-01-02")}
+		result, seen := fetched[key]
+		if !seen {
+			result.rate, result.err = uc.treasuryService.FetchExchangeRate(ctx, entities.USD, record.TargetCurrency, record.TransactionDate)
+			fetched[key] = result
+		}
+
+		entry := auditEntry(record, result.rate, result.err)
+		switch entry.Outcome {
+		case dto.AuditMatched:
+			report.Matched++
+			continue
+		case dto.AuditMismatched:
+			report.Mismatched++
+		default:
+			report.Unavailable++
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+
+	return report, nil
+}
+
+// auditEntry classifies a stored record against the authoritative rate (or the error fetching it)
+func auditEntry(record *entities.ConversionRecord, authoritative *entities.ExchangeRate, fetchErr error) dto.RateAuditEntry {
+	entry := dto.RateAuditEntry{
+		RecordID:              record.ID,
+		TransactionID:         record.TransactionID,
+		TargetCurrency:        record.TargetCurrency,
+		TransactionDate:       record.TransactionDate.Format("2006-01-02"),
+		StoredRate:            record.ExchangeRate,
+		StoredEffectiveDate:   record.EffectiveDate.Format("2006-01-02"),
+		StoredConvertedAmount: record.ConvertedAmount.Dollars(),
+	}
+
+	if fetchErr != nil || authoritative == nil {
+		entry.Outcome = dto.AuditUnavailable
+		if fetchErr != nil {
+			entry.Error = fetchErr.Error()
+		} else {
+			entry.Error = "no rate returned"
+		}
+		return entry
+	}
+
+	entry.AuthoritativeRate = authoritative.Rate
+	entry.AuthoritativeEffectiveDate = authoritative.EffectiveDate.Format("2006-01-02")
+	entry.AuthoritativeConvertedAmount = authoritative.ConvertAmount(record.OriginalAmount).Dollars()
+
+	entry.Outcome = dto.AuditMatched
+	if math.Abs(authoritative.Rate-record.ExchangeRate) > rateAuditTolerance ||
+		entry.AuthoritativeEffectiveDate != entry.StoredEffectiveDate {
+		entry.Outcome = dto.AuditMismatched
+	}
+	return entry
+}
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// AuditLogUseCase handles recording changes in the audit trail and reviewing it
+type AuditLogUseCase struct {
+	auditRepo repositories.AuditLogRepository
+	clock     clock.Clock
+}
+
+// NewAuditLogUseCase creates a new instance of AuditLogUseCase
+func NewAuditLogUseCase(auditRepo repositories.AuditLogRepository) *AuditLogUseCase {
+	return &AuditLogUseCase{
+		auditRepo: auditRepo,
+		clock:     clock.System(),
+	}
+}
+
+// WithClock sets the clock audit entries are dated by
+func (uc *AuditLogUseCase) WithClock(clk clock.Clock) *AuditLogUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Record appends a change to the audit trail
+func (uc *AuditLogUseCase) Record(entry dto.AuditEntry) error {
+	auditLog, err := entities.NewAuditLog(entry.EntityType, entry.EntityID, entry.Action, entry.Actor, entry.RequestID, entry.Changes, uc.clock)
+	if err != nil {
+		return fmt.Errorf("failed to record audit log: %w", err)
+	}
+
+	if err := uc.auditRepo.Save(auditLog); err != nil {
+		return fmt.Errorf("failed to record audit log: %w", err)
+	}
+
+	return nil
+}
+
+// List returns a page of the audit trail, most recent first
+func (uc *AuditLogUseCase) List(request *dto.ListAuditLogsRequest) (*dto.ListAuditLogsResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+	if request.From != nil && request.To != nil && request.To.Before(*request.From) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: to must not be before from")
+	}
+
+	filter := entities.AuditLogFilter{
+		EntityID: request.EntityID,
+		From:     request.From,
+	}
+	if request.To != nil {
+		// The end date is inclusive, so entries recorded during that day match
+		end := request.To.AddDate(0, 0, 1)
+		filter.To = &end
+	}
+
+	entries, total, err := uc.auditRepo.FindPaginated(filter, request.Page, request.Size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve audit logs: %w", err)
+	}
+
+	return dto.NewListAuditLogsResponse(entries, request.Page, request.Size, total), nil
+}
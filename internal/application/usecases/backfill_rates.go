@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// BackfillRatesUseCase fetches and stores the provider rates covering a past date range
+// The Treasury publishes rates quarterly, so the range is looked up at every quarter end in it and at its last day
+type BackfillRatesUseCase struct {
+	exchangeRateRepo repositories.ExchangeRateRepository
+	treasuryService  services.RateProvider
+	window           entities.RateWindow
+}
+
+// NewBackfillRatesUseCase creates a new instance of BackfillRatesUseCase
+func NewBackfillRatesUseCase(
+	exchangeRateRepo repositories.ExchangeRateRepository,
+	treasuryService services.RateProvider,
+) *BackfillRatesUseCase {
+	return &BackfillRatesUseCase{
+		exchangeRateRepo: exchangeRateRepo,
+		treasuryService:  treasuryService,
+	}
+}
+
+// WithRateWindow sets the lookback window stored rates are searched in
+// Without it the default 6-month window applies
+func (uc *BackfillRatesUseCase) WithRateWindow(window entities.RateWindow) *BackfillRatesUseCase {
+	uc.window = window
+	return uc
+}
+
+// Execute stores the rate in effect on each lookup date of [from, to] for every currency
+// Dates whose rate is already stored are not fetched again; a failed lookup does not stop the others
+func (uc *BackfillRatesUseCase) Execute(ctx context.Context, currencies []entities.CurrencyCode, from, to time.Time) (*dto.RateBackfillResult, error) {
+	if len(currencies) == 0 {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: at least one currency is required")
+	}
+	if to.Before(from) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: the range ends before it starts")
+	}
+
+	dates := backfillDates(from, to)
+	result := &dto.RateBackfillResult{Currencies: len(currencies), Dates: len(dates)}
+
+	for _, currency := range currencies {
+		for _, date := range dates {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			stored, err := uc.exchangeRateRepo.FindRateForConversion(entities.USD, currency, date, uc.window)
+			if err != nil {
+				return result, fmt.Errorf("failed to look up stored rate for %s: %w", currency, err)
+			}
+			if stored != nil && stored.EffectiveDate.Equal(date) {
+				result.Cached++
+				continue
+			}
+
+			rate, err := uc.treasuryService.FetchExchangeRate(ctx, entities.USD, currency, date)
+			if err != nil {
+				uc.fail(result, currency, date, fmt.Errorf("failed to fetch exchange rate: %w", err))
+				continue
+			}
+			if !rate.IsAuthoritative() {
+				uc.fail(result, currency, date, errs.Newf(errs.ErrRateUnavailable, "no Treasury rate found; rates from the %s provider are not stored", rate.Source))
+				continue
+			}
+			if stored != nil && !rate.EffectiveDate.After(stored.EffectiveDate) {
+				result.Cached++
+				continue
+			}
+
+			if err := uc.exchangeRateRepo.Save(rate); err != nil {
+				uc.fail(result, currency, date, fmt.Errorf("failed to store exchange rate: %w", err))
+				continue
+			}
+			result.Stored++
+		}
+	}
+
+	return result, nil
+}
+
+// fail records a lookup that could not be backfilled
+func (uc *BackfillRatesUseCase) fail(result *dto.RateBackfillResult, currency entities.CurrencyCode, date time.Time, err error) {
+	result.Failed++
+	if result.Errors == nil {
+		result.Errors = make(map[string]string)
+	}
+	result.Errors[fmt.Sprintf("%s %s", currency, date.Format("2006-01-02"))] = err.Error()
+}
+
+// backfillDates returns the quarter ends within [from, to] followed by to itself, as UTC dates
+func backfillDates(from, to time.Time) []time.Time {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	// The last day of a quarter is the day before the first day of the next one
+	quarterEnd := func(year int, month time.Month) time.Time {
+		next := (int(month)-1)/3*3 + 4
+		return time.Date(year, time.Month(next), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	}
+
+	var dates []time.Time
+	for date := quarterEnd(from.Year(), from.Month()); date.Before(to); date = quarterEnd(date.Year(), date.Month()+1) {
+		dates = append(dates, date)
+	}
+	return append(dates, to)
+}
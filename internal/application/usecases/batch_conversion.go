@@ -0,0 +1,368 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// batchProgressEvery is how many processed transactions trigger a records flush and progress update
+const batchProgressEvery = 50
+
+// RatePrefetcher stores the provider rates of a date range ahead of the lookups made for dates within it
+type RatePrefetcher interface {
+	PrefetchRates(ctx context.Context, currency entities.CurrencyCode, startDate, endDate time.Time) (int, error)
+}
+
+// BatchConversionUseCase converts every transaction in a date range in the background, persisting the results
+// Batches use raw rates without margin since they feed internal reporting
+type BatchConversionUseCase struct {
+	transactionRepo repositories.TransactionRepository
+	batchRepo       repositories.ConversionBatchRepository
+	recordRepo      repositories.ConversionRecordRepository
+	rateFinder      ExchangeRateFinder
+	concurrency     int
+	validator       *validator.Validate
+	clock           clock.Clock
+
+	running sync.WaitGroup
+}
+
+// NewBatchConversionUseCase creates a new instance of BatchConversionUseCase
+// concurrency bounds how many transactions are converted at once; values below 1 mean 1
+func NewBatchConversionUseCase(
+	transactionRepo repositories.TransactionRepository,
+	batchRepo repositories.ConversionBatchRepository,
+	recordRepo repositories.ConversionRecordRepository,
+	rateFinder ExchangeRateFinder,
+	concurrency int,
+	validator *validator.Validate,
+) *BatchConversionUseCase {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &BatchConversionUseCase{
+		transactionRepo: transactionRepo,
+		batchRepo:       batchRepo,
+		recordRepo:      recordRepo,
+		rateFinder:      rateFinder,
+		concurrency:     concurrency,
+		validator:       validator,
+		clock:           clock.System(),
+	}
+}
+
+// WithClock sets the clock batches and their records are dated by
+func (uc *BatchConversionUseCase) WithClock(clk clock.Clock) *BatchConversionUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Start records a pending batch and begins converting it in the background
+func (uc *BatchConversionUseCase) Start(request *dto.StartBatchConversionRequest) (*dto.ConversionBatchResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	if !uc.rateFinder.SupportsCurrency(request.TargetCurrency) {
+		return nil, errs.Newf(errs.ErrUnsupportedCurrency, "unsupported target currency: %s", request.TargetCurrency)
+	}
+
+	batch, err := entities.NewConversionBatch(request.TargetCurrency, request.From, request.To, uc.clock)
+	if err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	if err := uc.batchRepo.Save(batch); err != nil {
+		return nil, fmt.Errorf("failed to save conversion batch: %w", err)
+	}
+
+	response := dto.NewConversionBatchResponse(batch)
+
+	uc.running.Add(1)
+	go func() {
+		defer uc.running.Done()
+		uc.run(batch)
+	}()
+
+	return response, nil
+}
+
+// GetStatus returns the current progress of a batch
+func (uc *BatchConversionUseCase) GetStatus(batchID uuid.UUID) (*dto.ConversionBatchResponse, error) {
+	batch, err := uc.getBatch(batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewConversionBatchResponse(batch), nil
+}
+
+// GetRecords returns a page of the conversion records a batch produced
+func (uc *BatchConversionUseCase) GetRecords(batchID uuid.UUID, page, size int) (*dto.ListConversionRecordsResponse, error) {
+	if _, err := uc.getBatch(batchID); err != nil {
+		return nil, err
+	}
+
+	records, total, err := uc.recordRepo.GetByBatchPaginated(batchID, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve conversion records: %w", err)
+	}
+
+	return dto.NewListConversionRecordsResponse(records, page, size, total), nil
+}
+
+// Wait blocks until every batch started by this use case has finished
+func (uc *BatchConversionUseCase) Wait() {
+	uc.running.Wait()
+}
+
+// getBatch loads a batch, turning a missing one into a not found error
+func (uc *BatchConversionUseCase) getBatch(batchID uuid.UUID) (*entities.ConversionBatch, error) {
+	batch, err := uc.batchRepo.GetByID(batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve conversion batch: %w", err)
+	}
+	if batch == nil {
+		return nil, errs.Newf(errs.ErrNotFound, "conversion batch not found with id: %s", batchID)
+	}
+
+	return batch, nil
+}
+
+// batchResult is the outcome of converting one transaction
+type batchResult struct {
+	record *entities.ConversionRecord
+	err    error
+}
+
+// run converts the batch's transactions with a bounded worker pool
+// Only this goroutine writes records and progress, so workers never contend on the database for writes
+func (uc *BatchConversionUseCase) run(batch *entities.ConversionBatch) {
+	startedAt := uc.clock.Now().UTC()
+	batch.Status = entities.BatchStatusRunning
+	batch.StartedAt = &startedAt
+	if err := uc.batchRepo.Update(batch); err != nil {
+		slog.Warn("Failed to mark conversion batch running", "batch_id", batch.ID.String(), "error", err.Error())
+	}
+
+	// The range is inclusive of the whole To day
+	transactions, err := uc.transactionRepo.FindByDateBetween(batch.FromDate, batch.ToDate.AddDate(0, 0, 1))
+	if err != nil {
+		uc.finish(batch, fmt.Errorf("failed to load transactions: %w", err))
+		return
+	}
+
+	batch.Total = len(transactions)
+	if err := uc.batchRepo.Update(batch); err != nil {
+		slog.Warn("Failed to update conversion batch", "batch_id", batch.ID.String(), "error", err.Error())
+	}
+
+	// The batch outlives the request that started it, so its lookups are not bound to any request deadline
+	ctx := context.Background()
+	uc.prefetchRates(ctx, batch, transactions)
+
+	jobs := make(chan entities.Transaction)
+	results := make(chan batchResult)
+	rates := newRateMemo(uc.rateFinder, batch.TargetCurrency)
+
+	var workers sync.WaitGroup
+	for i := 0; i < uc.concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for transaction := range jobs {
+				results <- uc.convert(ctx, batch.ID, transaction, batch.TargetCurrency, rates)
+			}
+		}()
+	}
+
+	go func() {
+		for _, transaction := range transactions {
+			jobs <- transaction
+		}
+		close(jobs)
+		workers.Wait()
+		close(results)
+	}()
+
+	pending := make([]entities.ConversionRecord, 0, batchProgressEvery)
+	var saveErr error
+	for result := range results {
+		batch.Processed++
+		if result.err != nil {
+			batch.Failed++
+		} else {
+			batch.Succeeded++
+			pending = append(pending, *result.record)
+		}
+
+		if saveErr == nil && batch.Processed%batchProgressEvery == 0 {
+			saveErr = uc.flush(batch, &pending)
+		}
+	}
+
+	if saveErr == nil {
+		saveErr = uc.flush(batch, &pending)
+	}
+	uc.finish(batch, saveErr)
+}
+
+// prefetchRates stores the rates of every currency the batch converts with, one provider call per currency
+// for the whole range instead of one per purchase date; failures are logged and left to the per-date lookups
+func (uc *BatchConversionUseCase) prefetchRates(ctx context.Context, batch *entities.ConversionBatch, transactions []entities.Transaction) {
+	prefetcher, ok := uc.rateFinder.(RatePrefetcher)
+	if !ok || len(transactions) == 0 {
+		return
+	}
+
+	// USD transactions need the target's rates; others are crossed through USD and need their own too
+	currencies := map[entities.CurrencyCode]bool{}
+	for _, transaction := range transactions {
+		source := transaction.SourceCurrency()
+		if source == batch.TargetCurrency {
+			continue
+		}
+		if source != entities.USD {
+			currencies[source] = true
+		}
+		if batch.TargetCurrency != entities.USD {
+			currencies[batch.TargetCurrency] = true
+		}
+	}
+
+	// A purchase on the first day may use a rate from up to a window before it
+	startDate := uc.rateFinder.RateWindow().Start(batch.FromDate)
+	endDate := batch.ToDate
+	for currency := range currencies {
+		if !uc.rateFinder.SupportsCurrency(currency) {
+			continue
+		}
+		stored, err := prefetcher.PrefetchRates(ctx, currency, startDate, endDate)
+		if err != nil {
+			slog.Warn("Failed to prefetch exchange rates for conversion batch",
+				"batch_id", batch.ID.String(),
+				"currency", string(currency),
+				"error", err.Error(),
+			)
+			continue
+		}
+		slog.Info("Prefetched exchange rates for conversion batch",
+			"batch_id", batch.ID.String(),
+			"currency", string(currency),
+			"stored", stored,
+		)
+	}
+}
+
+// convert builds the conversion record for one transaction
+func (uc *BatchConversionUseCase) convert(
+	ctx context.Context,
+	batchID uuid.UUID,
+	transaction entities.Transaction,
+	currency entities.CurrencyCode,
+	rates *rateMemo,
+) batchResult {
+	rate, err := rates.get(ctx, transaction.SourceCurrency(), transaction.Date)
+	if err != nil {
+		return batchResult{err: err}
+	}
+
+	converted, err := entities.NewConvertedTransaction(transaction, currency, rate, uc.rateFinder.RateWindow(), uc.rateFinder.Rounding())
+	if err != nil {
+		return batchResult{err: err}
+	}
+
+	record, err := entities.NewConversionRecord(converted, &batchID, uc.clock)
+	return batchResult{record: record, err: err}
+}
+
+// flush saves buffered records and the batch's progress counters
+func (uc *BatchConversionUseCase) flush(batch *entities.ConversionBatch, pending *[]entities.ConversionRecord) error {
+	if err := uc.recordRepo.SaveAll(*pending); err != nil {
+		return fmt.Errorf("failed to save conversion records: %w", err)
+	}
+	*pending = (*pending)[:0]
+
+	if err := uc.batchRepo.Update(batch); err != nil {
+		slog.Warn("Failed to update conversion batch progress", "batch_id", batch.ID.String(), "error", err.Error())
+	}
+	return nil
+}
+
+// finish marks the batch completed, or failed with err
+func (uc *BatchConversionUseCase) finish(batch *entities.ConversionBatch, err error) {
+	completedAt := uc.clock.Now().UTC()
+	batch.CompletedAt = &completedAt
+	batch.Status = entities.BatchStatusCompleted
+	if err != nil {
+		batch.Status = entities.BatchStatusFailed
+		batch.Error = err.Error()
+	}
+
+	if updateErr := uc.batchRepo.Update(batch); updateErr != nil {
+		slog.Warn("Failed to record conversion batch result", "batch_id", batch.ID.String(), "error", updateErr.Error())
+	}
+}
+
+// rateMemo looks up each distinct source currency and transaction date once, even when workers ask concurrently
+type rateMemo struct {
+	finder   ExchangeRateFinder
+	currency entities.CurrencyCode
+
+	mu      sync.Mutex
+	lookups map[rateMemoKey]*rateLookup
+}
+
+// rateMemoKey identifies a lookup by the currency converted from and the transaction date
+type rateMemoKey struct {
+	from entities.CurrencyCode
+	date int64
+}
+
+// rateLookup is a single memoized rate lookup
+type rateLookup struct {
+	once sync.Once
+	rate *entities.ExchangeRate
+	err  error
+}
+
+func newRateMemo(finder ExchangeRateFinder, currency entities.CurrencyCode) *rateMemo {
+	return &rateMemo{
+		finder:   finder,
+		currency: currency,
+		lookups:  make(map[rateMemoKey]*rateLookup),
+	}
+}
+
+// get returns the rate from the currency on date, fetching it under ctx on first use
+func (m *rateMemo) get(ctx context.Context, from entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	key := rateMemoKey{from: from, date: date.UnixNano()}
+	m.mu.Lock()
+	lookup, ok := m.lookups[key]
+	if !ok {
+		lookup = &rateLookup{}
+		m.lookups[key] = lookup
+	}
+	m.mu.Unlock()
+
+	lookup.once.Do(func() {
+		lookup.rate, lookup.err = m.finder.FindConversionRate(ctx, from, m.currency, date)
+	})
+	return lookup.rate, lookup.err
+}
@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+)
+
+// healthCheckTimeout bounds each dependency ping so /health stays fast
+const healthCheckTimeout = 2 * time.Second
+
+// serviceName identifies the service in health reports
+const serviceName = "purchase-transaction-api"
+
+// Pinger is a dependency that can report whether it is reachable
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// HealthDependency names a dependency checked by the health report
+type HealthDependency struct {
+	Name   string
+	Pinger Pinger
+}
+
+// CircuitBreakerState reports the state of a circuit breaker around an external service
+// Implemented by external.CircuitBreaker
+type CircuitBreakerState interface {
+	State() string
+	ConsecutiveFailures() int
+	RetryAt() *time.Time
+}
+
+// CheckHealthUseCase builds the service health report
+type CheckHealthUseCase struct {
+	version      string
+	startedAt    time.Time
+	dependencies []HealthDependency
+	breakers     map[string]CircuitBreakerState
+	clock        clock.Clock
+}
+
+// NewCheckHealthUseCase creates a new instance of CheckHealthUseCase
+func NewCheckHealthUseCase(version string, startedAt time.Time, dependencies ...HealthDependency) *CheckHealthUseCase {
+	return &CheckHealthUseCase{
+		version:      version,
+		startedAt:    startedAt,
+		dependencies: dependencies,
+		clock:        clock.System(),
+	}
+}
+
+// WithClock sets the clock reports are dated and uptime is measured by
+func (uc *CheckHealthUseCase) WithClock(clk clock.Clock) *CheckHealthUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// WithCircuitBreaker includes the state of a circuit breaker in the report under name
+func (uc *CheckHealthUseCase) WithCircuitBreaker(name string, breaker CircuitBreakerState) *CheckHealthUseCase {
+	if uc.breakers == nil {
+		uc.breakers = make(map[string]CircuitBreakerState)
+	}
+	uc.breakers[name] = breaker
+	return uc
+}
+
+// Execute pings every dependency; the service is unhealthy if any of them is down
+func (uc *CheckHealthUseCase) Execute(ctx context.Context) *dto.HealthResponse {
+	now := uc.clock.Now().UTC()
+	response := &dto.HealthResponse{
+		Status:        dto.HealthStatusHealthy,
+		Service:       serviceName,
+		Version:       uc.version,
+		Timestamp:     now,
+		UptimeSeconds: int64(now.Sub(uc.startedAt).Seconds()),
+		Dependencies:  make(map[string]dto.DependencyHealth, len(uc.dependencies)),
+	}
+
+	for _, dependency := range uc.dependencies {
+		health := uc.check(ctx, dependency.Pinger)
+		if health.Status != dto.DependencyStatusUp {
+			response.Status = dto.HealthStatusUnhealthy
+		}
+		response.Dependencies[dependency.Name] = health
+	}
+
+	if len(uc.breakers) > 0 {
+		response.CircuitBreakers = make(map[string]dto.CircuitBreakerHealth, len(uc.breakers))
+		for name, breaker := range uc.breakers {
+			response.CircuitBreakers[name] = dto.CircuitBreakerHealth{
+				State:               breaker.State(),
+				ConsecutiveFailures: breaker.ConsecutiveFailures(),
+				RetryAt:             breaker.RetryAt(),
+			}
+		}
+	}
+
+	return response
+}
+
+// check pings a single dependency and measures the round trip
+func (uc *CheckHealthUseCase) check(ctx context.Context, pinger Pinger) dto.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := uc.clock.Now()
+	err := pinger.Ping(ctx)
+	health := dto.DependencyHealth{
+		Status:    dto.DependencyStatusUp,
+		LatencyMs: float64(uc.clock.Now().Sub(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		health.Status = dto.DependencyStatusDown
+		health.Error = err.Error()
+	}
+
+	return health
+}
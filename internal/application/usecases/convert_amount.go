@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+)
+
+// QuoteResolver looks up the rate locked by a quote
+// Implemented by ConvertTransactionUseCase
+type QuoteResolver interface {
+	ResolveQuote(quoteID uuid.UUID, targetCurrency entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error)
+}
+
+// ConvertAmountUseCase handles conversion of USD amounts that are not tied to a stored transaction
+type ConvertAmountUseCase struct {
+	rateFinder    ExchangeRateFinder
+	quoteResolver QuoteResolver
+	margins       *MarginPolicy
+	validator     *validator.Validate
+}
+
+// NewConvertAmountUseCase creates a new instance of ConvertAmountUseCase
+func NewConvertAmountUseCase(
+	rateFinder ExchangeRateFinder,
+	quoteResolver QuoteResolver,
+	margins *MarginPolicy,
+	validator *validator.Validate,
+) *ConvertAmountUseCase {
+	return &ConvertAmountUseCase{
+		rateFinder:    rateFinder,
+		quoteResolver: quoteResolver,
+		margins:       margins,
+		validator:     validator,
+	}
+}
+
+// Execute converts the amount using the same rate lookback window as transaction conversions
+func (uc *ConvertAmountUseCase) Execute(ctx context.Context, request *dto.ConvertAmountRequest) (*dto.ConvertAmountResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	if !uc.SupportsCurrency(request.TargetCurrency) {
+		return nil, errs.Newf(errs.ErrUnsupportedCurrency, "unsupported target currency: %s", request.TargetCurrency)
+	}
+
+	var exchangeRate *entities.ExchangeRate
+	var err error
+	if request.QuoteID != nil {
+		// A quote pins the exact rate the client was shown
+		exchangeRate, err = uc.quoteResolver.ResolveQuote(*request.QuoteID, request.TargetCurrency, request.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve quote: %w", err)
+		}
+	} else {
+		exchangeRate, err = uc.rateFinder.FindExchangeRate(ctx, request.TargetCurrency, request.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find exchange rate: %w", err)
+		}
+	}
+
+	window := uc.rateFinder.RateWindow()
+	if !exchangeRate.IsWithinDateRange(request.Date, window) {
+		return nil, errs.Newf(errs.ErrRateUnavailable, "failed to find exchange rate: exchange rate date %v is not within %s of %v",
+			exchangeRate.EffectiveDate, window, request.Date)
+	}
+
+	// Apply the configured margin on top of the raw rate
+	marginBps := uc.margins.For(request.APIKey)
+	pricedRate := *exchangeRate
+	pricedRate.Rate = entities.ApplyMargin(exchangeRate.Rate, marginBps)
+
+	amount := entities.NewMoney(request.Amount)
+	convertedAmount, err := pricedRate.Convert(amount, uc.rateFinder.Rounding())
+	if err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: cannot convert %.2f USD: %w", amount.Dollars(), err)
+	}
+
+	return &dto.ConvertAmountResponse{
+		Amount:          amount.Dollars(),
+		Date:            request.Date,
+		TargetCurrency:  request.TargetCurrency,
+		RawExchangeRate: exchangeRate.Rate,
+		MarginBps:       marginBps,
+		ExchangeRate:    pricedRate.Rate,
+		ConvertedAmount: convertedAmount.Dollars(),
+		EffectiveDate:   exchangeRate.EffectiveDate,
+		QuoteID:         request.QuoteID,
+	}, nil
+}
+
+// SupportsCurrency reports whether amounts can be converted to the given currency, the same as transactions
+func (uc *ConvertAmountUseCase) SupportsCurrency(code entities.CurrencyCode) bool {
+	return uc.rateFinder.SupportsCurrency(code)
+}
+
+// SupportedCurrencies lists the currencies amounts can be converted to
+func (uc *ConvertAmountUseCase) SupportedCurrencies() []entities.CurrencyCode {
+	return supportedCurrencies(uc.SupportsCurrency)
+}
@@ -1,46 +1,186 @@
 package usecases
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
 )
 
+// interpolationWindowMonths bounds how far the surrounding rates may be from the transaction date
+const interpolationWindowMonths = 12
+
 // ConvertTransactionUseCase handles the business logic for currency conversion of transactions
 type ConvertTransactionUseCase struct {
 	transactionRepo  repositories.TransactionRepository
 	exchangeRateRepo repositories.ExchangeRateRepository
-	treasuryService  services.TreasuryService
+	quoteRepo        repositories.QuoteRepository
+	treasuryService  services.RateProvider
+	margins          *MarginPolicy
 	validator        *validator.Validate
+	publisher        services.EventPublisher
+	history          repositories.ConversionHistoryRepository
+	unitOfWork       repositories.UnitOfWork // Nil when fetched rates are cached as soon as they are found
+	window           entities.RateWindow
+	rounding         entities.RoundingMode
+	crossRates       bool
+	staleByDefault   bool                // Fall back to stale rates unless the request asks for strict
+	staleWindow      entities.RateWindow // How old a stale rate may be; zero when stale fallback is unavailable
+	clock            clock.Clock
 }
 
 // NewConvertTransactionUseCase creates a new instance of ConvertTransactionUseCase
 func NewConvertTransactionUseCase(
 	transactionRepo repositories.TransactionRepository,
 	exchangeRateRepo repositories.ExchangeRateRepository,
-	treasuryService services.TreasuryService,
+	quoteRepo repositories.QuoteRepository,
+	treasuryService services.RateProvider,
+	margins *MarginPolicy,
 	validator *validator.Validate,
 ) *ConvertTransactionUseCase {
 	return &ConvertTransactionUseCase{
 		transactionRepo:  transactionRepo,
 		exchangeRateRepo: exchangeRateRepo,
+		quoteRepo:        quoteRepo,
 		treasuryService:  treasuryService,
+		margins:          margins,
 		validator:        validator,
+		clock:            clock.System(),
 	}
 }
 
+// WithClock sets the clock that decides whether a quote has expired and dates conversions
+func (uc *ConvertTransactionUseCase) WithClock(clk clock.Clock) *ConvertTransactionUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// WithEventPublisher publishes a TransactionConvertedEvent for every conversion made by Execute
+func (uc *ConvertTransactionUseCase) WithEventPublisher(publisher services.EventPublisher) *ConvertTransactionUseCase {
+	uc.publisher = publisher
+	return uc
+}
+
+// WithConversionHistory stores every conversion made by Execute and ExecuteBatch in the transaction's history
+func (uc *ConvertTransactionUseCase) WithConversionHistory(history repositories.ConversionHistoryRepository) *ConvertTransactionUseCase {
+	uc.history = history
+	return uc
+}
+
+// WithUnitOfWork makes Execute cache the rates it fetches from the provider together with the conversion
+// it records, in one database transaction, so the history never holds a conversion whose rate was not cached
+// Without it fetched rates are cached as soon as they are found and the conversion is recorded on its own
+func (uc *ConvertTransactionUseCase) WithUnitOfWork(unitOfWork repositories.UnitOfWork) *ConvertTransactionUseCase {
+	uc.unitOfWork = unitOfWork
+	return uc
+}
+
+// WithRateWindow sets how long before a transaction a rate may take effect and still convert it
+// Without it the default 6-month window applies
+func (uc *ConvertTransactionUseCase) WithRateWindow(window entities.RateWindow) *ConvertTransactionUseCase {
+	uc.window = window
+	return uc
+}
+
+// WithRounding sets how converted amounts between two cents are rounded
+// Without it they are rounded half up
+func (uc *ConvertTransactionUseCase) WithRounding(rounding entities.RoundingMode) *ConvertTransactionUseCase {
+	uc.rounding = rounding
+	return uc
+}
+
+// WithCrossRates lets transactions recorded in currencies other than USD be converted through USD cross rates
+func (uc *ConvertTransactionUseCase) WithCrossRates(enabled bool) *ConvertTransactionUseCase {
+	uc.crossRates = enabled
+	return uc
+}
+
+// WithStaleFallback lets conversions with no rate within the lookback window use the nearest older rate
+// taking effect within window before the transaction date, flagged as stale
+// Requests opt in with the stale mode, or, when byDefault is set, by leaving the mode unset
+func (uc *ConvertTransactionUseCase) WithStaleFallback(byDefault bool, window entities.RateWindow) *ConvertTransactionUseCase {
+	uc.staleByDefault = byDefault
+	uc.staleWindow = window
+	return uc
+}
+
+// RateWindow returns the lookback window rates are searched in
+func (uc *ConvertTransactionUseCase) RateWindow() entities.RateWindow {
+	return uc.window
+}
+
+// Rounding returns how converted amounts are rounded to the cent
+func (uc *ConvertTransactionUseCase) Rounding() entities.RoundingMode {
+	return uc.rounding
+}
+
 // Execute converts a transaction to the specified target currency
-func (uc *ConvertTransactionUseCase) Execute(request *dto.ConvertTransactionRequest) (*dto.ConvertTransactionResponse, error) {
+func (uc *ConvertTransactionUseCase) Execute(ctx context.Context, request *dto.ConvertTransactionRequest) (*dto.ConvertTransactionResponse, error) {
+	// With a unit of work, rates fetched from the provider are held back and cached together with the conversion
+	var fetched *[]entities.ExchangeRate
+	if uc.unitOfWork != nil {
+		fetched = &[]entities.ExchangeRate{}
+	}
+
+	event, err := uc.convert(ctx, request, fetched)
+	if err != nil {
+		return nil, err
+	}
+
+	// Announce the conversion and answer with the same data
+	uc.publish(*event)
+	var conversions []entities.Conversion
+	conversion, err := entities.NewConversion(&event.Conversion, event.RawExchangeRate, event.MarginBps, event.QuoteID, uc.clock)
+	if err != nil {
+		slog.Warn("Failed to build conversion history record",
+			"error", err.Error(),
+			"transaction_id", event.Conversion.Transaction.ID.String(),
+		)
+	} else {
+		conversions = append(conversions, *conversion)
+	}
+	if fetched != nil {
+		uc.recordWithRates(*fetched, conversions)
+	} else {
+		uc.record(conversions...)
+	}
+
+	return dto.NewConvertTransactionResponseFromEvent(*event), nil
+}
+
+// Preview converts a transaction like Execute without recording the conversion: nothing is added to the history
+// and no event is published, so reads such as GET /transactions/:id?currency= can answer with it
+// Rates fetched from the provider are still cached, like the conversions of transaction listings
+func (uc *ConvertTransactionUseCase) Preview(ctx context.Context, request *dto.ConvertTransactionRequest) (*dto.ConvertTransactionResponse, error) {
+	event, err := uc.convert(ctx, request, nil)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewConvertTransactionResponseFromEvent(*event), nil
+}
+
+// convert resolves the rate, applies the caller's margin and converts the transaction, returning the event
+// announcing the conversion without publishing or recording it
+// With fetched, rates found at the provider are added to it for the caller to cache
+func (uc *ConvertTransactionUseCase) convert(
+	ctx context.Context,
+	request *dto.ConvertTransactionRequest,
+	fetched *[]entities.ExchangeRate,
+) (*entities.TransactionConvertedEvent, error) {
 	// Validate input request
 	if err := uc.validateRequest(request); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
 	}
 
 	// Get the original transaction
@@ -51,27 +191,309 @@ func (uc *ConvertTransactionUseCase) Execute(request *dto.ConvertTransactionRequ
 
 	// Validate business rules for conversion
 	if err := uc.validateConversionRules(transaction, request.TargetCurrency); err != nil {
-		return nil, fmt.Errorf("conversion validation failed: %w", err)
+		return nil, errs.Newf(errs.ErrValidation, "conversion validation failed: %w", err)
 	}
 
-	// Find suitable exchange rate (implements 6-month rule)
-	exchangeRate, err := uc.findExchangeRate(request.TargetCurrency, transaction.Date)
+	// Resolve the raw rate: a quote pins the exact rate the client was shown
+	exchangeRate, fallback, err := uc.resolveExchangeRate(ctx, request, transaction, fetched)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find exchange rate: %w", err)
+		return nil, err
 	}
 
-	// Create converted transaction with the found exchange rate
-	convertedTransaction, err := uc.createConvertedTransaction(transaction, request.TargetCurrency, exchangeRate)
+	// Apply the configured margin on top of the raw rate
+	marginBps := uc.margins.For(request.APIKey)
+	pricedRate := *exchangeRate
+	pricedRate.Rate = entities.ApplyMargin(exchangeRate.Rate, marginBps)
+
+	// Create converted transaction with the priced exchange rate; a stale rate is only valid within the stale window
+	window := uc.window
+	if fallback.stale {
+		window = uc.staleWindow
+	}
+	convertedTransaction, err := uc.createConvertedTransaction(transaction, request.TargetCurrency, &pricedRate, window)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create converted transaction: %w", err)
 	}
+	if fallback.rateBounds != nil {
+		convertedTransaction.Interpolated = true
+		convertedTransaction.RateBounds = fallback.rateBounds
+	}
+	convertedTransaction.RateStale = fallback.stale
 
-	// Convert to response DTO
-	response := dto.NewConvertTransactionResponse(convertedTransaction)
+	return &entities.TransactionConvertedEvent{
+		EventMeta:       entities.NewEventMeta(uc.clock),
+		Conversion:      *convertedTransaction,
+		RawExchangeRate: exchangeRate.Rate,
+		MarginBps:       marginBps,
+		QuoteID:         request.QuoteID,
+	}, nil
+}
+
+// publish hands the event to the publisher, if any; a failure is only logged since the conversion succeeded
+func (uc *ConvertTransactionUseCase) publish(event entities.TransactionConvertedEvent) {
+	if uc.publisher == nil {
+		return
+	}
+	if err := uc.publisher.Publish(context.Background(), event); err != nil {
+		slog.Warn("Failed to publish transaction converted event",
+			"error", err.Error(),
+			"transaction_id", event.Conversion.Transaction.ID.String(),
+		)
+	}
+}
+
+// record appends conversions to the history, if any; a failure is only logged since the conversions succeeded
+func (uc *ConvertTransactionUseCase) record(conversions ...entities.Conversion) {
+	if uc.history == nil || len(conversions) == 0 {
+		return
+	}
+	if err := uc.history.SaveAll(conversions); err != nil {
+		slog.Warn("Failed to record conversion history",
+			"error", err.Error(),
+			"conversions", len(conversions),
+		)
+	}
+}
+
+// recordWithRates caches the fetched rates and appends the conversions to the history in one unit of work
+// A failure stores neither and is only logged, since the conversions succeeded
+func (uc *ConvertTransactionUseCase) recordWithRates(rates []entities.ExchangeRate, conversions []entities.Conversion) {
+	if uc.history == nil {
+		conversions = nil
+	}
+	if len(rates) == 0 && len(conversions) == 0 {
+		return
+	}
+
+	err := uc.unitOfWork.Do(func(repos repositories.Repositories) error {
+		if len(rates) > 0 {
+			if err := repos.ExchangeRates.SaveAll(rates); err != nil {
+				return fmt.Errorf("failed to cache exchange rates: %w", err)
+			}
+		}
+		if len(conversions) > 0 {
+			if err := repos.ConversionHistory.SaveAll(conversions); err != nil {
+				return fmt.Errorf("failed to record conversion history: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Warn("Failed to record conversion with its exchange rates",
+			"error", err.Error(),
+			"rates", len(rates),
+			"conversions", len(conversions),
+		)
+	}
+}
+
+// ExecuteBatch converts several transactions to one currency
+// Each distinct purchase date's rate is looked up once, oldest first, so a rate fetched from the Treasury
+// is cached before later dates look for it; per-transaction failures are reported in their item
+func (uc *ConvertTransactionUseCase) ExecuteBatch(ctx context.Context, request *dto.ConvertTransactionsBatchRequest) (*dto.ConvertTransactionsBatchResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+	if !uc.SupportsCurrency(request.TargetCurrency) {
+		return nil, errs.Newf(errs.ErrUnsupportedCurrency, "unsupported target currency: %s", request.TargetCurrency)
+	}
+
+	transactions := make([]*entities.Transaction, len(request.TransactionIDs))
+	for i, transactionID := range request.TransactionIDs {
+		transaction, err := uc.transactionRepo.GetByID(transactionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction %s: %w", transactionID, err)
+		}
+		transactions[i] = transaction
+	}
+
+	// Warm the memo in date order before converting in request order; transactions that cannot be converted need no rate
+	rates := newRateMemo(uc, request.TargetCurrency)
+	warm := make([]*entities.Transaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		if transaction != nil && uc.validateConversionRules(transaction, request.TargetCurrency) == nil {
+			warm = append(warm, transaction)
+		}
+	}
+	sort.SliceStable(warm, func(i, j int) bool { return warm[i].Date.Before(warm[j].Date) })
+	for _, transaction := range warm {
+		_, _ = rates.get(ctx, transaction.SourceCurrency(), transaction.Date)
+	}
+
+	marginBps := uc.margins.For(request.APIKey)
+	response := &dto.ConvertTransactionsBatchResponse{
+		TargetCurrency: request.TargetCurrency,
+		Data:           make([]dto.ConvertTransactionsBatchItem, len(transactions)),
+	}
+	conversions := make([]entities.Conversion, 0, len(transactions))
+	for i, transaction := range transactions {
+		item := &response.Data[i]
+		item.TransactionID = request.TransactionIDs[i]
+
+		converted, conversion, err := uc.convertWithRate(ctx, transaction, request.TargetCurrency, rates, marginBps)
+		if err != nil {
+			item.Error = err.Error()
+			response.Failed++
+			continue
+		}
+		item.ConvertTransactionResponse = converted
+		conversions = append(conversions, *conversion)
+		response.Converted++
+	}
+	uc.record(conversions...)
 
 	return response, nil
 }
 
+// convertWithRate converts one batch transaction with its memoized rate and the caller's margin
+// The transaction must pass the same conversion rules as in Execute
+// It also returns the conversion to keep in the transaction's history
+func (uc *ConvertTransactionUseCase) convertWithRate(
+	ctx context.Context,
+	transaction *entities.Transaction,
+	targetCurrency entities.CurrencyCode,
+	rates *rateMemo,
+	marginBps int,
+) (*dto.ConvertTransactionResponse, *entities.Conversion, error) {
+	if transaction == nil {
+		return nil, nil, errs.Newf(errs.ErrNotFound, "transaction not found")
+	}
+	if err := uc.validateConversionRules(transaction, targetCurrency); err != nil {
+		return nil, nil, errs.Newf(errs.ErrValidation, "conversion validation failed: %w", err)
+	}
+
+	exchangeRate, err := rates.get(ctx, transaction.SourceCurrency(), transaction.Date)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+
+	pricedRate := *exchangeRate
+	pricedRate.Rate = entities.ApplyMargin(exchangeRate.Rate, marginBps)
+
+	convertedTransaction, err := uc.createConvertedTransaction(transaction, targetCurrency, &pricedRate, uc.window)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create converted transaction: %w", err)
+	}
+	conversion, err := entities.NewConversion(convertedTransaction, exchangeRate.Rate, marginBps, nil, uc.clock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := dto.NewConvertTransactionResponse(convertedTransaction)
+	response.RawExchangeRate = exchangeRate.Rate
+	response.MarginBps = marginBps
+	return response, conversion, nil
+}
+
+// rateFallback describes how a rate was found when none was within the lookback window
+type rateFallback struct {
+	rateBounds []time.Time // Effective dates of the rates interpolated between; nil when not interpolated
+	stale      bool        // The nearest older rate was used
+}
+
+// resolveExchangeRate finds the raw rate from a quote, the lookback window lookup, interpolation or a stale rate
+// Transactions in other currencies use a cross rate, which quotes and the fallbacks do not provide
+// With fetched, rates found at the provider are added to it for the caller to cache
+func (uc *ConvertTransactionUseCase) resolveExchangeRate(
+	ctx context.Context,
+	request *dto.ConvertTransactionRequest,
+	transaction *entities.Transaction,
+	fetched *[]entities.ExchangeRate,
+) (*entities.ExchangeRate, rateFallback, error) {
+	date := transaction.Date
+	if source := transaction.SourceCurrency(); source != entities.USD {
+		if request.QuoteID != nil {
+			return nil, rateFallback{}, errs.Newf(errs.ErrValidation, "validation failed: quotes only apply to USD transactions, this one is in %s", source)
+		}
+		exchangeRate, err := uc.findConversionRate(ctx, source, request.TargetCurrency, date, fetched)
+		if err != nil {
+			return nil, rateFallback{}, fmt.Errorf("failed to find exchange rate: %w", err)
+		}
+		return exchangeRate, rateFallback{}, nil
+	}
+
+	if request.QuoteID != nil {
+		exchangeRate, err := uc.ResolveQuote(*request.QuoteID, request.TargetCurrency, date)
+		if err != nil {
+			return nil, rateFallback{}, fmt.Errorf("failed to resolve quote: %w", err)
+		}
+		return exchangeRate, rateFallback{}, nil
+	}
+
+	// Find suitable exchange rate (implements the lookback window rule)
+	exchangeRate, err := uc.findExchangeRate(ctx, request.TargetCurrency, date, fetched)
+	if err == nil {
+		return exchangeRate, rateFallback{}, nil
+	}
+
+	// Only a missing rate falls back; provider outages and other failures are returned as they are
+	if !errors.Is(err, errs.ErrRateUnavailable) {
+		return nil, rateFallback{}, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+
+	switch uc.conversionMode(request) {
+	case dto.ConversionModeInterpolate:
+		// Fall back to interpolating between surrounding rates when requested
+		exchangeRate, rateBounds, err := uc.interpolateExchangeRate(request.TargetCurrency, date, err)
+		if err != nil {
+			return nil, rateFallback{}, fmt.Errorf("failed to find exchange rate: %w", err)
+		}
+		return exchangeRate, rateFallback{rateBounds: rateBounds}, nil
+	case dto.ConversionModeStale:
+		// Fall back to the nearest older rate, flagged so the client knows it predates the lookback window
+		exchangeRate, err := uc.findStaleExchangeRate(ctx, request.TargetCurrency, date, err, fetched)
+		if err != nil {
+			return nil, rateFallback{}, fmt.Errorf("failed to find exchange rate: %w", err)
+		}
+		return exchangeRate, rateFallback{stale: true}, nil
+	default:
+		return nil, rateFallback{}, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+}
+
+// conversionMode returns the mode of the request, stale when it has none and stale rates are the default
+// Stale rates are refused without a stale window
+func (uc *ConvertTransactionUseCase) conversionMode(request *dto.ConvertTransactionRequest) string {
+	mode := request.Mode
+	if mode == "" && uc.staleByDefault {
+		mode = dto.ConversionModeStale
+	}
+	if mode == dto.ConversionModeStale && uc.staleWindow == (entities.RateWindow{}) {
+		return dto.ConversionModeStrict
+	}
+	return mode
+}
+
+// SupportsCurrency reports whether transactions can be converted to the given currency
+func (uc *ConvertTransactionUseCase) SupportsCurrency(code entities.CurrencyCode) bool {
+	return code != entities.USD && uc.treasuryService.SupportsCurrency(code)
+}
+
+// SupportsSourceCurrency reports whether transactions recorded in the given currency can be converted
+// USD always can; other currencies need cross rates enabled and a published USD rate
+func (uc *ConvertTransactionUseCase) SupportsSourceCurrency(code entities.CurrencyCode) bool {
+	return code == entities.USD || (uc.crossRates && uc.treasuryService.SupportsCurrency(code))
+}
+
+// SupportedCurrencies lists the currencies transactions can be converted to
+func (uc *ConvertTransactionUseCase) SupportedCurrencies() []entities.CurrencyCode {
+	return supportedCurrencies(uc.SupportsCurrency)
+}
+
+// supportedCurrencies lists the known currencies supports accepts, in the order they are known
+func supportedCurrencies(supports func(code entities.CurrencyCode) bool) []entities.CurrencyCode {
+	supported := make([]entities.CurrencyCode, 0)
+	for _, code := range entities.KnownCurrencies() {
+		if supports(code) {
+			supported = append(supported, code)
+		}
+	}
+	return supported
+}
+
 // validateRequest validates the input request using struct tags
 func (uc *ConvertTransactionUseCase) validateRequest(request *dto.ConvertTransactionRequest) error {
 	if request == nil {
@@ -93,7 +515,7 @@ func (uc *ConvertTransactionUseCase) getTransaction(transactionID uuid.UUID) (*e
 	}
 
 	if transaction == nil {
-		return nil, fmt.Errorf("transaction not found with id: %s", transactionID.String())
+		return nil, errs.Newf(errs.ErrNotFound, "transaction not found with id: %s", transactionID.String())
 	}
 
 	return transaction, nil
@@ -103,12 +525,17 @@ func (uc *ConvertTransactionUseCase) getTransaction(transactionID uuid.UUID) (*e
 func (uc *ConvertTransactionUseCase) validateConversionRules(transaction *entities.Transaction, targetCurrency entities.CurrencyCode) error {
 	// Validate target currency
 	if !targetCurrency.IsValid() {
-		return fmt.Errorf("invalid target currency: %s", targetCurrency)
+		return errs.Newf(errs.ErrValidation, "invalid target currency: %s", targetCurrency)
+	}
+
+	source := transaction.SourceCurrency()
+	if !uc.SupportsSourceCurrency(source) {
+		return fmt.Errorf("cannot convert %s transactions: cross-rate conversion is disabled or %s is not published", source, source)
 	}
 
-	// Check if conversion from USD to same currency (should be USD originally)
-	if targetCurrency == entities.USD {
-		return fmt.Errorf("cannot convert USD transaction to USD")
+	// A conversion must change the currency
+	if targetCurrency == source {
+		return fmt.Errorf("cannot convert %s transaction to %s", source, targetCurrency)
 	}
 
 	// Additional business rules can be added here
@@ -117,11 +544,21 @@ func (uc *ConvertTransactionUseCase) validateConversionRules(transaction *entiti
 	return nil
 }
 
-// findExchangeRate finds a suitable exchange rate implementing the 6-month rule
-// First tries local repository, then falls back to Treasury API
-func (uc *ConvertTransactionUseCase) findExchangeRate(targetCurrency entities.CurrencyCode, transactionDate time.Time) (*entities.ExchangeRate, error) {
+// FindExchangeRate finds a suitable exchange rate within the lookback window
+// First tries local repository, then falls back to Treasury API, abandoning the call once ctx is done
+func (uc *ConvertTransactionUseCase) FindExchangeRate(ctx context.Context, targetCurrency entities.CurrencyCode, transactionDate time.Time) (*entities.ExchangeRate, error) {
+	return uc.findExchangeRate(ctx, targetCurrency, transactionDate, nil)
+}
+
+// findExchangeRate finds a rate like FindExchangeRate; with fetched, a rate to cache is added to it instead of stored
+func (uc *ConvertTransactionUseCase) findExchangeRate(
+	ctx context.Context,
+	targetCurrency entities.CurrencyCode,
+	transactionDate time.Time,
+	fetched *[]entities.ExchangeRate,
+) (*entities.ExchangeRate, error) {
 	// 1. First, try to find exchange rate in local repository
-	exchangeRate, err := uc.exchangeRateRepo.FindRateForConversion(entities.USD, targetCurrency, transactionDate)
+	exchangeRate, err := uc.exchangeRateRepo.FindRateForConversion(entities.USD, targetCurrency, transactionDate, uc.window)
 	if err != nil {
 		return nil, fmt.Errorf("error searching local exchange rates: %w", err)
 	}
@@ -132,12 +569,19 @@ func (uc *ConvertTransactionUseCase) findExchangeRate(targetCurrency entities.Cu
 	}
 
 	// 3. If not found locally, fetch from Treasury API
-	treasuryRate, err := uc.treasuryService.FetchExchangeRate(entities.USD, targetCurrency, transactionDate)
+	treasuryRate, err := uc.treasuryService.FetchExchangeRate(ctx, entities.USD, targetCurrency, transactionDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch exchange rate from Treasury API: %w", err)
 	}
 
-	// 4. Save the fetched rate to local repository for future use (caching)
+	// 4. Save the fetched rate to local repository for future use (caching); fallback provider rates are not stored
+	if !treasuryRate.IsAuthoritative() {
+		return treasuryRate, nil
+	}
+	if fetched != nil {
+		*fetched = append(*fetched, *treasuryRate)
+		return treasuryRate, nil
+	}
 	if err := uc.exchangeRateRepo.Save(treasuryRate); err != nil {
 		// Log error but don't fail the conversion - we still have the rate
 		slog.Warn("Failed to cache exchange rate from Treasury API",
@@ -151,14 +595,175 @@ func (uc *ConvertTransactionUseCase) findExchangeRate(targetCurrency entities.Cu
 	return treasuryRate, nil
 }
 
+// PrefetchRates fetches every Treasury rate to currency published between startDate and endDate in one call
+// and stores those not stored yet, so lookups for dates in the range are answered locally
+// Returns the number of rates stored
+func (uc *ConvertTransactionUseCase) PrefetchRates(ctx context.Context, currency entities.CurrencyCode, startDate, endDate time.Time) (int, error) {
+	fetched, err := uc.treasuryService.FetchExchangeRates(ctx, entities.USD, currency, startDate, endDate)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch exchange rates from Treasury API: %w", err)
+	}
+
+	missing := make([]entities.ExchangeRate, 0, len(fetched))
+	for _, rate := range fetched {
+		stored, err := uc.exchangeRateRepo.FindRateForConversion(entities.USD, currency, rate.EffectiveDate, uc.window)
+		if err != nil {
+			return 0, fmt.Errorf("error searching local exchange rates: %w", err)
+		}
+		if stored == nil || !stored.EffectiveDate.Equal(rate.EffectiveDate) {
+			missing = append(missing, rate)
+		}
+	}
+	if len(missing) == 0 {
+		return 0, nil
+	}
+
+	if err := uc.exchangeRateRepo.SaveAll(missing); err != nil {
+		return 0, fmt.Errorf("failed to store exchange rates: %w", err)
+	}
+	return len(missing), nil
+}
+
+// FindConversionRate finds the rate converting from one currency to another within the lookback window
+// USD sources use the published rate; others are crossed through USD from the two published rates,
+// e.g. EUR→BRL as USD/BRL divided by USD/EUR
+func (uc *ConvertTransactionUseCase) FindConversionRate(ctx context.Context, from, to entities.CurrencyCode, transactionDate time.Time) (*entities.ExchangeRate, error) {
+	return uc.findConversionRate(ctx, from, to, transactionDate, nil)
+}
+
+// findConversionRate finds a rate like FindConversionRate, adding the rates to cache to fetched when given
+func (uc *ConvertTransactionUseCase) findConversionRate(
+	ctx context.Context,
+	from, to entities.CurrencyCode,
+	transactionDate time.Time,
+	fetched *[]entities.ExchangeRate,
+) (*entities.ExchangeRate, error) {
+	if from == entities.USD {
+		return uc.findExchangeRate(ctx, to, transactionDate, fetched)
+	}
+	if !uc.SupportsSourceCurrency(from) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: conversions from %s are not supported", from)
+	}
+
+	usdFrom, err := uc.findExchangeRate(ctx, from, transactionDate, fetched)
+	if err != nil {
+		return nil, err
+	}
+	var usdTo *entities.ExchangeRate
+	if to != entities.USD {
+		if usdTo, err = uc.findExchangeRate(ctx, to, transactionDate, fetched); err != nil {
+			return nil, err
+		}
+	}
+
+	return entities.CrossRate(from, to, usdFrom, usdTo)
+}
+
+// ResolveQuote returns the rate locked by a quote after checking it is unexpired and applies to the conversion
+func (uc *ConvertTransactionUseCase) ResolveQuote(quoteID uuid.UUID, targetCurrency entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	quote, err := uc.quoteRepo.GetByID(quoteID)
+	if err != nil {
+		return nil, err
+	}
+	if quote == nil {
+		return nil, errs.Newf(errs.ErrNotFound, "quote not found with id: %s", quoteID)
+	}
+
+	if quote.IsExpired(uc.clock.Now()) {
+		return nil, errs.Newf(errs.ErrExpired, "quote %s expired at %s", quoteID, quote.ExpiresAt.Format(time.RFC3339))
+	}
+
+	if quote.ToCurrency != targetCurrency {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: quote %s is for %s, not %s", quoteID, quote.ToCurrency, targetCurrency)
+	}
+
+	exchangeRate := quote.ExchangeRate()
+	if !exchangeRate.IsWithinDateRange(date, uc.window) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: quote %s rate effective %s does not apply to date %s",
+			quoteID, quote.EffectiveDate.Format("2006-01-02"), date.Format("2006-01-02"))
+	}
+
+	return exchangeRate, nil
+}
+
+// interpolateExchangeRate builds a rate for the date from the nearest cached rates on each side
+// The strict lookup error is returned unchanged when no surrounding pair exists
+func (uc *ConvertTransactionUseCase) interpolateExchangeRate(
+	targetCurrency entities.CurrencyCode,
+	date time.Time,
+	strictErr error,
+) (*entities.ExchangeRate, []time.Time, error) {
+	before, after, err := uc.exchangeRateRepo.FindSurroundingRates(entities.USD, targetCurrency, date, interpolationWindowMonths)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error searching surrounding exchange rates: %w", err)
+	}
+	if before == nil || after == nil {
+		return nil, nil, strictErr
+	}
+
+	exchangeRate, err := entities.InterpolateExchangeRate(before, after, date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return exchangeRate, []time.Time{before.EffectiveDate, after.EffectiveDate}, nil
+}
+
+// findStaleExchangeRate returns the nearest rate taking effect within the stale window before the date,
+// stored locally or else published by the rate provider
+// The strict lookup error is returned unchanged when there is none; a failed provider call is returned instead
+// With fetched, a published rate to cache is added to it instead of stored
+func (uc *ConvertTransactionUseCase) findStaleExchangeRate(
+	ctx context.Context,
+	targetCurrency entities.CurrencyCode,
+	date time.Time,
+	strictErr error,
+	fetched *[]entities.ExchangeRate,
+) (*entities.ExchangeRate, error) {
+	before, _, err := uc.exchangeRateRepo.FindSurroundingRates(entities.USD, targetCurrency, date, uc.staleWindow.Months())
+	if err != nil {
+		return nil, fmt.Errorf("error searching older exchange rates: %w", err)
+	}
+	if before != nil {
+		return before, nil
+	}
+
+	published, err := uc.treasuryService.FetchExchangeRates(ctx, entities.USD, targetCurrency, uc.staleWindow.Start(date), date)
+	if err != nil && !errors.Is(err, errs.ErrRateUnavailable) {
+		return nil, fmt.Errorf("failed to fetch older exchange rates: %w", err)
+	}
+	if len(published) == 0 {
+		return nil, strictErr
+	}
+	staleRate := &published[0]
+	if !staleRate.IsAuthoritative() {
+		return staleRate, nil
+	}
+	if fetched != nil {
+		*fetched = append(*fetched, *staleRate)
+		return staleRate, nil
+	}
+
+	if err := uc.exchangeRateRepo.Save(staleRate); err != nil {
+		slog.Warn("Failed to cache stale exchange rate",
+			"error", err.Error(),
+			"to_currency", string(targetCurrency),
+			"effective_date", staleRate.EffectiveDate.Format("2006-01-02"),
+		)
+	}
+	return staleRate, nil
+}
+
 // createConvertedTransaction creates a ConvertedTransaction entity with validation
+// The rate must have taken effect within window before the transaction date
 func (uc *ConvertTransactionUseCase) createConvertedTransaction(
 	transaction *entities.Transaction,
 	targetCurrency entities.CurrencyCode,
 	exchangeRate *entities.ExchangeRate,
+	window entities.RateWindow,
 ) (*entities.ConvertedTransaction, error) {
 	// Use the entity's factory method which includes validation
-	convertedTransaction, err := entities.NewConvertedTransaction(*transaction, targetCurrency, exchangeRate)
+	convertedTransaction, err := entities.NewConvertedTransaction(*transaction, targetCurrency, exchangeRate, window, uc.rounding)
 	if err != nil {
 		return nil, err
 	}
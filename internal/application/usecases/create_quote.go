@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// CreateQuoteUseCase handles the business logic for locking a rate quote
+type CreateQuoteUseCase struct {
+	quoteRepo  repositories.QuoteRepository
+	rateFinder ExchangeRateFinder
+	ttl        time.Duration
+	validator  *validator.Validate
+	clock      clock.Clock
+}
+
+// NewCreateQuoteUseCase creates a new instance of CreateQuoteUseCase
+func NewCreateQuoteUseCase(
+	quoteRepo repositories.QuoteRepository,
+	rateFinder ExchangeRateFinder,
+	ttl time.Duration,
+	validator *validator.Validate,
+) *CreateQuoteUseCase {
+	return &CreateQuoteUseCase{
+		quoteRepo:  quoteRepo,
+		rateFinder: rateFinder,
+		ttl:        ttl,
+		validator:  validator,
+		clock:      clock.System(),
+	}
+}
+
+// WithClock sets the clock quotes are dated and expired by
+func (uc *CreateQuoteUseCase) WithClock(clk clock.Clock) *CreateQuoteUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Execute resolves the rate for the requested date and locks it until the quote expires
+func (uc *CreateQuoteUseCase) Execute(ctx context.Context, request *dto.CreateQuoteRequest) (*dto.QuoteResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+
+	// Quote for the current date unless a date was given
+	if request.Date.IsZero() {
+		request.Date = uc.clock.Now().UTC()
+	}
+
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	if request.TargetCurrency == entities.USD || !uc.rateFinder.SupportsCurrency(request.TargetCurrency) {
+		return nil, errs.Newf(errs.ErrUnsupportedCurrency, "unsupported target currency: %s", request.TargetCurrency)
+	}
+
+	exchangeRate, err := uc.rateFinder.FindExchangeRate(ctx, request.TargetCurrency, request.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+
+	quote, err := entities.NewRateQuote(exchangeRate, request.Date, uc.ttl, uc.clock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quote: %w", err)
+	}
+
+	if err := uc.quoteRepo.Save(quote); err != nil {
+		return nil, fmt.Errorf("failed to save quote: %w", err)
+	}
+
+	// Prune expired quotes so the table doesn't grow without bound
+	if _, err := uc.quoteRepo.DeleteExpired(uc.clock.Now()); err != nil {
+		slog.Warn("Failed to delete expired quotes", "error", err.Error())
+	}
+
+	return dto.NewQuoteResponse(quote), nil
+}
@@ -1,18 +1,34 @@
 package usecases
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
 )
 
+// idempotencyLockTimeout is how long a pending Idempotency-Key blocks retries before it counts as abandoned,
+// e.g. after the instance handling the first attempt crashed
+const idempotencyLockTimeout = time.Minute
+
 // CreateTransactionUseCase handles the business logic for creating transactions
 type CreateTransactionUseCase struct {
-	transactionRepo repositories.TransactionRepository
-	validator       *validator.Validate
+	transactionRepo    repositories.TransactionRepository
+	categoryRepo       repositories.CategoryRepository
+	idempotencyKeyRepo repositories.IdempotencyKeyRepository
+	idempotencyWindow  time.Duration
+	rateFinder         ExchangeRateFinder
+	validator          *validator.Validate
+	clock              clock.Clock
 }
 
 // NewCreateTransactionUseCase creates a new instance of CreateTransactionUseCase
@@ -23,22 +39,161 @@ func NewCreateTransactionUseCase(
 	return &CreateTransactionUseCase{
 		transactionRepo: transactionRepo,
 		validator:       validator,
+		clock:           clock.System(),
+	}
+}
+
+// WithClock sets the clock that dates Idempotency-Keys and decides when they expire or count as abandoned
+func (uc *CreateTransactionUseCase) WithClock(clk clock.Clock) *CreateTransactionUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// WithCategories requires the category of a new transaction to exist, storing it with the category's spelling
+func (uc *CreateTransactionUseCase) WithCategories(repo repositories.CategoryRepository) *CreateTransactionUseCase {
+	uc.categoryRepo = repo
+	return uc
+}
+
+// WithCurrencies accepts transactions paid in any currency rateFinder can convert from
+// Without it every transaction must be in USD
+func (uc *CreateTransactionUseCase) WithCurrencies(rateFinder ExchangeRateFinder) *CreateTransactionUseCase {
+	uc.rateFinder = rateFinder
+	return uc
+}
+
+// WithIdempotency stores Idempotency-Key outcomes so retries within window return the original transaction
+func (uc *CreateTransactionUseCase) WithIdempotency(repo repositories.IdempotencyKeyRepository, window time.Duration) *CreateTransactionUseCase {
+	uc.idempotencyKeyRepo = repo
+	uc.idempotencyWindow = window
+	return uc
+}
+
+// ExecuteIdempotent creates a transaction once per Idempotency-Key and scope
+// A retry with the same key and body returns the stored response and replayed=true instead of a duplicate;
+// reusing the key with a different body, or while the first attempt is still running, is a conflict
+func (uc *CreateTransactionUseCase) ExecuteIdempotent(scope, key string, request *dto.CreateTransactionRequest) (*dto.CreateTransactionResponse, bool, error) {
+	if uc.idempotencyKeyRepo == nil {
+		response, err := uc.Execute(request)
+		return response, false, err
+	}
+	if err := entities.ValidateIdempotencyKey(key); err != nil {
+		return nil, false, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	// Expired keys can be reused as new ones
+	if _, err := uc.idempotencyKeyRepo.DeleteExpired(uc.clock.Now()); err != nil {
+		slog.Warn("Failed to delete expired idempotency keys", "error", err.Error())
+	}
+
+	record, err := entities.NewIdempotencyKey(scope, key, fingerprint(request), uc.idempotencyWindow, uc.clock)
+	if err != nil {
+		return nil, false, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	replayed, err := uc.reserve(record)
+	if err != nil || replayed != nil {
+		return replayed, replayed != nil, err
 	}
+
+	response, err := uc.Execute(request)
+	if err != nil {
+		// Release the key so the client can retry once the cause is fixed
+		if deleteErr := uc.idempotencyKeyRepo.Delete(record.ID); deleteErr != nil {
+			slog.Warn("Failed to release idempotency key", "error", deleteErr.Error())
+		}
+		return nil, false, err
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encode idempotent response: %w", err)
+	}
+	record.Complete(response.ID, string(body))
+	if err := uc.idempotencyKeyRepo.Complete(record); err != nil {
+		// The transaction exists; a retry after the lock timeout would create another one
+		slog.Warn("Failed to store idempotency key outcome", "transaction_id", response.ID.String(), "error", err.Error())
+	}
+
+	return response, false, nil
+}
+
+// reserve claims the key, returning the stored response when an earlier attempt with the same body completed
+func (uc *CreateTransactionUseCase) reserve(record *entities.IdempotencyKey) (*dto.CreateTransactionResponse, error) {
+	// A second pass covers a pending key that was abandoned, or released while we looked at it
+	for attempt := 0; attempt < 2; attempt++ {
+		reserved, err := uc.idempotencyKeyRepo.Reserve(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		if reserved {
+			return nil, nil
+		}
+
+		existing, err := uc.idempotencyKeyRepo.Find(record.Scope, record.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up idempotency key: %w", err)
+		}
+		if existing == nil {
+			continue
+		}
+
+		if existing.RequestHash != record.RequestHash {
+			return nil, errs.Newf(errs.ErrIdempotencyReused, "idempotency key conflict: key was already used with a different request body")
+		}
+		if existing.IsCompleted() {
+			var response dto.CreateTransactionResponse
+			if err := json.Unmarshal([]byte(existing.Response), &response); err != nil {
+				return nil, fmt.Errorf("failed to decode idempotent response: %w", err)
+			}
+			return &response, nil
+		}
+		if uc.clock.Now().Sub(existing.CreatedAt) < idempotencyLockTimeout {
+			return nil, errs.Newf(errs.ErrConflict, "idempotency key conflict: a request with this key is still in progress")
+		}
+
+		if err := uc.idempotencyKeyRepo.Delete(existing.ID); err != nil {
+			return nil, fmt.Errorf("failed to release abandoned idempotency key: %w", err)
+		}
+	}
+
+	return nil, errs.Newf(errs.ErrConflict, "idempotency key conflict: a request with this key is still in progress")
+}
+
+// fingerprint hashes the request so a reused key can be told apart from a genuine retry
+func fingerprint(request *dto.CreateTransactionRequest) string {
+	body, _ := json.Marshal(request)
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
 }
 
 // Execute creates a new transaction with the provided request data
 func (uc *CreateTransactionUseCase) Execute(request *dto.CreateTransactionRequest) (*dto.CreateTransactionResponse, error) {
 	// Validate input
 	if err := uc.validateRequest(request); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
 	}
 
 	// Convert DTO to entity
-	transaction := request.ToEntity()
+	transaction := request.ToEntity(uc.clock)
+
+	// A transaction in another currency must be convertible, which takes cross rates
+	if currency := transaction.SourceCurrency(); currency != entities.USD && (uc.rateFinder == nil || !uc.rateFinder.SupportsSourceCurrency(currency)) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: unsupported transaction currency: %s", currency)
+	}
+
+	// The category must exist when categories are managed
+	if uc.categoryRepo != nil {
+		category, err := resolveCategory(uc.categoryRepo, transaction.Category)
+		if err != nil {
+			return nil, err
+		}
+		transaction.Category = category
+	}
 
 	// Additional business validation (beyond struct tags)
 	if err := uc.validateBusinessRules(transaction); err != nil {
-		return nil, fmt.Errorf("business validation failed: %w", err)
+		return nil, errs.Newf(errs.ErrValidation, "business validation failed: %w", err)
 	}
 
 	// Save transaction to repository
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// DeleteTransactionUseCase handles the business logic for moving a transaction to the trash
+type DeleteTransactionUseCase struct {
+	transactionRepo repositories.TransactionRepository
+}
+
+// NewDeleteTransactionUseCase creates a new instance of DeleteTransactionUseCase
+func NewDeleteTransactionUseCase(transactionRepo repositories.TransactionRepository) *DeleteTransactionUseCase {
+	return &DeleteTransactionUseCase{
+		transactionRepo: transactionRepo,
+	}
+}
+
+// Execute soft-deletes a transaction; it can be brought back with RestoreTransactionUseCase
+func (uc *DeleteTransactionUseCase) Execute(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errs.Newf(errs.ErrValidation, "validation failed: transaction ID cannot be empty")
+	}
+
+	if err := uc.transactionRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete transaction: %w", err)
+	}
+
+	return nil
+}
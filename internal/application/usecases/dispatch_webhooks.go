@@ -0,0 +1,231 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// Webhook delivery limits
+const (
+	webhookConcurrency    = 8         // Deliveries sent at the same time
+	webhookRetryBatchSize = 100       // Due deliveries claimed per retry run
+	maxWebhookRetryDelay  = time.Hour // Upper bound of the exponential backoff
+)
+
+// DispatchWebhooksUseCase sends transaction events to the webhooks subscribed to them
+// Every event becomes a persisted delivery per webhook, attempted at once and retried with exponential backoff
+type DispatchWebhooksUseCase struct {
+	webhookRepo repositories.WebhookRepository
+	sender      services.WebhookSender
+	maxAttempts int
+	retryBase   time.Duration
+	lease       time.Duration
+
+	slots chan struct{} // Bounds concurrent sends
+	clock clock.Clock
+}
+
+// NewDispatchWebhooksUseCase creates a new instance of DispatchWebhooksUseCase
+// A delivery is tried maxAttempts times in total, the nth retry waiting retryBase * 2^(n-1);
+// lease hides a delivery being sent from other workers and must exceed the sender's timeout
+func NewDispatchWebhooksUseCase(
+	webhookRepo repositories.WebhookRepository,
+	sender services.WebhookSender,
+	maxAttempts int,
+	retryBase time.Duration,
+	lease time.Duration,
+) *DispatchWebhooksUseCase {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	return &DispatchWebhooksUseCase{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		maxAttempts: maxAttempts,
+		retryBase:   retryBase,
+		lease:       lease,
+		slots:       make(chan struct{}, webhookConcurrency),
+		clock:       clock.System(),
+	}
+}
+
+// WithClock sets the clock delivery leases and retry times are computed from
+func (uc *DispatchWebhooksUseCase) WithClock(clk clock.Clock) *DispatchWebhooksUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// OnTransactionEvent is an EventHandler that publishes transaction.created and transaction.converted to webhooks
+func (uc *DispatchWebhooksUseCase) OnTransactionEvent(ctx context.Context, event entities.DomainEvent) error {
+	_, err := uc.Publish(ctx, event)
+	return err
+}
+
+// Publish records a delivery of the event for every webhook subscribed to it and attempts each one
+// Deliveries that fail are left pending for DeliverDue to retry; the recorded deliveries are returned
+func (uc *DispatchWebhooksUseCase) Publish(ctx context.Context, event entities.DomainEvent) ([]entities.WebhookDelivery, error) {
+	message, err := dto.NewEventMessage(event)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	hooks, err := uc.webhookRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+
+	// Deliveries start out leased to this call, so retry runs leave them alone during the first attempt
+	leaseUntil := uc.clock.Now().UTC().Add(uc.lease)
+	subscribed := make(map[uuid.UUID]*entities.Webhook)
+	var deliveries []entities.WebhookDelivery
+	for i := range hooks {
+		if !hooks[i].Subscribes(event.EventName()) {
+			continue
+		}
+		subscribed[hooks[i].ID] = &hooks[i]
+		deliveries = append(deliveries, entities.WebhookDelivery{
+			ID:            uuid.New(),
+			WebhookID:     hooks[i].ID,
+			EventID:       event.EventID(),
+			Event:         event.EventName(),
+			Payload:       string(payload),
+			Status:        entities.DeliveryPending,
+			NextAttemptAt: &leaseUntil,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+
+	if err := uc.webhookRepo.SaveDeliveries(deliveries); err != nil {
+		return nil, fmt.Errorf("failed to save webhook deliveries: %w", err)
+	}
+
+	uc.attemptAll(ctx, subscribed, deliveries)
+	return deliveries, nil
+}
+
+// DeliverDue retries the pending deliveries whose next attempt is due
+// Deliveries of webhooks deleted in the meantime are skipped
+func (uc *DispatchWebhooksUseCase) DeliverDue(ctx context.Context) (*dto.WebhookDeliveryResult, error) {
+	now := uc.clock.Now().UTC()
+	due, err := uc.webhookRepo.ClaimDueDeliveries(now, now.Add(uc.lease), webhookRetryBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due webhook deliveries: %w", err)
+	}
+
+	hooks := make(map[uuid.UUID]*entities.Webhook)
+	for _, delivery := range due {
+		if _, loaded := hooks[delivery.WebhookID]; loaded {
+			continue
+		}
+		hook, err := uc.webhookRepo.GetByID(delivery.WebhookID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve webhook %s: %w", delivery.WebhookID, err)
+		}
+		hooks[delivery.WebhookID] = hook
+	}
+
+	uc.attemptAll(ctx, hooks, due)
+
+	result := &dto.WebhookDeliveryResult{}
+	for _, delivery := range due {
+		if hooks[delivery.WebhookID] == nil {
+			continue
+		}
+		result.Attempted++
+		switch delivery.Status {
+		case entities.DeliverySucceeded:
+			result.Succeeded++
+		case entities.DeliveryFailed:
+			result.Failed++
+		default:
+			result.Retrying++
+		}
+	}
+	return result, nil
+}
+
+// attemptAll sends the deliveries concurrently, updating each in place with its outcome
+func (uc *DispatchWebhooksUseCase) attemptAll(ctx context.Context, hooks map[uuid.UUID]*entities.Webhook, deliveries []entities.WebhookDelivery) {
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		hook := hooks[deliveries[i].WebhookID]
+		if hook == nil {
+			continue
+		}
+
+		wg.Add(1)
+		uc.slots <- struct{}{}
+		go func(delivery *entities.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-uc.slots }()
+			uc.attempt(ctx, hook, delivery)
+		}(&deliveries[i])
+	}
+	wg.Wait()
+}
+
+// attempt sends one delivery and stores the outcome; any 2xx answer counts as delivered
+func (uc *DispatchWebhooksUseCase) attempt(ctx context.Context, hook *entities.Webhook, delivery *entities.WebhookDelivery) {
+	statusCode, err := uc.sender.Send(ctx, hook, delivery)
+	now := uc.clock.Now().UTC()
+
+	if err == nil && statusCode >= 200 && statusCode < 300 {
+		delivery.RecordSuccess(statusCode, now)
+	} else {
+		reason := fmt.Sprintf("receiver answered %d", statusCode)
+		if err != nil {
+			reason = err.Error()
+		}
+
+		var retryAt *time.Time
+		if delivery.Attempts+1 < uc.maxAttempts {
+			next := now.Add(uc.retryDelay(delivery.Attempts + 1))
+			retryAt = &next
+		}
+		delivery.RecordFailure(statusCode, reason, retryAt)
+
+		slog.Warn("Webhook delivery failed",
+			"webhook_id", hook.ID.String(),
+			"delivery_id", delivery.ID.String(),
+			"event", delivery.Event,
+			"attempt", delivery.Attempts,
+			"status_code", statusCode,
+			"error", reason,
+			"will_retry", retryAt != nil,
+		)
+	}
+
+	if err := uc.webhookRepo.UpdateDelivery(delivery); err != nil {
+		slog.Warn("Failed to store webhook delivery outcome",
+			"delivery_id", delivery.ID.String(),
+			"error", err.Error(),
+		)
+	}
+}
+
+// retryDelay is the wait after the given number of failed attempts: retryBase doubled per earlier failure
+func (uc *DispatchWebhooksUseCase) retryDelay(failures int) time.Duration {
+	delay := uc.retryBase
+	for i := 1; i < failures && delay < maxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxWebhookRetryDelay)
+}
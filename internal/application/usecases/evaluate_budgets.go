@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// EvaluateBudgetsUseCase compares category spend with budgets as transactions arrive and announces crossed thresholds
+type EvaluateBudgetsUseCase struct {
+	budgetRepo      repositories.BudgetRepository
+	transactionRepo repositories.TransactionRepository
+	rateFinder      ExchangeRateFinder
+	publisher       services.BudgetAlertPublisher
+	clock           clock.Clock
+
+	evaluating sync.Mutex // Serializes evaluations so concurrent saves see each other's spend in order
+}
+
+// NewEvaluateBudgetsUseCase creates a new instance of EvaluateBudgetsUseCase
+func NewEvaluateBudgetsUseCase(
+	budgetRepo repositories.BudgetRepository,
+	transactionRepo repositories.TransactionRepository,
+	rateFinder ExchangeRateFinder,
+	publisher services.BudgetAlertPublisher,
+) *EvaluateBudgetsUseCase {
+	return &EvaluateBudgetsUseCase{
+		budgetRepo:      budgetRepo,
+		transactionRepo: transactionRepo,
+		rateFinder:      rateFinder,
+		publisher:       publisher,
+		clock:           clock.System(),
+	}
+}
+
+// WithClock sets the clock budget alerts are dated by
+func (uc *EvaluateBudgetsUseCase) WithClock(clk clock.Clock) *EvaluateBudgetsUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// OnTransactionCreated evaluates budgets for the transaction of a transaction.created event
+// It is an EventHandler, so it runs on the event bus and does not delay the caller storing the transaction
+func (uc *EvaluateBudgetsUseCase) OnTransactionCreated(ctx context.Context, event entities.DomainEvent) error {
+	created, ok := event.(entities.TransactionCreatedEvent)
+	if !ok || created.Transaction.Category == "" {
+		return nil
+	}
+
+	if _, err := uc.Execute(ctx, &created.Transaction); err != nil {
+		return fmt.Errorf("failed to evaluate budgets for transaction %s: %w", created.Transaction.ID, err)
+	}
+	return nil
+}
+
+// Execute checks every budget of the transaction's category and publishes one event per crossed threshold
+// Spend is the category total for the budget period containing the transaction, including it,
+// converted to the budget currency at the rate applicable on the transaction date
+func (uc *EvaluateBudgetsUseCase) Execute(ctx context.Context, transaction *entities.Transaction) ([]entities.BudgetThresholdCrossedEvent, error) {
+	if transaction == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: transaction is required")
+	}
+	if transaction.Category == "" {
+		return nil, nil
+	}
+
+	uc.evaluating.Lock()
+	defer uc.evaluating.Unlock()
+
+	budgets, err := uc.budgetRepo.FindByCategory(transaction.Category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find budgets for category %s: %w", transaction.Category, err)
+	}
+
+	var events []entities.BudgetThresholdCrossedEvent
+	for i := range budgets {
+		budget := budgets[i]
+
+		crossed, err := uc.evaluate(ctx, &budget, transaction)
+		if err != nil {
+			// One budget without a usable rate must not hide alerts from the others
+			slog.Warn("Failed to evaluate budget",
+				"error", err.Error(),
+				"budget_id", budget.ID.String(),
+				"transaction_id", transaction.ID.String(),
+			)
+			continue
+		}
+
+		for _, event := range crossed {
+			if err := uc.publisher.PublishBudgetThresholdCrossed(event); err != nil {
+				slog.Warn("Failed to publish budget threshold crossed event",
+					"error", err.Error(),
+					"budget_id", budget.ID.String(),
+					"threshold", event.Threshold,
+				)
+			}
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// evaluate returns the events for the thresholds of one budget crossed by the transaction
+func (uc *EvaluateBudgetsUseCase) evaluate(ctx context.Context, budget *entities.Budget, transaction *entities.Transaction) ([]entities.BudgetThresholdCrossedEvent, error) {
+	periodStart, periodEnd := budget.PeriodBounds(transaction.Date)
+
+	summary, err := uc.transactionRepo.SummarizeCategoryBetween(budget.Category, periodStart, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize spend: %w", err)
+	}
+
+	after := summary.Total
+	before := after - transaction.Amount
+	if budget.Currency != entities.USD {
+		exchangeRate, err := uc.rateFinder.FindExchangeRate(ctx, budget.Currency, transaction.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find exchange rate: %w", err)
+		}
+		before = exchangeRate.ConvertAmount(before)
+		after = exchangeRate.ConvertAmount(after)
+	}
+
+	var events []entities.BudgetThresholdCrossedEvent
+	for _, threshold := range budget.CrossedThresholds(before, after) {
+		events = append(events, entities.BudgetThresholdCrossedEvent{
+			Budget:        *budget,
+			Threshold:     threshold,
+			Spent:         after,
+			PeriodStart:   periodStart,
+			PeriodEnd:     periodEnd,
+			TransactionID: transaction.ID,
+			OccurredAt:    uc.clock.Now().UTC(),
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// ExportDatasetUseCase handles the business logic for exporting the full dataset
+type ExportDatasetUseCase struct {
+	transactionRepo  repositories.TransactionRepository
+	exchangeRateRepo repositories.ExchangeRateRepository
+	conversionRepo   repositories.ConversionHistoryRepository
+	clock            clock.Clock
+}
+
+// NewExportDatasetUseCase creates a new instance of ExportDatasetUseCase
+func NewExportDatasetUseCase(
+	transactionRepo repositories.TransactionRepository,
+	exchangeRateRepo repositories.ExchangeRateRepository,
+	conversionRepo repositories.ConversionHistoryRepository,
+) *ExportDatasetUseCase {
+	return &ExportDatasetUseCase{
+		transactionRepo:  transactionRepo,
+		exchangeRateRepo: exchangeRateRepo,
+		conversionRepo:   conversionRepo,
+		clock:            clock.System(),
+	}
+}
+
+// WithClock sets the clock archives are dated by
+func (uc *ExportDatasetUseCase) WithClock(clk clock.Clock) *ExportDatasetUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Execute writes every transaction, exchange rate and conversion to w as a versioned DatasetArchive
+// Records are encoded one repository batch at a time, so the dataset is never held in memory;
+// on error w holds an incomplete archive
+func (uc *ExportDatasetUseCase) Execute(w io.Writer) (*dto.ExportSummary, error) {
+	summary := &dto.ExportSummary{SchemaVersion: dto.ArchiveSchemaVersion}
+	out := bufio.NewWriter(w)
+
+	exportedAt, err := json.Marshal(uc.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export time: %w", err)
+	}
+	if _, err := fmt.Fprintf(out, `{"schema_version":%d,"exported_at":%s,"transactions":`, summary.SchemaVersion, exportedAt); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	summary.Transactions, err = writeArchiveList(out, uc.transactionRepo.ForEach)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export transactions: %w", err)
+	}
+
+	if _, err := io.WriteString(out, `,"exchange_rates":`); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	summary.ExchangeRates, err = writeArchiveList(out, uc.exchangeRateRepo.ForEach)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export exchange rates: %w", err)
+	}
+
+	if _, err := io.WriteString(out, `,"conversions":`); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	summary.Conversions, err = writeArchiveList(out, uc.conversionRepo.ForEach)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export conversions: %w", err)
+	}
+
+	if _, err := io.WriteString(out, "}\n"); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := out.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return summary, nil
+}
+
+// writeArchiveList writes the records streamed by forEach as a JSON array and returns how many there were
+func writeArchiveList[T any](out *bufio.Writer, forEach func(batchSize int, fn func(batch []T) error) error) (int, error) {
+	if err := out.WriteByte('['); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err := forEach(0, func(batch []T) error {
+		for i := range batch {
+			record, err := json.Marshal(&batch[i])
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				if err := out.WriteByte(','); err != nil {
+					return err
+				}
+			}
+			if _, err := out.Write(record); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, out.WriteByte(']')
+}
@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// GetCurrencyUseCase handles the business logic for retrieving currency metadata
+type GetCurrencyUseCase struct {
+	treasuryService services.RateProvider
+}
+
+// NewGetCurrencyUseCase creates a new instance of GetCurrencyUseCase
+func NewGetCurrencyUseCase(treasuryService services.RateProvider) *GetCurrencyUseCase {
+	return &GetCurrencyUseCase{
+		treasuryService: treasuryService,
+	}
+}
+
+// Execute returns metadata for the given currency code, including conversion coverage
+func (uc *GetCurrencyUseCase) Execute(code string) (*dto.CurrencyResponse, error) {
+	// Normalize and validate the code format
+	currencyCode, err := entities.NewCurrencyCode(code)
+	if err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	info, exists := currencyCode.Info()
+	if !exists {
+		return nil, errs.Newf(errs.ErrNotFound, "currency not found: %s", currencyCode)
+	}
+
+	// Transactions are stored in USD, so only foreign currencies are conversion targets
+	provider := ""
+	if currencyCode != entities.USD && uc.treasuryService.SupportsCurrency(currencyCode) {
+		provider = uc.treasuryService.ProviderName()
+	}
+
+	return dto.NewCurrencyResponse(info, provider), nil
+}
@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+)
+
+// GetExchangeRateUseCase looks up the rate a conversion would use, so clients can preview it before converting
+type GetExchangeRateUseCase struct {
+	rateFinder ExchangeRateFinder
+	margins    *MarginPolicy
+	validator  *validator.Validate
+}
+
+// NewGetExchangeRateUseCase creates a new instance of GetExchangeRateUseCase
+func NewGetExchangeRateUseCase(
+	rateFinder ExchangeRateFinder,
+	margins *MarginPolicy,
+	validator *validator.Validate,
+) *GetExchangeRateUseCase {
+	return &GetExchangeRateUseCase{
+		rateFinder: rateFinder,
+		margins:    margins,
+		validator:  validator,
+	}
+}
+
+// Execute finds the rate under the same lookback window as conversions, from the local cache or the Treasury,
+// and prices it with the caller's margin
+func (uc *GetExchangeRateUseCase) Execute(ctx context.Context, request *dto.GetExchangeRateRequest) (*dto.ExchangeRateResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	// Rates are published against the US dollar; other sources are crossed through it when cross rates are enabled
+	if !uc.SupportsSourceCurrency(request.From) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: unsupported source currency: %s", request.From)
+	}
+	if !uc.supportsPair(request.From, request.To) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: unsupported target currency: %s", request.To)
+	}
+
+	exchangeRate, err := uc.rateFinder.FindConversionRate(ctx, request.From, request.To, request.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+
+	window := uc.rateFinder.RateWindow()
+	if !exchangeRate.IsWithinDateRange(request.Date, window) {
+		return nil, errs.Newf(errs.ErrRateUnavailable, "failed to find exchange rate: exchange rate date %v is not within %s of %v",
+			exchangeRate.EffectiveDate, window, request.Date)
+	}
+
+	marginBps := uc.margins.For(request.APIKey)
+
+	return &dto.ExchangeRateResponse{
+		From:            request.From,
+		To:              request.To,
+		Date:            request.Date,
+		RawExchangeRate: exchangeRate.Rate,
+		MarginBps:       marginBps,
+		ExchangeRate:    entities.ApplyMargin(exchangeRate.Rate, marginBps),
+		EffectiveDate:   exchangeRate.EffectiveDate,
+	}, nil
+}
+
+// SupportsCurrency reports whether rates can be looked up for the given target currency
+func (uc *GetExchangeRateUseCase) SupportsCurrency(code entities.CurrencyCode) bool {
+	return uc.rateFinder.SupportsCurrency(code)
+}
+
+// SupportsSourceCurrency reports whether rates can be looked up from the given currency
+func (uc *GetExchangeRateUseCase) SupportsSourceCurrency(code entities.CurrencyCode) bool {
+	return uc.rateFinder.SupportsSourceCurrency(code)
+}
+
+// SupportedSourceCurrencies lists the currencies rates can be looked up from
+func (uc *GetExchangeRateUseCase) SupportedSourceCurrencies() []entities.CurrencyCode {
+	return supportedCurrencies(uc.SupportsSourceCurrency)
+}
+
+// supportsPair reports whether a rate converts from one currency to a different one; USD is a target of cross rates only
+func (uc *GetExchangeRateUseCase) supportsPair(from, to entities.CurrencyCode) bool {
+	if to == entities.USD {
+		return from != entities.USD
+	}
+	return to != from && uc.SupportsCurrency(to)
+}
+
+// SupportedCurrencies lists the target currencies rates can be looked up for
+func (uc *GetExchangeRateUseCase) SupportedCurrencies() []entities.CurrencyCode {
+	return supportedCurrencies(uc.SupportsCurrency)
+}
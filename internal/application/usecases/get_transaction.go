@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
 )
 
@@ -24,7 +25,7 @@ func NewGetTransactionUseCase(transactionRepo repositories.TransactionRepository
 func (uc *GetTransactionUseCase) Execute(id uuid.UUID) (*dto.GetTransactionResponse, error) {
 	// Validate input
 	if err := uc.validateInput(id); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
 	}
 
 	// Get transaction from repository
@@ -35,7 +36,7 @@ func (uc *GetTransactionUseCase) Execute(id uuid.UUID) (*dto.GetTransactionRespo
 
 	// Check if transaction was found
 	if transaction == nil {
-		return nil, fmt.Errorf("transaction not found with id: %s", id.String())
+		return nil, errs.Newf(errs.ErrNotFound, "transaction not found with id: %s", id.String())
 	}
 
 	// Convert entity to response DTO
@@ -44,6 +45,38 @@ func (uc *GetTransactionUseCase) Execute(id uuid.UUID) (*dto.GetTransactionRespo
 	return response, nil
 }
 
+// ExecuteIncluding retrieves a transaction by its ID, falling back to cold storage and the trash when asked to
+func (uc *GetTransactionUseCase) ExecuteIncluding(id uuid.UUID, archived, deleted bool) (*dto.GetTransactionResponse, error) {
+	if err := uc.validateInput(id); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	transaction, err := uc.transactionRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transaction: %w", err)
+	}
+
+	if transaction == nil && archived {
+		transaction, err = uc.transactionRepo.GetArchivedByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve archived transaction: %w", err)
+		}
+	}
+
+	if transaction == nil && deleted {
+		transaction, err = uc.transactionRepo.GetDeletedByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve deleted transaction: %w", err)
+		}
+	}
+
+	if transaction == nil {
+		return nil, errs.Newf(errs.ErrNotFound, "transaction not found with id: %s", id.String())
+	}
+
+	return dto.NewGetTransactionResponse(transaction), nil
+}
+
 // validateInput validates the input parameters
 func (uc *GetTransactionUseCase) validateInput(id uuid.UUID) error {
 	// Check if UUID is valid (not nil/empty)
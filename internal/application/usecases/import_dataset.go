@@ -0,0 +1,279 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// ImportDatasetUseCase handles the business logic for importing a dataset archive
+type ImportDatasetUseCase struct {
+	transactionRepo  repositories.TransactionRepository
+	exchangeRateRepo repositories.ExchangeRateRepository
+	conversionRepo   repositories.ConversionHistoryRepository
+	unitOfWork       repositories.UnitOfWork
+	guard            ImportGuard
+	validator        *validator.Validate
+}
+
+// NewImportDatasetUseCase creates a new instance of ImportDatasetUseCase
+// guard may be nil, in which case imports are never blocked
+func NewImportDatasetUseCase(
+	transactionRepo repositories.TransactionRepository,
+	exchangeRateRepo repositories.ExchangeRateRepository,
+	conversionRepo repositories.ConversionHistoryRepository,
+	guard ImportGuard,
+	validator *validator.Validate,
+) *ImportDatasetUseCase {
+	return &ImportDatasetUseCase{
+		transactionRepo:  transactionRepo,
+		exchangeRateRepo: exchangeRateRepo,
+		conversionRepo:   conversionRepo,
+		guard:            guard,
+		validator:        validator,
+	}
+}
+
+// WithUnitOfWork makes an import write every record of the archive in one database transaction
+// Without it a failure part way through leaves the records written before it in place
+func (uc *ImportDatasetUseCase) WithUnitOfWork(unitOfWork repositories.UnitOfWork) *ImportDatasetUseCase {
+	uc.unitOfWork = unitOfWork
+	return uc
+}
+
+// Execute writes the archive records, resolving existing IDs with the requested conflict strategy
+func (uc *ImportDatasetUseCase) Execute(request *dto.ImportArchiveRequest) (*dto.ImportArchiveResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+
+	// Apply default strategy
+	if request.Strategy == "" {
+		request.Strategy = dto.ConflictSkip
+	}
+
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	archive := request.Archive
+	if archive.SchemaVersion < dto.MinArchiveSchemaVersion || archive.SchemaVersion > dto.ArchiveSchemaVersion {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: unsupported archive schema version %d (expected %d to %d)",
+			archive.SchemaVersion, dto.MinArchiveSchemaVersion, dto.ArchiveSchemaVersion)
+	}
+
+	// Validate every record up front so a bad archive doesn't leave a partial import behind
+	for i := range archive.Transactions {
+		if err := archive.Transactions[i].Validate(); err != nil {
+			return nil, errs.Newf(errs.ErrValidation, "validation failed: transaction %s: %w", archive.Transactions[i].ID, err)
+		}
+	}
+	for i := range archive.ExchangeRates {
+		if err := archive.ExchangeRates[i].Validate(); err != nil {
+			return nil, errs.Newf(errs.ErrValidation, "validation failed: exchange rate %s: %w", archive.ExchangeRates[i].ID, err)
+		}
+	}
+	for _, conversion := range archive.Conversions {
+		if conversion.ID == uuid.Nil || conversion.TransactionID == uuid.Nil || !conversion.TargetCurrency.IsValid() {
+			return nil, errs.Newf(errs.ErrValidation, "validation failed: conversion %s: id, transaction_id and target_currency are required",
+				conversion.ID)
+		}
+	}
+
+	if uc.guard != nil {
+		if err := uc.guard.AllowImport(); err != nil {
+			return nil, err
+		}
+	}
+
+	var response *dto.ImportArchiveResponse
+	err := uc.atomically(func(repos repositories.Repositories) error {
+		var err error
+		response, err = importArchive(repos, archive, request.Strategy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// atomically runs fn in the unit of work, or directly on the use case's repositories when there is none
+func (uc *ImportDatasetUseCase) atomically(fn func(repos repositories.Repositories) error) error {
+	if uc.unitOfWork == nil {
+		return fn(repositories.Repositories{
+			Transactions:      uc.transactionRepo,
+			ExchangeRates:     uc.exchangeRateRepo,
+			ConversionHistory: uc.conversionRepo,
+		})
+	}
+	return uc.unitOfWork.Do(fn)
+}
+
+// importArchive writes the archive records to repos, resolving existing IDs with strategy
+func importArchive(repos repositories.Repositories, archive *dto.DatasetArchive, strategy string) (*dto.ImportArchiveResponse, error) {
+	// With the fail strategy, refuse the whole archive before writing anything
+	if strategy == dto.ConflictFail {
+		if err := checkConflicts(repos, archive); err != nil {
+			return nil, err
+		}
+	}
+
+	response := &dto.ImportArchiveResponse{
+		SchemaVersion: archive.SchemaVersion,
+		Strategy:      strategy,
+	}
+
+	err := importRecords(archive.Transactions, "transaction", strategy, &response.Transactions,
+		func(transaction *entities.Transaction) uuid.UUID { return transaction.ID },
+		repos.Transactions.Exists,
+		func(transaction *entities.Transaction) error {
+			// Overwriting replaces whichever version is stored, whatever the archive recorded
+			current, err := repos.Transactions.GetByID(transaction.ID)
+			if err != nil {
+				return err
+			}
+			if current == nil {
+				// The stored transaction is in the trash; overwriting it brings it back
+				if current, err = repos.Transactions.Restore(transaction.ID); err != nil {
+					return err
+				}
+			}
+			transaction.Version = current.Version
+			return repos.Transactions.Update(transaction)
+		},
+		repos.Transactions.SaveAll,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = importRecords(archive.ExchangeRates, "exchange rate", strategy, &response.ExchangeRates,
+		func(exchangeRate *entities.ExchangeRate) uuid.UUID { return exchangeRate.ID },
+		repos.ExchangeRates.Exists,
+		repos.ExchangeRates.Update,
+		repos.ExchangeRates.SaveAll,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = importRecords(archive.Conversions, "conversion", strategy, &response.Conversions,
+		func(conversion *entities.Conversion) uuid.UUID { return conversion.ID },
+		repos.ConversionHistory.Exists,
+		repos.ConversionHistory.Update,
+		repos.ConversionHistory.SaveAll,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// importRecords writes the records of one kind: new ones are collected and inserted in batches,
+// existing ones go through the conflict strategy
+func importRecords[T any](
+	records []T,
+	kind, strategy string,
+	counts *dto.ImportCounts,
+	id func(record *T) uuid.UUID,
+	exists func(id uuid.UUID) (bool, error),
+	overwrite func(record *T) error,
+	saveAll func(records []T) error,
+) error {
+	newRecords := make([]T, 0, len(records))
+	queued := make(map[uuid.UUID]int) // Index in newRecords, so a repeated ID in the archive resolves like an existing one
+	for i := range records {
+		record := &records[i]
+		recordID := id(record)
+		isNew, err := importRecord(strategy, counts,
+			func() (bool, error) {
+				if _, ok := queued[recordID]; ok {
+					return true, nil
+				}
+				return exists(recordID)
+			},
+			func() error {
+				if index, ok := queued[recordID]; ok {
+					newRecords[index] = *record
+					return nil
+				}
+				return overwrite(record)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to import %s %s: %w", kind, recordID, err)
+		}
+		if isNew {
+			queued[recordID] = len(newRecords)
+			newRecords = append(newRecords, *record)
+		}
+	}
+	if err := saveAll(newRecords); err != nil {
+		return fmt.Errorf("failed to import %ss: %w", kind, err)
+	}
+	counts.Created = len(newRecords)
+	return nil
+}
+
+// checkConflicts returns an error naming the first archive record whose ID already exists
+func checkConflicts(repos repositories.Repositories, archive *dto.DatasetArchive) error {
+	for _, transaction := range archive.Transactions {
+		if err := conflictError("transaction", transaction.ID, repos.Transactions.Exists); err != nil {
+			return err
+		}
+	}
+	for _, exchangeRate := range archive.ExchangeRates {
+		if err := conflictError("exchange rate", exchangeRate.ID, repos.ExchangeRates.Exists); err != nil {
+			return err
+		}
+	}
+	for _, conversion := range archive.Conversions {
+		if err := conflictError("conversion", conversion.ID, repos.ConversionHistory.Exists); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conflictError reports whether a record with the given ID already exists
+func conflictError(kind string, id uuid.UUID, exists func(uuid.UUID) (bool, error)) error {
+	found, err := exists(id)
+	if err != nil {
+		return fmt.Errorf("failed to check existing %s %s: %w", kind, id, err)
+	}
+	if found {
+		return errs.Newf(errs.ErrConflict, "import conflict: %s %s already exists", kind, id)
+	}
+	return nil
+}
+
+// importRecord applies the conflict strategy to an existing record
+// It returns true for a record that doesn't exist yet, which the caller saves in the next batch
+func importRecord(strategy string, counts *dto.ImportCounts, exists func() (bool, error), overwrite func() error) (bool, error) {
+	found, err := exists()
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return true, nil
+	}
+
+	if strategy == dto.ConflictOverwrite {
+		if err := overwrite(); err != nil {
+			return false, err
+		}
+		counts.Overwritten++
+		return false, nil
+	}
+
+	counts.Skipped++
+	return false, nil
+}
@@ -0,0 +1,288 @@
+package usecases
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// MaxImportRows caps the data rows of one CSV import, so a single upload cannot hold the database for long
+const MaxImportRows = 10000
+
+// CSV columns read into a transaction; other columns, such as the id of an exported file, are ignored
+var (
+	requiredImportColumns = []string{"description", "date", "amount"}
+	importColumns         = append(requiredImportColumns, "category")
+)
+
+// ImportTransactionsUseCase handles the business logic for importing transactions from a CSV file
+type ImportTransactionsUseCase struct {
+	transactionRepo repositories.TransactionRepository
+	categoryRepo    repositories.CategoryRepository
+	guard           ImportGuard
+	validator       *validator.Validate
+	clock           clock.Clock
+}
+
+// NewImportTransactionsUseCase creates a new instance of ImportTransactionsUseCase
+// guard may be nil, in which case imports are never blocked
+func NewImportTransactionsUseCase(
+	transactionRepo repositories.TransactionRepository,
+	guard ImportGuard,
+	validator *validator.Validate,
+) *ImportTransactionsUseCase {
+	return &ImportTransactionsUseCase{
+		transactionRepo: transactionRepo,
+		guard:           guard,
+		validator:       validator,
+		clock:           clock.System(),
+	}
+}
+
+// WithClock sets the clock imported transactions are dated by
+func (uc *ImportTransactionsUseCase) WithClock(clk clock.Clock) *ImportTransactionsUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// WithCategories rejects rows whose category does not exist, storing the others with the category's spelling
+func (uc *ImportTransactionsUseCase) WithCategories(repo repositories.CategoryRepository) *ImportTransactionsUseCase {
+	uc.categoryRepo = repo
+	return uc
+}
+
+// Execute reads a CSV file with a description, date, amount and optional category header and stores every valid row
+// Valid rows are inserted together in one database transaction; invalid rows are skipped and reported by line
+// Files saved from Excel work too: a UTF-8 byte order mark is ignored, and semicolon-separated files may use decimal commas
+func (uc *ImportTransactionsUseCase) Execute(file io.Reader) (*dto.ImportTransactionsResponse, error) {
+	reader, delimiter, err := newImportReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: the file is empty")
+	}
+	if err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: malformed CSV: %w", err)
+	}
+	columns, err := importColumnIndexes(header)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ImportTransactionsResponse{Errors: []dto.ImportRowError{}}
+	categories := make(map[string]string) // Resolved names by spelling in the file, "" for unknown ones
+	var transactions []entities.Transaction
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errs.Newf(errs.ErrValidation, "validation failed: malformed CSV: %w", err)
+		}
+
+		response.Rows++
+		if response.Rows > MaxImportRows {
+			return nil, errs.Newf(errs.ErrValidation, "validation failed: the file has more than %d rows", MaxImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		transaction, messages := uc.parseRow(record, columns, delimiter)
+		if len(messages) == 0 && uc.categoryRepo != nil && transaction.Category != "" {
+			category, seen := categories[transaction.Category]
+			if !seen {
+				category, err = resolveCategory(uc.categoryRepo, transaction.Category)
+				if err != nil && !errors.Is(err, errs.ErrValidation) {
+					return nil, err
+				}
+				categories[transaction.Category] = category
+			}
+			if category == "" {
+				messages = []string{fmt.Sprintf("category %q does not exist", transaction.Category)}
+			}
+			transaction.Category = category
+		}
+		if len(messages) > 0 {
+			response.Errors = append(response.Errors, dto.ImportRowError{Line: line, Messages: messages})
+			continue
+		}
+		transactions = append(transactions, *transaction)
+	}
+
+	if len(transactions) > 0 {
+		if uc.guard != nil {
+			if err := uc.guard.AllowImport(); err != nil {
+				return nil, err
+			}
+		}
+		if err := uc.transactionRepo.SaveAll(transactions); err != nil {
+			return nil, fmt.Errorf("failed to import transactions: %w", err)
+		}
+	}
+
+	response.Imported = len(transactions)
+	response.Rejected = len(response.Errors)
+	return response, nil
+}
+
+// parseRow reads a record into a transaction, returning every problem found instead when it is invalid
+func (uc *ImportTransactionsUseCase) parseRow(record []string, columns map[string]int, delimiter rune) (*entities.Transaction, []string) {
+	field := func(name string) string {
+		index, ok := columns[name]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	var messages []string
+	request := &dto.CreateTransactionRequest{
+		Description: field("description"),
+		Category:    field("category"),
+	}
+
+	if raw := field("date"); raw != "" {
+		date, err := parseImportDate(raw)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("date %q must be YYYY-MM-DD or RFC 3339", raw))
+		}
+		request.Date = date
+	}
+
+	if raw := field("amount"); raw != "" {
+		// Semicolon-separated exports come from locales that write decimals with a comma
+		if delimiter == ';' && !strings.Contains(raw, ".") {
+			raw = strings.Replace(raw, ",", ".", 1)
+		}
+		amount, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("amount %q must be a number", field("amount")))
+		}
+		request.Amount = amount
+	}
+
+	if err := uc.validator.Struct(request); err != nil {
+		var fieldErrors validator.ValidationErrors
+		if !errors.As(err, &fieldErrors) {
+			return nil, append(messages, err.Error())
+		}
+		for _, fieldError := range fieldErrors {
+			// An unparsable date or amount also fails required; it was reported already
+			if !reportedField(messages, fieldError.Field()) {
+				messages = append(messages, importFieldMessage(fieldError))
+			}
+		}
+	}
+	if len(messages) > 0 {
+		return nil, messages
+	}
+
+	transaction := request.ToEntity(uc.clock)
+	if err := transaction.Validate(); err != nil {
+		return nil, []string{err.Error()}
+	}
+	return transaction, nil
+}
+
+// newImportReader prepares a CSV reader, skipping a byte order mark and detecting a semicolon delimiter from the header
+func newImportReader(file io.Reader) (*csv.Reader, rune, error) {
+	buffered := bufio.NewReader(file)
+	if bom, err := buffered.Peek(3); err == nil && string(bom) == "\uFEFF" {
+		_, _ = buffered.Discard(3)
+	}
+
+	firstLine, err := buffered.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, 0, fmt.Errorf("failed to read the file: %w", err)
+	}
+
+	delimiter := ','
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		delimiter = ';'
+	}
+
+	reader := csv.NewReader(io.MultiReader(strings.NewReader(firstLine), buffered))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1 // Short rows are reported as missing values rather than failing the file
+	reader.TrimLeadingSpace = true
+	return reader, delimiter, nil
+}
+
+// importColumnIndexes maps the known columns to their position in the header, matching names case-insensitively
+func importColumnIndexes(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for _, known := range importColumns {
+			if name == known {
+				if _, duplicate := columns[name]; duplicate {
+					return nil, errs.Newf(errs.ErrValidation, "validation failed: column %q appears twice in the header", name)
+				}
+				columns[name] = i
+			}
+		}
+	}
+
+	var missing []string
+	for _, required := range requiredImportColumns {
+		if _, ok := columns[required]; !ok {
+			missing = append(missing, required)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: the header must name the %s columns, missing %s",
+			strings.Join(requiredImportColumns, ", "), strings.Join(missing, ", "))
+	}
+	return columns, nil
+}
+
+// parseImportDate accepts an RFC 3339 timestamp or a YYYY-MM-DD date, read as midnight UTC
+func parseImportDate(raw string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, raw); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
+
+// importFieldMessage describes a failed struct tag on a CreateTransactionRequest field
+func importFieldMessage(fieldError validator.FieldError) string {
+	field := strings.ToLower(fieldError.Field())
+	switch fieldError.Tag() {
+	case "required":
+		return field + " is required"
+	case "max":
+		return fmt.Sprintf("%s must not exceed %s characters", field, fieldError.Param())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, fieldError.Param())
+	case "money":
+		return field + " is out of range"
+	default:
+		return fmt.Sprintf("%s is invalid (%s)", field, fieldError.Tag())
+	}
+}
+
+// reportedField reports whether messages already hold a message about the field
+func reportedField(messages []string, field string) bool {
+	field = strings.ToLower(field)
+	for _, message := range messages {
+		if strings.HasPrefix(message, field+" ") {
+			return true
+		}
+	}
+	return false
+}
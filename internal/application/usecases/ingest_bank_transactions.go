@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// maxDescriptionLength mirrors the transaction description limit, which counts bytes
+const maxDescriptionLength = 50
+
+// IngestBankTransactionsUseCase imports purchases from a bank aggregator as transactions
+type IngestBankTransactionsUseCase struct {
+	transactionRepo repositories.TransactionRepository
+	aggregator      services.BankAggregator
+	clock           clock.Clock
+}
+
+// NewIngestBankTransactionsUseCase creates a new instance of IngestBankTransactionsUseCase
+func NewIngestBankTransactionsUseCase(
+	transactionRepo repositories.TransactionRepository,
+	aggregator services.BankAggregator,
+) *IngestBankTransactionsUseCase {
+	return &IngestBankTransactionsUseCase{
+		transactionRepo: transactionRepo,
+		aggregator:      aggregator,
+		clock:           clock.System(),
+	}
+}
+
+// WithClock sets the clock the sync window ends at
+func (uc *IngestBankTransactionsUseCase) WithClock(clk clock.Clock) *IngestBankTransactionsUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Execute fetches the connection's recent transactions and creates one transaction per new posted USD purchase
+// Each sync looks back LookbackDays so late-posting transactions are picked up; external IDs prevent duplicates
+func (uc *IngestBankTransactionsUseCase) Execute(ctx context.Context, connection dto.BankConnection) (*dto.BankSyncResult, error) {
+	lookback := connection.LookbackDays
+	if lookback < 1 {
+		lookback = 30
+	}
+	to := uc.clock.Now().UTC()
+	from := to.AddDate(0, 0, -lookback)
+
+	bankTransactions, err := uc.aggregator.FetchTransactions(ctx, connection.AccessToken, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions for connection %s: %w", connection.Name, err)
+	}
+
+	result := &dto.BankSyncResult{Connection: connection.Name, Fetched: len(bankTransactions)}
+	for _, bankTransaction := range bankTransactions {
+		if !uc.importable(connection, bankTransaction) {
+			result.Skipped++
+			continue
+		}
+
+		externalID := uc.aggregator.ProviderName() + ":" + bankTransaction.ID
+		existing, err := uc.transactionRepo.GetByExternalID(externalID)
+		if err != nil {
+			return result, fmt.Errorf("failed to check for imported transaction %s: %w", externalID, err)
+		}
+		if existing != nil {
+			result.Duplicates++
+			continue
+		}
+
+		transaction := &entities.Transaction{
+			ID:          uuid.New(),
+			Description: bankDescription(bankTransaction.Description),
+			Date:        bankDate(bankTransaction.Date),
+			Amount:      entities.NewMoney(bankTransaction.Amount),
+			ExternalID:  &externalID,
+		}
+		err = transaction.Validate()
+		if err == nil {
+			err = uc.transactionRepo.Save(transaction)
+		}
+		if err != nil {
+			// One malformed transaction must not stop the rest of the sync
+			slog.Warn("Failed to import bank transaction",
+				"connection", connection.Name,
+				"external_id", externalID,
+				"error", err.Error(),
+			)
+			result.Failed++
+			continue
+		}
+		result.Created++
+	}
+
+	return result, nil
+}
+
+// importable reports whether a bank transaction is a posted USD purchase on one of the connection's accounts
+// Amounts are in dollars, so only USD transactions can be stored as they are
+func (uc *IngestBankTransactionsUseCase) importable(connection dto.BankConnection, bankTransaction entities.BankTransaction) bool {
+	if bankTransaction.Pending || bankTransaction.Amount <= 0 {
+		return false
+	}
+	if !strings.EqualFold(bankTransaction.Currency, string(entities.USD)) {
+		return false
+	}
+	return len(connection.AccountIDs) == 0 || slices.Contains(connection.AccountIDs, bankTransaction.AccountID)
+}
+
+// bankDescription collapses whitespace and trims a bank description to the transaction description limit
+func bankDescription(description string) string {
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" {
+		return "Bank transaction"
+	}
+	for len(description) > maxDescriptionLength {
+		runes := []rune(description)
+		description = string(runes[:len(runes)-1])
+	}
+	return strings.TrimSpace(description)
+}
+
+// bankDate keeps the calendar day of a posting date, as transaction dates carry no time of day
+func bankDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
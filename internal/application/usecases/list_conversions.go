@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// ListConversionsUseCase handles reviewing the conversions served for a transaction
+type ListConversionsUseCase struct {
+	transactionRepo repositories.TransactionRepository
+	historyRepo     repositories.ConversionHistoryRepository
+}
+
+// NewListConversionsUseCase creates a new instance of ListConversionsUseCase
+func NewListConversionsUseCase(
+	transactionRepo repositories.TransactionRepository,
+	historyRepo repositories.ConversionHistoryRepository,
+) *ListConversionsUseCase {
+	return &ListConversionsUseCase{
+		transactionRepo: transactionRepo,
+		historyRepo:     historyRepo,
+	}
+}
+
+// Execute returns a page of the conversions of a transaction, most recent first
+func (uc *ListConversionsUseCase) Execute(transactionID uuid.UUID, page, size int) (*dto.ListConversionsResponse, error) {
+	if transactionID == uuid.Nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: transaction ID cannot be empty")
+	}
+
+	transaction, err := uc.transactionRepo.GetByID(transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transaction: %w", err)
+	}
+	if transaction == nil {
+		return nil, errs.Newf(errs.ErrNotFound, "transaction not found with id: %s", transactionID.String())
+	}
+
+	conversions, total, err := uc.historyRepo.GetByTransactionPaginated(transactionID, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve conversions: %w", err)
+	}
+
+	return dto.NewListConversionsResponse(transactionID, conversions, page, size, total), nil
+}
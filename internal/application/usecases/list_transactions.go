@@ -1,49 +1,182 @@
 package usecases
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
 )
 
+// ExchangeRateFinder looks up exchange rates for converting transactions
+// Implemented by ConvertTransactionUseCase
+type ExchangeRateFinder interface {
+	SupportsCurrency(code entities.CurrencyCode) bool // Never true for USD, which is what transactions convert from
+	SupportsSourceCurrency(code entities.CurrencyCode) bool
+	FindExchangeRate(ctx context.Context, targetCurrency entities.CurrencyCode, transactionDate time.Time) (*entities.ExchangeRate, error)
+	FindConversionRate(ctx context.Context, from, to entities.CurrencyCode, transactionDate time.Time) (*entities.ExchangeRate, error)
+	RateWindow() entities.RateWindow
+	Rounding() entities.RoundingMode
+}
+
 // ListTransactionsUseCase handles the business logic for listing transactions with pagination
 type ListTransactionsUseCase struct {
 	transactionRepo repositories.TransactionRepository
+	rateFinder      ExchangeRateFinder
+	margins         *MarginPolicy
 	validator       *validator.Validate
 }
 
 // NewListTransactionsUseCase creates a new instance of ListTransactionsUseCase
+// rateFinder may be nil, in which case requests with a currency are rejected
 func NewListTransactionsUseCase(
 	transactionRepo repositories.TransactionRepository,
+	rateFinder ExchangeRateFinder,
 	validator *validator.Validate,
 ) *ListTransactionsUseCase {
 	return &ListTransactionsUseCase{
 		transactionRepo: transactionRepo,
+		rateFinder:      rateFinder,
 		validator:       validator,
 	}
 }
 
+// WithMargins applies the caller's conversion margin to converted items, as the convert endpoints do
+// Without it converted items use the raw rate
+func (uc *ListTransactionsUseCase) WithMargins(margins *MarginPolicy) *ListTransactionsUseCase {
+	uc.margins = margins
+	return uc
+}
+
 // Execute retrieves a paginated list of transactions
-func (uc *ListTransactionsUseCase) Execute(request *dto.ListTransactionsRequest) (*dto.ListTransactionsResponse, error) {
+func (uc *ListTransactionsUseCase) Execute(ctx context.Context, request *dto.ListTransactionsRequest) (*dto.ListTransactionsResponse, error) {
 	// Validate and set defaults for request
 	if err := uc.validateAndSetDefaults(request); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
 	}
 
-	// Get paginated transactions from repository
-	transactions, total, err := uc.transactionRepo.GetAllPaginated(request.Page, request.Size)
+	list := uc.listPage
+	if request.Cursor != nil || request.ByCursor {
+		list = uc.listAfterCursor
+	}
+	transactions, response, err := list(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve transactions: %w", err)
 	}
 
-	// Convert to response DTO with pagination metadata
-	response := dto.NewListTransactionsResponse(transactions, request.Page, request.Size, total)
+	// Optionally convert every item in the page to the requested currency
+	if request.Currency != "" {
+		uc.applyConversions(ctx, response, transactions, request.Currency, uc.margins.For(request.APIKey))
+	}
 
 	return response, nil
 }
 
+// listPage retrieves the numbered page of transactions (or the trash) with pagination metadata
+func (uc *ListTransactionsUseCase) listPage(request *dto.ListTransactionsRequest) ([]entities.Transaction, *dto.ListTransactionsResponse, error) {
+	listPage := uc.transactionRepo.GetAllPaginated
+	filter := request.Filter()
+	switch {
+	case request.Trash:
+		listPage = uc.transactionRepo.GetDeletedPaginated
+	case request.IncludeArchived:
+		listPage = uc.transactionRepo.GetAllPaginatedWithArchived
+	case !filter.IsEmpty():
+		listPage = func(page, size int) ([]entities.Transaction, int64, error) {
+			return uc.transactionRepo.FindPaginated(filter, page, size)
+		}
+	}
+
+	transactions, total, err := listPage(request.Page, request.Size)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := dto.NewListTransactionsResponse(transactions, request.Page, request.Size, total)
+
+	// Active listings also hand out a cursor, so clients can continue without offsets after any page
+	if !request.Trash && !request.IncludeArchived && request.Page < response.TotalPages && len(transactions) > 0 {
+		response.NextCursor = entities.CursorOf(transactions[len(transactions)-1]).Encode()
+	}
+	return transactions, response, nil
+}
+
+// listAfterCursor retrieves the transactions following request.Cursor, or the first ones when it is nil, without counting the whole listing
+func (uc *ListTransactionsUseCase) listAfterCursor(request *dto.ListTransactionsRequest) ([]entities.Transaction, *dto.ListTransactionsResponse, error) {
+	// One extra row tells whether another page follows
+	transactions, err := uc.transactionRepo.FindAfter(request.Filter(), request.Cursor, request.Size+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nextCursor string
+	if len(transactions) > request.Size {
+		transactions = transactions[:request.Size]
+		nextCursor = entities.CursorOf(transactions[len(transactions)-1]).Encode()
+	}
+	return transactions, dto.NewCursorListTransactionsResponse(transactions, request.Size, nextCursor), nil
+}
+
+// LastModified returns when the listed data last changed, truncated to HTTP-date precision
+// Lists with a currency are not covered: their conversions depend on rates as well as transactions
+func (uc *ListTransactionsUseCase) LastModified() (time.Time, error) {
+	lastModified, err := uc.transactionRepo.LastModified()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to determine last modification time: %w", err)
+	}
+	return lastModified.UTC().Truncate(time.Second), nil
+}
+
+// applyConversions converts each transaction in the page with the margin on top of the raw rate, recording per-item errors
+// Rates are looked up once per distinct source currency and transaction date
+func (uc *ListTransactionsUseCase) applyConversions(
+	ctx context.Context,
+	response *dto.ListTransactionsResponse,
+	transactions []entities.Transaction,
+	currency entities.CurrencyCode,
+	marginBps int,
+) {
+	type lookup struct {
+		rate *entities.ExchangeRate
+		err  error
+	}
+	type lookupKey struct {
+		from entities.CurrencyCode
+		date int64
+	}
+	rates := make(map[lookupKey]lookup)
+
+	response.Currency = currency
+	for i := range transactions {
+		key := lookupKey{from: transactions[i].SourceCurrency(), date: transactions[i].Date.UnixNano()}
+		found, cached := rates[key]
+		if !cached {
+			rate, err := uc.rateFinder.FindConversionRate(ctx, key.from, currency, transactions[i].Date)
+			found = lookup{rate: rate, err: err}
+			rates[key] = found
+		}
+
+		if found.err != nil {
+			response.Data[i].ConversionError = found.err.Error()
+			continue
+		}
+
+		pricedRate := *found.rate
+		pricedRate.Rate = entities.ApplyMargin(found.rate.Rate, marginBps)
+		convertedTx, err := entities.NewConvertedTransaction(transactions[i], currency, &pricedRate, uc.rateFinder.RateWindow(), uc.rateFinder.Rounding())
+		if err != nil {
+			response.Data[i].ConversionError = err.Error()
+			continue
+		}
+
+		response.Data[i].ApplyConversion(convertedTx)
+	}
+}
+
 // validateAndSetDefaults validates the request and sets default values
 func (uc *ListTransactionsUseCase) validateAndSetDefaults(request *dto.ListTransactionsRequest) error {
 	if request == nil {
@@ -69,10 +202,42 @@ func (uc *ListTransactionsUseCase) validateAndSetDefaults(request *dto.ListTrans
 		return fmt.Errorf("size cannot exceed 100")
 	}
 
+	// The trash only holds active-table rows; archived transactions never enter it
+	if request.Trash && request.IncludeArchived {
+		return fmt.Errorf("trash cannot be combined with include_archived")
+	}
+
+	// Cursors are positions in the active listing, which they continue instead of a page number
+	byCursor := request.Cursor != nil || request.ByCursor
+	if byCursor && (request.Trash || request.IncludeArchived) {
+		return fmt.Errorf("cursor cannot be combined with trash or include_archived")
+	}
+	if byCursor && request.Page > 1 {
+		return fmt.Errorf("page cannot be combined with cursor")
+	}
+
+	// Filters only apply to active transactions
+	if (request.Trash || request.IncludeArchived) && !request.Filter().IsEmpty() {
+		return fmt.Errorf("filters cannot be combined with trash or include_archived")
+	}
+	if request.DateFrom != nil && request.DateTo != nil && request.DateTo.Before(*request.DateFrom) {
+		return fmt.Errorf("date_to cannot be before date_from")
+	}
+	if request.MinAmount != nil && request.MaxAmount != nil && *request.MaxAmount < *request.MinAmount {
+		return fmt.Errorf("max_amount cannot be less than min_amount")
+	}
+
 	// Use validator for struct validation
 	if err := uc.validator.Struct(request); err != nil {
 		return err
 	}
 
+	// Conversion target must be supported by the rate provider
+	if request.Currency != "" {
+		if uc.rateFinder == nil || !uc.rateFinder.SupportsCurrency(request.Currency) {
+			return fmt.Errorf("currency %s is not supported for conversion", request.Currency)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,311 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+const (
+	// apiTokenPrefix marks issued secrets so they are recognisable in configs and secret scanners
+	apiTokenPrefix = "pta_"
+
+	// apiTokenDisplayLength is how many leading characters of a secret are stored to identify it
+	apiTokenDisplayLength = 12
+
+	// lastUsedResolution limits how often authentication writes the last-used time of a token
+	lastUsedResolution = time.Minute
+
+	// bootstrapTokenName names the admin token seeded from configuration
+	bootstrapTokenName = "bootstrap"
+)
+
+// ManageAPITokensUseCase handles issuing, rotating, revoking and checking API tokens
+type ManageAPITokensUseCase struct {
+	tokenRepo repositories.APITokenRepository
+	validator *validator.Validate
+	clock     clock.Clock
+}
+
+// NewManageAPITokensUseCase creates a new instance of ManageAPITokensUseCase
+func NewManageAPITokensUseCase(tokenRepo repositories.APITokenRepository, validator *validator.Validate) *ManageAPITokensUseCase {
+	return &ManageAPITokensUseCase{
+		tokenRepo: tokenRepo,
+		validator: validator,
+		clock:     clock.System(),
+	}
+}
+
+// WithClock sets the clock token expiry is checked against
+func (uc *ManageAPITokensUseCase) WithClock(clk clock.Clock) *ManageAPITokensUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Create issues a new token and returns its secret, which is not stored
+func (uc *ManageAPITokensUseCase) Create(request *dto.CreateAPITokenRequest) (*dto.IssuedAPITokenResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+
+	request.Name = strings.TrimSpace(request.Name)
+	request.Role = entities.APIRole(strings.ToLower(strings.TrimSpace(string(request.Role))))
+
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	now := uc.clock.Now().UTC()
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(now) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: expires_at must be in the future")
+	}
+
+	return uc.issue(request.Name, request.Role, request.ExpiresAt, now)
+}
+
+// Get retrieves a token by ID
+func (uc *ManageAPITokensUseCase) Get(id uuid.UUID) (*dto.APITokenResponse, error) {
+	token, err := uc.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewAPITokenResponse(token, uc.clock.Now().UTC()), nil
+}
+
+// List retrieves every token, including expired and revoked ones
+func (uc *ManageAPITokensUseCase) List() (*dto.ListAPITokensResponse, error) {
+	tokens, err := uc.tokenRepo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve api tokens: %w", err)
+	}
+
+	now := uc.clock.Now().UTC()
+	response := &dto.ListAPITokensResponse{Data: make([]dto.APITokenResponse, len(tokens))}
+	for i := range tokens {
+		response.Data[i] = *dto.NewAPITokenResponse(&tokens[i], now)
+	}
+
+	return response, nil
+}
+
+// Rotate issues a new secret with the same name and role and retires the old one
+// The old secret stops working immediately, or after the grace period so clients can switch over
+func (uc *ManageAPITokensUseCase) Rotate(id uuid.UUID, request *dto.RotateAPITokenRequest) (*dto.IssuedAPITokenResponse, error) {
+	if request == nil {
+		request = &dto.RotateAPITokenRequest{}
+	}
+
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	token, err := uc.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.clock.Now().UTC()
+	if token.Status(now) == entities.TokenRevoked || token.ReplacedByID != nil {
+		return nil, errs.Newf(errs.ErrConflict, "conflict: api token %s has already been revoked or rotated", id)
+	}
+
+	expiresAt := token.ExpiresAt
+	if request.ExpiresAt != nil {
+		expiresAt = request.ExpiresAt
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: expires_at must be in the future")
+	}
+
+	issued, err := uc.issue(token.Name, token.Role, expiresAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	retireAt := now.Add(time.Duration(request.GracePeriodMinutes) * time.Minute)
+	token.ReplacedByID = &issued.ID
+	if request.GracePeriodMinutes == 0 {
+		token.RevokedAt = &retireAt
+	} else if token.ExpiresAt == nil || token.ExpiresAt.After(retireAt) {
+		token.ExpiresAt = &retireAt
+	}
+	if err := uc.tokenRepo.Update(token); err != nil {
+		return nil, fmt.Errorf("failed to retire rotated api token: %w", err)
+	}
+
+	return issued, nil
+}
+
+// Revoke stops a token from authenticating; revoking a revoked token is not an error
+// The last active admin token cannot be revoked, since issuing a new one requires an admin token
+func (uc *ManageAPITokensUseCase) Revoke(id uuid.UUID) error {
+	token, err := uc.find(id)
+	if err != nil {
+		return err
+	}
+
+	now := uc.clock.Now().UTC()
+	if token.Status(now) == entities.TokenRevoked {
+		return nil
+	}
+
+	if token.Role == entities.RoleAdmin && token.IsActive(now) {
+		admins, err := uc.CountActiveAdmins()
+		if err != nil {
+			return err
+		}
+		if admins <= 1 {
+			return errs.Newf(errs.ErrConflict, "conflict: cannot revoke the last active admin token")
+		}
+	}
+
+	token.RevokedAt = &now
+	if err := uc.tokenRepo.Update(token); err != nil {
+		return fmt.Errorf("failed to revoke api token: %w", err)
+	}
+
+	return nil
+}
+
+// CountActiveAdmins returns how many admin tokens can currently authenticate
+func (uc *ManageAPITokensUseCase) CountActiveAdmins() (int64, error) {
+	admins, err := uc.tokenRepo.CountActive(entities.RoleAdmin)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count admin tokens: %w", err)
+	}
+	return admins, nil
+}
+
+// Authenticate resolves a secret to its token, failing when it is unknown, expired or revoked
+func (uc *ManageAPITokensUseCase) Authenticate(secret string) (*entities.APIToken, error) {
+	if secret == "" {
+		return nil, errs.Newf(errs.ErrUnauthorized, "api token required")
+	}
+
+	token, err := uc.tokenRepo.GetByHash(hashAPIToken(secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up api token: %w", err)
+	}
+	if token == nil {
+		return nil, errs.Newf(errs.ErrUnauthorized, "invalid api token")
+	}
+
+	now := uc.clock.Now().UTC()
+	switch token.Status(now) {
+	case entities.TokenRevoked:
+		return nil, errs.Newf(errs.ErrUnauthorized, "api token revoked")
+	case entities.TokenExpired:
+		return nil, errs.Newf(errs.ErrUnauthorized, "api token expired")
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedResolution {
+		token.LastUsedAt = &now
+		// Usage tracking is best effort; a failed write must not reject a valid token
+		_ = uc.tokenRepo.Update(token)
+	}
+
+	return token, nil
+}
+
+// EnsureBootstrapToken stores secret as an admin token unless it is already known
+// It lets operators issue the first tokens when the API requires them; rotate it away afterwards
+func (uc *ManageAPITokensUseCase) EnsureBootstrapToken(secret string) error {
+	if len(secret) < 32 {
+		return errs.Newf(errs.ErrValidation, "validation failed: bootstrap token must be at least 32 characters")
+	}
+
+	existing, err := uc.tokenRepo.GetByHash(hashAPIToken(secret))
+	if err != nil {
+		return fmt.Errorf("failed to look up bootstrap token: %w", err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	token := &entities.APIToken{
+		ID:        uuid.New(),
+		Name:      bootstrapTokenName,
+		Prefix:    displayPrefix(secret),
+		TokenHash: hashAPIToken(secret),
+		Role:      entities.RoleAdmin,
+	}
+	if err := uc.tokenRepo.Create(token); err != nil {
+		return fmt.Errorf("failed to save bootstrap token: %w", err)
+	}
+
+	return nil
+}
+
+// issue generates a secret and stores the hash of it
+func (uc *ManageAPITokensUseCase) issue(name string, role entities.APIRole, expiresAt *time.Time, now time.Time) (*dto.IssuedAPITokenResponse, error) {
+	secret, err := generateAPIToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api token: %w", err)
+	}
+
+	token := &entities.APIToken{
+		ID:        uuid.New(),
+		Name:      name,
+		Prefix:    displayPrefix(secret),
+		TokenHash: hashAPIToken(secret),
+		Role:      role,
+		ExpiresAt: expiresAt,
+	}
+	if err := uc.tokenRepo.Create(token); err != nil {
+		return nil, fmt.Errorf("failed to save api token: %w", err)
+	}
+
+	return &dto.IssuedAPITokenResponse{
+		APITokenResponse: *dto.NewAPITokenResponse(token, now),
+		Token:            secret,
+	}, nil
+}
+
+// find loads a token, failing with a not found error when it does not exist
+func (uc *ManageAPITokensUseCase) find(id uuid.UUID) (*entities.APIToken, error) {
+	token, err := uc.tokenRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve api token: %w", err)
+	}
+	if token == nil {
+		return nil, errs.Newf(errs.ErrNotFound, "api token with ID %s not found", id)
+	}
+
+	return token, nil
+}
+
+// generateAPIToken returns a new secret with 256 bits of randomness
+func generateAPIToken() (string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return apiTokenPrefix + hex.EncodeToString(random), nil
+}
+
+// hashAPIToken derives the stored form of a secret
+// Secrets are random and long, so a fast unsalted hash is enough to make a leaked table useless
+func hashAPIToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// displayPrefix returns the leading characters of a secret shown in listings
+func displayPrefix(secret string) string {
+	if len(secret) > apiTokenDisplayLength {
+		return secret[:apiTokenDisplayLength]
+	}
+	return secret
+}
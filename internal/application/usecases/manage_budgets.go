@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// ManageBudgetsUseCase handles creating, reading, replacing and deleting budgets
+type ManageBudgetsUseCase struct {
+	budgetRepo repositories.BudgetRepository
+	rateFinder ExchangeRateFinder
+	validator  *validator.Validate
+}
+
+// NewManageBudgetsUseCase creates a new instance of ManageBudgetsUseCase
+func NewManageBudgetsUseCase(
+	budgetRepo repositories.BudgetRepository,
+	rateFinder ExchangeRateFinder,
+	validator *validator.Validate,
+) *ManageBudgetsUseCase {
+	return &ManageBudgetsUseCase{
+		budgetRepo: budgetRepo,
+		rateFinder: rateFinder,
+		validator:  validator,
+	}
+}
+
+// Create stores a new budget
+func (uc *ManageBudgetsUseCase) Create(request *dto.BudgetRequest) (*dto.BudgetResponse, error) {
+	budget, err := uc.toBudget(request, uuid.New())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.budgetRepo.Save(budget); err != nil {
+		return nil, fmt.Errorf("failed to save budget: %w", err)
+	}
+
+	return dto.NewBudgetResponse(budget), nil
+}
+
+// Get retrieves a budget by ID
+func (uc *ManageBudgetsUseCase) Get(id uuid.UUID) (*dto.BudgetResponse, error) {
+	budget, err := uc.budgetRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve budget: %w", err)
+	}
+	if budget == nil {
+		return nil, errs.Newf(errs.ErrNotFound, "budget with ID %s not found", id)
+	}
+
+	return dto.NewBudgetResponse(budget), nil
+}
+
+// List retrieves every budget
+func (uc *ManageBudgetsUseCase) List() (*dto.ListBudgetsResponse, error) {
+	budgets, err := uc.budgetRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve budgets: %w", err)
+	}
+
+	return dto.NewListBudgetsResponse(budgets), nil
+}
+
+// Update replaces a budget; the new limit and thresholds apply to transactions arriving afterwards
+func (uc *ManageBudgetsUseCase) Update(id uuid.UUID, request *dto.BudgetRequest) (*dto.BudgetResponse, error) {
+	budget, err := uc.toBudget(request, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.budgetRepo.Update(budget); err != nil {
+		return nil, fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	updated, err := uc.budgetRepo.GetByID(id)
+	if err != nil || updated == nil {
+		return dto.NewBudgetResponse(budget), nil
+	}
+	return dto.NewBudgetResponse(updated), nil
+}
+
+// Delete removes a budget
+func (uc *ManageBudgetsUseCase) Delete(id uuid.UUID) error {
+	if err := uc.budgetRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+	return nil
+}
+
+// toBudget validates the request and builds the budget entity
+// A budget in another currency needs exchange rates for it, or its spend could never be evaluated
+func (uc *ManageBudgetsUseCase) toBudget(request *dto.BudgetRequest, id uuid.UUID) (*entities.Budget, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+
+	request.Category = strings.TrimSpace(request.Category)
+	request.Period = entities.BudgetPeriod(strings.ToLower(strings.TrimSpace(string(request.Period))))
+	request.Currency = entities.CurrencyCode(strings.ToUpper(strings.TrimSpace(string(request.Currency))))
+
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	budget := request.ToEntity(id)
+	if budget.Currency != entities.USD && !uc.rateFinder.SupportsCurrency(budget.Currency) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: unsupported budget currency: %s", budget.Currency)
+	}
+	if err := budget.Validate(); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	return budget, nil
+}
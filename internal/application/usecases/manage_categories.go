@@ -0,0 +1,216 @@
+package usecases
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// ManageCategoriesUseCase handles creating, reading, replacing and deleting categories
+// Transactions and budgets reference categories by name, so renames follow through to them
+type ManageCategoriesUseCase struct {
+	categoryRepo    repositories.CategoryRepository
+	transactionRepo repositories.TransactionRepository
+	budgetRepo      repositories.BudgetRepository
+	unitOfWork      repositories.UnitOfWork
+	validator       *validator.Validate
+}
+
+// NewManageCategoriesUseCase creates a new instance of ManageCategoriesUseCase
+func NewManageCategoriesUseCase(
+	categoryRepo repositories.CategoryRepository,
+	transactionRepo repositories.TransactionRepository,
+	budgetRepo repositories.BudgetRepository,
+	validator *validator.Validate,
+) *ManageCategoriesUseCase {
+	return &ManageCategoriesUseCase{
+		categoryRepo:    categoryRepo,
+		transactionRepo: transactionRepo,
+		budgetRepo:      budgetRepo,
+		validator:       validator,
+	}
+}
+
+// WithUnitOfWork makes a rename update the category, its transactions and its budgets atomically
+// Without it a failure part way through leaves the earlier writes in place
+func (uc *ManageCategoriesUseCase) WithUnitOfWork(unitOfWork repositories.UnitOfWork) *ManageCategoriesUseCase {
+	uc.unitOfWork = unitOfWork
+	return uc
+}
+
+// Create stores a new category; names are unique ignoring case
+func (uc *ManageCategoriesUseCase) Create(request *dto.CategoryRequest) (*dto.CategoryResponse, error) {
+	category, err := uc.toCategory(request, uuid.New())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.categoryRepo.Save(category); err != nil {
+		return nil, fmt.Errorf("failed to save category: %w", err)
+	}
+
+	return dto.NewCategoryResponse(category), nil
+}
+
+// Get retrieves a category by ID
+func (uc *ManageCategoriesUseCase) Get(id uuid.UUID) (*dto.CategoryResponse, error) {
+	category, err := uc.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewCategoryResponse(category), nil
+}
+
+// List retrieves every category
+func (uc *ManageCategoriesUseCase) List() (*dto.ListCategoriesResponse, error) {
+	categories, err := uc.categoryRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve categories: %w", err)
+	}
+
+	return dto.NewListCategoriesResponse(categories), nil
+}
+
+// Update replaces a category; a new name is carried over to its transactions and budgets
+func (uc *ManageCategoriesUseCase) Update(id uuid.UUID, request *dto.CategoryRequest) (*dto.CategoryResponse, error) {
+	category, err := uc.toCategory(request, id)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.atomically(func(repos repositories.Repositories) error {
+		return uc.replace(repos, existing, category)
+	}); err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.categoryRepo.GetByID(id)
+	if err != nil || updated == nil {
+		return dto.NewCategoryResponse(category), nil
+	}
+	return dto.NewCategoryResponse(updated), nil
+}
+
+// replace stores category over existing and moves the transactions and budgets of a renamed category
+func (uc *ManageCategoriesUseCase) replace(repos repositories.Repositories, existing, category *entities.Category) error {
+	if err := repos.Categories.Update(category); err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+	if existing.Name == category.Name {
+		return nil
+	}
+
+	transactions, err := repos.Transactions.RenameCategory(existing.Name, category.Name)
+	if err != nil {
+		return fmt.Errorf("failed to move transactions to category %q: %w", category.Name, err)
+	}
+	budgets, err := repos.Budgets.RenameCategory(existing.Name, category.Name)
+	if err != nil {
+		return fmt.Errorf("failed to move budgets to category %q: %w", category.Name, err)
+	}
+	slog.Info("Category renamed", "from", existing.Name, "to", category.Name,
+		"transactions", transactions, "budgets", budgets)
+	return nil
+}
+
+// atomically runs fn in the unit of work, or directly on the use case's repositories when there is none
+func (uc *ManageCategoriesUseCase) atomically(fn func(repos repositories.Repositories) error) error {
+	if uc.unitOfWork == nil {
+		return fn(repositories.Repositories{
+			Transactions: uc.transactionRepo,
+			Categories:   uc.categoryRepo,
+			Budgets:      uc.budgetRepo,
+		})
+	}
+	return uc.unitOfWork.Do(fn)
+}
+
+// Delete removes a category that no transaction or budget uses
+func (uc *ManageCategoriesUseCase) Delete(id uuid.UUID) error {
+	category, err := uc.find(id)
+	if err != nil {
+		return err
+	}
+
+	transactions, err := uc.transactionRepo.CountByCategory(category.Name)
+	if err != nil {
+		return fmt.Errorf("failed to count transactions of category %q: %w", category.Name, err)
+	}
+	budgets, err := uc.budgetRepo.FindByCategory(category.Name)
+	if err != nil {
+		return fmt.Errorf("failed to find budgets of category %q: %w", category.Name, err)
+	}
+	if transactions > 0 || len(budgets) > 0 {
+		return errs.Newf(errs.ErrConflict, "category %q is used by %d transactions and %d budgets",
+			category.Name, transactions, len(budgets))
+	}
+
+	if err := uc.categoryRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	return nil
+}
+
+// find retrieves a category, failing with ErrNotFound when it does not exist
+func (uc *ManageCategoriesUseCase) find(id uuid.UUID) (*entities.Category, error) {
+	category, err := uc.categoryRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve category: %w", err)
+	}
+	if category == nil {
+		return nil, errs.Newf(errs.ErrNotFound, "category with ID %s not found", id)
+	}
+	return category, nil
+}
+
+// toCategory validates the request and builds the category entity
+func (uc *ManageCategoriesUseCase) toCategory(request *dto.CategoryRequest, id uuid.UUID) (*entities.Category, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+
+	request.Name = strings.TrimSpace(request.Name)
+	request.Description = strings.TrimSpace(request.Description)
+
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	category := request.ToEntity(id)
+	if err := category.Validate(); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	return category, nil
+}
+
+// resolveCategory returns the stored spelling of a category name, failing validation when no such category exists
+// An empty name means no category
+func resolveCategory(categoryRepo repositories.CategoryRepository, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil
+	}
+
+	category, err := categoryRepo.GetByName(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up category %q: %w", name, err)
+	}
+	if category == nil {
+		return "", errs.Newf(errs.ErrValidation, "validation failed: category %q does not exist", name)
+	}
+	return category.Name, nil
+}
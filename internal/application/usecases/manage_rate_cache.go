@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
+)
+
+// RateCacheStatsSource reports rate cache hits and misses per currency
+// Implemented by activity.Recorder
+type RateCacheStatsSource interface {
+	RateCacheStats() map[string]activity.RateCacheCounts
+}
+
+// ManageRateCacheUseCase inspects and evicts the exchange rates cached from the Treasury
+type ManageRateCacheUseCase struct {
+	exchangeRateRepo repositories.ExchangeRateRepository
+	stats            RateCacheStatsSource
+	statsSince       time.Time
+}
+
+// NewManageRateCacheUseCase creates a new instance of ManageRateCacheUseCase
+// statsSince is when the stats source started counting, normally the process start
+func NewManageRateCacheUseCase(
+	exchangeRateRepo repositories.ExchangeRateRepository,
+	stats RateCacheStatsSource,
+	statsSince time.Time,
+) *ManageRateCacheUseCase {
+	return &ManageRateCacheUseCase{
+		exchangeRateRepo: exchangeRateRepo,
+		stats:            stats,
+		statsSince:       statsSince,
+	}
+}
+
+// Inspect reports the cached rates and lookup counts of every currency, or of one when currency is set
+func (uc *ManageRateCacheUseCase) Inspect(currency string) (*dto.RateCacheResponse, error) {
+	var only entities.CurrencyCode
+	if currency != "" {
+		code, err := entities.NewCurrencyCode(currency)
+		if err != nil {
+			return nil, errs.Newf(errs.ErrValidation, "validation failed: invalid currency: %s", currency)
+		}
+		only = code
+	}
+
+	summaries, err := uc.exchangeRateRepo.SummarizeByCurrency()
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize cached rates: %w", err)
+	}
+
+	byCurrency := make(map[entities.CurrencyCode]*dto.CurrencyRateCache)
+	entry := func(code entities.CurrencyCode) *dto.CurrencyRateCache {
+		if byCurrency[code] == nil {
+			byCurrency[code] = &dto.CurrencyRateCache{Currency: code}
+		}
+		return byCurrency[code]
+	}
+
+	for _, summary := range summaries {
+		if only != "" && summary.Currency != only {
+			continue
+		}
+		oldest, newest := summary.OldestEffectiveDate, summary.NewestEffectiveDate
+		cache := entry(summary.Currency)
+		cache.CachedRates = summary.Rates
+		cache.OldestEffectiveDate = &oldest
+		cache.NewestEffectiveDate = &newest
+	}
+
+	if uc.stats != nil {
+		for code, counts := range uc.stats.RateCacheStats() {
+			if only != "" && entities.CurrencyCode(code) != only {
+				continue
+			}
+			cache := entry(entities.CurrencyCode(code))
+			cache.Hits = counts.Hits
+			cache.Misses = counts.Misses
+		}
+	}
+
+	if only != "" {
+		entry(only) // Report a currency with nothing cached rather than an empty list
+	}
+
+	response := &dto.RateCacheResponse{
+		StatsSince: uc.statsSince,
+		Currencies: make([]dto.CurrencyRateCache, 0, len(byCurrency)),
+	}
+	var cachedRates, hits, misses int64
+	for _, cache := range byCurrency {
+		cache.RateCacheStats = dto.NewRateCacheStats(cache.CachedRates, cache.Hits, cache.Misses)
+		response.Currencies = append(response.Currencies, *cache)
+		cachedRates += cache.CachedRates
+		hits += cache.Hits
+		misses += cache.Misses
+	}
+	sort.Slice(response.Currencies, func(i, j int) bool {
+		return response.Currencies[i].Currency < response.Currencies[j].Currency
+	})
+	response.Totals = dto.NewRateCacheStats(cachedRates, hits, misses)
+
+	return response, nil
+}
+
+// Evict deletes cached rates so the next conversion fetches them from the Treasury again
+// Evicting every currency must be asked for explicitly with All
+func (uc *ManageRateCacheUseCase) Evict(request *dto.EvictRateCacheRequest) (*dto.EvictRateCacheResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+
+	response := &dto.EvictRateCacheResponse{}
+	currency := strings.TrimSpace(request.Currency)
+	if currency == "" {
+		if !request.All {
+			return nil, errs.Newf(errs.ErrValidation, "validation failed: currency is required unless all=true")
+		}
+		if request.EffectiveDate != "" {
+			return nil, errs.Newf(errs.ErrValidation, "validation failed: effective_date requires a currency")
+		}
+	} else {
+		code, err := entities.NewCurrencyCode(currency)
+		if err != nil {
+			return nil, errs.Newf(errs.ErrValidation, "validation failed: invalid currency: %s", currency)
+		}
+		response.Currency = code
+	}
+
+	var effectiveDate *time.Time
+	if request.EffectiveDate != "" {
+		date, err := time.Parse(time.DateOnly, request.EffectiveDate)
+		if err != nil {
+			return nil, errs.Newf(errs.ErrValidation, "validation failed: invalid effective_date %q: expected YYYY-MM-DD", request.EffectiveDate)
+		}
+		effectiveDate = &date
+		response.EffectiveDate = request.EffectiveDate
+	}
+
+	evicted, err := uc.exchangeRateRepo.Purge(response.Currency, effectiveDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evict cached rates: %w", err)
+	}
+	response.Evicted = evicted
+
+	return response, nil
+}
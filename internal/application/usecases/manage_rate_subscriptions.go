@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// anonymousSubscriber owns the subscriptions of clients that send no API key
+const anonymousSubscriber = "anonymous"
+
+// ManageRateSubscriptionsUseCase handles clients subscribing to currencies whose rates the sync keeps fresh
+type ManageRateSubscriptionsUseCase struct {
+	subscriptionRepo repositories.RateSubscriptionRepository
+	exchangeRateRepo repositories.ExchangeRateRepository
+	rateFinder       ExchangeRateFinder
+	freshFor         time.Duration
+	clock            clock.Clock
+}
+
+// NewManageRateSubscriptionsUseCase creates a new instance of ManageRateSubscriptionsUseCase
+// freshFor is how old the newest cached rate may be before it is reported stale
+func NewManageRateSubscriptionsUseCase(
+	subscriptionRepo repositories.RateSubscriptionRepository,
+	exchangeRateRepo repositories.ExchangeRateRepository,
+	rateFinder ExchangeRateFinder,
+	freshFor time.Duration,
+) *ManageRateSubscriptionsUseCase {
+	return &ManageRateSubscriptionsUseCase{
+		subscriptionRepo: subscriptionRepo,
+		exchangeRateRepo: exchangeRateRepo,
+		rateFinder:       rateFinder,
+		freshFor:         freshFor,
+		clock:            clock.System(),
+	}
+}
+
+// WithClock sets the clock the freshness of subscribed rates is judged by
+func (uc *ManageRateSubscriptionsUseCase) WithClock(clk clock.Clock) *ManageRateSubscriptionsUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Subscribe registers the caller's interest in each currency and returns all of its subscriptions
+// Currencies already subscribed are left untouched
+func (uc *ManageRateSubscriptionsUseCase) Subscribe(apiKey string, currencies []string) (*dto.ListRateSubscriptionsResponse, error) {
+	codes := make([]entities.CurrencyCode, 0, len(currencies))
+	for _, currency := range currencies {
+		code, err := entities.NewCurrencyCode(currency)
+		if err != nil || !uc.rateFinder.SupportsCurrency(code) {
+			return nil, errs.Newf(errs.ErrValidation, "validation failed: unsupported currency: %s", currency)
+		}
+		codes = append(codes, code)
+	}
+
+	subscriber := subscriberFor(apiKey)
+	for _, code := range codes {
+		subscription := &entities.RateSubscription{
+			ID:         uuid.New(),
+			Subscriber: subscriber,
+			Currency:   code,
+		}
+		if err := uc.subscriptionRepo.Save(subscription); err != nil {
+			return nil, fmt.Errorf("failed to save rate subscription: %w", err)
+		}
+	}
+
+	return uc.List(apiKey)
+}
+
+// List reports the caller's subscriptions with the freshness of each currency's cached rate
+func (uc *ManageRateSubscriptionsUseCase) List(apiKey string) (*dto.ListRateSubscriptionsResponse, error) {
+	subscriptions, err := uc.subscriptionRepo.FindBySubscriber(subscriberFor(apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve rate subscriptions: %w", err)
+	}
+
+	now := uc.clock.Now().UTC()
+	response := &dto.ListRateSubscriptionsResponse{
+		Data:         make([]dto.RateSubscriptionResponse, 0, len(subscriptions)),
+		FreshForDays: int(uc.freshFor.Hours() / 24),
+	}
+	window := uc.rateFinder.RateWindow()
+	for _, subscription := range subscriptions {
+		latest, err := uc.exchangeRateRepo.FindRateForConversion(entities.USD, subscription.Currency, now, window)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up cached rate for %s: %w", subscription.Currency, err)
+		}
+		response.Data = append(response.Data, dto.NewRateSubscriptionResponse(subscription, latest, now, uc.freshFor, window))
+	}
+
+	return response, nil
+}
+
+// Unsubscribe removes the caller's subscription to a currency
+func (uc *ManageRateSubscriptionsUseCase) Unsubscribe(apiKey string, currency string) error {
+	code, err := entities.NewCurrencyCode(currency)
+	if err != nil {
+		return errs.Newf(errs.ErrValidation, "validation failed: invalid currency: %s", currency)
+	}
+
+	if err := uc.subscriptionRepo.Delete(subscriberFor(apiKey), code); err != nil {
+		return fmt.Errorf("failed to delete rate subscription: %w", err)
+	}
+
+	return nil
+}
+
+// subscriberFor identifies a client by a digest of its API key so keys are never stored
+func subscriberFor(apiKey string) string {
+	if apiKey == "" {
+		return anonymousSubscriber
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:16])
+}
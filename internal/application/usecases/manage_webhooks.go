@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/pkg/webhook"
+)
+
+// webhookDeliveryHistory is how many recent deliveries are reported per webhook
+const webhookDeliveryHistory = 50
+
+// ManageWebhooksUseCase handles registering, reading and deleting webhooks and reporting their deliveries
+type ManageWebhooksUseCase struct {
+	webhookRepo repositories.WebhookRepository
+	validator   *validator.Validate
+}
+
+// NewManageWebhooksUseCase creates a new instance of ManageWebhooksUseCase
+func NewManageWebhooksUseCase(
+	webhookRepo repositories.WebhookRepository,
+	validator *validator.Validate,
+) *ManageWebhooksUseCase {
+	return &ManageWebhooksUseCase{
+		webhookRepo: webhookRepo,
+		validator:   validator,
+	}
+}
+
+// Create registers a webhook with a generated signing secret, returned only in this response
+func (uc *ManageWebhooksUseCase) Create(request *dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+
+	request.URL = strings.TrimSpace(request.URL)
+	for i, event := range request.Events {
+		request.Events[i] = strings.ToLower(strings.TrimSpace(event))
+	}
+
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	hook := request.ToEntity(uuid.New(), secret)
+	if err := hook.Validate(); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	if err := uc.webhookRepo.Save(hook); err != nil {
+		return nil, fmt.Errorf("failed to save webhook: %w", err)
+	}
+
+	response := dto.NewWebhookResponse(hook)
+	response.Secret = secret
+	return response, nil
+}
+
+// Get retrieves a webhook by ID
+func (uc *ManageWebhooksUseCase) Get(id uuid.UUID) (*dto.WebhookResponse, error) {
+	hook, err := uc.webhookRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+	if hook == nil {
+		return nil, errs.Newf(errs.ErrNotFound, "webhook with ID %s not found", id)
+	}
+
+	return dto.NewWebhookResponse(hook), nil
+}
+
+// List retrieves every webhook
+func (uc *ManageWebhooksUseCase) List() (*dto.ListWebhooksResponse, error) {
+	hooks, err := uc.webhookRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+
+	return dto.NewListWebhooksResponse(hooks), nil
+}
+
+// Delete removes a webhook; its pending deliveries are dropped
+func (uc *ManageWebhooksUseCase) Delete(id uuid.UUID) error {
+	if err := uc.webhookRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries reports the most recent deliveries of a webhook, newest first
+func (uc *ManageWebhooksUseCase) ListDeliveries(id uuid.UUID) (*dto.ListWebhookDeliveriesResponse, error) {
+	if _, err := uc.Get(id); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := uc.webhookRepo.FindDeliveries(id, webhookDeliveryHistory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook deliveries: %w", err)
+	}
+
+	return dto.NewListWebhookDeliveriesResponse(deliveries), nil
+}
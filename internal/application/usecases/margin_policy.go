@@ -0,0 +1,52 @@
+package usecases
+
+import "fmt"
+
+// MaxMarginBps is 100% of the rate; margins must stay below it
+const MaxMarginBps = 10000
+
+// MarginPolicy decides the conversion margin, in basis points, applied on top of raw rates
+// A nil policy applies no margin
+type MarginPolicy struct {
+	defaultBps int
+	byAPIKey   map[string]int
+}
+
+// NewMarginPolicy creates a MarginPolicy with a global margin and optional per API key overrides
+func NewMarginPolicy(defaultBps int, byAPIKey map[string]int) (*MarginPolicy, error) {
+	if err := validateMarginBps(defaultBps); err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]int, len(byAPIKey))
+	for apiKey, bps := range byAPIKey {
+		if err := validateMarginBps(bps); err != nil {
+			return nil, fmt.Errorf("margin for API key %q: %w", apiKey, err)
+		}
+		overrides[apiKey] = bps
+	}
+
+	return &MarginPolicy{
+		defaultBps: defaultBps,
+		byAPIKey:   overrides,
+	}, nil
+}
+
+// For returns the margin for the given API key, falling back to the global margin
+func (p *MarginPolicy) For(apiKey string) int {
+	if p == nil {
+		return 0
+	}
+	if bps, ok := p.byAPIKey[apiKey]; ok && apiKey != "" {
+		return bps
+	}
+	return p.defaultBps
+}
+
+// validateMarginBps checks a margin is within 0..MaxMarginBps
+func validateMarginBps(bps int) error {
+	if bps < 0 || bps >= MaxMarginBps {
+		return fmt.Errorf("margin must be between 0 and %d basis points, got %d", MaxMarginBps-1, bps)
+	}
+	return nil
+}
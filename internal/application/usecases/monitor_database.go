@@ -0,0 +1,156 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+)
+
+// quotaCheckTimeout bounds the measurement made before an import
+const quotaCheckTimeout = 5 * time.Second
+
+// StatsProvider reports the database size and row counts
+type StatsProvider interface {
+	Stats(ctx context.Context) (dto.DatabaseStats, error)
+}
+
+// PoolStatsProvider reports the database connection pools; storage without pools need not implement it
+type PoolStatsProvider interface {
+	PoolStats() []dto.ConnectionPoolStats
+}
+
+// ImportGuard decides whether a bulk import may write to the database
+type ImportGuard interface {
+	AllowImport() error
+}
+
+// MonitorDatabaseUseCase measures database growth against a soft quota, alerting when usage crosses thresholds
+type MonitorDatabaseUseCase struct {
+	stats        StatsProvider
+	quotaBytes   int64
+	warnPercent  int
+	blockImports bool
+	clock        clock.Clock
+
+	mu         sync.Mutex
+	lastStatus string
+}
+
+// NewMonitorDatabaseUseCase creates a new instance of MonitorDatabaseUseCase
+// A quotaBytes of zero disables the quota; warnPercent is the share of the quota that raises a warning
+func NewMonitorDatabaseUseCase(stats StatsProvider, quotaBytes int64, warnPercent int, blockImports bool) *MonitorDatabaseUseCase {
+	if warnPercent < 1 || warnPercent > 100 {
+		warnPercent = 80
+	}
+
+	return &MonitorDatabaseUseCase{
+		stats:        stats,
+		quotaBytes:   quotaBytes,
+		warnPercent:  warnPercent,
+		blockImports: blockImports,
+		lastStatus:   dto.QuotaOK,
+		clock:        clock.System(),
+	}
+}
+
+// WithClock sets the clock quota checks are dated by
+func (uc *MonitorDatabaseUseCase) WithClock(clk clock.Clock) *MonitorDatabaseUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Execute measures the database and logs an alert whenever the quota status gets worse
+func (uc *MonitorDatabaseUseCase) Execute(ctx context.Context) (*dto.DatabaseUsageResponse, error) {
+	stats, err := uc.stats.Stats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure database: %w", err)
+	}
+
+	response := &dto.DatabaseUsageResponse{
+		SizeBytes:   stats.SizeBytes,
+		RowCounts:   stats.RowCounts,
+		QuotaStatus: dto.QuotaDisabled,
+		CheckedAt:   uc.clock.Now().UTC(),
+		Pools:       uc.ConnectionPools(),
+	}
+	if uc.quotaBytes <= 0 {
+		return response, nil
+	}
+
+	response.QuotaBytes = uc.quotaBytes
+	response.UsagePercent = float64(stats.SizeBytes) * 100 / float64(uc.quotaBytes)
+	response.QuotaStatus = dto.QuotaOK
+	if response.UsagePercent >= 100 {
+		response.QuotaStatus = dto.QuotaExceeded
+	} else if response.UsagePercent >= float64(uc.warnPercent) {
+		response.QuotaStatus = dto.QuotaWarning
+	}
+	response.ImportsBlocked = uc.blockImports && response.QuotaStatus == dto.QuotaExceeded
+
+	uc.alert(response)
+	return response, nil
+}
+
+// ConnectionPools reports the connection pools without querying the database, so it works while the pool is saturated
+func (uc *MonitorDatabaseUseCase) ConnectionPools() []dto.ConnectionPoolStats {
+	if pools, ok := uc.stats.(PoolStatsProvider); ok {
+		return pools.PoolStats()
+	}
+	return nil
+}
+
+// AllowImport rejects bulk imports once the quota is exceeded, when blocking is enabled
+// If the database cannot be measured the import is allowed, since the quota is advisory
+func (uc *MonitorDatabaseUseCase) AllowImport() error {
+	if !uc.blockImports || uc.quotaBytes <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), quotaCheckTimeout)
+	defer cancel()
+
+	usage, err := uc.Execute(ctx)
+	if err != nil {
+		slog.Warn("Could not check database quota before import", "error", err.Error())
+		return nil
+	}
+
+	if usage.ImportsBlocked {
+		return errs.Newf(errs.ErrQuotaExceeded, "database quota exceeded: %d of %d bytes used", usage.SizeBytes, usage.QuotaBytes)
+	}
+	return nil
+}
+
+// alert logs quota status transitions so repeated checks don't repeat the same alert
+func (uc *MonitorDatabaseUseCase) alert(usage *dto.DatabaseUsageResponse) {
+	uc.mu.Lock()
+	previous := uc.lastStatus
+	uc.lastStatus = usage.QuotaStatus
+	uc.mu.Unlock()
+
+	if usage.QuotaStatus == previous {
+		return
+	}
+
+	attrs := []any{
+		"size_bytes", usage.SizeBytes,
+		"quota_bytes", usage.QuotaBytes,
+		"usage_percent", fmt.Sprintf("%.1f", usage.UsagePercent),
+		"previous_status", previous,
+	}
+
+	switch usage.QuotaStatus {
+	case dto.QuotaExceeded:
+		slog.Warn("Database exceeded soft quota", append(attrs, "imports_blocked", usage.ImportsBlocked)...)
+	case dto.QuotaWarning:
+		slog.Warn("Database approaching soft quota", attrs...)
+	default:
+		slog.Info("Database back under soft quota threshold", attrs...)
+	}
+}
@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// outboxEventPublisher stores every event in the outbox for the broker relay before passing it on
+type outboxEventPublisher struct {
+	next       services.EventPublisher
+	outboxRepo repositories.OutboxRepository
+	topic      string
+}
+
+// NewOutboxEventPublisher wraps next so every published event is first stored in the outbox under topic
+// The event is stored before Publish returns, so once a change is made its event survives a broker outage or a restart
+func NewOutboxEventPublisher(
+	next services.EventPublisher,
+	outboxRepo repositories.OutboxRepository,
+	topic string,
+) services.EventPublisher {
+	return &outboxEventPublisher{
+		next:       next,
+		outboxRepo: outboxRepo,
+		topic:      topic,
+	}
+}
+
+// Publish stores the event in the outbox, then hands it to the next publisher even if storing failed
+func (p *outboxEventPublisher) Publish(ctx context.Context, event entities.DomainEvent) error {
+	stored := p.store(event)
+	if err := p.next.Publish(ctx, event); err != nil {
+		return err
+	}
+	return stored
+}
+
+// store encodes the event as an EventMessage and saves it
+func (p *outboxEventPublisher) store(event entities.DomainEvent) error {
+	return storeOutboxMessage(p.outboxRepo, event, p.topic)
+}
+
+// storeOutboxMessage encodes the event as an EventMessage and saves it in outboxRepo under topic
+func storeOutboxMessage(outboxRepo repositories.OutboxRepository, event entities.DomainEvent, topic string) error {
+	message, err := dto.NewEventMessage(event)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox message: %w", err)
+	}
+
+	if err := outboxRepo.Save(entities.NewOutboxMessage(event, topic, payload)); err != nil {
+		return fmt.Errorf("failed to store %s event in the outbox: %w", event.EventName(), err)
+	}
+	return nil
+}
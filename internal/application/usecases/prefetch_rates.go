@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// PrefetchRatesUseCase stores the latest provider rate of a fixed set of currencies ahead of conversions
+// Unlike the subscribed rate sync it does not skip fresh rates, so every run asks the provider for each currency
+type PrefetchRatesUseCase struct {
+	exchangeRateRepo repositories.ExchangeRateRepository
+	treasuryService  services.RateProvider
+	currencies       []entities.CurrencyCode
+	window           entities.RateWindow
+	clock            clock.Clock
+}
+
+// NewPrefetchRatesUseCase creates a new instance of PrefetchRatesUseCase
+func NewPrefetchRatesUseCase(
+	exchangeRateRepo repositories.ExchangeRateRepository,
+	treasuryService services.RateProvider,
+	currencies []entities.CurrencyCode,
+) *PrefetchRatesUseCase {
+	return &PrefetchRatesUseCase{
+		exchangeRateRepo: exchangeRateRepo,
+		treasuryService:  treasuryService,
+		currencies:       currencies,
+		clock:            clock.System(),
+	}
+}
+
+// WithClock sets the clock that decides which rates count as the latest
+func (uc *PrefetchRatesUseCase) WithClock(clk clock.Clock) *PrefetchRatesUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// WithRateWindow sets the lookback window the stored rate is searched in
+// Without it the default 6-month window applies
+func (uc *PrefetchRatesUseCase) WithRateWindow(window entities.RateWindow) *PrefetchRatesUseCase {
+	uc.window = window
+	return uc
+}
+
+// Execute fetches, in one call per currency, every rate published since the newest stored one and stores them,
+// filling any gap left by missed runs; without a stored rate the whole lookback window is fetched
+// A failed currency does not stop the others; the run only fails when ctx is cancelled or a lookup fails
+func (uc *PrefetchRatesUseCase) Execute(ctx context.Context) (*dto.RatePrefetchResult, error) {
+	now := uc.clock.Now().UTC()
+	result := &dto.RatePrefetchResult{Currencies: len(uc.currencies)}
+
+	for _, currency := range uc.currencies {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		latest, err := uc.exchangeRateRepo.FindRateForConversion(entities.USD, currency, now, uc.window)
+		if err != nil {
+			return result, fmt.Errorf("failed to look up stored rate for %s: %w", currency, err)
+		}
+
+		startDate := uc.window.Start(now)
+		if latest != nil {
+			startDate = latest.EffectiveDate.AddDate(0, 0, 1)
+		}
+		rates, err := uc.treasuryService.FetchExchangeRates(ctx, entities.USD, currency, startDate, now)
+		if err != nil {
+			uc.fail(result, currency, fmt.Errorf("failed to fetch exchange rates: %w", err))
+			continue
+		}
+		rates = authoritativeRates(rates)
+
+		if len(rates) == 0 {
+			if latest != nil {
+				result.UpToDate++
+				continue
+			}
+			uc.fail(result, currency, errs.Newf(errs.ErrRateUnavailable, "no exchange rate found for %s within %s of %s", currency, uc.window, now.Format("2006-01-02")))
+			continue
+		}
+
+		if err := uc.exchangeRateRepo.SaveAll(rates); err != nil {
+			uc.fail(result, currency, fmt.Errorf("failed to store exchange rates: %w", err))
+			continue
+		}
+		result.Stored++
+	}
+
+	return result, nil
+}
+
+// fail records a currency that could not be prefetched
+func (uc *PrefetchRatesUseCase) fail(result *dto.RatePrefetchResult, currency entities.CurrencyCode, err error) {
+	result.Failed++
+	if result.Errors == nil {
+		result.Errors = make(map[string]string)
+	}
+	result.Errors[string(currency)] = err.Error()
+}
+
+// authoritativeRates drops the rates published by fallback providers, which are not stored
+func authoritativeRates(rates []entities.ExchangeRate) []entities.ExchangeRate {
+	stored := rates[:0:0]
+	for _, rate := range rates {
+		if rate.IsAuthoritative() {
+			stored = append(stored, rate)
+		}
+	}
+	return stored
+}
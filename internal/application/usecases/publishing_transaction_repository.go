@@ -0,0 +1,161 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// publishingTransactionRepository publishes an event for every transaction it stores, updates, deletes or restores
+type publishingTransactionRepository struct {
+	repositories.TransactionRepository
+	publisher  services.EventPublisher
+	unitOfWork repositories.UnitOfWork // Nil when events are not stored in the outbox
+	topic      string
+	clock      clock.Clock
+}
+
+// NewPublishingTransactionRepository wraps inner so each successful write publishes a domain event
+// Decorating the repository covers every way transactions change: the API, imports and bank sync
+// Bulk category renames are not announced per transaction; events are dated by clk
+func NewPublishingTransactionRepository(
+	inner repositories.TransactionRepository,
+	publisher services.EventPublisher,
+	clk clock.Clock,
+) repositories.TransactionRepository {
+	return &publishingTransactionRepository{
+		TransactionRepository: inner,
+		publisher:             publisher,
+		clock:                 clk,
+	}
+}
+
+// NewOutboxTransactionRepository wraps inner like NewPublishingTransactionRepository, and also stores each event
+// in the outbox under topic within the database transaction of the write, so a change is never kept without its event
+// Writes go through the repositories of unitOfWork, which must share the database of inner
+func NewOutboxTransactionRepository(
+	inner repositories.TransactionRepository,
+	unitOfWork repositories.UnitOfWork,
+	publisher services.EventPublisher,
+	topic string,
+	clk clock.Clock,
+) repositories.TransactionRepository {
+	return &publishingTransactionRepository{
+		TransactionRepository: inner,
+		publisher:             publisher,
+		unitOfWork:            unitOfWork,
+		topic:                 topic,
+		clock:                 clk,
+	}
+}
+
+// Save stores the transaction, then publishes it
+func (r *publishingTransactionRepository) Save(transaction *entities.Transaction) error {
+	return r.change(func(transactions repositories.TransactionRepository) ([]entities.DomainEvent, error) {
+		if err := transactions.Save(transaction); err != nil {
+			return nil, err
+		}
+		return []entities.DomainEvent{entities.TransactionCreatedEvent{EventMeta: entities.NewEventMeta(r.clock), Transaction: *transaction}}, nil
+	})
+}
+
+// SaveAll stores the transactions, then publishes each one
+func (r *publishingTransactionRepository) SaveAll(transactions []entities.Transaction) error {
+	return r.change(func(repo repositories.TransactionRepository) ([]entities.DomainEvent, error) {
+		if err := repo.SaveAll(transactions); err != nil {
+			return nil, err
+		}
+		events := make([]entities.DomainEvent, len(transactions))
+		for i := range transactions {
+			events[i] = entities.TransactionCreatedEvent{EventMeta: entities.NewEventMeta(r.clock), Transaction: transactions[i]}
+		}
+		return events, nil
+	})
+}
+
+// Update stores the changes, then publishes the updated transaction
+func (r *publishingTransactionRepository) Update(transaction *entities.Transaction) error {
+	return r.change(func(transactions repositories.TransactionRepository) ([]entities.DomainEvent, error) {
+		if err := transactions.Update(transaction); err != nil {
+			return nil, err
+		}
+		return []entities.DomainEvent{entities.TransactionUpdatedEvent{EventMeta: entities.NewEventMeta(r.clock), Transaction: *transaction}}, nil
+	})
+}
+
+// Delete moves the transaction to the trash, then publishes its ID
+func (r *publishingTransactionRepository) Delete(id uuid.UUID) error {
+	return r.change(func(transactions repositories.TransactionRepository) ([]entities.DomainEvent, error) {
+		if err := transactions.Delete(id); err != nil {
+			return nil, err
+		}
+		return []entities.DomainEvent{entities.TransactionDeletedEvent{EventMeta: entities.NewEventMeta(r.clock), TransactionID: id}}, nil
+	})
+}
+
+// Restore brings the transaction back from the trash, then publishes it as updated
+func (r *publishingTransactionRepository) Restore(id uuid.UUID) (*entities.Transaction, error) {
+	var restored *entities.Transaction
+	err := r.change(func(transactions repositories.TransactionRepository) ([]entities.DomainEvent, error) {
+		transaction, err := transactions.Restore(id)
+		if err != nil {
+			return nil, err
+		}
+		restored = transaction
+		return []entities.DomainEvent{entities.TransactionUpdatedEvent{EventMeta: entities.NewEventMeta(r.clock), Transaction: *transaction}}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// change makes a write and publishes the events it returns once it is stored
+// With an outbox, the write and its outbox messages commit together; failing to store a message undoes the write
+func (r *publishingTransactionRepository) change(write func(transactions repositories.TransactionRepository) ([]entities.DomainEvent, error)) error {
+	var events []entities.DomainEvent
+	if r.unitOfWork == nil {
+		var err error
+		if events, err = write(r.TransactionRepository); err != nil {
+			return err
+		}
+	} else {
+		err := r.unitOfWork.Do(func(repos repositories.Repositories) error {
+			var err error
+			if events, err = write(repos.Transactions); err != nil {
+				return err
+			}
+			for _, event := range events {
+				if err := storeOutboxMessage(repos.Outbox, event, r.topic); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		r.publish(event)
+	}
+	return nil
+}
+
+// publish announces a change; events hold copies so later changes by the caller are not seen
+// A failure is only logged, since the change is already stored
+func (r *publishingTransactionRepository) publish(event entities.DomainEvent) {
+	if err := r.publisher.Publish(context.Background(), event); err != nil {
+		slog.Warn("Failed to publish transaction event",
+			"error", err.Error(),
+			"event", event.EventName(),
+			"event_id", event.EventID().String(),
+		)
+	}
+}
@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// PurgeTransactionsUseCase permanently removes transactions that have been in the trash for a while
+// Purged transactions can no longer be restored
+type PurgeTransactionsUseCase struct {
+	transactionRepo repositories.TransactionRepository
+	clock           clock.Clock
+}
+
+// NewPurgeTransactionsUseCase creates a new instance of PurgeTransactionsUseCase
+func NewPurgeTransactionsUseCase(transactionRepo repositories.TransactionRepository) *PurgeTransactionsUseCase {
+	return &PurgeTransactionsUseCase{
+		transactionRepo: transactionRepo,
+		clock:           clock.System(),
+	}
+}
+
+// WithClock sets the clock the retention period is measured from
+func (uc *PurgeTransactionsUseCase) WithClock(clk clock.Clock) *PurgeTransactionsUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Execute removes the transactions deleted more than retention ago; a zero retention empties the trash
+func (uc *PurgeTransactionsUseCase) Execute(retention time.Duration) (*dto.PurgeResult, error) {
+	if retention < 0 {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: retention cannot be negative")
+	}
+
+	cutoff := uc.clock.Now().UTC().Add(-retention)
+	purged, err := uc.transactionRepo.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted transactions: %w", err)
+	}
+
+	return &dto.PurgeResult{Cutoff: cutoff, Purged: purged}, nil
+}
@@ -0,0 +1,184 @@
+package usecases
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// RateIngestListener is notified after a new exchange rate has been stored
+type RateIngestListener interface {
+	OnRateIngested(rate *entities.ExchangeRate)
+}
+
+// RefreshConversionsUseCase re-evaluates stored conversions when a rate closer to their transaction date arrives
+type RefreshConversionsUseCase struct {
+	recordRepo repositories.ConversionRecordRepository
+	publisher  services.ConversionEventPublisher
+	window     entities.RateWindow
+	rounding   entities.RoundingMode
+	clock      clock.Clock
+
+	running sync.WaitGroup
+}
+
+// NewRefreshConversionsUseCase creates a new instance of RefreshConversionsUseCase
+func NewRefreshConversionsUseCase(
+	recordRepo repositories.ConversionRecordRepository,
+	publisher services.ConversionEventPublisher,
+) *RefreshConversionsUseCase {
+	return &RefreshConversionsUseCase{
+		recordRepo: recordRepo,
+		publisher:  publisher,
+		clock:      clock.System(),
+	}
+}
+
+// WithClock sets the clock repriced conversions and their events are dated by
+func (uc *RefreshConversionsUseCase) WithClock(clk clock.Clock) *RefreshConversionsUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// WithRateWindow sets the lookback window a repriced conversion's rate must fall in
+// Without it the default 6-month window applies
+func (uc *RefreshConversionsUseCase) WithRateWindow(window entities.RateWindow) *RefreshConversionsUseCase {
+	uc.window = window
+	return uc
+}
+
+// WithRounding sets how repriced amounts between two cents are rounded
+// Without it they are rounded half up
+func (uc *RefreshConversionsUseCase) WithRounding(rounding entities.RoundingMode) *RefreshConversionsUseCase {
+	uc.rounding = rounding
+	return uc
+}
+
+// OnRateIngested refreshes affected conversions in the background so the caller storing the rate is not delayed
+func (uc *RefreshConversionsUseCase) OnRateIngested(rate *entities.ExchangeRate) {
+	if rate == nil {
+		return
+	}
+
+	ingested := *rate
+	uc.running.Add(1)
+	go func() {
+		defer uc.running.Done()
+
+		refreshed, err := uc.Execute(&ingested)
+		if err != nil {
+			slog.Warn("Failed to refresh conversions for new exchange rate",
+				"error", err.Error(),
+				"to_currency", string(ingested.ToCurrency),
+				"effective_date", ingested.EffectiveDate.Format("2006-01-02"),
+			)
+			return
+		}
+		if refreshed > 0 {
+			slog.Info("Refreshed conversions with closer exchange rate",
+				"count", refreshed,
+				"to_currency", string(ingested.ToCurrency),
+				"effective_date", ingested.EffectiveDate.Format("2006-01-02"),
+			)
+		}
+	}()
+}
+
+// Execute supersedes every current conversion record the rate improves, returning how many were replaced
+func (uc *RefreshConversionsUseCase) Execute(rate *entities.ExchangeRate) (int, error) {
+	if rate == nil {
+		return 0, errs.Newf(errs.ErrValidation, "validation failed: exchange rate is required")
+	}
+	if rate.FromCurrency != entities.USD {
+		return 0, nil // Stored conversions are always from USD
+	}
+
+	records, err := uc.recordRepo.FindSupersedable(rate.ToCurrency, rate.EffectiveDate)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find affected conversions: %w", err)
+	}
+
+	refreshed := 0
+	for i := range records {
+		previous := records[i]
+
+		replacement, err := previous.Reprice(rate, uc.window, uc.rounding, uc.clock)
+		if err != nil {
+			return refreshed, fmt.Errorf("failed to reprice conversion %s: %w", previous.ID, err)
+		}
+
+		if err := uc.recordRepo.Supersede(&previous, replacement); err != nil {
+			return refreshed, fmt.Errorf("failed to supersede conversion %s: %w", previous.ID, err)
+		}
+		refreshed++
+
+		event := entities.ConversionSupersededEvent{
+			Previous:    previous,
+			Replacement: *replacement,
+			OccurredAt:  uc.clock.Now().UTC(),
+		}
+		if err := uc.publisher.PublishConversionSuperseded(event); err != nil {
+			// The records are already consistent; a lost event must not undo the refresh
+			slog.Warn("Failed to publish conversion superseded event",
+				"error", err.Error(),
+				"record_id", previous.ID.String(),
+			)
+		}
+	}
+
+	return refreshed, nil
+}
+
+// Wait blocks until every background refresh has finished
+func (uc *RefreshConversionsUseCase) Wait() {
+	uc.running.Wait()
+}
+
+// notifyingExchangeRateRepository tells listeners about every rate it stores
+type notifyingExchangeRateRepository struct {
+	repositories.ExchangeRateRepository
+	listeners []RateIngestListener
+}
+
+// NewNotifyingExchangeRateRepository wraps inner so each successful Save notifies the listeners
+func NewNotifyingExchangeRateRepository(
+	inner repositories.ExchangeRateRepository,
+	listeners ...RateIngestListener,
+) repositories.ExchangeRateRepository {
+	return &notifyingExchangeRateRepository{
+		ExchangeRateRepository: inner,
+		listeners:              listeners,
+	}
+}
+
+// Save stores the rate, then notifies listeners
+func (r *notifyingExchangeRateRepository) Save(exchangeRate *entities.ExchangeRate) error {
+	if err := r.ExchangeRateRepository.Save(exchangeRate); err != nil {
+		return err
+	}
+
+	for _, listener := range r.listeners {
+		listener.OnRateIngested(exchangeRate)
+	}
+	return nil
+}
+
+// SaveAll stores the rates, then notifies listeners of each one
+func (r *notifyingExchangeRateRepository) SaveAll(exchangeRates []entities.ExchangeRate) error {
+	if err := r.ExchangeRateRepository.SaveAll(exchangeRates); err != nil {
+		return err
+	}
+
+	for i := range exchangeRates {
+		for _, listener := range r.listeners {
+			listener.OnRateIngested(&exchangeRates[i])
+		}
+	}
+	return nil
+}
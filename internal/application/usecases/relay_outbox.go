@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// Outbox relay limits
+const (
+	outboxRelayBatchSize = 100             // Messages claimed per run
+	outboxLease          = time.Minute     // Hides claimed messages from other instances while they are published
+	outboxRetryBase      = 2 * time.Second // Wait after the first failed attempt, doubled per further failure
+	maxOutboxRetryDelay  = 5 * time.Minute // Upper bound of the backoff; messages are retried until the broker accepts them
+)
+
+// RelayOutboxUseCase publishes the events stored in the outbox to the message broker
+// Delivery is at least once: a message published just before a crash is published again after it
+type RelayOutboxUseCase struct {
+	outboxRepo repositories.OutboxRepository
+	publisher  services.MessagePublisher
+	retention  time.Duration
+	clock      clock.Clock
+}
+
+// NewRelayOutboxUseCase creates a new instance of RelayOutboxUseCase
+// Published messages are purged once they are older than retention
+func NewRelayOutboxUseCase(
+	outboxRepo repositories.OutboxRepository,
+	publisher services.MessagePublisher,
+	retention time.Duration,
+) *RelayOutboxUseCase {
+	return &RelayOutboxUseCase{
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		retention:  retention,
+		clock:      clock.System(),
+	}
+}
+
+// WithClock sets the clock that decides which outbox messages are due
+func (uc *RelayOutboxUseCase) WithClock(clk clock.Clock) *RelayOutboxUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Execute publishes due messages oldest first and purges expired published ones
+// A run stops at the first message the broker does not accept, so an outage costs one attempt per run
+// and the messages behind it keep their order
+func (uc *RelayOutboxUseCase) Execute(ctx context.Context) (*dto.OutboxRelayResult, error) {
+	now := uc.clock.Now().UTC()
+	due, err := uc.outboxRepo.ClaimDue(now, now.Add(outboxLease), outboxRelayBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	result := &dto.OutboxRelayResult{}
+	for i := range due {
+		message := &due[i]
+
+		if publishErr := uc.publisher.Publish(ctx, message.Topic, message.ID.String(), []byte(message.Payload)); publishErr != nil {
+			retryAt := uc.clock.Now().UTC().Add(uc.retryDelay(message.Attempts + 1))
+			if err := uc.outboxRepo.MarkFailed(message.ID, publishErr.Error(), retryAt); err != nil {
+				return nil, fmt.Errorf("failed to record outbox failure: %w", err)
+			}
+			for _, waiting := range due[i+1:] {
+				if err := uc.outboxRepo.Reschedule(waiting.ID, retryAt); err != nil {
+					return nil, fmt.Errorf("failed to reschedule outbox message: %w", err)
+				}
+			}
+
+			result.Failed++
+			if result.Pending, err = uc.outboxRepo.CountPending(); err != nil {
+				return nil, fmt.Errorf("failed to count outbox messages: %w", err)
+			}
+			slog.Warn("Failed to publish outbox message",
+				"event_id", message.ID.String(),
+				"event", message.Event,
+				"attempt", message.Attempts+1,
+				"error", publishErr.Error(),
+				"retry_at", retryAt.Format(time.RFC3339),
+			)
+			break
+		}
+
+		if err := uc.outboxRepo.MarkPublished(message.ID, uc.clock.Now().UTC()); err != nil {
+			return nil, fmt.Errorf("failed to mark outbox message published: %w", err)
+		}
+		result.Published++
+	}
+
+	purged, err := uc.outboxRepo.DeletePublishedBefore(now.Add(-uc.retention))
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge published outbox messages: %w", err)
+	}
+	result.Purged = purged
+
+	return result, nil
+}
+
+// retryDelay is the wait after the given number of failed attempts: outboxRetryBase doubled per earlier failure
+func (uc *RelayOutboxUseCase) retryDelay(failures int) time.Duration {
+	delay := outboxRetryBase
+	for i := 1; i < failures && delay < maxOutboxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxOutboxRetryDelay)
+}
@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// RestoreTransactionUseCase handles the business logic for undoing a soft delete
+type RestoreTransactionUseCase struct {
+	transactionRepo repositories.TransactionRepository
+}
+
+// NewRestoreTransactionUseCase creates a new instance of RestoreTransactionUseCase
+func NewRestoreTransactionUseCase(transactionRepo repositories.TransactionRepository) *RestoreTransactionUseCase {
+	return &RestoreTransactionUseCase{
+		transactionRepo: transactionRepo,
+	}
+}
+
+// Execute restores a soft-deleted transaction and returns it
+func (uc *RestoreTransactionUseCase) Execute(id uuid.UUID) (*dto.GetTransactionResponse, error) {
+	if id == uuid.Nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: transaction ID cannot be empty")
+	}
+
+	transaction, err := uc.transactionRepo.Restore(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore transaction: %w", err)
+	}
+
+	return dto.NewGetTransactionResponse(transaction), nil
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
+)
+
+// ActivitySource provides activity counts that are not persisted in the database
+// Implemented by activity.Recorder
+type ActivitySource interface {
+	Drain() activity.Snapshot
+}
+
+// DigestRenderer turns a digest report into an email subject and body
+type DigestRenderer interface {
+	Render(report *dto.DigestReport) (subject, body string, err error)
+}
+
+// SendDigestUseCase handles building and emailing the periodic activity digest
+type SendDigestUseCase struct {
+	transactionRepo repositories.TransactionRepository
+	activity        ActivitySource
+	renderer        DigestRenderer
+	sender          services.EmailSender
+	recipients      []string
+}
+
+// NewSendDigestUseCase creates a new instance of SendDigestUseCase
+func NewSendDigestUseCase(
+	transactionRepo repositories.TransactionRepository,
+	activity ActivitySource,
+	renderer DigestRenderer,
+	sender services.EmailSender,
+	recipients []string,
+) *SendDigestUseCase {
+	return &SendDigestUseCase{
+		transactionRepo: transactionRepo,
+		activity:        activity,
+		renderer:        renderer,
+		sender:          sender,
+		recipients:      recipients,
+	}
+}
+
+// Execute builds the digest for [from, to) and emails it to the configured recipients
+// Activity counters are drained, so they cover everything since the previous digest
+func (uc *SendDigestUseCase) Execute(period string, from, to time.Time) (*dto.DigestReport, error) {
+	if len(uc.recipients) == 0 {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: no digest recipients configured")
+	}
+	if !from.Before(to) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: digest period start must be before its end")
+	}
+
+	summary, err := uc.transactionRepo.SummarizeCreatedBetween(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize transactions: %w", err)
+	}
+
+	snapshot := uc.activity.Drain()
+
+	report := &dto.DigestReport{
+		Period:           period,
+		From:             from,
+		To:               to,
+		NewTransactions:  summary.Count,
+		TotalSpend:       summary.Total.Dollars(),
+		Conversions:      snapshot.Conversions,
+		TreasuryFailures: snapshot.TreasuryFailures,
+	}
+
+	subject, body, err := uc.renderer.Render(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	if err := uc.sender.Send(uc.recipients, subject, body); err != nil {
+		return nil, fmt.Errorf("failed to send digest: %w", err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// SuggestDescriptionsUseCase handles the business logic for description autocomplete
+type SuggestDescriptionsUseCase struct {
+	transactionRepo repositories.TransactionRepository
+	validator       *validator.Validate
+}
+
+// NewSuggestDescriptionsUseCase creates a new instance of SuggestDescriptionsUseCase
+func NewSuggestDescriptionsUseCase(
+	transactionRepo repositories.TransactionRepository,
+	validator *validator.Validate,
+) *SuggestDescriptionsUseCase {
+	return &SuggestDescriptionsUseCase{
+		transactionRepo: transactionRepo,
+		validator:       validator,
+	}
+}
+
+// Execute returns the most frequent distinct descriptions starting with the request prefix
+func (uc *SuggestDescriptionsUseCase) Execute(request *dto.SuggestDescriptionsRequest) (*dto.SuggestDescriptionsResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+
+	// Trim whitespace and apply default limit
+	request.Prefix = strings.TrimSpace(request.Prefix)
+	if request.Limit == 0 {
+		request.Limit = 10
+	}
+
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	suggestions, err := uc.transactionRepo.SuggestDescriptions(request.Prefix, request.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve description suggestions: %w", err)
+	}
+
+	if suggestions == nil {
+		suggestions = []entities.DescriptionSuggestion{}
+	}
+
+	return &dto.SuggestDescriptionsResponse{
+		Prefix: request.Prefix,
+		Data:   suggestions,
+	}, nil
+}
@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// defaultSummaryMonths is how many calendar months a summary covers when the request sets no start date
+const defaultSummaryMonths = 12
+
+// SummarizeSpendingUseCase handles the business logic for spending summaries grouped by period
+type SummarizeSpendingUseCase struct {
+	reportRepo repositories.ReportRepository
+	rateFinder ExchangeRateFinder
+	validator  *validator.Validate
+	clock      clock.Clock
+}
+
+// NewSummarizeSpendingUseCase creates a new instance of SummarizeSpendingUseCase
+// rateFinder may be nil, in which case requests with a currency other than USD are rejected
+func NewSummarizeSpendingUseCase(
+	reportRepo repositories.ReportRepository,
+	rateFinder ExchangeRateFinder,
+	validator *validator.Validate,
+) *SummarizeSpendingUseCase {
+	return &SummarizeSpendingUseCase{
+		reportRepo: reportRepo,
+		rateFinder: rateFinder,
+		validator:  validator,
+		clock:      clock.System(),
+	}
+}
+
+// WithClock sets the clock default report periods end at
+func (uc *SummarizeSpendingUseCase) WithClock(clk clock.Clock) *SummarizeSpendingUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// Execute aggregates the transactions purchased in the requested range
+// Defaults to monthly groups over the last 12 calendar months, ending today (UTC)
+// With a currency, every amount is converted at the latest rate applicable on the last day of the range
+func (uc *SummarizeSpendingUseCase) Execute(ctx context.Context, request *dto.SummaryReportRequest) (*dto.SummaryReportResponse, error) {
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	convert := request.Currency != "" && request.Currency != entities.USD
+	if convert && (uc.rateFinder == nil || !uc.rateFinder.SupportsCurrency(request.Currency)) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: currency %s is not supported for conversion", request.Currency)
+	}
+
+	groupBy := request.GroupBy
+	if groupBy == "" {
+		groupBy = entities.GroupByMonth
+	}
+
+	now := uc.clock.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if request.To != nil {
+		to = request.To.UTC()
+	}
+	from := time.Date(to.Year(), to.Month()-defaultSummaryMonths+1, 1, 0, 0, 0, 0, time.UTC)
+	if request.From != nil {
+		from = request.From.UTC()
+	}
+	if from.After(to) {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: from (%s) must not be after to (%s)",
+			from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+
+	// The repository range is half-open, so it ends at the start of the day after to
+	summaries, err := uc.reportRepo.SummarizeByPeriod(from, to.AddDate(0, 0, 1), groupBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize transactions: %w", err)
+	}
+
+	response := dto.NewSummaryReportResponse(from, to, groupBy, summaries)
+	if convert {
+		rate, err := uc.findRate(ctx, request.Currency, to)
+		if err != nil {
+			return nil, err
+		}
+		response.ApplyConversion(summaries, rate)
+	}
+
+	return response, nil
+}
+
+// findRate looks up the rate converting USD to currency on date, enforcing the lookback window
+func (uc *SummarizeSpendingUseCase) findRate(ctx context.Context, currency entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	rate, err := uc.rateFinder.FindExchangeRate(ctx, currency, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+	window := uc.rateFinder.RateWindow()
+	if !rate.IsWithinDateRange(date, window) {
+		return nil, errs.Newf(errs.ErrRateUnavailable, "failed to find exchange rate: exchange rate date %v is not within %s of %v",
+			rate.EffectiveDate, window, date)
+	}
+	return rate, nil
+}
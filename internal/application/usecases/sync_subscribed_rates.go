@@ -0,0 +1,157 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// SyncSubscribedRatesUseCase refreshes the cached rates of subscribed currencies, most out-of-date first
+type SyncSubscribedRatesUseCase struct {
+	subscriptionRepo repositories.RateSubscriptionRepository
+	exchangeRateRepo repositories.ExchangeRateRepository
+	treasuryService  services.RateProvider
+	freshFor         time.Duration
+	maxPerRun        int
+	window           entities.RateWindow
+	clock            clock.Clock
+}
+
+// NewSyncSubscribedRatesUseCase creates a new instance of SyncSubscribedRatesUseCase
+// Currencies whose cached rate is younger than freshFor are skipped; at most maxPerRun are fetched per run
+func NewSyncSubscribedRatesUseCase(
+	subscriptionRepo repositories.RateSubscriptionRepository,
+	exchangeRateRepo repositories.ExchangeRateRepository,
+	treasuryService services.RateProvider,
+	freshFor time.Duration,
+	maxPerRun int,
+) *SyncSubscribedRatesUseCase {
+	return &SyncSubscribedRatesUseCase{
+		subscriptionRepo: subscriptionRepo,
+		exchangeRateRepo: exchangeRateRepo,
+		treasuryService:  treasuryService,
+		freshFor:         freshFor,
+		maxPerRun:        maxPerRun,
+		clock:            clock.System(),
+	}
+}
+
+// WithClock sets the clock that decides which subscribed rates are stale
+func (uc *SyncSubscribedRatesUseCase) WithClock(clk clock.Clock) *SyncSubscribedRatesUseCase {
+	uc.clock = clk
+	return uc
+}
+
+// WithRateWindow sets the lookback window a cached rate must fall in to count as present
+// Without it the default 6-month window applies
+func (uc *SyncSubscribedRatesUseCase) WithRateWindow(window entities.RateWindow) *SyncSubscribedRatesUseCase {
+	uc.window = window
+	return uc
+}
+
+// syncCandidate is a subscribed currency whose cached rate is not fresh
+type syncCandidate struct {
+	currency   entities.CurrencyCode
+	latest     *entities.ExchangeRate
+	lastSynced time.Time
+}
+
+// Execute checks the provider for newer rates of every subscribed currency that is not fresh
+// Missing rates go first, then the oldest cached rates, then the currencies synced longest ago
+func (uc *SyncSubscribedRatesUseCase) Execute(ctx context.Context) (*dto.RateSyncResult, error) {
+	currencies, err := uc.subscriptionRepo.SubscribedCurrencies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve subscribed currencies: %w", err)
+	}
+
+	lastSynced, err := uc.subscriptionRepo.LastSynced()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve rate sync history: %w", err)
+	}
+
+	now := uc.clock.Now().UTC()
+	result := &dto.RateSyncResult{Subscribed: len(currencies)}
+
+	candidates := make([]syncCandidate, 0, len(currencies))
+	for _, currency := range currencies {
+		latest, err := uc.exchangeRateRepo.FindRateForConversion(entities.USD, currency, now, uc.window)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up cached rate for %s: %w", currency, err)
+		}
+		if entities.RateFreshness(latest, now, uc.freshFor, uc.window) == entities.RateFresh {
+			result.Fresh++
+			continue
+		}
+		candidates = append(candidates, syncCandidate{currency: currency, latest: latest, lastSynced: lastSynced[currency]})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.latest == nil) != (b.latest == nil) {
+			return a.latest == nil
+		}
+		if a.latest != nil && !a.latest.EffectiveDate.Equal(b.latest.EffectiveDate) {
+			return a.latest.EffectiveDate.Before(b.latest.EffectiveDate)
+		}
+		return a.lastSynced.Before(b.lastSynced)
+	})
+
+	if uc.maxPerRun > 0 && len(candidates) > uc.maxPerRun {
+		result.Deferred = len(candidates) - uc.maxPerRun
+		candidates = candidates[:uc.maxPerRun]
+	}
+
+	for _, candidate := range candidates {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		result.Checked++
+		refreshed, err := uc.refresh(ctx, candidate, now)
+		syncError := ""
+		if err != nil {
+			result.Failed++
+			syncError = err.Error()
+		} else if refreshed {
+			result.Refreshed++
+		}
+
+		if err := uc.subscriptionRepo.RecordSync(candidate.currency, now, syncError); err != nil {
+			slog.Warn("Failed to record rate sync",
+				"error", err.Error(),
+				"currency", string(candidate.currency),
+			)
+		}
+	}
+
+	return result, nil
+}
+
+// refresh fetches the provider's newest rate and caches it when it is newer than the cached one
+func (uc *SyncSubscribedRatesUseCase) refresh(ctx context.Context, candidate syncCandidate, now time.Time) (bool, error) {
+	rate, err := uc.treasuryService.FetchExchangeRate(ctx, entities.USD, candidate.currency, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch exchange rate: %w", err)
+	}
+
+	if !rate.IsAuthoritative() {
+		return false, nil // Fallback provider rates are not stored
+	}
+	if candidate.latest != nil && !rate.EffectiveDate.After(candidate.latest.EffectiveDate) {
+		return false, nil
+	}
+
+	if err := uc.exchangeRateRepo.Save(rate); err != nil {
+		return false, fmt.Errorf("failed to cache exchange rate: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// UpdateTransactionCategoryUseCase handles the business logic for changing the category and tags of a transaction
+// Description, date and amount stay immutable since conversions and budgets were computed from them
+type UpdateTransactionCategoryUseCase struct {
+	transactionRepo repositories.TransactionRepository
+	categoryRepo    repositories.CategoryRepository
+	validator       *validator.Validate
+}
+
+// NewUpdateTransactionCategoryUseCase creates a new instance of UpdateTransactionCategoryUseCase
+func NewUpdateTransactionCategoryUseCase(
+	transactionRepo repositories.TransactionRepository,
+	categoryRepo repositories.CategoryRepository,
+	validator *validator.Validate,
+) *UpdateTransactionCategoryUseCase {
+	return &UpdateTransactionCategoryUseCase{
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+		validator:       validator,
+	}
+}
+
+// Execute sets the fields present in the request; the category must exist
+// The update is a conflict when the transaction is no longer at request.ExpectedVersion, or changes while being updated
+func (uc *UpdateTransactionCategoryUseCase) Execute(id uuid.UUID, request *dto.UpdateTransactionCategoryRequest) (*dto.GetTransactionResponse, error) {
+	if id == uuid.Nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: transaction ID cannot be empty")
+	}
+	if request == nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: request cannot be nil")
+	}
+	if err := uc.validator.Struct(request); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "validation failed: %w", err)
+	}
+
+	transaction, err := uc.transactionRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transaction: %w", err)
+	}
+	if transaction == nil {
+		return nil, errs.Newf(errs.ErrNotFound, "transaction with ID %s not found", id)
+	}
+	if request.ExpectedVersion != nil && *request.ExpectedVersion != transaction.Version {
+		return nil, errs.Newf(errs.ErrConflict, "transaction %s is at version %d, not %d", id, transaction.Version, *request.ExpectedVersion)
+	}
+
+	if request.Category != nil {
+		category, err := resolveCategory(uc.categoryRepo, *request.Category)
+		if err != nil {
+			return nil, err
+		}
+		transaction.Category = category
+	}
+	if request.Tags != nil {
+		transaction.Tags = entities.NormalizeTags(*request.Tags)
+	}
+
+	if err := transaction.Validate(); err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "business validation failed: %w", err)
+	}
+	if err := uc.transactionRepo.Update(transaction); err != nil {
+		return nil, fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	return dto.NewGetTransactionResponse(transaction), nil
+}
@@ -1,79 +1,555 @@
 package config
 
-import "os"
+import (
+	"os"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/errortracking"
+)
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Treasury TreasuryConfig
-	Logger   LoggerConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Treasury    TreasuryConfig
+	Providers   RateProvidersConfig
+	RateCache   RateCacheConfig
+	Quote       QuoteConfig
+	Idempotency IdempotencyConfig
+	Conversion  ConversionConfig
+	Digest      DigestConfig
+	RateLimit   RateLimitConfig
+	Auth        AuthConfig
+	Deprecation DeprecationConfig
+	Bank        BankConfig
+	Budget      BudgetConfig
+	RateSync    RateSyncConfig
+	Prefetch    RatePrefetchConfig
+	Webhook     WebhookConfig
+	Broker      BrokerConfig
+	Logger      LoggerConfig
+	Sentry      SentryConfig
 }
 
 type ServerConfig struct {
-	Port string
+	Port      string
+	AdminAddr string // host:port for health, metrics, pprof and admin routes; empty serves them on Port
+	GRPCAddr  string // host:port for the gRPC transaction service; empty disables it
+	ReusePort bool   // Bind with SO_REUSEPORT so a replacement process can start before this one drains
+
+	DrainTimeoutSecs    int // Time in-flight requests get to finish after SIGTERM
+	ShutdownTimeoutSecs int // Time background workers and components then get to stop
+
+	ContractValidation string // off, report or enforce requests and responses against the OpenAPI contract
+
+	RequestTimeoutSecs          int            // Time a request may run before it is answered 504; zero leaves requests unbounded
+	RequestTimeoutSecsByProfile map[string]int // Budgets of rate limit profiles (read, list, write, convert, admin) overriding it
 }
 
 type DatabaseConfig struct {
-	Path string
+	Driver string // sqlite, postgres, mysql (or mariadb) or memory
+	Path   string // SQLite file path
+	DSN    string // PostgreSQL or MySQL connection string
+
+	MaxOpenConns        int // Connection pool size per primary, replica or SQLite reader; zero is unlimited
+	MaxIdleConns        int // Connections kept open between requests
+	ConnMaxLifetimeMins int // Recycle connections after this many minutes; zero never does
+	ConnMaxIdleMins     int // Close connections idle for this many minutes; zero never does
+
+	LogLevel    string // SQL statements logged: silent, error, warn (failed and slow) or info (all)
+	SlowQueryMs int    // Statements slower than this are logged at warn; zero disables slow-query logging
+
+	SQLiteJournalMode     string // SQLite journal mode; wal lets reads run alongside a write
+	SQLiteBusyTimeoutMs   int    // How long SQLite waits for a lock before answering "database is locked"
+	SQLiteSynchronous     string // SQLite fsync level: off, normal, full or extra
+	SQLiteSerializeWrites bool   // Queue SQLite writes on a single connection and read from a separate pool
+
+	EncryptionKey     string // SQLCipher passphrase or 64-hex-digit raw key; empty keeps SQLite unencrypted
+	EncryptionKeyFile string // File holding the key, e.g. written by a KMS or secrets agent
+
+	ReplicaDSNs          []string // PostgreSQL read replicas; empty sends reads to the primary
+	ReplicaStickySeconds int      // Reads stay on the primary this long after a write
+
+	PartitionTransactions bool // Partition the PostgreSQL transactions table by month
+
+	ManualMigrations bool // Leave schema changes to `migrate up`; startup fails while migrations are pending instead of applying them
+
+	QuotaMB             int  // Soft size quota in megabytes; zero disables it
+	QuotaWarnPercent    int  // Share of the quota that raises a warning
+	QuotaBlockImports   bool // Reject bulk imports once the quota is exceeded
+	MonitorIntervalMins int  // How often size and row counts are measured and logged
+
+	ArchiveAfterYears    int // Move transactions dated more than this many years ago to cold storage; zero disables archiving
+	ArchiveBatchSize     int // Transactions moved per database transaction
+	ArchiveIntervalHours int
 }
 
 type TreasuryConfig struct {
+	BaseURL         string
+	TimeoutSeconds  int
+	CurrencyMapFile string // JSON file mapping currency codes to Treasury country_currency_desc values; empty uses the built-in map
+	PageSize        int    // Records requested per page of a lookup
+	MaxPages        int    // Pages read per lookup while none holds a usable rate
+
+	ResponseCacheSeconds    int // How long a response is reused for the same request; zero disables the response cache
+	ResponseCacheMaxEntries int // Responses kept before the least recently used is evicted
+
+	RetryMaxAttempts   int   // Attempts per lookup including the first; 1 disables retries
+	RetryBaseDelayMs   int   // Delay before the first retry, doubled on every further one
+	RetryMaxDelayMs    int   // Upper bound of a single delay
+	RetryJitterPercent int   // Randomly shorten each delay by up to this share so clients do not retry in step
+	RetryStatusCodes   []int // Response statuses worth retrying; network errors are always retried
+
+	BreakerEnabled          bool // Fail Treasury calls fast after repeated outages instead of waiting for timeouts
+	BreakerFailureThreshold int  // Consecutive failed lookups that open the breaker
+	BreakerOpenSeconds      int  // How long an open breaker fails fast before a trial call
+	BreakerHalfOpenMaxCalls int  // Trial calls allowed at once while half-open
+}
+
+// RateProvidersConfig chooses where exchange rates are fetched from and in which order
+type RateProvidersConfig struct {
+	Order []string // Providers tried in turn for a rate: treasury, ecb or fixed
+
+	ECBRatesURL       string // ECB euro foreign exchange reference rates history (eurofxref-hist.xml)
+	ECBTimeoutSeconds int    // Time the ECB download may take
+	ECBRefreshMinutes int    // How long the downloaded ECB rates are reused before downloading them again
+
+	FixedRates map[string]float64 // Units of each currency per US dollar served by the fixed provider
+}
+
+// RateCacheConfig controls the cache of exchange rate lookups in front of the database and the Treasury API
+type RateCacheConfig struct {
+	Backend    string // memory, redis or off
+	TTLSeconds int    // How long a cached lookup is reused
+	MaxEntries int    // Lookups kept by the in-memory backend before the least recently used are evicted
+
+	RedisAddr      string // host:port of the Redis server shared by every instance
+	RedisPassword  string
+	RedisDB        int
+	RedisKeyPrefix string // Namespaces the keys when the Redis server is shared with other services
+}
+
+type QuoteConfig struct {
+	TTLMinutes int // How long a quoted rate stays locked
+}
+
+// IdempotencyConfig controls how long Idempotency-Key outcomes are replayed
+type IdempotencyConfig struct {
+	WindowHours int // Retries within this window return the original response
+}
+
+type ConversionConfig struct {
+	MarginBps         int            // Global margin in basis points applied on top of raw rates
+	MarginBpsByAPIKey map[string]int // Per API key margin overrides
+	BatchConcurrency  int            // Transactions converted at once by admin batch runs
+	RefreshEnabled    bool           // Supersede stored conversions when a closer rate is ingested
+	LookbackMonths    int            // How long before a purchase a rate may take effect and still convert it
+	CrossRates        bool           // Accept transactions in other currencies and convert them through USD cross rates
+	Rounding          string         // How converted amounts between two cents are rounded: half_up, half_even or down
+	StaleFallback     bool           // Convert with the nearest older rate when none is within the lookback window, unless the request asks for strict
+	StaleMaxMonths    int            // How long before a purchase a stale rate may take effect and still convert it
+}
+
+type RateLimitConfig struct {
+	Profiles map[string]string // Profile name -> limit such as "10/min"; empty disables rate limiting
+}
+
+// AuthConfig controls API token authentication of the /api/v1 routes
+type AuthConfig struct {
+	RequireTokens  bool   // Reject /api/v1 requests without an API token whose role covers the route
+	BootstrapToken string // Admin secret stored on startup so the first tokens can be issued; empty seeds nothing
+
+	JWT JWTConfig
+}
+
+// JWTConfig accepts JWT bearer tokens whose roles claim grants reader, writer or admin access
+type JWTConfig struct {
+	Algorithm       string // HS256 or RS256; empty disables bearer tokens
+	Secret          string // HS256 shared secret
+	PublicKeyFile   string // RS256 PEM public key, used when no JWKS URL is set
+	JWKSURL         string // RS256 keys published by the identity provider, selected by the token's kid
+	JWKSRefreshMins int    // Refetch the JWKS this often
+	Issuer          string // Required iss claim; empty accepts any issuer
+	Audience        string // Required aud entry; empty accepts any audience
+	RolesClaim      string // Claim listing the caller's roles
+	LeewaySeconds   int    // Clock skew tolerated on exp and nbf
+}
+
+// DeprecationConfig announces the removal timeline of API v1 through response headers
+type DeprecationConfig struct {
+	V1DeprecatedAt string // YYYY-MM-DD or RFC 3339; empty leaves v1 undeprecated
+	V1SunsetAt     string // Date after which v1 may be removed
+	V1Link         string // Migration guide linked from the Link header
+}
+
+// BankConfig connects bank logins whose purchases are imported from a Plaid-compatible aggregator
+type BankConfig struct {
 	BaseURL        string
+	ClientID       string
+	Secret         string
 	TimeoutSeconds int
+
+	Connections              map[string]string   // Connection name -> access token; empty disables the sync
+	AccountsByConnection     map[string][]string // Connection name -> account IDs to import; unset imports all accounts
+	LookbackDays             int                 // Days each sync looks back for new or late-posted transactions
+	LookbackDaysByConnection map[string]int      // Per connection lookback overrides
+	SyncIntervalMins         int
+}
+
+// BudgetConfig controls how budget threshold alerts are delivered; they are always logged
+type BudgetConfig struct {
+	AlertRecipients []string // Also email alerts through the digest SMTP server; empty only logs them
+}
+
+// RateSyncConfig controls the background refresh of subscribed currencies' exchange rates
+type RateSyncConfig struct {
+	FreshDays    int // Cached rates younger than this are reported fresh and not refetched
+	MaxPerRun    int // Currencies fetched per run, most out-of-date first; zero fetches all
+	IntervalMins int
+}
+
+// RatePrefetchConfig controls the scheduled prefetch of the latest rates of a fixed set of currencies
+type RatePrefetchConfig struct {
+	Currencies []string // Currencies whose latest rate is stored on every run; empty disables the prefetch
+	Schedule   string   // Five-field cron expression evaluated in UTC
+}
+
+// WebhookConfig controls how transaction events are delivered to registered webhooks
+type WebhookConfig struct {
+	TimeoutSeconds   int // Time a receiver has to answer one delivery
+	MaxAttempts      int // Attempts per delivery, including the first, before it is marked failed
+	RetryBaseSeconds int // Wait before the first retry; doubled for every further retry, up to an hour
+	RetryIntervalSec int // How often due retries are looked for
+}
+
+// BrokerConfig controls publishing transaction events to a message broker through the outbox table
+type BrokerConfig struct {
+	Type                 string // none or nats
+	URL                  string // nats://[user:password@]host:port, or tls:// for TLS
+	Topic                string // Subject every event is published to
+	RelayIntervalSeconds int    // How often stored events are relayed to the broker
+	RetentionHours       int    // How long published events stay in the outbox
+}
+
+type DigestConfig struct {
+	Recipients   []string // Empty disables the digest
+	Period       string   // daily or weekly
+	HourUTC      int      // Hour of day the digest is sent
+	TemplatePath string   // Optional template overriding the built-in one
+	SMTP         SMTPConfig
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
 }
 
 type LoggerConfig struct {
 	Level  string
 	Format string
+	Export LogExportConfig
+
+	// Request and response bodies logged at DEBUG level, truncated and with sensitive fields redacted
+	Payloads        bool
+	PayloadMaxBytes int
+	RedactFields    []string // Added to the built-in list of redacted fields and headers
+}
+
+// LogExportConfig selects an optional log backend (loki, otlp or syslog)
+type LogExportConfig struct {
+	Target      string
+	Endpoint    string
+	ServiceName string
+}
+
+// SentryConfig enables reporting of panics, 5xx errors and Treasury outages to Sentry
+type SentryConfig struct {
+	DSN         string // Empty disables error reporting
+	Environment string // Defaults to ENVIRONMENT
+	Release     string // Defaults to the build version
 }
 
-// LoadConfig loads configuration with default values
-func LoadConfig() *Config {
-	return &Config{
+// Load reads the configuration from the environment and the optional YAML file named by CONFIG_FILE
+// Environment variables take precedence over the file, and unset keys get their defaults
+// Every malformed, out-of-range or inconsistent key is reported at once in an *Error
+func Load() (*Config, error) {
+	l, err := newLoader(os.Getenv(FileEnv))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
 		Server: ServerConfig{
-			Port: getEnv("PORT", ":8080"),
+			Port:      l.string("PORT", ":8080"),
+			AdminAddr: l.string("ADMIN_ADDR", ""),
+			GRPCAddr:  l.string("GRPC_ADDR", ""),
+			ReusePort: l.bool("SERVER_REUSE_PORT", false),
+
+			DrainTimeoutSecs:    l.int("SHUTDOWN_DRAIN_TIMEOUT_SECONDS", 30, 1),
+			ShutdownTimeoutSecs: l.int("SHUTDOWN_WORKER_TIMEOUT_SECONDS", 15, 1),
+
+			ContractValidation: l.oneOf("OPENAPI_VALIDATION", "off", "off", "report", "enforce"),
+
+			RequestTimeoutSecs:          l.int("REQUEST_TIMEOUT_SECONDS", 10, 0),
+			RequestTimeoutSecsByProfile: requestTimeoutProfiles(l),
 		},
 		Database: DatabaseConfig{
-			Path: getEnv("DB_PATH", "transactions.db"),
+			Driver: l.oneOf("DB_DRIVER", "sqlite", "sqlite", "postgres", "mysql", "mariadb", "memory"),
+			Path:   l.string("DB_PATH", "transactions.db"),
+			DSN:    l.string("DB_DSN", ""),
+
+			MaxOpenConns:        l.int("DB_MAX_OPEN_CONNS", 25, 0),
+			MaxIdleConns:        l.int("DB_MAX_IDLE_CONNS", 10, 0),
+			ConnMaxLifetimeMins: l.int("DB_CONN_MAX_LIFETIME_MINUTES", 30, 0),
+			ConnMaxIdleMins:     l.int("DB_CONN_MAX_IDLE_MINUTES", 5, 0),
+
+			LogLevel:    l.oneOf("DB_LOG_LEVEL", "warn", "silent", "error", "warn", "info"),
+			SlowQueryMs: l.int("DB_SLOW_QUERY_MS", 200, 0),
+
+			SQLiteJournalMode:     l.oneOf("DB_SQLITE_JOURNAL_MODE", "wal", "delete", "truncate", "persist", "memory", "wal", "off"),
+			SQLiteBusyTimeoutMs:   l.int("DB_SQLITE_BUSY_TIMEOUT_MS", 5000, 1),
+			SQLiteSynchronous:     l.oneOf("DB_SQLITE_SYNCHRONOUS", "normal", "off", "normal", "full", "extra"),
+			SQLiteSerializeWrites: l.bool("DB_SQLITE_SERIALIZE_WRITES", true),
+
+			EncryptionKey:     l.string("DB_ENCRYPTION_KEY", ""),
+			EncryptionKeyFile: l.string("DB_ENCRYPTION_KEY_FILE", ""),
+
+			ReplicaDSNs:          l.list("DB_REPLICA_DSNS"),
+			ReplicaStickySeconds: l.int("DB_REPLICA_STICKY_SECONDS", 2, 0),
+
+			PartitionTransactions: l.bool("DB_PARTITION_TRANSACTIONS", false),
+
+			ManualMigrations: l.bool("DB_MANUAL_MIGRATIONS", false),
+
+			QuotaMB:             l.int("DB_QUOTA_MB", 0, 0),
+			QuotaWarnPercent:    l.intBetween("DB_QUOTA_WARN_PERCENT", 80, 1, 100),
+			QuotaBlockImports:   l.bool("DB_QUOTA_BLOCK_IMPORTS", false),
+			MonitorIntervalMins: l.int("DB_MONITOR_INTERVAL_MINUTES", 5, 1),
+
+			ArchiveAfterYears:    l.int("DB_ARCHIVE_AFTER_YEARS", 0, 0),
+			ArchiveBatchSize:     l.int("DB_ARCHIVE_BATCH_SIZE", 500, 1),
+			ArchiveIntervalHours: l.int("DB_ARCHIVE_INTERVAL_HOURS", 24, 1),
 		},
 		Treasury: TreasuryConfig{
-			BaseURL:        getEnv("TREASURY_BASE_URL", "https://api.fiscaldata.treasury.gov/services/api/fiscal_service/v1/accounting/od/rates_of_exchange"),
-			TimeoutSeconds: getEnvInt("TREASURY_TIMEOUT_SECONDS", 30),
+			BaseURL:        l.url("TREASURY_BASE_URL", "https://api.fiscaldata.treasury.gov/services/api/fiscal_service/v1/accounting/od/rates_of_exchange"),
+			TimeoutSeconds: l.int("TREASURY_TIMEOUT_SECONDS", 30, 1),
+
+			CurrencyMapFile: l.string("TREASURY_CURRENCY_MAP_FILE", ""),
+			PageSize:        l.intBetween("TREASURY_PAGE_SIZE", 100, 1, 10000),
+			MaxPages:        l.int("TREASURY_MAX_PAGES", 10, 1),
+
+			ResponseCacheSeconds:    l.int("TREASURY_RESPONSE_CACHE_SECONDS", 300, 0),
+			ResponseCacheMaxEntries: l.int("TREASURY_RESPONSE_CACHE_MAX_ENTRIES", 1000, 1),
+
+			RetryMaxAttempts:   l.int("TREASURY_RETRY_MAX_ATTEMPTS", 3, 1),
+			RetryBaseDelayMs:   l.int("TREASURY_RETRY_BASE_DELAY_MS", 200, 0),
+			RetryMaxDelayMs:    l.int("TREASURY_RETRY_MAX_DELAY_MS", 5000, 0),
+			RetryJitterPercent: l.intBetween("TREASURY_RETRY_JITTER_PERCENT", 20, 0, 100),
+			RetryStatusCodes:   l.intList("TREASURY_RETRY_STATUS_CODES", []int{429, 500, 502, 503, 504}, 100, 599),
+
+			BreakerEnabled:          l.bool("TREASURY_BREAKER_ENABLED", true),
+			BreakerFailureThreshold: l.int("TREASURY_BREAKER_FAILURE_THRESHOLD", 5, 1),
+			BreakerOpenSeconds:      l.int("TREASURY_BREAKER_OPEN_SECONDS", 30, 1),
+			BreakerHalfOpenMaxCalls: l.int("TREASURY_BREAKER_HALF_OPEN_MAX_CALLS", 1, 1),
+		},
+		Providers: RateProvidersConfig{
+			Order: l.listOf("RATE_PROVIDERS", []string{"treasury"}, "treasury", "ecb", "fixed"),
+
+			ECBRatesURL:       l.url("ECB_RATES_URL", "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml"),
+			ECBTimeoutSeconds: l.int("ECB_TIMEOUT_SECONDS", 30, 1),
+			ECBRefreshMinutes: l.int("ECB_REFRESH_MINUTES", 60, 1),
+
+			FixedRates: l.floatMap("FIXED_RATES"),
+		},
+		RateCache: RateCacheConfig{
+			Backend:    l.oneOf("RATE_CACHE_BACKEND", "memory", "memory", "redis", "off"),
+			TTLSeconds: l.int("RATE_CACHE_TTL_SECONDS", 3600, 1),
+			MaxEntries: l.int("RATE_CACHE_MAX_ENTRIES", 10000, 1),
+
+			RedisAddr:      l.string("REDIS_ADDR", "localhost:6379"),
+			RedisPassword:  l.string("REDIS_PASSWORD", ""),
+			RedisDB:        l.intBetween("REDIS_DB", 0, 0, 15),
+			RedisKeyPrefix: l.string("RATE_CACHE_KEY_PREFIX", "pta:"),
+		},
+		Quote: QuoteConfig{
+			TTLMinutes: l.int("QUOTE_TTL_MINUTES", 15, 1),
+		},
+		Idempotency: IdempotencyConfig{
+			WindowHours: l.int("IDEMPOTENCY_WINDOW_HOURS", 24, 1),
+		},
+		Conversion: ConversionConfig{
+			MarginBps:         l.intBetween("CONVERSION_MARGIN_BPS", 0, 0, 10000),
+			MarginBpsByAPIKey: l.intMap("CONVERSION_MARGIN_BPS_BY_API_KEY", 0),
+			BatchConcurrency:  l.int("BATCH_CONVERSION_CONCURRENCY", 4, 1),
+			RefreshEnabled:    l.bool("CONVERSION_REFRESH_ENABLED", false),
+			LookbackMonths:    l.intBetween("CONVERSION_LOOKBACK_MONTHS", 6, 1, 24),
+			CrossRates:        l.bool("CONVERSION_CROSS_RATES_ENABLED", false),
+			Rounding:          l.oneOf("CONVERSION_ROUNDING", "half_up", "half_up", "half_even", "down"),
+			StaleFallback:     l.bool("CONVERSION_STALE_FALLBACK", false),
+			StaleMaxMonths:    l.intBetween("CONVERSION_STALE_MAX_MONTHS", 12, 1, 24),
+		},
+		Digest: DigestConfig{
+			Recipients:   l.list("DIGEST_RECIPIENTS"),
+			Period:       l.oneOf("DIGEST_PERIOD", "daily", "daily", "weekly"),
+			HourUTC:      l.intBetween("DIGEST_HOUR_UTC", 8, 0, 23),
+			TemplatePath: l.string("DIGEST_TEMPLATE_PATH", ""),
+			SMTP: SMTPConfig{
+				Host:     l.string("SMTP_HOST", ""),
+				Port:     l.intBetween("SMTP_PORT", 587, 1, 65535),
+				Username: l.string("SMTP_USERNAME", ""),
+				Password: l.string("SMTP_PASSWORD", ""),
+				From:     l.string("SMTP_FROM", "purchase-transaction-api@localhost"),
+			},
+		},
+		RateLimit: RateLimitConfig{
+			Profiles: l.stringMap("RATE_LIMIT_PROFILES"),
+		},
+		Auth: AuthConfig{
+			RequireTokens:  l.bool("API_TOKENS_REQUIRED", false),
+			BootstrapToken: l.string("API_BOOTSTRAP_TOKEN", ""),
+
+			JWT: JWTConfig{
+				Algorithm:       l.oneOf("JWT_ALGORITHM", "", "HS256", "RS256"),
+				Secret:          l.string("JWT_SECRET", ""),
+				PublicKeyFile:   l.string("JWT_PUBLIC_KEY_FILE", ""),
+				JWKSURL:         l.url("JWT_JWKS_URL", ""),
+				JWKSRefreshMins: l.int("JWT_JWKS_REFRESH_MINUTES", 60, 1),
+				Issuer:          l.string("JWT_ISSUER", ""),
+				Audience:        l.string("JWT_AUDIENCE", ""),
+				RolesClaim:      l.string("JWT_ROLES_CLAIM", "roles"),
+				LeewaySeconds:   l.int("JWT_LEEWAY_SECONDS", 60, 0),
+			},
+		},
+		Deprecation: DeprecationConfig{
+			V1DeprecatedAt: l.string("API_V1_DEPRECATED_AT", ""),
+			V1SunsetAt:     l.string("API_V1_SUNSET_AT", ""),
+			V1Link:         l.string("API_V1_DEPRECATION_LINK", ""),
+		},
+		Bank: BankConfig{
+			BaseURL:        l.url("BANK_AGGREGATOR_URL", "https://production.plaid.com"),
+			ClientID:       l.string("BANK_AGGREGATOR_CLIENT_ID", ""),
+			Secret:         l.string("BANK_AGGREGATOR_SECRET", ""),
+			TimeoutSeconds: l.int("BANK_AGGREGATOR_TIMEOUT_SECONDS", 30, 1),
+
+			Connections:              l.stringMap("BANK_CONNECTIONS"),
+			AccountsByConnection:     l.listMap("BANK_ACCOUNTS_BY_CONNECTION"),
+			LookbackDays:             l.int("BANK_LOOKBACK_DAYS", 30, 1),
+			LookbackDaysByConnection: l.intMap("BANK_LOOKBACK_DAYS_BY_CONNECTION", 1),
+			SyncIntervalMins:         l.int("BANK_SYNC_INTERVAL_MINUTES", 60, 1),
+		},
+		Budget: BudgetConfig{
+			AlertRecipients: l.list("BUDGET_ALERT_RECIPIENTS"),
+		},
+		RateSync: RateSyncConfig{
+			FreshDays:    l.int("RATE_FRESH_DAYS", 100, 1),
+			MaxPerRun:    l.int("RATE_SYNC_MAX_PER_RUN", 10, 0),
+			IntervalMins: l.int("RATE_SYNC_INTERVAL_MINUTES", 60, 1),
+		},
+		Prefetch: RatePrefetchConfig{
+			Currencies: l.list("RATE_PREFETCH_CURRENCIES"),
+			Schedule:   l.string("RATE_PREFETCH_SCHEDULE", "0 6 * * *"),
+		},
+		Webhook: WebhookConfig{
+			TimeoutSeconds:   l.int("WEBHOOK_TIMEOUT_SECONDS", 10, 1),
+			MaxAttempts:      l.int("WEBHOOK_MAX_ATTEMPTS", 6, 1),
+			RetryBaseSeconds: l.int("WEBHOOK_RETRY_BASE_SECONDS", 30, 1),
+			RetryIntervalSec: l.int("WEBHOOK_RETRY_INTERVAL_SECONDS", 15, 1),
+		},
+		Broker: BrokerConfig{
+			Type:                 l.oneOf("EVENT_BROKER", "none", "none", "nats"),
+			URL:                  l.string("EVENT_BROKER_URL", "nats://localhost:4222"),
+			Topic:                l.string("EVENT_BROKER_TOPIC", "transactions.events"),
+			RelayIntervalSeconds: l.int("EVENT_OUTBOX_RELAY_INTERVAL_SECONDS", 2, 1),
+			RetentionHours:       l.int("EVENT_OUTBOX_RETENTION_HOURS", 72, 1),
 		},
 		Logger: LoggerConfig{
-			Level:  getEnv("LOG_LEVEL", "INFO"),
-			Format: getEnv("LOG_FORMAT", "json"), // json for production, text for development
+			Level:  l.oneOf("LOG_LEVEL", "INFO", "DEBUG", "INFO", "WARN", "ERROR"),
+			Format: l.oneOf("LOG_FORMAT", "json", "json", "text"), // json for production, text for development
+			Export: LogExportConfig{
+				Target:      l.oneOf("LOG_EXPORT", "", "loki", "otlp", "syslog"),
+				Endpoint:    l.string("LOG_EXPORT_ENDPOINT", ""),
+				ServiceName: l.string("LOG_EXPORT_SERVICE", "purchase-transaction-api"),
+			},
+			Payloads:        l.bool("LOG_PAYLOADS", false),
+			PayloadMaxBytes: l.int("LOG_PAYLOAD_MAX_BYTES", 4096, 1),
+			RedactFields:    l.list("LOG_REDACT_FIELDS"),
+		},
+		Sentry: SentryConfig{
+			DSN:         l.url("SENTRY_DSN", ""),
+			Environment: l.string("SENTRY_ENVIRONMENT", os.Getenv("ENVIRONMENT")),
+			Release:     l.string("SENTRY_RELEASE", ""),
 		},
 	}
+
+	cfg.validate(l)
+	if err := l.err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
-// getEnv gets an environment variable with a default fallback
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// requestTimeoutProfiles returns REQUEST_TIMEOUT_PROFILES over the built-in budgets
+// Conversions may wait on the Treasury API, and admin exports and imports are only bounded by the listener
+func requestTimeoutProfiles(l *loader) map[string]int {
+	profiles := map[string]int{"convert": 25, "admin": 0}
+	for profile, seconds := range l.intMap("REQUEST_TIMEOUT_PROFILES", 0) {
+		profiles[profile] = seconds
 	}
-	return defaultValue
+	return profiles
 }
 
-// getEnvInt gets an environment variable as integer with a default fallback
-func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue := parseInt(value); intValue > 0 {
-			return intValue
+// validate checks the settings that depend on each other
+func (c *Config) validate(l *loader) {
+	switch c.Database.Driver {
+	case "postgres", "mysql", "mariadb":
+		if c.Database.DSN == "" {
+			l.fail("DB_DSN", "required when DB_DRIVER is %s", c.Database.Driver)
+		}
+	}
+	if len(c.Database.ReplicaDSNs) > 0 && c.Database.Driver != "postgres" {
+		l.fail("DB_REPLICA_DSNS", "read replicas need DB_DRIVER=postgres")
+	}
+	if c.Database.EncryptionKey != "" && c.Database.EncryptionKeyFile != "" {
+		l.fail("DB_ENCRYPTION_KEY_FILE", "set either DB_ENCRYPTION_KEY or DB_ENCRYPTION_KEY_FILE, not both")
+	}
+
+	if c.Treasury.RetryMaxDelayMs < c.Treasury.RetryBaseDelayMs {
+		l.fail("TREASURY_RETRY_MAX_DELAY_MS", "%d is below TREASURY_RETRY_BASE_DELAY_MS (%d)",
+			c.Treasury.RetryMaxDelayMs, c.Treasury.RetryBaseDelayMs)
+	}
+
+	if c.Conversion.StaleMaxMonths <= c.Conversion.LookbackMonths {
+		l.fail("CONVERSION_STALE_MAX_MONTHS", "%d must exceed CONVERSION_LOOKBACK_MONTHS (%d)",
+			c.Conversion.StaleMaxMonths, c.Conversion.LookbackMonths)
+	}
+
+	for _, provider := range c.Providers.Order {
+		if provider == "fixed" && len(c.Providers.FixedRates) == 0 {
+			l.fail("FIXED_RATES", "required when RATE_PROVIDERS includes fixed")
+		}
+	}
+
+	switch c.Auth.JWT.Algorithm {
+	case "HS256":
+		if c.Auth.JWT.Secret == "" {
+			l.fail("JWT_SECRET", "required when JWT_ALGORITHM is HS256")
+		}
+	case "RS256":
+		if c.Auth.JWT.PublicKeyFile == "" && c.Auth.JWT.JWKSURL == "" {
+			l.fail("JWT_PUBLIC_KEY_FILE", "JWT_PUBLIC_KEY_FILE or JWT_JWKS_URL is required when JWT_ALGORITHM is RS256")
+		}
+	}
+
+	if c.Logger.Export.Target == "loki" || c.Logger.Export.Target == "otlp" {
+		if c.Logger.Export.Endpoint == "" {
+			l.fail("LOG_EXPORT_ENDPOINT", "required when LOG_EXPORT is %s", c.Logger.Export.Target)
 		}
 	}
-	return defaultValue
-}
 
-// parseInt safely parses string to int
-func parseInt(s string) int {
-	result := 0
-	for _, char := range s {
-		if char >= '0' && char <= '9' {
-			result = result*10 + int(char-'0')
-		} else {
-			return 0 // Invalid character, return 0
+	if c.Sentry.DSN != "" {
+		if _, err := errortracking.ParseDSN(c.Sentry.DSN); err != nil {
+			l.fail("SENTRY_DSN", "%v", err)
 		}
 	}
-	return result
 }
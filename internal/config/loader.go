@@ -0,0 +1,351 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the environment variable holding the optional YAML configuration file
+const FileEnv = "CONFIG_FILE"
+
+// Problem is one invalid configuration key
+type Problem struct {
+	Key     string
+	Message string
+}
+
+// Error lists every invalid configuration key found while loading, so they can all be fixed at once
+type Error struct {
+	Problems []Problem
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration (%d problems):", len(e.Problems))
+	for _, problem := range e.Problems {
+		fmt.Fprintf(&b, "\n  %s: %s", problem.Key, problem.Message)
+	}
+	return b.String()
+}
+
+// Keys returns the invalid keys in the order they were found
+func (e *Error) Keys() []string {
+	keys := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		keys = append(keys, problem.Key)
+	}
+	return keys
+}
+
+// loader reads typed values from the environment, falling back to the configuration file and then to defaults
+// Parse and range errors are collected rather than returned, and the key keeps its default
+type loader struct {
+	file     map[string]string
+	read     map[string]bool
+	problems []Problem
+}
+
+// newLoader reads the YAML file at path, if any, whose top-level keys are environment variable names
+// Lists may be written as YAML sequences and maps as YAML mappings
+func newLoader(path string) (*loader, error) {
+	l := &loader{file: map[string]string{}, read: map[string]bool{}}
+	if path == "" {
+		return l, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	var document map[string]any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+	}
+	for key, value := range document {
+		flat, err := flattenFileValue(value)
+		if err != nil {
+			l.fail(key, "%v", err)
+			continue
+		}
+		l.file[key] = flat
+	}
+	return l, nil
+}
+
+// flattenFileValue renders a YAML value in the syntax of the matching environment variable
+func flattenFileValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			flat, err := flattenFileValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, flat)
+		}
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		entries := make([]string, 0, len(v))
+		for name, item := range v {
+			flat, err := flattenFileValue(item)
+			if err != nil {
+				return "", err
+			}
+			if list, isList := item.([]any); isList && len(list) > 0 {
+				flat = strings.ReplaceAll(flat, ",", "|")
+			}
+			entries = append(entries, name+":"+flat)
+		}
+		sort.Strings(entries)
+		return strings.Join(entries, ","), nil
+	case map[any]any:
+		return "", fmt.Errorf("map keys must be strings")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// fail records a problem with key
+func (l *loader) fail(key, format string, args ...any) {
+	l.problems = append(l.problems, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns the collected problems, including file keys no setting reads, or nil when there are none
+func (l *loader) err() error {
+	var unknown []string
+	for key := range l.file {
+		if !l.read[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		l.fail(key, "unknown key in %s", FileEnv)
+	}
+
+	if len(l.problems) == 0 {
+		return nil
+	}
+	return &Error{Problems: l.problems}
+}
+
+// raw returns the trimmed value of key, the environment taking precedence over the file
+// An empty value counts as unset
+func (l *loader) raw(key string) (string, bool) {
+	l.read[key] = true
+	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+		return value, true
+	}
+	if value := strings.TrimSpace(l.file[key]); value != "" {
+		return value, true
+	}
+	return "", false
+}
+
+// string returns key as a string
+func (l *loader) string(key, defaultValue string) string {
+	if value, ok := l.raw(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// oneOf returns key spelled as the matching allowed value, compared ignoring case
+func (l *loader) oneOf(key, defaultValue string, allowed ...string) string {
+	value, ok := l.raw(key)
+	if !ok {
+		return defaultValue
+	}
+	for _, candidate := range allowed {
+		if strings.EqualFold(value, candidate) {
+			return candidate
+		}
+	}
+	l.fail(key, "%q is not one of %s", value, strings.Join(allowed, ", "))
+	return defaultValue
+}
+
+// url returns key, which must be an absolute http or https URL
+func (l *loader) url(key, defaultValue string) string {
+	value, ok := l.raw(key)
+	if !ok {
+		return defaultValue
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		l.fail(key, "%q is not an http or https URL", value)
+		return defaultValue
+	}
+	return value
+}
+
+// int returns key as an integer of at least min
+func (l *loader) int(key string, defaultValue, min int) int {
+	return l.intBetween(key, defaultValue, min, math.MaxInt)
+}
+
+// intBetween returns key as an integer within [min, max]
+func (l *loader) intBetween(key string, defaultValue, min, max int) int {
+	value, ok := l.raw(key)
+	if !ok {
+		return defaultValue
+	}
+	parsed, err := l.parseInt(key, value, min, max)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+// parseInt parses value for key, recording a problem when it is not an integer within [min, max]
+func (l *loader) parseInt(key, value string, min, max int) (int, error) {
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		l.fail(key, "%q is not an integer", value)
+		return 0, err
+	}
+	if parsed < min || parsed > max {
+		err := fmt.Errorf("out of range")
+		if max == math.MaxInt {
+			l.fail(key, "%d must be at least %d", parsed, min)
+		} else {
+			l.fail(key, "%d must be between %d and %d", parsed, min, max)
+		}
+		return 0, err
+	}
+	return parsed, nil
+}
+
+// bool returns key as a boolean, accepting the spellings of strconv.ParseBool
+func (l *loader) bool(key string, defaultValue bool) bool {
+	value, ok := l.raw(key)
+	if !ok {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		l.fail(key, "%q is not a boolean", value)
+		return defaultValue
+	}
+	return parsed
+}
+
+// list returns key as a comma-separated list, dropping empty entries
+func (l *loader) list(key string) []string {
+	value, _ := l.raw(key)
+	return splitList(value, ",")
+}
+
+// listOf returns key as a comma-separated list of distinct allowed values, spelled as the matching allowed value
+func (l *loader) listOf(key string, defaultValue []string, allowed ...string) []string {
+	entries := l.list(key)
+	if len(entries) == 0 {
+		return defaultValue
+	}
+	result := make([]string, 0, len(entries))
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		index := slices.IndexFunc(allowed, func(candidate string) bool { return strings.EqualFold(entry, candidate) })
+		if index < 0 {
+			l.fail(key, "%q is not one of %s", entry, strings.Join(allowed, ", "))
+			return defaultValue
+		}
+		if seen[allowed[index]] {
+			l.fail(key, "%q is listed more than once", entry)
+			return defaultValue
+		}
+		seen[allowed[index]] = true
+		result = append(result, allowed[index])
+	}
+	return result
+}
+
+// intList returns key as a comma-separated list of integers within [min, max]
+func (l *loader) intList(key string, defaultValue []int, min, max int) []int {
+	entries := l.list(key)
+	if len(entries) == 0 {
+		return defaultValue
+	}
+	result := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		parsed, err := l.parseInt(key, entry, min, max)
+		if err != nil {
+			return defaultValue
+		}
+		result = append(result, parsed)
+	}
+	return result
+}
+
+// stringMap returns key written as "key1:value1,key2:value2"
+func (l *loader) stringMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, entry := range l.list(key) {
+		name, value, found := strings.Cut(entry, ":")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !found || name == "" || value == "" {
+			l.fail(key, "entry %q is not of the form name:value", entry)
+			continue
+		}
+		result[name] = value
+	}
+	return result
+}
+
+// intMap returns key written as "key1:10,key2:25" with values of at least min
+func (l *loader) intMap(key string, min int) map[string]int {
+	result := make(map[string]int)
+	for name, value := range l.stringMap(key) {
+		if parsed, err := l.parseInt(key, value, min, math.MaxInt); err == nil {
+			result[name] = parsed
+		}
+	}
+	return result
+}
+
+// floatMap returns key written as "key1:0.92,key2:5.1" with positive values
+func (l *loader) floatMap(key string) map[string]float64 {
+	result := make(map[string]float64)
+	for name, value := range l.stringMap(key) {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 || math.IsInf(parsed, 0) {
+			l.fail(key, "value %q of %s is not a positive number", value, name)
+			continue
+		}
+		result[name] = parsed
+	}
+	return result
+}
+
+// listMap returns key written as "key1:a|b,key2:c"
+func (l *loader) listMap(key string) map[string][]string {
+	result := make(map[string][]string)
+	for name, value := range l.stringMap(key) {
+		result[name] = splitList(value, "|")
+	}
+	return result
+}
+
+// splitList splits value on sep, trimming entries and dropping empty ones
+func splitList(value, sep string) []string {
+	var result []string
+	for _, entry := range strings.Split(value, sep) {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
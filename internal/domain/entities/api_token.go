@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIRole is the scope granted to an API token; each role includes the ones below it
+type APIRole string
+
+// Supported API token roles
+const (
+	RoleRead  APIRole = "read"  // Read and list endpoints
+	RoleWrite APIRole = "write" // Creating transactions, budgets and conversions
+	RoleAdmin APIRole = "admin" // Admin endpoints, including token management
+)
+
+// rank orders roles so a role can be checked against the one a route requires
+func (r APIRole) rank() int {
+	switch r {
+	case RoleRead:
+		return 1
+	case RoleWrite:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// IsValid checks if the role is a supported one
+func (r APIRole) IsValid() bool {
+	return r.rank() > 0
+}
+
+// Includes reports whether the role grants everything the required role does
+func (r APIRole) Includes(required APIRole) bool {
+	return r.IsValid() && r.rank() >= required.rank()
+}
+
+// API token statuses reported to clients
+const (
+	TokenActive  = "active"
+	TokenExpired = "expired"
+	TokenRevoked = "revoked"
+)
+
+// APIToken is a credential for the X-API-Key header; only a hash of the secret is stored
+type APIToken struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	Name         string     `json:"name" gorm:"not null" validate:"required,max=100"`
+	Prefix       string     `json:"prefix" gorm:"not null"`                    // Leading characters of the secret, to tell tokens apart
+	TokenHash    string     `json:"-" gorm:"not null;uniqueIndex"`             // SHA-256 of the secret, hex-encoded
+	Role         APIRole    `json:"role" gorm:"not null"`                      // read, write or admin
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`                      // Nil for tokens that never expire
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`                      // Set when the token is revoked or rotated without a grace period
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`                    // Refreshed at most once a minute
+	ReplacedByID *uuid.UUID `json:"replaced_by_id,omitempty" gorm:"type:uuid"` // Token issued when this one was rotated
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Validate performs business rule validation
+func (t *APIToken) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("token name is required")
+	}
+
+	if len(t.Name) > 100 {
+		return fmt.Errorf("token name must not exceed 100 characters")
+	}
+
+	if t.TokenHash == "" {
+		return fmt.Errorf("token hash is required")
+	}
+
+	if !t.Role.IsValid() {
+		return fmt.Errorf("invalid role: %s", t.Role)
+	}
+
+	return nil
+}
+
+// Status reports whether the token is active, expired or revoked at now
+func (t *APIToken) Status(now time.Time) string {
+	switch {
+	case t.RevokedAt != nil && !t.RevokedAt.After(now):
+		return TokenRevoked
+	case t.ExpiresAt != nil && !t.ExpiresAt.After(now):
+		return TokenExpired
+	default:
+		return TokenActive
+	}
+}
+
+// IsActive reports whether the token can authenticate requests at now
+func (t *APIToken) IsActive(now time.Time) bool {
+	return t.Status(now) == TokenActive
+}
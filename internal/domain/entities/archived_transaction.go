@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchivedTransaction is a transaction moved out of the hot transactions table into cold storage
+type ArchivedTransaction struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primary_key"`
+	Description string       `gorm:"not null"`
+	Date        time.Time    `gorm:"not null;index"`
+	Amount      Money        `gorm:"not null"`
+	Currency    CurrencyCode `gorm:"size:3;not null;default:USD"`
+	Category    string
+	Tags        []string  `gorm:"serializer:json"`
+	ExternalID  *string   `gorm:"index"`
+	Version     int64     `gorm:"not null;default:1"`
+	CreatedAt   time.Time `gorm:"not null;index"`
+	UpdatedAt   time.Time `gorm:"not null"`
+	ArchivedAt  time.Time `gorm:"not null;index"`
+}
+
+// NewArchivedTransaction copies a transaction into its archived form
+func NewArchivedTransaction(transaction Transaction, archivedAt time.Time) ArchivedTransaction {
+	return ArchivedTransaction{
+		ID:          transaction.ID,
+		Description: transaction.Description,
+		Date:        transaction.Date,
+		Amount:      transaction.Amount,
+		Currency:    transaction.Currency,
+		Category:    transaction.Category,
+		Tags:        transaction.Tags,
+		ExternalID:  transaction.ExternalID,
+		Version:     transaction.Version,
+		CreatedAt:   transaction.CreatedAt,
+		UpdatedAt:   transaction.UpdatedAt,
+		ArchivedAt:  archivedAt,
+	}
+}
+
+// Transaction returns the archived record as a transaction marked with its archive time
+func (a ArchivedTransaction) Transaction() Transaction {
+	archivedAt := a.ArchivedAt
+	return Transaction{
+		ID:          a.ID,
+		Description: a.Description,
+		Date:        a.Date,
+		Amount:      a.Amount,
+		Currency:    a.Currency,
+		Category:    a.Category,
+		Tags:        a.Tags,
+		ExternalID:  a.ExternalID,
+		Version:     a.Version,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+		ArchivedAt:  &archivedAt,
+	}
+}
@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+)
+
+// Audited entity types
+const (
+	AuditEntityTransaction = "transaction"
+)
+
+// Audited actions
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionConvert = "convert"
+)
+
+// AuditActorAnonymous identifies callers that presented no credential
+const AuditActorAnonymous = "anonymous"
+
+// AuditLog records one change made to an entity, for compliance reviews
+// Entries are only ever inserted
+type AuditLog struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	EntityType string    `json:"entity_type" gorm:"not null;index:idx_audit_logs_entity"`
+	EntityID   uuid.UUID `json:"entity_id" gorm:"type:uuid;not null;index:idx_audit_logs_entity"`
+	Action     string    `json:"action" gorm:"not null"`
+	Actor      string    `json:"actor" gorm:"not null"`              // token:<id>, subject:<sub>, key:<sha256 of an unverified key> or anonymous
+	RequestID  string    `json:"request_id"`                         // X-Request-ID of the request that made the change
+	Changes    string    `json:"changes,omitempty" gorm:"type:text"` // JSON of the values written, empty when the action carries none
+	CreatedAt  time.Time `json:"created_at" gorm:"not null;index"`
+}
+
+// NewAuditLog records action on an entity at the current time of clk; changes is marshaled to JSON unless nil
+func NewAuditLog(entityType string, entityID uuid.UUID, action, actor, requestID string, changes interface{}, clk clock.Clock) (*AuditLog, error) {
+	if actor == "" {
+		actor = AuditActorAnonymous
+	}
+
+	entry := &AuditLog{
+		ID:         uuid.New(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Actor:      actor,
+		RequestID:  requestID,
+		CreatedAt:  clk.Now().UTC(),
+	}
+	if changes != nil {
+		encoded, err := json.Marshal(changes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode audit changes: %w", err)
+		}
+		entry.Changes = string(encoded)
+	}
+
+	return entry, entry.Validate()
+}
+
+// Validate performs business rule validation
+func (a *AuditLog) Validate() error {
+	if a.EntityType == "" {
+		return fmt.Errorf("audit entity type is required")
+	}
+	if a.EntityID == uuid.Nil {
+		return fmt.Errorf("audit entity ID is required")
+	}
+	if a.Action == "" {
+		return fmt.Errorf("audit action is required")
+	}
+	return nil
+}
+
+// AuditLogFilter narrows an audit trail listing; zero fields don't filter
+type AuditLogFilter struct {
+	EntityID *uuid.UUID
+	From     *time.Time // Recorded at or after
+	To       *time.Time // Recorded before
+}
@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+// BankTransaction is an account transaction reported by a bank aggregation provider
+type BankTransaction struct {
+	ID          string    // Provider's transaction ID, stable across syncs
+	AccountID   string    // Provider's account ID
+	Description string    // Merchant name, or the raw bank description when there is none
+	Amount      float64   // Positive for money leaving the account (purchases), negative for credits
+	Currency    string    // ISO 4217 code
+	Date        time.Time // Posting date
+	Pending     bool      // Not yet posted; may still change or disappear
+}
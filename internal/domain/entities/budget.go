@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BudgetPeriod is the window over which spend is measured against a budget limit
+type BudgetPeriod string
+
+// Supported budget periods; weeks start on Monday, all periods in UTC
+const (
+	BudgetWeekly  BudgetPeriod = "weekly"
+	BudgetMonthly BudgetPeriod = "monthly"
+	BudgetYearly  BudgetPeriod = "yearly"
+)
+
+// DefaultBudgetThresholds are the alert percentages used when a budget does not set its own
+var DefaultBudgetThresholds = []int{80, 100}
+
+// Budget caps the spend of a category per period, in the budget's currency
+type Budget struct {
+	ID         uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	Category   string       `json:"category" gorm:"not null;index"`
+	Period     BudgetPeriod `json:"period" gorm:"not null"`
+	Limit      Money        `json:"limit" gorm:"column:limit_amount;not null"` // LIMIT is reserved in SQL
+	Currency   CurrencyCode `json:"currency" gorm:"not null"`
+	Thresholds []int        `json:"thresholds" gorm:"serializer:json"` // Percentages of the limit that raise an alert, ascending
+	CreatedAt  time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Validate performs business rule validation
+func (b *Budget) Validate() error {
+	if b.Category == "" {
+		return fmt.Errorf("category is required")
+	}
+	if len(b.Category) > 50 {
+		return fmt.Errorf("category must not exceed 50 characters")
+	}
+
+	switch b.Period {
+	case BudgetWeekly, BudgetMonthly, BudgetYearly:
+	default:
+		return fmt.Errorf("invalid budget period %q: must be weekly, monthly or yearly", b.Period)
+	}
+
+	if !b.Limit.IsPositive() {
+		return fmt.Errorf("budget limit must be positive")
+	}
+	if _, known := b.Currency.Info(); !known {
+		return fmt.Errorf("invalid budget currency %q", b.Currency)
+	}
+
+	for i, threshold := range b.Thresholds {
+		if threshold < 1 || threshold > 1000 {
+			return fmt.Errorf("invalid budget threshold %d: must be between 1 and 1000 percent", threshold)
+		}
+		if i > 0 && threshold <= b.Thresholds[i-1] {
+			return fmt.Errorf("invalid budget thresholds: must be ascending without duplicates")
+		}
+	}
+
+	return nil
+}
+
+// PeriodBounds returns the [start, end) window of the period containing date
+func (b *Budget) PeriodBounds(date time.Time) (time.Time, time.Time) {
+	date = date.UTC()
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch b.Period {
+	case BudgetWeekly:
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	case BudgetYearly:
+		start := time.Date(day.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, 0)
+	default:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+}
+
+// CrossedThresholds returns the thresholds reached by spend going from before to after
+// A threshold is crossed once, when spend first reaches it within a period
+func (b *Budget) CrossedThresholds(before, after Money) []int {
+	var crossed []int
+	for _, threshold := range b.Thresholds {
+		level := b.Limit * Money(threshold) / 100
+		if before < level && after >= level {
+			crossed = append(crossed, threshold)
+		}
+	}
+	return crossed
+}
+
+// NormalizeThresholds sorts and deduplicates thresholds, falling back to DefaultBudgetThresholds
+func NormalizeThresholds(thresholds []int) []int {
+	if len(thresholds) == 0 {
+		return slices.Clone(DefaultBudgetThresholds)
+	}
+	normalized := slices.Clone(thresholds)
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// BudgetThresholdCrossedEvent describes a transaction that took a category's spend past a budget threshold
+type BudgetThresholdCrossedEvent struct {
+	Budget        Budget    `json:"budget"`
+	Threshold     int       `json:"threshold"` // Percent of the limit
+	Spent         Money     `json:"spent"`     // Period spend in the budget currency, including the transaction
+	PeriodStart   time.Time `json:"period_start"`
+	PeriodEnd     time.Time `json:"period_end"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category is a named spending category; transactions and budgets reference it by name
+type Category struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Name        string    `json:"name" gorm:"not null;uniqueIndex"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Validate performs business rule validation
+func (c *Category) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("category name is required")
+	}
+	if len(c.Name) > 50 {
+		return fmt.Errorf("category name must not exceed 50 characters")
+	}
+	if strings.TrimSpace(c.Name) != c.Name {
+		return fmt.Errorf("category name must not start or end with spaces")
+	}
+	if len(c.Description) > 255 {
+		return fmt.Errorf("category description must not exceed 255 characters")
+	}
+	return nil
+}
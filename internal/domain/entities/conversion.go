@@ -0,0 +1,160 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+)
+
+// ConversionRecord is a persisted conversion of a transaction, kept for reporting
+type ConversionRecord struct {
+	ID              uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	TransactionID   uuid.UUID    `json:"transaction_id" gorm:"type:uuid;not null;index"`
+	BatchID         *uuid.UUID   `json:"batch_id,omitempty" gorm:"type:uuid;index"` // Batch run that produced the record
+	TargetCurrency  CurrencyCode `json:"target_currency" gorm:"not null;index"`
+	TransactionDate time.Time    `json:"transaction_date" gorm:"not null"`
+	OriginalAmount  Money        `json:"original_amount" gorm:"not null"`
+	ExchangeRate    float64      `json:"exchange_rate" gorm:"not null"`
+	EffectiveDate   time.Time    `json:"effective_date" gorm:"not null"` // Effective date of the rate used
+	ConvertedAmount Money        `json:"converted_amount" gorm:"not null"`
+	CreatedAt       time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	SupersededAt    *time.Time   `json:"superseded_at,omitempty" gorm:"index"` // Set when a closer rate replaced this record
+	SupersededBy    *uuid.UUID   `json:"superseded_by,omitempty" gorm:"type:uuid"`
+}
+
+// NewConversionRecord captures a converted transaction so it can be reported on later, stored at the current time of clk
+func NewConversionRecord(converted *ConvertedTransaction, batchID *uuid.UUID, clk clock.Clock) (*ConversionRecord, error) {
+	if converted == nil {
+		return nil, fmt.Errorf("converted transaction is required")
+	}
+
+	return &ConversionRecord{
+		ID:              uuid.New(),
+		TransactionID:   converted.Transaction.ID,
+		BatchID:         batchID,
+		TargetCurrency:  converted.TargetCurrency,
+		TransactionDate: converted.Transaction.Date,
+		OriginalAmount:  converted.Transaction.Amount,
+		ExchangeRate:    converted.ExchangeRate,
+		EffectiveDate:   converted.EffectiveDate,
+		ConvertedAmount: converted.ConvertedAmount,
+		CreatedAt:       clk.Now().UTC(),
+	}, nil
+}
+
+// IsSuperseded reports whether the record has been replaced by one using a better rate
+func (r *ConversionRecord) IsSuperseded() bool {
+	return r.SupersededAt != nil
+}
+
+// Reprice builds the replacement record for the same transaction using exchangeRate, which must apply within window
+func (r *ConversionRecord) Reprice(exchangeRate *ExchangeRate, window RateWindow, rounding RoundingMode, clk clock.Clock) (*ConversionRecord, error) {
+	transaction := Transaction{
+		ID:     r.TransactionID,
+		Date:   r.TransactionDate,
+		Amount: r.OriginalAmount,
+	}
+
+	converted, err := NewConvertedTransaction(transaction, r.TargetCurrency, exchangeRate, window, rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConversionRecord(converted, r.BatchID, clk)
+}
+
+// Conversion is a conversion served to a client, kept in the transaction's conversion history
+// Unlike a ConversionRecord it is never repriced: it records the rate that was actually applied
+type Conversion struct {
+	ID              uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	TransactionID   uuid.UUID    `json:"transaction_id" gorm:"type:uuid;not null;index:idx_conversions_transaction"`
+	TargetCurrency  CurrencyCode `json:"target_currency" gorm:"not null"`
+	RawExchangeRate float64      `json:"raw_exchange_rate" gorm:"not null"`
+	MarginBps       int          `json:"margin_bps" gorm:"not null"`
+	ExchangeRate    float64      `json:"exchange_rate" gorm:"not null"` // Includes the margin
+	EffectiveDate   time.Time    `json:"effective_date" gorm:"not null"`
+	ConvertedAmount Money        `json:"converted_amount" gorm:"not null"`
+	QuoteID         *uuid.UUID   `json:"quote_id,omitempty" gorm:"type:uuid"` // Quote that pinned the rate
+	CreatedAt       time.Time    `json:"created_at" gorm:"not null;index:idx_conversions_transaction"`
+}
+
+// NewConversion captures a converted transaction priced from rawRate with marginBps, served at the current time of clk
+func NewConversion(converted *ConvertedTransaction, rawRate float64, marginBps int, quoteID *uuid.UUID, clk clock.Clock) (*Conversion, error) {
+	if converted == nil {
+		return nil, fmt.Errorf("converted transaction is required")
+	}
+
+	return &Conversion{
+		ID:              uuid.New(),
+		TransactionID:   converted.Transaction.ID,
+		TargetCurrency:  converted.TargetCurrency,
+		RawExchangeRate: rawRate,
+		MarginBps:       marginBps,
+		ExchangeRate:    converted.ExchangeRate,
+		EffectiveDate:   converted.EffectiveDate,
+		ConvertedAmount: converted.ConvertedAmount,
+		QuoteID:         quoteID,
+		CreatedAt:       clk.Now().UTC(),
+	}, nil
+}
+
+// ConversionSupersededEvent describes a stored conversion replaced because a closer rate arrived
+type ConversionSupersededEvent struct {
+	Previous    ConversionRecord `json:"previous"`
+	Replacement ConversionRecord `json:"replacement"`
+	OccurredAt  time.Time        `json:"occurred_at"`
+}
+
+// ConversionBatchStatus is the lifecycle state of a batch conversion run
+type ConversionBatchStatus string
+
+// Batch conversion states
+const (
+	BatchStatusPending   ConversionBatchStatus = "pending"
+	BatchStatusRunning   ConversionBatchStatus = "running"
+	BatchStatusCompleted ConversionBatchStatus = "completed"
+	BatchStatusFailed    ConversionBatchStatus = "failed"
+)
+
+// ConversionBatch tracks a run that converts every transaction in a date range to one currency
+type ConversionBatch struct {
+	ID             uuid.UUID             `json:"id" gorm:"type:uuid;primaryKey"`
+	TargetCurrency CurrencyCode          `json:"target_currency" gorm:"not null"`
+	FromDate       time.Time             `json:"from_date" gorm:"not null"`
+	ToDate         time.Time             `json:"to_date" gorm:"not null"` // Inclusive
+	Status         ConversionBatchStatus `json:"status" gorm:"not null;index"`
+	Total          int                   `json:"total"`
+	Processed      int                   `json:"processed"`
+	Succeeded      int                   `json:"succeeded"`
+	Failed         int                   `json:"failed"`
+	Error          string                `json:"error,omitempty"`
+	CreatedAt      time.Time             `json:"created_at" gorm:"autoCreateTime"`
+	StartedAt      *time.Time            `json:"started_at,omitempty"`
+	CompletedAt    *time.Time            `json:"completed_at,omitempty"`
+}
+
+// NewConversionBatch creates a pending batch for transactions dated from..to inclusive, created at the current time of clk
+func NewConversionBatch(targetCurrency CurrencyCode, from, to time.Time, clk clock.Clock) (*ConversionBatch, error) {
+	if !targetCurrency.IsValid() {
+		return nil, fmt.Errorf("invalid target currency: %s", targetCurrency)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid date range: to %s is before from %s", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+
+	return &ConversionBatch{
+		ID:             uuid.New(),
+		TargetCurrency: targetCurrency,
+		FromDate:       from,
+		ToDate:         to,
+		Status:         BatchStatusPending,
+		CreatedAt:      clk.Now().UTC(),
+	}, nil
+}
+
+// IsFinished reports whether the batch has stopped running
+func (b *ConversionBatch) IsFinished() bool {
+	return b.Status == BatchStatusCompleted || b.Status == BatchStatusFailed
+}
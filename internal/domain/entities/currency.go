@@ -2,10 +2,14 @@ package entities
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
 )
 
 // CurrencyCode represents a 3-letter ISO currency code
@@ -21,8 +25,32 @@ const (
 	CAD CurrencyCode = "CAD"
 	AUD CurrencyCode = "AUD"
 	CNY CurrencyCode = "CNY"
+	KRW CurrencyCode = "KRW"
+	CLP CurrencyCode = "CLP"
 )
 
+// CurrencyInfo describes display and formatting metadata for a currency
+type CurrencyInfo struct {
+	Code       CurrencyCode `json:"code"`
+	Name       string       `json:"name"`
+	Symbol     string       `json:"symbol"`
+	MinorUnits int          `json:"minor_units"` // Number of decimal places (ISO 4217 exponent)
+}
+
+// currencyInfos holds metadata for the currencies known to the system
+var currencyInfos = map[CurrencyCode]CurrencyInfo{
+	USD: {Code: USD, Name: "US Dollar", Symbol: "$", MinorUnits: 2},
+	EUR: {Code: EUR, Name: "Euro", Symbol: "€", MinorUnits: 2},
+	BRL: {Code: BRL, Name: "Brazilian Real", Symbol: "R$", MinorUnits: 2},
+	GBP: {Code: GBP, Name: "Pound Sterling", Symbol: "£", MinorUnits: 2},
+	JPY: {Code: JPY, Name: "Japanese Yen", Symbol: "¥", MinorUnits: 0},
+	CAD: {Code: CAD, Name: "Canadian Dollar", Symbol: "CA$", MinorUnits: 2},
+	AUD: {Code: AUD, Name: "Australian Dollar", Symbol: "A$", MinorUnits: 2},
+	CNY: {Code: CNY, Name: "Chinese Yuan Renminbi", Symbol: "CN¥", MinorUnits: 2},
+	KRW: {Code: KRW, Name: "South Korean Won", Symbol: "₩", MinorUnits: 0},
+	CLP: {Code: CLP, Name: "Chilean Peso", Symbol: "CLP$", MinorUnits: 0},
+}
+
 // ExchangeRate represents a currency exchange rate from Treasury API
 type ExchangeRate struct {
 	ID            uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
@@ -32,6 +60,15 @@ type ExchangeRate struct {
 	EffectiveDate time.Time    `json:"effective_date" gorm:"not null" validate:"required"`
 	RecordDate    time.Time    `json:"record_date" gorm:"not null"`
 	CreatedAt     time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	Source        string       `json:"source,omitempty" gorm:"-"` // Fallback provider that published the rate; empty for the Treasury
+}
+
+// RateCacheSummary describes the exchange rates cached locally for one target currency
+type RateCacheSummary struct {
+	Currency            CurrencyCode
+	Rates               int64
+	OldestEffectiveDate time.Time
+	NewestEffectiveDate time.Time
 }
 
 // ConvertedTransaction represents a transaction with currency conversion applied
@@ -41,6 +78,9 @@ type ConvertedTransaction struct {
 	ExchangeRate    float64      `json:"exchange_rate"`
 	ConvertedAmount Money        `json:"converted_amount"`
 	EffectiveDate   time.Time    `json:"effective_date"`
+	Interpolated    bool         `json:"interpolated"`
+	RateBounds      []time.Time  `json:"rate_bounds,omitempty"` // Effective dates of the quotes used for interpolation
+	RateStale       bool         `json:"rate_stale"`            // The rate took effect before the lookback window
 }
 
 // String returns the currency code as string
@@ -65,6 +105,56 @@ func (c CurrencyCode) IsValid() bool {
 	return true
 }
 
+// Info returns the metadata for the currency code, if it is known
+func (c CurrencyCode) Info() (CurrencyInfo, bool) {
+	info, exists := currencyInfos[c]
+	return info, exists
+}
+
+// MinorUnits returns the number of decimals amounts in the currency are rounded to
+// Unknown currencies use 2; Money holds cents, so currencies with 3 minor units are kept to 2
+func (c CurrencyCode) MinorUnits() int {
+	info, exists := c.Info()
+	if !exists {
+		return 2
+	}
+	return min(info.MinorUnits, 2)
+}
+
+// centsPerMinorUnit returns how many cents make up one minor unit of the currency: 100 for JPY, 1 for USD
+func (c CurrencyCode) centsPerMinorUnit() int64 {
+	unit := int64(1)
+	for range 2 - c.MinorUnits() {
+		unit *= 10
+	}
+	return unit
+}
+
+// RegisterCurrency adds metadata for a currency the built-in table does not know, such as one mapped from configuration
+// Built-in currencies keep their metadata. It is not safe for concurrent use and must run before requests are served
+func RegisterCurrency(info CurrencyInfo) error {
+	if !info.Code.IsValid() {
+		return fmt.Errorf("invalid currency code format: %s", info.Code)
+	}
+	if info.MinorUnits < 0 || info.MinorUnits > 3 {
+		return fmt.Errorf("minor units of %s must be between 0 and 3, got %d", info.Code, info.MinorUnits)
+	}
+	if _, exists := currencyInfos[info.Code]; !exists {
+		currencyInfos[info.Code] = info
+	}
+	return nil
+}
+
+// KnownCurrencies returns all currency codes with metadata, sorted alphabetically
+func KnownCurrencies() []CurrencyCode {
+	codes := make([]CurrencyCode, 0, len(currencyInfos))
+	for code := range currencyInfos {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	return codes
+}
+
 // NewCurrencyCode creates a new currency code from string with validation
 func NewCurrencyCode(code string) (CurrencyCode, error) {
 	normalized := strings.ToUpper(strings.TrimSpace(code))
@@ -106,28 +196,57 @@ func (er *ExchangeRate) Validate() error {
 	return nil
 }
 
-// IsWithinDateRange checks if the exchange rate is within 6 months before the given date
-func (er *ExchangeRate) IsWithinDateRange(transactionDate time.Time) bool {
-	sixMonthsAgo := transactionDate.AddDate(0, -6, 0)
-	return !er.EffectiveDate.Before(sixMonthsAgo) && !er.EffectiveDate.After(transactionDate)
+// IsAuthoritative reports whether the rate was published by the Treasury
+// Only Treasury rates are stored, so stored rates always take precedence over fallback providers
+func (er *ExchangeRate) IsAuthoritative() bool {
+	return er.Source == ""
 }
 
-// ConvertAmount converts a Money amount using this exchange rate
+// IsWithinDateRange checks if the exchange rate took effect within window before the given date
+func (er *ExchangeRate) IsWithinDateRange(transactionDate time.Time, window RateWindow) bool {
+	return window.Contains(er.EffectiveDate, transactionDate)
+}
+
+// ConvertAmount converts a Money amount using this exchange rate, rounding half up to the target's minor unit
+// Results beyond the range of Money saturate; use Convert where overflow must be reported
 func (er *ExchangeRate) ConvertAmount(amount Money) Money {
-	dollars := amount.Dollars()
-	convertedDollars := dollars * er.Rate
-	return NewMoney(convertedDollars)
+	converted, err := er.Convert(amount, RoundHalfUp)
+	if err != nil {
+		return saturate(amount.Dollars() * er.Rate)
+	}
+	return converted
+}
+
+// Convert converts a Money amount using this exchange rate, rounding to the target currency's minor unit with mode
+func (er *ExchangeRate) Convert(amount Money, mode RoundingMode) (Money, error) {
+	return amount.MulRateIn(er.Rate, er.ToCurrency, mode)
+}
+
+// ApplyMargin reduces a rate by a margin in basis points (1 bps = 0.01%)
+// The customer receives fewer units of the target currency per USD
+func ApplyMargin(rate float64, marginBps int) float64 {
+	if marginBps <= 0 {
+		return rate
+	}
+
+	// Round to 6 decimal places to avoid float noise in responses
+	return math.Round(rate*(1-float64(marginBps)/10000)*1e6) / 1e6
+}
+
+// NewExchangeRate creates a new exchange rate with validation, recorded at the current time of clk
+func NewExchangeRate(from, to CurrencyCode, rate float64, effectiveDate time.Time, clk clock.Clock) (*ExchangeRate, error) {
+	return newExchangeRate(from, to, rate, effectiveDate, clk.Now())
 }
 
-// NewExchangeRate creates a new exchange rate with validation
-func NewExchangeRate(from, to CurrencyCode, rate float64, effectiveDate time.Time) (*ExchangeRate, error) {
+// newExchangeRate creates a new exchange rate with validation
+func newExchangeRate(from, to CurrencyCode, rate float64, effectiveDate, recordDate time.Time) (*ExchangeRate, error) {
 	exchangeRate := &ExchangeRate{
 		ID:            uuid.New(),
 		FromCurrency:  from,
 		ToCurrency:    to,
 		Rate:          rate,
 		EffectiveDate: effectiveDate,
-		RecordDate:    time.Now(),
+		RecordDate:    recordDate,
 	}
 
 	if err := exchangeRate.Validate(); err != nil {
@@ -137,15 +256,81 @@ func NewExchangeRate(from, to CurrencyCode, rate float64, effectiveDate time.Tim
 	return exchangeRate, nil
 }
 
+// CrossRate derives the from→to rate from the USD→from and USD→to rates, e.g. EUR→BRL as USD/BRL divided by USD/EUR
+// A nil leg stands for USD itself. The result takes the older effective date of its legs, so the lookback rule holds for both;
+// it is not meant to be persisted and is recorded on the date it takes effect
+func CrossRate(from, to CurrencyCode, usdFrom, usdTo *ExchangeRate) (*ExchangeRate, error) {
+	fromRate, fromDate, err := crossLeg(from, usdFrom)
+	if err != nil {
+		return nil, err
+	}
+	toRate, toDate, err := crossLeg(to, usdTo)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveDate := fromDate
+	if effectiveDate.IsZero() || (!toDate.IsZero() && toDate.Before(effectiveDate)) {
+		effectiveDate = toDate
+	}
+
+	return newExchangeRate(from, to, toRate/fromRate, effectiveDate, effectiveDate)
+}
+
+// crossLeg returns the USD→code rate and its effective date; USD itself has rate 1 and no date
+func crossLeg(code CurrencyCode, leg *ExchangeRate) (float64, time.Time, error) {
+	if code == USD {
+		if leg != nil {
+			return 0, time.Time{}, fmt.Errorf("a USD leg needs no exchange rate")
+		}
+		return 1, time.Time{}, nil
+	}
+	if leg == nil {
+		return 0, time.Time{}, fmt.Errorf("cross rate requires the USD/%s rate", code)
+	}
+	if leg.FromCurrency != USD || leg.ToCurrency != code {
+		return 0, time.Time{}, fmt.Errorf("cross rate leg must be USD/%s, got %s/%s", code, leg.FromCurrency, leg.ToCurrency)
+	}
+	return leg.Rate, leg.EffectiveDate, nil
+}
+
+// InterpolateExchangeRate linearly interpolates the rate for date between two surrounding quotes
+// The result is effective and recorded on date itself and is not meant to be persisted
+func InterpolateExchangeRate(before, after *ExchangeRate, date time.Time) (*ExchangeRate, error) {
+	if before == nil || after == nil {
+		return nil, fmt.Errorf("interpolation requires rates on both sides of %s", date.Format("2006-01-02"))
+	}
+
+	if before.FromCurrency != after.FromCurrency || before.ToCurrency != after.ToCurrency {
+		return nil, fmt.Errorf("cannot interpolate between %s/%s and %s/%s rates",
+			before.FromCurrency, before.ToCurrency, after.FromCurrency, after.ToCurrency)
+	}
+
+	if date.Before(before.EffectiveDate) || !date.Before(after.EffectiveDate) {
+		return nil, fmt.Errorf("date %v is not between rate dates %v and %v",
+			date, before.EffectiveDate, after.EffectiveDate)
+	}
+
+	span := after.EffectiveDate.Sub(before.EffectiveDate).Seconds()
+	elapsed := date.Sub(before.EffectiveDate).Seconds()
+	rate := before.Rate + (after.Rate-before.Rate)*elapsed/span
+
+	// Round to 6 decimal places to avoid float noise in responses
+	rate = math.Round(rate*1e6) / 1e6
+
+	return newExchangeRate(before.FromCurrency, before.ToCurrency, rate, date, date)
+}
+
 // NewConvertedTransaction creates a converted transaction with proper validation
-func NewConvertedTransaction(tx Transaction, targetCurrency CurrencyCode, exchangeRate *ExchangeRate) (*ConvertedTransaction, error) {
-	if !exchangeRate.IsWithinDateRange(tx.Date) {
-		return nil, fmt.Errorf("exchange rate date %v is not within 6 months of transaction date %v",
-			exchangeRate.EffectiveDate, tx.Date)
+// The rate must have taken effect within window before the transaction date; the amount is rounded with rounding
+func NewConvertedTransaction(tx Transaction, targetCurrency CurrencyCode, exchangeRate *ExchangeRate, window RateWindow, rounding RoundingMode) (*ConvertedTransaction, error) {
+	if !exchangeRate.IsWithinDateRange(tx.Date, window) {
+		return nil, errs.Newf(errs.ErrRateUnavailable, "exchange rate date %v is not within %s of transaction date %v",
+			exchangeRate.EffectiveDate, window, tx.Date)
 	}
 
-	if exchangeRate.FromCurrency != USD {
-		return nil, fmt.Errorf("conversion must be from USD, got %s", exchangeRate.FromCurrency)
+	if exchangeRate.FromCurrency != tx.SourceCurrency() {
+		return nil, fmt.Errorf("conversion must be from %s, got %s", tx.SourceCurrency(), exchangeRate.FromCurrency)
 	}
 
 	if exchangeRate.ToCurrency != targetCurrency {
@@ -153,7 +338,10 @@ func NewConvertedTransaction(tx Transaction, targetCurrency CurrencyCode, exchan
 			exchangeRate.ToCurrency, targetCurrency)
 	}
 
-	convertedAmount := exchangeRate.ConvertAmount(tx.Amount)
+	convertedAmount, err := exchangeRate.Convert(tx.Amount, rounding)
+	if err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "cannot convert %.2f %s at %v: %w", tx.Amount.Dollars(), tx.SourceCurrency(), exchangeRate.Rate, err)
+	}
 
 	return &ConvertedTransaction{
 		Transaction:     tx,
@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+)
+
+// Names of the domain events published on the event bus
+const (
+	EventTransactionCreated   = "transaction.created"
+	EventTransactionUpdated   = "transaction.updated"
+	EventTransactionDeleted   = "transaction.deleted"
+	EventTransactionConverted = "transaction.converted"
+)
+
+// DomainEvent is something that happened in the domain that other parts of the system react to
+// Events are plain data so a broker-backed bus can serialize them as JSON
+type DomainEvent interface {
+	EventName() string
+	EventID() uuid.UUID
+	EventTime() time.Time
+}
+
+// EventMeta identifies one occurrence of a domain event
+type EventMeta struct {
+	ID         uuid.UUID `json:"id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// NewEventMeta stamps a new event occurring at the current time of clk
+func NewEventMeta(clk clock.Clock) EventMeta {
+	return EventMeta{ID: uuid.New(), OccurredAt: clk.Now().UTC()}
+}
+
+// EventID returns the unique ID of the event, shared by every consumer of it
+func (m EventMeta) EventID() uuid.UUID {
+	return m.ID
+}
+
+// EventTime returns when the event occurred
+func (m EventMeta) EventTime() time.Time {
+	return m.OccurredAt
+}
+
+// TransactionCreatedEvent announces a stored transaction, including imports and bank sync
+type TransactionCreatedEvent struct {
+	EventMeta
+	Transaction Transaction `json:"transaction"`
+}
+
+// EventName returns transaction.created
+func (e TransactionCreatedEvent) EventName() string {
+	return EventTransactionCreated
+}
+
+// TransactionUpdatedEvent announces a change to a stored transaction, including a restore from the trash
+type TransactionUpdatedEvent struct {
+	EventMeta
+	Transaction Transaction `json:"transaction"`
+}
+
+// EventName returns transaction.updated
+func (e TransactionUpdatedEvent) EventName() string {
+	return EventTransactionUpdated
+}
+
+// TransactionDeletedEvent announces that a transaction was moved to the trash
+type TransactionDeletedEvent struct {
+	EventMeta
+	TransactionID uuid.UUID `json:"transaction_id"`
+}
+
+// EventName returns transaction.deleted
+func (e TransactionDeletedEvent) EventName() string {
+	return EventTransactionDeleted
+}
+
+// TransactionConvertedEvent announces a conversion of a stored transaction to another currency
+type TransactionConvertedEvent struct {
+	EventMeta
+	Conversion      ConvertedTransaction `json:"conversion"` // ExchangeRate includes the margin
+	RawExchangeRate float64              `json:"raw_exchange_rate"`
+	MarginBps       int                  `json:"margin_bps"`
+	QuoteID         *uuid.UUID           `json:"quote_id,omitempty"`
+}
+
+// EventName returns transaction.converted
+func (e TransactionConvertedEvent) EventName() string {
+	return EventTransactionConverted
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+)
+
+// MaxIdempotencyKeyLength bounds the Idempotency-Key header value
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyKey records a request sent with an Idempotency-Key header so retries get the original response
+// A key is pending until the request completes; only completed keys are replayed
+type IdempotencyKey struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	Scope         string     `json:"scope" gorm:"not null;uniqueIndex:idx_idempotency_keys_scope_key"`                      // Caller the key belongs to; empty for anonymous callers
+	Key           string     `json:"key" gorm:"column:idempotency_key;not null;uniqueIndex:idx_idempotency_keys_scope_key"` // Client-chosen header value
+	RequestHash   string     `json:"request_hash" gorm:"not null"`                                                          // Fingerprint of the request body the key was first used with
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty" gorm:"type:uuid"`                                             // Set once the request completed
+	Response      string     `json:"response,omitempty"`                                                                    // JSON response body replayed on retries
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"not null;index"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// NewIdempotencyKey creates a pending key that can be replayed until window after the current time of clk
+func NewIdempotencyKey(scope, key, requestHash string, window time.Duration, clk clock.Clock) (*IdempotencyKey, error) {
+	if err := ValidateIdempotencyKey(key); err != nil {
+		return nil, err
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("idempotency window must be positive, got %s", window)
+	}
+
+	now := clk.Now().UTC()
+	return &IdempotencyKey{
+		ID:          uuid.New(),
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   now.Add(window),
+		CreatedAt:   now,
+	}, nil
+}
+
+// ValidateIdempotencyKey checks the header value is 1-255 printable ASCII characters
+func ValidateIdempotencyKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("invalid Idempotency-Key: must not be blank")
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return fmt.Errorf("invalid Idempotency-Key: must be at most %d characters", MaxIdempotencyKeyLength)
+	}
+	for _, r := range key {
+		if r < 0x20 || r > 0x7e {
+			return fmt.Errorf("invalid Idempotency-Key: must only contain printable ASCII characters")
+		}
+	}
+	return nil
+}
+
+// IsCompleted reports whether the request finished and its response can be replayed
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.TransactionID != nil
+}
+
+// Complete stores the outcome of the request
+func (k *IdempotencyKey) Complete(transactionID uuid.UUID, response string) {
+	k.TransactionID = &transactionID
+	k.Response = response
+}
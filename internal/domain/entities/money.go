@@ -0,0 +1,196 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money represents a monetary value in cents to avoid floating point precision issues
+type Money int64
+
+// ErrMoneyOverflow reports an amount, or the result of arithmetic on amounts, that Money cannot hold
+var ErrMoneyOverflow = errors.New("amount out of range")
+
+// RoundingMode selects how a value between two cents is rounded
+// The zero value rounds half up
+type RoundingMode string
+
+// Rounding modes for amounts that fall between two cents
+const (
+	RoundHalfUp   RoundingMode = "half_up"   // Ties away from zero (default)
+	RoundHalfEven RoundingMode = "half_even" // Ties to the even cent, also known as banker's rounding
+	RoundDown     RoundingMode = "down"      // Toward zero, dropping fractions of a cent
+)
+
+// ParseRoundingMode parses a rounding mode name; empty means RoundHalfUp
+func ParseRoundingMode(name string) (RoundingMode, error) {
+	switch mode := RoundingMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case "", RoundHalfUp:
+		return RoundHalfUp, nil
+	case RoundHalfEven, RoundDown:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown rounding mode %q, expected %s, %s or %s", name, RoundHalfUp, RoundHalfEven, RoundDown)
+	}
+}
+
+// FromCents creates a Money value from a whole number of cents
+func FromCents(cents int64) Money {
+	return Money(cents)
+}
+
+// NewMoneyFromString parses a decimal amount in dollars such as "-12.345", rounding half up to the cent
+// Only plain decimals are accepted: no exponents, thousands separators or currency symbols
+func NewMoneyFromString(amount string) (Money, error) {
+	dollars, err := parseDecimal(strings.TrimSpace(amount))
+	if err != nil {
+		return 0, err
+	}
+	return roundToUnit(dollars.Mul(dollars, big.NewRat(100, 1)), 1, RoundHalfUp)
+}
+
+// NewMoneyFromFloat creates a Money value from dollars, rounding half up to the cent
+// The dollars are read as their shortest decimal form, so 1.005 rounds to 1.01
+func NewMoneyFromFloat(dollars float64) (Money, error) {
+	if math.IsNaN(dollars) || math.IsInf(dollars, 0) {
+		return 0, fmt.Errorf("invalid amount %v", dollars)
+	}
+	return NewMoneyFromString(strconv.FormatFloat(dollars, 'f', -1, 64))
+}
+
+// NewMoney creates a Money value from dollars like NewMoneyFromFloat for amounts known to be in range
+// Out-of-range amounts saturate and NaN is zero; use NewMoneyFromFloat to report them instead
+func NewMoney(dollars float64) Money {
+	money, err := NewMoneyFromFloat(dollars)
+	if err != nil {
+		return saturate(dollars)
+	}
+	return money
+}
+
+// Dollars returns the monetary value in dollars (float64)
+func (m Money) Dollars() float64 {
+	return float64(m) / 100.0
+}
+
+// Cents returns the money value in cents
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+// IsPositive checks if the money value is positive
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+// Add returns m + other, or ErrMoneyOverflow when the sum does not fit
+func (m Money) Add(other Money) (Money, error) {
+	sum := m + other
+	if (other > 0 && sum < m) || (other < 0 && sum > m) {
+		return 0, ErrMoneyOverflow
+	}
+	return sum, nil
+}
+
+// Sub returns m - other, or ErrMoneyOverflow when the difference does not fit
+func (m Money) Sub(other Money) (Money, error) {
+	difference := m - other
+	if (other > 0 && difference > m) || (other < 0 && difference < m) {
+		return 0, ErrMoneyOverflow
+	}
+	return difference, nil
+}
+
+// MulRate multiplies the amount by an exchange rate and rounds the product to the cent with mode
+// The rate is read as its shortest decimal form, so ties such as 0.125 at 5.0 are exact
+func (m Money) MulRate(rate float64, mode RoundingMode) (Money, error) {
+	return m.mulRate(rate, 1, mode)
+}
+
+// MulRateIn is MulRate for a product in currency, rounded to its minor unit: whole yen for JPY
+func (m Money) MulRateIn(rate float64, currency CurrencyCode, mode RoundingMode) (Money, error) {
+	return m.mulRate(rate, currency.centsPerMinorUnit(), mode)
+}
+
+// Round rounds the amount to the minor unit of currency with mode
+func (m Money) Round(currency CurrencyCode, mode RoundingMode) (Money, error) {
+	return roundToUnit(new(big.Rat).SetInt64(int64(m)), currency.centsPerMinorUnit(), mode)
+}
+
+// Format renders the amount with the number of decimals of currency, e.g. "1234.50" in USD and "1235" in JPY
+// Amounts with more precision than currency allows are rounded half up
+func (m Money) Format(currency CurrencyCode) string {
+	if rounded, err := m.Round(currency, RoundHalfUp); err == nil {
+		m = rounded
+	}
+	return strconv.FormatFloat(m.Dollars(), 'f', currency.MinorUnits(), 64)
+}
+
+func (m Money) mulRate(rate float64, unit int64, mode RoundingMode) (Money, error) {
+	if math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return 0, fmt.Errorf("invalid rate %v", rate)
+	}
+	decimalRate, ok := new(big.Rat).SetString(strconv.FormatFloat(rate, 'g', -1, 64))
+	if !ok {
+		return 0, fmt.Errorf("invalid rate %v", rate)
+	}
+	return roundToUnit(decimalRate.Mul(decimalRate, new(big.Rat).SetInt64(int64(m))), unit, mode)
+}
+
+// parseDecimal parses an optionally signed decimal with digits on at least one side of the point
+func parseDecimal(amount string) (*big.Rat, error) {
+	digits := strings.TrimLeft(amount, "+-")
+	if len(amount)-len(digits) > 1 {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if whole+fraction == "" || strings.Trim(whole+fraction, "0123456789") != "" {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	return value, nil
+}
+
+// roundToUnit rounds an exact number of cents to a whole multiple of unit cents with mode
+func roundToUnit(cents *big.Rat, unit int64, mode RoundingMode) (Money, error) {
+	units := new(big.Rat).Quo(cents, big.NewRat(unit, 1))
+	quotient, remainder := new(big.Int).QuoRem(units.Num(), units.Denom(), new(big.Int))
+
+	if remainder.Sign() != 0 && mode != RoundDown {
+		// Compare the dropped fraction with one half: 2*|remainder| against the denominator
+		twice := new(big.Int).Abs(remainder)
+		twice.Lsh(twice, 1)
+		comparison := twice.Cmp(units.Denom())
+		tieRoundsAway := mode != RoundHalfEven || quotient.Bit(0) == 1
+		if comparison > 0 || (comparison == 0 && tieRoundsAway) {
+			quotient.Add(quotient, big.NewInt(int64(units.Sign())))
+		}
+	}
+
+	quotient.Mul(quotient, big.NewInt(unit))
+	if !quotient.IsInt64() {
+		return 0, ErrMoneyOverflow
+	}
+	return Money(quotient.Int64()), nil
+}
+
+// saturate clamps an amount in dollars that Money cannot hold to its limits
+func saturate(dollars float64) Money {
+	switch {
+	case math.IsNaN(dollars):
+		return 0
+	case dollars > 0:
+		return Money(math.MaxInt64)
+	default:
+		return Money(math.MinInt64)
+	}
+}
@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage is a domain event stored until it has been handed to the message broker
+// Messages are relayed at least once: a message may be published again if the relay stops before marking it
+type OutboxMessage struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"` // The event ID, so consumers can drop duplicates
+	Topic         string     `json:"topic" gorm:"not null"`
+	Event         string     `json:"event" gorm:"not null"`
+	Payload       string     `json:"-" gorm:"not null"` // JSON message published as is
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null;index"`
+	LastError     string     `json:"last_error,omitempty"`
+	PublishedAt   *time.Time `json:"published_at,omitempty" gorm:"index"` // Nil while the message waits for the broker
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// NewOutboxMessage stores payload for event, due for publishing from the moment the event occurred
+func NewOutboxMessage(event DomainEvent, topic string, payload []byte) *OutboxMessage {
+	return &OutboxMessage{
+		ID:            event.EventID(),
+		Topic:         topic,
+		Event:         event.EventName(),
+		Payload:       string(payload),
+		NextAttemptAt: event.EventTime().UTC(),
+	}
+}
+
+// IsPublished reports whether the broker has accepted the message
+func (m *OutboxMessage) IsPublished() bool {
+	return m.PublishedAt != nil
+}
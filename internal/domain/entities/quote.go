@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+)
+
+// RateQuote is an exchange rate locked for a short period so a later conversion uses exactly that rate
+type RateQuote struct {
+	ID            uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	FromCurrency  CurrencyCode `json:"from_currency" gorm:"not null"`
+	ToCurrency    CurrencyCode `json:"to_currency" gorm:"not null"`
+	Rate          float64      `json:"rate" gorm:"not null"`
+	EffectiveDate time.Time    `json:"effective_date" gorm:"not null"` // Effective date of the quoted rate
+	QuotedDate    time.Time    `json:"quoted_date" gorm:"not null"`    // Date the rate was resolved for
+	ExpiresAt     time.Time    `json:"expires_at" gorm:"not null;index"`
+	CreatedAt     time.Time    `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// NewRateQuote locks an exchange rate resolved for quotedDate until ttl after the current time of clk
+func NewRateQuote(exchangeRate *ExchangeRate, quotedDate time.Time, ttl time.Duration, clk clock.Clock) (*RateQuote, error) {
+	if exchangeRate == nil {
+		return nil, fmt.Errorf("exchange rate is required")
+	}
+	if err := exchangeRate.Validate(); err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("quote ttl must be positive, got %s", ttl)
+	}
+
+	now := clk.Now().UTC()
+	return &RateQuote{
+		ID:            uuid.New(),
+		FromCurrency:  exchangeRate.FromCurrency,
+		ToCurrency:    exchangeRate.ToCurrency,
+		Rate:          exchangeRate.Rate,
+		EffectiveDate: exchangeRate.EffectiveDate,
+		QuotedDate:    quotedDate,
+		ExpiresAt:     now.Add(ttl),
+		CreatedAt:     now,
+	}, nil
+}
+
+// IsExpired reports whether the quote can no longer be used at the given time
+func (q *RateQuote) IsExpired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
+
+// ExchangeRate returns the quoted rate as an ExchangeRate value for conversion
+func (q *RateQuote) ExchangeRate() *ExchangeRate {
+	return &ExchangeRate{
+		ID:            q.ID,
+		FromCurrency:  q.FromCurrency,
+		ToCurrency:    q.ToCurrency,
+		Rate:          q.Rate,
+		EffectiveDate: q.EffectiveDate,
+		RecordDate:    q.CreatedAt,
+	}
+}
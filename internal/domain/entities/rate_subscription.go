@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RateSubscription records that a client wants USD rates for a currency kept fresh by the background sync
+type RateSubscription struct {
+	ID           uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	Subscriber   string       `json:"-" gorm:"not null;uniqueIndex:idx_rate_subscriptions_subscriber_currency"` // Hash of the client's API key
+	Currency     CurrencyCode `json:"currency" gorm:"not null;index;uniqueIndex:idx_rate_subscriptions_subscriber_currency"`
+	LastSyncedAt *time.Time   `json:"last_synced_at,omitempty"` // Last time the sync checked the Treasury for this currency
+	LastError    string       `json:"last_error,omitempty"`     // Error of the last sync attempt; empty when it succeeded
+	CreatedAt    time.Time    `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Validate performs business rule validation
+func (s *RateSubscription) Validate() error {
+	if s.Subscriber == "" {
+		return fmt.Errorf("subscriber is required")
+	}
+
+	if !s.Currency.IsValid() {
+		return fmt.Errorf("invalid currency code: %s", s.Currency)
+	}
+
+	return nil
+}
+
+// Rate freshness states reported for subscriptions
+const (
+	RateFresh   = "fresh"   // A rate published within the freshness window is cached
+	RateStale   = "stale"   // The newest cached rate is still usable today but older than the freshness window
+	RateMissing = "missing" // No cached rate is usable for today's transactions
+)
+
+// RateFreshness classifies the newest cached rate usable today
+// freshFor is how old a rate may be before a newer publication is expected; window is how long a rate stays usable
+func RateFreshness(latest *ExchangeRate, now time.Time, freshFor time.Duration, window RateWindow) string {
+	switch {
+	case latest == nil || !latest.IsWithinDateRange(now, window):
+		return RateMissing
+	case now.Sub(latest.EffectiveDate) > freshFor:
+		return RateStale
+	default:
+		return RateFresh
+	}
+}
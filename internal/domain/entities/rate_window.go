@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultRateWindowMonths is the Treasury rule: a rate applies to purchases up to 6 months after it takes effect
+const DefaultRateWindowMonths = 6
+
+// MaxRateWindowMonths bounds the configurable window, beyond which a rate no longer says much about a purchase
+const MaxRateWindowMonths = 24
+
+// RateWindow is how long before a purchase date an exchange rate may take effect and still apply to it
+// The zero value is the default 6-month window
+type RateWindow struct {
+	months int
+}
+
+// NewRateWindow creates a window of months, between 1 and MaxRateWindowMonths
+func NewRateWindow(months int) (RateWindow, error) {
+	if months < 1 || months > MaxRateWindowMonths {
+		return RateWindow{}, fmt.Errorf("rate window must be between 1 and %d months, got %d", MaxRateWindowMonths, months)
+	}
+	return RateWindow{months: months}, nil
+}
+
+// Months returns the length of the window
+func (w RateWindow) Months() int {
+	if w.months == 0 {
+		return DefaultRateWindowMonths
+	}
+	return w.months
+}
+
+// Start returns the earliest effective date of a rate applying to date
+func (w RateWindow) Start(date time.Time) time.Time {
+	return date.AddDate(0, -w.Months(), 0)
+}
+
+// End returns the last purchase date a rate effective on effectiveDate applies to
+func (w RateWindow) End(effectiveDate time.Time) time.Time {
+	return effectiveDate.AddDate(0, w.Months(), 0)
+}
+
+// Contains reports whether a rate effective on effectiveDate applies to a purchase on date
+func (w RateWindow) Contains(effectiveDate, date time.Time) bool {
+	return !effectiveDate.Before(w.Start(date)) && !effectiveDate.After(date)
+}
+
+// String describes the window for messages, such as "6 months"
+func (w RateWindow) String() string {
+	if w.Months() == 1 {
+		return "1 month"
+	}
+	return fmt.Sprintf("%d months", w.Months())
+}
@@ -0,0 +1,79 @@
+package entities
+
+import "time"
+
+// SummaryGrouping is the calendar period a spending summary is broken down by
+type SummaryGrouping string
+
+// Supported summary groupings, by purchase date in UTC
+const (
+	GroupByDay   SummaryGrouping = "day"
+	GroupByMonth SummaryGrouping = "month"
+	GroupByYear  SummaryGrouping = "year"
+)
+
+// IsValid reports whether g is a supported grouping
+func (g SummaryGrouping) IsValid() bool {
+	switch g {
+	case GroupByDay, GroupByMonth, GroupByYear:
+		return true
+	}
+	return false
+}
+
+// Layout returns the time layout of the period keys of g, e.g. 2024-05 for months
+func (g SummaryGrouping) Layout() string {
+	switch g {
+	case GroupByDay:
+		return time.DateOnly
+	case GroupByYear:
+		return "2006"
+	default:
+		return "2006-01"
+	}
+}
+
+// PeriodSummary aggregates the transactions purchased within one period
+type PeriodSummary struct {
+	Period  string // Period key in the grouping's layout
+	Count   int64
+	Total   Money
+	Average Money // Rounded to the nearest cent
+	Min     Money
+	Max     Money
+}
+
+// AverageAmount divides total by count, rounding half up to the nearest cent
+// Returns zero when count is zero
+func AverageAmount(total Money, count int64) Money {
+	if count <= 0 {
+		return 0
+	}
+	return Money((int64(total)*2 + count) / (count * 2))
+}
+
+// CombineSummaries aggregates period summaries into one covering all of them, with an empty Period
+func CombineSummaries(summaries []PeriodSummary) PeriodSummary {
+	var combined PeriodSummary
+	for i, summary := range summaries {
+		if i == 0 || summary.Min < combined.Min {
+			combined.Min = summary.Min
+		}
+		if summary.Max > combined.Max {
+			combined.Max = summary.Max
+		}
+		combined.Count += summary.Count
+		combined.Total += summary.Total
+	}
+	combined.Average = AverageAmount(combined.Total, combined.Count)
+	return combined
+}
+
+// Converted returns the summary with every amount converted from USD at rate
+func (s PeriodSummary) Converted(rate *ExchangeRate) PeriodSummary {
+	s.Total = rate.ConvertAmount(s.Total)
+	s.Average = rate.ConvertAmount(s.Average)
+	s.Min = rate.ConvertAmount(s.Min)
+	s.Max = rate.ConvertAmount(s.Max)
+	return s
+}
@@ -1,44 +1,166 @@
 package entities
 
 import (
+	"encoding/base64"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 )
 
 // Transaction represents a purchase transaction in the system
 type Transaction struct {
-	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
-	Description string    `json:"description" gorm:"not null" validate:"required,max=50"`
-	Date        time.Time `json:"date" gorm:"not null" validate:"required"`
-	Amount      Money     `json:"amount" gorm:"not null" validate:"required,gt=0"`
-	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID          uuid.UUID    `json:"id"`
+	Description string       `json:"description" validate:"required,max=50"`
+	Date        time.Time    `json:"date" validate:"required"`
+	Amount      Money        `json:"amount" validate:"required,gt=0"`
+	Currency    CurrencyCode `json:"currency,omitempty"`                   // Currency the amount was paid in; see SourceCurrency
+	Category    string       `json:"category,omitempty" validate:"max=50"` // Name of a Category, matched by budgets
+	Tags        []string     `json:"tags,omitempty"`                       // Free-form labels, normalized by NormalizeTags
+	ExternalID  *string      `json:"external_id,omitempty"`                // Source system ID for imported transactions, e.g. "plaid:<id>"
+	Version     int64        `json:"version"`                              // Starts at 1 and grows with every update; updates of a stale version are rejected
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	DeletedAt   *time.Time   `json:"-"`                     // Soft-delete marker; repositories hide set rows from queries
+	ArchivedAt  *time.Time   `json:"archived_at,omitempty"` // Set on transactions read back from cold storage
 }
 
-// Money represents a monetary value in cents to avoid floating point precision issues
-type Money int64
+// DescriptionSuggestion is a distinct transaction description with the number of times it was used
+type DescriptionSuggestion struct {
+	Description string `json:"description" xml:"description"`
+	Count       int64  `json:"count" xml:"count"`
+}
+
+// TransactionSummary aggregates the number and total amount of a set of transactions
+type TransactionSummary struct {
+	Count int64 `json:"count"`
+	Total Money `json:"total"`
+}
+
+// TransactionFilter narrows a transaction listing; zero fields don't filter
+type TransactionFilter struct {
+	DateFrom            *time.Time // Purchase date on or after
+	DateTo              *time.Time // Purchase date before
+	MinAmount           *Money     // Amount of at least
+	MaxAmount           *Money     // Amount of at most
+	DescriptionContains string     // Case-insensitive substring of the description
+	Category            string     // Exact category name
+	Tag                 string     // One of the tags, normalized
+}
+
+// IsEmpty reports whether the filter matches every transaction
+func (f TransactionFilter) IsEmpty() bool {
+	return f.DateFrom == nil && f.DateTo == nil && f.MinAmount == nil && f.MaxAmount == nil && f.DescriptionContains == "" &&
+		f.Category == "" && f.Tag == ""
+}
+
+// TransactionCursor is the position of a transaction in the most-recent-first listing order (created_at DESC, id DESC)
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// CursorOf returns the listing position of a transaction
+func CursorOf(transaction Transaction) TransactionCursor {
+	return TransactionCursor{CreatedAt: transaction.CreatedAt, ID: transaction.ID}
+}
+
+// Precedes reports whether the cursor comes before transaction in listing order, i.e. whether a page after it includes the transaction
+func (c TransactionCursor) Precedes(transaction Transaction) bool {
+	if !transaction.CreatedAt.Equal(c.CreatedAt) {
+		return transaction.CreatedAt.Before(c.CreatedAt)
+	}
+	return transaction.ID.String() < c.ID.String()
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c TransactionCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + c.ID.String()))
+}
+
+// ParseTransactionCursor decodes a token returned by Encode
+// The time is in the local zone, the one autoCreateTime stores created_at in
+func ParseTransactionCursor(token string) (TransactionCursor, error) {
+	invalid := fmt.Errorf("invalid cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return TransactionCursor{}, invalid
+	}
+	nanos, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return TransactionCursor{}, invalid
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return TransactionCursor{}, invalid
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return TransactionCursor{}, invalid
+	}
+	return TransactionCursor{CreatedAt: time.Unix(0, unixNano), ID: parsedID}, nil
+}
+
+// Limits on transaction tags
+const (
+	MaxTags      = 10
+	MaxTagLength = 30
+)
 
-// NewMoney creates a Money value from dollars (converts to cents)
-func NewMoney(dollars float64) Money {
-	// Round to nearest cent and convert to int64 cents
-	return Money(dollars*100 + 0.5)
+// NormalizeTags trims and lowercases tags, dropping empty ones and duplicates while keeping their order
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
 }
 
-// Dollars returns the monetary value in dollars (float64)
-func (m Money) Dollars() float64 {
-	return float64(m) / 100.0
+// ValidateTag checks a normalized tag: letters, digits, hyphens and underscores, up to MaxTagLength bytes
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("tag must not be empty")
+	}
+	if len(tag) > MaxTagLength {
+		return fmt.Errorf("tag %q must not exceed %d characters", tag, MaxTagLength)
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return fmt.Errorf("tag %q may only contain letters, digits, hyphens and underscores", tag)
+		}
+	}
+	return nil
 }
 
-// Cents returns the money value in cents
-func (m Money) Cents() int64 {
-	return int64(m)
+// HasTag reports whether the transaction carries the normalized tag
+func (t *Transaction) HasTag(tag string) bool {
+	return slices.Contains(t.Tags, tag)
 }
 
-// IsPositive checks if the money value is positive
-func (m Money) IsPositive() bool {
-	return m > 0
+// SourceCurrency returns the currency the amount was paid in, USD when the transaction does not record one
+func (t *Transaction) SourceCurrency() CurrencyCode {
+	if t.Currency == "" {
+		return USD
+	}
+	return t.Currency
+}
+
+// IsDeleted reports whether the transaction has been soft-deleted
+func (t *Transaction) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
+// IsArchived reports whether the transaction was read from cold storage
+func (t *Transaction) IsArchived() bool {
+	return t.ArchivedAt != nil
 }
 
 // Validate performs business rule validation
@@ -51,6 +173,19 @@ func (t *Transaction) Validate() error {
 		return fmt.Errorf("description must not exceed 50 characters")
 	}
 
+	if len(t.Category) > 50 {
+		return fmt.Errorf("category must not exceed 50 characters")
+	}
+
+	if len(t.Tags) > MaxTags {
+		return fmt.Errorf("a transaction can have at most %d tags", MaxTags)
+	}
+	for _, tag := range t.Tags {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+
 	if t.Date.IsZero() {
 		return fmt.Errorf("transaction date is required")
 	}
@@ -59,5 +194,9 @@ func (t *Transaction) Validate() error {
 		return fmt.Errorf("purchase amount must be positive")
 	}
 
+	if t.Currency != "" && !t.Currency.IsValid() {
+		return fmt.Errorf("invalid transaction currency: %s", t.Currency)
+	}
+
 	return nil
 }
@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook event types a webhook can subscribe to
+const (
+	WebhookEventTransactionCreated   = EventTransactionCreated   // A transaction was stored, including imports and bank sync
+	WebhookEventTransactionConverted = EventTransactionConverted // A transaction was converted to another currency
+)
+
+// WebhookEvents lists every supported event type
+var WebhookEvents = []string{WebhookEventTransactionCreated, WebhookEventTransactionConverted}
+
+// MaxWebhookURLLength bounds the length of a webhook URL
+const MaxWebhookURLLength = 2048
+
+// Webhook is a URL notified of transaction events; deliveries are signed with its secret
+type Webhook struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	URL       string    `json:"url" gorm:"not null"`
+	Events    []string  `json:"events" gorm:"serializer:json"`
+	Secret    string    `json:"-" gorm:"not null"` // HMAC key for the X-Signature header; kept in clear since signing needs it
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Validate performs business rule validation
+func (w *Webhook) Validate() error {
+	if len(w.URL) > MaxWebhookURLLength {
+		return fmt.Errorf("webhook URL must not exceed %d characters", MaxWebhookURLLength)
+	}
+
+	parsed, err := url.Parse(w.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("webhook URL must be an absolute http or https URL: %s", w.URL)
+	}
+
+	if len(w.Events) == 0 {
+		return fmt.Errorf("at least one event is required")
+	}
+	for _, event := range w.Events {
+		if !slices.Contains(WebhookEvents, event) {
+			return fmt.Errorf("unsupported event: %s", event)
+		}
+	}
+
+	if w.Secret == "" {
+		return fmt.Errorf("webhook secret is required")
+	}
+
+	return nil
+}
+
+// Subscribes reports whether the webhook wants the event
+func (w *Webhook) Subscribes(event string) bool {
+	return slices.Contains(w.Events, event)
+}
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"   // Waiting for its first attempt or a retry
+	DeliverySucceeded = "succeeded" // The receiver answered 2xx
+	DeliveryFailed    = "failed"    // Every attempt failed; no more retries
+)
+
+// WebhookDelivery is one event sent to one webhook, with the outcome of its attempts
+type WebhookDelivery struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	WebhookID      uuid.UUID  `json:"webhook_id" gorm:"type:uuid;not null;index"`
+	EventID        uuid.UUID  `json:"event_id" gorm:"type:uuid;not null"` // Shared by the deliveries of one event, for receivers to deduplicate
+	Event          string     `json:"event" gorm:"not null"`
+	Payload        string     `json:"-" gorm:"not null"` // JSON body; signed again at every attempt
+	Status         string     `json:"status" gorm:"not null;index"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty" gorm:"index"` // Nil once the delivery succeeded or failed
+	LastStatusCode int        `json:"last_status_code,omitempty"`             // Zero when no response was received
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// RecordSuccess marks the delivery as accepted by the receiver
+func (d *WebhookDelivery) RecordSuccess(statusCode int, at time.Time) {
+	d.Attempts++
+	d.Status = DeliverySucceeded
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.NextAttemptAt = nil
+	d.DeliveredAt = &at
+}
+
+// RecordFailure notes a failed attempt, scheduling a retry at retryAt or giving up when retryAt is nil
+func (d *WebhookDelivery) RecordFailure(statusCode int, reason string, retryAt *time.Time) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = reason
+	d.NextAttemptAt = retryAt
+	if retryAt == nil {
+		d.Status = DeliveryFailed
+	}
+}
@@ -0,0 +1,49 @@
+// Package errs defines the kinds of domain errors so callers can classify them with errors.Is
+// instead of matching on messages
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error kinds; the HTTP layer maps each one to a status code
+var (
+	ErrValidation          = errors.New("validation failed")          // The request or entity is invalid
+	ErrNotFound            = errors.New("not found")                  // The referenced resource does not exist
+	ErrConflict            = errors.New("conflict")                   // The request conflicts with the current state
+	ErrIdempotencyReused   = errors.New("idempotency key reused")     // An Idempotency-Key was sent again with a different request
+	ErrExpired             = errors.New("expired")                    // The resource existed but can no longer be used, e.g. a quote
+	ErrRateUnavailable     = errors.New("no exchange rate available") // No rate within the lookback window before the purchase date
+	ErrUnsupportedCurrency = errors.New("unsupported currency")       // No exchange rate source publishes rates for the currency
+	ErrServiceUnavailable  = errors.New("service unavailable")        // A dependency is failing fast, e.g. behind an open circuit breaker
+	ErrQuotaExceeded       = errors.New("quota exceeded")             // Storing more data would exceed the configured quota
+	ErrUnauthorized        = errors.New("unauthorized")               // The credential is missing, invalid or expired
+)
+
+// kindError marks an error with a kind while keeping its message and wrapped chain
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// Wrap marks err as kind without changing its message; a nil err stays nil
+func Wrap(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// Newf formats an error of the given kind; %w verbs in format keep wrapping their errors
+func Newf(kind error, format string, args ...any) error {
+	return Wrap(kind, fmt.Errorf(format, args...))
+}
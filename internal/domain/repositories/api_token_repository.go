@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// APITokenRepository defines the contract for API token persistence operations
+type APITokenRepository interface {
+	// Create persists a new token
+	Create(token *entities.APIToken) error
+
+	// Update saves changes to an existing token
+	// Returns an error containing "not found" if the token does not exist
+	Update(token *entities.APIToken) error
+
+	// GetByID retrieves a token by its ID; returns nil, nil when it does not exist
+	GetByID(id uuid.UUID) (*entities.APIToken, error)
+
+	// GetByHash retrieves a token by the hash of its secret; returns nil, nil when it does not exist
+	GetByHash(tokenHash string) (*entities.APIToken, error)
+
+	// List retrieves every token, newest first, including expired and revoked ones
+	List() ([]entities.APIToken, error)
+
+	// CountActive returns how many tokens with the role are neither revoked nor expired at the time of the call
+	CountActive(role entities.APIRole) (int64, error)
+}
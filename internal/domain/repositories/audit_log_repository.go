@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// AuditLogRepository defines the contract for the append-only audit trail
+type AuditLogRepository interface {
+
+	// Save appends an entry to the trail
+	Save(entry *entities.AuditLog) error
+
+	// FindPaginated retrieves the entries matching filter, most recent first, with pagination support
+	// Returns the page and the total count of matching entries
+	FindPaginated(filter entities.AuditLogFilter, page, size int) ([]entities.AuditLog, int64, error)
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// BudgetRepository defines the contract for budget persistence operations
+type BudgetRepository interface {
+	// Save persists a new budget
+	// Returns error if the operation fails
+	Save(budget *entities.Budget) error
+
+	// GetByID retrieves a budget by its unique identifier
+	// Returns nil and no error if the budget is not found
+	GetByID(id uuid.UUID) (*entities.Budget, error)
+
+	// GetAll retrieves every budget ordered by category, then period
+	GetAll() ([]entities.Budget, error)
+
+	// FindByCategory retrieves the budgets that track a category
+	FindByCategory(category string) ([]entities.Budget, error)
+
+	// RenameCategory moves every budget of a category to another category name
+	// Returns the number of budgets moved
+	RenameCategory(from, to string) (int64, error)
+
+	// Update replaces a stored budget
+	// Returns an error containing "not found" if the budget does not exist
+	Update(budget *entities.Budget) error
+
+	// Delete removes a budget
+	// Returns an error containing "not found" if the budget does not exist
+	Delete(id uuid.UUID) error
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// CategoryRepository defines the contract for category persistence operations
+type CategoryRepository interface {
+	// Save persists a new category
+	// Returns an error wrapping errs.ErrConflict if a category with the same name exists, ignoring case
+	Save(category *entities.Category) error
+
+	// GetByID retrieves a category by its unique identifier
+	// Returns nil and no error if the category is not found
+	GetByID(id uuid.UUID) (*entities.Category, error)
+
+	// GetByName retrieves a category by name, ignoring case
+	// Returns nil and no error if no category has the name
+	GetByName(name string) (*entities.Category, error)
+
+	// GetAll retrieves every category ordered by name
+	GetAll() ([]entities.Category, error)
+
+	// Update replaces a stored category
+	// Returns an error wrapping errs.ErrNotFound if the category does not exist,
+	// or errs.ErrConflict if another category has the new name
+	Update(category *entities.Category) error
+
+	// Delete removes a category
+	// Returns an error wrapping errs.ErrNotFound if the category does not exist
+	Delete(id uuid.UUID) error
+}
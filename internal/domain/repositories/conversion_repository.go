@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// ConversionRecordRepository defines the contract for persisted conversion records
+type ConversionRecordRepository interface {
+	// SaveAll persists a set of conversion records in a single operation
+	// Returns error if the operation fails
+	SaveAll(records []entities.ConversionRecord) error
+
+	// GetByBatchPaginated retrieves the current (not superseded) records produced by a batch run
+	// Returns records for the page, total count, and error if the operation fails
+	GetByBatchPaginated(batchID uuid.UUID, page, size int) ([]entities.ConversionRecord, int64, error)
+
+	// FindSupersedable returns current records in currency whose rate is older than effectiveDate
+	// while their transaction date is on or after it, i.e. records a rate effective on that date would improve
+	FindSupersedable(currency entities.CurrencyCode, effectiveDate time.Time) ([]entities.ConversionRecord, error)
+
+	// Supersede stores replacement and marks previous as superseded by it in one operation
+	// Returns error if previous is missing or already superseded
+	Supersede(previous *entities.ConversionRecord, replacement *entities.ConversionRecord) error
+
+	// Sample returns up to limit current records picked at random
+	// Returns empty slice if no records exist
+	Sample(limit int) ([]entities.ConversionRecord, error)
+}
+
+// ConversionBatchRepository defines the contract for batch conversion run persistence
+type ConversionBatchRepository interface {
+	// Save persists a new batch
+	// Returns error if the operation fails
+	Save(batch *entities.ConversionBatch) error
+
+	// Update stores the batch's current status and progress counters
+	// Returns error if the batch doesn't exist or the operation fails
+	Update(batch *entities.ConversionBatch) error
+
+	// GetByID retrieves a batch by its unique identifier
+	// Returns nil and no error if the batch is not found
+	GetByID(id uuid.UUID) (*entities.ConversionBatch, error)
+}
+
+// ConversionHistoryRepository defines the contract for the conversions served per transaction
+type ConversionHistoryRepository interface {
+	// SaveAll appends conversions to the history in a single operation
+	// Returns error if the operation fails
+	SaveAll(conversions []entities.Conversion) error
+
+	// GetByTransactionPaginated retrieves the conversions of a transaction, most recent first
+	// Returns conversions for the page, total count, and error if the operation fails
+	GetByTransactionPaginated(transactionID uuid.UUID, page, size int) ([]entities.Conversion, int64, error)
+
+	// ForEach streams all conversions in batches of batchSize, calling fn once per batch
+	// Iteration stops at the first error returned by fn, which is propagated to the caller
+	ForEach(batchSize int, fn func(batch []entities.Conversion) error) error
+
+	// Update replaces a stored conversion, for imports restoring the history an archive recorded
+	// Returns error if the conversion doesn't exist or the operation fails
+	Update(conversion *entities.Conversion) error
+
+	// Exists checks if a conversion with the given ID exists
+	// Returns true if exists, false otherwise
+	Exists(id uuid.UUID) (bool, error)
+}
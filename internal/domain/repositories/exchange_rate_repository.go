@@ -13,14 +13,26 @@ type ExchangeRateRepository interface {
 	// Returns error if the operation fails
 	Save(exchangeRate *entities.ExchangeRate) error
 
+	// SaveAll inserts the exchange rates in batches within a single database transaction
+	// Either every rate is stored or none is; returns error if any is invalid or already exists
+	SaveAll(exchangeRates []entities.ExchangeRate) error
+
 	// GetByID retrieves an exchange rate by its unique identifier
 	// Returns nil and no error if exchange rate is not found
 	GetByID(id uuid.UUID) (*entities.ExchangeRate, error)
 
 	// FindRateForConversion finds the most suitable exchange rate for currency conversion
-	// Must comply with the 6-month rule: rate date <= transaction date and within 6 months
+	// Must comply with the lookback rule: rate date <= transaction date and within window before it
 	// Returns the most recent valid rate, or nil if no valid rate exists
-	FindRateForConversion(from, to entities.CurrencyCode, transactionDate time.Time) (*entities.ExchangeRate, error)
+	FindRateForConversion(from, to entities.CurrencyCode, transactionDate time.Time, window entities.RateWindow) (*entities.ExchangeRate, error)
+
+	// FindSurroundingRates finds the nearest rate on or before the date and the nearest rate after it
+	// Only rates within windowMonths of the date are considered; either result may be nil
+	FindSurroundingRates(from, to entities.CurrencyCode, date time.Time, windowMonths int) (before, after *entities.ExchangeRate, err error)
+
+	// ForEach streams all exchange rates in batches of batchSize, calling fn once per batch
+	// Iteration stops at the first error returned by fn, which is propagated to the caller
+	ForEach(batchSize int, fn func(batch []entities.ExchangeRate) error) error
 
 	// Update modifies an existing exchange rate in the database
 	// Returns error if exchange rate doesn't exist or operation fails
@@ -30,6 +42,14 @@ type ExchangeRateRepository interface {
 	// Returns error if exchange rate doesn't exist or operation fails
 	Delete(id uuid.UUID) error
 
+	// SummarizeByCurrency counts the cached rates of each target currency, ordered by currency
+	SummarizeByCurrency() ([]entities.RateCacheSummary, error)
+
+	// Purge deletes cached rates to a currency, or to every currency when currency is empty
+	// A non-nil effectiveDate limits the purge to rates effective on that day
+	// Returns the number of rates deleted
+	Purge(currency entities.CurrencyCode, effectiveDate *time.Time) (int64, error)
+
 	// Exists checks if an exchange rate with the given ID exists
 	// Returns true if exists, false otherwise
 	Exists(id uuid.UUID) (bool, error)
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// IdempotencyKeyRepository defines the contract for idempotency key persistence operations
+type IdempotencyKeyRepository interface {
+	// Reserve stores a pending key unless its scope already holds the same key
+	// Returns false and no error when the key is taken, so concurrent retries cannot both proceed
+	Reserve(key *entities.IdempotencyKey) (bool, error)
+
+	// Find retrieves a key by scope and header value
+	// Returns nil and no error if the key is not found
+	Find(scope, key string) (*entities.IdempotencyKey, error)
+
+	// Complete stores the outcome of a reserved key
+	// Returns error if the key does not exist
+	Complete(key *entities.IdempotencyKey) error
+
+	// Delete removes a key, releasing it for another attempt
+	Delete(id uuid.UUID) error
+
+	// DeleteExpired removes keys that expired before the given time
+	// Returns the number of keys removed
+	DeleteExpired(before time.Time) (int64, error)
+}
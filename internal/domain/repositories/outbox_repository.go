@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// OutboxRepository defines the contract for persisting events waiting to be published to the message broker
+type OutboxRepository interface {
+
+	// Save persists a new outbox message
+	Save(message *entities.OutboxMessage) error
+
+	// ClaimDue leases up to limit unpublished messages whose next attempt is at or before now, oldest first,
+	// by moving their next attempt to leaseUntil; a message claimed by another worker is skipped
+	ClaimDue(now time.Time, leaseUntil time.Time, limit int) ([]entities.OutboxMessage, error)
+
+	// MarkPublished records that the broker accepted the message
+	MarkPublished(id uuid.UUID, publishedAt time.Time) error
+
+	// MarkFailed records a failed attempt and when the message is due again
+	MarkFailed(id uuid.UUID, reason string, retryAt time.Time) error
+
+	// Reschedule makes a claimed message due again at without counting an attempt
+	Reschedule(id uuid.UUID, at time.Time) error
+
+	// CountPending counts the messages not yet published
+	CountPending() (int64, error)
+
+	// DeletePublishedBefore removes messages published before cutoff, returning how many were removed
+	DeletePublishedBefore(cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// QuoteRepository defines the contract for rate quote persistence operations
+type QuoteRepository interface {
+	// Save persists a rate quote
+	// Returns error if the operation fails
+	Save(quote *entities.RateQuote) error
+
+	// GetByID retrieves a rate quote by its unique identifier
+	// Returns nil and no error if the quote is not found
+	GetByID(id uuid.UUID) (*entities.RateQuote, error)
+
+	// DeleteExpired removes quotes that expired before the given time
+	// Returns the number of quotes removed
+	DeleteExpired(before time.Time) (int64, error)
+}
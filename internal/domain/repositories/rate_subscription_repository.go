@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// RateSubscriptionRepository defines the contract for rate subscription persistence operations
+type RateSubscriptionRepository interface {
+	// Save persists a subscription; subscribing twice to the same currency is not an error
+	Save(subscription *entities.RateSubscription) error
+
+	// FindBySubscriber retrieves a subscriber's subscriptions ordered by currency
+	FindBySubscriber(subscriber string) ([]entities.RateSubscription, error)
+
+	// SubscribedCurrencies returns every currency with at least one subscriber, ordered by code
+	SubscribedCurrencies() ([]entities.CurrencyCode, error)
+
+	// LastSynced returns when each subscribed currency was last synced; never-synced currencies are absent
+	LastSynced() (map[entities.CurrencyCode]time.Time, error)
+
+	// RecordSync stores the outcome of a sync on every subscription to the currency
+	// An empty syncError marks the sync as successful
+	RecordSync(currency entities.CurrencyCode, syncedAt time.Time, syncError string) error
+
+	// Delete removes a subscriber's subscription to a currency
+	// Returns an error containing "not found" if the subscription does not exist
+	Delete(subscriber string, currency entities.CurrencyCode) error
+}
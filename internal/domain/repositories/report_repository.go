@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// ReportRepository defines the contract for aggregate queries over transactions
+type ReportRepository interface {
+	// SummarizeByPeriod aggregates the active transactions purchased in [from, to), one summary per period of grouping
+	// Periods without transactions are left out; the rest are ordered oldest first
+	SummarizeByPeriod(from, to time.Time, grouping entities.SummaryGrouping) ([]entities.PeriodSummary, error)
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
 )
@@ -12,27 +14,100 @@ type TransactionRepository interface {
 	// Returns error if the operation fails
 	Save(transaction *entities.Transaction) error
 
+	// SaveAll inserts the transactions in batches within a single database transaction
+	// Either every transaction is stored or none is; returns error if any is invalid or already exists
+	SaveAll(transactions []entities.Transaction) error
+
 	// GetByID retrieves a transaction by its unique identifier
 	// Returns nil and no error if transaction is not found
 	GetByID(id uuid.UUID) (*entities.Transaction, error)
 
+	// GetByExternalID retrieves a transaction by the ID it has in its source system, including soft-deleted ones
+	// so imports don't bring back transactions a user deleted
+	// Returns nil and no error if no transaction has the external ID
+	GetByExternalID(externalID string) (*entities.Transaction, error)
+
 	// GetAll retrieves all transactions from the database
 	// Returns empty slice if no transactions exist
 	GetAll() ([]entities.Transaction, error)
 
+	// ForEach streams all transactions in batches of batchSize, calling fn once per batch
+	// Iteration stops at the first error returned by fn, which is propagated to the caller
+	ForEach(batchSize int, fn func(batch []entities.Transaction) error) error
+
 	// GetAllPaginated retrieves transactions with pagination support
 	// Returns transactions for the specified page, total count, and error if operation fails
 	GetAllPaginated(page, size int) ([]entities.Transaction, int64, error)
 
+	// FindPaginated retrieves the transactions matching filter, most recent first, with pagination support
+	// Returns the page and the total count of matching transactions
+	FindPaginated(filter entities.TransactionFilter, page, size int) ([]entities.Transaction, int64, error)
+
+	// FindAfter retrieves up to size transactions matching filter that come after cursor, most recent first
+	// A nil cursor starts at the most recent transaction; unlike page offsets, the cost does not grow with the position
+	FindAfter(filter entities.TransactionFilter, cursor *entities.TransactionCursor, size int) ([]entities.Transaction, error)
+
+	// SuggestDescriptions returns up to limit distinct descriptions starting with prefix
+	// Ordered by usage count descending, then alphabetically
+	SuggestDescriptions(prefix string, limit int) ([]entities.DescriptionSuggestion, error)
+
 	// Update modifies an existing transaction in the database
 	// Returns error if transaction doesn't exist or operation fails
 	Update(transaction *entities.Transaction) error
 
-	// Delete removes a transaction from the database by ID
+	// Delete soft-deletes a transaction by ID; it is hidden from all other queries until restored
 	// Returns error if transaction doesn't exist or operation fails
 	Delete(id uuid.UUID) error
 
-	// Exists checks if a transaction with the given ID exists
+	// Restore clears the soft-delete marker of a deleted transaction and returns it
+	// Returns error if no soft-deleted transaction with the ID exists
+	Restore(id uuid.UUID) (*entities.Transaction, error)
+
+	// GetDeletedByID retrieves a soft-deleted transaction, with DeletedAt set
+	// Returns nil and no error if no deleted transaction has the ID
+	GetDeletedByID(id uuid.UUID) (*entities.Transaction, error)
+
+	// GetDeletedPaginated retrieves soft-deleted transactions (the trash), most recently deleted first
+	GetDeletedPaginated(page, size int) ([]entities.Transaction, int64, error)
+
+	// FindByDateBetween retrieves transactions whose purchase date is in [from, to), oldest first
+	FindByDateBetween(from, to time.Time) ([]entities.Transaction, error)
+
+	// SummarizeCreatedBetween counts and sums transactions created in [from, to)
+	SummarizeCreatedBetween(from, to time.Time) (entities.TransactionSummary, error)
+
+	// SummarizeCategoryBetween counts and sums transactions of a category whose purchase date is in [from, to)
+	SummarizeCategoryBetween(category string, from, to time.Time) (entities.TransactionSummary, error)
+
+	// CountByCategory counts the transactions of a category, including soft-deleted and archived ones
+	CountByCategory(category string) (int64, error)
+
+	// RenameCategory moves every transaction of a category, including soft-deleted and archived ones, to another category name
+	// Returns the number of transactions moved
+	RenameCategory(from, to string) (int64, error)
+
+	// LastModified returns the latest change time across all transactions, including deletions and archiving
+	// Returns the zero time when no transactions have ever been stored
+	LastModified() (time.Time, error)
+
+	// ArchiveDatedBefore moves up to limit active transactions with a purchase date before cutoff, oldest first,
+	// from the transactions table into cold storage, returning how many were moved
+	// Soft-deleted transactions stay in the trash
+	ArchiveDatedBefore(cutoff time.Time, limit int) (int64, error)
+
+	// PurgeDeletedBefore permanently removes soft-deleted transactions whose deletion happened before cutoff
+	// Returns the number of transactions removed
+	PurgeDeletedBefore(cutoff time.Time) (int64, error)
+
+	// GetArchivedByID retrieves an archived transaction, with ArchivedAt set
+	// Returns nil and no error if no archived transaction has the ID
+	GetArchivedByID(id uuid.UUID) (*entities.Transaction, error)
+
+	// GetAllPaginatedWithArchived pages through active transactions followed by archived ones, each most recent first
+	// Returns the page and the combined total count
+	GetAllPaginatedWithArchived(page, size int) ([]entities.Transaction, int64, error)
+
+	// Exists checks if a transaction with the given ID is stored, soft-deleted ones included
 	// Returns true if exists, false otherwise
 	Exists(id uuid.UUID) (bool, error)
 
@@ -0,0 +1,20 @@
+package repositories
+
+// Repositories are the repositories handed to a unit of work, all bound to the same database transaction
+type Repositories struct {
+	Transactions      TransactionRepository
+	ExchangeRates     ExchangeRateRepository
+	ConversionHistory ConversionHistoryRepository
+	Categories        CategoryRepository
+	Budgets           BudgetRepository
+	Outbox            OutboxRepository
+	AuditLog          AuditLogRepository
+}
+
+// UnitOfWork defines the contract for running several repository calls atomically
+type UnitOfWork interface {
+	// Do calls fn with repositories sharing one database transaction
+	// The transaction commits when fn returns nil and rolls back when it returns an error or panics
+	// Returns the error of fn, or the error of committing the transaction
+	Do(fn func(repos Repositories) error) error
+}
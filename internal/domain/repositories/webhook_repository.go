@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// WebhookRepository defines the contract for webhook and webhook delivery persistence operations
+type WebhookRepository interface {
+	// Save persists a new webhook
+	Save(webhook *entities.Webhook) error
+
+	// GetByID retrieves a webhook by its unique identifier
+	// Returns nil, nil if the webhook is not found
+	GetByID(id uuid.UUID) (*entities.Webhook, error)
+
+	// GetAll retrieves every webhook ordered by creation time
+	GetAll() ([]entities.Webhook, error)
+
+	// Delete removes a webhook together with its deliveries
+	// Returns an error containing "not found" if the webhook does not exist
+	Delete(id uuid.UUID) error
+
+	// SaveDeliveries persists new deliveries
+	SaveDeliveries(deliveries []entities.WebhookDelivery) error
+
+	// UpdateDelivery stores the outcome of a delivery attempt
+	UpdateDelivery(delivery *entities.WebhookDelivery) error
+
+	// ClaimDueDeliveries returns up to limit pending deliveries whose next attempt is due at now, oldest first
+	// Each claimed delivery's next attempt is moved to leaseUntil so concurrent workers skip it while it is sent
+	ClaimDueDeliveries(now time.Time, leaseUntil time.Time, limit int) ([]entities.WebhookDelivery, error)
+
+	// FindDeliveries retrieves up to limit deliveries of a webhook, newest first
+	FindDeliveries(webhookID uuid.UUID, limit int) ([]entities.WebhookDelivery, error)
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// BankAggregator defines the contract for pulling account transactions from a bank aggregation API
+type BankAggregator interface {
+	// FetchTransactions returns the transactions dated in [from, to] for the accounts behind accessToken
+	FetchTransactions(ctx context.Context, accessToken string, from, to time.Time) ([]entities.BankTransaction, error)
+
+	// ProviderName returns a stable identifier used to namespace external IDs (e.g. "plaid")
+	ProviderName() string
+}
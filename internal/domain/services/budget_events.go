@@ -0,0 +1,9 @@
+package services
+
+import "github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+
+// BudgetAlertPublisher defines the contract for notifying that spend crossed a budget threshold
+type BudgetAlertPublisher interface {
+	// PublishBudgetThresholdCrossed announces that a transaction took a category's spend past a threshold
+	PublishBudgetThresholdCrossed(event entities.BudgetThresholdCrossedEvent) error
+}
@@ -0,0 +1,9 @@
+package services
+
+import "github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+
+// ConversionEventPublisher defines the contract for announcing changes to stored conversions
+type ConversionEventPublisher interface {
+	// PublishConversionSuperseded announces that a stored conversion was replaced using a closer rate
+	PublishConversionSuperseded(event entities.ConversionSupersededEvent) error
+}
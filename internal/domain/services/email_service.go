@@ -0,0 +1,7 @@
+package services
+
+// EmailSender defines the contract for delivering plain-text email
+type EmailSender interface {
+	// Send delivers a message to all recipients
+	Send(recipients []string, subject, body string) error
+}
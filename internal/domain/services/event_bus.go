@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// EventPublisher defines the contract for announcing domain events without knowing who consumes them
+type EventPublisher interface {
+	// Publish hands the event over for delivery; it does not wait for subscribers to handle it
+	Publish(ctx context.Context, event entities.DomainEvent) error
+}
+
+// EventHandler reacts to one domain event; a returned error is logged and does not affect other handlers
+type EventHandler func(ctx context.Context, event entities.DomainEvent) error
+
+// EventBus routes published events to the handlers subscribed to their name
+// It is implemented in process today and can be backed by a broker such as Kafka or NATS
+type EventBus interface {
+	EventPublisher
+
+	// Subscribe registers handler for events named eventName; subscribe before publishing starts
+	Subscribe(eventName string, handler EventHandler)
+}
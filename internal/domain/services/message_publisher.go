@@ -0,0 +1,10 @@
+package services
+
+import "context"
+
+// MessagePublisher defines the contract for handing messages to a message broker
+type MessagePublisher interface {
+	// Publish sends payload to topic and returns once the broker has accepted it
+	// id identifies the message so the broker or consumers can discard redelivered duplicates
+	Publish(ctx context.Context, topic string, id string, payload []byte) error
+}
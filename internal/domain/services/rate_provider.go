@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// RateProvider defines the contract for fetching published exchange rates from an external source,
+// such as the US Treasury or the European Central Bank
+type RateProvider interface {
+	// FetchExchangeRate retrieves the exchange rate for a specific date
+	// Returns the most recent rate within the lookback window before the given date
+	// Cancelling ctx abandons the call, including any retries still pending
+	FetchExchangeRate(ctx context.Context, from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error)
+
+	// FetchExchangeRates retrieves every rate published between startDate and endDate inclusive, newest first
+	// The whole range comes from a single lookup, so callers needing many dates avoid a call per date
+	// Returns an empty slice when no rate was published in the range
+	FetchExchangeRates(ctx context.Context, from, to entities.CurrencyCode, startDate, endDate time.Time) ([]entities.ExchangeRate, error)
+
+	// SupportsCurrency reports whether rates from USD to the given currency can be fetched
+	SupportsCurrency(code entities.CurrencyCode) bool
+
+	// ProviderName returns a stable identifier for the rate source (e.g. "us_treasury")
+	ProviderName() string
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// WebhookSender defines the contract for posting event payloads to webhook URLs
+type WebhookSender interface {
+	// Send posts the delivery's payload to the webhook URL, signed with the webhook secret
+	// It returns the response status code; an error without a status code means no response was received
+	Send(ctx context.Context, webhook *entities.Webhook, delivery *entities.WebhookDelivery) (int, error)
+}
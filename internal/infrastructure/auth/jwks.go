@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"golang.org/x/sync/singleflight"
+)
+
+// jwksFetchTimeout bounds fetching the key set so a slow identity provider cannot stall requests
+const jwksFetchTimeout = 5 * time.Second
+
+// jwksMinRefetch limits refetches triggered by unknown key IDs, so forged kids cannot flood the provider
+const jwksMinRefetch = time.Minute
+
+// Fetches failing in a row are retried after jwksMinBackoff, doubling up to jwksMaxBackoff
+const (
+	jwksMinBackoff = 5 * time.Second
+	jwksMaxBackoff = 5 * time.Minute
+)
+
+// JWKS is an RSA key set fetched from an identity provider's JWKS URL
+// Keys are refetched once the refresh interval passes, or when a token names an unknown key after a rotation
+// The fetch runs outside the lock and is shared by concurrent callers, so an unreachable provider only delays one request
+type JWKS struct {
+	url     string
+	refresh time.Duration
+	client  *http.Client
+	clock   clock.Clock
+	fetches singleflight.Group
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time // Last fetch, successful or not
+	failures    int       // Fetches failed in a row
+	lastErr     error     // Error of the last failed fetch, returned while backing off
+}
+
+// NewJWKS creates a key set; keys are fetched on first use
+func NewJWKS(url string, refresh time.Duration) *JWKS {
+	return &JWKS{
+		url:     url,
+		refresh: refresh,
+		client:  &http.Client{Timeout: jwksFetchTimeout},
+		clock:   clock.System(),
+	}
+}
+
+// WithClock sets the clock the key set ages and backs off by
+func (k *JWKS) WithClock(clk clock.Clock) *JWKS {
+	k.clock = clk
+	return k
+}
+
+// Key returns the key with the given ID; an empty ID matches when the set holds a single key
+func (k *JWKS) Key(kid string) (*rsa.PublicKey, error) {
+	k.mu.Lock()
+	now := k.clock.Now()
+	key, found := k.lookup(kid)
+	fresh := k.keys != nil && now.Sub(k.fetchedAt) <= k.refresh
+	switch {
+	case found && fresh:
+		k.mu.Unlock()
+		return key, nil
+	case !found && fresh && now.Sub(k.fetchedAt) < jwksMinRefetch:
+		k.mu.Unlock()
+		return nil, errs.Newf(errs.ErrUnauthorized, "invalid bearer token: unknown signing key %q", kid)
+	case k.backingOff(now):
+		// Keep serving the previous keys while the provider is unreachable
+		err := k.lastErr
+		k.mu.Unlock()
+		if found {
+			return key, nil
+		}
+		return nil, err
+	}
+	k.mu.Unlock()
+
+	_, err, _ := k.fetches.Do(k.url, func() (interface{}, error) {
+		return nil, k.fetch()
+	})
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if refetched, ok := k.lookup(kid); ok {
+		return refetched, nil
+	}
+	if err != nil {
+		if found {
+			return key, nil
+		}
+		return nil, err
+	}
+	return nil, errs.Newf(errs.ErrUnauthorized, "invalid bearer token: unknown signing key %q", kid)
+}
+
+// backingOff reports whether the last fetches failed too recently to try again; callers hold the lock
+func (k *JWKS) backingOff(now time.Time) bool {
+	if k.failures == 0 {
+		return false
+	}
+	backoff := jwksMinBackoff << min(k.failures-1, 6)
+	return now.Sub(k.attemptedAt) < min(backoff, jwksMaxBackoff)
+}
+
+// lookup finds a cached key; callers hold the lock
+func (k *JWKS) lookup(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+	key, found := k.keys[kid]
+	return key, found
+}
+
+// fetch downloads the key set and records the outcome; callers do not hold the lock
+func (k *JWKS) fetch() error {
+	keys, err := k.download()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.attemptedAt = k.clock.Now()
+	if err != nil {
+		k.failures++
+		k.lastErr = err
+		return err
+	}
+	k.keys, k.fetchedAt, k.failures, k.lastErr = keys, k.attemptedAt, 0, nil
+	return nil
+}
+
+// download fetches the key set and returns its RSA signing keys
+func (k *JWKS) download() (map[string]*rsa.PublicKey, error) {
+	resp, err := k.client.Get(k.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var document struct {
+		Keys []struct {
+			KeyType string `json:"kty"`
+			KeyID   string `json:"kid"`
+			Use     string `json:"use"`
+			N       string `json:"n"`
+			E       string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range document.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		modulus, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+		exponent, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+		if errN != nil || errE != nil {
+			continue
+		}
+		keys[jwk.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(modulus),
+			E: int(new(big.Int).SetBytes(exponent).Int64()),
+		}
+	}
+
+	return keys, nil
+}
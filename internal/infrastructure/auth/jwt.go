@@ -0,0 +1,308 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+)
+
+// Supported signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+// Claims are the verified claims of a bearer token
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	Role      entities.APIRole // Highest role granted by the roles claim; empty when it grants none
+}
+
+// keySource resolves the RSA key that signed a token
+type keySource interface {
+	Key(kid string) (*rsa.PublicKey, error)
+}
+
+// staticKey is a single RSA public key read from a PEM file
+type staticKey struct {
+	key *rsa.PublicKey
+}
+
+func (k staticKey) Key(string) (*rsa.PublicKey, error) {
+	return k.key, nil
+}
+
+// JWTVerifier verifies HS256 or RS256 JSON Web Tokens and maps their roles claim to API roles
+// Roles are reader, writer and admin (read, write and admin are accepted too); the highest one wins
+type JWTVerifier struct {
+	algorithm  string
+	secret     []byte
+	keys       keySource
+	issuer     string
+	audience   string
+	rolesClaim string
+	leeway     time.Duration
+	clock      clock.Clock
+}
+
+// NewJWTVerifier creates a verifier from the configuration; it returns nil when no algorithm is configured
+func NewJWTVerifier(cfg *config.JWTConfig) (*JWTVerifier, error) {
+	if cfg.Algorithm == "" {
+		return nil, nil
+	}
+
+	verifier := &JWTVerifier{
+		algorithm:  strings.ToUpper(cfg.Algorithm),
+		issuer:     cfg.Issuer,
+		audience:   cfg.Audience,
+		rolesClaim: cfg.RolesClaim,
+		leeway:     time.Duration(cfg.LeewaySeconds) * time.Second,
+		clock:      clock.System(),
+	}
+
+	switch verifier.algorithm {
+	case AlgorithmHS256:
+		if len(cfg.Secret) < 32 {
+			return nil, errors.New("JWT_SECRET must be at least 32 bytes for HS256")
+		}
+		verifier.secret = []byte(cfg.Secret)
+	case AlgorithmRS256:
+		switch {
+		case cfg.JWKSURL != "":
+			verifier.keys = NewJWKS(cfg.JWKSURL, time.Duration(cfg.JWKSRefreshMins)*time.Minute)
+		case cfg.PublicKeyFile != "":
+			key, err := loadRSAPublicKey(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			verifier.keys = staticKey{key: key}
+		default:
+			return nil, errors.New("RS256 needs JWT_JWKS_URL or JWT_PUBLIC_KEY_FILE")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q: use HS256 or RS256", cfg.Algorithm)
+	}
+
+	return verifier, nil
+}
+
+// WithClock sets the clock token expiry and JWKS refreshes are checked against
+func (v *JWTVerifier) WithClock(clk clock.Clock) *JWTVerifier {
+	v.clock = clk
+	if jwks, ok := v.keys.(*JWKS); ok {
+		jwks.WithClock(clk)
+	}
+	return v
+}
+
+// AuthenticateBearer verifies a bearer token and returns its subject and role
+func (v *JWTVerifier) AuthenticateBearer(token string) (string, entities.APIRole, error) {
+	claims, err := v.Verify(token)
+	if err != nil {
+		return "", "", err
+	}
+	return claims.Subject, claims.Role, nil
+}
+
+// Verify checks the token's signature, expiry, issuer and audience and returns its claims
+func (v *JWTVerifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errs.Newf(errs.ErrUnauthorized, "invalid bearer token: malformed JWT")
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errs.Newf(errs.ErrUnauthorized, "invalid bearer token: header: %w", err)
+	}
+	// Only the configured algorithm is accepted, so neither "none" nor an HS256 token signed with the RSA public key passes
+	if header.Algorithm != v.algorithm {
+		return nil, errs.Newf(errs.ErrUnauthorized, "invalid bearer token: algorithm %q is not accepted", header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errs.Newf(errs.ErrUnauthorized, "invalid bearer token: malformed signature")
+	}
+	if err := v.verifySignature(header.KeyID, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var payload map[string]any
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return nil, errs.Newf(errs.ErrUnauthorized, "invalid bearer token: payload: %w", err)
+	}
+	return v.claims(payload)
+}
+
+// verifySignature checks the signature over the header and payload segments
+func (v *JWTVerifier) verifySignature(kid, signingInput string, signature []byte) error {
+	if v.algorithm == AlgorithmHS256 {
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return errs.Newf(errs.ErrUnauthorized, "invalid bearer token: signature mismatch")
+		}
+		return nil
+	}
+
+	key, err := v.keys.Key(kid)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256([]byte(signingInput))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return errs.Newf(errs.ErrUnauthorized, "invalid bearer token: signature mismatch")
+	}
+	return nil
+}
+
+// claims validates the registered claims and resolves the role
+func (v *JWTVerifier) claims(payload map[string]any) (*Claims, error) {
+	now := v.clock.Now()
+
+	expiresAt, ok := numericDate(payload["exp"])
+	if !ok {
+		return nil, errs.Newf(errs.ErrUnauthorized, "invalid bearer token: exp claim is required")
+	}
+	if now.After(expiresAt.Add(v.leeway)) {
+		return nil, errs.Newf(errs.ErrUnauthorized, "bearer token expired")
+	}
+	if notBefore, ok := numericDate(payload["nbf"]); ok && now.Add(v.leeway).Before(notBefore) {
+		return nil, errs.Newf(errs.ErrUnauthorized, "bearer token is not valid yet")
+	}
+
+	claims := &Claims{
+		ExpiresAt: expiresAt,
+		Audience:  stringList(payload["aud"]),
+	}
+	claims.Subject, _ = payload["sub"].(string)
+	claims.Issuer, _ = payload["iss"].(string)
+
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return nil, errs.Newf(errs.ErrUnauthorized, "invalid bearer token: issuer %q is not accepted", claims.Issuer)
+	}
+	if v.audience != "" && !slices.Contains(claims.Audience, v.audience) {
+		return nil, errs.Newf(errs.ErrUnauthorized, "invalid bearer token: audience does not include %q", v.audience)
+	}
+
+	for _, name := range roleNames(payload[v.rolesClaim]) {
+		if role := roleFromClaim(name); role.Includes(claims.Role) {
+			claims.Role = role
+		}
+	}
+
+	return claims, nil
+}
+
+// roleFromClaim maps a role name from the token to an API role; unknown names map to no role
+func roleFromClaim(name string) entities.APIRole {
+	switch strings.ToLower(name) {
+	case "reader", "read":
+		return entities.RoleRead
+	case "writer", "write":
+		return entities.RoleWrite
+	case "admin":
+		return entities.RoleAdmin
+	default:
+		return ""
+	}
+}
+
+// decodeSegment decodes a base64url JSON segment
+func decodeSegment(segment string, target any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.New("malformed base64")
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return errors.New("malformed JSON")
+	}
+	return nil
+}
+
+// numericDate reads a JWT NumericDate (seconds since the epoch)
+func numericDate(value any) (time.Time, bool) {
+	seconds, ok := value.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+// roleNames reads the roles claim, which may also be a single string of space separated names like an OAuth scope
+func roleNames(value any) []string {
+	if text, ok := value.(string); ok {
+		return strings.Fields(text)
+	}
+	return stringList(value)
+}
+
+// stringList reads a claim that is either a single string or an array of strings, such as aud (RFC 7519)
+func stringList(value any) []string {
+	switch typed := value.(type) {
+	case string:
+		if typed == "" {
+			return nil
+		}
+		return []string{typed}
+	case []any:
+		values := make([]string, 0, len(typed))
+		for _, item := range typed {
+			if text, ok := item.(string); ok {
+				values = append(values, text)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// loadRSAPublicKey reads a PEM encoded PKIX or PKCS #1 RSA public key
+func loadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT public key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("JWT public key %s is not PEM encoded", path)
+	}
+
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT public key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("JWT public key %s is not an RSA key", path)
+	}
+	return key, nil
+}
@@ -0,0 +1,35 @@
+package broker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// Supported message brokers (EVENT_BROKER values)
+const (
+	BrokerNone = "none"
+	BrokerNATS = "nats"
+)
+
+// NewPublisherFromConfig builds the publisher for cfg.Type, or returns nil when no broker is configured
+// Publishers holding a connection also implement io.Closer
+func NewPublisherFromConfig(cfg *config.BrokerConfig) (services.MessagePublisher, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Type)) {
+	case BrokerNone, "":
+		return nil, nil
+	case BrokerNATS:
+		if strings.TrimSpace(cfg.Topic) == "" {
+			return nil, fmt.Errorf("EVENT_BROKER_TOPIC is required when EVENT_BROKER is %s", BrokerNATS)
+		}
+		publisher, err := NewNATSPublisher(cfg.URL)
+		if err != nil {
+			return nil, err
+		}
+		return publisher, nil
+	default:
+		return nil, fmt.Errorf("unsupported event broker %q: expected %s or %s", cfg.Type, BrokerNATS, BrokerNone)
+	}
+}
@@ -0,0 +1,266 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// natsDialTimeout bounds connecting to the NATS server so an unreachable broker fails a relay run fast
+const natsDialTimeout = 2 * time.Second
+
+// natsIOTimeout bounds a publish round trip when the context has no earlier deadline
+const natsIOTimeout = 5 * time.Second
+
+// natsMessageIDHeader lets JetStream streams drop duplicates of a message published more than once
+const natsMessageIDHeader = "Nats-Msg-Id"
+
+// NATSPublisher is a MessagePublisher for a NATS server
+// It speaks the client protocol over a single connection, reconnecting after errors, and confirms every
+// publish with a PING so a message only counts as published once the server has processed it
+type NATSPublisher struct {
+	addr     string
+	useTLS   bool
+	user     string
+	password string
+	token    string
+
+	mu      sync.Mutex
+	conn    net.Conn
+	reader  *bufio.Reader
+	headers bool // Whether the server accepts HPUB, learned from its INFO
+}
+
+// natsInfo is the part of the server INFO the publisher uses
+type natsInfo struct {
+	Headers bool `json:"headers"`
+}
+
+// natsConnect is the CONNECT sent after INFO
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Headers  bool   `json:"headers"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	Protocol int    `json:"protocol"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+// NewNATSPublisher creates a NATS publisher for rawURL: nats://[user:password@|token@]host[:port], or tls:// for TLS
+// The connection is opened on first use
+func NewNATSPublisher(rawURL string) (*NATSPublisher, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS URL: %w", err)
+	}
+	if parsed.Scheme != "nats" && parsed.Scheme != "tls" {
+		return nil, fmt.Errorf("invalid NATS URL %q: scheme must be nats or tls", rawURL)
+	}
+	if parsed.Hostname() == "" {
+		return nil, fmt.Errorf("invalid NATS URL %q: host is required", rawURL)
+	}
+
+	publisher := &NATSPublisher{
+		addr:   parsed.Host,
+		useTLS: parsed.Scheme == "tls",
+	}
+	if parsed.Port() == "" {
+		publisher.addr = net.JoinHostPort(parsed.Hostname(), "4222")
+	}
+	if parsed.User != nil {
+		if password, ok := parsed.User.Password(); ok {
+			publisher.user = parsed.User.Username()
+			publisher.password = password
+		} else {
+			publisher.token = parsed.User.Username()
+		}
+	}
+	return publisher, nil
+}
+
+// Publish sends payload to the topic subject, tagged with id when the server supports headers
+func (p *NATSPublisher) Publish(ctx context.Context, topic string, id string, payload []byte) error {
+	if topic == "" || strings.ContainsAny(topic, " \t\r\n") {
+		return fmt.Errorf("invalid NATS subject %q", topic)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := p.publish(ctx, topic, id, payload); err != nil {
+		_ = p.disconnect()
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection
+func (p *NATSPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.disconnect()
+}
+
+// connect dials the server, reads its INFO and authenticates; callers hold the lock
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: natsDialTimeout}
+	var conn net.Conn
+	var err error
+	if p.useTLS {
+		host, _, _ := net.SplitHostPort(p.addr)
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host}}).DialContext(ctx, "tcp", p.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", p.addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS at %s: %w", p.addr, err)
+	}
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+
+	if err := p.handshake(ctx); err != nil {
+		_ = p.disconnect()
+		return err
+	}
+	return nil
+}
+
+// handshake reads INFO, sends CONNECT and waits for the PONG that confirms it; callers hold the lock
+func (p *NATSPublisher) handshake(ctx context.Context) error {
+	if err := p.setDeadline(ctx); err != nil {
+		return err
+	}
+
+	line, err := p.readLine()
+	if err != nil {
+		return fmt.Errorf("failed to read NATS INFO: %w", err)
+	}
+	infoJSON, found := strings.CutPrefix(line, "INFO ")
+	if !found {
+		return fmt.Errorf("nats: expected INFO, got %q", line)
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(infoJSON), &info); err != nil {
+		return fmt.Errorf("nats: invalid INFO: %w", err)
+	}
+	p.headers = info.Headers
+
+	connect, err := json.Marshal(natsConnect{
+		Headers:  info.Headers,
+		Name:     "purchase-transaction-api",
+		Lang:     "go",
+		Version:  "1.0.0",
+		Protocol: 1,
+		User:     p.user,
+		Pass:     p.password,
+		Token:    p.token,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(p.conn, "CONNECT "+string(connect)+"\r\nPING\r\n"); err != nil {
+		return fmt.Errorf("failed to write NATS CONNECT: %w", err)
+	}
+	if err := p.awaitPong(); err != nil {
+		return fmt.Errorf("NATS connection rejected: %w", err)
+	}
+	return nil
+}
+
+// publish writes one message followed by PING and waits for the PONG; callers hold the lock
+func (p *NATSPublisher) publish(ctx context.Context, subject string, id string, payload []byte) error {
+	if err := p.setDeadline(ctx); err != nil {
+		return err
+	}
+
+	var frame strings.Builder
+	if p.headers && id != "" {
+		headers := "NATS/1.0\r\n" + natsMessageIDHeader + ": " + id + "\r\n\r\n"
+		fmt.Fprintf(&frame, "HPUB %s %d %d\r\n%s", subject, len(headers), len(headers)+len(payload), headers)
+	} else {
+		fmt.Fprintf(&frame, "PUB %s %d\r\n", subject, len(payload))
+	}
+	frame.Write(payload)
+	frame.WriteString("\r\nPING\r\n")
+
+	if _, err := io.WriteString(p.conn, frame.String()); err != nil {
+		return fmt.Errorf("failed to write NATS message: %w", err)
+	}
+	if err := p.awaitPong(); err != nil {
+		return fmt.Errorf("NATS did not accept the message: %w", err)
+	}
+	return nil
+}
+
+// awaitPong reads until PONG, answering server PINGs and failing on -ERR; callers hold the lock
+func (p *NATSPublisher) awaitPong() error {
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := io.WriteString(p.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("nats: " + strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'"))
+		case line == "+OK", strings.HasPrefix(line, "INFO "):
+			// Acknowledgements and cluster updates need no answer
+		default:
+			return fmt.Errorf("nats: unexpected %q", line)
+		}
+	}
+}
+
+// readLine reads one protocol line without its CRLF; callers hold the lock
+func (p *NATSPublisher) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// setDeadline applies the context deadline, or natsIOTimeout when it is later or missing; callers hold the lock
+func (p *NATSPublisher) setDeadline(ctx context.Context) error {
+	deadline := time.Now().Add(natsIOTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	return p.conn.SetDeadline(deadline)
+}
+
+// disconnect closes the connection if open; callers hold the lock
+func (p *NATSPublisher) disconnect() error {
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	p.reader = nil
+	return err
+}
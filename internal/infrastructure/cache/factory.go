@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+)
+
+// Supported rate cache backends (RATE_CACHE_BACKEND values)
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+	BackendOff    = "off"
+)
+
+// NewRateCacheFromConfig builds the rate cache for cfg.Backend
+// It returns nil for the off backend; the backend is returned too so Redis can be health checked
+func NewRateCacheFromConfig(cfg *config.RateCacheConfig) (*RateCache, Backend, error) {
+	var backend Backend
+	prefix := ""
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Backend)) {
+	case BackendOff:
+		return nil, nil, nil
+	case BackendMemory, "":
+		backend = NewLRUBackend(cfg.MaxEntries)
+	case BackendRedis:
+		backend = NewRedisBackend(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
+		prefix = cfg.RedisKeyPrefix
+	default:
+		return nil, nil, fmt.Errorf("unsupported rate cache backend %q: expected %s, %s or %s", cfg.Backend, BackendMemory, BackendRedis, BackendOff)
+	}
+
+	return NewRateCache(backend, prefix, time.Duration(cfg.TTLSeconds)*time.Second), backend, nil
+}
@@ -0,0 +1,138 @@
+// Package cache keeps hot exchange rate lookups out of the database and the Treasury API
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Backend stores cache entries; implementations must be safe for concurrent use
+// Backend errors are logged by callers and treated as misses, never failing a lookup
+type Backend interface {
+	// Get returns the value stored under key and whether it was found and unexpired
+	Get(key string) ([]byte, bool, error)
+
+	// Set stores value under key for ttl; a non-positive ttl keeps it until evicted
+	Set(key string, value []byte, ttl time.Duration) error
+
+	// Counter returns the counter stored under key, or zero when it was never incremented
+	Counter(key string) (int64, error)
+
+	// Incr atomically increments the counter stored under key and returns the new value
+	// Counters are kept apart from cached values and never expire
+	Incr(key string) (int64, error)
+
+	// Ping reports whether the backend is reachable
+	Ping(ctx context.Context) error
+}
+
+// lruEntry is a cached value and its expiry
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // Zero never expires
+}
+
+// LRUBackend is an in-process Backend that evicts the least recently used entry once full
+type LRUBackend struct {
+	maxEntries int
+
+	mu       sync.Mutex
+	order    *list.List // Front is the most recently used
+	entries  map[string]*list.Element
+	counters map[string]int64 // Not evicted, so a counter never goes back to an earlier value
+}
+
+// NewLRUBackend creates an in-process cache holding at most maxEntries entries; zero or less is unbounded
+func NewLRUBackend(maxEntries int) *LRUBackend {
+	return &LRUBackend{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		counters:   make(map[string]int64),
+	}
+}
+
+// Get returns an unexpired entry and marks it recently used; expired entries are dropped
+func (b *LRUBackend) Get(key string) ([]byte, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	element, found := b.entries[key]
+	if !found {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && !time.Now().Before(entry.expiresAt) {
+		b.remove(element)
+		return nil, false, nil
+	}
+
+	b.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+// Set stores an entry, evicting the least recently used ones beyond maxEntries
+func (b *LRUBackend) Set(key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	b.set(key, value, expiresAt)
+	return nil
+}
+
+// Counter returns a counter; the in-process cache never fails
+func (b *LRUBackend) Counter(key string) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.counters[key], nil
+}
+
+// Incr increments a counter
+func (b *LRUBackend) Incr(key string) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.counters[key]++
+	return b.counters[key], nil
+}
+
+// Ping always succeeds for the in-process cache
+func (b *LRUBackend) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Len reports the number of cached values, including expired ones not yet dropped
+func (b *LRUBackend) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.order.Len()
+}
+
+// set stores or replaces an entry and marks it recently used; callers hold the lock
+func (b *LRUBackend) set(key string, value []byte, expiresAt time.Time) {
+	if element, found := b.entries[key]; found {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		b.order.MoveToFront(element)
+		return
+	}
+
+	b.entries[key] = b.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for b.maxEntries > 0 && b.order.Len() > b.maxEntries {
+		b.remove(b.order.Back())
+	}
+}
+
+// remove drops an entry; callers hold the lock
+func (b *LRUBackend) remove(element *list.Element) {
+	b.order.Remove(element)
+	delete(b.entries, element.Value.(*lruEntry).key)
+}
@@ -0,0 +1,253 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// Sources of cached lookups; each has its own per-pair generation
+const (
+	sourceDatabase = "db"       // Rates found in the exchange rate repository
+	sourceTreasury = "treasury" // Rates fetched from the Treasury API
+)
+
+// RateCache caches exchange rate lookups keyed by source, currency pair and day
+// Keys embed a global and a per-pair generation; bumping one invalidates every entry under it,
+// which works the same for the in-process and the shared Redis backend
+type RateCache struct {
+	backend Backend
+	prefix  string
+	ttl     time.Duration
+}
+
+// NewRateCache creates a rate cache; prefix namespaces the keys of a shared backend
+func NewRateCache(backend Backend, prefix string, ttl time.Duration) *RateCache {
+	return &RateCache{
+		backend: backend,
+		prefix:  prefix,
+		ttl:     ttl,
+	}
+}
+
+// get returns the cached rate for the pair on date's day, or nil on a miss
+// A cached rate is only returned if it is still valid for date within window, so the time of day cannot break the lookback rule
+func (c *RateCache) get(source string, from, to entities.CurrencyCode, date time.Time, window entities.RateWindow) *entities.ExchangeRate {
+	key, err := c.key(source, from, to, date)
+	if err != nil {
+		c.warn("read", from, to, err)
+		return nil
+	}
+
+	value, found, err := c.backend.Get(key)
+	if err != nil {
+		c.warn("read", from, to, err)
+		return nil
+	}
+	if !found {
+		return nil
+	}
+
+	var exchangeRate entities.ExchangeRate
+	if err := json.Unmarshal(value, &exchangeRate); err != nil {
+		c.warn("decode", from, to, err)
+		return nil
+	}
+	if !exchangeRate.IsWithinDateRange(date, window) {
+		return nil
+	}
+
+	return &exchangeRate
+}
+
+// put caches the rate found for the pair on date's day
+func (c *RateCache) put(source string, from, to entities.CurrencyCode, date time.Time, exchangeRate *entities.ExchangeRate) {
+	key, err := c.key(source, from, to, date)
+	if err != nil {
+		c.warn("write", from, to, err)
+		return
+	}
+
+	value, err := json.Marshal(exchangeRate)
+	if err != nil {
+		c.warn("encode", from, to, err)
+		return
+	}
+	if err := c.backend.Set(key, value, c.ttl); err != nil {
+		c.warn("write", from, to, err)
+	}
+}
+
+// invalidate drops every cached lookup of a currency pair from the given sources
+func (c *RateCache) invalidate(from, to entities.CurrencyCode, sources ...string) {
+	for _, source := range sources {
+		if _, err := c.backend.Incr(c.generationKey(source, from, to)); err != nil {
+			c.warn("invalidate", from, to, err)
+		}
+	}
+}
+
+// invalidateAll drops every cached lookup
+func (c *RateCache) invalidateAll() {
+	if _, err := c.backend.Incr(c.prefix + "rates:gen"); err != nil {
+		c.warn("invalidate", "", "", err)
+	}
+}
+
+// key builds the entry key from the current generations, the source, the pair and the day
+func (c *RateCache) key(source string, from, to entities.CurrencyCode, date time.Time) (string, error) {
+	global, err := c.backend.Counter(c.prefix + "rates:gen")
+	if err != nil {
+		return "", err
+	}
+	pair, err := c.backend.Counter(c.generationKey(source, from, to))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%srates:%d:%s:%s:%s:%d:%s", c.prefix, global, source, from, to, pair, date.UTC().Format(time.DateOnly)), nil
+}
+
+func (c *RateCache) generationKey(source string, from, to entities.CurrencyCode) string {
+	return fmt.Sprintf("%srates:gen:%s:%s:%s", c.prefix, source, from, to)
+}
+
+// warn logs a backend failure; the cache is an optimisation, so lookups carry on without it
+func (c *RateCache) warn(operation string, from, to entities.CurrencyCode, err error) {
+	slog.Warn("Exchange rate cache unavailable",
+		"operation", operation,
+		"from_currency", string(from),
+		"to_currency", string(to),
+		"error", err.Error(),
+	)
+}
+
+// cachingExchangeRateRepository answers conversion lookups from the cache before the database
+type cachingExchangeRateRepository struct {
+	repositories.ExchangeRateRepository
+	cache *RateCache
+}
+
+// NewCachingExchangeRateRepository wraps an ExchangeRateRepository so conversion lookups are cached
+// Writes through the wrapper invalidate the lookups of the affected currency pair
+func NewCachingExchangeRateRepository(inner repositories.ExchangeRateRepository, cache *RateCache) repositories.ExchangeRateRepository {
+	return &cachingExchangeRateRepository{
+		ExchangeRateRepository: inner,
+		cache:                  cache,
+	}
+}
+
+// FindRateForConversion returns a cached rate or looks it up and caches it
+// Misses are not cached, so a rate stored later is found on the next lookup
+func (r *cachingExchangeRateRepository) FindRateForConversion(from, to entities.CurrencyCode, transactionDate time.Time, window entities.RateWindow) (*entities.ExchangeRate, error) {
+	if cached := r.cache.get(sourceDatabase, from, to, transactionDate, window); cached != nil {
+		return cached, nil
+	}
+
+	exchangeRate, err := r.ExchangeRateRepository.FindRateForConversion(from, to, transactionDate, window)
+	if err == nil && exchangeRate != nil {
+		r.cache.put(sourceDatabase, from, to, transactionDate, exchangeRate)
+	}
+	return exchangeRate, err
+}
+
+// Save stores the rate and invalidates its pair, since it may be closer to some dates than the cached rates
+func (r *cachingExchangeRateRepository) Save(exchangeRate *entities.ExchangeRate) error {
+	if err := r.ExchangeRateRepository.Save(exchangeRate); err != nil {
+		return err
+	}
+	r.cache.invalidate(exchangeRate.FromCurrency, exchangeRate.ToCurrency, sourceDatabase)
+	return nil
+}
+
+// SaveAll stores the rates and invalidates every pair among them
+func (r *cachingExchangeRateRepository) SaveAll(exchangeRates []entities.ExchangeRate) error {
+	if err := r.ExchangeRateRepository.SaveAll(exchangeRates); err != nil {
+		return err
+	}
+	r.invalidatePairs(exchangeRates)
+	return nil
+}
+
+// Update modifies the rate and invalidates its pair
+func (r *cachingExchangeRateRepository) Update(exchangeRate *entities.ExchangeRate) error {
+	if err := r.ExchangeRateRepository.Update(exchangeRate); err != nil {
+		return err
+	}
+	r.cache.invalidate(exchangeRate.FromCurrency, exchangeRate.ToCurrency, sourceDatabase)
+	return nil
+}
+
+// Delete removes the rate and invalidates everything, since only the ID is known
+func (r *cachingExchangeRateRepository) Delete(id uuid.UUID) error {
+	if err := r.ExchangeRateRepository.Delete(id); err != nil {
+		return err
+	}
+	r.cache.invalidateAll()
+	return nil
+}
+
+// Purge deletes rates and invalidates the purged currency, or everything when no currency is given
+// Rates fetched from the Treasury are dropped as well, so an evicted rate is fetched again
+func (r *cachingExchangeRateRepository) Purge(currency entities.CurrencyCode, effectiveDate *time.Time) (int64, error) {
+	purged, err := r.ExchangeRateRepository.Purge(currency, effectiveDate)
+	if err != nil {
+		return purged, err
+	}
+	if currency == "" {
+		r.cache.invalidateAll()
+	} else {
+		r.cache.invalidate(entities.USD, currency, sourceDatabase, sourceTreasury)
+	}
+	return purged, nil
+}
+
+// invalidatePairs bumps the generation of each distinct pair once
+func (r *cachingExchangeRateRepository) invalidatePairs(exchangeRates []entities.ExchangeRate) {
+	seen := make(map[[2]entities.CurrencyCode]bool)
+	for _, exchangeRate := range exchangeRates {
+		pair := [2]entities.CurrencyCode{exchangeRate.FromCurrency, exchangeRate.ToCurrency}
+		if !seen[pair] {
+			seen[pair] = true
+			r.cache.invalidate(pair[0], pair[1], sourceDatabase)
+		}
+	}
+}
+
+// cachingTreasuryService answers repeated Treasury lookups from the cache
+type cachingTreasuryService struct {
+	services.RateProvider
+	cache  *RateCache
+	window entities.RateWindow
+}
+
+// NewCachingTreasuryService wraps a RateProvider so rates fetched for a pair and day are reused
+// Sharing the rate cache with the repository decorator lets a purge through it drop fetched rates too
+// window must match the one the inner service searches, so a cached rate is reused for the same dates it was fetched for
+func NewCachingTreasuryService(inner services.RateProvider, cache *RateCache, window entities.RateWindow) services.RateProvider {
+	return &cachingTreasuryService{
+		RateProvider: inner,
+		cache:        cache,
+		window:       window,
+	}
+}
+
+// FetchExchangeRate returns a cached rate or fetches and caches it; errors are never cached
+// Rates of fallback providers are not cached either, so the Treasury is asked again once it recovers
+func (s *cachingTreasuryService) FetchExchangeRate(ctx context.Context, from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	if cached := s.cache.get(sourceTreasury, from, to, date, s.window); cached != nil {
+		return cached, nil
+	}
+
+	exchangeRate, err := s.RateProvider.FetchExchangeRate(ctx, from, to, date)
+	if err == nil && exchangeRate != nil && exchangeRate.IsAuthoritative() {
+		s.cache.put(sourceTreasury, from, to, date, exchangeRate)
+	}
+	return exchangeRate, err
+}
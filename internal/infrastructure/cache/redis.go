@@ -0,0 +1,238 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redisDialTimeout bounds connecting to Redis so an unreachable server fails lookups fast
+const redisDialTimeout = 2 * time.Second
+
+// redisIOTimeout bounds each command round trip
+const redisIOTimeout = time.Second
+
+// errRedisNil is the reply to GET on a missing key
+var errRedisNil = errors.New("redis: nil")
+
+// RedisBackend is a Backend shared by every instance through a Redis server
+// It speaks the RESP protocol over a single connection, reconnecting after errors
+type RedisBackend struct {
+	addr     string
+	password string
+	db       int
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedisBackend creates a Redis backend; the connection is opened on first use
+func NewRedisBackend(addr, password string, db int) *RedisBackend {
+	return &RedisBackend{
+		addr:     addr,
+		password: password,
+		db:       db,
+	}
+}
+
+// Get returns the value of key; a missing or expired key is a miss
+func (b *RedisBackend) Get(key string) ([]byte, bool, error) {
+	reply, err := b.do("GET", key)
+	if errors.Is(err, errRedisNil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+	return value, true, nil
+}
+
+// Set stores value under key with a millisecond expiry
+func (b *RedisBackend) Set(key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	_, err := b.do(args...)
+	return err
+}
+
+// Counter returns the integer stored under key, or zero when it does not exist
+func (b *RedisBackend) Counter(key string) (int64, error) {
+	value, found, err := b.Get(key)
+	if err != nil || !found {
+		return 0, err
+	}
+	return strconv.ParseInt(string(value), 10, 64)
+}
+
+// Incr increments the integer stored under key with INCR
+func (b *RedisBackend) Incr(key string) (int64, error) {
+	reply, err := b.do("INCR", key)
+	if err != nil {
+		return 0, err
+	}
+
+	value, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis: unexpected INCR reply %T", reply)
+	}
+	return value, nil
+}
+
+// Ping sends PING so the health report can show whether Redis is reachable
+func (b *RedisBackend) Ping(ctx context.Context) error {
+	_, err := b.do("PING")
+	return err
+}
+
+// Close closes the connection
+func (b *RedisBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.disconnect()
+}
+
+// do sends one command and reads its reply, dropping the connection on any I/O or protocol error
+func (b *RedisBackend) do(args ...string) (any, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.conn == nil {
+		if err := b.connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := b.roundTrip(args...)
+	var serverError redisError
+	if err != nil && !errors.Is(err, errRedisNil) && !errors.As(err, &serverError) {
+		_ = b.disconnect()
+	}
+	return reply, err
+}
+
+// connect dials Redis and authenticates; callers hold the lock
+func (b *RedisBackend) connect() error {
+	conn, err := net.DialTimeout("tcp", b.addr, redisDialTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis at %s: %w", b.addr, err)
+	}
+	b.conn = conn
+	b.reader = bufio.NewReader(conn)
+
+	if b.password != "" {
+		if _, err := b.roundTrip("AUTH", b.password); err != nil {
+			_ = b.disconnect()
+			return fmt.Errorf("redis authentication failed: %w", err)
+		}
+	}
+	if b.db != 0 {
+		if _, err := b.roundTrip("SELECT", strconv.Itoa(b.db)); err != nil {
+			_ = b.disconnect()
+			return fmt.Errorf("failed to select redis database %d: %w", b.db, err)
+		}
+	}
+
+	return nil
+}
+
+// disconnect closes the connection if open; callers hold the lock
+func (b *RedisBackend) disconnect() error {
+	if b.conn == nil {
+		return nil
+	}
+	err := b.conn.Close()
+	b.conn = nil
+	b.reader = nil
+	return err
+}
+
+// roundTrip writes a command as a RESP array of bulk strings and reads the reply; callers hold the lock
+func (b *RedisBackend) roundTrip(args ...string) (any, error) {
+	if err := b.conn.SetDeadline(time.Now().Add(redisIOTimeout)); err != nil {
+		return nil, err
+	}
+
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(b.conn, command.String()); err != nil {
+		return nil, fmt.Errorf("failed to write redis command: %w", err)
+	}
+
+	return readRedisReply(b.reader)
+}
+
+// redisError is an error reply sent by the server; the connection stays usable after one
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+// readRedisReply parses one RESP reply: simple strings, errors, integers, bulk strings and arrays
+func readRedisReply(reader *bufio.Reader) (any, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read redis reply: %w", err)
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch prefix, rest := line[0], line[1:]; prefix {
+	case '+':
+		return rest, nil
+	case '-':
+		return nil, redisError(rest)
+	case ':':
+		return strconv.ParseInt(rest, 10, 64)
+	case '$':
+		length, err := strconv.Atoi(rest)
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length %q", rest)
+		}
+		if length < 0 {
+			return nil, errRedisNil
+		}
+		value := make([]byte, length+2) // Payload and trailing CRLF
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, fmt.Errorf("failed to read redis reply: %w", err)
+		}
+		return value[:length], nil
+	case '*':
+		count, err := strconv.Atoi(rest)
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length %q", rest)
+		}
+		if count < 0 {
+			return nil, errRedisNil
+		}
+		items := make([]any, 0, count)
+		for i := 0; i < count; i++ {
+			item, err := readRedisReply(reader)
+			if err != nil && !errors.Is(err, errRedisNil) {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// sqliteAPITokenRepository implements APITokenRepository interface using GORM
+type sqliteAPITokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAPITokenRepository creates a new GORM implementation of APITokenRepository
+func NewAPITokenRepository(db *gorm.DB) repositories.APITokenRepository {
+	return &sqliteAPITokenRepository{
+		db: db,
+	}
+}
+
+// Create persists a new token
+func (r *sqliteAPITokenRepository) Create(token *entities.APIToken) error {
+	if token == nil {
+		return errors.New("api token cannot be nil")
+	}
+
+	if err := token.Validate(); err != nil {
+		return err
+	}
+
+	return r.db.Create(token).Error
+}
+
+// Update saves changes to an existing token
+func (r *sqliteAPITokenRepository) Update(token *entities.APIToken) error {
+	if token == nil {
+		return errors.New("api token cannot be nil")
+	}
+
+	if err := token.Validate(); err != nil {
+		return err
+	}
+
+	result := r.db.Model(&entities.APIToken{}).Where("id = ?", token.ID).Select("*").Omit("created_at").Updates(token)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.Newf(errs.ErrNotFound, "api token with ID %s not found", token.ID)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a token by its unique identifier
+func (r *sqliteAPITokenRepository) GetByID(id uuid.UUID) (*entities.APIToken, error) {
+	var token entities.APIToken
+
+	result := UsePrimary(r.db).First(&token, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &token, nil
+}
+
+// GetByHash retrieves a token by the hash of its secret
+// Reads the primary so revocations and newly issued tokens take effect immediately
+func (r *sqliteAPITokenRepository) GetByHash(tokenHash string) (*entities.APIToken, error) {
+	var token entities.APIToken
+
+	result := UsePrimary(r.db).First(&token, "token_hash = ?", tokenHash)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &token, nil
+}
+
+// List retrieves every token, newest first
+func (r *sqliteAPITokenRepository) List() ([]entities.APIToken, error) {
+	var tokens []entities.APIToken
+
+	result := r.db.Order("created_at DESC, id ASC").Find(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tokens, nil
+}
+
+// CountActive returns how many tokens with the role are neither revoked nor expired
+func (r *sqliteAPITokenRepository) CountActive(role entities.APIRole) (int64, error) {
+	var count int64
+	now := time.Now()
+
+	result := UsePrimary(r.db).Model(&entities.APIToken{}).
+		Where("role = ?", role).
+		Where("revoked_at IS NULL OR revoked_at > ?", now).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return count, nil
+}
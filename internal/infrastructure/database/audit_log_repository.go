@@ -0,0 +1,70 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// sqliteAuditLogRepository implements AuditLogRepository interface using GORM
+type sqliteAuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new GORM implementation of AuditLogRepository
+func NewAuditLogRepository(db *gorm.DB) repositories.AuditLogRepository {
+	return &sqliteAuditLogRepository{
+		db: db,
+	}
+}
+
+// Save appends an entry to the audit_logs table
+func (r *sqliteAuditLogRepository) Save(entry *entities.AuditLog) error {
+	if entry == nil {
+		return errors.New("audit log entry cannot be nil")
+	}
+
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	return r.db.Create(entry).Error
+}
+
+// FindPaginated retrieves matching entries, most recent first
+func (r *sqliteAuditLogRepository) FindPaginated(filter entities.AuditLogFilter, page, size int) ([]entities.AuditLog, int64, error) {
+	var entries []entities.AuditLog
+	var total int64
+
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	query := r.db.Model(&entities.AuditLog{})
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * size
+	if err := query.Order("created_at DESC").Order("id").Limit(size).Offset(offset).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
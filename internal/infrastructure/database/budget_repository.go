@@ -0,0 +1,122 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// sqliteBudgetRepository implements BudgetRepository interface using GORM
+type sqliteBudgetRepository struct {
+	db *gorm.DB
+}
+
+// NewBudgetRepository creates a new GORM implementation of BudgetRepository
+func NewBudgetRepository(db *gorm.DB) repositories.BudgetRepository {
+	return &sqliteBudgetRepository{
+		db: db,
+	}
+}
+
+// Save persists a new budget to the database
+func (r *sqliteBudgetRepository) Save(budget *entities.Budget) error {
+	if budget == nil {
+		return errors.New("budget cannot be nil")
+	}
+
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+
+	return r.db.Create(budget).Error
+}
+
+// GetByID retrieves a budget by its unique identifier
+func (r *sqliteBudgetRepository) GetByID(id uuid.UUID) (*entities.Budget, error) {
+	var budget entities.Budget
+
+	result := r.db.Where("id = ?", id).First(&budget)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil, nil when not found (as per interface contract)
+		}
+		return nil, result.Error
+	}
+
+	return &budget, nil
+}
+
+// GetAll retrieves every budget ordered by category, then period
+func (r *sqliteBudgetRepository) GetAll() ([]entities.Budget, error) {
+	var budgets []entities.Budget
+
+	result := r.db.Order("category ASC, period ASC, created_at ASC").Find(&budgets)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return budgets, nil
+}
+
+// FindByCategory retrieves the budgets that track a category
+// Reads the primary so a budget created moments ago already applies
+func (r *sqliteBudgetRepository) FindByCategory(category string) ([]entities.Budget, error) {
+	var budgets []entities.Budget
+
+	result := UsePrimary(r.db).Where("category = ?", category).Order("period ASC").Find(&budgets)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return budgets, nil
+}
+
+// RenameCategory moves every budget of a category to another category name
+func (r *sqliteBudgetRepository) RenameCategory(from, to string) (int64, error) {
+	result := r.db.Model(&entities.Budget{}).Where("category = ?", from).
+		Updates(map[string]interface{}{"category": to, "updated_at": time.Now()})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// Update replaces a stored budget
+func (r *sqliteBudgetRepository) Update(budget *entities.Budget) error {
+	if budget == nil {
+		return errors.New("budget cannot be nil")
+	}
+
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+
+	result := r.db.Model(&entities.Budget{}).Where("id = ?", budget.ID).Select("*").Omit("created_at").Updates(budget)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.Newf(errs.ErrNotFound, "budget with ID %s not found", budget.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a budget
+func (r *sqliteBudgetRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&entities.Budget{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.Newf(errs.ErrNotFound, "budget with ID %s not found", id)
+	}
+
+	return nil
+}
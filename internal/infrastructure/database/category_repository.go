@@ -0,0 +1,140 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// sqliteCategoryRepository implements CategoryRepository interface using GORM
+type sqliteCategoryRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository creates a new GORM implementation of CategoryRepository
+func NewCategoryRepository(db *gorm.DB) repositories.CategoryRepository {
+	return &sqliteCategoryRepository{
+		db: db,
+	}
+}
+
+// Save persists a new category to the database
+// The name check ignores case; the unique index on name rejects an exact duplicate saved concurrently
+func (r *sqliteCategoryRepository) Save(category *entities.Category) error {
+	if category == nil {
+		return errors.New("category cannot be nil")
+	}
+
+	if err := category.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := r.GetByName(category.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errs.Newf(errs.ErrConflict, "category %q already exists", existing.Name)
+	}
+
+	result := UsePrimary(r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(category)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.Newf(errs.ErrConflict, "category %q already exists", category.Name)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a category by its unique identifier
+func (r *sqliteCategoryRepository) GetByID(id uuid.UUID) (*entities.Category, error) {
+	var category entities.Category
+
+	result := r.db.Where("id = ?", id).First(&category)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil, nil when not found (as per interface contract)
+		}
+		return nil, result.Error
+	}
+
+	return &category, nil
+}
+
+// GetByName retrieves a category by name, ignoring case
+// Reads the primary so a category created moments ago can be used right away
+func (r *sqliteCategoryRepository) GetByName(name string) (*entities.Category, error) {
+	var category entities.Category
+
+	result := UsePrimary(r.db).Where("LOWER(name) = ?", strings.ToLower(name)).First(&category)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil, nil when not found (as per interface contract)
+		}
+		return nil, result.Error
+	}
+
+	return &category, nil
+}
+
+// GetAll retrieves every category ordered by name
+func (r *sqliteCategoryRepository) GetAll() ([]entities.Category, error) {
+	var categories []entities.Category
+
+	result := r.db.Order("name ASC").Find(&categories)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return categories, nil
+}
+
+// Update replaces a stored category
+func (r *sqliteCategoryRepository) Update(category *entities.Category) error {
+	if category == nil {
+		return errors.New("category cannot be nil")
+	}
+
+	if err := category.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := r.GetByName(category.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != category.ID {
+		return errs.Newf(errs.ErrConflict, "category %q already exists", existing.Name)
+	}
+
+	result := r.db.Model(&entities.Category{}).Where("id = ?", category.ID).Select("*").Omit("created_at").Updates(category)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.Newf(errs.ErrNotFound, "category with ID %s not found", category.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a category
+func (r *sqliteCategoryRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&entities.Category{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.Newf(errs.ErrNotFound, "category with ID %s not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// sqliteConversionRecordRepository implements ConversionRecordRepository interface using GORM
+type sqliteConversionRecordRepository struct {
+	db *gorm.DB
+}
+
+// NewConversionRecordRepository creates a new GORM implementation of ConversionRecordRepository
+func NewConversionRecordRepository(db *gorm.DB) repositories.ConversionRecordRepository {
+	return &sqliteConversionRecordRepository{
+		db: db,
+	}
+}
+
+// SaveAll inserts the records in batches within a single database transaction
+func (r *sqliteConversionRecordRepository) SaveAll(records []entities.ConversionRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	return r.db.CreateInBatches(records, defaultBatchSize).Error
+}
+
+// GetByBatchPaginated retrieves the records produced by a batch run, ordered by transaction date
+func (r *sqliteConversionRecordRepository) GetByBatchPaginated(batchID uuid.UUID, page, size int) ([]entities.ConversionRecord, int64, error) {
+	var records []entities.ConversionRecord
+	var total int64
+
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	offset := (page - 1) * size
+
+	current := "batch_id = ? AND superseded_at IS NULL"
+	result := r.db.Model(&entities.ConversionRecord{}).Where(current, batchID).Count(&total)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	result = r.db.Where(current, batchID).
+		Order("transaction_date ASC, id ASC").Limit(size).Offset(offset).Find(&records)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return records, total, nil
+}
+
+// FindSupersedable returns current records in currency that a rate effective on effectiveDate would improve
+func (r *sqliteConversionRecordRepository) FindSupersedable(currency entities.CurrencyCode, effectiveDate time.Time) ([]entities.ConversionRecord, error) {
+	var records []entities.ConversionRecord
+
+	result := r.db.Where("target_currency = ? AND superseded_at IS NULL AND effective_date < ? AND transaction_date >= ?",
+		currency, effectiveDate, effectiveDate).
+		Order("transaction_date ASC, id ASC").Find(&records)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return records, nil
+}
+
+// Supersede stores replacement and marks previous as superseded within a database transaction
+func (r *sqliteConversionRecordRepository) Supersede(previous *entities.ConversionRecord, replacement *entities.ConversionRecord) error {
+	if previous == nil || replacement == nil {
+		return errors.New("conversion records cannot be nil")
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(replacement).Error; err != nil {
+			return err
+		}
+
+		supersededAt := time.Now().UTC()
+		result := tx.Model(&entities.ConversionRecord{}).
+			Where("id = ? AND superseded_at IS NULL", previous.ID).
+			Updates(map[string]interface{}{"superseded_at": supersededAt, "superseded_by": replacement.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errs.Newf(errs.ErrNotFound, "conversion record not found or already superseded")
+		}
+
+		previous.SupersededAt = &supersededAt
+		previous.SupersededBy = &replacement.ID
+		return nil
+	})
+}
+
+// Sample returns up to limit current records in random order
+func (r *sqliteConversionRecordRepository) Sample(limit int) ([]entities.ConversionRecord, error) {
+	var records []entities.ConversionRecord
+
+	// RANDOM() is understood by both SQLite and PostgreSQL; MySQL spells it RAND()
+	random := "RANDOM()"
+	if r.db.Dialector.Name() == "mysql" {
+		random = "RAND()"
+	}
+	result := r.db.Where("superseded_at IS NULL").Order(random).Limit(limit).Find(&records)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return records, nil
+}
+
+// sqliteConversionBatchRepository implements ConversionBatchRepository interface using GORM
+type sqliteConversionBatchRepository struct {
+	db *gorm.DB
+}
+
+// NewConversionBatchRepository creates a new GORM implementation of ConversionBatchRepository
+func NewConversionBatchRepository(db *gorm.DB) repositories.ConversionBatchRepository {
+	return &sqliteConversionBatchRepository{
+		db: db,
+	}
+}
+
+// Save persists a new batch
+func (r *sqliteConversionBatchRepository) Save(batch *entities.ConversionBatch) error {
+	if batch == nil {
+		return errors.New("batch cannot be nil")
+	}
+
+	return r.db.Create(batch).Error
+}
+
+// Update stores the batch's current status and progress counters
+func (r *sqliteConversionBatchRepository) Update(batch *entities.ConversionBatch) error {
+	if batch == nil {
+		return errors.New("batch cannot be nil")
+	}
+
+	result := r.db.Model(batch).Select("*").Updates(batch)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.Newf(errs.ErrNotFound, "conversion batch not found")
+	}
+
+	return nil
+}
+
+// GetByID retrieves a batch by its unique identifier
+func (r *sqliteConversionBatchRepository) GetByID(id uuid.UUID) (*entities.ConversionBatch, error) {
+	var batch entities.ConversionBatch
+
+	result := r.db.Where("id = ?", id).First(&batch)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil, nil when not found (as per interface contract)
+		}
+		return nil, result.Error
+	}
+
+	return &batch, nil
+}
+
+// sqliteConversionHistoryRepository implements ConversionHistoryRepository interface using GORM
+type sqliteConversionHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewConversionHistoryRepository creates a new GORM implementation of ConversionHistoryRepository
+func NewConversionHistoryRepository(db *gorm.DB) repositories.ConversionHistoryRepository {
+	return &sqliteConversionHistoryRepository{
+		db: db,
+	}
+}
+
+// SaveAll inserts the conversions in batches within a single database transaction
+func (r *sqliteConversionHistoryRepository) SaveAll(conversions []entities.Conversion) error {
+	if len(conversions) == 0 {
+		return nil
+	}
+
+	return r.db.CreateInBatches(conversions, defaultBatchSize).Error
+}
+
+// GetByTransactionPaginated retrieves the conversions of a transaction, most recent first
+func (r *sqliteConversionHistoryRepository) GetByTransactionPaginated(transactionID uuid.UUID, page, size int) ([]entities.Conversion, int64, error) {
+	var conversions []entities.Conversion
+	var total int64
+
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	offset := (page - 1) * size
+
+	result := r.db.Model(&entities.Conversion{}).Where("transaction_id = ?", transactionID).Count(&total)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	result = r.db.Where("transaction_id = ?", transactionID).
+		Order("created_at DESC, id ASC").Limit(size).Offset(offset).Find(&conversions)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return conversions, total, nil
+}
+
+// ForEach streams all conversions in batches of batchSize, calling fn once per batch
+func (r *sqliteConversionHistoryRepository) ForEach(batchSize int, fn func(batch []entities.Conversion) error) error {
+	if fn == nil {
+		return errors.New("batch callback cannot be nil")
+	}
+	if batchSize < 1 {
+		batchSize = defaultBatchSize
+	}
+
+	var batch []entities.Conversion
+	result := r.db.FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	})
+
+	return result.Error
+}
+
+// Update replaces a stored conversion
+func (r *sqliteConversionHistoryRepository) Update(conversion *entities.Conversion) error {
+	if conversion == nil {
+		return errors.New("conversion cannot be nil")
+	}
+
+	exists, err := r.Exists(conversion.ID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errs.Newf(errs.ErrNotFound, "conversion not found")
+	}
+
+	return r.db.Save(conversion).Error
+}
+
+// Exists checks if a conversion with the given ID exists
+func (r *sqliteConversionHistoryRepository) Exists(id uuid.UUID) (bool, error) {
+	var count int64
+
+	result := r.db.Model(&entities.Conversion{}).Where("id = ?", id).Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
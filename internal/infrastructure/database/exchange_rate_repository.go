@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
 	"gorm.io/gorm"
 )
@@ -42,6 +43,24 @@ func (r *sqliteExchangeRateRepository) Save(exchangeRate *entities.ExchangeRate)
 	return nil
 }
 
+// SaveAll inserts the exchange rates in batches of defaultBatchSize within a single database transaction
+func (r *sqliteExchangeRateRepository) SaveAll(exchangeRates []entities.ExchangeRate) error {
+	if len(exchangeRates) == 0 {
+		return nil
+	}
+
+	// Validate everything up front so a bad record doesn't roll back a half-written import
+	for i := range exchangeRates {
+		if err := exchangeRates[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(exchangeRates, defaultBatchSize).Error
+	})
+}
+
 // GetByID retrieves an exchange rate by its unique identifier
 func (r *sqliteExchangeRateRepository) GetByID(id uuid.UUID) (*entities.ExchangeRate, error) {
 	var exchangeRate entities.ExchangeRate
@@ -58,18 +77,15 @@ func (r *sqliteExchangeRateRepository) GetByID(id uuid.UUID) (*entities.Exchange
 }
 
 // FindRateForConversion finds the most suitable exchange rate for currency conversion
-// Must comply with the 6-month rule: rate date <= transaction date and within 6 months
-func (r *sqliteExchangeRateRepository) FindRateForConversion(from, to entities.CurrencyCode, transactionDate time.Time) (*entities.ExchangeRate, error) {
-	// Calculate 6 months ago from transaction date
-	sixMonthsAgo := transactionDate.AddDate(0, -6, 0)
-
+// Must comply with the lookback rule: rate date <= transaction date and within window before it
+func (r *sqliteExchangeRateRepository) FindRateForConversion(from, to entities.CurrencyCode, transactionDate time.Time, window entities.RateWindow) (*entities.ExchangeRate, error) {
 	var exchangeRate entities.ExchangeRate
 
-	// Find the most recent exchange rate that satisfies the 6-month rule
+	// Find the most recent exchange rate that satisfies the lookback rule
 	result := r.db.Where("from_currency = ? AND to_currency = ?", from, to).
-		Where("effective_date <= ?", transactionDate). // Rate date <= transaction date
-		Where("effective_date >= ?", sixMonthsAgo).    // Within 6 months
-		Order("effective_date DESC").                  // Most recent first
+		Where("effective_date <= ?", transactionDate).               // Rate date <= transaction date
+		Where("effective_date >= ?", window.Start(transactionDate)). // Within the window
+		Order("effective_date DESC").                                // Most recent first
 		First(&exchangeRate)
 
 	if result.Error != nil {
@@ -82,6 +98,60 @@ func (r *sqliteExchangeRateRepository) FindRateForConversion(from, to entities.C
 	return &exchangeRate, nil
 }
 
+// FindSurroundingRates finds the nearest rates on either side of a date within a window of months
+func (r *sqliteExchangeRateRepository) FindSurroundingRates(from, to entities.CurrencyCode, date time.Time, windowMonths int) (*entities.ExchangeRate, *entities.ExchangeRate, error) {
+	pair := r.db.Where("from_currency = ? AND to_currency = ?", from, to)
+
+	// Nearest rate on or before the date
+	before, err := firstRate(pair.Session(&gorm.Session{}).
+		Where("effective_date <= ? AND effective_date >= ?", date, date.AddDate(0, -windowMonths, 0)).
+		Order("effective_date DESC"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Nearest rate after the date
+	after, err := firstRate(pair.Session(&gorm.Session{}).
+		Where("effective_date > ? AND effective_date <= ?", date, date.AddDate(0, windowMonths, 0)).
+		Order("effective_date ASC"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return before, after, nil
+}
+
+// firstRate returns the first exchange rate matched by query, or nil if there is none
+func firstRate(query *gorm.DB) (*entities.ExchangeRate, error) {
+	var exchangeRate entities.ExchangeRate
+	result := query.First(&exchangeRate)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &exchangeRate, nil
+}
+
+// ForEach streams all exchange rates in batches ordered by primary key
+// The batch slice is reused between calls, so fn must copy any rows it needs to keep
+func (r *sqliteExchangeRateRepository) ForEach(batchSize int, fn func(batch []entities.ExchangeRate) error) error {
+	if fn == nil {
+		return errors.New("batch callback cannot be nil")
+	}
+	if batchSize < 1 {
+		batchSize = defaultBatchSize
+	}
+
+	var batch []entities.ExchangeRate
+	result := r.db.FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	})
+
+	return result.Error
+}
+
 // Update modifies an existing exchange rate in the database
 func (r *sqliteExchangeRateRepository) Update(exchangeRate *entities.ExchangeRate) error {
 	if exchangeRate == nil {
@@ -99,7 +169,7 @@ func (r *sqliteExchangeRateRepository) Update(exchangeRate *entities.ExchangeRat
 		return err
 	}
 	if !exists {
-		return errors.New("exchange rate not found")
+		return errs.Newf(errs.ErrNotFound, "exchange rate not found")
 	}
 
 	// Update exchange rate in database
@@ -119,7 +189,7 @@ func (r *sqliteExchangeRateRepository) Delete(id uuid.UUID) error {
 		return err
 	}
 	if !exists {
-		return errors.New("exchange rate not found")
+		return errs.Newf(errs.ErrNotFound, "exchange rate not found")
 	}
 
 	// Delete exchange rate from database
@@ -131,6 +201,68 @@ func (r *sqliteExchangeRateRepository) Delete(id uuid.UUID) error {
 	return nil
 }
 
+// SummarizeByCurrency counts the cached rates of each target currency, ordered by currency
+func (r *sqliteExchangeRateRepository) SummarizeByCurrency() ([]entities.RateCacheSummary, error) {
+	var counts []struct {
+		ToCurrency entities.CurrencyCode
+		Rates      int64
+	}
+	result := r.db.Model(&entities.ExchangeRate{}).
+		Select("to_currency, COUNT(*) AS rates").
+		Group("to_currency").
+		Order("to_currency ASC").
+		Scan(&counts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// Dates are read per currency: SQLite returns MIN/MAX of a datetime column as text
+	summaries := make([]entities.RateCacheSummary, 0, len(counts))
+	for _, count := range counts {
+		pair := r.db.Where("to_currency = ?", count.ToCurrency)
+
+		oldest, err := firstRate(pair.Session(&gorm.Session{}).Order("effective_date ASC"))
+		if err != nil {
+			return nil, err
+		}
+		newest, err := firstRate(pair.Session(&gorm.Session{}).Order("effective_date DESC"))
+		if err != nil {
+			return nil, err
+		}
+		if oldest == nil || newest == nil {
+			continue // Purged since the count was taken
+		}
+
+		summaries = append(summaries, entities.RateCacheSummary{
+			Currency:            count.ToCurrency,
+			Rates:               count.Rates,
+			OldestEffectiveDate: oldest.EffectiveDate,
+			NewestEffectiveDate: newest.EffectiveDate,
+		})
+	}
+
+	return summaries, nil
+}
+
+// Purge deletes cached rates to a currency, or to every currency when currency is empty
+func (r *sqliteExchangeRateRepository) Purge(currency entities.CurrencyCode, effectiveDate *time.Time) (int64, error) {
+	query := r.db.Session(&gorm.Session{AllowGlobalUpdate: true})
+	if currency != "" {
+		query = query.Where("to_currency = ?", currency)
+	}
+	if effectiveDate != nil {
+		day := effectiveDate.UTC().Truncate(24 * time.Hour)
+		query = query.Where("effective_date >= ? AND effective_date < ?", day, day.AddDate(0, 0, 1))
+	}
+
+	result := query.Delete(&entities.ExchangeRate{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
 // Exists checks if an exchange rate with the given ID exists
 func (r *sqliteExchangeRateRepository) Exists(id uuid.UUID) (bool, error) {
 	var count int64
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// sqliteIdempotencyKeyRepository implements IdempotencyKeyRepository interface using GORM
+type sqliteIdempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyKeyRepository creates a new GORM implementation of IdempotencyKeyRepository
+func NewIdempotencyKeyRepository(db *gorm.DB) repositories.IdempotencyKeyRepository {
+	return &sqliteIdempotencyKeyRepository{
+		db: db,
+	}
+}
+
+// Reserve inserts the key, relying on the unique (scope, key) index to reject a concurrent duplicate
+func (r *sqliteIdempotencyKeyRepository) Reserve(key *entities.IdempotencyKey) (bool, error) {
+	if key == nil {
+		return false, errors.New("idempotency key cannot be nil")
+	}
+
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// Find retrieves a key by scope and header value
+func (r *sqliteIdempotencyKeyRepository) Find(scope, key string) (*entities.IdempotencyKey, error) {
+	var record entities.IdempotencyKey
+
+	result := r.db.Where("scope = ? AND idempotency_key = ?", scope, key).First(&record)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil, nil when not found (as per interface contract)
+		}
+		return nil, result.Error
+	}
+
+	return &record, nil
+}
+
+// Complete stores the transaction and response of a reserved key
+func (r *sqliteIdempotencyKeyRepository) Complete(key *entities.IdempotencyKey) error {
+	if key == nil {
+		return errors.New("idempotency key cannot be nil")
+	}
+
+	result := r.db.Model(&entities.IdempotencyKey{}).Where("id = ?", key.ID).Updates(map[string]interface{}{
+		"transaction_id": key.TransactionID,
+		"response":       key.Response,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.Newf(errs.ErrNotFound, "idempotency key with ID %s not found", key.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a key
+func (r *sqliteIdempotencyKeyRepository) Delete(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&entities.IdempotencyKey{}).Error
+}
+
+// DeleteExpired removes keys that expired before the given time
+func (r *sqliteIdempotencyKeyRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&entities.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,257 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// The structs below snapshot the entities as they were when versioned migrations were introduced
+// They are frozen: the entities keep changing, so later schema changes belong in new migrations
+
+type baselineTransaction struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key"`
+	Description string    `gorm:"not null;index"`
+	Date        time.Time `gorm:"not null;index"`
+	Amount      int64     `gorm:"not null;index"`
+	Category    string    `gorm:"index"`
+	Tags        []string  `gorm:"serializer:json"`
+	ExternalID  *string   `gorm:"index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+func (baselineTransaction) TableName() string { return "transactions" }
+
+type baselineArchivedTransaction struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key"`
+	Description string    `gorm:"not null"`
+	Date        time.Time `gorm:"not null;index"`
+	Amount      int64     `gorm:"not null"`
+	Category    string
+	Tags        []string  `gorm:"serializer:json"`
+	ExternalID  *string   `gorm:"index"`
+	CreatedAt   time.Time `gorm:"not null;index"`
+	UpdatedAt   time.Time `gorm:"not null"`
+	ArchivedAt  time.Time `gorm:"not null;index"`
+}
+
+func (baselineArchivedTransaction) TableName() string { return "archived_transactions" }
+
+type baselineExchangeRate struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	FromCurrency  string    `gorm:"not null"`
+	ToCurrency    string    `gorm:"not null"`
+	Rate          float64   `gorm:"not null"`
+	EffectiveDate time.Time `gorm:"not null"`
+	RecordDate    time.Time `gorm:"not null"`
+	CreatedAt     time.Time
+}
+
+func (baselineExchangeRate) TableName() string { return "exchange_rates" }
+
+type baselineRateQuote struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	FromCurrency  string    `gorm:"not null"`
+	ToCurrency    string    `gorm:"not null"`
+	Rate          float64   `gorm:"not null"`
+	EffectiveDate time.Time `gorm:"not null"`
+	QuotedDate    time.Time `gorm:"not null"`
+	ExpiresAt     time.Time `gorm:"not null;index"`
+	CreatedAt     time.Time
+}
+
+func (baselineRateQuote) TableName() string { return "rate_quotes" }
+
+type baselineConversionRecord struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TransactionID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	BatchID         *uuid.UUID `gorm:"type:uuid;index"`
+	TargetCurrency  string     `gorm:"not null;index"`
+	TransactionDate time.Time  `gorm:"not null"`
+	OriginalAmount  int64      `gorm:"not null"`
+	ExchangeRate    float64    `gorm:"not null"`
+	EffectiveDate   time.Time  `gorm:"not null"`
+	ConvertedAmount int64      `gorm:"not null"`
+	CreatedAt       time.Time
+	SupersededAt    *time.Time `gorm:"index"`
+	SupersededBy    *uuid.UUID `gorm:"type:uuid"`
+}
+
+func (baselineConversionRecord) TableName() string { return "conversion_records" }
+
+type baselineConversionBatch struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TargetCurrency string    `gorm:"not null"`
+	FromDate       time.Time `gorm:"not null"`
+	ToDate         time.Time `gorm:"not null"`
+	Status         string    `gorm:"not null;index"`
+	Total          int
+	Processed      int
+	Succeeded      int
+	Failed         int
+	Error          string
+	CreatedAt      time.Time
+	StartedAt      *time.Time
+	CompletedAt    *time.Time
+}
+
+func (baselineConversionBatch) TableName() string { return "conversion_batches" }
+
+type baselineBudget struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Category   string    `gorm:"not null;index"`
+	Period     string    `gorm:"not null"`
+	Limit      int64     `gorm:"column:limit_amount;not null"`
+	Currency   string    `gorm:"not null"`
+	Thresholds []int     `gorm:"serializer:json"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (baselineBudget) TableName() string { return "budgets" }
+
+type baselineCategory struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name        string    `gorm:"not null;uniqueIndex"`
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (baselineCategory) TableName() string { return "categories" }
+
+type baselineRateSubscription struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Subscriber   string    `gorm:"not null;uniqueIndex:idx_rate_subscriptions_subscriber_currency"`
+	Currency     string    `gorm:"not null;index;uniqueIndex:idx_rate_subscriptions_subscriber_currency"`
+	LastSyncedAt *time.Time
+	LastError    string
+	CreatedAt    time.Time
+}
+
+func (baselineRateSubscription) TableName() string { return "rate_subscriptions" }
+
+type baselineAPIToken struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name         string    `gorm:"not null"`
+	Prefix       string    `gorm:"not null"`
+	TokenHash    string    `gorm:"not null;uniqueIndex"`
+	Role         string    `gorm:"not null"`
+	ExpiresAt    *time.Time
+	RevokedAt    *time.Time
+	LastUsedAt   *time.Time
+	ReplacedByID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt    time.Time
+}
+
+func (baselineAPIToken) TableName() string { return "api_tokens" }
+
+type baselineIdempotencyKey struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Scope         string     `gorm:"not null;uniqueIndex:idx_idempotency_keys_scope_key"`
+	Key           string     `gorm:"column:idempotency_key;not null;uniqueIndex:idx_idempotency_keys_scope_key"`
+	RequestHash   string     `gorm:"not null"`
+	TransactionID *uuid.UUID `gorm:"type:uuid"`
+	Response      string
+	ExpiresAt     time.Time `gorm:"not null;index"`
+	CreatedAt     time.Time
+}
+
+func (baselineIdempotencyKey) TableName() string { return "idempotency_keys" }
+
+type baselineWebhook struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	URL       string    `gorm:"not null"`
+	Events    []string  `gorm:"serializer:json"`
+	Secret    string    `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+func (baselineWebhook) TableName() string { return "webhooks" }
+
+type baselineWebhookDelivery struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	WebhookID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	EventID        uuid.UUID `gorm:"type:uuid;not null"`
+	Event          string    `gorm:"not null"`
+	Payload        string    `gorm:"not null"`
+	Status         string    `gorm:"not null;index"`
+	Attempts       int
+	NextAttemptAt  *time.Time `gorm:"index"`
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+func (baselineWebhookDelivery) TableName() string { return "webhook_deliveries" }
+
+type baselineOutboxMessage struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Topic         string    `gorm:"not null"`
+	Event         string    `gorm:"not null"`
+	Payload       string    `gorm:"not null"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null;index"`
+	LastError     string
+	PublishedAt   *time.Time `gorm:"index"`
+	CreatedAt     time.Time
+}
+
+func (baselineOutboxMessage) TableName() string { return "outbox_messages" }
+
+type baselineAuditLog struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EntityType string    `gorm:"not null;index:idx_audit_logs_entity"`
+	EntityID   uuid.UUID `gorm:"type:uuid;not null;index:idx_audit_logs_entity"`
+	Action     string    `gorm:"not null"`
+	Actor      string    `gorm:"not null"`
+	RequestID  string
+	Changes    string    `gorm:"type:text"`
+	CreatedAt  time.Time `gorm:"not null;index"`
+}
+
+func (baselineAuditLog) TableName() string { return "audit_logs" }
+
+// baselineModels are the tables of the schema before versioned migrations, in creation order
+func baselineModels() []interface{} {
+	return []interface{}{
+		&baselineTransaction{},
+		&baselineArchivedTransaction{},
+		&baselineExchangeRate{},
+		&baselineRateQuote{},
+		&baselineConversionRecord{},
+		&baselineConversionBatch{},
+		&baselineBudget{},
+		&baselineCategory{},
+		&baselineRateSubscription{},
+		&baselineAPIToken{},
+		&baselineIdempotencyKey{},
+		&baselineWebhook{},
+		&baselineWebhookDelivery{},
+		&baselineOutboxMessage{},
+		&baselineAuditLog{},
+	}
+}
+
+// initialSchema creates every table; databases created by the former AutoMigrate at startup
+// already have them, so applying it there only records the baseline version
+var initialSchema = Migration{
+	Version: 1,
+	Name:    "initial_schema",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(baselineModels()...)
+	},
+	Down: func(tx *gorm.DB) error {
+		models := baselineModels()
+		for i := len(models) - 1; i >= 0; i-- {
+			if err := tx.Migrator().DropTable(models[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
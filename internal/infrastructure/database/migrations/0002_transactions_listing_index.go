@@ -0,0 +1,21 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// transactionsListingIndex matches the listing order, so cursor pages seek straight to their first row
+const transactionsListingIndex = "idx_transactions_created_at_id"
+
+// transactionsListingIndexMigration indexes transactions by (created_at, id) for keyset pagination
+var transactionsListingIndexMigration = Migration{
+	Version: 2,
+	Name:    "transactions_listing_index",
+	Up: func(tx *gorm.DB) error {
+		if tx.Migrator().HasIndex("transactions", transactionsListingIndex) {
+			return nil
+		}
+		return tx.Exec("CREATE INDEX " + transactionsListingIndex + " ON transactions (created_at, id)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropIndex("transactions", transactionsListingIndex)
+	},
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// transactionsVersionColumn snapshots the column added by the migration
+type transactionsVersionColumn struct {
+	Version int64 `gorm:"not null;default:1"`
+}
+
+func (transactionsVersionColumn) TableName() string { return "transactions" }
+
+// transactionsVersionMigration adds the version column used for optimistic locking; existing rows start at 1
+var transactionsVersionMigration = Migration{
+	Version: 3,
+	Name:    "transactions_version",
+	Up: func(tx *gorm.DB) error {
+		if tx.Migrator().HasColumn(&transactionsVersionColumn{}, "Version") {
+			return nil
+		}
+		return tx.Migrator().AddColumn(&transactionsVersionColumn{}, "Version")
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropColumn(tx, "transactions", "version")
+	},
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// conversionsTable snapshots the conversions table created by the migration
+type conversionsTable struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TransactionID   uuid.UUID  `gorm:"type:uuid;not null;index:idx_conversions_transaction"`
+	TargetCurrency  string     `gorm:"not null"`
+	RawExchangeRate float64    `gorm:"not null"`
+	MarginBps       int        `gorm:"not null"`
+	ExchangeRate    float64    `gorm:"not null"`
+	EffectiveDate   time.Time  `gorm:"not null"`
+	ConvertedAmount int64      `gorm:"not null"`
+	QuoteID         *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt       time.Time  `gorm:"not null;index:idx_conversions_transaction"`
+}
+
+func (conversionsTable) TableName() string { return "conversions" }
+
+// conversionsMigration creates the conversions table holding the conversion history of each transaction
+var conversionsMigration = Migration{
+	Version: 4,
+	Name:    "conversions",
+	Up: func(tx *gorm.DB) error {
+		if tx.Migrator().HasTable(&conversionsTable{}) {
+			return nil
+		}
+		return tx.Migrator().CreateTable(&conversionsTable{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&conversionsTable{})
+	},
+}
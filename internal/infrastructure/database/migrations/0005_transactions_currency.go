@@ -0,0 +1,25 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// transactionsCurrencyColumn snapshots the column added by the migration
+type transactionsCurrencyColumn struct {
+	Currency string `gorm:"size:3;not null;default:USD"`
+}
+
+func (transactionsCurrencyColumn) TableName() string { return "transactions" }
+
+// transactionsCurrencyMigration adds the currency a transaction was paid in; existing rows are USD
+var transactionsCurrencyMigration = Migration{
+	Version: 5,
+	Name:    "transactions_currency",
+	Up: func(tx *gorm.DB) error {
+		if tx.Migrator().HasColumn(&transactionsCurrencyColumn{}, "Currency") {
+			return nil
+		}
+		return tx.Migrator().AddColumn(&transactionsCurrencyColumn{}, "Currency")
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropColumn(tx, "transactions", "currency")
+	},
+}
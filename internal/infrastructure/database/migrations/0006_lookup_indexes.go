@@ -0,0 +1,34 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// exchangeRatesLookupIndex matches the conversion lookup, which takes the latest rate of a pair on or before a date
+const exchangeRatesLookupIndex = "idx_exchange_rates_pair_effective_date"
+
+// transactionsLiveListingIndex matches listings of transactions that are not soft-deleted
+// idx_transactions_created_at_id alone loses to the deleted_at index, leaving every live row to be sorted
+const transactionsLiveListingIndex = "idx_transactions_live_created_at_id"
+
+// lookupIndexesMigration indexes exchange rates by (from_currency, to_currency, effective_date DESC)
+// and transactions by (deleted_at, created_at, id), so rate lookups and listing pages read rows in index order
+var lookupIndexesMigration = Migration{
+	Version: 6,
+	Name:    "lookup_indexes",
+	Up: func(tx *gorm.DB) error {
+		if !tx.Migrator().HasIndex("exchange_rates", exchangeRatesLookupIndex) {
+			if err := tx.Exec("CREATE INDEX " + exchangeRatesLookupIndex + " ON exchange_rates (from_currency, to_currency, effective_date DESC)").Error; err != nil {
+				return err
+			}
+		}
+		if tx.Migrator().HasIndex("transactions", transactionsLiveListingIndex) {
+			return nil
+		}
+		return tx.Exec("CREATE INDEX " + transactionsLiveListingIndex + " ON transactions (deleted_at, created_at, id)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := tx.Migrator().DropIndex("transactions", transactionsLiveListingIndex); err != nil {
+			return err
+		}
+		return tx.Migrator().DropIndex("exchange_rates", exchangeRatesLookupIndex)
+	},
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// archivedTransactionsCurrencyVersionColumns snapshots the columns added by the migration
+type archivedTransactionsCurrencyVersionColumns struct {
+	Currency string `gorm:"size:3;not null;default:USD"`
+	Version  int64  `gorm:"not null;default:1"`
+}
+
+func (archivedTransactionsCurrencyVersionColumns) TableName() string { return "archived_transactions" }
+
+// archivedTransactionsCurrencyVersionMigration adds the currency and version of archived transactions,
+// which transactions gained in migrations 3 and 5; rows archived before are USD at version 1
+var archivedTransactionsCurrencyVersionMigration = Migration{
+	Version: 7,
+	Name:    "archived_transactions_currency_version",
+	Up: func(tx *gorm.DB) error {
+		for _, column := range []string{"Currency", "Version"} {
+			if tx.Migrator().HasColumn(&archivedTransactionsCurrencyVersionColumns{}, column) {
+				continue
+			}
+			if err := tx.Migrator().AddColumn(&archivedTransactionsCurrencyVersionColumns{}, column); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := dropColumn(tx, "archived_transactions", "version"); err != nil {
+			return err
+		}
+		return dropColumn(tx, "archived_transactions", "currency")
+	},
+}
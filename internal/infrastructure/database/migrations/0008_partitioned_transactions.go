@@ -0,0 +1,122 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// partitionedTransactionsMigration turns transactions into a table range-partitioned by month of purchase date
+// It is optional: PostgreSQL databases apply it when DB_PARTITION_TRANSACTIONS is set, and no other driver does
+var partitionedTransactionsMigration = Migration{
+	Version: 8,
+	Name:    "partitioned_transactions",
+	Up: func(tx *gorm.DB) error {
+		return repartitionTransactions(tx, true)
+	},
+	Down: func(tx *gorm.DB) error {
+		return repartitionTransactions(tx, false)
+	},
+}
+
+// PartitionedTransactions returns the optional migration partitioning the transactions table by month
+func PartitionedTransactions() Migration {
+	return partitionedTransactionsMigration
+}
+
+// TransactionPartitionName returns the partition holding transactions dated in month (UTC)
+func TransactionPartitionName(month time.Time) string {
+	return fmt.Sprintf("transactions_p%04d_%02d", month.Year(), int(month.Month()))
+}
+
+// CreateTransactionPartition creates the partition covering month (UTC) if it does not exist
+func CreateTransactionPartition(tx *gorm.DB, month time.Time) error {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	statement := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF transactions FOR VALUES FROM ('%s') TO ('%s')",
+		TransactionPartitionName(start), start.Format(time.RFC3339), end.Format(time.RFC3339))
+	return tx.Exec(statement).Error
+}
+
+// repartitionTransactions rebuilds transactions partitioned or not, copying its rows
+// Columns, defaults and indexes are taken from the table as it is, so the columns added by any migration are kept
+// The partition key must be part of the primary key, so partitioned tables use (id, date) instead of id
+func repartitionTransactions(tx *gorm.DB, partitioned bool) error {
+	if tx.Dialector.Name() != "postgres" {
+		return errors.New("transactions can only be partitioned on PostgreSQL")
+	}
+
+	var kind string
+	if err := tx.Raw("SELECT relkind FROM pg_class WHERE oid = to_regclass('transactions')").Scan(&kind).Error; err != nil {
+		return err
+	}
+	if kind == "" {
+		return errors.New("transactions table does not exist")
+	}
+	if (kind == "p") == partitioned {
+		return nil // Already in shape, e.g. partitioned before partitioning was a migration
+	}
+
+	// The old primary key is dropped so the new one can take the usual name, and index definitions
+	// name the table, so both are read before the table is renamed; the indexes are replayed on the new table
+	var primaryKey string
+	err := tx.Raw("SELECT conname FROM pg_constraint WHERE conrelid = 'transactions'::regclass AND contype = 'p'").Scan(&primaryKey).Error
+	if err != nil {
+		return err
+	}
+	var indexes []string
+	err = tx.Raw("SELECT indexdef FROM pg_indexes WHERE schemaname = current_schema() AND tablename = 'transactions' AND indexname <> ?", primaryKey).
+		Scan(&indexes).Error
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Exec("ALTER TABLE transactions RENAME TO transactions_previous").Error; err != nil {
+		return err
+	}
+	if primaryKey != "" {
+		if err := tx.Exec("ALTER TABLE transactions_previous DROP CONSTRAINT ?", clause.Column{Name: primaryKey}).Error; err != nil {
+			return err
+		}
+	}
+
+	create := "CREATE TABLE transactions (LIKE transactions_previous INCLUDING DEFAULTS INCLUDING CONSTRAINTS, PRIMARY KEY (id))"
+	if partitioned {
+		create = "CREATE TABLE transactions (LIKE transactions_previous INCLUDING DEFAULTS INCLUDING CONSTRAINTS, PRIMARY KEY (id, date)) PARTITION BY RANGE (date)"
+	}
+	if err := tx.Exec(create).Error; err != nil {
+		return err
+	}
+
+	if partitioned {
+		var months []time.Time
+		err := tx.Raw("SELECT DISTINCT date_trunc('month', date AT TIME ZONE 'UTC') FROM transactions_previous").Scan(&months).Error
+		if err != nil {
+			return err
+		}
+		for _, month := range months {
+			if err := CreateTransactionPartition(tx, month); err != nil {
+				return err
+			}
+		}
+	}
+
+	// LIKE keeps the column order, so rows copy over as they are
+	if err := tx.Exec("INSERT INTO transactions SELECT * FROM transactions_previous").Error; err != nil {
+		return err
+	}
+	// Dropping a partitioned table drops its partitions; dropping either frees the index names
+	if err := tx.Exec("DROP TABLE transactions_previous").Error; err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if err := tx.Exec(index).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// transactionsDescriptionPrefixIndex matches description suggestions, which compare descriptions ignoring case
+const transactionsDescriptionPrefixIndex = "idx_transactions_description_prefix"
+
+// transactionsDescriptionPrefixMigration indexes transactions by description ignoring case, so prefix
+// suggestions seek the index on every driver. The plain description index cannot serve them: PostgreSQL only
+// uses an index for LIKE with text_pattern_ops, and SQLite's LIKE ignores case, which needs a NOCASE index
+var transactionsDescriptionPrefixMigration = Migration{
+	Version: 9,
+	Name:    "transactions_description_prefix_index",
+	Up: func(tx *gorm.DB) error {
+		if tx.Migrator().HasIndex("transactions", transactionsDescriptionPrefixIndex) {
+			return nil
+		}
+
+		switch tx.Dialector.Name() {
+		case "postgres":
+			return tx.Exec("CREATE INDEX " + transactionsDescriptionPrefixIndex + " ON transactions (lower(description) text_pattern_ops)").Error
+		case "mysql":
+			return tx.Exec("CREATE INDEX " + transactionsDescriptionPrefixIndex + " ON transactions ((lower(description)))").Error
+		default:
+			return tx.Exec("CREATE INDEX " + transactionsDescriptionPrefixIndex + " ON transactions (description COLLATE NOCASE)").Error
+		}
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropIndex("transactions", transactionsDescriptionPrefixIndex)
+	},
+}
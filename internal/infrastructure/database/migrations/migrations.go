@@ -0,0 +1,34 @@
+// Package migrations holds the versioned schema of the SQL databases and the migrator that applies it.
+//
+// To change the schema, add a file named NNNN_description.go declaring the next Migration and list it in All.
+// Applied migrations must never be edited: production databases record their version in schema_migrations
+// and will not run a changed migration again. Migrations must not depend on the entity structs for the DDL they
+// run, since those keep changing; declare a snapshot of the columns in the migration file, as the baseline does.
+// Databases created by the former AutoMigrate at startup may already hold later changes, so migrations must
+// tolerate the change being present already (check HasColumn/HasIndex first).
+package migrations
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// All returns the application's migrations in version order
+// Version 8 is taken by PartitionedTransactions, which PostgreSQL databases add when partitioning is enabled
+func All() []Migration {
+	return []Migration{
+		initialSchema,
+		transactionsListingIndexMigration,
+		transactionsVersionMigration,
+		conversionsMigration,
+		transactionsCurrencyMigration,
+		lookupIndexesMigration,
+		archivedTransactionsCurrencyVersionMigration,
+		transactionsDescriptionPrefixMigration,
+	}
+}
+
+// dropColumn drops a column in place; gorm's SQLite migrator rebuilds the table instead, losing its indexes
+func dropColumn(tx *gorm.DB, table, column string) error {
+	return tx.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: table}, clause.Column{Name: column}).Error
+}
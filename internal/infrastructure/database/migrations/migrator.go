@@ -0,0 +1,241 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Locks that serialize migrations of concurrently starting PostgreSQL and MySQL instances
+const (
+	advisoryLockID   = 7_362_001
+	advisoryLockName = "purchase_transaction_api_migrations"
+)
+
+// Migration is one versioned schema change; Down reverts Up and is nil when the change cannot be reverted
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// Status describes a known migration and when it was applied; AppliedAt is nil while it is pending
+type Status struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// schemaMigration records an applied migration in the schema_migrations table
+type schemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator applies and reverts migrations, recording each applied version in schema_migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New creates a Migrator for the application schema
+func New(db *gorm.DB) *Migrator {
+	return NewMigrator(db, All())
+}
+
+// NewMigrator creates a Migrator for the given migrations, which are applied in version order
+func NewMigrator(db *gorm.DB, migrations []Migration) *Migrator {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return &Migrator{db: db, migrations: sorted}
+}
+
+// Up applies every pending migration in version order, each in its own transaction, and returns those applied
+func (m *Migrator) Up() ([]Migration, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		ran, err := m.run(migration, true)
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		if ran {
+			done = append(done, migration)
+		}
+	}
+	return done, nil
+}
+
+// Down reverts the latest steps applied migrations, most recent first, and returns those reverted
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == nil {
+			return done, fmt.Errorf("migration %04d_%s cannot be reverted", migration.Version, migration.Name)
+		}
+
+		ran, err := m.run(migration, false)
+		if err != nil {
+			return done, fmt.Errorf("reverting migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		if ran {
+			done = append(done, migration)
+		}
+	}
+	return done, nil
+}
+
+// Status lists every known migration in version order with when it was applied
+func (m *Migrator) Status() ([]Status, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// Pending returns the migrations not applied yet, in version order
+func (m *Migrator) Pending() ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for i, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, m.migrations[i])
+		}
+	}
+	return pending, nil
+}
+
+// validate rejects migration lists with missing functions or duplicate versions
+func (m *Migrator) validate() error {
+	for i, migration := range m.migrations {
+		if migration.Version < 1 || migration.Name == "" || migration.Up == nil {
+			return fmt.Errorf("migration %d is missing its version, name or Up function", migration.Version)
+		}
+		if i > 0 && m.migrations[i-1].Version == migration.Version {
+			return fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+	}
+	return nil
+}
+
+// applied returns the recorded migrations by version, creating the schema_migrations table on first use
+func (m *Migrator) applied() (map[int]schemaMigration, error) {
+	if err := m.db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var records []schemaMigration
+	if err := m.db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	applied := make(map[int]schemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// run applies or reverts a migration together with its schema_migrations record
+// It reports false when another instance got there first, which the advisory lock makes visible
+// MySQL commits DDL implicitly, so there a failed migration can leave its completed statements behind
+func (m *Migrator) run(migration Migration, up bool) (bool, error) {
+	ran := false
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		switch tx.Dialector.Name() {
+		case "postgres":
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", advisoryLockID).Error; err != nil {
+				return err
+			}
+		case "mysql":
+			// GET_LOCK belongs to the session rather than the transaction, so it is released explicitly
+			var locked int
+			if err := tx.Raw("SELECT GET_LOCK(?, 60)", advisoryLockName).Scan(&locked).Error; err != nil {
+				return err
+			}
+			if locked != 1 {
+				return errors.New("timed out waiting for the migration lock")
+			}
+			defer tx.Exec("SELECT RELEASE_LOCK(?)", advisoryLockName)
+		}
+
+		var recorded int64
+		if err := tx.Model(&schemaMigration{}).Where("version = ?", migration.Version).Count(&recorded).Error; err != nil {
+			return err
+		}
+		if up == (recorded > 0) {
+			return nil // Already applied, or already reverted
+		}
+
+		if up {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			ran = true
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}).Error
+		}
+
+		if err := migration.Down(tx); err != nil {
+			return err
+		}
+		ran = true
+		return tx.Delete(&schemaMigration{}, "version = ?", migration.Version).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return ran, nil
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database/migrations"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// MySQLDB wraps GORM database connection for MySQL and MariaDB
+type MySQLDB struct {
+	DB *gorm.DB
+}
+
+// NewMySQLDB creates a new MySQL or MariaDB connection from a go-sql-driver DSN (user:pass@tcp(host:3306)/dbname)
+// Timestamps are always parsed into time.Time and stored in UTC, whatever the DSN says; call Migrate to bring the schema up to date
+func NewMySQLDB(dsn string, pool PoolConfig) (*MySQLDB, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("mysql DSN is required")
+	}
+
+	driverConfig, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mysql DSN: %w", err)
+	}
+	driverConfig.ParseTime = true
+	driverConfig.Loc = time.UTC
+
+	db, err := gorm.Open(mysqlDialector{mysql.New(mysql.Config{DSNConfig: driverConfig}).(*mysql.Dialector)}, &gorm.Config{
+		Logger: NewSQLLogger(defaultSQLLogConfig),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MySQL database: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	pool.Apply(sqlDB)
+
+	return &MySQLDB{
+		DB: db,
+	}, nil
+}
+
+// Migrations returns the application's migrations; MySQL has no optional migrations
+func (m *MySQLDB) Migrations() []migrations.Migration {
+	return migrations.All()
+}
+
+// Migrate applies the pending versioned migrations
+func (m *MySQLDB) Migrate() error {
+	if _, err := migrations.NewMigrator(m.DB, m.Migrations()).Up(); err != nil {
+		return fmt.Errorf("failed to run database migrations: %w", err)
+	}
+	return nil
+}
+
+// Close closes the database connection
+func (m *MySQLDB) Close() error {
+	sqlDB, err := m.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// Ping verifies the database connection is alive
+func (m *MySQLDB) Ping(ctx context.Context) error {
+	sqlDB, err := m.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Size returns the data and index size of the current database in bytes
+func (m *MySQLDB) Size(ctx context.Context) (int64, error) {
+	var size int64
+	err := m.DB.WithContext(ctx).
+		Raw("SELECT COALESCE(SUM(data_length + index_length), 0) FROM information_schema.tables WHERE table_schema = DATABASE()").
+		Scan(&size).Error
+	return size, err
+}
+
+// Pools returns the connection pool for statistics
+func (m *MySQLDB) Pools() []NamedPool {
+	return primaryPool(m.DB)
+}
+
+// GetDB returns the underlying GORM database instance
+func (m *MySQLDB) GetDB() *gorm.DB {
+	return m.DB
+}
+
+// mysqlDialector maps the column types the entities declare for SQLite and PostgreSQL onto MySQL:
+// uuid becomes char(36) and uniquely indexed strings get a length, since MySQL cannot index TEXT
+// The GORM dialector already sizes strings with a plain index the same way
+type mysqlDialector struct {
+	*mysql.Dialector
+}
+
+// DataTypeOf implements gorm.Dialector
+func (d mysqlDialector) DataTypeOf(field *schema.Field) string {
+	if strings.EqualFold(string(field.DataType), "uuid") {
+		return "char(36)"
+	}
+	if field.DataType == schema.String && field.Size == 0 && field.TagSettings["UNIQUEINDEX"] != "" {
+		return "varchar(191)"
+	}
+	return d.Dialector.DataTypeOf(field)
+}
+
+// Migrator implements gorm.Dialector so schema changes use the mapped column types
+func (d mysqlDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	migrator := d.Dialector.Migrator(db).(mysql.Migrator)
+	migrator.Migrator.Config.Dialector = d
+	return migrator
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// sqliteOutboxRepository implements OutboxRepository interface using GORM
+type sqliteOutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new GORM implementation of OutboxRepository
+func NewOutboxRepository(db *gorm.DB) repositories.OutboxRepository {
+	return &sqliteOutboxRepository{
+		db: db,
+	}
+}
+
+// Save persists a new outbox message
+func (r *sqliteOutboxRepository) Save(message *entities.OutboxMessage) error {
+	if message == nil {
+		return errors.New("outbox message cannot be nil")
+	}
+
+	return r.db.Create(message).Error
+}
+
+// ClaimDue leases due unpublished messages, oldest first
+// Each row is claimed with a conditional update, so a row another worker claimed in between is skipped
+func (r *sqliteOutboxRepository) ClaimDue(now time.Time, leaseUntil time.Time, limit int) ([]entities.OutboxMessage, error) {
+	var due []entities.OutboxMessage
+
+	result := UsePrimary(r.db).
+		Where("published_at IS NULL AND next_attempt_at <= ?", now).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&due)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	claimed := make([]entities.OutboxMessage, 0, len(due))
+	for _, message := range due {
+		update := r.db.Model(&entities.OutboxMessage{}).
+			Where("id = ? AND published_at IS NULL AND next_attempt_at = ?", message.ID, message.NextAttemptAt).
+			Update("next_attempt_at", leaseUntil)
+		if update.Error != nil {
+			return nil, update.Error
+		}
+		if update.RowsAffected == 1 {
+			message.NextAttemptAt = leaseUntil
+			claimed = append(claimed, message)
+		}
+	}
+
+	return claimed, nil
+}
+
+// MarkPublished records that the broker accepted the message
+func (r *sqliteOutboxRepository) MarkPublished(id uuid.UUID, publishedAt time.Time) error {
+	result := r.db.Model(&entities.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"published_at": publishedAt,
+		"attempts":     gorm.Expr("attempts + 1"),
+		"last_error":   "",
+	})
+	return outboxUpdateResult(result, id)
+}
+
+// MarkFailed records a failed attempt and when the message is due again
+func (r *sqliteOutboxRepository) MarkFailed(id uuid.UUID, reason string, retryAt time.Time) error {
+	result := r.db.Model(&entities.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"next_attempt_at": retryAt,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      reason,
+	})
+	return outboxUpdateResult(result, id)
+}
+
+// Reschedule makes a claimed message due again at without counting an attempt
+func (r *sqliteOutboxRepository) Reschedule(id uuid.UUID, at time.Time) error {
+	result := r.db.Model(&entities.OutboxMessage{}).Where("id = ?", id).Update("next_attempt_at", at)
+	return outboxUpdateResult(result, id)
+}
+
+// CountPending counts the messages not yet published
+func (r *sqliteOutboxRepository) CountPending() (int64, error) {
+	var count int64
+	result := UsePrimary(r.db).Model(&entities.OutboxMessage{}).Where("published_at IS NULL").Count(&count)
+	return count, result.Error
+}
+
+// DeletePublishedBefore removes messages published before cutoff
+func (r *sqliteOutboxRepository) DeletePublishedBefore(cutoff time.Time) (int64, error) {
+	result := r.db.Where("published_at IS NOT NULL AND published_at < ?", cutoff).Delete(&entities.OutboxMessage{})
+	return result.RowsAffected, result.Error
+}
+
+// outboxUpdateResult maps an update that touched no row to ErrNotFound
+func outboxUpdateResult(result *gorm.DB, id uuid.UUID) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.Newf(errs.ErrNotFound, "outbox message with ID %s not found", id)
+	}
+	return nil
+}
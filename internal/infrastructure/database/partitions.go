@@ -0,0 +1,85 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database/migrations"
+	"gorm.io/gorm"
+)
+
+// transactionPartitionsName identifies the partition manager among the plugins registered on a *gorm.DB
+const transactionPartitionsName = "transaction_partitions"
+
+// transactionPartitions is a GORM plugin that creates the monthly partition a transaction belongs to before it is written
+// The table itself is partitioned by the optional partitioned_transactions migration
+type transactionPartitions struct {
+	mu      sync.Mutex
+	created map[string]bool // Partitions known to exist, by table name
+}
+
+// Name implements gorm.Plugin
+func (p *transactionPartitions) Name() string {
+	return transactionPartitionsName
+}
+
+// Initialize implements gorm.Plugin by hooking inserts and updates of transactions
+func (p *transactionPartitions) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("transaction_partitions:create", p.ensure); err != nil {
+		return err
+	}
+	return db.Callback().Update().Before("gorm:update").Register("transaction_partitions:update", p.ensure)
+}
+
+// ensure creates the partitions for every transaction date the statement is about to write
+func (p *transactionPartitions) ensure(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.Table != "transactions" {
+		return
+	}
+
+	field := db.Statement.Schema.LookUpField("Date")
+	if field == nil {
+		return
+	}
+
+	var months []time.Time
+	collect := func(value reflect.Value) {
+		if date, isZero := field.ValueOf(db.Statement.Context, value); !isZero {
+			if t, ok := date.(time.Time); ok {
+				months = append(months, t.UTC())
+			}
+		}
+	}
+
+	switch db.Statement.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < db.Statement.ReflectValue.Len(); i++ {
+			collect(reflect.Indirect(db.Statement.ReflectValue.Index(i)))
+		}
+	case reflect.Struct:
+		collect(db.Statement.ReflectValue)
+	}
+
+	// Partitions created inside a transaction vanish on rollback, so only remember committed ones
+	_, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter)
+	session := db.Session(&gorm.Session{NewDB: true})
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, month := range months {
+		name := migrations.TransactionPartitionName(month)
+		if p.created[name] {
+			continue
+		}
+		if err := migrations.CreateTransactionPartition(session, month); err != nil {
+			_ = db.AddError(fmt.Errorf("failed to create partition %s: %w", name, err))
+			return
+		}
+		if !inTransaction {
+			p.created[name] = true
+		}
+	}
+}
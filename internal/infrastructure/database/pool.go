@@ -0,0 +1,42 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PoolConfig sizes the connection pool of a database connection
+type PoolConfig struct {
+	MaxOpenConns    int           // Zero leaves the number of open connections unlimited
+	MaxIdleConns    int           // Connections kept open between requests
+	ConnMaxLifetime time.Duration // Zero never recycles connections by age
+	ConnMaxIdleTime time.Duration // Zero never closes idle connections by age
+}
+
+// NamedPool is a connection pool with the name its statistics are reported under
+type NamedPool struct {
+	Name string
+	DB   *sql.DB
+}
+
+// PoolPrimary names the pool of the primary connection; replicas are replica-1, replica-2, ...
+const PoolPrimary = "primary"
+
+// primaryPool returns the pool behind db
+func primaryPool(db *gorm.DB) []NamedPool {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil
+	}
+	return []NamedPool{{Name: PoolPrimary, DB: sqlDB}}
+}
+
+// Apply sets the pool limits on sqlDB
+func (c PoolConfig) Apply(sqlDB *sql.DB) {
+	sqlDB.SetMaxOpenConns(c.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(c.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(c.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database/migrations"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// PostgresDB wraps GORM database connection for PostgreSQL
+type PostgresDB struct {
+	DB                    *gorm.DB
+	pool                  PoolConfig
+	replicas              []*sql.DB
+	partitionTransactions bool
+}
+
+// NewPostgresDB creates a new PostgreSQL database connection from a DSN
+// With partitionTransactions the migrations partition the transactions table by month of purchase date
+// pool sizes the connection pool of the primary and of any replicas added later; call Migrate to bring the schema up to date
+func NewPostgresDB(dsn string, partitionTransactions bool, pool PoolConfig) (*PostgresDB, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("postgres DSN is required")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: NewSQLLogger(defaultSQLLogConfig),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to PostgreSQL database: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	pool.Apply(sqlDB)
+
+	postgresDB := &PostgresDB{
+		DB:                    db,
+		pool:                  pool,
+		partitionTransactions: partitionTransactions,
+	}
+
+	if partitionTransactions {
+		if err := db.Use(&transactionPartitions{created: make(map[string]bool)}); err != nil {
+			return nil, err
+		}
+	}
+
+	return postgresDB, nil
+}
+
+// Migrations returns the application's migrations, plus the partitioning of transactions when it is enabled
+func (p *PostgresDB) Migrations() []migrations.Migration {
+	if p.partitionTransactions {
+		return append(migrations.All(), migrations.PartitionedTransactions())
+	}
+	return migrations.All()
+}
+
+// Migrate applies the pending versioned migrations
+func (p *PostgresDB) Migrate() error {
+	if _, err := migrations.NewMigrator(p.DB, p.Migrations()).Up(); err != nil {
+		return fmt.Errorf("failed to run database migrations: %w", err)
+	}
+	return nil
+}
+
+// UseReplicas connects to the read replicas and routes queries to them
+// Reads within stickyWindow of a write stay on the primary so callers see their own changes
+func (p *PostgresDB) UseReplicas(dsns []string, stickyWindow time.Duration) error {
+	pools := make([]gorm.ConnPool, 0, len(dsns))
+	for i, dsn := range dsns {
+		replica, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+			Logger: NewSQLLogger(defaultSQLLogConfig),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to connect to PostgreSQL replica %d: %w", i+1, err)
+		}
+
+		sqlDB, err := replica.DB()
+		if err != nil {
+			return err
+		}
+		p.pool.Apply(sqlDB)
+		p.replicas = append(p.replicas, sqlDB)
+		pools = append(pools, sqlDB)
+	}
+
+	return RouteReadsToReplicas(p.DB, pools, stickyWindow)
+}
+
+// Close closes the primary and replica connections
+func (p *PostgresDB) Close() error {
+	sqlDB, err := p.DB.DB()
+	if err != nil {
+		return err
+	}
+
+	errs := []error{sqlDB.Close()}
+	for _, replica := range p.replicas {
+		errs = append(errs, replica.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Ping verifies the primary and replica connections are alive
+func (p *PostgresDB) Ping(ctx context.Context) error {
+	sqlDB, err := p.DB.DB()
+	if err != nil {
+		return err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return err
+	}
+
+	for i, replica := range p.replicas {
+		if err := replica.PingContext(ctx); err != nil {
+			return fmt.Errorf("replica %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Size returns the on-disk size of the current database in bytes
+func (p *PostgresDB) Size(ctx context.Context) (int64, error) {
+	var size int64
+	err := UsePrimary(p.DB).WithContext(ctx).Raw("SELECT pg_database_size(current_database())").Scan(&size).Error
+	return size, err
+}
+
+// Pools returns the connection pools of the primary and of each replica for statistics
+func (p *PostgresDB) Pools() []NamedPool {
+	pools := primaryPool(p.DB)
+	for i, replica := range p.replicas {
+		pools = append(pools, NamedPool{Name: fmt.Sprintf("replica-%d", i+1), DB: replica})
+	}
+	return pools
+}
+
+// GetDB returns the underlying GORM database instance
+func (p *PostgresDB) GetDB() *gorm.DB {
+	return p.DB
+}
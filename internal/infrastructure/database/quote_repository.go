@@ -0,0 +1,57 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// sqliteQuoteRepository implements QuoteRepository interface using GORM
+type sqliteQuoteRepository struct {
+	db *gorm.DB
+}
+
+// NewQuoteRepository creates a new GORM implementation of QuoteRepository
+func NewQuoteRepository(db *gorm.DB) repositories.QuoteRepository {
+	return &sqliteQuoteRepository{
+		db: db,
+	}
+}
+
+// Save persists a rate quote to the database
+func (r *sqliteQuoteRepository) Save(quote *entities.RateQuote) error {
+	if quote == nil {
+		return errors.New("quote cannot be nil")
+	}
+
+	return r.db.Create(quote).Error
+}
+
+// GetByID retrieves a rate quote by its unique identifier
+func (r *sqliteQuoteRepository) GetByID(id uuid.UUID) (*entities.RateQuote, error) {
+	var quote entities.RateQuote
+
+	result := r.db.Where("id = ?", id).First(&quote)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil, nil when not found (as per interface contract)
+		}
+		return nil, result.Error
+	}
+
+	return &quote, nil
+}
+
+// DeleteExpired removes quotes that expired before the given time
+func (r *sqliteQuoteRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&entities.RateQuote{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// sqliteRateSubscriptionRepository implements RateSubscriptionRepository interface using GORM
+type sqliteRateSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewRateSubscriptionRepository creates a new GORM implementation of RateSubscriptionRepository
+func NewRateSubscriptionRepository(db *gorm.DB) repositories.RateSubscriptionRepository {
+	return &sqliteRateSubscriptionRepository{
+		db: db,
+	}
+}
+
+// Save persists a subscription, loading the stored one into it when the subscriber is already subscribed
+func (r *sqliteRateSubscriptionRepository) Save(subscription *entities.RateSubscription) error {
+	if subscription == nil {
+		return errors.New("rate subscription cannot be nil")
+	}
+
+	if err := subscription.Validate(); err != nil {
+		return err
+	}
+
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(subscription)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var existing entities.RateSubscription
+	if err := UsePrimary(r.db).
+		Where("subscriber = ? AND currency = ?", subscription.Subscriber, subscription.Currency).
+		First(&existing).Error; err != nil {
+		return err
+	}
+
+	*subscription = existing
+	return nil
+}
+
+// FindBySubscriber retrieves a subscriber's subscriptions ordered by currency
+func (r *sqliteRateSubscriptionRepository) FindBySubscriber(subscriber string) ([]entities.RateSubscription, error) {
+	var subscriptions []entities.RateSubscription
+
+	result := r.db.Where("subscriber = ?", subscriber).Order("currency ASC").Find(&subscriptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return subscriptions, nil
+}
+
+// SubscribedCurrencies returns every currency with at least one subscriber, ordered by code
+func (r *sqliteRateSubscriptionRepository) SubscribedCurrencies() ([]entities.CurrencyCode, error) {
+	var currencies []entities.CurrencyCode
+
+	result := r.db.Model(&entities.RateSubscription{}).Distinct("currency").Order("currency ASC").Pluck("currency", &currencies)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return currencies, nil
+}
+
+// LastSynced returns when each subscribed currency was last synced
+func (r *sqliteRateSubscriptionRepository) LastSynced() (map[entities.CurrencyCode]time.Time, error) {
+	var subscriptions []entities.RateSubscription
+
+	result := r.db.Select("currency", "last_synced_at").Where("last_synced_at IS NOT NULL").Find(&subscriptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	synced := make(map[entities.CurrencyCode]time.Time)
+	for _, subscription := range subscriptions {
+		if last, ok := synced[subscription.Currency]; !ok || subscription.LastSyncedAt.After(last) {
+			synced[subscription.Currency] = *subscription.LastSyncedAt
+		}
+	}
+
+	return synced, nil
+}
+
+// RecordSync stores the outcome of a sync on every subscription to the currency
+func (r *sqliteRateSubscriptionRepository) RecordSync(currency entities.CurrencyCode, syncedAt time.Time, syncError string) error {
+	return r.db.Model(&entities.RateSubscription{}).
+		Where("currency = ?", currency).
+		Updates(map[string]interface{}{"last_synced_at": syncedAt, "last_error": syncError}).Error
+}
+
+// Delete removes a subscriber's subscription to a currency
+func (r *sqliteRateSubscriptionRepository) Delete(subscriber string, currency entities.CurrencyCode) error {
+	result := r.db.Delete(&entities.RateSubscription{}, "subscriber = ? AND currency = ?", subscriber, currency)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.Newf(errs.ErrNotFound, "rate subscription to %s not found", currency)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// replicaRouterName identifies the router among the plugins registered on a *gorm.DB
+const replicaRouterName = "replica_router"
+
+// primarySettingKey marks a statement that must read from the primary
+const primarySettingKey = "replica_router:primary"
+
+// replicaRouter is a GORM plugin sending reads to replicas round-robin while writes stay on the primary
+// Reads made within stickyWindow of a write on this instance also stay on the primary, hiding replica lag
+type replicaRouter struct {
+	replicas     []gorm.ConnPool
+	stickyWindow time.Duration
+
+	next      atomic.Uint64
+	lastWrite atomic.Int64 // Unix nanoseconds of the latest successful write
+}
+
+// RouteReadsToReplicas installs replica routing on db
+// Queries inside transactions, or marked with UsePrimary, always run on the primary
+func RouteReadsToReplicas(db *gorm.DB, replicas []gorm.ConnPool, stickyWindow time.Duration) error {
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	return db.Use(&replicaRouter{
+		replicas:     replicas,
+		stickyWindow: stickyWindow,
+	})
+}
+
+// UsePrimary forces the statements built from the returned handle to read from the primary
+func UsePrimary(db *gorm.DB) *gorm.DB {
+	return db.Set(primarySettingKey, true)
+}
+
+// hasReplicas reports whether reads on db may be served by a replica
+func hasReplicas(db *gorm.DB) bool {
+	_, installed := db.Config.Plugins[replicaRouterName]
+	return installed
+}
+
+// Name implements gorm.Plugin
+func (r *replicaRouter) Name() string {
+	return replicaRouterName
+}
+
+// Initialize implements gorm.Plugin by hooking reads and writes
+func (r *replicaRouter) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register("replica_router:route_query", r.route); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("replica_router:route_row", r.route); err != nil {
+		return err
+	}
+
+	if err := db.Callback().Create().After("gorm:create").Register("replica_router:mark_create", r.markWrite); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("replica_router:mark_update", r.markWrite); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("replica_router:mark_delete", r.markWrite); err != nil {
+		return err
+	}
+	return db.Callback().Raw().After("gorm:raw").Register("replica_router:mark_raw", r.markWrite)
+}
+
+// route swaps the statement's connection for the next replica when a replica may serve it
+func (r *replicaRouter) route(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	// Reads inside a transaction must see its own uncommitted writes
+	if _, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+		return
+	}
+	if primary, _ := db.Get(primarySettingKey); primary == true {
+		return
+	}
+	if time.Since(time.Unix(0, r.lastWrite.Load())) < r.stickyWindow {
+		return
+	}
+
+	index := r.next.Add(1) % uint64(len(r.replicas))
+	db.Statement.ConnPool = r.replicas[index]
+}
+
+// markWrite records when the primary last changed
+func (r *replicaRouter) markWrite(db *gorm.DB) {
+	if db.Error == nil {
+		r.lastWrite.Store(time.Now().UnixNano())
+	}
+}
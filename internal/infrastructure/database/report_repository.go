@@ -0,0 +1,94 @@
+package database
+
+import (
+	"math"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// sqliteReportRepository implements ReportRepository interface using GORM
+type sqliteReportRepository struct {
+	db *gorm.DB
+}
+
+// NewReportRepository creates a new GORM implementation of ReportRepository
+func NewReportRepository(db *gorm.DB) repositories.ReportRepository {
+	return &sqliteReportRepository{
+		db: db,
+	}
+}
+
+// periodSummaryRow is the shape of one aggregated row; AVG comes back fractional
+type periodSummaryRow struct {
+	Period  string
+	Count   int64
+	Total   int64
+	Average float64
+	Min     int64
+	Max     int64
+}
+
+// SummarizeByPeriod aggregates transactions per period in a single GROUP BY query
+func (r *sqliteReportRepository) SummarizeByPeriod(from, to time.Time, grouping entities.SummaryGrouping) ([]entities.PeriodSummary, error) {
+	var rows []periodSummaryRow
+
+	result := r.db.Model(&transactionModel{}).
+		Select(periodExpression(r.db, grouping)+" AS period, COUNT(*) AS count, SUM(amount) AS total, "+
+			"AVG(amount) AS average, MIN(amount) AS min, MAX(amount) AS max").
+		Where("date >= ? AND date < ?", from.UTC(), to.UTC()).
+		Group("period").
+		Order("period ASC").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	summaries := make([]entities.PeriodSummary, len(rows))
+	for i, row := range rows {
+		summaries[i] = entities.PeriodSummary{
+			Period:  row.Period,
+			Count:   row.Count,
+			Total:   entities.Money(row.Total),
+			Average: entities.Money(math.Round(row.Average)),
+			Min:     entities.Money(row.Min),
+			Max:     entities.Money(row.Max),
+		}
+	}
+	return summaries, nil
+}
+
+// periodExpression formats the purchase date as the grouping's period key in the connection's SQL dialect
+func periodExpression(db *gorm.DB, grouping entities.SummaryGrouping) string {
+	postgres := db.Dialector.Name() == "postgres"
+
+	var format string
+	switch grouping {
+	case entities.GroupByDay:
+		format = "%Y-%m-%d"
+		if postgres {
+			format = "YYYY-MM-DD"
+		}
+	case entities.GroupByYear:
+		format = "%Y"
+		if postgres {
+			format = "YYYY"
+		}
+	default:
+		format = "%Y-%m"
+		if postgres {
+			format = "YYYY-MM"
+		}
+	}
+
+	switch db.Dialector.Name() {
+	case "postgres":
+		return "to_char(date AT TIME ZONE 'UTC', '" + format + "')"
+	case "mysql":
+		return "DATE_FORMAT(date, '" + format + "')"
+	default:
+		return "strftime('" + format + "', date)"
+	}
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// SQL log levels (DB_LOG_LEVEL values)
+const (
+	SQLLogSilent = "silent"
+	SQLLogError  = "error"
+	SQLLogWarn   = "warn"
+	SQLLogInfo   = "info"
+)
+
+// SQLLogConfig controls which GORM statements are logged
+type SQLLogConfig struct {
+	Level         string        // silent, error (failed statements), warn (also slow ones) or info (every statement)
+	SlowThreshold time.Duration // Statements taking longer are logged as slow at warn; zero disables slow-query logging
+}
+
+// defaultSQLLogConfig logs failed and slow statements, which is what connections get until UseSQLLogger is called
+var defaultSQLLogConfig = SQLLogConfig{Level: SQLLogWarn, SlowThreshold: 200 * time.Millisecond}
+
+// UseSQLLogger routes the statements of db through the application's slog logger according to cfg
+func UseSQLLogger(db *gorm.DB, cfg SQLLogConfig) {
+	db.Logger = NewSQLLogger(cfg)
+}
+
+// NewSQLLogger returns a GORM logger writing to the default slog logger, so statements share the
+// application's format, level, export and request ID; an unknown level falls back to warn
+func NewSQLLogger(cfg SQLLogConfig) gormlogger.Interface {
+	levels := map[string]gormlogger.LogLevel{
+		SQLLogSilent: gormlogger.Silent,
+		SQLLogError:  gormlogger.Error,
+		SQLLogWarn:   gormlogger.Warn,
+		SQLLogInfo:   gormlogger.Info,
+	}
+	level, ok := levels[strings.ToLower(cfg.Level)]
+	if !ok {
+		level = gormlogger.Warn
+	}
+	return &sqlLogger{level: level, slowThreshold: cfg.SlowThreshold}
+}
+
+// sqlLogger implements GORM's logger interface on top of slog
+type sqlLogger struct {
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+// LogMode returns a copy logging at level
+func (l *sqlLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+// Info logs a GORM message at info level
+func (l *sqlLogger) Info(ctx context.Context, format string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		slog.InfoContext(ctx, fmt.Sprintf(format, args...))
+	}
+}
+
+// Warn logs a GORM message at warn level
+func (l *sqlLogger) Warn(ctx context.Context, format string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		slog.WarnContext(ctx, fmt.Sprintf(format, args...))
+	}
+}
+
+// Error logs a GORM message at error level
+func (l *sqlLogger) Error(ctx context.Context, format string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		slog.ErrorContext(ctx, fmt.Sprintf(format, args...))
+	}
+}
+
+// Trace logs a finished statement: failures at error, slow statements at warn and, at info, every other one
+// A record that was not found is an answer rather than a failure and is never logged as one
+func (l *sqlLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := l.slowThreshold > 0 && elapsed > l.slowThreshold
+
+	switch {
+	case failed && l.level >= gormlogger.Error:
+		slog.ErrorContext(ctx, "SQL statement failed", append(statementFields(elapsed, fc), "error", err.Error())...)
+	case slow && l.level >= gormlogger.Warn:
+		slog.WarnContext(ctx, "Slow SQL statement", append(statementFields(elapsed, fc), "threshold", l.slowThreshold.String())...)
+	case l.level >= gormlogger.Info:
+		slog.InfoContext(ctx, "SQL statement", statementFields(elapsed, fc)...)
+	}
+}
+
+// statementFields describes a statement by its SQL, duration and rows affected (-1 when unknown)
+func statementFields(elapsed time.Duration, fc func() (string, int64)) []interface{} {
+	sql, rows := fc()
+	return []interface{}{
+		"sql", sql,
+		"duration", elapsed.String(),
+		"rows", rows,
+	}
+}
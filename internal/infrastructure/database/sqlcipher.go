@@ -0,0 +1,184 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// ErrSQLCipherUnavailable is returned when an encryption key is configured but the linked SQLite library is not SQLCipher
+var ErrSQLCipherUnavailable = errors.New("database encryption requires a build linked against SQLCipher (see make build-sqlcipher)")
+
+// rawKeyPattern matches a hex-encoded 256-bit key, optionally followed by a 128-bit salt, used without key derivation
+var rawKeyPattern = regexp.MustCompile(`^[0-9a-fA-F]{64}([0-9a-fA-F]{32})?$`)
+
+// ResolveEncryptionKey returns the configured key, reading keyFile when no key is given inline
+// keyFile suits keys delivered by a KMS or secrets agent; surrounding whitespace is ignored
+// Returns an empty key when neither is set
+func ResolveEncryptionKey(key, keyFile string) (string, error) {
+	if key != "" && keyFile != "" {
+		return "", errors.New("set either the encryption key or the encryption key file, not both")
+	}
+	if keyFile == "" {
+		return key, nil
+	}
+
+	content, err := os.ReadFile(keyFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read encryption key file: %w", err)
+	}
+
+	resolved := strings.TrimSpace(string(content))
+	if resolved == "" {
+		return "", fmt.Errorf("encryption key file %s is empty", keyFile)
+	}
+	return resolved, nil
+}
+
+// NewEncryptedSQLiteDB opens a SQLCipher database tuned by cfg, keying every pooled connection before use
+// A new file is created encrypted; an existing one must have been encrypted with the same key
+func NewEncryptedSQLiteDB(dbPath, key string, cfg SQLiteConfig) (*SQLiteDB, error) {
+	return openSQLite(dbPath, cfg, func() (*sql.DB, error) {
+		return openKeyedSQLite(dbPath, key, cfg)
+	})
+}
+
+// RekeySQLite re-encrypts a SQLCipher database in place from currentKey to newKey
+// The server must be stopped first: other processes holding the file open would keep using the old key
+func RekeySQLite(dbPath, currentKey, newKey string) error {
+	if newKey == "" {
+		return errors.New("new encryption key is required")
+	}
+	if newKey == currentKey {
+		return errors.New("new encryption key must differ from the current one")
+	}
+
+	sqlDB, err := openKeyedSQLite(dbPath, currentKey, SQLiteConfig{})
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	if _, err := sqlDB.Exec(keyPragma("rekey", newKey)); err != nil {
+		return fmt.Errorf("failed to rekey database: %w", err)
+	}
+
+	return nil
+}
+
+// EncryptSQLite writes an encrypted copy of a plaintext SQLite database to encryptedPath
+// The plaintext file is left untouched so it can be removed once the copy is verified
+func EncryptSQLite(plainPath, encryptedPath, key string) error {
+	if key == "" {
+		return errors.New("encryption key is required")
+	}
+	if _, err := os.Stat(encryptedPath); err == nil {
+		return fmt.Errorf("%s already exists", encryptedPath)
+	}
+
+	sqlDB := sql.OpenDB(sqliteConnector{driver: &sqlite3.SQLiteDriver{}, dsn: plainPath})
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1) // ATTACH applies to a single connection
+
+	if err := requireSQLCipher(sqlDB); err != nil {
+		return err
+	}
+
+	attach := fmt.Sprintf("ATTACH DATABASE %s AS encrypted KEY %s", quoteLiteral(encryptedPath), keyLiteral(key))
+	if _, err := sqlDB.Exec(attach); err != nil {
+		return fmt.Errorf("failed to create encrypted database: %w", err)
+	}
+	if _, err := sqlDB.Exec("SELECT sqlcipher_export('encrypted')"); err != nil {
+		return fmt.Errorf("failed to copy data into encrypted database: %w", err)
+	}
+	if _, err := sqlDB.Exec("DETACH DATABASE encrypted"); err != nil {
+		return fmt.Errorf("failed to finish encrypted database: %w", err)
+	}
+
+	return nil
+}
+
+// openKeyedSQLite opens a connection pool whose connections are keyed and tuned by cfg on open, then checks SQLCipher and the key
+func openKeyedSQLite(dbPath, key string, cfg SQLiteConfig) (*sql.DB, error) {
+	if key == "" {
+		return nil, errors.New("encryption key is required")
+	}
+
+	keyedDriver := &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			// PRAGMA key must be the first statement on a SQLCipher connection
+			if _, err := conn.Exec(keyPragma("key", key), nil); err != nil {
+				return err
+			}
+			return cfg.configure(conn)
+		},
+	}
+	sqlDB := sql.OpenDB(sqliteConnector{driver: keyedDriver, dsn: dbPath})
+
+	if err := requireSQLCipher(sqlDB); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+
+	// Reading the schema decrypts the first page, which fails when the key is wrong
+	var tables int
+	if err := sqlDB.QueryRow("SELECT count(*) FROM sqlite_master").Scan(&tables); err != nil {
+		_ = sqlDB.Close()
+		return nil, fmt.Errorf("failed to open encrypted database (wrong key or not encrypted): %w", err)
+	}
+
+	return sqlDB, nil
+}
+
+// requireSQLCipher fails unless the linked SQLite library is SQLCipher; plain SQLite ignores PRAGMA key silently
+func requireSQLCipher(sqlDB *sql.DB) error {
+	var version sql.NullString
+	if err := sqlDB.QueryRow("PRAGMA cipher_version").Scan(&version); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to query SQLCipher version: %w", err)
+	}
+	if version.String == "" {
+		return ErrSQLCipherUnavailable
+	}
+	return nil
+}
+
+// keyPragma builds a PRAGMA key or rekey statement for a passphrase or raw hex key
+func keyPragma(pragma, key string) string {
+	return fmt.Sprintf("PRAGMA %s = %s", pragma, keyLiteral(key))
+}
+
+// keyLiteral quotes a key for SQLCipher; raw hex keys skip the passphrase key derivation
+func keyLiteral(key string) string {
+	if rawKeyPattern.MatchString(key) {
+		return fmt.Sprintf(`"x'%s'"`, key)
+	}
+	return quoteLiteral(key)
+}
+
+// quoteLiteral renders s as a SQL string literal
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// sqliteConnector opens connections through a specific SQLite driver instance without registering it globally
+type sqliteConnector struct {
+	driver *sqlite3.SQLiteDriver
+	dsn    string
+}
+
+// Connect opens a new connection to the database file
+func (c sqliteConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+// Driver returns the underlying SQLite driver
+func (c sqliteConnector) Driver() driver.Driver {
+	return c.driver
+}
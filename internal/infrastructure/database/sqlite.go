@@ -1,51 +1,137 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
-	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/mattn/go-sqlite3"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database/migrations"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
+// PoolReader names the pool serving reads when SQLite writes are serialized
+const PoolReader = "reader"
+
+// SQLiteConfig tunes SQLite for concurrent access; the zero value keeps the defaults of SQLite and the driver
+type SQLiteConfig struct {
+	JournalMode     string        // e.g. wal, which lets reads proceed while a write is in progress
+	BusyTimeout     time.Duration // How long a statement waits for a lock before failing with "database is locked"; zero keeps the driver's 5s
+	Synchronous     string        // off, normal, full or extra
+	SerializeWrites bool          // Queue writes on a single connection and serve reads from a separate pool
+	Pool            PoolConfig    // Limits of the pools of a file database; the writer keeps one connection while writes are serialized
+}
+
+// configure applies the pragmas of c to a new connection
+func (c SQLiteConfig) configure(conn *sqlite3.SQLiteConn) error {
+	var pragmas []string
+	if c.BusyTimeout > 0 {
+		pragmas = append(pragmas, fmt.Sprintf("PRAGMA busy_timeout = %d", c.BusyTimeout.Milliseconds()))
+	}
+	if c.JournalMode != "" {
+		pragmas = append(pragmas, "PRAGMA journal_mode = "+strings.ToUpper(c.JournalMode))
+	}
+	if c.Synchronous != "" {
+		pragmas = append(pragmas, "PRAGMA synchronous = "+strings.ToUpper(c.Synchronous))
+	}
+
+	for _, pragma := range pragmas {
+		if _, err := conn.Exec(pragma, nil); err != nil {
+			return fmt.Errorf("failed to apply %s: %w", pragma, err)
+		}
+	}
+	return nil
+}
+
 // SQLiteDB wraps GORM database connection
 type SQLiteDB struct {
-	DB *gorm.DB
+	DB     *gorm.DB
+	reader *sql.DB // Serves reads while writes are serialized; nil otherwise
 }
 
-// NewSQLiteDB creates a new SQLite database connection
-func NewSQLiteDB(dbPath string) (*SQLiteDB, error) {
-	// Configure GORM with SQLite driver
-	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info), // Log SQL queries
+// NewSQLiteDB creates a new SQLite database connection tuned by cfg; call Migrate to bring its schema up to date
+func NewSQLiteDB(dbPath string, cfg SQLiteConfig) (*SQLiteDB, error) {
+	return openSQLite(dbPath, cfg, func() (*sql.DB, error) {
+		return sql.OpenDB(sqliteConnector{driver: &sqlite3.SQLiteDriver{ConnectHook: cfg.configure}, dsn: dbPath}), nil
+	})
+}
+
+// openSQLite wraps the pool returned by open in GORM
+// With SerializeWrites on a file database the pool is limited to one connection, so writers queue for it in
+// order instead of racing for the file lock, and reads go to a second pool from open
+// In-memory databases keep the database/sql defaults: closing their last connection would drop them
+func openSQLite(dbPath string, cfg SQLiteConfig, open func() (*sql.DB, error)) (*SQLiteDB, error) {
+	sqlDB, err := open()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(sqlite.New(sqlite.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: NewSQLLogger(defaultSQLLogConfig),
 	})
 	if err != nil {
+		_ = sqlDB.Close()
 		return nil, fmt.Errorf("failed to connect to SQLite database: %w", err)
 	}
 
 	sqliteDB := &SQLiteDB{
 		DB: db,
 	}
+	// Each connection to an in-memory database sees its own database, so there is nothing to share with a reader
+	if isMemorySQLite(dbPath) {
+		return sqliteDB, nil
+	}
+	cfg.Pool.Apply(sqlDB)
+	if !cfg.SerializeWrites {
+		return sqliteDB, nil
+	}
 
-	// Run auto-migration to create tables
-	if err := sqliteDB.Migrate(); err != nil {
-		return nil, fmt.Errorf("failed to run database migrations: %w", err)
+	sqlDB.SetMaxOpenConns(1)
+	reader, err := open()
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	cfg.Pool.Apply(reader)
+	if err := RouteReadsToReplicas(db, []gorm.ConnPool{reader}, 0); err != nil {
+		_ = reader.Close()
+		_ = sqlDB.Close()
+		return nil, err
 	}
+	sqliteDB.reader = reader
 
 	return sqliteDB, nil
 }
 
-// Migrate runs auto-migration for all entities
+// isMemorySQLite reports whether dbPath names an in-memory or temporary database rather than a file
+func isMemorySQLite(dbPath string) bool {
+	return dbPath == "" || strings.HasPrefix(dbPath, ":memory:") || strings.Contains(dbPath, "mode=memory")
+}
+
+// Migrations returns the application's migrations; SQLite has no optional migrations
+func (s *SQLiteDB) Migrations() []migrations.Migration {
+	return migrations.All()
+}
+
+// Migrate applies the pending versioned migrations
 func (s *SQLiteDB) Migrate() error {
-	return s.DB.AutoMigrate(
-		&entities.Transaction{},
-		&entities.ExchangeRate{},
-	)
+	if _, err := migrations.NewMigrator(s.DB, s.Migrations()).Up(); err != nil {
+		return fmt.Errorf("failed to run database migrations: %w", err)
+	}
+	return nil
 }
 
-// Close closes the database connection
+// Close closes the database connections
 func (s *SQLiteDB) Close() error {
+	if s.reader != nil {
+		if err := s.reader.Close(); err != nil {
+			return err
+		}
+	}
+
 	sqlDB, err := s.DB.DB()
 	if err != nil {
 		return err
@@ -53,6 +139,36 @@ func (s *SQLiteDB) Close() error {
 	return sqlDB.Close()
 }
 
+// Ping verifies the database connection is alive
+func (s *SQLiteDB) Ping(ctx context.Context) error {
+	sqlDB, err := s.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Size returns the size of the database file in bytes
+func (s *SQLiteDB) Size(ctx context.Context) (int64, error) {
+	var pageCount, pageSize int64
+	if err := s.DB.WithContext(ctx).Raw("PRAGMA page_count").Scan(&pageCount).Error; err != nil {
+		return 0, err
+	}
+	if err := s.DB.WithContext(ctx).Raw("PRAGMA page_size").Scan(&pageSize).Error; err != nil {
+		return 0, err
+	}
+	return pageCount * pageSize, nil
+}
+
+// Pools returns the connection pools for statistics, including the reader while writes are serialized
+func (s *SQLiteDB) Pools() []NamedPool {
+	pools := primaryPool(s.DB)
+	if s.reader != nil {
+		pools = append(pools, NamedPool{Name: PoolReader, DB: s.reader})
+	}
+	return pools
+}
+
 // GetDB returns the underlying GORM database instance
 func (s *SQLiteDB) GetDB() *gorm.DB {
 	return s.DB
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"gorm.io/gorm"
+)
+
+// migratedModels lists every entity stored in its own table
+func migratedModels() []interface{} {
+	return []interface{}{
+		&transactionModel{},
+		&entities.ArchivedTransaction{},
+		&entities.ExchangeRate{},
+		&entities.RateQuote{},
+		&entities.ConversionRecord{},
+		&entities.ConversionBatch{},
+		&entities.Conversion{},
+		&entities.Budget{},
+		&entities.Category{},
+		&entities.RateSubscription{},
+		&entities.APIToken{},
+		&entities.IdempotencyKey{},
+		&entities.Webhook{},
+		&entities.WebhookDelivery{},
+		&entities.OutboxMessage{},
+		&entities.AuditLog{},
+	}
+}
+
+// RowCounts returns the number of rows in every table keyed by table name
+// Soft-deleted rows are included since they still take up space
+func RowCounts(ctx context.Context, db *gorm.DB) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	for _, model := range migratedModels() {
+		statement := &gorm.Statement{DB: db}
+		if err := statement.Parse(model); err != nil {
+			return nil, err
+		}
+
+		var count int64
+		if err := db.WithContext(ctx).Unscoped().Model(model).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		counts[statement.Schema.Table] = count
+	}
+	return counts, nil
+}
@@ -2,13 +2,90 @@ package database
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
 	"gorm.io/gorm"
 )
 
+// defaultBatchSize is used by ForEach when the caller does not provide a positive batch size
+const defaultBatchSize = 500
+
+// transactionModel is the row of a transaction, carrying its GORM mapping so the entity stays free of it
+// gorm.DeletedAt keeps soft-deleted rows out of scoped queries
+type transactionModel struct {
+	ID          uuid.UUID             `gorm:"type:uuid;primary_key"`
+	Description string                `gorm:"not null;index"`
+	Date        time.Time             `gorm:"not null;index"`
+	Amount      entities.Money        `gorm:"not null;index"`
+	Currency    entities.CurrencyCode `gorm:"size:3;not null;default:USD"`
+	Category    string                `gorm:"index"`
+	Tags        []string              `gorm:"serializer:json"`
+	ExternalID  *string               `gorm:"index"`
+	Version     int64                 `gorm:"not null;default:1"`
+	CreatedAt   time.Time             `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time             `gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt        `gorm:"index"`
+}
+
+func (transactionModel) TableName() string { return "transactions" }
+
+// newTransactionModel maps a transaction to its row
+func newTransactionModel(transaction entities.Transaction) transactionModel {
+	model := transactionModel{
+		ID:          transaction.ID,
+		Description: transaction.Description,
+		Date:        transaction.Date,
+		Amount:      transaction.Amount,
+		Currency:    transaction.Currency,
+		Category:    transaction.Category,
+		Tags:        transaction.Tags,
+		ExternalID:  transaction.ExternalID,
+		Version:     transaction.Version,
+		CreatedAt:   transaction.CreatedAt,
+		UpdatedAt:   transaction.UpdatedAt,
+	}
+	if transaction.DeletedAt != nil {
+		model.DeletedAt = gorm.DeletedAt{Time: *transaction.DeletedAt, Valid: true}
+	}
+	return model
+}
+
+// entity maps the row back to a transaction
+func (m transactionModel) entity() entities.Transaction {
+	transaction := entities.Transaction{
+		ID:          m.ID,
+		Description: m.Description,
+		Date:        m.Date,
+		Amount:      m.Amount,
+		Currency:    m.Currency,
+		Category:    m.Category,
+		Tags:        m.Tags,
+		ExternalID:  m.ExternalID,
+		Version:     m.Version,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+	if m.DeletedAt.Valid {
+		deletedAt := m.DeletedAt.Time
+		transaction.DeletedAt = &deletedAt
+	}
+	return transaction
+}
+
+// transactionEntities maps rows back to transactions
+func transactionEntities(models []transactionModel) []entities.Transaction {
+	transactions := make([]entities.Transaction, len(models))
+	for i := range models {
+		transactions[i] = models[i].entity()
+	}
+	return transactions
+}
+
 // sqliteTransactionRepository implements TransactionRepository interface using SQLite
 type sqliteTransactionRepository struct {
 	db *gorm.DB
@@ -32,20 +109,63 @@ func (r *sqliteTransactionRepository) Save(transaction *entities.Transaction) er
 		return err
 	}
 
+	if transaction.Version == 0 {
+		transaction.Version = 1
+	}
+
 	// Create transaction in database
-	result := r.db.Create(transaction)
+	model := newTransactionModel(*transaction)
+	result := r.db.Create(&model)
 	if result.Error != nil {
 		return result.Error
 	}
 
+	*transaction = model.entity()
+	return nil
+}
+
+// SaveAll inserts the transactions in batches of defaultBatchSize within a single database transaction
+func (r *sqliteTransactionRepository) SaveAll(transactions []entities.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	// Validate everything up front so a bad record doesn't roll back a half-written import
+	for i := range transactions {
+		if err := transactions[i].Validate(); err != nil {
+			return err
+		}
+		if transactions[i].Version == 0 {
+			transactions[i].Version = 1
+		}
+	}
+
+	models := make([]transactionModel, len(transactions))
+	for i := range transactions {
+		models[i] = newTransactionModel(transactions[i])
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(models, defaultBatchSize).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range models {
+		transactions[i] = models[i].entity()
+	}
 	return nil
 }
 
 // GetByID retrieves a transaction by its unique identifier
 func (r *sqliteTransactionRepository) GetByID(id uuid.UUID) (*entities.Transaction, error) {
-	var transaction entities.Transaction
+	var transaction transactionModel
 
 	result := r.db.First(&transaction, "id = ?", id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) && hasReplicas(r.db) {
+		// A transaction created moments ago may not have reached the replica yet
+		result = UsePrimary(r.db).First(&transaction, "id = ?", id)
+	}
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil // Return nil, nil when not found (as per interface contract)
@@ -53,24 +173,66 @@ func (r *sqliteTransactionRepository) GetByID(id uuid.UUID) (*entities.Transacti
 		return nil, result.Error
 	}
 
-	return &transaction, nil
+	found := transaction.entity()
+	return &found, nil
+}
+
+// GetByExternalID retrieves a transaction, deleted or not, by its source system ID
+func (r *sqliteTransactionRepository) GetByExternalID(externalID string) (*entities.Transaction, error) {
+	var transaction transactionModel
+
+	// Read from the primary: an import deciding whether to create a row must not miss one that is still replicating
+	result := UsePrimary(r.db).Unscoped().First(&transaction, "external_id = ?", externalID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		// Archived transactions were imported too
+		var archived entities.ArchivedTransaction
+		result = UsePrimary(r.db).First(&archived, "external_id = ?", externalID)
+		transaction = newTransactionModel(archived.Transaction())
+	}
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	found := transaction.entity()
+	return &found, nil
 }
 
 // GetAll retrieves all transactions from the database
 func (r *sqliteTransactionRepository) GetAll() ([]entities.Transaction, error) {
-	var transactions []entities.Transaction
+	var transactions []transactionModel
 
 	result := r.db.Find(&transactions)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	return transactions, nil
+	return transactionEntities(transactions), nil
+}
+
+// ForEach streams all transactions in batches ordered by primary key
+// fn must copy any rows of a batch it needs to keep
+func (r *sqliteTransactionRepository) ForEach(batchSize int, fn func(batch []entities.Transaction) error) error {
+	if fn == nil {
+		return errors.New("batch callback cannot be nil")
+	}
+	if batchSize < 1 {
+		batchSize = defaultBatchSize
+	}
+
+	var batch []transactionModel
+	result := r.db.FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(transactionEntities(batch))
+	})
+
+	return result.Error
 }
 
 // GetAllPaginated retrieves transactions with pagination support
 func (r *sqliteTransactionRepository) GetAllPaginated(page, size int) ([]entities.Transaction, int64, error) {
-	var transactions []entities.Transaction
+	var transactions []transactionModel
 	var total int64
 
 	// Validate pagination parameters
@@ -85,21 +247,141 @@ func (r *sqliteTransactionRepository) GetAllPaginated(page, size int) ([]entitie
 	offset := (page - 1) * size
 
 	// Get total count
-	result := r.db.Model(&entities.Transaction{}).Count(&total)
+	result := r.db.Model(&transactionModel{}).Count(&total)
 	if result.Error != nil {
 		return nil, 0, result.Error
 	}
 
-	// Get paginated transactions ordered by created_at DESC (most recent first)
-	result = r.db.Order("created_at DESC").Limit(size).Offset(offset).Find(&transactions)
+	// Get paginated transactions ordered by created_at DESC (most recent first), ties broken by ID like cursor pages
+	result = r.db.Order("created_at DESC, id DESC").Limit(size).Offset(offset).Find(&transactions)
 	if result.Error != nil {
 		return nil, 0, result.Error
 	}
 
-	return transactions, total, nil
+	return transactionEntities(transactions), total, nil
+}
+
+// FindPaginated retrieves the transactions matching filter, ordered by created_at DESC
+func (r *sqliteTransactionRepository) FindPaginated(filter entities.TransactionFilter, page, size int) ([]entities.Transaction, int64, error) {
+	var transactions []transactionModel
+	var total int64
+
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	query := r.filtered(filter)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * size
+	if err := query.Order("created_at DESC, id DESC").Limit(size).Offset(offset).Find(&transactions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return transactionEntities(transactions), total, nil
+}
+
+// FindAfter retrieves up to size transactions matching filter after cursor, ordered by created_at DESC, id DESC
+// The keyset condition seeks on the (created_at, id) index instead of skipping rows with OFFSET
+func (r *sqliteTransactionRepository) FindAfter(filter entities.TransactionFilter, cursor *entities.TransactionCursor, size int) ([]entities.Transaction, error) {
+	if size < 1 {
+		size = 20 // Default size
+	}
+
+	query := r.filtered(filter)
+	if cursor != nil {
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+
+	var transactions []transactionModel
+	if err := query.Order("created_at DESC, id DESC").Limit(size).Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return transactionEntities(transactions), nil
+}
+
+// filtered returns a query for the active transactions matching filter
+// Date and amount bounds use their indexes; the description match is a case-insensitive LIKE
+func (r *sqliteTransactionRepository) filtered(filter entities.TransactionFilter) *gorm.DB {
+	query := r.db.Model(&transactionModel{})
+	if filter.DateFrom != nil {
+		query = query.Where("date >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		query = query.Where("date < ?", *filter.DateTo)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+	if filter.DescriptionContains != "" {
+		pattern := "%" + escapeLike(strings.ToLower(filter.DescriptionContains)) + "%"
+		query = query.Where("LOWER(description) LIKE ?"+likeEscape(r.db), pattern)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.Tag != "" {
+		// Tags are stored as a JSON array; ValidateTag keeps quotes and backslashes out of them
+		query = query.Where("tags LIKE ?"+likeEscape(r.db), "%\""+escapeLike(filter.Tag)+"\"%")
+	}
+	return query
+}
+
+// SuggestDescriptions returns the most frequent distinct descriptions matching a prefix, ignoring case
+// Uses the description prefix index; LIKE wildcards in the prefix are escaped
+func (r *sqliteTransactionRepository) SuggestDescriptions(prefix string, limit int) ([]entities.DescriptionSuggestion, error) {
+	var suggestions []entities.DescriptionSuggestion
+
+	pattern := escapeLike(strings.ToLower(prefix)) + "%"
+	result := r.db.Model(&transactionModel{}).
+		Select("description, COUNT(*) AS count").
+		Where(lowerDescriptionLike(r.db), pattern).
+		Group("description").
+		Order("count DESC, description ASC").
+		Limit(limit).
+		Scan(&suggestions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return suggestions, nil
+}
+
+// lowerDescriptionLike returns the condition matching the lower-cased description against a lower-case LIKE pattern
+// SQLite's LIKE already ignores case, as ASCII-only as its LOWER, and only seeks an index on a bare column
+func lowerDescriptionLike(db *gorm.DB) string {
+	if db.Dialector.Name() == "sqlite" {
+		return "description LIKE ?" + likeEscape(db)
+	}
+	return "LOWER(description) LIKE ?" + likeEscape(db)
+}
+
+// escapeLike escapes LIKE wildcards so user input is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(s)
 }
 
-// Update modifies an existing transaction in the database
+// likeEscape returns the clause making backslash the LIKE escape character in the connection's SQL dialect
+// MySQL escapes with backslash by default and would read '\' as an unterminated string literal
+func likeEscape(db *gorm.DB) string {
+	if db.Dialector.Name() == "mysql" {
+		return ""
+	}
+	return " ESCAPE '\\'"
+}
+
+// Update modifies an existing transaction in the database, provided it is still at transaction.Version
+// The stored version is incremented and copied back to transaction; a concurrent update in between is a conflict
 func (r *sqliteTransactionRepository) Update(transaction *entities.Transaction) error {
 	if transaction == nil {
 		return errors.New("transaction cannot be nil")
@@ -111,36 +393,45 @@ func (r *sqliteTransactionRepository) Update(transaction *entities.Transaction)
 	}
 
 	// Check if transaction exists
-	exists, err := r.Exists(transaction.ID)
+	exists, err := r.isLive(transaction.ID)
 	if err != nil {
 		return err
 	}
 	if !exists {
-		return errors.New("transaction not found")
+		return errs.Newf(errs.ErrNotFound, "transaction not found")
 	}
 
-	// Update transaction in database
-	result := r.db.Save(transaction)
+	// Compare-and-swap on the version, so a row changed since it was read is left alone
+	expected := transaction.Version
+	transaction.Version = expected + 1
+	model := newTransactionModel(*transaction)
+	result := r.db.Model(&model).Where("version = ?", expected).Select("*").Updates(&model)
 	if result.Error != nil {
+		transaction.Version = expected
 		return result.Error
 	}
+	if result.RowsAffected == 0 {
+		transaction.Version = expected
+		return errs.Newf(errs.ErrConflict, "transaction %s was modified since version %d", transaction.ID, expected)
+	}
 
+	*transaction = model.entity()
 	return nil
 }
 
-// Delete removes a transaction from the database by ID
+// Delete soft-deletes a transaction by ID
 func (r *sqliteTransactionRepository) Delete(id uuid.UUID) error {
 	// Check if transaction exists
-	exists, err := r.Exists(id)
+	exists, err := r.isLive(id)
 	if err != nil {
 		return err
 	}
 	if !exists {
-		return errors.New("transaction not found")
+		return errs.Newf(errs.ErrNotFound, "transaction not found")
 	}
 
-	// Delete transaction from database
-	result := r.db.Delete(&entities.Transaction{}, "id = ?", id)
+	// Soft-delete transaction (GORM sets deleted_at because of the model's DeletedAt field)
+	result := r.db.Delete(&transactionModel{}, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -148,11 +439,326 @@ func (r *sqliteTransactionRepository) Delete(id uuid.UUID) error {
 	return nil
 }
 
-// Exists checks if a transaction with the given ID exists
+// Restore clears the soft-delete marker of a deleted transaction
+func (r *sqliteTransactionRepository) Restore(id uuid.UUID) (*entities.Transaction, error) {
+	result := r.db.Unscoped().Model(&transactionModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errs.Newf(errs.ErrNotFound, "deleted transaction not found")
+	}
+
+	return r.GetByID(id)
+}
+
+// GetDeletedByID retrieves a soft-deleted transaction by its unique identifier
+func (r *sqliteTransactionRepository) GetDeletedByID(id uuid.UUID) (*entities.Transaction, error) {
+	var transaction transactionModel
+
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&transaction, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil, nil when not found (as per interface contract)
+		}
+		return nil, result.Error
+	}
+
+	found := transaction.entity()
+	return &found, nil
+}
+
+// GetDeletedPaginated retrieves soft-deleted transactions with pagination support
+func (r *sqliteTransactionRepository) GetDeletedPaginated(page, size int) ([]entities.Transaction, int64, error) {
+	var transactions []transactionModel
+	var total int64
+
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	offset := (page - 1) * size
+	trash := r.db.Unscoped().Model(&transactionModel{}).Where("deleted_at IS NOT NULL")
+
+	result := trash.Count(&total)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	result = r.db.Unscoped().Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").Limit(size).Offset(offset).Find(&transactions)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return transactionEntities(transactions), total, nil
+}
+
+// FindByDateBetween retrieves transactions whose purchase date is in [from, to), oldest first
+// The bounds compare the bare date column so PostgreSQL can prune monthly partitions outside the range
+func (r *sqliteTransactionRepository) FindByDateBetween(from, to time.Time) ([]entities.Transaction, error) {
+	var transactions []transactionModel
+
+	result := r.db.Where("date >= ? AND date < ?", from.UTC(), to.UTC()).Order("date ASC, id ASC").Find(&transactions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return transactionEntities(transactions), nil
+}
+
+// SummarizeCreatedBetween counts and sums transactions created in [from, to)
+func (r *sqliteTransactionRepository) SummarizeCreatedBetween(from, to time.Time) (entities.TransactionSummary, error) {
+	var summary entities.TransactionSummary
+
+	result := r.db.Model(&transactionModel{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(amount), 0) AS total").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Scan(&summary)
+	if result.Error != nil {
+		return entities.TransactionSummary{}, result.Error
+	}
+
+	return summary, nil
+}
+
+// SummarizeCategoryBetween counts and sums a category's transactions by purchase date
+// Reads the primary so a transaction just saved is always included
+func (r *sqliteTransactionRepository) SummarizeCategoryBetween(category string, from, to time.Time) (entities.TransactionSummary, error) {
+	var summary entities.TransactionSummary
+
+	result := UsePrimary(r.db).Model(&transactionModel{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(amount), 0) AS total").
+		Where("category = ? AND date >= ? AND date < ?", category, from, to).
+		Scan(&summary)
+	if result.Error != nil {
+		return entities.TransactionSummary{}, result.Error
+	}
+
+	return summary, nil
+}
+
+// CountByCategory counts a category's transactions across live, soft-deleted and archived rows
+func (r *sqliteTransactionRepository) CountByCategory(category string) (int64, error) {
+	var active, archived int64
+
+	if err := r.db.Unscoped().Model(&transactionModel{}).Where("category = ?", category).Count(&active).Error; err != nil {
+		return 0, err
+	}
+	if err := r.db.Model(&entities.ArchivedTransaction{}).Where("category = ?", category).Count(&archived).Error; err != nil {
+		return 0, err
+	}
+
+	return active + archived, nil
+}
+
+// RenameCategory moves a category's live, soft-deleted and archived transactions to another name in one database transaction
+// Moved transactions get a new updated_at so cached listings are invalidated
+func (r *sqliteTransactionRepository) RenameCategory(from, to string) (int64, error) {
+	var moved int64
+	err := UsePrimary(r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&transactionModel{}).Where("category = ?", from).
+			Updates(map[string]interface{}{"category": to, "updated_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		moved = result.RowsAffected
+
+		result = tx.Model(&entities.ArchivedTransaction{}).Where("category = ?", from).Update("category", to)
+		if result.Error != nil {
+			return result.Error
+		}
+		moved += result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return moved, nil
+}
+
+// LastModified returns the newest updated_at or deleted_at across live and soft-deleted rows, or archived_at
+// Soft deletes only set deleted_at and archiving removes rows, so all three columns are needed to see every change
+func (r *sqliteTransactionRepository) LastModified() (time.Time, error) {
+	var updated, deleted transactionModel
+	var archived entities.ArchivedTransaction
+
+	result := r.db.Unscoped().Select("updated_at").Order("updated_at DESC").Limit(1).Find(&updated)
+	if result.Error != nil {
+		return time.Time{}, result.Error
+	}
+
+	result = r.db.Unscoped().Select("deleted_at").Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").Limit(1).Find(&deleted)
+	if result.Error != nil {
+		return time.Time{}, result.Error
+	}
+
+	result = r.db.Select("archived_at").Order("archived_at DESC").Limit(1).Find(&archived)
+	if result.Error != nil {
+		return time.Time{}, result.Error
+	}
+
+	lastModified := updated.UpdatedAt
+	if deleted.DeletedAt.Valid && deleted.DeletedAt.Time.After(lastModified) {
+		lastModified = deleted.DeletedAt.Time
+	}
+	if archived.ArchivedAt.After(lastModified) {
+		lastModified = archived.ArchivedAt
+	}
+	return lastModified, nil
+}
+
+// ArchiveDatedBefore moves the oldest active transactions dated before cutoff into archived_transactions
+// Each call copies and removes one batch inside a single database transaction
+func (r *sqliteTransactionRepository) ArchiveDatedBefore(cutoff time.Time, limit int) (int64, error) {
+	if limit < 1 {
+		limit = defaultBatchSize
+	}
+
+	var moved int64
+	err := UsePrimary(r.db).Transaction(func(tx *gorm.DB) error {
+		var transactions []transactionModel
+		if err := tx.Where("date < ?", cutoff).Order("date ASC").Limit(limit).Find(&transactions).Error; err != nil {
+			return err
+		}
+		if len(transactions) == 0 {
+			return nil
+		}
+
+		archivedAt := time.Now().UTC()
+		archived := make([]entities.ArchivedTransaction, 0, len(transactions))
+		ids := make([]uuid.UUID, 0, len(transactions))
+		for _, transaction := range transactions {
+			archived = append(archived, entities.NewArchivedTransaction(transaction.entity(), archivedAt))
+			ids = append(ids, transaction.ID)
+		}
+
+		if err := tx.Create(&archived).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&transactionModel{})
+		if result.Error != nil {
+			return result.Error
+		}
+		moved = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return moved, nil
+}
+
+// PurgeDeletedBefore hard-deletes soft-deleted transactions whose deleted_at is before cutoff
+func (r *sqliteTransactionRepository) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	result := UsePrimary(r.db).Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Delete(&transactionModel{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// GetArchivedByID retrieves an archived transaction by its unique identifier
+func (r *sqliteTransactionRepository) GetArchivedByID(id uuid.UUID) (*entities.Transaction, error) {
+	var archived entities.ArchivedTransaction
+
+	result := r.db.First(&archived, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil, nil when not found (as per interface contract)
+		}
+		return nil, result.Error
+	}
+
+	transaction := archived.Transaction()
+	return &transaction, nil
+}
+
+// GetAllPaginatedWithArchived pages through active transactions, then archived ones, each ordered by created_at DESC
+func (r *sqliteTransactionRepository) GetAllPaginatedWithArchived(page, size int) ([]entities.Transaction, int64, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	var activeTotal, archivedTotal int64
+	if result := r.db.Model(&transactionModel{}).Count(&activeTotal); result.Error != nil {
+		return nil, 0, result.Error
+	}
+	if result := r.db.Model(&entities.ArchivedTransaction{}).Count(&archivedTotal); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	// Active rows fill the first activeTotal positions, archived rows follow
+	offset := int64((page - 1) * size)
+	transactions := make([]entities.Transaction, 0, size)
+	if offset < activeTotal {
+		var active []transactionModel
+		result := r.db.Order("created_at DESC").Limit(size).Offset(int(offset)).Find(&active)
+		if result.Error != nil {
+			return nil, 0, result.Error
+		}
+		transactions = append(transactions, transactionEntities(active)...)
+	}
+
+	if remaining := size - len(transactions); remaining > 0 {
+		archivedOffset := offset - activeTotal
+		if archivedOffset < 0 {
+			archivedOffset = 0
+		}
+
+		var archived []entities.ArchivedTransaction
+		result := r.db.Order("created_at DESC").Limit(remaining).Offset(int(archivedOffset)).Find(&archived)
+		if result.Error != nil {
+			return nil, 0, result.Error
+		}
+		for _, record := range archived {
+			transactions = append(transactions, record.Transaction())
+		}
+	}
+
+	return transactions, activeTotal + archivedTotal, nil
+}
+
+// Exists checks if a transaction with the given ID is stored, soft-deleted ones included, since their ID is taken
 func (r *sqliteTransactionRepository) Exists(id uuid.UUID) (bool, error) {
-	var count int64
+	return r.exists(id, true)
+}
 
-	result := r.db.Model(&entities.Transaction{}).Where("id = ?", id).Count(&count)
+// isLive checks if a transaction with the given ID is stored and not soft-deleted
+func (r *sqliteTransactionRepository) isLive(id uuid.UUID) (bool, error) {
+	return r.exists(id, false)
+}
+
+// exists counts the transactions with the given ID, soft-deleted ones only when unscoped
+func (r *sqliteTransactionRepository) exists(id uuid.UUID, unscoped bool) (bool, error) {
+	countIn := func(db *gorm.DB, count *int64) *gorm.DB {
+		if unscoped {
+			db = db.Unscoped()
+		}
+		return db.Model(&transactionModel{}).Where("id = ?", id).Count(count)
+	}
+
+	var count int64
+	result := countIn(r.db, &count)
+	if result.Error == nil && count == 0 && hasReplicas(r.db) {
+		// A transaction created moments ago may not have reached the replica yet
+		result = countIn(UsePrimary(r.db), &count)
+	}
 	if result.Error != nil {
 		return false, result.Error
 	}
@@ -164,7 +770,7 @@ func (r *sqliteTransactionRepository) Exists(id uuid.UUID) (bool, error) {
 func (r *sqliteTransactionRepository) Count() (int64, error) {
 	var count int64
 
-	result := r.db.Model(&entities.Transaction{}).Count(&count)
+	result := r.db.Model(&transactionModel{}).Count(&count)
 	if result.Error != nil {
 		return 0, result.Error
 	}
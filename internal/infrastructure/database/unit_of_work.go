@@ -0,0 +1,32 @@
+package database
+
+import (
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// gormUnitOfWork runs units of work in GORM transactions on the primary
+type gormUnitOfWork struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork creates a unit of work whose repositories share a GORM transaction on db
+func NewUnitOfWork(db *gorm.DB) repositories.UnitOfWork {
+	return &gormUnitOfWork{db: db}
+}
+
+// Do calls fn inside a transaction, committing when it returns nil and rolling back otherwise
+// Repositories that open transactions of their own join this one through savepoints
+func (u *gormUnitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	return UsePrimary(u.db).Transaction(func(tx *gorm.DB) error {
+		return fn(repositories.Repositories{
+			Transactions:      NewTransactionRepository(tx),
+			ExchangeRates:     NewExchangeRateRepository(tx),
+			ConversionHistory: NewConversionHistoryRepository(tx),
+			Categories:        NewCategoryRepository(tx),
+			Budgets:           NewBudgetRepository(tx),
+			Outbox:            NewOutboxRepository(tx),
+			AuditLog:          NewAuditLogRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,157 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// sqliteWebhookRepository implements WebhookRepository interface using GORM
+type sqliteWebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new GORM implementation of WebhookRepository
+func NewWebhookRepository(db *gorm.DB) repositories.WebhookRepository {
+	return &sqliteWebhookRepository{
+		db: db,
+	}
+}
+
+// Save persists a new webhook to the database
+func (r *sqliteWebhookRepository) Save(webhook *entities.Webhook) error {
+	if webhook == nil {
+		return errors.New("webhook cannot be nil")
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return err
+	}
+
+	return r.db.Create(webhook).Error
+}
+
+// GetByID retrieves a webhook by its unique identifier
+func (r *sqliteWebhookRepository) GetByID(id uuid.UUID) (*entities.Webhook, error) {
+	var webhook entities.Webhook
+
+	result := r.db.Where("id = ?", id).First(&webhook)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil, nil when not found (as per interface contract)
+		}
+		return nil, result.Error
+	}
+
+	return &webhook, nil
+}
+
+// GetAll retrieves every webhook ordered by creation time
+// Reads the primary so a webhook registered moments ago receives the next event
+func (r *sqliteWebhookRepository) GetAll() ([]entities.Webhook, error) {
+	var webhooks []entities.Webhook
+
+	result := UsePrimary(r.db).Order("created_at ASC").Find(&webhooks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return webhooks, nil
+}
+
+// Delete removes a webhook together with its deliveries
+func (r *sqliteWebhookRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entities.Webhook{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errs.Newf(errs.ErrNotFound, "webhook with ID %s not found", id)
+		}
+
+		return tx.Delete(&entities.WebhookDelivery{}, "webhook_id = ?", id).Error
+	})
+}
+
+// SaveDeliveries persists new deliveries in a single statement
+func (r *sqliteWebhookRepository) SaveDeliveries(deliveries []entities.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return r.db.Create(&deliveries).Error
+}
+
+// UpdateDelivery stores the outcome of a delivery attempt
+func (r *sqliteWebhookRepository) UpdateDelivery(delivery *entities.WebhookDelivery) error {
+	if delivery == nil {
+		return errors.New("webhook delivery cannot be nil")
+	}
+
+	result := r.db.Model(&entities.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(map[string]interface{}{
+		"status":           delivery.Status,
+		"attempts":         delivery.Attempts,
+		"next_attempt_at":  delivery.NextAttemptAt,
+		"last_status_code": delivery.LastStatusCode,
+		"last_error":       delivery.LastError,
+		"delivered_at":     delivery.DeliveredAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.Newf(errs.ErrNotFound, "webhook delivery with ID %s not found", delivery.ID)
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries leases due pending deliveries, oldest first
+// Each row is claimed with a conditional update, so a row another worker claimed in between is skipped
+func (r *sqliteWebhookRepository) ClaimDueDeliveries(now time.Time, leaseUntil time.Time, limit int) ([]entities.WebhookDelivery, error) {
+	var due []entities.WebhookDelivery
+
+	result := UsePrimary(r.db).
+		Where("status = ? AND next_attempt_at <= ?", entities.DeliveryPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&due)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	claimed := make([]entities.WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		update := r.db.Model(&entities.WebhookDelivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, entities.DeliveryPending, delivery.NextAttemptAt).
+			Update("next_attempt_at", leaseUntil)
+		if update.Error != nil {
+			return nil, update.Error
+		}
+		if update.RowsAffected == 1 {
+			lease := leaseUntil
+			delivery.NextAttemptAt = &lease
+			claimed = append(claimed, delivery)
+		}
+	}
+
+	return claimed, nil
+}
+
+// FindDeliveries retrieves up to limit deliveries of a webhook, newest first
+func (r *sqliteWebhookRepository) FindDeliveries(webhookID uuid.UUID, limit int) ([]entities.WebhookDelivery, error) {
+	var deliveries []entities.WebhookDelivery
+
+	result := r.db.Where("webhook_id = ?", webhookID).Order("created_at DESC").Limit(limit).Find(&deliveries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return deliveries, nil
+}
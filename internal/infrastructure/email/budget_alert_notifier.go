@@ -0,0 +1,49 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// BudgetAlertNotifier implements BudgetAlertPublisher by emailing each alert, then passing it on
+type BudgetAlertNotifier struct {
+	sender     services.EmailSender
+	recipients []string
+	next       services.BudgetAlertPublisher
+}
+
+// NewBudgetAlertNotifier creates a publisher that emails alerts to recipients and forwards them to next
+func NewBudgetAlertNotifier(sender services.EmailSender, recipients []string, next services.BudgetAlertPublisher) services.BudgetAlertPublisher {
+	return &BudgetAlertNotifier{
+		sender:     sender,
+		recipients: recipients,
+		next:       next,
+	}
+}
+
+// PublishBudgetThresholdCrossed forwards the alert first so it is recorded even when email delivery fails
+func (n *BudgetAlertNotifier) PublishBudgetThresholdCrossed(event entities.BudgetThresholdCrossedEvent) error {
+	if n.next != nil {
+		if err := n.next.PublishBudgetThresholdCrossed(event); err != nil {
+			return err
+		}
+	}
+
+	subject := fmt.Sprintf("Budget alert: %s reached %d%% of its %s budget",
+		event.Budget.Category, event.Threshold, event.Budget.Period)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Spending in %q reached %d%% of its %s budget.\n\n", event.Budget.Category, event.Threshold, event.Budget.Period)
+	fmt.Fprintf(&body, "Period:  %s to %s\n", event.PeriodStart.Format("2006-01-02"), event.PeriodEnd.AddDate(0, 0, -1).Format("2006-01-02"))
+	fmt.Fprintf(&body, "Limit:   %s %s\n", event.Budget.Limit.Format(event.Budget.Currency), event.Budget.Currency)
+	fmt.Fprintf(&body, "Spent:   %s %s\n", event.Spent.Format(event.Budget.Currency), event.Budget.Currency)
+	fmt.Fprintf(&body, "Trigger: transaction %s\n", event.TransactionID)
+
+	if err := n.sender.Send(n.recipients, subject, body.String()); err != nil {
+		return fmt.Errorf("failed to email budget alert: %w", err)
+	}
+	return nil
+}
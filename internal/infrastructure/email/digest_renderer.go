@@ -0,0 +1,54 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+)
+
+//go:embed templates/digest.tmpl
+var defaultTemplates embed.FS
+
+// DigestRenderer renders digest reports from a template defining "subject" and "body"
+type DigestRenderer struct {
+	tmpl *template.Template
+}
+
+// NewDigestRenderer parses the digest template at path, or the built-in template when path is empty
+func NewDigestRenderer(path string) (*DigestRenderer, error) {
+	var tmpl *template.Template
+	var err error
+	if path == "" {
+		tmpl, err = template.ParseFS(defaultTemplates, "templates/digest.tmpl")
+	} else {
+		tmpl, err = template.ParseFiles(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse digest template: %w", err)
+	}
+
+	for _, name := range []string{"subject", "body"} {
+		if tmpl.Lookup(name) == nil {
+			return nil, fmt.Errorf("digest template must define %q", name)
+		}
+	}
+
+	return &DigestRenderer{tmpl: tmpl}, nil
+}
+
+// Render produces the subject and body for a digest report
+func (r *DigestRenderer) Render(report *dto.DigestReport) (string, string, error) {
+	var subject, body bytes.Buffer
+	if err := r.tmpl.ExecuteTemplate(&subject, "subject", report); err != nil {
+		return "", "", fmt.Errorf("failed to render digest subject: %w", err)
+	}
+	if err := r.tmpl.ExecuteTemplate(&body, "body", report); err != nil {
+		return "", "", fmt.Errorf("failed to render digest body: %w", err)
+	}
+
+	return strings.TrimSpace(subject.String()), body.String(), nil
+}
@@ -0,0 +1,54 @@
+package email
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// SMTPSender implements EmailSender using a plain SMTP server
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPSender creates a new SMTP-backed EmailSender
+// PLAIN auth is used only when a username is configured
+func NewSMTPSender(cfg *config.SMTPConfig) services.EmailSender {
+	sender := &SMTPSender{
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		from: cfg.From,
+	}
+	if cfg.Username != "" {
+		sender.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return sender
+}
+
+// Send delivers a plain-text message to all recipients
+func (s *SMTPSender) Send(recipients []string, subject, body string) error {
+	if len(recipients) == 0 {
+		return fmt.Errorf("at least one recipient is required")
+	}
+
+	var message strings.Builder
+	message.WriteString("From: " + s.from + "\r\n")
+	message.WriteString("To: " + strings.Join(recipients, ", ") + "\r\n")
+	message.WriteString("Subject: " + subject + "\r\n")
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(body)
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from, recipients, []byte(message.String())); err != nil {
+		return fmt.Errorf("failed to send email via %s: %w", s.addr, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// Bus implements EventBus in process, running every handler of an event in the background
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]services.EventHandler
+	running  sync.WaitGroup
+}
+
+// NewBus creates an in-process event bus with no subscribers
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[string][]services.EventHandler),
+	}
+}
+
+// Subscribe registers handler for events named eventName
+func (b *Bus) Subscribe(eventName string, handler services.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventName] = append(b.handlers[eventName], handler)
+}
+
+// Publish starts every handler of the event and returns without waiting for them
+// Handlers keep the values of ctx but not its cancellation, so they outlive the request that published
+func (b *Bus) Publish(ctx context.Context, event entities.DomainEvent) error {
+	b.mu.RLock()
+	handlers := b.handlers[event.EventName()]
+	b.mu.RUnlock()
+
+	detached := context.WithoutCancel(ctx)
+	for _, handler := range handlers {
+		b.running.Add(1)
+		go func(handler services.EventHandler) {
+			defer b.running.Done()
+
+			if err := handler(detached, event); err != nil {
+				slog.Warn("Event handler failed",
+					"event", event.EventName(),
+					"event_id", event.EventID().String(),
+					"error", err.Error(),
+				)
+			}
+		}(handler)
+	}
+	return nil
+}
+
+// Wait blocks until every handler started so far has finished
+func (b *Bus) Wait() {
+	b.running.Wait()
+}
@@ -0,0 +1,82 @@
+package events
+
+import (
+	"context"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// logPublisher implements ConversionEventPublisher and BudgetAlertPublisher by writing events to the structured log
+type logPublisher struct {
+	log *logger.Logger
+}
+
+// NewLogPublisher creates a ConversionEventPublisher that logs each event
+func NewLogPublisher(log *logger.Logger) services.ConversionEventPublisher {
+	return &logPublisher{
+		log: log,
+	}
+}
+
+// NewBudgetLogPublisher creates a BudgetAlertPublisher that logs each alert
+func NewBudgetLogPublisher(log *logger.Logger) services.BudgetAlertPublisher {
+	return &logPublisher{
+		log: log,
+	}
+}
+
+// PublishConversionSuperseded logs the replaced and replacement records
+func (p *logPublisher) PublishConversionSuperseded(event entities.ConversionSupersededEvent) error {
+	p.log.Info("Conversion superseded",
+		"event", "conversion.superseded",
+		"transaction_id", event.Previous.TransactionID.String(),
+		"target_currency", string(event.Previous.TargetCurrency),
+		"previous_record_id", event.Previous.ID.String(),
+		"previous_rate", event.Previous.ExchangeRate,
+		"previous_effective_date", event.Previous.EffectiveDate.Format("2006-01-02"),
+		"replacement_record_id", event.Replacement.ID.String(),
+		"replacement_rate", event.Replacement.ExchangeRate,
+		"replacement_effective_date", event.Replacement.EffectiveDate.Format("2006-01-02"),
+	)
+	return nil
+}
+
+// PublishBudgetThresholdCrossed logs the budget, the threshold reached and the period spend
+func (p *logPublisher) PublishBudgetThresholdCrossed(event entities.BudgetThresholdCrossedEvent) error {
+	p.log.Warn("Budget threshold crossed",
+		"event", "budget.threshold_crossed",
+		"budget_id", event.Budget.ID.String(),
+		"category", event.Budget.Category,
+		"period", string(event.Budget.Period),
+		"period_start", event.PeriodStart.Format("2006-01-02"),
+		"threshold_percent", event.Threshold,
+		"limit", event.Budget.Limit.Dollars(),
+		"spent", event.Spent.Dollars(),
+		"currency", string(event.Budget.Currency),
+		"transaction_id", event.TransactionID.String(),
+	)
+	return nil
+}
+
+// NewEventLogHandler creates an EventHandler that logs each domain event at debug level
+func NewEventLogHandler(log *logger.Logger) services.EventHandler {
+	return func(ctx context.Context, event entities.DomainEvent) error {
+		attrs := []any{
+			"event", event.EventName(),
+			"event_id", event.EventID().String(),
+		}
+		switch e := event.(type) {
+		case entities.TransactionCreatedEvent:
+			attrs = append(attrs, "transaction_id", e.Transaction.ID.String())
+		case entities.TransactionConvertedEvent:
+			attrs = append(attrs,
+				"transaction_id", e.Conversion.Transaction.ID.String(),
+				"target_currency", string(e.Conversion.TargetCurrency),
+			)
+		}
+		log.WithContext(ctx).Debug("Domain event published", attrs...)
+		return nil
+	}
+}
@@ -0,0 +1,178 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// bankPageSize is the number of transactions requested per page (the Plaid maximum)
+const bankPageSize = 500
+
+// BankAggregatorClient implements BankAggregator against a Plaid-compatible /transactions/get API
+type BankAggregatorClient struct {
+	baseURL    string
+	clientID   string
+	secret     string
+	httpClient *http.Client
+}
+
+type bankTransactionsRequest struct {
+	ClientID    string `json:"client_id"`
+	Secret      string `json:"secret"`
+	AccessToken string `json:"access_token"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+	Options     struct {
+		Count  int `json:"count"`
+		Offset int `json:"offset"`
+	} `json:"options"`
+}
+
+// BankTransactionsResponse represents a page of the /transactions/get response
+type BankTransactionsResponse struct {
+	Transactions      []BankTransactionRecord `json:"transactions"`
+	TotalTransactions int                     `json:"total_transactions"`
+}
+
+// BankTransactionRecord represents a single transaction from the aggregator
+type BankTransactionRecord struct {
+	TransactionID   string  `json:"transaction_id"`
+	AccountID       string  `json:"account_id"`
+	Amount          float64 `json:"amount"`
+	ISOCurrencyCode string  `json:"iso_currency_code"`
+	Date            string  `json:"date"`
+	Name            string  `json:"name"`
+	MerchantName    string  `json:"merchant_name"`
+	Pending         bool    `json:"pending"`
+}
+
+type bankErrorResponse struct {
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// NewBankAggregatorClient creates a new bank aggregator client with configuration
+func NewBankAggregatorClient(cfg *config.BankConfig) services.BankAggregator {
+	return &BankAggregatorClient{
+		baseURL:  strings.TrimSuffix(cfg.BaseURL, "/"),
+		clientID: cfg.ClientID,
+		secret:   cfg.Secret,
+		httpClient: &http.Client{
+			Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+		},
+	}
+}
+
+// FetchTransactions pages through /transactions/get until every transaction in the range is read
+func (c *BankAggregatorClient) FetchTransactions(ctx context.Context, accessToken string, from, to time.Time) ([]entities.BankTransaction, error) {
+	var transactions []entities.BankTransaction
+	for offset := 0; ; {
+		page, err := c.fetchPage(ctx, accessToken, from, to, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range page.Transactions {
+			transaction, err := record.toEntity()
+			if err != nil {
+				slog.Warn("Skipping unreadable bank transaction",
+					"transaction_id", record.TransactionID,
+					"error", err.Error(),
+				)
+				continue
+			}
+			transactions = append(transactions, transaction)
+		}
+
+		// Stop on an empty page as well, so a shrinking total cannot loop forever
+		offset += len(page.Transactions)
+		if len(page.Transactions) == 0 || offset >= page.TotalTransactions {
+			return transactions, nil
+		}
+	}
+}
+
+// ProviderName identifies the aggregator in external transaction IDs
+func (c *BankAggregatorClient) ProviderName() string {
+	return "plaid"
+}
+
+// fetchPage requests one page of transactions starting at offset
+func (c *BankAggregatorClient) fetchPage(ctx context.Context, accessToken string, from, to time.Time, offset int) (*BankTransactionsResponse, error) {
+	request := bankTransactionsRequest{
+		ClientID:    c.clientID,
+		Secret:      c.secret,
+		AccessToken: accessToken,
+		StartDate:   from.Format(time.DateOnly),
+		EndDate:     to.Format(time.DateOnly),
+	}
+	request.Options.Count = bankPageSize
+	request.Options.Offset = offset
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode bank aggregator request: %w", err)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/transactions/get", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build bank aggregator request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from bank aggregator: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiError bankErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&apiError) == nil && apiError.ErrorCode != "" {
+			return nil, fmt.Errorf("bank aggregator returned status %d: %s: %s", resp.StatusCode, apiError.ErrorCode, apiError.ErrorMessage)
+		}
+		return nil, fmt.Errorf("bank aggregator returned status %d", resp.StatusCode)
+	}
+
+	var page BankTransactionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to parse bank aggregator response: %w", err)
+	}
+	return &page, nil
+}
+
+// toEntity converts an aggregator record, preferring the cleaned merchant name over the raw description
+func (r BankTransactionRecord) toEntity() (entities.BankTransaction, error) {
+	if r.TransactionID == "" {
+		return entities.BankTransaction{}, fmt.Errorf("missing transaction_id")
+	}
+	date, err := time.Parse(time.DateOnly, r.Date)
+	if err != nil {
+		return entities.BankTransaction{}, fmt.Errorf("invalid date %q: %w", r.Date, err)
+	}
+
+	description := r.MerchantName
+	if description == "" {
+		description = r.Name
+	}
+
+	return entities.BankTransaction{
+		ID:          r.TransactionID,
+		AccountID:   r.AccountID,
+		Description: description,
+		Amount:      r.Amount,
+		Currency:    r.ISOCurrencyCode,
+		Date:        date,
+		Pending:     r.Pending,
+	}, nil
+}
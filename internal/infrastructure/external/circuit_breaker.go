@@ -0,0 +1,223 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// Circuit breaker states
+const (
+	BreakerClosed   = "closed"    // Calls go through; consecutive failures are counted
+	BreakerOpen     = "open"      // Calls fail fast until the open period ends
+	BreakerHalfOpen = "half_open" // A limited number of trial calls decide whether to close or reopen
+)
+
+// CircuitBreakerSettings configures when a breaker opens and how it recovers
+type CircuitBreakerSettings struct {
+	FailureThreshold int           // Consecutive failures that open the breaker
+	OpenDuration     time.Duration // How long the breaker fails fast before letting trial calls through
+	HalfOpenMaxCalls int           // Trial calls allowed at once while half-open
+}
+
+// CircuitBreaker stops calling a failing dependency for a while so callers fail fast instead of waiting for timeouts
+type CircuitBreaker struct {
+	name     string
+	settings CircuitBreakerSettings
+	clock    clock.Clock
+
+	mu                  sync.Mutex
+	state               string
+	consecutiveFailures int
+	openedAt            time.Time
+	halfOpenCalls       int
+}
+
+// NewCircuitBreaker creates a closed circuit breaker; non-positive settings fall back to one failure, call and second
+func NewCircuitBreaker(name string, settings CircuitBreakerSettings) *CircuitBreaker {
+	settings.FailureThreshold = max(settings.FailureThreshold, 1)
+	settings.HalfOpenMaxCalls = max(settings.HalfOpenMaxCalls, 1)
+	if settings.OpenDuration <= 0 {
+		settings.OpenDuration = time.Second
+	}
+
+	return &CircuitBreaker{
+		name:     name,
+		settings: settings,
+		clock:    clock.System(),
+		state:    BreakerClosed,
+	}
+}
+
+// WithClock sets the clock the open period is measured with
+func (b *CircuitBreaker) WithClock(clk clock.Clock) *CircuitBreaker {
+	b.clock = clk
+	return b
+}
+
+// NewTreasuryCircuitBreaker creates the breaker guarding the Treasury API from configuration
+func NewTreasuryCircuitBreaker(cfg *config.TreasuryConfig) *CircuitBreaker {
+	return NewCircuitBreaker("treasury", CircuitBreakerSettings{
+		FailureThreshold: cfg.BreakerFailureThreshold,
+		OpenDuration:     time.Duration(cfg.BreakerOpenSeconds) * time.Second,
+		HalfOpenMaxCalls: cfg.BreakerHalfOpenMaxCalls,
+	})
+}
+
+// Allow reports whether a call may go ahead, moving an open breaker to half-open once its open period ends
+// Every allowed call must be followed by Record
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen {
+		retryAt := b.openedAt.Add(b.settings.OpenDuration)
+		if b.clock.Now().Before(retryAt) {
+			return errs.Newf(errs.ErrServiceUnavailable, "%s unavailable: circuit breaker open until %s", b.name, retryAt.UTC().Format(time.RFC3339))
+		}
+		b.transition(BreakerHalfOpen)
+	}
+
+	if b.state == BreakerHalfOpen {
+		if b.halfOpenCalls >= b.settings.HalfOpenMaxCalls {
+			return fmt.Errorf("%s unavailable: circuit breaker half-open, trial call in progress", b.name)
+		}
+		b.halfOpenCalls++
+	}
+
+	return nil
+}
+
+// Record reports the outcome of an allowed call
+// A failure while half-open reopens the breaker; a success closes it
+func (b *CircuitBreaker) Record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.halfOpenCalls--
+		if failed {
+			b.open()
+		} else {
+			b.consecutiveFailures = 0
+			b.transition(BreakerClosed)
+		}
+		return
+	}
+
+	if !failed {
+		b.consecutiveFailures = 0
+		return
+	}
+
+	b.consecutiveFailures++
+	if b.state == BreakerClosed && b.consecutiveFailures >= b.settings.FailureThreshold {
+		b.open()
+	}
+}
+
+// State reports the current state; an open breaker whose open period ended reports half-open
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && !b.clock.Now().Before(b.openedAt.Add(b.settings.OpenDuration)) {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// ConsecutiveFailures reports the failures counted since the last success
+func (b *CircuitBreaker) ConsecutiveFailures() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.consecutiveFailures
+}
+
+// RetryAt reports when an open breaker lets a trial call through, or nil when it is not open
+func (b *CircuitBreaker) RetryAt() *time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != BreakerOpen {
+		return nil
+	}
+	retryAt := b.openedAt.Add(b.settings.OpenDuration)
+	return &retryAt
+}
+
+// open trips the breaker; callers hold the lock
+func (b *CircuitBreaker) open() {
+	b.openedAt = b.clock.Now()
+	b.transition(BreakerOpen)
+}
+
+// transition logs and applies a state change; callers hold the lock
+func (b *CircuitBreaker) transition(state string) {
+	if b.state == state {
+		return
+	}
+	slog.Warn("Circuit breaker state changed",
+		"breaker", b.name,
+		"from", b.state,
+		"to", state,
+		"consecutive_failures", b.consecutiveFailures,
+	)
+	b.state = state
+	b.halfOpenCalls = 0
+}
+
+// circuitBreakerTreasuryService fails Treasury calls fast while the breaker is open
+type circuitBreakerTreasuryService struct {
+	services.RateProvider
+	breaker *CircuitBreaker
+}
+
+// NewCircuitBreakerTreasuryService wraps a RateProvider so outages open the breaker
+func NewCircuitBreakerTreasuryService(inner services.RateProvider, breaker *CircuitBreaker) services.RateProvider {
+	return &circuitBreakerTreasuryService{
+		RateProvider: inner,
+		breaker:      breaker,
+	}
+}
+
+// FetchExchangeRate delegates to the wrapped service unless the breaker is open
+// Only outages count as failures; a rate that does not exist is a normal answer, and neither is a call the caller abandoned
+func (s *circuitBreakerTreasuryService) FetchExchangeRate(ctx context.Context, from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	if err := s.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	exchangeRate, err := s.RateProvider.FetchExchangeRate(ctx, from, to, date)
+	s.breaker.Record(err != nil && ctx.Err() == nil && isTreasuryOutage(err))
+	return exchangeRate, err
+}
+
+// FetchExchangeRates delegates to the wrapped service unless the breaker is open, counting outages like FetchExchangeRate
+func (s *circuitBreakerTreasuryService) FetchExchangeRates(ctx context.Context, from, to entities.CurrencyCode, startDate, endDate time.Time) ([]entities.ExchangeRate, error) {
+	if err := s.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	exchangeRates, err := s.RateProvider.FetchExchangeRates(ctx, from, to, startDate, endDate)
+	s.breaker.Record(err != nil && ctx.Err() == nil && isTreasuryOutage(err))
+	return exchangeRates, err
+}
+
+// isTreasuryOutage reports whether a Treasury error means the API is unreachable or failing
+func isTreasuryOutage(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "failed to fetch from Treasury API") ||
+		strings.Contains(message, "failed to parse Treasury API response") ||
+		strings.Contains(message, "Treasury API returned status 5") ||
+		strings.Contains(message, "Treasury API returned status 429")
+}
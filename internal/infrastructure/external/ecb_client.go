@@ -0,0 +1,237 @@
+package external
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+)
+
+// ecbCurrencies are the currencies the ECB publishes euro reference rates for, besides the euro itself
+var ecbCurrencies = []entities.CurrencyCode{
+	"USD", "JPY", "BGN", "CZK", "DKK", "GBP", "HUF", "PLN", "RON", "SEK", "CHF", "ISK", "NOK", "TRY",
+	"AUD", "BRL", "CAD", "CNY", "HKD", "IDR", "ILS", "INR", "KRW", "MXN", "MYR", "NZD", "PHP", "SGD", "THB", "ZAR",
+}
+
+// ECBClient implements RateProvider with the European Central Bank euro foreign exchange reference rates
+// The ECB publishes units per euro, so USD rates are derived by crossing each currency with the dollar
+type ECBClient struct {
+	url        string
+	httpClient *http.Client
+	window     entities.RateWindow // How far before the requested date rates are searched
+	refresh    time.Duration       // How long downloaded rates are reused
+	clock      clock.Clock
+
+	mu           sync.Mutex
+	days         []ecbDay // Newest first
+	downloadedAt time.Time
+}
+
+// ecbDay holds the units per euro published for one day
+type ecbDay struct {
+	date   time.Time
+	perEUR map[entities.CurrencyCode]float64
+}
+
+// ecbEnvelope is the layout of the eurofxref XML files: Cube > Cube time > Cube currency rate
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// NewECBClient creates an ECB reference rate client searching the rates published within window before the requested date
+func NewECBClient(cfg *config.RateProvidersConfig, window entities.RateWindow) *ECBClient {
+	return &ECBClient{
+		url:        cfg.ECBRatesURL,
+		httpClient: &http.Client{Timeout: time.Duration(cfg.ECBTimeoutSeconds) * time.Second},
+		window:     window,
+		refresh:    time.Duration(cfg.ECBRefreshMinutes) * time.Minute,
+		clock:      clock.System(),
+	}
+}
+
+// WithClock sets the clock downloaded rates age by
+func (c *ECBClient) WithClock(clk clock.Clock) *ECBClient {
+	c.clock = clk
+	return c
+}
+
+// FetchExchangeRate returns the most recent ECB rate within the lookback window before date
+func (c *ECBClient) FetchExchangeRate(ctx context.Context, from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	if from != entities.USD {
+		return nil, fmt.Errorf("ECB reference rates are only served with USD as base currency, got %s", from)
+	}
+
+	days, err := c.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, day := range days {
+		if day.date.After(date) {
+			continue
+		}
+		rate, ok := day.usdRate(to)
+		if !ok {
+			continue
+		}
+		if !rate.IsWithinDateRange(date, c.window) {
+			break
+		}
+		return rate, nil
+	}
+	return nil, errs.Newf(errs.ErrRateUnavailable, "no ECB reference rate found for %s within %s of %s", to, c.window, date.Format("2006-01-02"))
+}
+
+// FetchExchangeRates returns the ECB rates published between startDate and endDate, newest first
+func (c *ECBClient) FetchExchangeRates(ctx context.Context, from, to entities.CurrencyCode, startDate, endDate time.Time) ([]entities.ExchangeRate, error) {
+	if from != entities.USD {
+		return nil, fmt.Errorf("ECB reference rates are only served with USD as base currency, got %s", from)
+	}
+
+	days, err := c.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := []entities.ExchangeRate{}
+	for _, day := range days {
+		if day.date.After(endDate) || day.date.Before(startDate.Truncate(24*time.Hour)) {
+			continue
+		}
+		if rate, ok := day.usdRate(to); ok {
+			rates = append(rates, *rate)
+		}
+	}
+	return rates, nil
+}
+
+// SupportsCurrency reports whether the ECB publishes a reference rate for the currency, the euro included
+func (c *ECBClient) SupportsCurrency(code entities.CurrencyCode) bool {
+	return code == entities.EUR || (code != entities.USD && slices.Contains(ecbCurrencies, code))
+}
+
+// ProviderName identifies the ECB as the rate source
+func (c *ECBClient) ProviderName() string {
+	return "ecb"
+}
+
+// load returns the published days, downloading them again once they are older than the refresh interval
+// Callers wait on the same download rather than starting their own
+func (c *ECBClient) load(ctx context.Context) ([]ecbDay, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if c.days != nil && now.Sub(c.downloadedAt) < c.refresh {
+		return c.days, nil
+	}
+
+	startTime := time.Now()
+	days, err := c.download(ctx)
+	if err != nil {
+		slog.Error("ECB reference rate download failed",
+			"error", err.Error(),
+			"duration", time.Since(startTime),
+			"url", c.url,
+		)
+		return nil, err
+	}
+
+	slog.Info("ECB reference rates downloaded",
+		"days", len(days),
+		"duration", time.Since(startTime),
+	)
+	c.days, c.downloadedAt = days, now
+	return days, nil
+}
+
+// download fetches and parses the reference rate file
+func (c *ECBClient) download(ctx context.Context) ([]ecbDay, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ECB request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ECB reference rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ECB returned status %d", resp.StatusCode)
+	}
+
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse ECB reference rates: %w", err)
+	}
+	return parseECBDays(envelope), nil
+}
+
+// parseECBDays converts the published days, skipping invalid dates and rates, and sorts them newest first
+func parseECBDays(envelope ecbEnvelope) []ecbDay {
+	days := make([]ecbDay, 0, len(envelope.Days))
+	for _, published := range envelope.Days {
+		date, err := time.Parse("2006-01-02", published.Time)
+		if err != nil {
+			continue // Skip invalid days
+		}
+		day := ecbDay{date: date, perEUR: make(map[entities.CurrencyCode]float64, len(published.Rates))}
+		for _, rate := range published.Rates {
+			value, err := strconv.ParseFloat(rate.Rate, 64)
+			if err != nil || value <= 0 {
+				continue // Skip invalid rates
+			}
+			day.perEUR[entities.CurrencyCode(rate.Currency)] = value
+		}
+		days = append(days, day)
+	}
+
+	// The files list days newest first, but nothing guarantees it
+	sort.Slice(days, func(i, j int) bool { return days[i].date.After(days[j].date) })
+	return days
+}
+
+// usdRate derives the USD→to rate of the day from the units of both currencies per euro
+func (d ecbDay) usdRate(to entities.CurrencyCode) (*entities.ExchangeRate, bool) {
+	perUSD, ok := d.perEUR[entities.USD]
+	if !ok {
+		return nil, false
+	}
+	perTo := 1.0
+	if to != entities.EUR {
+		if perTo, ok = d.perEUR[to]; !ok {
+			return nil, false
+		}
+	}
+
+	return &entities.ExchangeRate{
+		ID:            uuid.New(),
+		FromCurrency:  entities.USD,
+		ToCurrency:    to,
+		Rate:          math.Round(perTo/perUSD*10000) / 10000,
+		EffectiveDate: d.date,
+		RecordDate:    d.date,
+		Source:        "ecb",
+	}, true
+}
@@ -0,0 +1,140 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+)
+
+// fallbackRateProvider asks its providers in order, moving on to the next when one fails or has no rate
+type fallbackRateProvider struct {
+	providers []services.RateProvider
+}
+
+// NewFallbackRateProvider chains providers so a rate missing from one is fetched from the next supporting the currency
+// A single provider is returned unwrapped
+func NewFallbackRateProvider(providers ...services.RateProvider) services.RateProvider {
+	if len(providers) == 1 {
+		return providers[0]
+	}
+	return &fallbackRateProvider{providers: providers}
+}
+
+// NewConfiguredRateProvider chains the providers named in cfg.Order, treasury being the already built Treasury client
+func NewConfiguredRateProvider(cfg *config.RateProvidersConfig, treasury services.RateProvider, window entities.RateWindow) (services.RateProvider, error) {
+	providers := make([]services.RateProvider, 0, len(cfg.Order))
+	for _, name := range cfg.Order {
+		switch name {
+		case "treasury":
+			providers = append(providers, treasury)
+		case "ecb":
+			providers = append(providers, NewECBClient(cfg, window))
+		case "fixed":
+			fixed, err := NewFixedRateProvider(cfg.FixedRates)
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, fixed)
+		default:
+			return nil, fmt.Errorf("unknown rate provider %q", name)
+		}
+	}
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no rate provider configured")
+	}
+	return NewFallbackRateProvider(providers...), nil
+}
+
+// FetchExchangeRate returns the rate of the first provider able to answer; the last error when none is
+func (p *fallbackRateProvider) FetchExchangeRate(ctx context.Context, from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	var lastErr error
+	for _, provider := range p.supporting(to) {
+		exchangeRate, err := provider.FetchExchangeRate(ctx, from, to, date)
+		if err == nil {
+			return exchangeRate, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+		slog.Warn("Rate provider failed, trying the next one",
+			"provider", provider.ProviderName(),
+			"to_currency", string(to),
+			"date", date.Format("2006-01-02"),
+			"error", err.Error(),
+		)
+	}
+	return nil, p.noProvider(to, lastErr)
+}
+
+// FetchExchangeRates returns the rates of the first provider able to answer with at least one rate
+// An empty answer is returned when every provider answers without rates
+func (p *fallbackRateProvider) FetchExchangeRates(ctx context.Context, from, to entities.CurrencyCode, startDate, endDate time.Time) ([]entities.ExchangeRate, error) {
+	var lastErr error
+	answered := false
+	for _, provider := range p.supporting(to) {
+		exchangeRates, err := provider.FetchExchangeRates(ctx, from, to, startDate, endDate)
+		if err == nil && len(exchangeRates) > 0 {
+			return exchangeRates, nil
+		}
+		if err == nil {
+			answered = true
+			continue
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+		slog.Warn("Rate provider failed, trying the next one",
+			"provider", provider.ProviderName(),
+			"to_currency", string(to),
+			"start_date", startDate.Format("2006-01-02"),
+			"end_date", endDate.Format("2006-01-02"),
+			"error", err.Error(),
+		)
+	}
+	if answered && ctx.Err() == nil {
+		return []entities.ExchangeRate{}, nil
+	}
+	return nil, p.noProvider(to, lastErr)
+}
+
+// SupportsCurrency reports whether any provider supports the currency
+func (p *fallbackRateProvider) SupportsCurrency(code entities.CurrencyCode) bool {
+	return len(p.supporting(code)) > 0
+}
+
+// ProviderName lists the providers in the order they are tried, e.g. "us_treasury,ecb"
+func (p *fallbackRateProvider) ProviderName() string {
+	names := make([]string, len(p.providers))
+	for i, provider := range p.providers {
+		names[i] = provider.ProviderName()
+	}
+	return strings.Join(names, ",")
+}
+
+// supporting returns the providers supporting the currency, in order
+func (p *fallbackRateProvider) supporting(code entities.CurrencyCode) []services.RateProvider {
+	var supporting []services.RateProvider
+	for _, provider := range p.providers {
+		if provider.SupportsCurrency(code) {
+			supporting = append(supporting, provider)
+		}
+	}
+	return supporting
+}
+
+// noProvider is the error answered when no provider returned a rate
+func (p *fallbackRateProvider) noProvider(to entities.CurrencyCode, lastErr error) error {
+	if lastErr != nil {
+		return lastErr
+	}
+	return errs.Newf(errs.ErrRateUnavailable, "no rate provider supports %s", to)
+}
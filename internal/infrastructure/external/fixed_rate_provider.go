@@ -0,0 +1,80 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+)
+
+// FixedRateProvider implements RateProvider with rates set in configuration, a last resort when no published rate is available
+// A rate applies to every date, so each lookup answers it as effective on the requested date
+type FixedRateProvider struct {
+	rates map[entities.CurrencyCode]float64 // Units of each currency per US dollar
+}
+
+// NewFixedRateProvider creates a provider serving rates, keyed by currency code, as units per US dollar
+func NewFixedRateProvider(rates map[string]float64) (*FixedRateProvider, error) {
+	provider := &FixedRateProvider{rates: make(map[entities.CurrencyCode]float64, len(rates))}
+	for code, rate := range rates {
+		currency, err := entities.NewCurrencyCode(code)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixed rate currency %q: %w", code, err)
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("fixed rate for %s must be positive, got %v", currency, rate)
+		}
+		provider.rates[currency] = rate
+	}
+	return provider, nil
+}
+
+// FetchExchangeRate returns the configured rate as effective on date
+func (p *FixedRateProvider) FetchExchangeRate(ctx context.Context, from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	return p.rate(from, to, date)
+}
+
+// FetchExchangeRates returns the configured rate once, as effective on endDate
+func (p *FixedRateProvider) FetchExchangeRates(ctx context.Context, from, to entities.CurrencyCode, startDate, endDate time.Time) ([]entities.ExchangeRate, error) {
+	rate, err := p.rate(from, to, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return []entities.ExchangeRate{*rate}, nil
+}
+
+// SupportsCurrency reports whether a rate is configured for the currency
+func (p *FixedRateProvider) SupportsCurrency(code entities.CurrencyCode) bool {
+	_, exists := p.rates[code]
+	return exists
+}
+
+// ProviderName identifies configured rates as the rate source
+func (p *FixedRateProvider) ProviderName() string {
+	return "fixed"
+}
+
+// rate builds the USD→to rate effective on the day of date
+func (p *FixedRateProvider) rate(from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	if from != entities.USD {
+		return nil, fmt.Errorf("fixed rates are only served with USD as base currency, got %s", from)
+	}
+	rate, exists := p.rates[to]
+	if !exists {
+		return nil, errs.Newf(errs.ErrRateUnavailable, "no fixed rate configured for %s", to)
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return &entities.ExchangeRate{
+		ID:            uuid.New(),
+		FromCurrency:  entities.USD,
+		ToCurrency:    to,
+		Rate:          rate,
+		EffectiveDate: day,
+		RecordDate:    day,
+		Source:        "fixed",
+	}, nil
+}
@@ -0,0 +1,42 @@
+package external
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
+)
+
+// instrumentedTreasuryService records failed Treasury calls on an activity recorder
+type instrumentedTreasuryService struct {
+	services.RateProvider
+	recorder *activity.Recorder
+}
+
+// NewInstrumentedTreasuryService wraps a RateProvider so failed fetches are counted
+func NewInstrumentedTreasuryService(inner services.RateProvider, recorder *activity.Recorder) services.RateProvider {
+	return &instrumentedTreasuryService{
+		RateProvider: inner,
+		recorder:     recorder,
+	}
+}
+
+// FetchExchangeRate delegates to the wrapped service and counts errors
+func (s *instrumentedTreasuryService) FetchExchangeRate(ctx context.Context, from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	exchangeRate, err := s.RateProvider.FetchExchangeRate(ctx, from, to, date)
+	if err != nil {
+		s.recorder.RecordTreasuryFailure()
+	}
+	return exchangeRate, err
+}
+
+// FetchExchangeRates delegates to the wrapped service and counts errors
+func (s *instrumentedTreasuryService) FetchExchangeRates(ctx context.Context, from, to entities.CurrencyCode, startDate, endDate time.Time) ([]entities.ExchangeRate, error) {
+	exchangeRates, err := s.RateProvider.FetchExchangeRates(ctx, from, to, startDate, endDate)
+	if err != nil {
+		s.recorder.RecordTreasuryFailure()
+	}
+	return exchangeRates, err
+}
@@ -0,0 +1,57 @@
+package external
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/errortracking"
+)
+
+// reportingTreasuryService reports Treasury outages to the error tracker
+type reportingTreasuryService struct {
+	services.RateProvider
+	reporter *errortracking.Reporter
+}
+
+// NewReportingTreasuryService wraps a RateProvider so outages are reported with the currencies and date requested
+// A nil reporter leaves inner unwrapped
+func NewReportingTreasuryService(inner services.RateProvider, reporter *errortracking.Reporter) services.RateProvider {
+	if reporter == nil {
+		return inner
+	}
+	return &reportingTreasuryService{
+		RateProvider: inner,
+		reporter:     reporter,
+	}
+}
+
+// FetchExchangeRate delegates to the wrapped service and reports outages, like the circuit breaker counts them
+func (s *reportingTreasuryService) FetchExchangeRate(ctx context.Context, from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	exchangeRate, err := s.RateProvider.FetchExchangeRate(ctx, from, to, date)
+	if err != nil && ctx.Err() == nil && isTreasuryOutage(err) {
+		s.reporter.CaptureError(ctx, err, map[string]string{
+			"dependency": "treasury",
+			"from":       string(from),
+			"to":         string(to),
+			"date":       date.Format("2006-01-02"),
+		})
+	}
+	return exchangeRate, err
+}
+
+// FetchExchangeRates delegates to the wrapped service and reports outages with the date range requested
+func (s *reportingTreasuryService) FetchExchangeRates(ctx context.Context, from, to entities.CurrencyCode, startDate, endDate time.Time) ([]entities.ExchangeRate, error) {
+	exchangeRates, err := s.RateProvider.FetchExchangeRates(ctx, from, to, startDate, endDate)
+	if err != nil && ctx.Err() == nil && isTreasuryOutage(err) {
+		s.reporter.CaptureError(ctx, err, map[string]string{
+			"dependency": "treasury",
+			"from":       string(from),
+			"to":         string(to),
+			"start_date": startDate.Format("2006-01-02"),
+			"end_date":   endDate.Format("2006-01-02"),
+		})
+	}
+	return exchangeRates, err
+}
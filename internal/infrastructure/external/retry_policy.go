@@ -0,0 +1,85 @@
+package external
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+)
+
+// RetryPolicy decides whether and when a failed Treasury request is attempted again
+type RetryPolicy struct {
+	MaxAttempts     int           // Attempts including the first; values below 1 mean a single attempt
+	BaseDelay       time.Duration // Delay before the first retry, doubled on every further one
+	MaxDelay        time.Duration // Upper bound of a single delay, including a server's Retry-After
+	Jitter          float64       // Share of each delay, between 0 and 1, that is randomly taken off
+	RetryableStatus map[int]bool  // Response statuses worth retrying
+}
+
+// NewRetryPolicy builds the retry policy of the Treasury client from configuration
+func NewRetryPolicy(cfg *config.TreasuryConfig) RetryPolicy {
+	retryable := make(map[int]bool, len(cfg.RetryStatusCodes))
+	for _, code := range cfg.RetryStatusCodes {
+		retryable[code] = true
+	}
+
+	return RetryPolicy{
+		MaxAttempts:     cfg.RetryMaxAttempts,
+		BaseDelay:       time.Duration(cfg.RetryBaseDelayMs) * time.Millisecond,
+		MaxDelay:        time.Duration(cfg.RetryMaxDelayMs) * time.Millisecond,
+		Jitter:          float64(cfg.RetryJitterPercent) / 100,
+		RetryableStatus: retryable,
+	}
+}
+
+// attempts is the number of tries allowed per request
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// ShouldRetry reports whether a response status is worth another attempt
+func (p RetryPolicy) ShouldRetry(statusCode int) bool {
+	return p.RetryableStatus[statusCode]
+}
+
+// Backoff returns the delay before retry number attempt (1 for the first retry)
+// The delay grows exponentially from BaseDelay up to MaxDelay, then loses a random share of up to Jitter
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 && delay > 0 {
+		delay -= time.Duration(float64(delay) * jitter * rand.Float64())
+	}
+
+	return delay
+}
+
+// delayFor returns the wait before retrying after resp, honouring a Retry-After header in seconds
+// when it asks for longer than the backoff, but never waiting beyond MaxDelay
+func (p RetryPolicy) delayFor(attempt int, resp *http.Response) time.Duration {
+	delay := p.Backoff(attempt)
+	if resp == nil {
+		return delay
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		retryAfter := time.Duration(seconds) * time.Second
+		if p.MaxDelay > 0 && retryAfter > p.MaxDelay {
+			retryAfter = p.MaxDelay
+		}
+		delay = max(delay, retryAfter)
+	}
+
+	return delay
+}
@@ -1,24 +1,42 @@
 package external
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
 )
 
-// TreasuryAPIClient implements TreasuryService interface using the real Treasury API
+// Paging defaults used when the configuration leaves them unset
+const (
+	defaultTreasuryPageSize = 100
+	defaultTreasuryMaxPages = 10
+)
+
+// TreasuryAPIClient implements RateProvider interface using the real Treasury API
 type TreasuryAPIClient struct {
 	baseURL    string
 	httpClient *http.Client
 	timeout    time.Duration
+	retry      RetryPolicy
+	window     entities.RateWindow    // How far before the requested date rates are searched
+	currencies TreasuryCurrencies     // Treasury descriptors of each supported currency
+	pageSize   int                    // Records requested per page
+	maxPages   int                    // Pages read per lookup before giving up
+	responses  *treasuryResponseCache // Nil when response caching is disabled
+	clock      clock.Clock
 }
 
 // TreasuryAPIResponse represents the response structure from Treasury API
@@ -27,6 +45,7 @@ type TreasuryAPIResponse struct {
 	Meta struct {
 		Count      int `json:"count"`
 		TotalCount int `json:"total-count"`
+		TotalPages int `json:"total-pages"`
 	} `json:"meta"`
 }
 
@@ -40,19 +59,38 @@ type TreasuryRecord struct {
 	EffectiveDate       string `json:"effective_date"`
 }
 
-// NewTreasuryAPIClient creates a new Treasury API client with configuration
-func NewTreasuryAPIClient(cfg *config.TreasuryConfig) services.TreasuryService {
+// NewTreasuryAPIClient creates a new Treasury API client with configuration and the built-in currency mapping
+// Lookups search the rates published within window before the requested date
+func NewTreasuryAPIClient(cfg *config.TreasuryConfig, window entities.RateWindow) services.RateProvider {
+	return NewTreasuryAPIClientWithCurrencies(cfg, window, DefaultTreasuryCurrencies())
+}
+
+// NewTreasuryAPIClientWithCurrencies creates a Treasury API client that supports exactly the currencies mapped in currencies
+func NewTreasuryAPIClientWithCurrencies(cfg *config.TreasuryConfig, window entities.RateWindow, currencies TreasuryCurrencies) services.RateProvider {
 	return &TreasuryAPIClient{
 		baseURL: cfg.BaseURL,
 		httpClient: &http.Client{
 			Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
 		},
-		timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+		timeout:    time.Duration(cfg.TimeoutSeconds) * time.Second,
+		retry:      NewRetryPolicy(cfg),
+		window:     window,
+		currencies: currencies,
+		pageSize:   orDefault(cfg.PageSize, defaultTreasuryPageSize),
+		maxPages:   orDefault(cfg.MaxPages, defaultTreasuryMaxPages),
+		responses:  newTreasuryResponseCache(time.Duration(cfg.ResponseCacheSeconds)*time.Second, cfg.ResponseCacheMaxEntries),
+		clock:      clock.System(),
 	}
 }
 
+// WithClock sets the clock fetched rates are stamped with and cached responses age by
+func (c *TreasuryAPIClient) WithClock(clk clock.Clock) *TreasuryAPIClient {
+	c.clock = clk
+	return c
+}
+
 // FetchExchangeRate retrieves exchange rate from Treasury API for a specific date
-func (c *TreasuryAPIClient) FetchExchangeRate(from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+func (c *TreasuryAPIClient) FetchExchangeRate(ctx context.Context, from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
 	startTime := time.Now()
 
 	// Treasury API only supports USD as base currency
@@ -64,71 +102,228 @@ func (c *TreasuryAPIClient) FetchExchangeRate(from, to entities.CurrencyCode, da
 		return nil, fmt.Errorf("Treasury API only supports USD as base currency, got %s", from)
 	}
 
-	// Calculate date range (6 months before the transaction date)
-	sixMonthsAgo := date.AddDate(0, -6, 0)
+	// Records come newest first, so later pages are only needed while earlier ones hold no usable rate
+	for page := 1; ; page++ {
+		// Build API URL with filters for the lookback window before the transaction date
+		url := c.buildURL(to, c.window.Start(date), date, page)
+
+		slog.Info("Calling Treasury API",
+			"from_currency", string(from),
+			"to_currency", string(to),
+			"date", date.Format("2006-01-02"),
+			"page", page,
+			"url", url,
+			"currency_descriptors", c.currencies[to],
+		)
+
+		apiResponse, err := c.fetch(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+
+		slog.Info("Treasury API call successful",
+			"page", page,
+			"duration", time.Since(startTime),
+		)
+
+		// Find the most recent rate within the date range
+		exchangeRate, err := c.parseExchangeRate(apiResponse.Data, from, to, date)
+		if err == nil {
+			return exchangeRate, nil
+		}
+		if !c.hasNextPage(apiResponse, page) {
+			return nil, err
+		}
+		if page >= c.maxPages {
+			slog.Warn("Treasury API page limit reached without a usable rate",
+				"to_currency", string(to),
+				"date", date.Format("2006-01-02"),
+				"pages", page,
+				"total_count", apiResponse.Meta.TotalCount,
+			)
+			return nil, err
+		}
+	}
+}
+
+// FetchExchangeRates retrieves every rate published between startDate and endDate from the Treasury API
+// Pages are read until the range is exhausted or the page limit is reached, which is logged
+func (c *TreasuryAPIClient) FetchExchangeRates(ctx context.Context, from, to entities.CurrencyCode, startDate, endDate time.Time) ([]entities.ExchangeRate, error) {
+	if from != entities.USD {
+		return nil, fmt.Errorf("Treasury API only supports USD as base currency, got %s", from)
+	}
+
+	startTime := time.Now()
+	rates := []entities.ExchangeRate{}
+	for page := 1; ; page++ {
+		url := c.buildURL(to, startDate, endDate, page)
+
+		slog.Info("Calling Treasury API for a date range",
+			"from_currency", string(from),
+			"to_currency", string(to),
+			"start_date", startDate.Format("2006-01-02"),
+			"end_date", endDate.Format("2006-01-02"),
+			"page", page,
+			"url", url,
+		)
+
+		apiResponse, err := c.fetch(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range apiResponse.Data {
+			rate, err := c.parseRecord(record, from, to)
+			if err != nil {
+				continue // Skip invalid records
+			}
+			rates = append(rates, *rate)
+		}
 
-	// Build API URL with filters
-	url := c.buildURL(to, sixMonthsAgo, date)
+		if !c.hasNextPage(apiResponse, page) {
+			break
+		}
+		if page >= c.maxPages {
+			slog.Warn("Treasury API page limit reached before the end of the date range",
+				"to_currency", string(to),
+				"start_date", startDate.Format("2006-01-02"),
+				"end_date", endDate.Format("2006-01-02"),
+				"pages", page,
+				"total_count", apiResponse.Meta.TotalCount,
+			)
+			break
+		}
+	}
 
-	slog.Info("Calling Treasury API",
-		"from_currency", string(from),
+	slog.Info("Treasury API range call successful",
 		"to_currency", string(to),
-		"date", date.Format("2006-01-02"),
-		"url", url,
-		"currency_filter", c.mapCurrencyCodeToFilter(to),
+		"rates", len(rates),
+		"duration", time.Since(startTime),
 	)
+	return rates, nil
+}
 
-	// Make HTTP request
-	resp, err := c.httpClient.Get(url)
-	duration := time.Since(startTime)
+// orDefault returns value, or fallback when value is not positive
+func orDefault(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
 
-	if err != nil {
-		slog.Error("Failed to fetch from Treasury API",
+// hasNextPage reports whether the Treasury has records beyond page
+// Responses without paging metadata are treated as complete
+func (c *TreasuryAPIClient) hasNextPage(apiResponse *TreasuryAPIResponse, page int) bool {
+	if apiResponse.Meta.TotalPages > 0 {
+		return page < apiResponse.Meta.TotalPages
+	}
+	return len(apiResponse.Data) > 0 && page*c.pageSize < apiResponse.Meta.TotalCount
+}
+
+// fetch answers from the response cache while the cached response is fresh, and from the API otherwise
+func (c *TreasuryAPIClient) fetch(ctx context.Context, url string) (*TreasuryAPIResponse, error) {
+	cached, fresh := c.responses.get(url, c.clock.Now())
+	if fresh {
+		slog.Debug("Treasury API response served from cache", "url", url)
+		return cached.response, nil
+	}
+	return c.fetchWithRetry(ctx, url, cached)
+}
+
+// fetchWithRetry performs the GET, retrying network errors and retryable statuses with backoff
+// A stale cached response is revalidated rather than downloaded again
+// Every failed attempt is logged with the delay before the next one; no retry is made once ctx is done
+func (c *TreasuryAPIClient) fetchWithRetry(ctx context.Context, url string, cached *cachedTreasuryResponse) (*TreasuryAPIResponse, error) {
+	attempts := c.retry.attempts()
+	for attempt := 1; ; attempt++ {
+		attemptStart := time.Now()
+		apiResponse, resp, err := c.fetchOnce(ctx, url, cached)
+		if err == nil {
+			return apiResponse, nil
+		}
+
+		retryable := (resp == nil || c.retry.ShouldRetry(resp.StatusCode)) && ctx.Err() == nil
+		if !retryable || attempt >= attempts {
+			slog.Error("Treasury API request failed",
+				"error", err.Error(),
+				"attempt", attempt,
+				"max_attempts", attempts,
+				"retryable", retryable,
+				"duration", time.Since(attemptStart),
+				"url", url,
+			)
+			if attempt > 1 {
+				return nil, fmt.Errorf("%w (after %d attempts)", err, attempt)
+			}
+			return nil, err
+		}
+
+		delay := c.retry.delayFor(attempt, resp)
+		slog.Warn("Treasury API request failed, retrying",
 			"error", err.Error(),
-			"duration", duration,
+			"attempt", attempt,
+			"max_attempts", attempts,
+			"retry_in", delay,
+			"duration", time.Since(attemptStart),
 			"url", url,
 		)
-		return nil, fmt.Errorf("failed to fetch from Treasury API: %w", err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to fetch from Treasury API: %w (after %d attempts)", ctx.Err(), attempt)
+		}
+	}
+}
+
+// fetchOnce performs a single GET, conditional when a cached response can be revalidated, and decodes the response
+// The response is returned alongside a status error so the caller can decide whether to retry; it is nil for network errors
+func (c *TreasuryAPIClient) fetchOnce(ctx context.Context, url string, cached *cachedTreasuryResponse) (*TreasuryAPIResponse, *http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build Treasury API request: %w", err)
+	}
+	cached.setConditionalHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch from Treasury API: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		slog.Error("Treasury API returned non-200 status",
-			"status_code", resp.StatusCode,
-			"duration", duration,
-			"url", url,
-		)
-		return nil, fmt.Errorf("Treasury API returned status %d", resp.StatusCode)
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		c.responses.refresh(cached, c.clock.Now())
+		return cached.response, nil, nil
 	}
 
-	slog.Info("Treasury API call successful",
-		"status_code", resp.StatusCode,
-		"duration", duration,
-	)
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp, fmt.Errorf("Treasury API returned status %d", resp.StatusCode)
+	}
 
-	// Parse response
 	var apiResponse TreasuryAPIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		slog.Error("Failed to parse Treasury API response",
-			"error", err.Error(),
-			"duration", duration,
-		)
-		return nil, fmt.Errorf("failed to parse Treasury API response: %w", err)
+		return nil, resp, fmt.Errorf("failed to parse Treasury API response: %w", err)
 	}
+	c.responses.put(url, &apiResponse, resp.Header, c.clock.Now())
 
-	// Find the most recent rate within the date range
-	exchangeRate, err := c.parseExchangeRate(apiResponse.Data, from, to, date)
-	if err != nil {
-		return nil, err
-	}
+	return &apiResponse, nil, nil
+}
 
-	return exchangeRate, nil
+// SupportsCurrency reports whether the Treasury API publishes USD rates for the currency
+func (c *TreasuryAPIClient) SupportsCurrency(code entities.CurrencyCode) bool {
+	_, exists := c.currencies[code]
+	return exists
+}
+
+// ProviderName identifies the Treasury API as the rate source
+func (c *TreasuryAPIClient) ProviderName() string {
+	return "us_treasury"
 }
 
 // buildURL constructs the Treasury API URL with appropriate filters
-func (c *TreasuryAPIClient) buildURL(currency entities.CurrencyCode, startDate, endDate time.Time) string {
+// Pages are numbered from 1
+func (c *TreasuryAPIClient) buildURL(currency entities.CurrencyCode, startDate, endDate time.Time, page int) string {
 	// Treasury API expects currency in full name format via country_currency_desc
-	currencyFilter := c.mapCurrencyCodeToFilter(currency)
+	currencyFilter := c.currencyFilter(currency)
 
 	startDateStr := startDate.Format("2006-01-02")
 	endDateStr := endDate.Format("2006-01-02")
@@ -137,37 +332,32 @@ func (c *TreasuryAPIClient) buildURL(currency entities.CurrencyCode, startDate,
 	baseURL := c.baseURL
 	params := url.Values{}
 	params.Add("fields", "country_currency_desc,exchange_rate,record_date")
-	params.Add("filter", fmt.Sprintf("country_currency_desc:eq:%s,record_date:gte:%s,record_date:lte:%s", currencyFilter, startDateStr, endDateStr))
+	params.Add("filter", fmt.Sprintf("country_currency_desc:%s,record_date:gte:%s,record_date:lte:%s", currencyFilter, startDateStr, endDateStr))
 	params.Add("sort", "-record_date")
+	params.Add("page[number]", strconv.Itoa(page))
+	params.Add("page[size]", strconv.Itoa(c.pageSize))
 
 	return fmt.Sprintf("%s?%s", baseURL, params.Encode())
 }
 
-// mapCurrencyCodeToFilter maps currency codes to Treasury API filter format
-func (c *TreasuryAPIClient) mapCurrencyCodeToFilter(code entities.CurrencyCode) string {
-	currencyMap := map[entities.CurrencyCode]string{
-		entities.EUR: "Euro Zone-Euro",
-		entities.GBP: "United Kingdom-Pound", // Back to original from PDF
-		entities.JPY: "Japan-Yen",
-		entities.CAD: "Canada-Dollar",
-		entities.AUD: "Australia-Dollar",
-		entities.CNY: "China-Renminbi",
-		entities.BRL: "Brazil-Real",
-		// Add more mappings as needed
+// currencyFilter builds the country_currency_desc filter condition matching any descriptor of the currency
+func (c *TreasuryAPIClient) currencyFilter(code entities.CurrencyCode) string {
+	descriptors := c.currencies[code]
+	switch len(descriptors) {
+	case 0:
+		// Fallback to currency code itself
+		return "eq:" + string(code)
+	case 1:
+		return "eq:" + descriptors[0]
+	default:
+		return "in:(" + strings.Join(descriptors, ",") + ")"
 	}
-
-	if filter, exists := currencyMap[code]; exists {
-		return filter
-	}
-
-	// Fallback to currency code itself
-	return string(code)
 }
 
 // parseExchangeRate finds the most recent valid exchange rate from API response
 func (c *TreasuryAPIClient) parseExchangeRate(records []TreasuryRecord, from, to entities.CurrencyCode, transactionDate time.Time) (*entities.ExchangeRate, error) {
 	if len(records) == 0 {
-		return nil, fmt.Errorf("no exchange rate found for %s within 6 months of %s", to, transactionDate.Format("2006-01-02"))
+		return nil, errs.Newf(errs.ErrRateUnavailable, "no exchange rate found for %s within %s of %s", to, c.window, transactionDate.Format("2006-01-02"))
 	}
 
 	// Records are sorted by record_date descending, so take the first valid one
@@ -177,13 +367,13 @@ func (c *TreasuryAPIClient) parseExchangeRate(records []TreasuryRecord, from, to
 			continue // Skip invalid records
 		}
 
-		// Verify the rate is within the 6-month rule
-		if rate.IsWithinDateRange(transactionDate) {
+		// Verify the rate is within the lookback window
+		if rate.IsWithinDateRange(transactionDate, c.window) {
 			return rate, nil
 		}
 	}
 
-	return nil, fmt.Errorf("no suitable exchange rate found for %s within 6 months of %s", to, transactionDate.Format("2006-01-02"))
+	return nil, errs.Newf(errs.ErrRateUnavailable, "no suitable exchange rate found for %s within %s of %s", to, c.window, transactionDate.Format("2006-01-02"))
 }
 
 // parseRecord converts a Treasury API record to an ExchangeRate entity
@@ -208,7 +398,7 @@ func (c *TreasuryAPIClient) parseRecord(record TreasuryRecord, from, to entities
 		Rate:          rate,
 		EffectiveDate: recordDate, // Use record_date as effective_date
 		RecordDate:    recordDate,
-		CreatedAt:     time.Now(),
+		CreatedAt:     c.clock.Now(),
 	}
 
 	return exchangeRate, nil
@@ -0,0 +1,106 @@
+package external
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// TreasuryCurrencies maps currency codes to the Treasury API country_currency_desc values that publish their rates
+// A currency may have several descriptors, e.g. when the Treasury renamed a country or lists it under more than one
+type TreasuryCurrencies map[entities.CurrencyCode][]string
+
+// DefaultTreasuryCurrencies returns the built-in mapping used when no currency map file is configured
+func DefaultTreasuryCurrencies() TreasuryCurrencies {
+	return TreasuryCurrencies{
+		entities.EUR: {"Euro Zone-Euro"},
+		entities.GBP: {"United Kingdom-Pound"},
+		entities.JPY: {"Japan-Yen"},
+		entities.CAD: {"Canada-Dollar"},
+		entities.AUD: {"Australia-Dollar"},
+		entities.CNY: {"China-Renminbi"},
+		entities.BRL: {"Brazil-Real"},
+		entities.KRW: {"Korea-Won"},
+		entities.CLP: {"Chile-Peso"},
+	}
+}
+
+// treasuryCurrencyFile is the layout of the currency map file
+// Name, symbol and minor units describe currencies the API does not know yet; they are ignored for known ones
+type treasuryCurrencyFile struct {
+	Currencies []struct {
+		Code        string   `json:"code"`
+		Descriptors []string `json:"descriptors"`
+		Name        string   `json:"name"`
+		Symbol      string   `json:"symbol"`
+		MinorUnits  *int     `json:"minor_units"`
+	} `json:"currencies"`
+}
+
+// LoadTreasuryCurrencies returns the built-in mapping extended with the entries of the JSON file at path
+// An entry replaces the built-in descriptors of its currency; an empty path returns the built-in mapping unchanged
+// Currencies new to the API are registered with the metadata of their entry, 2 minor units when it has none
+func LoadTreasuryCurrencies(path string) (TreasuryCurrencies, error) {
+	currencies := DefaultTreasuryCurrencies()
+	if path == "" {
+		return currencies, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read currency map: %w", err)
+	}
+
+	var file treasuryCurrencyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse currency map %s: %w", path, err)
+	}
+
+	for i, entry := range file.Currencies {
+		code, err := entities.NewCurrencyCode(entry.Code)
+		if err != nil {
+			return nil, fmt.Errorf("currency map entry %d: %w", i, err)
+		}
+		if code == entities.USD {
+			return nil, fmt.Errorf("currency map entry %d: USD is the base currency and has no Treasury rate", i)
+		}
+
+		var descriptors []string
+		for _, descriptor := range entry.Descriptors {
+			if descriptor != "" && !slices.Contains(descriptors, descriptor) {
+				descriptors = append(descriptors, descriptor)
+			}
+		}
+		if len(descriptors) == 0 {
+			return nil, fmt.Errorf("currency map entry %d: %s needs at least one descriptor", i, code)
+		}
+
+		if _, known := code.Info(); !known {
+			info := entities.CurrencyInfo{Code: code, Name: entry.Name, Symbol: entry.Symbol, MinorUnits: 2}
+			if entry.MinorUnits != nil {
+				info.MinorUnits = *entry.MinorUnits
+			}
+			if err := entities.RegisterCurrency(info); err != nil {
+				return nil, fmt.Errorf("currency map entry %d: %w", i, err)
+			}
+		}
+
+		currencies[code] = descriptors
+	}
+
+	return currencies, nil
+}
+
+// Codes returns the mapped currency codes, sorted alphabetically
+func (t TreasuryCurrencies) Codes() []entities.CurrencyCode {
+	codes := make([]entities.CurrencyCode, 0, len(t))
+	for code := range t {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	return codes
+}
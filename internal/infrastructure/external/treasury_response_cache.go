@@ -0,0 +1,122 @@
+package external
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// treasuryResponseCache keeps decoded Treasury responses by request URL, evicting the least recently used once full
+// Lookups of the same currency and date window share a URL, so conversions in a burst make a single call
+// An entry older than the TTL is revalidated with its ETag or Last-Modified when the API sent one, and dropped otherwise
+type treasuryResponseCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List // Front is the most recently used
+	entries map[string]*list.Element
+}
+
+// cachedTreasuryResponse is a response and the validators to revalidate it with
+type cachedTreasuryResponse struct {
+	url          string
+	response     *TreasuryAPIResponse
+	etag         string
+	lastModified string
+	storedAt     time.Time
+}
+
+// newTreasuryResponseCache creates a cache of at most maxEntries responses; a non-positive ttl disables caching
+func newTreasuryResponseCache(ttl time.Duration, maxEntries int) *treasuryResponseCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &treasuryResponseCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// get returns the entry cached for url and whether it is fresh at now
+// Stale entries are only returned when they can be revalidated
+func (c *treasuryResponseCache) get(url string, now time.Time) (*cachedTreasuryResponse, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, found := c.entries[url]
+	if !found {
+		return nil, false
+	}
+
+	entry := element.Value.(*cachedTreasuryResponse)
+	if now.Sub(entry.storedAt) < c.ttl {
+		c.order.MoveToFront(element)
+		return entry, true
+	}
+	if entry.etag == "" && entry.lastModified == "" {
+		c.order.Remove(element)
+		delete(c.entries, url)
+		return nil, false
+	}
+	return entry, false
+}
+
+// put caches a response received at now with the validators of its headers
+func (c *treasuryResponseCache) put(url string, response *TreasuryAPIResponse, header http.Header, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cachedTreasuryResponse{
+		url:          url,
+		response:     response,
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+		storedAt:     now,
+	}
+	if element, found := c.entries[url]; found {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[url] = c.order.PushFront(entry)
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedTreasuryResponse).url)
+	}
+}
+
+// refresh restarts the TTL of an entry the API confirmed unchanged at now
+func (c *treasuryResponseCache) refresh(entry *cachedTreasuryResponse, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, found := c.entries[entry.url]; found && element.Value == entry {
+		entry.storedAt = now
+		c.order.MoveToFront(element)
+	}
+}
+
+// setConditionalHeaders asks the API to answer 304 Not Modified when the cached response is still current
+func (e *cachedTreasuryResponse) setConditionalHeaders(req *http.Request) {
+	if e == nil {
+		return
+	}
+	if e.etag != "" {
+		req.Header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		req.Header.Set("If-Modified-Since", e.lastModified)
+	}
+}
@@ -0,0 +1,66 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/services"
+	"github.com/rafaelreis-se/purchase-transaction-api/pkg/webhook"
+)
+
+// Headers sent with every webhook delivery besides the X-Signature header
+const (
+	WebhookEventHeader    = "X-Webhook-Event"
+	WebhookDeliveryHeader = "X-Webhook-Delivery"
+)
+
+// maxWebhookResponseBytes bounds how much of a receiver's answer is read before the connection is reused
+const maxWebhookResponseBytes = 64 << 10
+
+// WebhookSender implements WebhookSender by POSTing signed JSON payloads
+type WebhookSender struct {
+	httpClient *http.Client
+}
+
+// NewWebhookSender creates a webhook sender with configuration
+// Redirects are not followed, so a delivery only reaches the registered URL
+func NewWebhookSender(cfg *config.WebhookConfig) services.WebhookSender {
+	return &WebhookSender{
+		httpClient: &http.Client{
+			Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send posts the delivery payload, signed with the webhook secret at the time of sending
+func (s *WebhookSender) Send(ctx context.Context, hook *entities.Webhook, delivery *entities.WebhookDelivery) (int, error) {
+	payload := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "purchase-transaction-api-webhooks")
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(hook.Secret, payload, time.Now()))
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID.String())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponseBytes))
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,64 @@
+package graphql
+
+import (
+	"errors"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+)
+
+// Error is an entry of the errors list of a response
+type Error struct {
+	Message    string                 `json:"message"`
+	Locations  []Location             `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Error codes reported in extensions.code
+const (
+	CodeBadUserInput        = "BAD_USER_INPUT"
+	CodeValidationFailed    = "GRAPHQL_VALIDATION_FAILED"
+	CodeParseFailed         = "GRAPHQL_PARSE_FAILED"
+	CodeNotFound            = "NOT_FOUND"
+	CodeRateUnavailable     = "RATE_UNAVAILABLE"
+	CodeServiceUnavailable  = "SERVICE_UNAVAILABLE"
+	CodeInternalServerError = "INTERNAL_SERVER_ERROR"
+)
+
+// errorKinds maps domain error kinds to error codes, checked in order like the HTTP handlers
+var errorKinds = []struct {
+	kind error
+	code string
+}{
+	{errs.ErrValidation, CodeBadUserInput},
+	{errs.ErrUnsupportedCurrency, CodeBadUserInput},
+	{errs.ErrNotFound, CodeNotFound},
+	{errs.ErrRateUnavailable, CodeRateUnavailable},
+	{errs.ErrServiceUnavailable, CodeServiceUnavailable},
+}
+
+// errorCode classifies a resolver error; unclassified errors are INTERNAL_SERVER_ERROR
+func errorCode(err error) string {
+	for _, entry := range errorKinds {
+		if errors.Is(err, entry.kind) {
+			return entry.code
+		}
+	}
+	return CodeInternalServerError
+}
+
+// withCode returns err as an Error carrying code in its extensions
+func withCode(err error, code string) *Error {
+	var gqlErr *Error
+	if !errors.As(err, &gqlErr) {
+		gqlErr = &Error{Message: err.Error()}
+	}
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{"code": code}
+	}
+	return gqlErr
+}
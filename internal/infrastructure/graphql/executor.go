@@ -0,0 +1,293 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Request is a GraphQL request as sent in a POST body or GET query string
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	APIKey        string                 `json:"-"` // Caller's API key, selects the conversion margin
+	Context       context.Context        `json:"-"` // Cancels resolvers with the HTTP request; nil means no deadline
+}
+
+// context returns the request's context, or the background context when none was set
+func (r *Request) context() context.Context {
+	if r.Context == nil {
+		return context.Background()
+	}
+	return r.Context
+}
+
+// Response is the result of a request; Data is only written once execution started
+type Response struct {
+	Data     interface{}
+	Errors   []*Error
+	executed bool
+}
+
+// Executed reports whether the request was valid and ran, possibly with field errors
+func (r *Response) Executed() bool {
+	return r.executed
+}
+
+// MarshalJSON writes errors before data, leaving data out for requests that failed before execution
+func (r *Response) MarshalJSON() ([]byte, error) {
+	type withData struct {
+		Errors []*Error    `json:"errors,omitempty"`
+		Data   interface{} `json:"data"`
+	}
+	type withoutData struct {
+		Errors []*Error `json:"errors"`
+	}
+	if r.executed {
+		return json.Marshal(withData{Errors: r.Errors, Data: r.Data})
+	}
+	return json.Marshal(withoutData{Errors: r.Errors})
+}
+
+// requestError is a Response for a request rejected before execution
+func requestError(err error, code string) *Response {
+	return &Response{Errors: []*Error{withCode(err, code)}}
+}
+
+// execute parses, validates and runs a request against the schema
+func (s *schema) execute(request *Request) *Response {
+	doc, err := parse(request.Query)
+	if err != nil {
+		return requestError(err, CodeParseFailed)
+	}
+
+	op, err := selectOperation(doc, request.OperationName)
+	if err != nil {
+		return requestError(err, CodeValidationFailed)
+	}
+	if errs := s.validate(doc, op); len(errs) > 0 {
+		for i, err := range errs {
+			errs[i] = withCode(err, CodeValidationFailed)
+		}
+		return &Response{Errors: errs}
+	}
+
+	variables, err := s.coerceVariables(op, request.Variables)
+	if err != nil {
+		return requestError(err, CodeBadUserInput)
+	}
+
+	x := &execution{schema: s, doc: doc, request: request, variables: variables}
+	data, ok := x.executeFields("Query", nil, op.selections, nil)
+	response := &Response{Errors: x.errors, executed: true}
+	if ok {
+		response.Data = data
+	}
+	return response
+}
+
+// selectOperation picks the named operation, or the only one when no name is given
+func selectOperation(doc *document, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, &Error{Message: "Must provide operation name if query contains multiple operations."}
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("Unknown operation named %q.", name)}
+}
+
+// coerceVariables applies defaults and checks provided values against the declared types
+// Values stay in their JSON form; arguments coerce them again at the position they are used in
+func (s *schema) coerceVariables(op *operation, provided map[string]interface{}) (map[string]interface{}, error) {
+	variables := make(map[string]interface{}, len(op.variables))
+	for _, definition := range op.variables {
+		t := typeRef{name: definition.typeName, nonNull: definition.nonNull, list: definition.list}
+
+		raw, present := provided[definition.name]
+		if !present && definition.defaultValue != nil {
+			var err error
+			if raw, err = literalValue(*definition.defaultValue, nil); err != nil {
+				return nil, &Error{Message: fmt.Sprintf("Variable $%s has an invalid default value: %v", definition.name, err)}
+			}
+		}
+		if _, err := s.coerce(raw, t); err != nil {
+			return nil, &Error{Message: fmt.Sprintf("Variable $%s got an invalid value: %v", definition.name, err)}
+		}
+		if raw != nil {
+			variables[definition.name] = raw
+		}
+	}
+	return variables, nil
+}
+
+// execution holds the state of one request while fields resolve
+type execution struct {
+	schema    *schema
+	doc       *document
+	request   *Request
+	variables map[string]interface{}
+	errors    []*Error
+}
+
+func (x *execution) addError(err error, code string, path []interface{}) {
+	gqlErr := withCode(err, code)
+	gqlErr.Path = append([]interface{}(nil), path...)
+	x.errors = append(x.errors, gqlErr)
+}
+
+// fieldGroup is the fields selected under one response key; their sub-selections are merged
+type fieldGroup struct {
+	key    string
+	fields []*field
+}
+
+// collectFields flattens fragments and applies @skip and @include, keeping selection order
+func (x *execution) collectFields(selections []selection, groups []*fieldGroup, visited map[string]bool) []*fieldGroup {
+	for _, sel := range selections {
+		if !x.included(sel.directives) {
+			continue
+		}
+		switch {
+		case sel.field != nil:
+			key := sel.field.responseKey()
+			found := false
+			for _, group := range groups {
+				if group.key == key {
+					group.fields = append(group.fields, sel.field)
+					found = true
+					break
+				}
+			}
+			if !found {
+				groups = append(groups, &fieldGroup{key: key, fields: []*field{sel.field}})
+			}
+		case sel.inlineFragment != nil:
+			groups = x.collectFields(sel.inlineFragment.selections, groups, visited)
+		default:
+			if visited[sel.fragmentSpread] {
+				continue
+			}
+			visited[sel.fragmentSpread] = true
+			frag := x.doc.fragments[sel.fragmentSpread]
+			if x.included(frag.directives) {
+				groups = x.collectFields(frag.selections, groups, visited)
+			}
+		}
+	}
+	return groups
+}
+
+// included evaluates @skip(if:) and @include(if:)
+func (x *execution) included(directives []directive) bool {
+	for _, d := range directives {
+		condition, _ := literalValue(d.arguments[0].value, x.variables)
+		if d.name == "skip" && condition == true {
+			return false
+		}
+		if d.name == "include" && condition != true {
+			return false
+		}
+	}
+	return true
+}
+
+// executeFields resolves the selected fields of an object
+// It reports false when a non-null field became null, which makes the object itself null
+func (x *execution) executeFields(objectType string, source interface{}, selections []selection, path []interface{}) (*orderedMap, bool) {
+	result := newOrderedMap()
+	for _, group := range x.collectFields(selections, nil, make(map[string]bool)) {
+		first := group.fields[0]
+		fieldPath := append(append([]interface{}(nil), path...), group.key)
+
+		if first.name == "__typename" {
+			result.set(group.key, objectType)
+			continue
+		}
+
+		var subSelections []selection
+		for _, f := range group.fields {
+			subSelections = append(subSelections, f.selections...)
+		}
+
+		definition := x.schema.objects[objectType][first.name]
+		value, ok := x.executeField(definition, source, first, subSelections, fieldPath)
+		if !ok {
+			return nil, false
+		}
+		result.set(group.key, value)
+	}
+	return result, true
+}
+
+func (x *execution) executeField(definition *fieldDefinition, source interface{}, f *field, selections []selection, path []interface{}) (interface{}, bool) {
+	args := make(map[string]interface{}, len(definition.args))
+	for name, argType := range definition.args {
+		var raw interface{}
+		var err error
+		for _, arg := range f.arguments {
+			if arg.name == name {
+				raw, err = literalValue(arg.value, x.variables)
+			}
+		}
+		value, coerceErr := x.schema.coerce(raw, argType)
+		if err == nil {
+			err = coerceErr
+		}
+		if err != nil {
+			x.addError(fmt.Errorf("Argument %q has an invalid value: %v", name, err), CodeBadUserInput, path)
+			return nil, !definition.typ.nonNull
+		}
+		if value != nil {
+			args[name] = value
+		}
+	}
+
+	resolved, err := definition.resolve(x.request, source, args)
+	if err != nil {
+		x.addError(err, errorCode(err), path)
+		return nil, !definition.typ.nonNull
+	}
+	return x.completeValue(definition.typ, resolved, selections, path)
+}
+
+// completeValue shapes a resolved value by its type; false means a non-null position became null
+func (x *execution) completeValue(t typeRef, resolved interface{}, selections []selection, path []interface{}) (interface{}, bool) {
+	if resolved == nil {
+		if t.nonNull {
+			x.addError(fmt.Errorf("Cannot return null for non-nullable field."), CodeInternalServerError, path)
+			return nil, false
+		}
+		return nil, true
+	}
+
+	if t.list {
+		items := resolved.([]interface{})
+		completed := make([]interface{}, len(items))
+		for i, item := range items {
+			itemPath := append(append([]interface{}(nil), path...), i)
+			value, ok := x.completeValue(t.item(), item, selections, itemPath)
+			if !ok {
+				return nil, !t.nonNull
+			}
+			completed[i] = value
+		}
+		return completed, true
+	}
+
+	if x.schema.isLeaf(t.name) {
+		return resolved, true
+	}
+
+	object, ok := x.executeFields(t.name, resolved, selections, path)
+	if !ok {
+		return nil, !t.nonNull
+	}
+	return object, true
+}
@@ -0,0 +1,229 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenKind classifies a lexical token of a GraphQL document
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+// token is a lexical token; value holds the punctuator, name, number or unescaped string
+type token struct {
+	kind     tokenKind
+	value    string
+	location Location
+}
+
+// Location is a 1-based position in the query, reported with errors
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// lexer splits a query into tokens, skipping whitespace, commas and comments
+type lexer struct {
+	source    string
+	pos       int
+	line      int
+	lineStart int
+}
+
+func newLexer(source string) *lexer {
+	return &lexer{source: source, line: 1}
+}
+
+func (l *lexer) location() Location {
+	return Location{Line: l.line, Column: l.pos - l.lineStart + 1}
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return &Error{Message: "Syntax error: " + fmt.Sprintf(format, args...), Locations: []Location{l.location()}}
+}
+
+// next returns the following token
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	start := l.location()
+	if l.pos >= len(l.source) {
+		return token{kind: tokenEOF, location: start}, nil
+	}
+
+	c := l.source[l.pos]
+	switch {
+	case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+		l.pos++
+		return token{kind: tokenPunctuator, value: string(c), location: start}, nil
+	case c == '.':
+		if !strings.HasPrefix(l.source[l.pos:], "...") {
+			return token{}, l.errorf("unexpected %q", c)
+		}
+		l.pos += 3
+		return token{kind: tokenPunctuator, value: "...", location: start}, nil
+	case c == '_' || isLetter(c):
+		begin := l.pos
+		for l.pos < len(l.source) && (l.source[l.pos] == '_' || isLetter(l.source[l.pos]) || isDigit(l.source[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokenName, value: l.source[begin:l.pos], location: start}, nil
+	case c == '-' || isDigit(c):
+		return l.number(start)
+	case c == '"':
+		if strings.HasPrefix(l.source[l.pos:], `"""`) {
+			return l.blockString(start)
+		}
+		return l.string(start)
+	}
+	return token{}, l.errorf("unexpected character %q", c)
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.source) {
+		switch c := l.source[l.pos]; c {
+		case ' ', '\t', ',', '\r':
+			l.pos++
+		case '\n':
+			l.pos++
+			l.line++
+			l.lineStart = l.pos
+		case '#':
+			for l.pos < len(l.source) && l.source[l.pos] != '\n' {
+				l.pos++
+			}
+		default:
+			if strings.HasPrefix(l.source[l.pos:], "\uFEFF") {
+				l.pos += len("\uFEFF")
+				continue
+			}
+			return
+		}
+	}
+}
+
+func (l *lexer) number(start Location) (token, error) {
+	begin := l.pos
+	kind := tokenInt
+	if l.source[l.pos] == '-' {
+		l.pos++
+	}
+	if !l.digits() {
+		return token{}, l.errorf("invalid number")
+	}
+	if l.pos < len(l.source) && l.source[l.pos] == '.' {
+		kind = tokenFloat
+		l.pos++
+		if !l.digits() {
+			return token{}, l.errorf("invalid number")
+		}
+	}
+	if l.pos < len(l.source) && (l.source[l.pos] == 'e' || l.source[l.pos] == 'E') {
+		kind = tokenFloat
+		l.pos++
+		if l.pos < len(l.source) && (l.source[l.pos] == '+' || l.source[l.pos] == '-') {
+			l.pos++
+		}
+		if !l.digits() {
+			return token{}, l.errorf("invalid number")
+		}
+	}
+	return token{kind: kind, value: l.source[begin:l.pos], location: start}, nil
+}
+
+func (l *lexer) digits() bool {
+	begin := l.pos
+	for l.pos < len(l.source) && isDigit(l.source[l.pos]) {
+		l.pos++
+	}
+	return l.pos > begin
+}
+
+func (l *lexer) string(start Location) (token, error) {
+	l.pos++ // Opening quote
+	var b strings.Builder
+	for l.pos < len(l.source) {
+		c := l.source[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			return token{kind: tokenString, value: b.String(), location: start}, nil
+		case c == '\n':
+			return token{}, l.errorf("unterminated string")
+		case c == '\\':
+			if l.pos+1 >= len(l.source) {
+				return token{}, l.errorf("unterminated string")
+			}
+			escape := l.source[l.pos+1]
+			l.pos += 2
+			switch escape {
+			case '"', '\\', '/':
+				b.WriteByte(escape)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.source) {
+					return token{}, l.errorf("invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(l.source[l.pos:l.pos+4], 16, 32)
+				if err != nil {
+					return token{}, l.errorf("invalid unicode escape")
+				}
+				b.WriteRune(rune(code))
+				l.pos += 4
+			default:
+				return token{}, l.errorf("invalid escape sequence \\%c", escape)
+			}
+		default:
+			r, size := utf8.DecodeRuneInString(l.source[l.pos:])
+			b.WriteRune(r)
+			l.pos += size
+		}
+	}
+	return token{}, l.errorf("unterminated string")
+}
+
+// blockString reads a """ string; common indentation is kept, which is enough for argument values
+func (l *lexer) blockString(start Location) (token, error) {
+	l.pos += 3
+	end := strings.Index(l.source[l.pos:], `"""`)
+	if end < 0 {
+		return token{}, l.errorf("unterminated block string")
+	}
+	value := l.source[l.pos : l.pos+end]
+	for _, c := range value {
+		if c == '\n' {
+			l.line++
+		}
+	}
+	l.pos += end + 3
+	if newline := strings.LastIndexByte(l.source[:l.pos], '\n'); newline >= 0 && newline >= l.pos-end-3 {
+		l.lineStart = newline + 1
+	}
+	return token{kind: tokenString, value: strings.Trim(value, "\n"), location: start}, nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,484 @@
+package graphql
+
+import "fmt"
+
+// document is a parsed query document
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+// operation is a query, mutation or subscription with its variable definitions
+type operation struct {
+	kind       string // query, mutation or subscription
+	name       string
+	variables  []variableDefinition
+	directives []directive
+	selections []selection
+	location   Location
+}
+
+type variableDefinition struct {
+	name         string
+	typeName     string // Named type, e.g. Int
+	nonNull      bool
+	list         bool
+	defaultValue *value
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	directives    []directive
+	selections    []selection
+	location      Location
+}
+
+// selection is a field, a fragment spread or an inline fragment
+type selection struct {
+	field          *field
+	fragmentSpread string
+	inlineFragment *fragment
+	directives     []directive
+	location       Location
+}
+
+type field struct {
+	alias      string
+	name       string
+	arguments  []argument
+	selections []selection
+}
+
+// responseKey is the name of the field in the result: its alias, or its name
+func (f *field) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type argument struct {
+	name     string
+	value    value
+	location Location
+}
+
+type directive struct {
+	name      string
+	arguments []argument
+	location  Location
+}
+
+// valueKind is the kind of an input value literal
+type valueKind int
+
+const (
+	valueVariable valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+// value is an input value literal; raw holds scalars, variable and enum names
+type value struct {
+	kind   valueKind
+	raw    string
+	list   []value
+	fields []argument
+}
+
+// parser builds a document from tokens with one token of lookahead
+type parser struct {
+	lexer *lexer
+	token token
+}
+
+// parse parses a query document
+func parse(source string) (*document, error) {
+	p := &parser{lexer: newLexer(source)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: make(map[string]*fragment)}
+	for p.token.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: selections})
+		case p.token.kind == tokenName && (p.token.value == "query" || p.token.value == "mutation" || p.token.value == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.token.kind == tokenName && p.token.value == "fragment":
+			frag, err := p.fragmentDefinition()
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := doc.fragments[frag.name]; exists {
+				return nil, &Error{Message: fmt.Sprintf("There can be only one fragment named %q.", frag.name), Locations: []Location{frag.location}}
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, &Error{Message: "The document contains no operation."}
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	next, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.token = next
+	return nil
+}
+
+// peek reports whether the current token is the punctuator
+func (p *parser) peek(punctuator string) bool {
+	return p.token.kind == tokenPunctuator && p.token.value == punctuator
+}
+
+// skip consumes the punctuator when it is the current token
+func (p *parser) skip(punctuator string) (bool, error) {
+	if !p.peek(punctuator) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(punctuator string) error {
+	if !p.peek(punctuator) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.token.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.token.value
+	return name, p.advance()
+}
+
+func (p *parser) unexpected() error {
+	description := fmt.Sprintf("%q", p.token.value)
+	if p.token.kind == tokenEOF {
+		description = "<EOF>"
+	}
+	return &Error{Message: "Syntax error: unexpected " + description, Locations: []Location{p.token.location}}
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{kind: p.token.value, location: p.token.location}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if p.token.kind == tokenName {
+		if op.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if op.variables, err = p.variableDefinitions(); err != nil {
+		return nil, err
+	}
+	if op.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if op.selections, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) variableDefinitions() ([]variableDefinition, error) {
+	if open, err := p.skip("("); err != nil || !open {
+		return nil, err
+	}
+
+	var definitions []variableDefinition
+	for !p.peek(")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+
+		definition := variableDefinition{name: name}
+		if definition.list, err = p.skip("["); err != nil {
+			return nil, err
+		}
+		if definition.typeName, err = p.name(); err != nil {
+			return nil, err
+		}
+		if definition.list {
+			if _, err := p.skip("!"); err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+		}
+		if definition.nonNull, err = p.skip("!"); err != nil {
+			return nil, err
+		}
+
+		if hasDefault, err := p.skip("="); err != nil {
+			return nil, err
+		} else if hasDefault {
+			defaultValue, err := p.value(true)
+			if err != nil {
+				return nil, err
+			}
+			definition.defaultValue = &defaultValue
+		}
+		if _, err := p.directives(); err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, p.advance()
+}
+
+func (p *parser) fragmentDefinition() (*fragment, error) {
+	frag := &fragment{location: p.token.location}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if frag.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if p.token.kind != tokenName || p.token.value != "on" {
+		return nil, p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if frag.typeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+	if frag.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if frag.selections, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return frag, nil
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var selections []selection
+	for !p.peek("}") {
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	if len(selections) == 0 {
+		return nil, p.unexpected()
+	}
+	return selections, p.advance()
+}
+
+func (p *parser) selection() (selection, error) {
+	sel := selection{location: p.token.location}
+
+	spread, err := p.skip("...")
+	if err != nil {
+		return sel, err
+	}
+	if spread {
+		if p.token.kind == tokenName && p.token.value != "on" {
+			if sel.fragmentSpread, err = p.name(); err != nil {
+				return sel, err
+			}
+			sel.directives, err = p.directives()
+			return sel, err
+		}
+
+		inline := &fragment{location: sel.location}
+		if p.token.kind == tokenName {
+			if err := p.advance(); err != nil { // on
+				return sel, err
+			}
+			if inline.typeCondition, err = p.name(); err != nil {
+				return sel, err
+			}
+		}
+		if sel.directives, err = p.directives(); err != nil {
+			return sel, err
+		}
+		if inline.selections, err = p.selectionSet(); err != nil {
+			return sel, err
+		}
+		sel.inlineFragment = inline
+		return sel, nil
+	}
+
+	f := &field{}
+	if f.name, err = p.name(); err != nil {
+		return sel, err
+	}
+	if alias, err := p.skip(":"); err != nil {
+		return sel, err
+	} else if alias {
+		f.alias = f.name
+		if f.name, err = p.name(); err != nil {
+			return sel, err
+		}
+	}
+	if f.arguments, err = p.arguments(); err != nil {
+		return sel, err
+	}
+	if sel.directives, err = p.directives(); err != nil {
+		return sel, err
+	}
+	if p.peek("{") {
+		if f.selections, err = p.selectionSet(); err != nil {
+			return sel, err
+		}
+	}
+	sel.field = f
+	return sel, nil
+}
+
+func (p *parser) arguments() ([]argument, error) {
+	if open, err := p.skip("("); err != nil || !open {
+		return nil, err
+	}
+
+	var arguments []argument
+	for !p.peek(")") {
+		arg := argument{location: p.token.location}
+		var err error
+		if arg.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if arg.value, err = p.value(false); err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, arg)
+	}
+	if len(arguments) == 0 {
+		return nil, p.unexpected()
+	}
+	return arguments, p.advance()
+}
+
+func (p *parser) directives() ([]directive, error) {
+	var directives []directive
+	for p.peek("@") {
+		d := directive{location: p.token.location}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var err error
+		if d.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if d.arguments, err = p.arguments(); err != nil {
+			return nil, err
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+// value parses an input value; constant values, such as variable defaults, cannot reference variables
+func (p *parser) value(constant bool) (value, error) {
+	tok := p.token
+	switch tok.kind {
+	case tokenInt:
+		return value{kind: valueInt, raw: tok.value}, p.advance()
+	case tokenFloat:
+		return value{kind: valueFloat, raw: tok.value}, p.advance()
+	case tokenString:
+		return value{kind: valueString, raw: tok.value}, p.advance()
+	case tokenName:
+		v := value{kind: valueEnum, raw: tok.value}
+		switch tok.value {
+		case "true", "false":
+			v.kind = valueBoolean
+		case "null":
+			v.kind = valueNull
+		}
+		return v, p.advance()
+	}
+
+	switch {
+	case p.peek("$") && !constant:
+		if err := p.advance(); err != nil {
+			return value{}, err
+		}
+		name, err := p.name()
+		return value{kind: valueVariable, raw: name}, err
+	case p.peek("["):
+		if err := p.advance(); err != nil {
+			return value{}, err
+		}
+		v := value{kind: valueList}
+		for !p.peek("]") {
+			item, err := p.value(constant)
+			if err != nil {
+				return value{}, err
+			}
+			v.list = append(v.list, item)
+		}
+		return v, p.advance()
+	case p.peek("{"):
+		if err := p.advance(); err != nil {
+			return value{}, err
+		}
+		v := value{kind: valueObject}
+		for !p.peek("}") {
+			objectField := argument{location: p.token.location}
+			var err error
+			if objectField.name, err = p.name(); err != nil {
+				return value{}, err
+			}
+			if err := p.expect(":"); err != nil {
+				return value{}, err
+			}
+			if objectField.value, err = p.value(constant); err != nil {
+				return value{}, err
+			}
+			v.fields = append(v.fields, objectField)
+		}
+		return v, p.advance()
+	}
+	return value{}, p.unexpected()
+}
@@ -0,0 +1,183 @@
+package graphql
+
+import (
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+)
+
+// SDL is the schema in GraphQL schema definition language, for clients generating types
+//
+//go:embed schema.graphql
+var SDL []byte
+
+// Executor runs GraphQL queries over transactions, sharing the use cases of the REST API
+type Executor struct {
+	getTransactionUseCase     *usecases.GetTransactionUseCase
+	listTransactionsUseCase   *usecases.ListTransactionsUseCase
+	convertTransactionUseCase *usecases.ConvertTransactionUseCase
+	schema                    *schema
+}
+
+// NewExecutor creates an Executor for the transaction use cases
+func NewExecutor(
+	getTransactionUseCase *usecases.GetTransactionUseCase,
+	listTransactionsUseCase *usecases.ListTransactionsUseCase,
+	convertTransactionUseCase *usecases.ConvertTransactionUseCase,
+) *Executor {
+	e := &Executor{
+		getTransactionUseCase:     getTransactionUseCase,
+		listTransactionsUseCase:   listTransactionsUseCase,
+		convertTransactionUseCase: convertTransactionUseCase,
+	}
+	e.schema = &schema{
+		objects: map[string]map[string]*fieldDefinition{
+			"Query": {
+				"transaction":  {typ: ref("Transaction"), args: map[string]typeRef{"id": ref("ID!")}, resolve: e.transaction},
+				"transactions": {typ: ref("TransactionPage!"), args: map[string]typeRef{"page": ref("Int"), "size": ref("Int"), "filter": ref("TransactionFilter")}, resolve: e.transactions},
+			},
+			"TransactionPage": {
+				"items":      {typ: ref("[Transaction!]!"), resolve: pageItems},
+				"page":       {typ: ref("Int!"), resolve: pageField(func(p *dto.ListTransactionsResponse) interface{} { return p.Page })},
+				"size":       {typ: ref("Int!"), resolve: pageField(func(p *dto.ListTransactionsResponse) interface{} { return p.Size })},
+				"total":      {typ: ref("Int!"), resolve: pageField(func(p *dto.ListTransactionsResponse) interface{} { return p.Total })},
+				"totalPages": {typ: ref("Int!"), resolve: pageField(func(p *dto.ListTransactionsResponse) interface{} { return p.TotalPages })},
+			},
+			"Transaction": {
+				"id":          {typ: ref("ID!"), resolve: transactionField(func(t *dto.GetTransactionResponse) interface{} { return t.ID.String() })},
+				"description": {typ: ref("String!"), resolve: transactionField(func(t *dto.GetTransactionResponse) interface{} { return t.Description })},
+				"date":        {typ: ref("DateTime!"), resolve: transactionField(func(t *dto.GetTransactionResponse) interface{} { return t.Date.Format(time.RFC3339) })},
+				"amount":      {typ: ref("Float!"), resolve: transactionField(func(t *dto.GetTransactionResponse) interface{} { return t.Amount })},
+				"currency":    {typ: ref("String!"), resolve: transactionField(func(t *dto.GetTransactionResponse) interface{} { return string(t.Currency) })},
+				"category": {typ: ref("String"), resolve: transactionField(func(t *dto.GetTransactionResponse) interface{} {
+					if t.Category == "" {
+						return nil
+					}
+					return t.Category
+				})},
+				"tags": {typ: ref("[String!]!"), resolve: transactionField(func(t *dto.GetTransactionResponse) interface{} {
+					tags := make([]interface{}, len(t.Tags))
+					for i, tag := range t.Tags {
+						tags[i] = tag
+					}
+					return tags
+				})},
+				"createdAt":       {typ: ref("DateTime!"), resolve: transactionField(func(t *dto.GetTransactionResponse) interface{} { return t.CreatedAt.Format(time.RFC3339) })},
+				"updatedAt":       {typ: ref("DateTime!"), resolve: transactionField(func(t *dto.GetTransactionResponse) interface{} { return t.UpdatedAt.Format(time.RFC3339) })},
+				"convertedAmount": {typ: ref("Float"), args: map[string]typeRef{"currency": ref("String!")}, resolve: e.convertedAmount},
+			},
+		},
+		inputs: map[string]map[string]typeRef{
+			"TransactionFilter": {
+				"dateFrom":            ref("Date"),
+				"dateTo":              ref("Date"),
+				"minAmount":           ref("Float"),
+				"maxAmount":           ref("Float"),
+				"descriptionContains": ref("String"),
+				"category":            ref("String"),
+				"tag":                 ref("String"),
+			},
+		},
+	}
+	return e
+}
+
+// Execute parses, validates and runs a query
+func (e *Executor) Execute(request *Request) *Response {
+	return e.schema.execute(request)
+}
+
+func (e *Executor) transaction(_ *Request, _ interface{}, args map[string]interface{}) (interface{}, error) {
+	id, err := uuid.Parse(args["id"].(string))
+	if err != nil {
+		return nil, errs.Newf(errs.ErrValidation, "invalid transaction ID: %q", args["id"])
+	}
+
+	transaction, err := e.getTransactionUseCase.Execute(id)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+func (e *Executor) transactions(request *Request, _ interface{}, args map[string]interface{}) (interface{}, error) {
+	listRequest := &dto.ListTransactionsRequest{}
+	if page, ok := args["page"].(int); ok {
+		listRequest.Page = page
+	}
+	if size, ok := args["size"].(int); ok {
+		listRequest.Size = size
+	}
+	if filter, ok := args["filter"].(map[string]interface{}); ok {
+		if date, ok := filter["dateFrom"].(time.Time); ok {
+			listRequest.DateFrom = &date
+		}
+		if date, ok := filter["dateTo"].(time.Time); ok {
+			listRequest.DateTo = &date
+		}
+		if amount, ok := filter["minAmount"].(float64); ok {
+			listRequest.MinAmount = &amount
+		}
+		if amount, ok := filter["maxAmount"].(float64); ok {
+			listRequest.MaxAmount = &amount
+		}
+		listRequest.DescriptionContains, _ = filter["descriptionContains"].(string)
+		listRequest.Category, _ = filter["category"].(string)
+		listRequest.Tag, _ = filter["tag"].(string)
+	}
+
+	page, err := e.listTransactionsUseCase.Execute(request.context(), listRequest)
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// convertedAmount previews the conversion of one transaction; a failure only nulls this field
+func (e *Executor) convertedAmount(request *Request, source interface{}, args map[string]interface{}) (interface{}, error) {
+	currency, err := entities.NewCurrencyCode(args["currency"].(string))
+	if err != nil || !e.convertTransactionUseCase.SupportsCurrency(currency) {
+		return nil, errs.Newf(errs.ErrUnsupportedCurrency, "unsupported currency: %s", args["currency"])
+	}
+
+	converted, err := e.convertTransactionUseCase.Preview(request.context(), &dto.ConvertTransactionRequest{
+		TransactionID:  source.(*dto.GetTransactionResponse).ID,
+		TargetCurrency: currency,
+		APIKey:         request.APIKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return converted.ConvertedAmount, nil
+}
+
+func pageItems(_ *Request, source interface{}, _ map[string]interface{}) (interface{}, error) {
+	page := source.(*dto.ListTransactionsResponse)
+	items := make([]interface{}, len(page.Data))
+	for i := range page.Data {
+		items[i] = &page.Data[i].GetTransactionResponse
+	}
+	return items, nil
+}
+
+func pageField(get func(*dto.ListTransactionsResponse) interface{}) resolver {
+	return func(_ *Request, source interface{}, _ map[string]interface{}) (interface{}, error) {
+		return get(source.(*dto.ListTransactionsResponse)), nil
+	}
+}
+
+func transactionField(get func(*dto.GetTransactionResponse) interface{}) resolver {
+	return func(_ *Request, source interface{}, _ map[string]interface{}) (interface{}, error) {
+		transaction, ok := source.(*dto.GetTransactionResponse)
+		if !ok {
+			return nil, fmt.Errorf("unexpected transaction source %T", source)
+		}
+		return get(transaction), nil
+	}
+}
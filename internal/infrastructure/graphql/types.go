@@ -0,0 +1,276 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// typeRef is a reference to a named type, possibly wrapped in a list and non-null markers
+type typeRef struct {
+	name        string
+	nonNull     bool
+	list        bool
+	itemNonNull bool
+}
+
+// ref parses a type reference in SDL notation, e.g. [String!]!
+func ref(notation string) typeRef {
+	t := typeRef{}
+	if strings.HasSuffix(notation, "!") {
+		t.nonNull = true
+		notation = strings.TrimSuffix(notation, "!")
+	}
+	if strings.HasPrefix(notation, "[") {
+		t.list = true
+		notation = strings.TrimSuffix(strings.TrimPrefix(notation, "["), "]")
+		if strings.HasSuffix(notation, "!") {
+			t.itemNonNull = true
+			notation = strings.TrimSuffix(notation, "!")
+		}
+	}
+	t.name = notation
+	return t
+}
+
+// item returns the type of the elements of a list type
+func (t typeRef) item() typeRef {
+	return typeRef{name: t.name, nonNull: t.itemNonNull}
+}
+
+func (t typeRef) String() string {
+	s := t.name
+	if t.list {
+		if t.itemNonNull {
+			s += "!"
+		}
+		s = "[" + s + "]"
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+// Built-in scalars plus Date (YYYY-MM-DD input) and DateTime (RFC 3339 output)
+var scalars = map[string]bool{
+	"ID": true, "String": true, "Int": true, "Float": true, "Boolean": true, "Date": true, "DateTime": true,
+}
+
+// resolver produces the value of a field from its parent value and coerced arguments
+// Objects are returned as values the object's resolvers understand, lists as []interface{}
+type resolver func(request *Request, source interface{}, args map[string]interface{}) (interface{}, error)
+
+// fieldDefinition is a field of an object type
+type fieldDefinition struct {
+	typ     typeRef
+	args    map[string]typeRef
+	resolve resolver
+}
+
+// schema holds the object and input object types reachable from Query
+type schema struct {
+	objects map[string]map[string]*fieldDefinition
+	inputs  map[string]map[string]typeRef
+}
+
+// isLeaf reports whether values of the named type are scalars without selections
+func (s *schema) isLeaf(name string) bool {
+	return scalars[name]
+}
+
+// isInput reports whether the named type can be used for arguments and variables
+func (s *schema) isInput(name string) bool {
+	_, input := s.inputs[name]
+	return (scalars[name] && name != "DateTime") || input
+}
+
+// coerce converts a JSON-like input value (variables, or literals after literalValue) to the type:
+// ID and String become string, Int int, Float float64, Boolean bool, Date time.Time and input objects maps
+func (s *schema) coerce(v interface{}, t typeRef) (interface{}, error) {
+	if v == nil {
+		if t.nonNull {
+			return nil, fmt.Errorf("expected a non-null value of type %s", t)
+		}
+		return nil, nil
+	}
+
+	if t.list {
+		items, ok := v.([]interface{})
+		if !ok {
+			items = []interface{}{v} // A single value is accepted as a list of one
+		}
+		coerced := make([]interface{}, len(items))
+		for i, item := range items {
+			var err error
+			if coerced[i], err = s.coerce(item, t.item()); err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+		return coerced, nil
+	}
+
+	if fields, ok := s.inputs[t.name]; ok {
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object of type %s", t.name)
+		}
+		coerced := make(map[string]interface{}, len(object))
+		for name := range object {
+			if _, known := fields[name]; !known {
+				return nil, fmt.Errorf("field %q is not defined by type %s", name, t.name)
+			}
+		}
+		for name, fieldType := range fields {
+			value, err := s.coerce(object[name], fieldType)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+			if value != nil {
+				coerced[name] = value
+			}
+		}
+		return coerced, nil
+	}
+
+	return coerceScalar(v, t.name)
+}
+
+func coerceScalar(v interface{}, name string) (interface{}, error) {
+	switch name {
+	case "ID":
+		switch id := v.(type) {
+		case string:
+			return id, nil
+		case json.Number:
+			if _, err := id.Int64(); err == nil {
+				return id.String(), nil
+			}
+		}
+	case "String":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "Int":
+		var n float64
+		switch number := v.(type) {
+		case json.Number:
+			i, err := number.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("Int cannot represent non-integer value: %s", number)
+			}
+			n = float64(i)
+		case int:
+			n = float64(number)
+		case float64:
+			n = number
+		default:
+			return nil, fmt.Errorf("Int cannot represent value: %v", v)
+		}
+		if n != math.Trunc(n) || n > math.MaxInt32 || n < math.MinInt32 {
+			return nil, fmt.Errorf("Int cannot represent value: %v", v)
+		}
+		return int(n), nil
+	case "Float":
+		switch number := v.(type) {
+		case json.Number:
+			if f, err := number.Float64(); err == nil {
+				return f, nil
+			}
+		case int:
+			return float64(number), nil
+		case float64:
+			return number, nil
+		}
+	case "Boolean":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case "Date":
+		if s, ok := v.(string); ok {
+			date, err := time.Parse(time.DateOnly, s)
+			if err != nil {
+				return nil, fmt.Errorf("Date must be formatted as YYYY-MM-DD, got %q", s)
+			}
+			return date, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown input type %s", name)
+	}
+	return nil, fmt.Errorf("%s cannot represent value: %v", name, v)
+}
+
+// literalValue converts a parsed literal to the JSON-like form variables arrive in, substituting variables
+func literalValue(v value, variables map[string]interface{}) (interface{}, error) {
+	switch v.kind {
+	case valueVariable:
+		return variables[v.raw], nil
+	case valueInt, valueFloat:
+		return json.Number(v.raw), nil
+	case valueString:
+		return v.raw, nil
+	case valueBoolean:
+		return v.raw == "true", nil
+	case valueNull:
+		return nil, nil
+	case valueList:
+		items := make([]interface{}, len(v.list))
+		for i, item := range v.list {
+			var err error
+			if items[i], err = literalValue(item, variables); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case valueObject:
+		object := make(map[string]interface{}, len(v.fields))
+		for _, objectField := range v.fields {
+			var err error
+			if object[objectField.name], err = literalValue(objectField.value, variables); err != nil {
+				return nil, err
+			}
+		}
+		return object, nil
+	}
+	return nil, fmt.Errorf("enum value %s is not valid here", v.raw)
+}
+
+// orderedMap is a result object whose fields keep the order they were selected in
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]interface{})}
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON writes the fields in selection order
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		b.Write(name)
+		b.WriteByte(':')
+		value, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return []byte(b.String()), nil
+}
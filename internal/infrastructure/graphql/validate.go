@@ -0,0 +1,153 @@
+package graphql
+
+import "fmt"
+
+// validator checks an operation against the schema before anything resolves
+type validator struct {
+	schema    *schema
+	doc       *document
+	defined   map[string]bool
+	errors    []*Error
+	fragments map[string]bool // Fragments on the current spread path, to reject cycles
+}
+
+// validate reports unknown fields, arguments, fragments and variables, and misplaced selections
+func (s *schema) validate(doc *document, op *operation) []*Error {
+	v := &validator{schema: s, doc: doc, defined: make(map[string]bool), fragments: make(map[string]bool)}
+
+	if op.kind != "query" {
+		v.errorf(op.location, "Only queries are supported, got a %s.", op.kind)
+		return v.errors
+	}
+	for _, definition := range op.variables {
+		if !s.isInput(definition.typeName) {
+			v.errorf(op.location, "Variable $%s cannot be of non-input type %s.", definition.name, definition.typeName)
+		}
+		v.defined[definition.name] = true
+	}
+	v.directives(op.directives)
+	v.selections("Query", op.selections)
+	return v.errors
+}
+
+func (v *validator) errorf(location Location, format string, args ...interface{}) {
+	err := &Error{Message: fmt.Sprintf(format, args...)}
+	if location.Line > 0 {
+		err.Locations = []Location{location}
+	}
+	v.errors = append(v.errors, err)
+}
+
+func (v *validator) selections(objectType string, selections []selection) {
+	fields := v.schema.objects[objectType]
+	for _, sel := range selections {
+		v.directives(sel.directives)
+
+		switch {
+		case sel.field != nil:
+			f := sel.field
+			if f.name == "__typename" {
+				if len(f.selections) > 0 {
+					v.errorf(sel.location, "Field \"__typename\" must not have a selection since type \"String!\" has no subfields.")
+				}
+				continue
+			}
+
+			definition, ok := fields[f.name]
+			if !ok {
+				v.errorf(sel.location, "Cannot query field %q on type %q.", f.name, objectType)
+				continue
+			}
+			v.arguments(sel.location, fmt.Sprintf("%s.%s", objectType, f.name), definition.args, f.arguments)
+
+			switch leaf := v.schema.isLeaf(definition.typ.name); {
+			case leaf && len(f.selections) > 0:
+				v.errorf(sel.location, "Field %q must not have a selection since type %q has no subfields.", f.name, definition.typ)
+			case !leaf && len(f.selections) == 0:
+				v.errorf(sel.location, "Field %q of type %q must have a selection of subfields.", f.name, definition.typ)
+			case !leaf:
+				v.selections(definition.typ.name, f.selections)
+			}
+
+		case sel.inlineFragment != nil:
+			if condition := sel.inlineFragment.typeCondition; condition != "" && condition != objectType {
+				v.errorf(sel.location, "Fragment cannot be spread here as objects of type %q can never be of type %q.", objectType, condition)
+				continue
+			}
+			v.selections(objectType, sel.inlineFragment.selections)
+
+		default:
+			frag, ok := v.doc.fragments[sel.fragmentSpread]
+			if !ok {
+				v.errorf(sel.location, "Unknown fragment %q.", sel.fragmentSpread)
+				continue
+			}
+			if frag.typeCondition != objectType {
+				v.errorf(sel.location, "Fragment %q cannot be spread here as objects of type %q can never be of type %q.", frag.name, objectType, frag.typeCondition)
+				continue
+			}
+			if v.fragments[frag.name] {
+				v.errorf(sel.location, "Cannot spread fragment %q within itself.", frag.name)
+				continue
+			}
+			v.fragments[frag.name] = true
+			v.directives(frag.directives)
+			v.selections(objectType, frag.selections)
+			delete(v.fragments, frag.name)
+		}
+	}
+}
+
+// arguments checks names and required arguments; values are coerced when the field resolves
+func (v *validator) arguments(location Location, coordinate string, definitions map[string]typeRef, arguments []argument) {
+	given := make(map[string]bool, len(arguments))
+	for _, arg := range arguments {
+		if _, ok := definitions[arg.name]; !ok {
+			v.errorf(arg.location, "Unknown argument %q on field %q.", arg.name, coordinate)
+			continue
+		}
+		if given[arg.name] {
+			v.errorf(arg.location, "There can be only one argument named %q.", arg.name)
+		}
+		given[arg.name] = true
+		v.variables(arg.location, arg.value)
+	}
+	for name, t := range definitions {
+		if t.nonNull && !given[name] {
+			v.errorf(location, "Field %q argument %q of type %q is required, but it was not provided.", coordinate, name, t)
+		}
+	}
+}
+
+// directives accepts only @skip(if:) and @include(if:)
+func (v *validator) directives(directives []directive) {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			v.errorf(d.location, "Unknown directive \"@%s\".", d.name)
+			continue
+		}
+		if len(d.arguments) != 1 || d.arguments[0].name != "if" {
+			v.errorf(d.location, "Directive \"@%s\" takes exactly one argument \"if\" of type \"Boolean!\".", d.name)
+			continue
+		}
+		v.variables(d.location, d.arguments[0].value)
+	}
+}
+
+// variables reports variables used in a value without being defined by the operation
+func (v *validator) variables(location Location, val value) {
+	switch val.kind {
+	case valueVariable:
+		if !v.defined[val.raw] {
+			v.errorf(location, "Variable \"$%s\" is not defined.", val.raw)
+		}
+	case valueList:
+		for _, item := range val.list {
+			v.variables(location, item)
+		}
+	case valueObject:
+		for _, objectField := range val.fields {
+			v.variables(objectField.location, objectField.value)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/errortracking"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// LoggingInterceptor logs every RPC with its status code and duration
+func LoggingInterceptor(log *logger.Logger) UnaryInterceptor {
+	return func(ctx context.Context, req Message, info *UnaryServerInfo, handler UnaryHandler) (Message, error) {
+		start := time.Now()
+		response, err := handler(ctx, req)
+
+		code := OK
+		if err != nil {
+			code = statusFromError(err).Code
+		}
+		fields := []interface{}{
+			"method", info.FullMethod,
+			"code", code,
+			"duration", time.Since(start).String(),
+		}
+		if code == Internal {
+			log.LogError(err, "gRPC call failed", fields...)
+		} else {
+			log.Info("gRPC call completed", fields...)
+		}
+		return response, err
+	}
+}
+
+// ErrorReportingInterceptor reports RPCs that failed with Internal to the error tracker; a nil reporter reports nothing
+func ErrorReportingInterceptor(reporter *errortracking.Reporter) UnaryInterceptor {
+	return func(ctx context.Context, req Message, info *UnaryServerInfo, handler UnaryHandler) (Message, error) {
+		response, err := handler(ctx, req)
+		if err != nil && statusFromError(err).Code == Internal {
+			reporter.CaptureError(ctx, err, map[string]string{"rpc": info.FullMethod})
+		}
+		return response, err
+	}
+}
+
+// TokenAuthenticator resolves an x-api-key secret to its stored token
+type TokenAuthenticator interface {
+	Authenticate(secret string) (*entities.APIToken, error)
+}
+
+// BearerAuthenticator verifies an authorization bearer token and returns its subject and role
+type BearerAuthenticator interface {
+	AuthenticateBearer(token string) (string, entities.APIRole, error)
+}
+
+// AuthInterceptor requires the same credentials as the REST API: an API token in x-api-key or,
+// when bearer is not nil, a bearer token in authorization, whose role covers the method
+func AuthInterceptor(tokens TokenAuthenticator, bearer BearerAuthenticator) UnaryInterceptor {
+	return func(ctx context.Context, req Message, info *UnaryServerInfo, handler UnaryHandler) (Message, error) {
+		md := MetadataFromContext(ctx)
+
+		var granted entities.APIRole
+		var caller string
+		scheme, bearerToken, found := strings.Cut(md.Get("Authorization"), " ")
+		if bearer != nil && found && strings.EqualFold(scheme, "Bearer") {
+			subject, role, err := bearer.AuthenticateBearer(strings.TrimSpace(bearerToken))
+			if err != nil {
+				return nil, authError(err)
+			}
+			granted = role
+			caller = "subject:" + subject
+		} else {
+			token, err := tokens.Authenticate(md.Get("X-Api-Key"))
+			if err != nil {
+				return nil, authError(err)
+			}
+			granted = token.Role
+			caller = "token:" + token.ID.String()
+		}
+
+		if !granted.Includes(info.Role) {
+			return nil, Errorf(PermissionDenied, "role %s does not grant %s access", granted, info.Role)
+		}
+		return handler(contextWithCaller(ctx, caller), req)
+	}
+}
+
+// authError is Unauthenticated for a rejected credential and Internal when the credential store failed
+func authError(err error) error {
+	if errors.Is(err, errs.ErrUnauthorized) {
+		return Errorf(Unauthenticated, "%v", err)
+	}
+	return Errorf(Internal, "failed to authenticate request: %v", err)
+}
@@ -0,0 +1,417 @@
+// Package grpc serves the transaction service of transaction.proto; its messages are generated by protoc-gen-go
+package grpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative transaction.proto
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ServiceName is the fully qualified name of the service in transaction.proto
+const ServiceName = "purchase.v1.TransactionService"
+
+// maxMessageSize bounds request messages, matching the default of gRPC servers
+const maxMessageSize = 4 << 20 // 4 MB
+
+// Message is a protobuf message of the transaction service
+type Message = proto.Message
+
+// UnaryHandler handles one decoded request message
+type UnaryHandler func(ctx context.Context, req Message) (Message, error)
+
+// UnaryServerInfo describes the RPC being called, for interceptors
+type UnaryServerInfo struct {
+	FullMethod string           // e.g. /purchase.v1.TransactionService/GetTransaction
+	Role       entities.APIRole // Role the caller needs when authentication is enabled
+}
+
+// UnaryInterceptor wraps every RPC; it calls handler to continue the chain
+type UnaryInterceptor func(ctx context.Context, req Message, info *UnaryServerInfo, handler UnaryHandler) (Message, error)
+
+// method is one RPC of the service
+type method struct {
+	role       entities.APIRole
+	newRequest func() Message
+	handle     UnaryHandler
+}
+
+// Server serves TransactionService over HTTP/2, sharing the use cases of the REST API
+type Server struct {
+	createTransactionUseCase  *usecases.CreateTransactionUseCase
+	getTransactionUseCase     *usecases.GetTransactionUseCase
+	listTransactionsUseCase   *usecases.ListTransactionsUseCase
+	convertTransactionUseCase *usecases.ConvertTransactionUseCase
+	auditLogUseCase           *usecases.AuditLogUseCase
+	methods                   map[string]method
+	interceptors              []UnaryInterceptor
+}
+
+// NewServer creates a Server for the transaction use cases
+func NewServer(
+	createTransactionUseCase *usecases.CreateTransactionUseCase,
+	getTransactionUseCase *usecases.GetTransactionUseCase,
+	listTransactionsUseCase *usecases.ListTransactionsUseCase,
+	convertTransactionUseCase *usecases.ConvertTransactionUseCase,
+) *Server {
+	s := &Server{
+		createTransactionUseCase:  createTransactionUseCase,
+		getTransactionUseCase:     getTransactionUseCase,
+		listTransactionsUseCase:   listTransactionsUseCase,
+		convertTransactionUseCase: convertTransactionUseCase,
+	}
+	s.methods = map[string]method{
+		"/" + ServiceName + "/CreateTransaction": {
+			role:       entities.RoleWrite,
+			newRequest: func() Message { return &CreateTransactionRequest{} },
+			handle:     s.createTransaction,
+		},
+		"/" + ServiceName + "/GetTransaction": {
+			role:       entities.RoleRead,
+			newRequest: func() Message { return &GetTransactionRequest{} },
+			handle:     s.getTransaction,
+		},
+		"/" + ServiceName + "/ListTransactions": {
+			role:       entities.RoleRead,
+			newRequest: func() Message { return &ListTransactionsRequest{} },
+			handle:     s.listTransactions,
+		},
+		"/" + ServiceName + "/ConvertTransaction": {
+			role:       entities.RoleWrite,
+			newRequest: func() Message { return &ConvertTransactionRequest{} },
+			handle:     s.convertTransaction,
+		},
+	}
+	return s
+}
+
+// WithAuditLog records created and converted transactions in the audit trail
+func (s *Server) WithAuditLog(auditLogUseCase *usecases.AuditLogUseCase) *Server {
+	s.auditLogUseCase = auditLogUseCase
+	return s
+}
+
+// WithInterceptors wraps every RPC in interceptors; the first one runs outermost
+func (s *Server) WithInterceptors(interceptors ...UnaryInterceptor) *Server {
+	s.interceptors = append(s.interceptors, interceptors...)
+	return s
+}
+
+// ServeHTTP answers a unary gRPC call; the status travels in the grpc-status and grpc-message trailers
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "gRPC requires POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != "application/grpc" && !strings.HasPrefix(contentType, "application/grpc+proto") {
+		http.Error(w, "unsupported content type: "+contentType, http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.WriteHeader(http.StatusOK)
+
+	response, err := s.call(r)
+	if err == nil {
+		err = writeResponse(w, response)
+	}
+	status := &Status{Code: OK}
+	if err != nil {
+		status = statusFromError(err)
+	}
+
+	code, message := status.trailer()
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", code)
+	if message != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", message)
+	}
+}
+
+// call decodes the request frame and runs the method through the interceptors
+func (s *Server) call(r *http.Request) (Message, error) {
+	method, ok := s.methods[r.URL.Path]
+	if !ok {
+		return nil, Errorf(Unimplemented, "unknown method %s", r.URL.Path)
+	}
+
+	payload, err := readFrame(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	request := method.newRequest()
+	if err := proto.Unmarshal(payload, request); err != nil {
+		return nil, Errorf(Internal, "failed to decode request: %v", err)
+	}
+
+	ctx := context.WithValue(r.Context(), metadataKey{}, r.Header)
+	info := &UnaryServerInfo{FullMethod: r.URL.Path, Role: method.role}
+	handler := method.handle
+	for i := len(s.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := s.interceptors[i], handler
+		handler = func(ctx context.Context, req Message) (Message, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	return handler(ctx, request)
+}
+
+// readFrame reads one length-prefixed message: a compressed flag, a 4-byte big-endian length and the payload
+func readFrame(body io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(body, prefix[:]); err != nil {
+		return nil, Errorf(Internal, "failed to read request: %v", err)
+	}
+	if prefix[0] != 0 {
+		return nil, Errorf(Unimplemented, "compressed messages are not supported")
+	}
+	length := binary.BigEndian.Uint32(prefix[1:])
+	if length > maxMessageSize {
+		return nil, Errorf(ResourceExhausted, "request of %d bytes exceeds the limit of %d", length, maxMessageSize)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(body, payload); err != nil {
+		return nil, Errorf(Internal, "failed to read request: %v", err)
+	}
+	return payload, nil
+}
+
+// writeResponse encodes the message and writes it as one frame
+func writeResponse(w io.Writer, message Message) error {
+	payload, err := proto.Marshal(message)
+	if err != nil {
+		return Errorf(Internal, "failed to encode response: %v", err)
+	}
+	return writeFrame(w, payload)
+}
+
+// writeFrame writes one uncompressed length-prefixed message
+func writeFrame(w io.Writer, payload []byte) error {
+	frame := make([]byte, 5, 5+len(payload))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(payload)))
+	if _, err := w.Write(append(frame, payload...)); err != nil {
+		return fmt.Errorf("failed to write response: %w", err)
+	}
+	return nil
+}
+
+type metadataKey struct{}
+
+type callerKey struct{}
+
+// MetadataFromContext returns the request metadata (HTTP/2 headers) of the current call
+func MetadataFromContext(ctx context.Context) http.Header {
+	if md, ok := ctx.Value(metadataKey{}).(http.Header); ok {
+		return md
+	}
+	return http.Header{}
+}
+
+// contextWithCaller returns a copy of ctx naming the authenticated caller, as token:<id> or subject:<sub>
+func contextWithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// callerScope identifies the caller like the REST API does: the authenticated token or subject,
+// else a hash of an unverified x-api-key, else "" for anonymous callers
+func callerScope(ctx context.Context) string {
+	if caller, ok := ctx.Value(callerKey{}).(string); ok {
+		return caller
+	}
+	if apiKey := MetadataFromContext(ctx).Get("X-Api-Key"); apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		return "key:" + hex.EncodeToString(sum[:])
+	}
+	return ""
+}
+
+// recordAudit appends a change made by the call to the audit trail; a failure is logged since the change is stored
+func (s *Server) recordAudit(ctx context.Context, entityID uuid.UUID, action string, changes interface{}) {
+	if s.auditLogUseCase == nil {
+		return
+	}
+
+	err := s.auditLogUseCase.Record(dto.AuditEntry{
+		EntityType: entities.AuditEntityTransaction,
+		EntityID:   entityID,
+		Action:     action,
+		Actor:      callerScope(ctx),
+		RequestID:  MetadataFromContext(ctx).Get("X-Request-Id"),
+		Changes:    changes,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to record audit log",
+			"entity_id", entityID.String(),
+			"action", action,
+			"error", err.Error(),
+		)
+	}
+}
+
+func (s *Server) createTransaction(ctx context.Context, req Message) (Message, error) {
+	request := req.(*CreateTransactionRequest)
+	response, err := s.createTransactionUseCase.Execute(&dto.CreateTransactionRequest{
+		Description: request.Description,
+		Date:        fromTimestamp(request.Date),
+		Amount:      request.Amount,
+		Category:    request.Category,
+		Tags:        request.Tags,
+		Currency:    entities.CurrencyCode(request.Currency),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.recordAudit(ctx, response.ID, entities.AuditActionCreate, response)
+
+	return &Transaction{
+		Id:          response.ID.String(),
+		Description: response.Description,
+		Date:        toTimestamp(response.Date),
+		Amount:      response.Amount,
+		Category:    response.Category,
+		Tags:        response.Tags,
+		CreatedAt:   toTimestamp(response.CreatedAt),
+		UpdatedAt:   toTimestamp(response.CreatedAt),
+		Currency:    string(response.Currency),
+	}, nil
+}
+
+func (s *Server) getTransaction(_ context.Context, req Message) (Message, error) {
+	id, err := parseID(req.(*GetTransactionRequest).Id)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.getTransactionUseCase.Execute(id)
+	if err != nil {
+		return nil, err
+	}
+	return newTransaction(response), nil
+}
+
+func (s *Server) listTransactions(ctx context.Context, req Message) (Message, error) {
+	request := req.(*ListTransactionsRequest)
+	listRequest := &dto.ListTransactionsRequest{
+		Page:                int(request.Page),
+		Size:                int(request.Size),
+		DescriptionContains: request.DescriptionContains,
+		Category:            request.Category,
+		Tag:                 request.Tag,
+	}
+	if request.DateFrom != nil {
+		dateFrom := fromTimestamp(request.DateFrom)
+		listRequest.DateFrom = &dateFrom
+	}
+	if request.DateTo != nil {
+		dateTo := fromTimestamp(request.DateTo)
+		listRequest.DateTo = &dateTo
+	}
+
+	response, err := s.listTransactionsUseCase.Execute(ctx, listRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &ListTransactionsResponse{
+		Transactions: make([]*Transaction, len(response.Data)),
+		Page:         int32(response.Page),
+		Size:         int32(response.Size),
+		Total:        response.Total,
+		TotalPages:   int32(response.TotalPages),
+	}
+	for i := range response.Data {
+		list.Transactions[i] = newTransaction(&response.Data[i].GetTransactionResponse)
+	}
+	return list, nil
+}
+
+func (s *Server) convertTransaction(ctx context.Context, req Message) (Message, error) {
+	request := req.(*ConvertTransactionRequest)
+	id, err := parseID(request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err := entities.NewCurrencyCode(request.TargetCurrency)
+	if err != nil || !s.convertTransactionUseCase.SupportsCurrency(currency) {
+		return nil, Errorf(InvalidArgument, "unsupported target currency: %s", request.TargetCurrency)
+	}
+
+	response, err := s.convertTransactionUseCase.Execute(ctx, &dto.ConvertTransactionRequest{
+		TransactionID:  id,
+		TargetCurrency: currency,
+		APIKey:         MetadataFromContext(ctx).Get("X-Api-Key"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.recordAudit(ctx, id, entities.AuditActionConvert, map[string]interface{}{
+		"target_currency":  response.TargetCurrency,
+		"exchange_rate":    response.ExchangeRate,
+		"converted_amount": response.ConvertedAmount,
+		"effective_date":   response.EffectiveDate,
+	})
+
+	return &ConvertTransactionResponse{
+		Transaction:     newTransaction(&response.Transaction),
+		TargetCurrency:  string(response.TargetCurrency),
+		ExchangeRate:    response.ExchangeRate,
+		ConvertedAmount: response.ConvertedAmount,
+		EffectiveDate:   toTimestamp(response.EffectiveDate),
+	}, nil
+}
+
+// parseID parses a transaction ID; a malformed one is InvalidArgument
+func parseID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, Errorf(InvalidArgument, "invalid transaction ID: %q", value)
+	}
+	return id, nil
+}
+
+func newTransaction(response *dto.GetTransactionResponse) *Transaction {
+	return &Transaction{
+		Id:          response.ID.String(),
+		Description: response.Description,
+		Date:        toTimestamp(response.Date),
+		Amount:      response.Amount,
+		Category:    response.Category,
+		Tags:        response.Tags,
+		CreatedAt:   toTimestamp(response.CreatedAt),
+		UpdatedAt:   toTimestamp(response.UpdatedAt),
+		Currency:    string(response.Currency),
+	}
+}
+
+// toTimestamp converts a time to a google.protobuf.Timestamp; the zero time is left unset
+func toTimestamp(value time.Time) *timestamppb.Timestamp {
+	if value.IsZero() {
+		return nil
+	}
+	return timestamppb.New(value)
+}
+
+// fromTimestamp converts a google.protobuf.Timestamp to UTC; an unset one is the zero time
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
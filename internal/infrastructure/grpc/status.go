@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+)
+
+// Code is a gRPC status code, sent in the grpc-status trailer
+type Code uint32
+
+// Status codes used by the transaction service
+const (
+	OK                 Code = 0
+	InvalidArgument    Code = 3
+	NotFound           Code = 5
+	AlreadyExists      Code = 6
+	PermissionDenied   Code = 7
+	ResourceExhausted  Code = 8
+	FailedPrecondition Code = 9
+	Unimplemented      Code = 12
+	Internal           Code = 13
+	Unavailable        Code = 14
+	Unauthenticated    Code = 16
+)
+
+// Status is an RPC error carrying its status code
+type Status struct {
+	Code    Code
+	Message string
+}
+
+// Errorf creates a Status error
+func Errorf(code Code, format string, args ...interface{}) *Status {
+	return &Status{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (s *Status) Error() string {
+	return fmt.Sprintf("rpc error: code = %d desc = %s", s.Code, s.Message)
+}
+
+// errorKinds maps domain error kinds to status codes, checked in order like the HTTP handlers
+var errorKinds = []struct {
+	kind error
+	code Code
+}{
+	{errs.ErrIdempotencyReused, InvalidArgument},
+	{errs.ErrValidation, InvalidArgument},
+	{errs.ErrNotFound, NotFound},
+	{errs.ErrConflict, AlreadyExists},
+	{errs.ErrExpired, FailedPrecondition},
+	{errs.ErrRateUnavailable, FailedPrecondition},
+	{errs.ErrUnsupportedCurrency, InvalidArgument},
+	{errs.ErrServiceUnavailable, Unavailable},
+	{errs.ErrQuotaExceeded, ResourceExhausted},
+	{errs.ErrUnauthorized, Unauthenticated},
+}
+
+// statusFromError converts a handler error to a Status; unclassified errors are Internal
+func statusFromError(err error) *Status {
+	var status *Status
+	if errors.As(err, &status) {
+		return status
+	}
+	for _, entry := range errorKinds {
+		if errors.Is(err, entry.kind) {
+			return &Status{Code: entry.code, Message: err.Error()}
+		}
+	}
+	return &Status{Code: Internal, Message: err.Error()}
+}
+
+// trailer returns the grpc-status and percent-encoded grpc-message trailer values
+func (s *Status) trailer() (string, string) {
+	return strconv.FormatUint(uint64(s.Code), 10), url.PathEscape(s.Message)
+}
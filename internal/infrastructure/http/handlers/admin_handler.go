@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// AdminHandler handles HTTP requests for administrative dataset operations
+type AdminHandler struct {
+	exportDatasetUseCase   *usecases.ExportDatasetUseCase
+	importDatasetUseCase   *usecases.ImportDatasetUseCase
+	batchConversionUseCase *usecases.BatchConversionUseCase
+	monitorDatabaseUseCase *usecases.MonitorDatabaseUseCase
+	manageRateCacheUseCase *usecases.ManageRateCacheUseCase
+	rootLogger             *logger.Logger
+}
+
+// NewAdminHandler creates a new AdminHandler
+func NewAdminHandler(
+	exportDatasetUseCase *usecases.ExportDatasetUseCase,
+	importDatasetUseCase *usecases.ImportDatasetUseCase,
+	batchConversionUseCase *usecases.BatchConversionUseCase,
+	monitorDatabaseUseCase *usecases.MonitorDatabaseUseCase,
+	manageRateCacheUseCase *usecases.ManageRateCacheUseCase,
+) *AdminHandler {
+	return &AdminHandler{
+		exportDatasetUseCase:   exportDatasetUseCase,
+		importDatasetUseCase:   importDatasetUseCase,
+		batchConversionUseCase: batchConversionUseCase,
+		monitorDatabaseUseCase: monitorDatabaseUseCase,
+		manageRateCacheUseCase: manageRateCacheUseCase,
+	}
+}
+
+// WithLogLevel lets the log level routes read and change the level of the root logger
+func (h *AdminHandler) WithLogLevel(rootLogger *logger.Logger) *AdminHandler {
+	h.rootLogger = rootLogger
+	return h
+}
+
+// ExportDataset handles GET /admin/export
+func (h *AdminHandler) ExportDataset(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	// The archive is streamed as it is read, so a failure part way through can only cut the response short
+	c.Header("Content-Disposition", `attachment; filename="dataset-archive.json"`)
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	summary, err := h.exportDatasetUseCase.Execute(c.Writer)
+	if err != nil {
+		contextLogger.LogError(err, "Failed to export dataset")
+		return
+	}
+
+	contextLogger.LogOperation("export_dataset", "", true,
+		"transactions", summary.Transactions,
+		"exchange_rates", summary.ExchangeRates,
+		"conversions", summary.Conversions,
+	)
+}
+
+// ImportDataset handles POST /admin/import?strategy=skip|overwrite|fail
+func (h *AdminHandler) ImportDataset(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	var archive dto.DatasetArchive
+	if err := c.ShouldBindJSON(&archive); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid archive format", err.Error()))
+		return
+	}
+
+	request := &dto.ImportArchiveRequest{
+		Archive:  &archive,
+		Strategy: c.Query("strategy"),
+	}
+
+	response, err := h.importDatasetUseCase.Execute(request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to import dataset", err))
+		return
+	}
+
+	contextLogger.LogOperation("import_dataset", "", true,
+		"strategy", response.Strategy,
+		"transactions_created", response.Transactions.Created,
+		"exchange_rates_created", response.ExchangeRates.Created,
+	)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DatabaseUsage handles GET /admin/database
+func (h *AdminHandler) DatabaseUsage(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	response, err := h.monitorDatabaseUseCase.Execute(c.Request.Context())
+	if err != nil {
+		contextLogger.LogError(err, "Failed to measure database")
+		respondProblem(c, errorProblem(c, "Failed to measure database", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// StartBatchConversion handles POST /admin/conversions
+func (h *AdminHandler) StartBatchConversion(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	var httpRequest dto.StartBatchConversionHTTPRequest
+	if err := c.ShouldBindJSON(&httpRequest); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	request, err := httpRequest.ToStartBatchConversionRequest()
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request", err.Error()))
+		return
+	}
+
+	response, err := h.batchConversionUseCase.Start(request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to start batch conversion", err))
+		return
+	}
+
+	contextLogger.LogOperation("start_batch_conversion", response.ID.String(), true,
+		"target_currency", response.TargetCurrency,
+		"from", response.From,
+		"to", response.To,
+	)
+
+	c.Header("Location", "/api/v1/admin/conversions/"+response.ID.String())
+	c.JSON(http.StatusAccepted, response)
+}
+
+// GetBatchConversion handles GET /admin/conversions/:id
+func (h *AdminHandler) GetBatchConversion(c *gin.Context) {
+	batchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid batch ID format", "Batch ID must be a valid UUID"))
+		return
+	}
+
+	response, err := h.batchConversionUseCase.GetStatus(batchID)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve batch conversion", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListBatchConversionRecords handles GET /admin/conversions/:id/records
+func (h *AdminHandler) ListBatchConversionRecords(c *gin.Context) {
+	batchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid batch ID format", "Batch ID must be a valid UUID"))
+		return
+	}
+
+	errs := queryErrors{}
+	page := parseIntQuery(c, errs, "page", 1, 1, math.MaxInt32)
+	size := parseIntQuery(c, errs, "size", 20, 1, 100)
+	if len(errs) > 0 {
+		respondProblem(c, invalidQuery(c, errs))
+		return
+	}
+
+	response, err := h.batchConversionUseCase.GetRecords(batchID, page, size)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve conversion records", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RateCache handles GET /admin/cache/rates?currency=EUR
+func (h *AdminHandler) RateCache(c *gin.Context) {
+	response, err := h.manageRateCacheUseCase.Inspect(c.Query("currency"))
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to inspect rate cache", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// EvictRateCache handles DELETE /admin/cache/rates?currency=EUR&effective_date=2025-03-31 or ?all=true
+func (h *AdminHandler) EvictRateCache(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	errs := queryErrors{}
+	request := &dto.EvictRateCacheRequest{
+		Currency:      c.Query("currency"),
+		EffectiveDate: c.Query("effective_date"),
+		All:           parseBoolQuery(c, errs, "all"),
+	}
+	if len(errs) > 0 {
+		respondProblem(c, invalidQuery(c, errs))
+		return
+	}
+
+	response, err := h.manageRateCacheUseCase.Evict(request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to evict cached rates", err))
+		return
+	}
+
+	contextLogger.LogOperation("evict_rate_cache", string(response.Currency), true,
+		"effective_date", response.EffectiveDate,
+		"evicted", response.Evicted,
+	)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetLogLevel handles GET /admin/loglevel
+func (h *AdminHandler) GetLogLevel(c *gin.Context) {
+	if h.rootLogger == nil {
+		respondProblem(c, errorProblem(c, "Log level unavailable", errs.Newf(errs.ErrNotFound, "log level control is not enabled")))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LogLevelResponse{Level: h.rootLogger.Level()})
+}
+
+// SetLogLevel handles PUT /admin/loglevel, changing the level without a restart
+func (h *AdminHandler) SetLogLevel(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	if h.rootLogger == nil {
+		respondProblem(c, errorProblem(c, "Log level unavailable", errs.Newf(errs.ErrNotFound, "log level control is not enabled")))
+		return
+	}
+
+	var request dto.LogLevelRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	previous, err := h.rootLogger.SetLevel(request.Level)
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid log level", err.Error()))
+		return
+	}
+
+	// Logged at WARN so the change is recorded whatever the new level
+	contextLogger.Warn("Log level changed", "from", previous, "to", h.rootLogger.Level())
+
+	c.JSON(http.StatusOK, dto.LogLevelResponse{Level: h.rootLogger.Level(), Previous: previous})
+}
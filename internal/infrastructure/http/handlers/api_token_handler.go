@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// APITokenHandler handles HTTP requests for API token lifecycle management
+type APITokenHandler struct {
+	manageAPITokensUseCase *usecases.ManageAPITokensUseCase
+}
+
+// NewAPITokenHandler creates a new APITokenHandler
+func NewAPITokenHandler(manageAPITokensUseCase *usecases.ManageAPITokensUseCase) *APITokenHandler {
+	return &APITokenHandler{
+		manageAPITokensUseCase: manageAPITokensUseCase,
+	}
+}
+
+// CreateToken handles POST /admin/tokens
+func (h *APITokenHandler) CreateToken(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	var request dto.CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	response, err := h.manageAPITokensUseCase.Create(&request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to create API token", err))
+		return
+	}
+
+	contextLogger.LogOperation("create_api_token", response.ID.String(), true,
+		"name", response.Name,
+		"role", response.Role,
+		"prefix", response.Prefix,
+	)
+
+	// The secret is only shown once; keep it out of caches
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListTokens handles GET /admin/tokens
+func (h *APITokenHandler) ListTokens(c *gin.Context) {
+	response, err := h.manageAPITokensUseCase.List()
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve API tokens", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetToken handles GET /admin/tokens/:id
+func (h *APITokenHandler) GetToken(c *gin.Context) {
+	tokenID, ok := parseAPITokenID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.manageAPITokensUseCase.Get(tokenID)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve API token", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RotateToken handles POST /admin/tokens/:id/rotate; the body is optional
+func (h *APITokenHandler) RotateToken(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	tokenID, ok := parseAPITokenID(c)
+	if !ok {
+		return
+	}
+
+	var request dto.RotateAPITokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	response, err := h.manageAPITokensUseCase.Rotate(tokenID, &request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to rotate API token", err))
+		return
+	}
+
+	contextLogger.LogOperation("rotate_api_token", tokenID.String(), true,
+		"replaced_by", response.ID.String(),
+		"grace_period_minutes", request.GracePeriodMinutes,
+	)
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, response)
+}
+
+// RevokeToken handles DELETE /admin/tokens/:id
+func (h *APITokenHandler) RevokeToken(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	tokenID, ok := parseAPITokenID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.manageAPITokensUseCase.Revoke(tokenID); err != nil {
+		respondProblem(c, errorProblem(c, "Failed to revoke API token", err))
+		return
+	}
+
+	contextLogger.LogOperation("revoke_api_token", tokenID.String(), true)
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseAPITokenID reads the :id path parameter, answering 400 when it is not a UUID
+func parseAPITokenID(c *gin.Context) (uuid.UUID, bool) {
+	tokenID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid token ID format", "Token ID must be a valid UUID"))
+		return uuid.Nil, false
+	}
+	return tokenID, true
+}
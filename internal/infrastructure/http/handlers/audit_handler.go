@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// AuditHandler handles HTTP requests for reviewing the audit trail
+type AuditHandler struct {
+	auditLogUseCase *usecases.AuditLogUseCase
+}
+
+// NewAuditHandler creates a new AuditHandler
+func NewAuditHandler(auditLogUseCase *usecases.AuditLogUseCase) *AuditHandler {
+	return &AuditHandler{
+		auditLogUseCase: auditLogUseCase,
+	}
+}
+
+// ListAuditLogs handles GET /audit
+func (h *AuditHandler) ListAuditLogs(c *gin.Context) {
+	errs := queryErrors{}
+	page := parseIntQuery(c, errs, "page", 1, 1, math.MaxInt32)
+	size := parseIntQuery(c, errs, "size", 20, 1, 100)
+	from := parseDateQuery(c, errs, "from")
+	to := parseDateQuery(c, errs, "to")
+
+	var entityID *uuid.UUID
+	if raw, present := c.GetQuery("entity_id"); present {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			errs.add("entity_id", "entity_id must be a valid UUID")
+		} else {
+			entityID = &parsed
+		}
+	}
+
+	if len(errs) > 0 {
+		respondProblem(c, invalidQuery(c, errs))
+		return
+	}
+
+	response, err := h.auditLogUseCase.List(&dto.ListAuditLogsRequest{
+		EntityID: entityID,
+		From:     from,
+		To:       to,
+		Page:     page,
+		Size:     size,
+	})
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve audit logs", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// recordAudit appends a change made by the request to the audit trail, attributed to the caller and request ID
+// The change is already stored when this runs, so a failure is logged instead of failing the request
+func recordAudit(c *gin.Context, auditLog *usecases.AuditLogUseCase, entityType string, entityID uuid.UUID, action string, changes interface{}) {
+	if auditLog == nil {
+		return
+	}
+
+	err := auditLog.Record(dto.AuditEntry{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Actor:      callerScope(c),
+		RequestID:  c.GetString("request_id"),
+		Changes:    changes,
+	})
+	if err != nil {
+		log, exists := c.Get("logger")
+		if !exists {
+			log = &logger.Logger{}
+		}
+		log.(*logger.Logger).LogError(err, "Failed to record audit log",
+			"entity_id", entityID.String(),
+			"action", action,
+		)
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// BudgetHandler handles HTTP requests for budget management
+type BudgetHandler struct {
+	manageBudgetsUseCase *usecases.ManageBudgetsUseCase
+}
+
+// NewBudgetHandler creates a new BudgetHandler
+func NewBudgetHandler(manageBudgetsUseCase *usecases.ManageBudgetsUseCase) *BudgetHandler {
+	return &BudgetHandler{
+		manageBudgetsUseCase: manageBudgetsUseCase,
+	}
+}
+
+// CreateBudget handles POST /budgets
+func (h *BudgetHandler) CreateBudget(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	var request dto.BudgetRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	response, err := h.manageBudgetsUseCase.Create(&request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to create budget", err))
+		return
+	}
+
+	contextLogger.LogOperation("create_budget", response.ID.String(), true,
+		"category", response.Category,
+		"period", response.Period,
+		"limit", response.Limit,
+		"currency", response.Currency,
+	)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListBudgets handles GET /budgets
+func (h *BudgetHandler) ListBudgets(c *gin.Context) {
+	response, err := h.manageBudgetsUseCase.List()
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve budgets", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBudget handles GET /budgets/:id
+func (h *BudgetHandler) GetBudget(c *gin.Context) {
+	budgetID, ok := parseBudgetID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.manageBudgetsUseCase.Get(budgetID)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve budget", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateBudget handles PUT /budgets/:id
+func (h *BudgetHandler) UpdateBudget(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	budgetID, ok := parseBudgetID(c)
+	if !ok {
+		return
+	}
+
+	var request dto.BudgetRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	response, err := h.manageBudgetsUseCase.Update(budgetID, &request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to update budget", err))
+		return
+	}
+
+	contextLogger.LogOperation("update_budget", budgetID.String(), true,
+		"limit", response.Limit,
+		"currency", response.Currency,
+	)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteBudget handles DELETE /budgets/:id
+func (h *BudgetHandler) DeleteBudget(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	budgetID, ok := parseBudgetID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.manageBudgetsUseCase.Delete(budgetID); err != nil {
+		respondProblem(c, errorProblem(c, "Failed to delete budget", err))
+		return
+	}
+
+	contextLogger.LogOperation("delete_budget", budgetID.String(), true)
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseBudgetID reads the :id path parameter, answering 400 when it is not a UUID
+func parseBudgetID(c *gin.Context) (uuid.UUID, bool) {
+	budgetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid budget ID format", "Budget ID must be a valid UUID"))
+		return uuid.Nil, false
+	}
+	return budgetID, true
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// CategoryHandler handles HTTP requests for category management
+type CategoryHandler struct {
+	manageCategoriesUseCase *usecases.ManageCategoriesUseCase
+}
+
+// NewCategoryHandler creates a new CategoryHandler
+func NewCategoryHandler(manageCategoriesUseCase *usecases.ManageCategoriesUseCase) *CategoryHandler {
+	return &CategoryHandler{
+		manageCategoriesUseCase: manageCategoriesUseCase,
+	}
+}
+
+// CreateCategory handles POST /categories
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	var request dto.CategoryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	response, err := h.manageCategoriesUseCase.Create(&request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to create category", err))
+		return
+	}
+
+	contextLogger.LogOperation("create_category", response.ID.String(), true,
+		"name", response.Name,
+	)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListCategories handles GET /categories
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	response, err := h.manageCategoriesUseCase.List()
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve categories", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCategory handles GET /categories/:id
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	categoryID, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.manageCategoriesUseCase.Get(categoryID)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve category", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateCategory handles PUT /categories/:id
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	categoryID, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	var request dto.CategoryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	response, err := h.manageCategoriesUseCase.Update(categoryID, &request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to update category", err))
+		return
+	}
+
+	contextLogger.LogOperation("update_category", categoryID.String(), true,
+		"name", response.Name,
+	)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteCategory handles DELETE /categories/:id
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	categoryID, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.manageCategoriesUseCase.Delete(categoryID); err != nil {
+		respondProblem(c, errorProblem(c, "Failed to delete category", err))
+		return
+	}
+
+	contextLogger.LogOperation("delete_category", categoryID.String(), true)
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseCategoryID reads the :id path parameter, answering 400 when it is not a UUID
+func parseCategoryID(c *gin.Context) (uuid.UUID, bool) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid category ID format", "Category ID must be a valid UUID"))
+		return uuid.Nil, false
+	}
+	return categoryID, true
+}
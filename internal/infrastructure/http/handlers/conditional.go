@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+)
+
+// notModifiedSince reports whether If-Modified-Since is at or after lastModified
+// Unparseable header values are ignored, as RFC 9110 requires
+func notModifiedSince(c *gin.Context, lastModified time.Time) bool {
+	header := c.GetHeader("If-Modified-Since")
+	if header == "" {
+		return false
+	}
+
+	since, err := http.ParseTime(header)
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.After(since)
+}
+
+// notModified reports whether a GET can be answered with 304 Not Modified
+// If-None-Match takes precedence over If-Modified-Since when both are sent (RFC 9110 section 13.2.2)
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if header := c.GetHeader("If-None-Match"); header != "" {
+		return etagMatches(header, etag)
+	}
+	return notModifiedSince(c, lastModified)
+}
+
+// etagMatches applies the weak comparison If-None-Match calls for: tags match whether or not either is weak
+// If-Match compares the same way, since every ETag this API hands out is weak
+func etagMatches(header, etag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	opaque := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == opaque {
+			return true
+		}
+	}
+	return false
+}
+
+// transactionLastModified returns the latest change to a transaction: its update, deletion or archiving
+// Truncated to HTTP-date precision so it round-trips through Last-Modified
+func transactionLastModified(transaction *dto.GetTransactionResponse) time.Time {
+	lastModified := transaction.UpdatedAt
+	for _, changed := range []*time.Time{transaction.DeletedAt, transaction.ArchivedAt} {
+		if changed != nil && changed.After(lastModified) {
+			lastModified = *changed
+		}
+	}
+	return lastModified.UTC().Truncate(time.Second)
+}
+
+// transactionETag returns a weak entity tag for a transaction rendered in format
+// It changes whenever the transaction does, and differs between the negotiated formats
+func transactionETag(transaction *dto.GetTransactionResponse, format string) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s|%s|%d|%d", transaction.ID, format, transaction.Version, transaction.UpdatedAt.UnixNano())
+	for _, changed := range []*time.Time{transaction.DeletedAt, transaction.ArchivedAt} {
+		if changed != nil {
+			fmt.Fprintf(hash, "|%d", changed.UnixNano())
+		} else {
+			fmt.Fprint(hash, "|")
+		}
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)[:8]) + `"`
+}
+
+// matchesCurrentETag reports whether an If-Match header names the current ETag of the transaction in any format
+// Every format's ETag changes with the transaction, so whichever one the client read proves it saw this state
+func matchesCurrentETag(header string, transaction *dto.GetTransactionResponse) bool {
+	for _, format := range []string{gin.MIMEJSON, gin.MIMEXML, mimeCSV, mimeJSONAPI} {
+		if etagMatches(header, transactionETag(transaction, format)) {
+			return true
+		}
+	}
+	return false
+}
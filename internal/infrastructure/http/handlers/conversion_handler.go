@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// APIKeyHeader carries the caller's API key, used to select per-key conversion margins
+const APIKeyHeader = "X-API-Key"
+
+// ConversionHandler handles HTTP requests for standalone currency conversions
+type ConversionHandler struct {
+	convertAmountUseCase   *usecases.ConvertAmountUseCase
+	createQuoteUseCase     *usecases.CreateQuoteUseCase
+	getExchangeRateUseCase *usecases.GetExchangeRateUseCase
+}
+
+// NewConversionHandler creates a new ConversionHandler
+func NewConversionHandler(
+	convertAmountUseCase *usecases.ConvertAmountUseCase,
+	createQuoteUseCase *usecases.CreateQuoteUseCase,
+	getExchangeRateUseCase *usecases.GetExchangeRateUseCase,
+) *ConversionHandler {
+	return &ConversionHandler{
+		convertAmountUseCase:   convertAmountUseCase,
+		createQuoteUseCase:     createQuoteUseCase,
+		getExchangeRateUseCase: getExchangeRateUseCase,
+	}
+}
+
+// ConvertAmount handles POST /convert
+func (h *ConversionHandler) ConvertAmount(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	var requestBody dto.ConvertAmountHTTPRequest
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		contextLogger.LogError(err, "Invalid request format in ConvertAmount")
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	// Normalize and check the currency against the supported set before running the use case
+	request, err := requestBody.ToConvertAmountRequest()
+	if errors.Is(err, dto.ErrInvalidQuoteID) {
+		respondProblem(c, invalidRequest(c, "Invalid request format", err.Error()))
+		return
+	}
+	if err != nil || !h.convertAmountUseCase.SupportsCurrency(request.TargetCurrency) {
+		respondProblem(c, unsupportedCurrency(c, "Failed to convert amount", requestBody.TargetCurrency, h.convertAmountUseCase.SupportedCurrencies()))
+		return
+	}
+
+	request.APIKey = c.GetHeader(APIKeyHeader)
+
+	response, err := h.convertAmountUseCase.Execute(c.Request.Context(), request)
+	if err != nil {
+		statusCode := errorStatus(err)
+
+		contextLogger.LogError(err, "Failed to convert amount",
+			"target_currency", request.TargetCurrency,
+			"status_code", statusCode,
+		)
+
+		respondProblem(c, errorProblem(c, "Failed to convert amount", err))
+		return
+	}
+
+	contextLogger.LogOperation("convert_amount", "", true,
+		"target_currency", response.TargetCurrency,
+		"amount", response.Amount,
+		"converted_amount", response.ConvertedAmount,
+		"exchange_rate", response.ExchangeRate,
+	)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateQuote handles POST /quotes
+func (h *ConversionHandler) CreateQuote(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	var requestBody dto.CreateQuoteHTTPRequest
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	request, err := requestBody.ToCreateQuoteRequest()
+	if err != nil || !h.convertAmountUseCase.SupportsCurrency(request.TargetCurrency) {
+		respondProblem(c, unsupportedCurrency(c, "Failed to create quote", requestBody.TargetCurrency, h.convertAmountUseCase.SupportedCurrencies()))
+		return
+	}
+
+	response, err := h.createQuoteUseCase.Execute(c.Request.Context(), request)
+	if err != nil {
+		statusCode := errorStatus(err)
+
+		contextLogger.LogError(err, "Failed to create quote",
+			"target_currency", request.TargetCurrency,
+			"status_code", statusCode,
+		)
+
+		respondProblem(c, errorProblem(c, "Failed to create quote", err))
+		return
+	}
+
+	contextLogger.LogOperation("create_quote", response.QuoteID.String(), true,
+		"target_currency", response.TargetCurrency,
+		"exchange_rate", response.ExchangeRate,
+		"expires_at", response.ExpiresAt,
+	)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetExchangeRate handles GET /exchange-rates?from=USD&to=EUR&date=2024-01-15
+// from defaults to USD and date to today
+func (h *ConversionHandler) GetExchangeRate(c *gin.Context) {
+	errs := queryErrors{}
+	request := &dto.GetExchangeRateRequest{
+		From:   entities.USD,
+		Date:   time.Now().UTC().Truncate(24 * time.Hour),
+		APIKey: c.GetHeader(APIKeyHeader),
+	}
+
+	if raw, present := c.GetQuery("from"); present {
+		code, err := entities.NewCurrencyCode(raw)
+		if err != nil || !h.getExchangeRateUseCase.SupportsSourceCurrency(code) {
+			errs.add("from", fmt.Sprintf("unsupported source currency %q, supported: %v", raw, h.getExchangeRateUseCase.SupportedSourceCurrencies()))
+		}
+		request.From = code
+	}
+
+	// USD is a valid target of cross rates; whether it fits the source is checked with the rate
+	raw := c.Query("to")
+	code, err := entities.NewCurrencyCode(raw)
+	if err != nil || (code != entities.USD && !h.getExchangeRateUseCase.SupportsCurrency(code)) {
+		errs.add("to", fmt.Sprintf("unsupported currency %q, supported: %v", raw, h.getExchangeRateUseCase.SupportedCurrencies()))
+	}
+	request.To = code
+
+	if date := parseDateQuery(c, errs, "date"); date != nil {
+		request.Date = *date
+	}
+
+	if len(errs) > 0 {
+		respondProblem(c, invalidQuery(c, errs))
+		return
+	}
+
+	response, err := h.getExchangeRateUseCase.Execute(c.Request.Context(), request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve exchange rate", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
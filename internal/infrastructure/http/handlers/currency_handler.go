@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+)
+
+// CurrencyHandler handles HTTP requests for currency metadata
+type CurrencyHandler struct {
+	getCurrencyUseCase *usecases.GetCurrencyUseCase
+}
+
+// NewCurrencyHandler creates a new CurrencyHandler
+func NewCurrencyHandler(getCurrencyUseCase *usecases.GetCurrencyUseCase) *CurrencyHandler {
+	return &CurrencyHandler{
+		getCurrencyUseCase: getCurrencyUseCase,
+	}
+}
+
+// GetCurrency handles GET /currencies/:code
+func (h *CurrencyHandler) GetCurrency(c *gin.Context) {
+	response, err := h.getCurrencyUseCase.Execute(c.Param("code"))
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve currency", err))
+		return
+	}
+
+	respond(c, http.StatusOK, response)
+}
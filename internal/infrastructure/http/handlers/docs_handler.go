@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUIVersion pins the Swagger UI assets loaded by the documentation page
+const swaggerUIVersion = "5.17.14"
+
+// swaggerUIPage renders the published contract with Swagger UI; the assets come from the swagger-ui-dist package on unpkg
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>Purchase Transaction API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui", deepLinking: true });
+    };
+  </script>
+</body>
+</html>
+`
+
+// DocsHandler serves the OpenAPI contract and an interactive page to browse it
+type DocsHandler struct {
+	spec []byte
+}
+
+// NewDocsHandler creates a new DocsHandler serving the given OpenAPI document
+func NewDocsHandler(spec []byte) *DocsHandler {
+	return &DocsHandler{
+		spec: spec,
+	}
+}
+
+// OpenAPISpec handles GET /openapi.json
+func (h *DocsHandler) OpenAPISpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// SwaggerUI handles GET /swagger/index.html
+func (h *DocsHandler) SwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
+
+// RedirectToSwaggerUI sends /swagger and /swagger/ to the documentation page
+func (h *DocsHandler) RedirectToSwaggerUI(c *gin.Context) {
+	c.Redirect(http.StatusMovedPermanently, "/swagger/index.html")
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+)
+
+// errorKinds maps domain error kinds to HTTP status codes and problem types, checked in order
+// A request that ran out of time is checked first, whatever failed as a result, and an idempotency key
+// reused with a different body is checked before plain validation failures
+var errorKinds = []struct {
+	kind        error
+	status      int
+	problemType string
+}{
+	{context.DeadlineExceeded, http.StatusGatewayTimeout, problem.TypeTimeout},
+	{errs.ErrIdempotencyReused, http.StatusUnprocessableEntity, problem.TypeIdempotencyReused},
+	{errs.ErrValidation, http.StatusBadRequest, problem.TypeValidation},
+	{errs.ErrNotFound, http.StatusNotFound, problem.TypeNotFound},
+	{errs.ErrConflict, http.StatusConflict, problem.TypeConflict},
+	{errs.ErrExpired, http.StatusGone, problem.TypeExpired},
+	{errs.ErrRateUnavailable, http.StatusUnprocessableEntity, problem.TypeRateUnavailable},
+	{errs.ErrUnsupportedCurrency, http.StatusUnprocessableEntity, problem.TypeUnsupportedCurrency},
+	{errs.ErrServiceUnavailable, http.StatusServiceUnavailable, problem.TypeServiceUnavailable},
+	{errs.ErrQuotaExceeded, http.StatusInsufficientStorage, problem.TypeQuotaExceeded},
+	{errs.ErrUnauthorized, http.StatusUnauthorized, problem.TypeUnauthorized},
+}
+
+// errorStatus maps a use case error to its HTTP status code; unclassified errors are 500
+func errorStatus(err error) int {
+	for _, entry := range errorKinds {
+		if errors.Is(err, entry.kind) {
+			return entry.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// errorProblem describes a failed use case; the error's kind picks the status and type
+// Unclassified errors are attached to the request so the error middleware logs and reports them
+func errorProblem(c *gin.Context, title string, err error) *problem.Problem {
+	for _, entry := range errorKinds {
+		if errors.Is(err, entry.kind) {
+			return problem.New(c, entry.status, entry.problemType, title, err.Error())
+		}
+	}
+	_ = c.Error(err)
+	return problem.New(c, http.StatusInternalServerError, problem.TypeBlank, title, err.Error())
+}
+
+// invalidRequest describes a request rejected before reaching a use case, such as a malformed body or ID
+func invalidRequest(c *gin.Context, title, detail string) *problem.Problem {
+	return problem.New(c, http.StatusBadRequest, problem.TypeValidation, title, detail)
+}
+
+// invalidQuery describes rejected query parameters, listing each one
+func invalidQuery(c *gin.Context, params queryErrors) *problem.Problem {
+	p := invalidRequest(c, "Invalid query parameters", "")
+	p.InvalidParams = params.invalidParams()
+	return p
+}
+
+// unsupportedCurrency describes a conversion to a currency the API does not support, listing the supported ones
+// The request is well-formed, so it is unprocessable rather than bad
+func unsupportedCurrency(c *gin.Context, title, currency string, supported []entities.CurrencyCode) *problem.Problem {
+	p := problem.New(c, http.StatusUnprocessableEntity, problem.TypeUnsupportedCurrency, title, "Unsupported target currency: "+currency)
+	p.SupportedCurrencies = make([]string, len(supported))
+	for i, code := range supported {
+		p.SupportedCurrencies[i] = string(code)
+	}
+	return p
+}
+
+// respondProblem renders a problem in the negotiated format
+// XML clients get application/problem+xml, JSON:API clients get error objects and everyone else application/problem+json
+// API v2 answers application/problem+json whatever the Accept header asks for
+func respondProblem(c *gin.Context, p *problem.Problem) {
+	c.Header("Vary", "Accept")
+
+	format := negotiateFormat(c)
+	if middleware.APIVersionOf(c) == middleware.VersionV2 {
+		format = gin.MIMEJSON
+	}
+
+	switch format {
+	case gin.MIMEXML:
+		c.Header("Content-Type", problem.ContentTypeXML)
+		c.XML(p.Status, p)
+	case mimeJSONAPI:
+		c.Header("Content-Type", mimeJSONAPI)
+		c.JSON(p.Status, jsonAPIErrors(p))
+	default:
+		c.Header("Content-Type", problem.ContentType)
+		c.JSON(p.Status, p)
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/graphql"
+)
+
+// MaxGraphQLRequestSize bounds the body of a GraphQL request
+const MaxGraphQLRequestSize = 1 << 20 // 1 MB
+
+// GraphQLHandler serves GraphQL queries over transactions
+type GraphQLHandler struct {
+	executor *graphql.Executor
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler
+func NewGraphQLHandler(executor *graphql.Executor) *GraphQLHandler {
+	return &GraphQLHandler{
+		executor: executor,
+	}
+}
+
+// Query handles GET and POST /graphql
+// POST takes a JSON body ({"query", "operationName", "variables"}) or a raw application/graphql query;
+// GET takes the same fields as query parameters, with variables JSON-encoded
+// Requests that fail to parse or validate are 400; once a query runs the status is 200 even with field errors
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	request, err := h.bindRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": []*graphql.Error{{
+			Message:    err.Error(),
+			Extensions: map[string]interface{}{"code": graphql.CodeBadUserInput},
+		}}})
+		return
+	}
+	request.APIKey = c.GetHeader(APIKeyHeader)
+	request.Context = c.Request.Context()
+
+	response := h.executor.Execute(request)
+	status := http.StatusOK
+	if !response.Executed() {
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, response)
+}
+
+// Schema handles GET /graphql/schema
+func (h *GraphQLHandler) Schema(c *gin.Context) {
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", graphql.SDL)
+}
+
+// bindRequest reads the query, operation name and variables; numbers in variables keep their literal form
+func (h *GraphQLHandler) bindRequest(c *gin.Context) (*graphql.Request, error) {
+	request := &graphql.Request{}
+
+	if c.Request.Method == http.MethodGet {
+		request.Query = c.Query("query")
+		request.OperationName = c.Query("operationName")
+		if raw := c.Query("variables"); raw != "" {
+			if err := decodeJSON([]byte(raw), &request.Variables); err != nil {
+				return nil, fmt.Errorf("variables must be a JSON object: %v", err)
+			}
+		}
+	} else {
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxGraphQLRequestSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %v", err)
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		switch mediaType {
+		case "application/graphql":
+			request.Query = string(body)
+		case "application/json", "":
+			if err := decodeJSON(body, request); err != nil {
+				return nil, fmt.Errorf("body must be a JSON object with query, operationName and variables: %v", err)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported content type %q: use application/json", mediaType)
+		}
+	}
+
+	if request.Query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	return request, nil
+}
+
+func decodeJSON(data []byte, target interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
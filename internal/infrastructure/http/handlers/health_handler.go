@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+)
+
+// HealthHandler handles the service health endpoint
+type HealthHandler struct {
+	checkHealthUseCase *usecases.CheckHealthUseCase
+}
+
+// NewHealthHandler creates a new HealthHandler
+func NewHealthHandler(checkHealthUseCase *usecases.CheckHealthUseCase) *HealthHandler {
+	return &HealthHandler{
+		checkHealthUseCase: checkHealthUseCase,
+	}
+}
+
+// Health handles GET /health, returning 503 when any dependency is down
+func (h *HealthHandler) Health(c *gin.Context) {
+	response := h.checkHealthUseCase.Execute(c.Request.Context())
+
+	statusCode := http.StatusOK
+	if response.Status != dto.HealthStatusHealthy {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	c.JSON(statusCode, response)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// MetricsHandler exposes service metrics in the Prometheus text format
+type MetricsHandler struct {
+	monitorDatabaseUseCase *usecases.MonitorDatabaseUseCase
+	recorder               *activity.Recorder
+	startedAt              time.Time
+}
+
+// NewMetricsHandler creates a new MetricsHandler
+func NewMetricsHandler(monitorDatabaseUseCase *usecases.MonitorDatabaseUseCase, recorder *activity.Recorder, startedAt time.Time) *MetricsHandler {
+	return &MetricsHandler{
+		monitorDatabaseUseCase: monitorDatabaseUseCase,
+		recorder:               recorder,
+		startedAt:              startedAt,
+	}
+}
+
+// Metrics handles GET /metrics
+func (h *MetricsHandler) Metrics(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	var b strings.Builder
+	writeMetric(&b, "purchase_api_uptime_seconds", "gauge", "Seconds since the process started",
+		fmt.Sprintf("%.0f", time.Since(h.startedAt).Seconds()))
+
+	// Calls to deprecated routes show which clients still have to migrate before the sunset date
+	deprecatedCalls := h.recorder.DeprecatedCalls()
+	routes := make([]string, 0, len(deprecatedCalls))
+	for route := range deprecatedCalls {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+
+	fmt.Fprintln(&b, "# HELP purchase_api_deprecated_requests_total Requests to deprecated routes since the process started")
+	fmt.Fprintln(&b, "# TYPE purchase_api_deprecated_requests_total counter")
+	for _, route := range routes {
+		method, path, _ := strings.Cut(route, " ")
+		fmt.Fprintf(&b, "purchase_api_deprecated_requests_total{method=%q,route=%q} %d\n", method, path, deprecatedCalls[route])
+	}
+
+	// Pool statistics come from the driver rather than a query, so saturation shows even when measuring fails
+	writePoolMetrics(&b, h.monitorDatabaseUseCase.ConnectionPools())
+
+	usage, err := h.monitorDatabaseUseCase.Execute(c.Request.Context())
+	if err != nil {
+		// Still serve the process metrics so a database outage is visible rather than a scrape failure
+		contextLogger.LogError(err, "Failed to measure database for metrics")
+		writeMetric(&b, "purchase_api_database_up", "gauge", "Whether the database could be measured", "0")
+	} else {
+		writeMetric(&b, "purchase_api_database_up", "gauge", "Whether the database could be measured", "1")
+		writeMetric(&b, "purchase_api_database_size_bytes", "gauge", "Size of the database in bytes",
+			fmt.Sprintf("%d", usage.SizeBytes))
+		if usage.QuotaBytes > 0 {
+			writeMetric(&b, "purchase_api_database_quota_bytes", "gauge", "Soft database size quota in bytes",
+				fmt.Sprintf("%d", usage.QuotaBytes))
+		}
+
+		tables := make([]string, 0, len(usage.RowCounts))
+		for table := range usage.RowCounts {
+			tables = append(tables, table)
+		}
+		sort.Strings(tables)
+
+		fmt.Fprintln(&b, "# HELP purchase_api_database_rows Rows per table, including soft-deleted rows")
+		fmt.Fprintln(&b, "# TYPE purchase_api_database_rows gauge")
+		for _, table := range tables {
+			fmt.Fprintf(&b, "purchase_api_database_rows{table=%q} %d\n", table, usage.RowCounts[table])
+		}
+	}
+
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(b.String()))
+}
+
+// writeMetric appends a single unlabelled sample with its HELP and TYPE lines
+func writeMetric(b *strings.Builder, name, metricType, help, value string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n%s %s\n", name, help, name, metricType, name, value)
+}
+
+// writePoolMetrics appends one sample per connection pool for each pool statistic
+func writePoolMetrics(b *strings.Builder, pools []dto.ConnectionPoolStats) {
+	if len(pools) == 0 {
+		return
+	}
+
+	metrics := []struct {
+		name, metricType, help string
+		value                  func(dto.ConnectionPoolStats) string
+	}{
+		{"purchase_api_db_pool_max_open_connections", "gauge", "Maximum open connections; zero is unlimited",
+			func(p dto.ConnectionPoolStats) string { return fmt.Sprintf("%d", p.MaxOpen) }},
+		{"purchase_api_db_pool_open_connections", "gauge", "Open connections, in use or idle",
+			func(p dto.ConnectionPoolStats) string { return fmt.Sprintf("%d", p.Open) }},
+		{"purchase_api_db_pool_in_use_connections", "gauge", "Connections currently in use",
+			func(p dto.ConnectionPoolStats) string { return fmt.Sprintf("%d", p.InUse) }},
+		{"purchase_api_db_pool_idle_connections", "gauge", "Idle connections",
+			func(p dto.ConnectionPoolStats) string { return fmt.Sprintf("%d", p.Idle) }},
+		{"purchase_api_db_pool_wait_count_total", "counter", "Queries that waited for a free connection",
+			func(p dto.ConnectionPoolStats) string { return fmt.Sprintf("%d", p.WaitCount) }},
+		{"purchase_api_db_pool_wait_seconds_total", "counter", "Time spent waiting for a free connection",
+			func(p dto.ConnectionPoolStats) string { return fmt.Sprintf("%g", p.WaitSeconds) }},
+		{"purchase_api_db_pool_max_idle_closed_total", "counter", "Connections closed because the idle pool was full",
+			func(p dto.ConnectionPoolStats) string { return fmt.Sprintf("%d", p.MaxIdleClosed) }},
+		{"purchase_api_db_pool_max_idle_time_closed_total", "counter", "Connections closed after sitting idle too long",
+			func(p dto.ConnectionPoolStats) string { return fmt.Sprintf("%d", p.MaxIdleTimeClosed) }},
+		{"purchase_api_db_pool_max_lifetime_closed_total", "counter", "Connections closed after reaching their maximum lifetime",
+			func(p dto.ConnectionPoolStats) string { return fmt.Sprintf("%d", p.MaxLifetimeClosed) }},
+	}
+
+	for _, metric := range metrics {
+		fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.metricType)
+		for _, pool := range pools {
+			fmt.Fprintf(b, "%s{pool=%q} %s\n", metric.name, pool.Pool, metric.value(pool))
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+)
+
+// Formats offered by read endpoints alongside JSON and XML
+const (
+	mimeCSV     = "text/csv"
+	mimeJSONAPI = "application/vnd.api+json"
+)
+
+// csvTable is implemented by responses that can be rendered as CSV; the first record is the header
+type csvTable interface {
+	CSV() [][]string
+}
+
+// jsonAPIDocumenter is implemented by responses with a JSON:API representation
+type jsonAPIDocumenter interface {
+	JSONAPI() dto.JSONAPIDocument
+}
+
+// paginated is implemented by list responses that get JSON:API pagination links
+type paginated interface {
+	Pagination() (page, totalPages int)
+}
+
+// cursorPaginated is implemented by list responses that can also be continued from a cursor
+type cursorPaginated interface {
+	CursorPagination() (nextCursor string, isCursorPage bool)
+}
+
+// negotiateFormat picks JSON, XML, CSV or JSON:API from the Accept header by quality, defaulting to JSON
+// ?format=jsonapi selects JSON:API for clients that can't set headers
+// Browsers list application/xml after text/html, so text/html and wildcards count as a request for JSON
+func negotiateFormat(c *gin.Context) string {
+	if c.Query("format") == "jsonapi" {
+		return mimeJSONAPI
+	}
+
+	best, bestQuality := gin.MIMEJSON, 0.0
+	for _, part := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+
+		var format string
+		switch mediaType {
+		case gin.MIMEJSON, gin.MIMEHTML, "application/*", "*/*":
+			format = gin.MIMEJSON
+		case gin.MIMEXML, gin.MIMEXML2:
+			format = gin.MIMEXML
+		case mimeCSV:
+			format = mimeCSV
+		case mimeJSONAPI:
+			format = mimeJSONAPI
+		default:
+			continue
+		}
+
+		// Earlier entries win ties, as clients list their preference first
+		if quality > bestQuality {
+			best, bestQuality = format, quality
+		}
+	}
+	return best
+}
+
+// respond renders payload in the format negotiated from the Accept header
+// Payloads without a table or JSON:API form are sent as JSON to CSV and JSON:API clients; errors go through respondProblem
+func respond(c *gin.Context, status int, payload any) {
+	c.Header("Vary", "Accept")
+
+	switch negotiateFormat(c) {
+	case gin.MIMEXML:
+		c.XML(status, payload)
+		return
+	case mimeCSV:
+		if table, ok := payload.(csvTable); ok {
+			writeCSV(c, status, table)
+			return
+		}
+	case mimeJSONAPI:
+		if document, ok := jsonAPIDocument(c, payload); ok {
+			c.Header("Content-Type", mimeJSONAPI)
+			c.JSON(status, document)
+			return
+		}
+	}
+
+	c.JSON(status, payload)
+}
+
+// writeCSV renders a table as RFC 4180 CSV
+func writeCSV(c *gin.Context, status int, table csvTable) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(table.CSV()); err != nil {
+		respondProblem(c, problem.New(c, http.StatusInternalServerError, problem.TypeBlank, "Failed to render CSV", err.Error()))
+		return
+	}
+
+	c.Data(status, mimeCSV+"; charset=utf-8", buf.Bytes())
+}
+
+// jsonAPIDocument converts a response to a JSON:API document
+func jsonAPIDocument(c *gin.Context, payload any) (dto.JSONAPIDocument, bool) {
+	body, ok := payload.(jsonAPIDocumenter)
+	if !ok {
+		return dto.JSONAPIDocument{}, false
+	}
+
+	document := body.JSONAPI()
+	if list, ok := payload.(paginated); ok {
+		document.Links = paginationLinks(c.Request.URL, list)
+	} else if list, ok := payload.(cursorPaginated); ok {
+		nextCursor, _ := list.CursorPagination()
+		document.Links = cursorLinks(c.Request.URL, nextCursor)
+	}
+	return document, true
+}
+
+// jsonAPIErrors converts a problem to JSON:API error objects
+// Each invalid query parameter becomes its own error object, pointing at the parameter
+func jsonAPIErrors(p *problem.Problem) dto.JSONAPIDocument {
+	status := strconv.Itoa(p.Status)
+
+	if len(p.InvalidParams) > 0 {
+		errors := make([]dto.JSONAPIError, len(p.InvalidParams))
+		for i, param := range p.InvalidParams {
+			errors[i] = dto.JSONAPIError{
+				Status: status,
+				Title:  p.Title,
+				Detail: param.Reason,
+				Source: &dto.JSONAPIErrorSource{Parameter: param.Name},
+			}
+		}
+		return dto.JSONAPIDocument{Errors: errors}
+	}
+
+	return dto.JSONAPIDocument{Errors: []dto.JSONAPIError{{
+		Status: status,
+		Title:  p.Title,
+		Detail: p.Detail,
+	}}}
+}
+
+// paginationLinks builds self, first, last, prev and next links, keeping the other query parameters
+// Cursor pages know neither their number nor the last page, so they only link to themselves, the first page and the next cursor
+func paginationLinks(requestURL *url.URL, list paginated) map[string]string {
+	if cursorList, ok := list.(cursorPaginated); ok {
+		if nextCursor, isCursorPage := cursorList.CursorPagination(); isCursorPage {
+			return cursorLinks(requestURL, nextCursor)
+		}
+	}
+
+	page, totalPages := list.Pagination()
+	lastPage := max(totalPages, 1)
+
+	link := func(target int) string {
+		query := requestURL.Query()
+		query.Set("page", strconv.Itoa(target))
+		return requestURL.Path + "?" + query.Encode()
+	}
+
+	links := map[string]string{
+		"self":  link(page),
+		"first": link(1),
+		"last":  link(lastPage),
+	}
+	if page > 1 {
+		links["prev"] = link(min(page-1, lastPage))
+	}
+	if page < lastPage {
+		links["next"] = link(page + 1)
+	}
+	return links
+}
+
+// cursorLinks builds the self, first and next links of a page continued from a cursor
+func cursorLinks(requestURL *url.URL, nextCursor string) map[string]string {
+	link := func(cursor string) string {
+		query := requestURL.Query()
+		query.Del("page")
+		if cursor == "" {
+			query.Del("cursor")
+		} else {
+			query.Set("cursor", cursor)
+		}
+		return requestURL.Path + "?" + query.Encode()
+	}
+
+	links := map[string]string{
+		"self":  requestURL.Path + "?" + requestURL.Query().Encode(),
+		"first": link(""),
+	}
+	if nextCursor != "" {
+		links["next"] = link(nextCursor)
+	}
+	return links
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+)
+
+// queryErrors collects per-parameter validation messages for a request
+type queryErrors map[string]string
+
+// add records a validation message for a query parameter
+func (e queryErrors) add(param, message string) {
+	e[param] = message
+}
+
+// invalidParams lists the collected messages in a stable, parameter-sorted order
+func (e queryErrors) invalidParams() []problem.InvalidParam {
+	params := make([]string, 0, len(e))
+	for param := range e {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	invalid := make([]problem.InvalidParam, len(params))
+	for i, param := range params {
+		invalid[i] = problem.InvalidParam{Name: param, Reason: e[param]}
+	}
+	return invalid
+}
+
+// parseIntQuery reads an integer query parameter, returning defaultValue when absent
+// Non-numeric or out-of-range values are recorded in errs instead of being silently replaced
+func parseIntQuery(c *gin.Context, errs queryErrors, name string, defaultValue, min, max int) int {
+	raw, present := c.GetQuery(name)
+	if !present {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		errs.add(name, fmt.Sprintf("%s must be an integer, got %q", name, raw))
+		return defaultValue
+	}
+
+	if value < min || value > max {
+		errs.add(name, fmt.Sprintf("%s must be between %d and %d, got %d", name, min, max, value))
+		return defaultValue
+	}
+
+	return value
+}
+
+// parseDateQuery reads an optional YYYY-MM-DD query parameter as midnight UTC
+func parseDateQuery(c *gin.Context, errs queryErrors, name string) *time.Time {
+	raw, present := c.GetQuery(name)
+	if !present {
+		return nil
+	}
+
+	value, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		errs.add(name, fmt.Sprintf("%s must be a date in YYYY-MM-DD format, got %q", name, raw))
+		return nil
+	}
+
+	return &value
+}
+
+// parseCursorQuery reads an optional listing cursor query parameter
+func parseCursorQuery(c *gin.Context, errs queryErrors, name string) *entities.TransactionCursor {
+	raw, present := c.GetQuery(name)
+	if !present {
+		return nil
+	}
+
+	cursor, err := entities.ParseTransactionCursor(raw)
+	if err != nil {
+		errs.add(name, fmt.Sprintf("%s must be a next_cursor returned by a previous page, got %q", name, raw))
+		return nil
+	}
+
+	return &cursor
+}
+
+// parseAmountQuery reads an optional positive dollar amount query parameter
+func parseAmountQuery(c *gin.Context, errs queryErrors, name string) *float64 {
+	raw, present := c.GetQuery(name)
+	if !present {
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) || value <= 0 {
+		errs.add(name, fmt.Sprintf("%s must be a positive amount, got %q", name, raw))
+		return nil
+	}
+
+	return &value
+}
+
+// parseBoolQuery reads an optional boolean query parameter, defaulting to false
+func parseBoolQuery(c *gin.Context, errs queryErrors, name string) bool {
+	raw, present := c.GetQuery(name)
+	if !present {
+		return false
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		errs.add(name, fmt.Sprintf("%s must be a boolean, got %q", name, raw))
+		return false
+	}
+
+	return value
+}
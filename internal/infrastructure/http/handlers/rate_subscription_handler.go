@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// RateSubscriptionHandler handles HTTP requests for exchange rate subscriptions
+// Subscriptions belong to the caller's X-API-Key; callers without a key share one anonymous set
+type RateSubscriptionHandler struct {
+	manageRateSubscriptionsUseCase *usecases.ManageRateSubscriptionsUseCase
+}
+
+// NewRateSubscriptionHandler creates a new RateSubscriptionHandler
+func NewRateSubscriptionHandler(manageRateSubscriptionsUseCase *usecases.ManageRateSubscriptionsUseCase) *RateSubscriptionHandler {
+	return &RateSubscriptionHandler{
+		manageRateSubscriptionsUseCase: manageRateSubscriptionsUseCase,
+	}
+}
+
+// Subscribe handles POST /rates/subscriptions
+func (h *RateSubscriptionHandler) Subscribe(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	var request dto.SubscribeRatesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	response, err := h.manageRateSubscriptionsUseCase.Subscribe(c.GetHeader(APIKeyHeader), request.Currencies)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to subscribe to rates", err))
+		return
+	}
+
+	contextLogger.LogOperation("subscribe_rates", "", true,
+		"currencies", request.Currencies,
+		"subscriptions", len(response.Data),
+	)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListSubscriptions handles GET /rates/subscriptions
+func (h *RateSubscriptionHandler) ListSubscriptions(c *gin.Context) {
+	response, err := h.manageRateSubscriptionsUseCase.List(c.GetHeader(APIKeyHeader))
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve rate subscriptions", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Unsubscribe handles DELETE /rates/subscriptions/:currency
+func (h *RateSubscriptionHandler) Unsubscribe(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	currency := c.Param("currency")
+	if err := h.manageRateSubscriptionsUseCase.Unsubscribe(c.GetHeader(APIKeyHeader), currency); err != nil {
+		respondProblem(c, errorProblem(c, "Failed to unsubscribe from rates", err))
+		return
+	}
+
+	contextLogger.LogOperation("unsubscribe_rates", currency, true)
+
+	c.Status(http.StatusNoContent)
+}
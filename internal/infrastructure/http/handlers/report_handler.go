@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// ReportHandler handles HTTP requests for spending reports
+type ReportHandler struct {
+	summarizeSpendingUseCase *usecases.SummarizeSpendingUseCase
+}
+
+// NewReportHandler creates a new ReportHandler
+func NewReportHandler(summarizeSpendingUseCase *usecases.SummarizeSpendingUseCase) *ReportHandler {
+	return &ReportHandler{
+		summarizeSpendingUseCase: summarizeSpendingUseCase,
+	}
+}
+
+// GetSummary handles GET /reports/summary
+func (h *ReportHandler) GetSummary(c *gin.Context) {
+	errs := queryErrors{}
+	from := parseDateQuery(c, errs, "from")
+	to := parseDateQuery(c, errs, "to")
+	groupBy := entities.SummaryGrouping(strings.ToLower(strings.TrimSpace(c.Query("group_by"))))
+	if groupBy != "" && !groupBy.IsValid() {
+		errs.add("group_by", fmt.Sprintf("group_by must be day, month or year, got %q", c.Query("group_by")))
+	}
+	var currency entities.CurrencyCode
+	if raw, present := c.GetQuery("currency"); present {
+		code, err := entities.NewCurrencyCode(raw)
+		if err != nil {
+			errs.add("currency", fmt.Sprintf("currency must be a 3-letter ISO 4217 code, got %q", raw))
+		}
+		currency = code
+	}
+
+	if len(errs) > 0 {
+		respondProblem(c, invalidQuery(c, errs))
+		return
+	}
+
+	response, err := h.summarizeSpendingUseCase.Execute(c.Request.Context(), &dto.SummaryReportRequest{
+		From:     from,
+		To:       to,
+		GroupBy:  groupBy,
+		Currency: currency,
+	})
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to summarize transactions", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -1,6 +1,11 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,15 +15,32 @@ import (
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
 )
 
+// MaxImportFileSize caps the CSV upload of POST /transactions/import
+const MaxImportFileSize = 10 << 20
+
+// Idempotency headers for transaction creation
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"     // Client-chosen key identifying retries of one request
+	IdempotentReplayedHeader = "Idempotent-Replayed" // Set on responses returned for a retry
+)
+
 // TransactionHandler handles HTTP requests for transaction operations
 type TransactionHandler struct {
-	createTransactionUseCase  *usecases.CreateTransactionUseCase
-	getTransactionUseCase     *usecases.GetTransactionUseCase
-	listTransactionsUseCase   *usecases.ListTransactionsUseCase
-	convertTransactionUseCase *usecases.ConvertTransactionUseCase
+	createTransactionUseCase   *usecases.CreateTransactionUseCase
+	getTransactionUseCase      *usecases.GetTransactionUseCase
+	listTransactionsUseCase    *usecases.ListTransactionsUseCase
+	convertTransactionUseCase  *usecases.ConvertTransactionUseCase
+	suggestDescriptionsUseCase *usecases.SuggestDescriptionsUseCase
+	restoreTransactionUseCase  *usecases.RestoreTransactionUseCase
+	deleteTransactionUseCase   *usecases.DeleteTransactionUseCase
+	importTransactionsUseCase  *usecases.ImportTransactionsUseCase
+	updateCategoryUseCase      *usecases.UpdateTransactionCategoryUseCase
+	auditLogUseCase            *usecases.AuditLogUseCase
+	listConversionsUseCase     *usecases.ListConversionsUseCase
 }
 
 // NewTransactionHandler creates a new TransactionHandler
@@ -27,12 +49,26 @@ func NewTransactionHandler(
 	getTransactionUseCase *usecases.GetTransactionUseCase,
 	listTransactionsUseCase *usecases.ListTransactionsUseCase,
 	convertTransactionUseCase *usecases.ConvertTransactionUseCase,
+	suggestDescriptionsUseCase *usecases.SuggestDescriptionsUseCase,
+	restoreTransactionUseCase *usecases.RestoreTransactionUseCase,
+	deleteTransactionUseCase *usecases.DeleteTransactionUseCase,
+	importTransactionsUseCase *usecases.ImportTransactionsUseCase,
+	updateCategoryUseCase *usecases.UpdateTransactionCategoryUseCase,
+	auditLogUseCase *usecases.AuditLogUseCase,
+	listConversionsUseCase *usecases.ListConversionsUseCase,
 ) *TransactionHandler {
 	return &TransactionHandler{
-		createTransactionUseCase:  createTransactionUseCase,
-		getTransactionUseCase:     getTransactionUseCase,
-		listTransactionsUseCase:   listTransactionsUseCase,
-		convertTransactionUseCase: convertTransactionUseCase,
+		createTransactionUseCase:   createTransactionUseCase,
+		getTransactionUseCase:      getTransactionUseCase,
+		listTransactionsUseCase:    listTransactionsUseCase,
+		convertTransactionUseCase:  convertTransactionUseCase,
+		suggestDescriptionsUseCase: suggestDescriptionsUseCase,
+		restoreTransactionUseCase:  restoreTransactionUseCase,
+		deleteTransactionUseCase:   deleteTransactionUseCase,
+		importTransactionsUseCase:  importTransactionsUseCase,
+		updateCategoryUseCase:      updateCategoryUseCase,
+		auditLogUseCase:            auditLogUseCase,
+		listConversionsUseCase:     listConversionsUseCase,
 	}
 }
 
@@ -51,10 +87,7 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 	// Bind JSON request to DTO
 	if err := c.ShouldBindJSON(&request); err != nil {
 		contextLogger.LogError(err, "Invalid request format in CreateTransaction")
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": formatValidationError(err),
-		})
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
 		return
 	}
 
@@ -63,115 +96,398 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 		"amount", request.Amount,
 	)
 
-	// Execute use case
-	response, err := h.createTransactionUseCase.Execute(&request)
+	// Execute use case; with an Idempotency-Key a retry returns the transaction created by the first attempt
+	var response *dto.CreateTransactionResponse
+	var replayed bool
+	var err error
+	if key := c.GetHeader(IdempotencyKeyHeader); key != "" {
+		response, replayed, err = h.createTransactionUseCase.ExecuteIdempotent(callerScope(c), key, &request)
+	} else {
+		response, err = h.createTransactionUseCase.Execute(&request)
+	}
 	if err != nil {
-		// Check error type for appropriate status code
-		statusCode := http.StatusInternalServerError
-		errorMessage := err.Error()
-
-		if isValidationError(err) {
-			statusCode = http.StatusBadRequest
-			errorMessage = formatValidationError(err)
+		failure := errorProblem(c, "Failed to create transaction", err)
+		if failure.Status == http.StatusBadRequest {
+			failure.Detail = formatValidationError(err)
 		}
 
 		contextLogger.LogError(err, "Failed to create transaction",
-			"status_code", statusCode,
+			"status_code", failure.Status,
 			"request", request,
 		)
 
-		c.JSON(statusCode, gin.H{
-			"error":   "Failed to create transaction",
-			"details": errorMessage,
-		})
+		respondProblem(c, failure)
 		return
 	}
 
+	if replayed {
+		c.Header(IdempotentReplayedHeader, "true")
+	} else {
+		recordAudit(c, h.auditLogUseCase, entities.AuditEntityTransaction, response.ID, entities.AuditActionCreate, response)
+	}
+
 	contextLogger.LogOperation("create_transaction", response.ID.String(), true,
 		"amount", response.Amount,
 		"description", response.Description,
+		"idempotent_replay", replayed,
 	)
 
 	// Return successful response
 	c.JSON(http.StatusCreated, response)
 }
 
+// ImportTransactions handles POST /transactions/import with a CSV file in the multipart field "file"
+func (h *TransactionHandler) ImportTransactions(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxImportFileSize)
+	upload, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondProblem(c, problem.New(c, http.StatusRequestEntityTooLarge, problem.TypeValidation, "Invalid upload",
+				fmt.Sprintf("the file must not exceed %d MB", MaxImportFileSize>>20)))
+			return
+		}
+		respondProblem(c, invalidRequest(c, "Invalid upload", `a CSV file is required in the multipart form field "file"`))
+		return
+	}
+
+	file, err := upload.Open()
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to import transactions", err))
+		return
+	}
+	defer file.Close()
+
+	response, err := h.importTransactionsUseCase.Execute(file)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to import transactions", err))
+		return
+	}
+
+	contextLogger.LogOperation("import_transactions", "", true,
+		"file", upload.Filename,
+		"rows", response.Rows,
+		"imported", response.Imported,
+		"rejected", response.Rejected,
+	)
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetTransaction handles GET /transactions/:id
 func (h *TransactionHandler) GetTransaction(c *gin.Context) {
 	// Parse UUID from path parameter
 	idParam := c.Param("id")
 	transactionID, err := uuid.Parse(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid transaction ID format",
-			"details": "Transaction ID must be a valid UUID",
-		})
+		respondProblem(c, invalidRequest(c, "Invalid transaction ID format", "Transaction ID must be a valid UUID"))
 		return
 	}
 
-	// Execute use case
-	response, err := h.getTransactionUseCase.Execute(transactionID)
+	errs := queryErrors{}
+	includeArchived := parseBoolQuery(c, errs, "include_archived")
+	includeDeleted := parseBoolQuery(c, errs, "include_deleted")
+	currency := h.parseCurrencyQuery(c, errs, "currency")
+	if currency != "" && (includeArchived || includeDeleted) {
+		errs.add("currency", "currency cannot be combined with include_archived or include_deleted")
+	}
+	if len(errs) > 0 {
+		respondProblem(c, invalidQuery(c, errs))
+		return
+	}
+
+	if currency != "" {
+		h.getConvertedTransaction(c, transactionID, currency)
+		return
+	}
+
+	// Execute use case, also searching cold storage and the trash when asked to
+	var response *dto.GetTransactionResponse
+	if includeArchived || includeDeleted {
+		response, err = h.getTransactionUseCase.ExecuteIncluding(transactionID, includeArchived, includeDeleted)
+	} else {
+		response, err = h.getTransactionUseCase.Execute(transactionID)
+	}
 	if err != nil {
-		// Check if transaction not found
-		statusCode := http.StatusInternalServerError
-		if isNotFoundError(err) {
-			statusCode = http.StatusNotFound
-		}
+		respondProblem(c, errorProblem(c, "Failed to retrieve transaction", err))
+		return
+	}
 
-		c.JSON(statusCode, gin.H{
-			"error":   "Failed to retrieve transaction",
-			"details": err.Error(),
-		})
+	// Conditional request: polling clients and caches revalidate with If-None-Match or If-Modified-Since
+	etag := transactionETag(response, negotiateFormat(c))
+	lastModified := transactionLastModified(response)
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	c.Header("Cache-Control", "no-cache")
+	if notModified(c, etag, lastModified) {
+		c.Header("Vary", "Accept")
+		c.Status(http.StatusNotModified)
 		return
 	}
 
 	// Return successful response
-	c.JSON(http.StatusOK, response)
+	respond(c, http.StatusOK, response)
+}
+
+// getConvertedTransaction answers GET /transactions/:id?currency= with the conversion inline
+// The conversion is only previewed: reads neither record it in the history nor announce it
+func (h *TransactionHandler) getConvertedTransaction(c *gin.Context, transactionID uuid.UUID, currency entities.CurrencyCode) {
+	converted, err := h.convertTransactionUseCase.Preview(c.Request.Context(), &dto.ConvertTransactionRequest{
+		TransactionID:  transactionID,
+		TargetCurrency: currency,
+		APIKey:         c.GetHeader(APIKeyHeader),
+	})
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve transaction", err))
+		return
+	}
+
+	respond(c, http.StatusOK, dto.NewGetConvertedTransactionResponse(converted))
 }
 
 // ListTransactions handles GET /transactions
 func (h *TransactionHandler) ListTransactions(c *gin.Context) {
-	// Parse query parameters with defaults
-	page := 1
-	size := 20
+	// Parse query parameters, rejecting invalid values instead of replacing them with defaults
+	errs := queryErrors{}
+	request := parseListFilters(c, errs)
+	request.Page = parseIntQuery(c, errs, "page", 1, 1, math.MaxInt32)
+	request.Trash = parseBoolQuery(c, errs, "trash")
+	request.IncludeArchived = parseBoolQuery(c, errs, "include_archived")
+	request.Currency = h.parseCurrencyQuery(c, errs, "currency")
+	request.APIKey = c.GetHeader(APIKeyHeader)
+
+	if len(errs) > 0 {
+		respondProblem(c, invalidQuery(c, errs))
+		return
+	}
+	if h.listNotModified(c, request.Currency) {
+		return
+	}
 
-	if pageParam := c.Query("page"); pageParam != "" {
-		if p, err := strconv.Atoi(pageParam); err == nil && p > 0 {
-			page = p
-		}
+	// Execute use case
+	response, err := h.listTransactionsUseCase.Execute(c.Request.Context(), request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve transactions", err))
+		return
+	}
+
+	// Pagination travels in headers too, since the CSV representation only carries the rows
+	if !response.IsCursorPage() {
+		c.Header("X-Total-Count", strconv.FormatInt(response.Total, 10))
+		c.Header("X-Total-Pages", strconv.Itoa(response.TotalPages))
+	}
+	if response.NextCursor != "" {
+		c.Header("X-Next-Cursor", response.NextCursor)
 	}
 
-	if sizeParam := c.Query("size"); sizeParam != "" {
-		if s, err := strconv.Atoi(sizeParam); err == nil && s > 0 && s <= 100 {
-			size = s
+	// Return successful response
+	respond(c, http.StatusOK, response)
+}
+
+// ListTransactionsByCursor handles GET /api/v2/transactions, which only pages by cursor
+// Pages are neither numbered nor counted, and the trash and archive are not listed
+func (h *TransactionHandler) ListTransactionsByCursor(c *gin.Context) {
+	errs := queryErrors{}
+	for _, name := range []string{"page", "trash", "include_archived"} {
+		if _, present := c.GetQuery(name); present {
+			errs.add(name, fmt.Sprintf("%s is not supported by this API version; follow next_cursor instead", name))
 		}
 	}
+	request := parseListFilters(c, errs)
+	request.ByCursor = true
+	request.Currency = h.parseCurrencyQuery(c, errs, "currency")
+	request.APIKey = c.GetHeader(APIKeyHeader)
 
-	// Create request DTO
-	request := &dto.ListTransactionsRequest{
-		Page: page,
-		Size: size,
+	if len(errs) > 0 {
+		respondProblem(c, invalidQuery(c, errs))
+		return
+	}
+	if h.listNotModified(c, request.Currency) {
+		return
 	}
 
-	// Execute use case
-	response, err := h.listTransactionsUseCase.Execute(request)
+	response, err := h.listTransactionsUseCase.Execute(c.Request.Context(), request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve transactions", err))
+		return
+	}
+
+	if response.NextCursor != "" {
+		c.Header("X-Next-Cursor", response.NextCursor)
+	}
+
+	respond(c, http.StatusOK, dto.NewTransactionPageResponse(response))
+}
+
+// parseListFilters reads the page size, cursor and filters shared by every version of the transaction list
+func parseListFilters(c *gin.Context, errs queryErrors) *dto.ListTransactionsRequest {
+	return &dto.ListTransactionsRequest{
+		Size:   parseIntQuery(c, errs, "size", 20, 1, 100),
+		Cursor: parseCursorQuery(c, errs, "cursor"),
+
+		DateFrom:            parseDateQuery(c, errs, "date_from"),
+		DateTo:              parseDateQuery(c, errs, "date_to"),
+		MinAmount:           parseAmountQuery(c, errs, "min_amount"),
+		MaxAmount:           parseAmountQuery(c, errs, "max_amount"),
+		DescriptionContains: strings.TrimSpace(c.Query("description_contains")),
+		Category:            strings.TrimSpace(c.Query("category")),
+		Tag:                 strings.ToLower(strings.TrimSpace(c.Query("tag"))),
+	}
+}
+
+// listNotModified answers 304 when no transaction changed since If-Modified-Since, and sets Last-Modified otherwise
+// Converted lists are skipped because rates can change without touching transactions
+func (h *TransactionHandler) listNotModified(c *gin.Context, currency entities.CurrencyCode) bool {
+	if currency != "" {
+		return false
+	}
+
+	lastModified, err := h.listTransactionsUseCase.LastModified()
+	if err != nil || lastModified.IsZero() {
+		return false
+	}
+	if notModifiedSince(c, lastModified) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	return false
+}
+
+// RestoreTransaction handles POST /transactions/:id/restore
+func (h *TransactionHandler) RestoreTransaction(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid transaction ID format", "Transaction ID must be a valid UUID"))
+		return
+	}
+
+	response, err := h.restoreTransactionUseCase.Execute(transactionID)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to restore transaction", err))
+		return
+	}
+
+	recordAudit(c, h.auditLogUseCase, entities.AuditEntityTransaction, transactionID, entities.AuditActionRestore, nil)
+	contextLogger.LogOperation("restore_transaction", transactionID.String(), true)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateTransactionCategory handles PATCH /transactions/:id
+func (h *TransactionHandler) UpdateTransactionCategory(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	transactionID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if isValidationError(err) {
-			statusCode = http.StatusBadRequest
+		respondProblem(c, invalidRequest(c, "Invalid transaction ID format", "Transaction ID must be a valid UUID"))
+		return
+	}
+
+	var request dto.UpdateTransactionCategoryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	// If-Match pins the update to the version the client read, like expected_version does
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		current, err := h.getTransactionUseCase.Execute(transactionID)
+		if err != nil {
+			respondProblem(c, errorProblem(c, "Failed to update transaction", err))
+			return
+		}
+		if !matchesCurrentETag(ifMatch, current) {
+			respondProblem(c, problem.New(c, http.StatusPreconditionFailed, problem.TypePreconditionFailed,
+				"Failed to update transaction", "If-Match does not match the current ETag of the transaction"))
+			return
+		}
+		if request.ExpectedVersion == nil {
+			request.ExpectedVersion = &current.Version
 		}
+	}
 
-		c.JSON(statusCode, gin.H{
-			"error":   "Failed to retrieve transactions",
-			"details": err.Error(),
-		})
+	response, err := h.updateCategoryUseCase.Execute(transactionID, &request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to update transaction", err))
 		return
 	}
 
-	// Return successful response
+	recordAudit(c, h.auditLogUseCase, entities.AuditEntityTransaction, transactionID, entities.AuditActionUpdate, categoryChanges(&request, response))
+	contextLogger.LogOperation("update_transaction_category", transactionID.String(), true,
+		"category", response.Category,
+		"tags", response.Tags,
+	)
+
+	c.Header("ETag", transactionETag(response, gin.MIMEJSON))
 	c.JSON(http.StatusOK, response)
 }
 
+// DeleteTransaction handles DELETE /transactions/:id
+func (h *TransactionHandler) DeleteTransaction(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid transaction ID format", "Transaction ID must be a valid UUID"))
+		return
+	}
+
+	if err := h.deleteTransactionUseCase.Execute(transactionID); err != nil {
+		respondProblem(c, errorProblem(c, "Failed to delete transaction", err))
+		return
+	}
+
+	recordAudit(c, h.auditLogUseCase, entities.AuditEntityTransaction, transactionID, entities.AuditActionDelete, nil)
+	contextLogger.LogOperation("delete_transaction", transactionID.String(), true)
+
+	c.Status(http.StatusNoContent)
+}
+
+// SuggestDescriptions handles GET /transactions/descriptions
+func (h *TransactionHandler) SuggestDescriptions(c *gin.Context) {
+	errs := queryErrors{}
+	limit := parseIntQuery(c, errs, "limit", 10, 1, 50)
+
+	if len(errs) > 0 {
+		respondProblem(c, invalidQuery(c, errs))
+		return
+	}
+
+	request := &dto.SuggestDescriptionsRequest{
+		Prefix: c.Query("prefix"),
+		Limit:  limit,
+	}
+
+	response, err := h.suggestDescriptionsUseCase.Execute(request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve description suggestions", err))
+		return
+	}
+
+	respond(c, http.StatusOK, response)
+}
+
 // ConvertTransaction handles POST /transactions/:id/convert
 func (h *TransactionHandler) ConvertTransaction(c *gin.Context) {
 	// Get logger from context
@@ -188,68 +504,63 @@ func (h *TransactionHandler) ConvertTransaction(c *gin.Context) {
 		contextLogger.LogError(err, "Invalid transaction ID format in ConvertTransaction",
 			"transaction_id_param", idParam,
 		)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid transaction ID format",
-			"details": "Transaction ID must be a valid UUID",
-		})
+		respondProblem(c, invalidRequest(c, "Invalid transaction ID format", "Transaction ID must be a valid UUID"))
 		return
 	}
 
 	// Parse request body for target currency
-	var requestBody struct {
-		TargetCurrency string `json:"target_currency" binding:"required"`
-	}
+	var requestBody dto.ConvertTransactionHTTPRequest
 
 	if err := c.ShouldBindJSON(&requestBody); err != nil {
 		contextLogger.LogError(err, "Invalid request format in ConvertTransaction",
 			"transaction_id", transactionID.String(),
 		)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	// Normalize and check the currency against the supported set before running the use case
+	request, err := requestBody.ToConvertRequest(transactionID)
+	if errors.Is(err, dto.ErrInvalidQuoteID) {
+		respondProblem(c, invalidRequest(c, "Invalid request format", err.Error()))
+		return
+	}
+	if err != nil || !h.convertTransactionUseCase.SupportsCurrency(request.TargetCurrency) {
+		contextLogger.Warn("Unsupported target currency in ConvertTransaction",
+			"transaction_id", transactionID.String(),
+			"target_currency", requestBody.TargetCurrency,
+		)
+		respondProblem(c, unsupportedCurrency(c, "Failed to convert transaction", requestBody.TargetCurrency, h.convertTransactionUseCase.SupportedCurrencies()))
 		return
 	}
 
+	request.APIKey = c.GetHeader(APIKeyHeader)
+
 	contextLogger.Info("Converting transaction currency",
 		"transaction_id", transactionID.String(),
-		"target_currency", requestBody.TargetCurrency,
+		"target_currency", request.TargetCurrency,
+		"mode", request.Mode,
 	)
 
-	// Create use case request
-	request := &dto.ConvertTransactionRequest{
-		TransactionID:  transactionID,
-		TargetCurrency: entities.CurrencyCode(requestBody.TargetCurrency),
-	}
-
 	// Execute use case
-	response, err := h.convertTransactionUseCase.Execute(request)
+	response, err := h.convertTransactionUseCase.Execute(c.Request.Context(), request)
 	if err != nil {
 		// Determine appropriate status code
-		statusCode := http.StatusInternalServerError
-		if isValidationError(err) {
-			statusCode = http.StatusBadRequest
-		} else if isNotFoundError(err) {
-			statusCode = http.StatusNotFound
-		} else if isExchangeRateNotFoundError(err) {
-			statusCode = http.StatusUnprocessableEntity
-		}
+		statusCode := errorStatus(err)
 
 		contextLogger.LogError(err, "Failed to convert transaction",
 			"transaction_id", transactionID.String(),
-			"target_currency", requestBody.TargetCurrency,
+			"target_currency", request.TargetCurrency,
 			"status_code", statusCode,
 		)
 
-		c.JSON(statusCode, gin.H{
-			"error":   "Failed to convert transaction",
-			"details": err.Error(),
-		})
+		respondProblem(c, errorProblem(c, "Failed to convert transaction", err))
 		return
 	}
 
+	recordAudit(c, h.auditLogUseCase, entities.AuditEntityTransaction, transactionID, entities.AuditActionConvert, conversionChanges(response))
 	contextLogger.LogOperation("convert_transaction", transactionID.String(), true,
-		"target_currency", requestBody.TargetCurrency,
+		"target_currency", request.TargetCurrency,
 		"original_amount", response.Transaction.Amount,
 		"converted_amount", response.ConvertedAmount,
 		"exchange_rate", response.ExchangeRate,
@@ -259,25 +570,133 @@ func (h *TransactionHandler) ConvertTransaction(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// Helper functions for error classification
+// ConvertTransactionsBatch handles POST /transactions/convert-batch
+func (h *TransactionHandler) ConvertTransactionsBatch(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	var requestBody dto.ConvertTransactionsBatchHTTPRequest
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	request, err := requestBody.ToBatchRequest()
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", err.Error()))
+		return
+	}
+	if !h.convertTransactionUseCase.SupportsCurrency(request.TargetCurrency) {
+		respondProblem(c, unsupportedCurrency(c, "Failed to convert transactions", requestBody.TargetCurrency, h.convertTransactionUseCase.SupportedCurrencies()))
+		return
+	}
+
+	request.APIKey = c.GetHeader(APIKeyHeader)
+
+	response, err := h.convertTransactionUseCase.ExecuteBatch(c.Request.Context(), request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to convert transactions", err))
+		return
+	}
+
+	for _, item := range response.Data {
+		if item.ConvertTransactionResponse != nil {
+			recordAudit(c, h.auditLogUseCase, entities.AuditEntityTransaction, item.TransactionID, entities.AuditActionConvert, conversionChanges(item.ConvertTransactionResponse))
+		}
+	}
+
+	contextLogger.LogOperation("convert_transactions_batch", string(request.TargetCurrency), true,
+		"converted", response.Converted,
+		"failed", response.Failed,
+	)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListConversions handles GET /transactions/:id/conversions
+func (h *TransactionHandler) ListConversions(c *gin.Context) {
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid transaction ID format", "Transaction ID must be a valid UUID"))
+		return
+	}
+
+	errs := queryErrors{}
+	page := parseIntQuery(c, errs, "page", 1, 1, math.MaxInt32)
+	size := parseIntQuery(c, errs, "size", 20, 1, 100)
+	if len(errs) > 0 {
+		respondProblem(c, invalidQuery(c, errs))
+		return
+	}
+
+	response, err := h.listConversionsUseCase.Execute(transactionID, page, size)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve conversions", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseCurrencyQuery reads an optional conversion currency, recording unknown or unsupported codes in errs
+func (h *TransactionHandler) parseCurrencyQuery(c *gin.Context, errs queryErrors, name string) entities.CurrencyCode {
+	raw, present := c.GetQuery(name)
+	if !present {
+		return ""
+	}
+
+	code, err := entities.NewCurrencyCode(raw)
+	if err != nil || !h.convertTransactionUseCase.SupportsCurrency(code) {
+		errs.add(name, fmt.Sprintf("unsupported currency %q, supported: %v", raw, h.convertTransactionUseCase.SupportedCurrencies()))
+		return ""
+	}
 
-func isValidationError(err error) bool {
-	return contains(err.Error(), "validation failed") ||
-		contains(err.Error(), "invalid") ||
-		contains(err.Error(), "required")
+	return code
 }
 
-func isNotFoundError(err error) bool {
-	return contains(err.Error(), "not found")
+// callerScope identifies the caller, scoping Idempotency-Keys so two callers can pick the same key and naming the actor of audit logs
+// Authenticated callers are scoped by token or subject; an unverified X-API-Key is hashed, and anonymous callers share one scope
+func callerScope(c *gin.Context) string {
+	if tokenID := c.GetString("api_token_id"); tokenID != "" {
+		return "token:" + tokenID
+	}
+	if subject := c.GetString("auth_subject"); subject != "" {
+		return "subject:" + subject
+	}
+	if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		return "key:" + hex.EncodeToString(sum[:])
+	}
+	return ""
 }
 
-func isExchangeRateNotFoundError(err error) bool {
-	return contains(err.Error(), "no suitable exchange rate found") ||
-		contains(err.Error(), "within 6 months")
+// categoryChanges lists the fields a category update wrote, with their stored values, for the audit trail
+func categoryChanges(request *dto.UpdateTransactionCategoryRequest, response *dto.GetTransactionResponse) gin.H {
+	changes := gin.H{}
+	if request.Category != nil {
+		changes["category"] = response.Category
+	}
+	if request.Tags != nil {
+		changes["tags"] = response.Tags
+	}
+	return changes
 }
 
-func contains(s, substr string) bool {
-	return strings.Contains(s, substr)
+// conversionChanges summarizes a conversion for the audit trail
+func conversionChanges(response *dto.ConvertTransactionResponse) gin.H {
+	changes := gin.H{
+		"target_currency":  response.TargetCurrency,
+		"exchange_rate":    response.ExchangeRate,
+		"converted_amount": response.ConvertedAmount,
+		"effective_date":   response.EffectiveDate,
+	}
+	if response.QuoteID != nil {
+		changes["quote_id"] = response.QuoteID
+	}
+	return changes
 }
 
 // formatValidationError converts technical validation errors to user-friendly messages
@@ -288,12 +707,26 @@ func formatValidationError(err error) string {
 	if strings.Contains(errMsg, "Description") && strings.Contains(errMsg, "max") {
 		return "Description must not exceed 50 characters"
 	}
+	if strings.Contains(errMsg, "Category") && strings.Contains(errMsg, "max") {
+		return "Category must not exceed 50 characters"
+	}
+	if strings.Contains(errMsg, "Tags") && strings.Contains(errMsg, "max") {
+		return "A transaction can have at most 10 tags of up to 30 characters each"
+	}
+	if strings.Contains(errMsg, "does not exist") || strings.Contains(errMsg, "tag \"") {
+		// Unknown categories and malformed tags already read well once the prefix is dropped
+		_, reason, _ := strings.Cut(errMsg, "failed: ")
+		return reason
+	}
 	if strings.Contains(errMsg, "Amount") && strings.Contains(errMsg, "min") {
 		return "Amount must be greater than 0"
 	}
 	if strings.Contains(errMsg, "Date") && strings.Contains(errMsg, "datetime") {
 		return "Date must be in valid ISO 8601 format (e.g., 2024-01-15T10:30:00Z)"
 	}
+	if strings.Contains(errMsg, "'currency' tag") {
+		return "Currency must be a supported 3-letter ISO 4217 code (e.g., EUR)"
+	}
 	if strings.Contains(errMsg, "Description") && strings.Contains(errMsg, "required") {
 		return "Description is required"
 	}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// WebhookHandler handles HTTP requests for webhook registration and delivery status
+type WebhookHandler struct {
+	manageWebhooksUseCase *usecases.ManageWebhooksUseCase
+}
+
+// NewWebhookHandler creates a new WebhookHandler
+func NewWebhookHandler(manageWebhooksUseCase *usecases.ManageWebhooksUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		manageWebhooksUseCase: manageWebhooksUseCase,
+	}
+}
+
+// CreateWebhook handles POST /webhooks
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	var request dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid request format", formatValidationError(err)))
+		return
+	}
+
+	response, err := h.manageWebhooksUseCase.Create(&request)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to create webhook", err))
+		return
+	}
+
+	contextLogger.LogOperation("create_webhook", response.ID.String(), true,
+		"url", response.URL,
+		"events", response.Events,
+	)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListWebhooks handles GET /webhooks
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	response, err := h.manageWebhooksUseCase.List()
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve webhooks", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetWebhook handles GET /webhooks/:id
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	webhookID, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.manageWebhooksUseCase.Get(webhookID)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve webhook", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteWebhook handles DELETE /webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	log, exists := c.Get("logger")
+	if !exists {
+		log = &logger.Logger{}
+	}
+	contextLogger := log.(*logger.Logger)
+
+	webhookID, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.manageWebhooksUseCase.Delete(webhookID); err != nil {
+		respondProblem(c, errorProblem(c, "Failed to delete webhook", err))
+		return
+	}
+
+	contextLogger.LogOperation("delete_webhook", webhookID.String(), true)
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /webhooks/:id/deliveries
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	webhookID, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.manageWebhooksUseCase.ListDeliveries(webhookID)
+	if err != nil {
+		respondProblem(c, errorProblem(c, "Failed to retrieve webhook deliveries", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseWebhookID reads the :id path parameter, answering 400 when it is not a UUID
+func parseWebhookID(c *gin.Context) (uuid.UUID, bool) {
+	webhookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondProblem(c, invalidRequest(c, "Invalid webhook ID format", "Webhook ID must be a valid UUID"))
+		return uuid.Nil, false
+	}
+	return webhookID, true
+}
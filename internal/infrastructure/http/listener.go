@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Names of the sockets handed over by systemd socket activation (FileDescriptorName= in the .socket unit)
+const (
+	ListenerPublic = "public"
+	ListenerOps    = "ops"
+	ListenerGRPC   = "grpc"
+)
+
+// systemdFirstFD is the first file descriptor passed by systemd (SD_LISTEN_FDS_START)
+const systemdFirstFD = 3
+
+var (
+	inheritOnce sync.Once
+	inherited   map[string]net.Listener
+	inheritErr  error
+)
+
+// Listen returns the listener for a server named name (ListenerPublic, ListenerOps or ListenerGRPC)
+// A socket passed by systemd socket activation is used when present, so restarts never close the port;
+// otherwise addr is bound, with SO_REUSEPORT when reusePort is set so a new process can bind alongside the old one
+func Listen(addr, name string, reusePort bool) (net.Listener, error) {
+	inheritOnce.Do(func() { inherited, inheritErr = systemdListeners() })
+	if inheritErr != nil {
+		return nil, inheritErr
+	}
+	if listener, ok := inherited[name]; ok {
+		return listener, nil
+	}
+
+	config := net.ListenConfig{}
+	if reusePort {
+		config.Control = reusePortControl
+	}
+	return config.Listen(context.Background(), "tcp", addr)
+}
+
+// systemdListeners converts the sockets passed through LISTEN_FDS into listeners keyed by name
+// Unnamed sockets are assigned by position: the first is public, the second ops; the gRPC socket must be named
+func systemdListeners() (map[string]net.Listener, error) {
+	listeners := make(map[string]net.Listener)
+
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return listeners, nil // Not socket activated, or the variables were meant for another process
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count < 1 {
+		return listeners, nil
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	// Don't pass the sockets on to child processes
+	_ = os.Unsetenv("LISTEN_PID")
+	_ = os.Unsetenv("LISTEN_FDS")
+	_ = os.Unsetenv("LISTEN_FDNAMES")
+
+	positional := []string{ListenerPublic, ListenerOps}
+	for i := 0; i < count; i++ {
+		file := os.NewFile(uintptr(systemdFirstFD+i), fmt.Sprintf("systemd-socket-%d", i))
+		listener, err := net.FileListener(file)
+		_ = file.Close() // FileListener duplicates the descriptor
+		if err != nil {
+			return nil, fmt.Errorf("failed to use socket %d from systemd: %w", i, err)
+		}
+
+		name := ""
+		if i < len(names) {
+			name = names[i]
+		}
+		if name != ListenerPublic && name != ListenerOps && name != ListenerGRPC && i < len(positional) {
+			name = positional[i]
+		}
+		listeners[name] = listener
+	}
+
+	return listeners, nil
+}
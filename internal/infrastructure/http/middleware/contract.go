@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/openapi"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+)
+
+// Contract validation modes
+const (
+	ContractOff     = "off"     // No validation
+	ContractReport  = "report"  // Log requests and responses that deviate from the contract
+	ContractEnforce = "enforce" // Reject deviating requests with 400 and replace deviating responses with 500
+)
+
+// ContractValidator checks requests and responses of documented routes against the OpenAPI contract
+type ContractValidator struct {
+	spec    *openapi.Document
+	enforce bool
+}
+
+// NewContractValidator creates a validator for mode; it returns nil for ContractOff
+func NewContractValidator(spec *openapi.Document, mode string) (*ContractValidator, error) {
+	switch mode {
+	case ContractOff, "":
+		return nil, nil
+	case ContractReport, ContractEnforce:
+		return &ContractValidator{spec: spec, enforce: mode == ContractEnforce}, nil
+	default:
+		return nil, fmt.Errorf("invalid contract validation mode %q: must be off, report or enforce", mode)
+	}
+}
+
+// Handler returns the validation middleware; a nil validator passes every request through
+// Routes missing from the contract are not checked
+func (v *ContractValidator) Handler() gin.HandlerFunc {
+	if v == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		operation := v.spec.Operation(c.Request.Method, c.FullPath())
+		if operation == nil {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				problem.Abort(c, problem.New(c, http.StatusBadRequest, problem.TypeValidation, "Invalid request format", err.Error()))
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+
+		violations := operation.ValidateRequest(openapi.Request{
+			PathParams:  pathParams,
+			Query:       c.Request.URL.Query(),
+			ContentType: c.ContentType(),
+			Body:        body,
+		})
+		if len(violations) > 0 {
+			v.report(c, "Request does not match API contract", violations)
+			if v.enforce {
+				failure := problem.New(c, http.StatusBadRequest, problem.TypeContractViolation, "Request does not match API contract", "")
+				failure.Violations = violations
+				problem.Abort(c, failure)
+				return
+			}
+		}
+
+		// Capture the response; when enforcing it is held back until it has been checked
+		writer := &contractWriter{ResponseWriter: c.Writer, hold: v.enforce}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		status := writer.Status()
+		if status == http.StatusNotModified || c.Request.Method == http.MethodHead {
+			writer.flush()
+			return
+		}
+
+		violations = operation.ValidateResponse(status, writer.Header().Get("Content-Type"), writer.body.Bytes())
+		if len(violations) == 0 {
+			writer.flush()
+			return
+		}
+
+		v.report(c, "Response does not match API contract", violations)
+		if !v.enforce {
+			return
+		}
+
+		failure := problem.New(c, http.StatusInternalServerError, problem.TypeContractViolation, "Response does not match API contract", "")
+		failure.Violations = violations
+		c.Header("Content-Type", problem.ContentType)
+		c.JSON(failure.Status, failure)
+	}
+}
+
+// report logs contract violations for a request
+func (v *ContractValidator) report(c *gin.Context, message string, violations []string) {
+	requestID, _ := c.Get("request_id")
+	slog.Warn(message,
+		"request_id", requestID,
+		"method", c.Request.Method,
+		"route", c.FullPath(),
+		"status", c.Writer.Status(),
+		"violations", violations,
+	)
+}
+
+// contractWriter copies the response body, and when hold is set keeps it from the client until flushed
+type contractWriter struct {
+	gin.ResponseWriter
+	hold bool
+	body bytes.Buffer
+}
+
+func (w *contractWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	if w.hold {
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *contractWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow defers sending the status line while the response is held
+func (w *contractWriter) WriteHeaderNow() {
+	if !w.hold {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// flush sends a held response to the client
+func (w *contractWriter) flush() {
+	if !w.hold {
+		return
+	}
+	w.ResponseWriter.WriteHeaderNow()
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
+)
+
+// DeprecationPolicy announces that a set of routes is deprecated and when it will be removed
+type DeprecationPolicy struct {
+	DeprecatedAt time.Time // When the routes were deprecated
+	SunsetAt     time.Time // When they stop being served; zero when no date is set
+	Link         string    // Migration guide or changelog; empty omits the Link header
+}
+
+// ParseDeprecationPolicy builds a policy from dates in YYYY-MM-DD or RFC 3339 form
+// It returns nil when deprecatedAt is empty, leaving the routes undeprecated
+func ParseDeprecationPolicy(deprecatedAt, sunsetAt, link string) (*DeprecationPolicy, error) {
+	if strings.TrimSpace(deprecatedAt) == "" {
+		if strings.TrimSpace(sunsetAt) != "" {
+			return nil, fmt.Errorf("a sunset date requires a deprecation date")
+		}
+		return nil, nil
+	}
+
+	policy := &DeprecationPolicy{Link: strings.TrimSpace(link)}
+
+	var err error
+	if policy.DeprecatedAt, err = parsePolicyDate(deprecatedAt); err != nil {
+		return nil, fmt.Errorf("invalid deprecation date: %w", err)
+	}
+	if strings.TrimSpace(sunsetAt) != "" {
+		if policy.SunsetAt, err = parsePolicyDate(sunsetAt); err != nil {
+			return nil, fmt.Errorf("invalid sunset date: %w", err)
+		}
+		if policy.SunsetAt.Before(policy.DeprecatedAt) {
+			return nil, fmt.Errorf("sunset date %s is before deprecation date %s",
+				policy.SunsetAt.Format(time.DateOnly), policy.DeprecatedAt.Format(time.DateOnly))
+		}
+	}
+
+	return policy, nil
+}
+
+// parsePolicyDate accepts a date (midnight UTC) or a full RFC 3339 timestamp
+func parsePolicyDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// Deprecate adds Deprecation (RFC 9745), Sunset (RFC 8594) and Link headers to every response
+// and counts the call per route on the recorder; a nil policy passes every request through
+func (p *DeprecationPolicy) Deprecate(recorder *activity.Recorder) gin.HandlerFunc {
+	if p == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	deprecation := "@" + strconv.FormatInt(p.DeprecatedAt.Unix(), 10)
+	var sunset string
+	if !p.SunsetAt.IsZero() {
+		sunset = p.SunsetAt.UTC().Format(http.TimeFormat)
+	}
+	var link string
+	if p.Link != "" {
+		link = fmt.Sprintf(`<%s>; rel="deprecation"; type="text/html"`, p.Link)
+		if sunset != "" {
+			link += fmt.Sprintf(`, <%s>; rel="sunset"; type="text/html"`, p.Link)
+		}
+	}
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		if sunset != "" {
+			c.Header("Sunset", sunset)
+		}
+		if link != "" {
+			c.Header("Link", link)
+		}
+
+		recorder.RecordDeprecatedCall(c.Request.Method, c.FullPath())
+
+		c.Next()
+	}
+}
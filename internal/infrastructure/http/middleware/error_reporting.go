@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/errortracking"
+)
+
+// ErrorReporting reports panics and the errors behind 5xx responses to the error tracker
+// Panics are re-raised so the recovery middleware still answers 500; a nil reporter reports nothing
+func ErrorReporting(reporter *errortracking.Reporter) gin.HandlerFunc {
+	if reporter == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				// http.ErrAbortHandler deliberately aborts the response and is not a failure
+				if recovered != http.ErrAbortHandler {
+					reporter.CapturePanic(c.Request, recovered, requestTags(c, http.StatusInternalServerError))
+				}
+				panic(recovered)
+			}
+		}()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < http.StatusInternalServerError {
+			return
+		}
+		for _, err := range c.Errors {
+			reporter.CaptureRequestError(c.Request, err.Err, requestTags(c, status))
+		}
+	}
+}
+
+// requestTags identifies the route and the status a request was answered with
+func requestTags(c *gin.Context, status int) map[string]string {
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	return map[string]string{
+		"route":       c.Request.Method + " " + route,
+		"status_code": strconv.Itoa(status),
+	}
+}
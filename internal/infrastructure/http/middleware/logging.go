@@ -11,7 +11,11 @@ import (
 func LoggingMiddleware(log *logger.Logger) gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
 		// Use structured logger instead of default Gin logger
-		log.LogRequest(
+		requestLogger := log
+		if requestID, ok := param.Keys["request_id"].(string); ok {
+			requestLogger = log.WithField("request_id", requestID)
+		}
+		requestLogger.LogRequest(
 			param.Method,
 			param.Path,
 			param.Request.UserAgent(),
@@ -53,9 +57,12 @@ func RequestIDMiddleware(log *logger.Logger) gin.HandlerFunc {
 		c.Set("request_id", requestID)
 		c.Header("X-Request-ID", requestID)
 
-		// Add request ID to logger context
-		contextLogger := log.WithField("request_id", requestID)
-		c.Set("logger", contextLogger)
+		// Store the request ID in the request context so code below the handler can log it
+		ctx := logger.ContextWithRequestID(c.Request.Context(), requestID)
+		c.Request = c.Request.WithContext(ctx)
+
+		// Add request metadata to logger context
+		c.Set("logger", log.WithContext(ctx))
 
 		c.Next()
 	}
@@ -3,11 +3,14 @@ package middleware
 import (
 	"fmt"
 	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
 )
 
 // CORS middleware for handling Cross-Origin Resource Sharing
@@ -15,8 +18,8 @@ func CORS() gin.HandlerFunc {
 	return cors.New(cors.Config{
 		AllowOrigins:     []string{"*"}, // Configure appropriately for production
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-Request-ID"},
-		ExposeHeaders:    []string{"Content-Length", "X-Request-ID"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-Request-ID", "X-API-Key", "Idempotency-Key", "If-None-Match", "If-Modified-Since", "If-Match"},
+		ExposeHeaders:    []string{"Content-Length", "X-Request-ID", "X-RateLimit-Limit", "X-RateLimit-Remaining", "Retry-After", "Deprecation", "Sunset", "Link", "X-Total-Count", "X-Total-Pages", "X-Next-Cursor", "ETag", "Idempotent-Replayed", "API-Version"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	})
@@ -60,10 +63,8 @@ func ErrorHandler() gin.HandlerFunc {
 
 			// Return error response if not already sent
 			if !c.Writer.Written() {
-				c.JSON(500, gin.H{
-					"error":      "Internal server error",
-					"request_id": requestID,
-				})
+				c.Header("Content-Type", problem.ContentType)
+				c.JSON(http.StatusInternalServerError, problem.New(c, http.StatusInternalServerError, problem.TypeBlank, "Internal server error", ""))
 			}
 		}
 	}
@@ -92,3 +93,14 @@ func Logger() gin.HandlerFunc {
 		)
 	})
 }
+
+// CountConversions records each successful conversion response on the activity recorder
+func CountConversions(recorder *activity.Recorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if status := c.Writer.Status(); status >= 200 && status < 300 {
+			recorder.RecordConversion()
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// payloadCaptureLimit bounds how much of a body is buffered for logging; larger bodies are logged by size only
+const payloadCaptureLimit = 1 << 20
+
+// redactedValue replaces the value of sensitive fields and headers
+const redactedValue = "[REDACTED]"
+
+// DefaultRedactedFields are always redacted from logged headers and JSON bodies
+var DefaultRedactedFields = []string{
+	"authorization", "cookie", "set-cookie", "x-api-key", "api_key",
+	"password", "secret", "token", "access_token", "refresh_token",
+}
+
+// PayloadLogger logs request and response bodies at debug level to help debug client integrations
+type PayloadLogger struct {
+	log      *logger.Logger
+	maxBytes int
+	redact   map[string]bool
+}
+
+// NewPayloadLogger creates a payload logger that truncates bodies to maxBytes and redacts the given
+// JSON fields and headers on top of DefaultRedactedFields; names match case-insensitively
+func NewPayloadLogger(log *logger.Logger, maxBytes int, redact []string) *PayloadLogger {
+	if maxBytes <= 0 {
+		maxBytes = 4096
+	}
+
+	fields := make(map[string]bool, len(DefaultRedactedFields)+len(redact))
+	for _, name := range append(append([]string{}, DefaultRedactedFields...), redact...) {
+		fields[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	return &PayloadLogger{log: log, maxBytes: maxBytes, redact: fields}
+}
+
+// Handler returns the logging middleware; a nil logger, or one not at debug level, passes every request through
+func (p *PayloadLogger) Handler() gin.HandlerFunc {
+	if p == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		if !p.log.Enabled(c.Request.Context(), slog.LevelDebug) {
+			c.Next()
+			return
+		}
+
+		// Read at most the capture limit and hand the handler the full body back
+		var requestBody []byte
+		requestComplete := true
+		if c.Request.Body != nil {
+			var err error
+			requestBody, err = io.ReadAll(io.LimitReader(c.Request.Body, payloadCaptureLimit+1))
+			if err != nil {
+				c.Next()
+				return
+			}
+			requestComplete = len(requestBody) <= payloadCaptureLimit
+			c.Request.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(requestBody), c.Request.Body), c.Request.Body}
+		}
+
+		writer := &payloadWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		p.log.WithContext(c.Request.Context()).Debug("HTTP payload",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", writer.Status(),
+			"request_headers", p.headers(c.Request.Header),
+			"request_body", p.body(requestBody, requestComplete, c.ContentType()),
+			"response_body", p.body(writer.body.Bytes(), !writer.overflow, writer.Header().Get("Content-Type")),
+		)
+	}
+}
+
+// headers returns the request headers with sensitive values redacted
+func (p *PayloadLogger) headers(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for name, values := range header {
+		if p.redact[strings.ToLower(name)] {
+			result[name] = redactedValue
+			continue
+		}
+		result[name] = strings.Join(values, ", ")
+	}
+	return result
+}
+
+// body renders a captured body for the log: JSON is redacted before truncation and other text is truncated,
+// while binary, incomplete or malformed JSON bodies are described by size so nothing unredacted leaks
+func (p *PayloadLogger) body(data []byte, complete bool, contentType string) string {
+	if len(data) == 0 {
+		return ""
+	}
+	if !complete {
+		return fmt.Sprintf("[body over %d bytes omitted]", payloadCaptureLimit)
+	}
+
+	switch {
+	case strings.Contains(contentType, "json"):
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return fmt.Sprintf("[%d bytes of malformed JSON omitted]", len(data))
+		}
+		redacted, err := json.Marshal(p.redactValue(value))
+		if err != nil {
+			return fmt.Sprintf("[%d bytes of JSON omitted]", len(data))
+		}
+		return p.truncate(redacted)
+	case strings.HasPrefix(contentType, "text/"):
+		return p.truncate(data)
+	default:
+		return fmt.Sprintf("[%d bytes of %q omitted]", len(data), contentType)
+	}
+}
+
+// redactValue replaces sensitive fields at any depth of a decoded JSON value
+func (p *PayloadLogger) redactValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			if p.redact[strings.ToLower(key)] {
+				typed[key] = redactedValue
+				continue
+			}
+			typed[key] = p.redactValue(nested)
+		}
+	case []interface{}:
+		for i, nested := range typed {
+			typed[i] = p.redactValue(nested)
+		}
+	}
+	return value
+}
+
+// truncate shortens data to the configured size, marking the cut
+func (p *PayloadLogger) truncate(data []byte) string {
+	if len(data) <= p.maxBytes {
+		return string(data)
+	}
+	return string(data[:p.maxBytes]) + "...[truncated]"
+}
+
+// payloadWriter copies the response body up to the capture limit while sending it to the client
+type payloadWriter struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *payloadWriter) Write(data []byte) (int, error) {
+	if !w.overflow {
+		if w.body.Len()+len(data) > payloadCaptureLimit {
+			w.overflow = true
+			w.body.Reset()
+		} else {
+			w.body.Write(data)
+		}
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *payloadWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
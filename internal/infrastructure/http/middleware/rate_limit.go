@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+)
+
+// DefaultRateLimitProfile applies to routes whose own profile is not configured
+const DefaultRateLimitProfile = "default"
+
+// maxIdleBuckets triggers a sweep of refilled buckets so the map does not grow without bound
+const maxIdleBuckets = 10000
+
+// RateLimit allows Requests per Period for a single client
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseRateLimit parses limits such as "10/min", "5/s" or "1000/hour"
+func ParseRateLimit(s string) (RateLimit, error) {
+	count, unit, found := strings.Cut(strings.TrimSpace(s), "/")
+	if !found {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: expected <requests>/<period>", s)
+	}
+
+	requests, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || requests < 1 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", s)
+	}
+
+	var period time.Duration
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "s", "sec", "second":
+		period = time.Second
+	case "m", "min", "minute":
+		period = time.Minute
+	case "h", "hour":
+		period = time.Hour
+	default:
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: period must be sec, min or hour", s)
+	}
+
+	return RateLimit{Requests: requests, Period: period}, nil
+}
+
+// bucket is a token bucket for one client under one profile
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// RateLimiter enforces named rate limit profiles per client (API key, or IP when no key is sent)
+type RateLimiter struct {
+	profiles map[string]RateLimit
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewRateLimiter parses profiles of the form name -> "10/min"
+func NewRateLimiter(profiles map[string]string) (*RateLimiter, error) {
+	parsed := make(map[string]RateLimit, len(profiles))
+	for name, spec := range profiles {
+		limit, err := ParseRateLimit(spec)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", name, err)
+		}
+		parsed[name] = limit
+	}
+
+	return &RateLimiter{
+		profiles: parsed,
+		buckets:  make(map[string]*bucket),
+	}, nil
+}
+
+// Limit returns middleware enforcing the named profile, falling back to the default profile
+// A nil limiter, or a profile with no configured limit, lets every request through
+func (l *RateLimiter) Limit(profile string) gin.HandlerFunc {
+	if l == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	limit, ok := l.profiles[profile]
+	if !ok {
+		profile = DefaultRateLimitProfile
+		limit, ok = l.profiles[profile]
+	}
+	if !ok {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		allowed, remaining, retryAfter := l.take(profile+"|"+clientKey(c), limit)
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			problem.Abort(c, problem.New(c, http.StatusTooManyRequests, problem.TypeRateLimited, "Rate limit exceeded",
+				fmt.Sprintf("%s profile allows %d requests per %s", profile, limit.Requests, limit.Period)))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// take consumes a token from the client's bucket, reporting how long until the next one when empty
+func (l *RateLimiter) take(key string, limit RateLimit) (bool, int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	capacity := float64(limit.Requests)
+	refillPerSecond := capacity / limit.Period.Seconds()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.sweep(now)
+		}
+		b = &bucket{tokens: capacity, lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.lastSeen).Seconds()*refillPerSecond)
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / refillPerSecond * float64(time.Second))
+		return false, 0, wait
+	}
+
+	b.tokens--
+	return true, int(b.tokens), 0
+}
+
+// sweep drops buckets idle long enough to have refilled under any profile
+func (l *RateLimiter) sweep(now time.Time) {
+	var longest time.Duration
+	for _, limit := range l.profiles {
+		if limit.Period > longest {
+			longest = limit.Period
+		}
+	}
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > longest {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// clientKey identifies the caller by API key, or by IP when no key is sent
+func clientKey(c *gin.Context) string {
+	if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+		return "key:" + apiKey
+	}
+	return "ip:" + c.ClientIP()
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+)
+
+// RequestTimeouts bounds how long requests may run, with budgets per rate limit profile
+type RequestTimeouts struct {
+	defaultBudget time.Duration
+	profiles      map[string]time.Duration
+}
+
+// NewRequestTimeouts gives routes of the named profiles their own budget and every other route defaultBudget
+// A zero budget leaves the routes it applies to unbounded
+func NewRequestTimeouts(defaultBudget time.Duration, profiles map[string]time.Duration) *RequestTimeouts {
+	return &RequestTimeouts{
+		defaultBudget: defaultBudget,
+		profiles:      profiles,
+	}
+}
+
+// Budget returns the time requests under profile may run; zero means unbounded
+func (t *RequestTimeouts) Budget(profile string) time.Duration {
+	if t == nil {
+		return 0
+	}
+	if budget, ok := t.profiles[profile]; ok {
+		return budget
+	}
+	return t.defaultBudget
+}
+
+// Longest returns the largest budget of any profile, so the server can leave time to write the response
+func (t *RequestTimeouts) Longest() time.Duration {
+	if t == nil {
+		return 0
+	}
+	longest := t.defaultBudget
+	for profile := range t.profiles {
+		longest = max(longest, t.Budget(profile))
+	}
+	return longest
+}
+
+// Limit returns middleware giving the request context a deadline from the profile's budget
+// Downstream work that honours the context is cancelled once it passes; a request still unanswered then
+// gets 504 Gateway Timeout, while one that ignored its context and answered late keeps its response
+// A nil RequestTimeouts, or a zero budget, lets every request run unbounded
+func (t *RequestTimeouts) Limit(profile string) gin.HandlerFunc {
+	budget := t.Budget(profile)
+	if budget <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), budget)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !answered(c) {
+			problem.Abort(c, problem.New(c, http.StatusGatewayTimeout, problem.TypeTimeout, "Request timed out",
+				fmt.Sprintf("%s requests must complete within %s", profile, budget)))
+		}
+	}
+}
+
+// answered reports whether a handler wrote a response or chose a status, such as 204, that Gin writes once the chain ends
+func answered(c *gin.Context) bool {
+	return c.Writer.Written() || c.Writer.Status() != http.StatusOK
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+)
+
+// TokenAuthenticator resolves an X-API-Key secret to its stored token
+type TokenAuthenticator interface {
+	Authenticate(secret string) (*entities.APIToken, error)
+}
+
+// BearerAuthenticator verifies an Authorization bearer token and returns its subject and role
+type BearerAuthenticator interface {
+	AuthenticateBearer(token string) (string, entities.APIRole, error)
+}
+
+// TokenAuth requires callers to present an active API token, or a bearer token when enabled, whose role covers the route
+type TokenAuth struct {
+	tokens TokenAuthenticator
+	bearer BearerAuthenticator
+}
+
+// NewTokenAuth creates a TokenAuth checking secrets against tokens
+func NewTokenAuth(tokens TokenAuthenticator) *TokenAuth {
+	return &TokenAuth{
+		tokens: tokens,
+	}
+}
+
+// WithBearer also accepts bearer tokens in the Authorization header, such as JWTs from an identity provider
+func (a *TokenAuth) WithBearer(bearer BearerAuthenticator) *TokenAuth {
+	a.bearer = bearer
+	return a
+}
+
+// Require returns middleware rejecting requests whose token lacks the role
+// A nil TokenAuth lets every request through
+func (a *TokenAuth) Require(role entities.APIRole) gin.HandlerFunc {
+	if a == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		a.authorize(c, role)
+	}
+}
+
+// RequireByMethod returns middleware requiring the read role for GET and HEAD and the write role otherwise
+// A nil TokenAuth lets every request through
+func (a *TokenAuth) RequireByMethod() gin.HandlerFunc {
+	if a == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		role := entities.RoleWrite
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			role = entities.RoleRead
+		}
+		a.authorize(c, role)
+	}
+}
+
+// authorize authenticates the bearer token or X-API-Key header and checks the caller's role
+func (a *TokenAuth) authorize(c *gin.Context, role entities.APIRole) {
+	if bearerToken, ok := a.bearerToken(c); ok {
+		subject, granted, err := a.bearer.AuthenticateBearer(bearerToken)
+		if err != nil {
+			a.reject(c, err)
+			return
+		}
+		if !granted.Includes(role) {
+			problem.Abort(c, problem.New(c, http.StatusForbidden, problem.TypeForbidden, "Forbidden",
+				fmt.Sprintf("bearer token does not grant %s access", role)))
+			return
+		}
+
+		c.Set("auth_subject", subject)
+		c.Next()
+		return
+	}
+
+	token, err := a.tokens.Authenticate(c.GetHeader("X-API-Key"))
+	if err != nil {
+		a.reject(c, err)
+		return
+	}
+
+	if !token.Role.Includes(role) {
+		problem.Abort(c, problem.New(c, http.StatusForbidden, problem.TypeForbidden, "Forbidden",
+			fmt.Sprintf("api token role %s does not grant %s access", token.Role, role)))
+		return
+	}
+
+	c.Set("api_token_id", token.ID.String())
+	c.Next()
+}
+
+// bearerToken returns the token of an Authorization: Bearer header when bearer tokens are enabled
+func (a *TokenAuth) bearerToken(c *gin.Context) (string, bool) {
+	if a.bearer == nil {
+		return "", false
+	}
+	scheme, token, found := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// reject answers a failed authentication: 401 for a rejected credential, 500 when the credential store failed
+func (a *TokenAuth) reject(c *gin.Context, err error) {
+	if !errors.Is(err, errs.ErrUnauthorized) {
+		problem.Abort(c, problem.New(c, http.StatusInternalServerError, problem.TypeBlank, "Failed to authenticate request", err.Error()))
+		return
+	}
+
+	challenge := `ApiKey header="X-API-Key"`
+	if a.bearer != nil {
+		challenge += ", Bearer"
+	}
+	c.Header("WWW-Authenticate", challenge)
+	problem.Abort(c, problem.New(c, http.StatusUnauthorized, problem.TypeUnauthorized, "Unauthorized", err.Error()))
+}
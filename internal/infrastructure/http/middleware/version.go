@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// API versions served under /api/<version>
+const (
+	VersionV1 = "v1"
+	VersionV2 = "v2"
+)
+
+// apiVersionKey is the context key holding the version of the API serving the request
+const apiVersionKey = "api_version"
+
+// APIVersion records the version of the API serving the request in the context and the API-Version header
+func APIVersion(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(apiVersionKey, version)
+		c.Header("API-Version", version)
+		c.Next()
+	}
+}
+
+// APIVersionOf returns the version of the API serving the request, or "" outside a versioned route group
+func APIVersionOf(c *gin.Context) string {
+	return c.GetString(apiVersionKey)
+}
+
+// SuccessorVersion links each route that a newer version also serves to it with a successor-version Link (RFC 5829)
+// served lists the route patterns of the newer version, such as /api/v2/transactions/:id; other routes pass through
+func SuccessorVersion(from, to string, served []string) gin.HandlerFunc {
+	successors := make(map[string]bool, len(served))
+	for _, route := range served {
+		successors[route] = true
+	}
+
+	return func(c *gin.Context) {
+		route := strings.Replace(c.FullPath(), "/api/"+from+"/", "/api/"+to+"/", 1)
+		if successors[route] {
+			// Added rather than set, so a Link announcing deprecation is kept
+			c.Writer.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`,
+				strings.Replace(c.Request.URL.Path, "/api/"+from+"/", "/api/"+to+"/", 1)))
+		}
+		c.Next()
+	}
+}
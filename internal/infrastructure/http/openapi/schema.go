@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema is the subset of the OpenAPI 3.0 schema object the contract uses
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Nullable             bool               `json:"nullable"`
+	Enum                 []any              `json:"enum"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties Additional         `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	Pattern              string             `json:"pattern"`
+
+	pattern *regexp.Regexp // Compiled when the document is parsed
+}
+
+// Additional is additionalProperties, which is either a boolean or a schema for the extra values
+type Additional struct {
+	Forbidden bool
+	Schema    *Schema
+}
+
+// UnmarshalJSON accepts both forms of additionalProperties
+func (a *Additional) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.Forbidden = !allowed
+		return nil
+	}
+	return json.Unmarshal(data, &a.Schema)
+}
+
+// Validate checks a decoded JSON value against the schema and returns one message per violation
+func (s *Schema) Validate(value any) []string {
+	var violations []string
+	s.validate("", value, &violations)
+	return violations
+}
+
+func (s *Schema) validate(path string, value any, violations *[]string) {
+	if s == nil {
+		return
+	}
+
+	report := func(format string, args ...any) {
+		field := path
+		if field == "" {
+			field = "body"
+		}
+		*violations = append(*violations, field+": "+fmt.Sprintf(format, args...))
+	}
+
+	if value == nil {
+		if !s.Nullable && s.Type != "" {
+			report("must not be null")
+		}
+		return
+	}
+
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		report("must be one of %v", s.Enum)
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			report("must be an object")
+			return
+		}
+		s.validateObject(path, object, violations, report)
+
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			report("must be an array")
+			return
+		}
+		for i, item := range items {
+			s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, violations)
+		}
+
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			report("must be a string")
+			return
+		}
+		s.validateString(text, report)
+
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok {
+			report("must be a number")
+			return
+		}
+		if s.Type == "integer" && number != math.Trunc(number) {
+			report("must be an integer")
+			return
+		}
+		s.validateNumber(number, report)
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			report("must be a boolean")
+		}
+	}
+}
+
+func (s *Schema) validateObject(path string, object map[string]any, violations *[]string, report func(string, ...any)) {
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			*violations = append(*violations, join(path, name)+": is required")
+		}
+	}
+
+	// Sort so violations are reported in a stable order
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if property, ok := s.Properties[name]; ok {
+			property.validate(join(path, name), object[name], violations)
+			continue
+		}
+		if s.AdditionalProperties.Forbidden {
+			*violations = append(*violations, join(path, name)+": is not a documented property")
+			continue
+		}
+		s.AdditionalProperties.Schema.validate(join(path, name), object[name], violations)
+	}
+}
+
+func (s *Schema) validateString(text string, report func(string, ...any)) {
+	length := len([]rune(text))
+	if s.MinLength != nil && length < *s.MinLength {
+		report("must be at least %d characters", *s.MinLength)
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		report("must be at most %d characters", *s.MaxLength)
+	}
+
+	switch s.Format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, text); err != nil {
+			report("must be an RFC 3339 date-time")
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, text); err != nil {
+			report("must be a date (YYYY-MM-DD)")
+		}
+	case "uuid":
+		if _, err := uuid.Parse(text); err != nil {
+			report("must be a UUID")
+		}
+	}
+
+	if s.pattern != nil && !s.pattern.MatchString(text) {
+		report("must match %s", s.Pattern)
+	}
+}
+
+func (s *Schema) validateNumber(number float64, report func(string, ...any)) {
+	if s.Minimum != nil {
+		if s.ExclusiveMinimum && number <= *s.Minimum {
+			report("must be greater than %v", *s.Minimum)
+		} else if number < *s.Minimum {
+			report("must be at least %v", *s.Minimum)
+		}
+	}
+	if s.Maximum != nil {
+		if s.ExclusiveMaximum && number >= *s.Maximum {
+			report("must be less than %v", *s.Maximum)
+		} else if number > *s.Maximum {
+			report("must be at most %v", *s.Maximum)
+		}
+	}
+}
+
+// containsValue reports whether value equals one of the enum members
+func containsValue(enum []any, value any) bool {
+	encoded, _ := json.Marshal(value)
+	for _, member := range enum {
+		candidate, _ := json.Marshal(member)
+		if bytes.Equal(encoded, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// join appends a property name to a JSON path
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,220 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Spec is the published OpenAPI contract of the public API
+//
+//go:embed openapi.json
+var Spec []byte
+
+// Document is the subset of an OpenAPI 3.0 document needed to validate requests and responses
+type Document struct {
+	operations map[string]*Operation // "METHOD /path/:param" -> operation
+}
+
+// Operation describes one method on one path
+type Operation struct {
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter describes a path or query parameter
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody describes the accepted request payloads by media type
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response payload by media type
+type Response struct {
+	Ref     string               `json:"$ref"`
+	Content map[string]MediaType `json:"content"`
+}
+
+// MediaType holds the schema of one content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type rawDocument struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas    map[string]*Schema    `json:"schemas"`
+		Parameters map[string]*Parameter `json:"parameters"`
+		Responses  map[string]*Response  `json:"responses"`
+	} `json:"components"`
+}
+
+var methods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true, "trace": true,
+}
+
+// Load parses the embedded contract
+func Load() (*Document, error) {
+	return Parse(Spec)
+}
+
+// Parse parses an OpenAPI 3.0 JSON document and resolves its local $refs
+func Parse(data []byte) (*Document, error) {
+	var raw rawDocument
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	resolver := &resolver{
+		schemas:    raw.Components.Schemas,
+		parameters: raw.Components.Parameters,
+		responses:  raw.Components.Responses,
+		seen:       make(map[*Schema]bool),
+	}
+
+	doc := &Document{operations: make(map[string]*Operation)}
+	for path, item := range raw.Paths {
+		for method, body := range item {
+			if !methods[method] {
+				continue
+			}
+
+			var operation Operation
+			if err := json.Unmarshal(body, &operation); err != nil {
+				return nil, fmt.Errorf("failed to parse %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			if err := resolver.operation(&operation); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+
+			doc.operations[strings.ToUpper(method)+" "+routePath(path)] = &operation
+		}
+	}
+
+	return doc, nil
+}
+
+// Operation returns the documented operation for a Gin route pattern such as /transactions/:id, or nil
+func (d *Document) Operation(method, route string) *Operation {
+	return d.operations[strings.ToUpper(method)+" "+route]
+}
+
+// routePath converts OpenAPI path templates (/transactions/{id}) to Gin route patterns (/transactions/:id)
+func routePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + segment[1:len(segment)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// resolver replaces #/components/... references with the components they point to
+type resolver struct {
+	schemas    map[string]*Schema
+	parameters map[string]*Parameter
+	responses  map[string]*Response
+	seen       map[*Schema]bool
+}
+
+func (r *resolver) operation(op *Operation) error {
+	for i, param := range op.Parameters {
+		if param.Ref != "" {
+			resolved, ok := r.parameters[strings.TrimPrefix(param.Ref, "#/components/parameters/")]
+			if !ok {
+				return fmt.Errorf("unresolved reference %s", param.Ref)
+			}
+			op.Parameters[i] = resolved
+			param = resolved
+		}
+		if err := r.schema(&param.Schema); err != nil {
+			return err
+		}
+	}
+
+	if op.RequestBody != nil {
+		if err := r.content(op.RequestBody.Content); err != nil {
+			return err
+		}
+	}
+
+	for status, response := range op.Responses {
+		if response.Ref != "" {
+			resolved, ok := r.responses[strings.TrimPrefix(response.Ref, "#/components/responses/")]
+			if !ok {
+				return fmt.Errorf("unresolved reference %s", response.Ref)
+			}
+			op.Responses[status] = resolved
+			response = resolved
+		}
+		if err := r.content(response.Content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *resolver) content(content map[string]MediaType) error {
+	for mediaType, media := range content {
+		if err := r.schema(&media.Schema); err != nil {
+			return err
+		}
+		content[mediaType] = media
+	}
+	return nil
+}
+
+// schema resolves a schema in place and compiles its pattern, descending into nested schemas once each
+func (r *resolver) schema(target **Schema) error {
+	s := *target
+	if s == nil {
+		return nil
+	}
+
+	if s.Ref != "" {
+		resolved, ok := r.schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+		if !ok {
+			return fmt.Errorf("unresolved reference %s", s.Ref)
+		}
+		*target = resolved
+		s = resolved
+	}
+
+	if r.seen[s] {
+		return nil
+	}
+	r.seen[s] = true
+
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = pattern
+	}
+
+	for name := range s.Properties {
+		property := s.Properties[name]
+		if err := r.schema(&property); err != nil {
+			return err
+		}
+		s.Properties[name] = property
+	}
+	if err := r.schema(&s.Items); err != nil {
+		return err
+	}
+	return r.schema(&s.AdditionalProperties.Schema)
+}
@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Request holds the parts of an HTTP request checked against an operation
+type Request struct {
+	PathParams  map[string]string
+	Query       url.Values
+	ContentType string
+	Body        []byte
+}
+
+// ValidateRequest checks parameters and body against the operation and returns one message per violation
+func (op *Operation) ValidateRequest(req Request) []string {
+	var violations []string
+
+	for _, param := range op.Parameters {
+		var raw string
+		var present bool
+		switch param.In {
+		case "path":
+			raw, present = req.PathParams[param.Name]
+		case "query":
+			if values, ok := req.Query[param.Name]; ok && len(values) > 0 {
+				raw, present = values[0], true
+			}
+		default:
+			continue
+		}
+
+		if !present {
+			if param.Required {
+				violations = append(violations, fmt.Sprintf("%s parameter %s: is required", param.In, param.Name))
+			}
+			continue
+		}
+
+		name := fmt.Sprintf("%s parameter %s", param.In, param.Name)
+		param.Schema.validate(name, parameterValue(param.Schema, raw), &violations)
+	}
+
+	if op.RequestBody == nil {
+		return violations
+	}
+
+	if len(req.Body) == 0 {
+		if op.RequestBody.Required {
+			violations = append(violations, "body: is required")
+		}
+		return violations
+	}
+
+	return append(violations, validateContent(op.RequestBody.Content, req.ContentType, req.Body)...)
+}
+
+// ValidateResponse checks a response against the documented response for its status code, falling back to "default"
+func (op *Operation) ValidateResponse(status int, contentType string, body []byte) []string {
+	response, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		response, ok = op.Responses["default"]
+	}
+	if !ok {
+		return []string{fmt.Sprintf("status %d is not documented", status)}
+	}
+
+	if len(response.Content) == 0 {
+		if len(body) > 0 {
+			return []string{fmt.Sprintf("status %d is documented without a body", status)}
+		}
+		return nil
+	}
+	if len(body) == 0 {
+		return []string{"body: is required"}
+	}
+
+	return validateContent(response.Content, contentType, body)
+}
+
+// validateContent decodes body as its media type and validates it against the matching schema
+func validateContent(content map[string]MediaType, contentType string, body []byte) []string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+
+	media, ok := content[mediaType]
+	if !ok {
+		return []string{fmt.Sprintf("content type %q is not documented", contentType)}
+	}
+	// Only JSON bodies are checked against their schema; others, such as multipart uploads, just need a documented type
+	if media.Schema == nil || !isJSONMediaType(mediaType) {
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{"body: is not valid JSON"}
+	}
+
+	return media.Schema.Validate(value)
+}
+
+// isJSONMediaType reports whether a media type carries JSON, such as application/json or application/problem+json
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// parameterValue converts a raw parameter string to the JSON type its schema declares
+// Values that don't parse stay strings so the type check reports them
+func parameterValue(schema *Schema, raw string) any {
+	if schema == nil {
+		return raw
+	}
+
+	switch schema.Type {
+	case "integer", "number":
+		if number, err := strconv.ParseFloat(raw, 64); err == nil {
+			return number
+		}
+	case "boolean":
+		if value, err := strconv.ParseBool(raw); err == nil {
+			return value
+		}
+	}
+	return raw
+}
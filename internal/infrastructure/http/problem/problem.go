@@ -0,0 +1,77 @@
+// Package problem renders errors as RFC 7807 problem details
+package problem
+
+import (
+	"encoding/xml"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Media types of problem details
+const (
+	ContentType    = "application/problem+json"
+	ContentTypeXML = "application/problem+xml"
+)
+
+// typePrefix namespaces the problem type URIs of this API
+const typePrefix = "urn:purchase-transaction-api:problem:"
+
+// Problem types; TypeBlank means the status code says all there is to say
+const (
+	TypeBlank               = "about:blank"
+	TypeValidation          = typePrefix + "validation"
+	TypeNotFound            = typePrefix + "not-found"
+	TypeConflict            = typePrefix + "conflict"
+	TypePreconditionFailed  = typePrefix + "precondition-failed"
+	TypeIdempotencyReused   = typePrefix + "idempotency-key-reused"
+	TypeExpired             = typePrefix + "expired"
+	TypeRateUnavailable     = typePrefix + "rate-unavailable"
+	TypeUnsupportedCurrency = typePrefix + "unsupported-currency"
+	TypeServiceUnavailable  = typePrefix + "service-unavailable"
+	TypeQuotaExceeded       = typePrefix + "quota-exceeded"
+	TypeUnauthorized        = typePrefix + "unauthorized"
+	TypeForbidden           = typePrefix + "forbidden"
+	TypeRateLimited         = typePrefix + "rate-limited"
+	TypeContractViolation   = typePrefix + "contract-violation"
+	TypeTimeout             = typePrefix + "timeout"
+)
+
+// Problem is an RFC 7807 problem details body
+// InvalidParams and Violations are extension members listing every invalid query parameter or contract violation,
+// and SupportedCurrencies lists the currencies a conversion could have used
+type Problem struct {
+	XMLName             xml.Name       `json:"-" xml:"urn:ietf:rfc:7807 problem"`
+	Type                string         `json:"type" xml:"type"`
+	Title               string         `json:"title" xml:"title"`
+	Status              int            `json:"status" xml:"status"`
+	Detail              string         `json:"detail,omitempty" xml:"detail,omitempty"`
+	Instance            string         `json:"instance,omitempty" xml:"instance,omitempty"`
+	RequestID           string         `json:"request_id,omitempty" xml:"request_id,omitempty"`
+	InvalidParams       []InvalidParam `json:"invalid_params,omitempty" xml:"invalid_param,omitempty"`
+	Violations          []string       `json:"violations,omitempty" xml:"violation,omitempty"`
+	SupportedCurrencies []string       `json:"supported_currencies,omitempty" xml:"supported_currency,omitempty"`
+}
+
+// InvalidParam names a rejected query parameter and why it was rejected
+type InvalidParam struct {
+	Name   string `json:"name" xml:"name"`
+	Reason string `json:"reason" xml:"reason"`
+}
+
+// New creates a problem about the current request; instance is the request path and request_id its ID
+func New(c *gin.Context, status int, problemType, title, detail string) *Problem {
+	return &Problem{
+		Type:      problemType,
+		Title:     title,
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		RequestID: c.GetString("request_id"),
+	}
+}
+
+// Abort renders the problem as application/problem+json and stops the handler chain
+func Abort(c *gin.Context, p *Problem) {
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
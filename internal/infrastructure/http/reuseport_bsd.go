@@ -0,0 +1,8 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd || (linux && (mips || mipsle || mips64 || mips64le))
+
+package http
+
+import "syscall"
+
+// soReusePort is SO_REUSEPORT as defined by the platform
+const soReusePort = syscall.SO_REUSEPORT
@@ -0,0 +1,6 @@
+//go:build linux && !mips && !mipsle && !mips64 && !mips64le
+
+package http
+
+// soReusePort is SO_REUSEPORT, which the frozen syscall package does not define for most Linux architectures
+const soReusePort = 0xf
@@ -1,28 +1,190 @@
 package http
 
 import (
+	"net/http/pprof"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/handlers"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/openapi"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/errortracking"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
+)
+
+// Rate limit profiles assigned to routes; limits per profile come from RATE_LIMIT_PROFILES
+const (
+	profileRead    = "read"
+	profileList    = "list"
+	profileWrite   = "write"
+	profileConvert = "convert"
+	profileAdmin   = "admin"
 )
 
 // Router sets up the HTTP routes for the application
 type Router struct {
-	transactionHandler *handlers.TransactionHandler
-	logger             *logger.Logger
+	transactionHandler      *handlers.TransactionHandler
+	currencyHandler         *handlers.CurrencyHandler
+	conversionHandler       *handlers.ConversionHandler
+	budgetHandler           *handlers.BudgetHandler
+	categoryHandler         *handlers.CategoryHandler
+	reportHandler           *handlers.ReportHandler
+	graphqlHandler          *handlers.GraphQLHandler
+	rateSubscriptionHandler *handlers.RateSubscriptionHandler
+	webhookHandler          *handlers.WebhookHandler
+	auditHandler            *handlers.AuditHandler
+	adminHandler            *handlers.AdminHandler
+	apiTokenHandler         *handlers.APITokenHandler
+	healthHandler           *handlers.HealthHandler
+	metricsHandler          *handlers.MetricsHandler
+	docsHandler             *handlers.DocsHandler
+	activity                *activity.Recorder
+	limiter                 *middleware.RateLimiter
+	timeouts                *middleware.RequestTimeouts
+	auth                    *middleware.TokenAuth
+	contract                *middleware.ContractValidator
+	payloads                *middleware.PayloadLogger
+	v1Deprecation           *middleware.DeprecationPolicy
+	errorReporter           *errortracking.Reporter
+	logger                  *logger.Logger
 }
 
 // NewRouter creates a new Router with the provided handlers
-func NewRouter(transactionHandler *handlers.TransactionHandler, log *logger.Logger) *Router {
+func NewRouter(
+	transactionHandler *handlers.TransactionHandler,
+	currencyHandler *handlers.CurrencyHandler,
+	conversionHandler *handlers.ConversionHandler,
+	budgetHandler *handlers.BudgetHandler,
+	categoryHandler *handlers.CategoryHandler,
+	reportHandler *handlers.ReportHandler,
+	graphqlHandler *handlers.GraphQLHandler,
+	rateSubscriptionHandler *handlers.RateSubscriptionHandler,
+	webhookHandler *handlers.WebhookHandler,
+	auditHandler *handlers.AuditHandler,
+	adminHandler *handlers.AdminHandler,
+	apiTokenHandler *handlers.APITokenHandler,
+	healthHandler *handlers.HealthHandler,
+	metricsHandler *handlers.MetricsHandler,
+	recorder *activity.Recorder,
+	limiter *middleware.RateLimiter,
+	log *logger.Logger,
+) *Router {
 	return &Router{
-		transactionHandler: transactionHandler,
-		logger:             log,
+		transactionHandler:      transactionHandler,
+		currencyHandler:         currencyHandler,
+		conversionHandler:       conversionHandler,
+		budgetHandler:           budgetHandler,
+		categoryHandler:         categoryHandler,
+		reportHandler:           reportHandler,
+		graphqlHandler:          graphqlHandler,
+		rateSubscriptionHandler: rateSubscriptionHandler,
+		webhookHandler:          webhookHandler,
+		auditHandler:            auditHandler,
+		adminHandler:            adminHandler,
+		apiTokenHandler:         apiTokenHandler,
+		healthHandler:           healthHandler,
+		metricsHandler:          metricsHandler,
+		docsHandler:             handlers.NewDocsHandler(openapi.Spec),
+		activity:                recorder,
+		limiter:                 limiter,
+		logger:                  log,
 	}
 }
 
-// SetupRoutes configures all the routes for the application
+// WithContractValidator checks documented routes against the OpenAPI contract
+func (r *Router) WithContractValidator(validator *middleware.ContractValidator) *Router {
+	r.contract = validator
+	return r
+}
+
+// WithPayloadLogger logs request and response bodies at debug level
+func (r *Router) WithPayloadLogger(payloads *middleware.PayloadLogger) *Router {
+	r.payloads = payloads
+	return r
+}
+
+// WithErrorReporter reports panics and the errors behind 5xx responses to the error tracker
+func (r *Router) WithErrorReporter(reporter *errortracking.Reporter) *Router {
+	r.errorReporter = reporter
+	return r
+}
+
+// WithRequestTimeouts bounds how long requests may run, with budgets per rate limit profile
+func (r *Router) WithRequestTimeouts(timeouts *middleware.RequestTimeouts) *Router {
+	r.timeouts = timeouts
+	return r
+}
+
+// WithTokenAuth requires an API token on the /api/v1 routes: read for GET, write otherwise and admin for admin routes
+func (r *Router) WithTokenAuth(auth *middleware.TokenAuth) *Router {
+	r.auth = auth
+	return r
+}
+
+// WithV1Deprecation marks the /api/v1 business routes as deprecated
+func (r *Router) WithV1Deprecation(policy *middleware.DeprecationPolicy) *Router {
+	r.v1Deprecation = policy
+	return r
+}
+
+// SetupRoutes configures the business API together with health, metrics and admin routes on a single engine
+// Profiling is left to the ops listener
 func (r *Router) SetupRoutes() *gin.Engine {
+	router := r.newEngine()
+
+	// Health check endpoint for Docker
+	router.GET("/health", r.healthHandler.Health)
+	router.GET("/metrics", r.metricsHandler.Metrics)
+
+	r.registerBusinessRoutes(router, true)
+	r.registerAdminRoutes(router)
+
+	return router
+}
+
+// SetupPublicRoutes configures only the business API, for when ops routes are served on a separate listener
+func (r *Router) SetupPublicRoutes() *gin.Engine {
+	router := r.newEngine()
+	r.registerBusinessRoutes(router, false)
+	return router
+}
+
+// SetupOpsRoutes configures health, metrics, profiling and admin routes for the internal listener
+func (r *Router) SetupOpsRoutes() *gin.Engine {
+	router := r.newEngine()
+
+	router.GET("/health", r.healthHandler.Health)
+	router.GET("/metrics", r.metricsHandler.Metrics)
+
+	// Go runtime profiling; pprof.Index also serves the named profiles (heap, goroutine, ...)
+	debug := router.Group("/debug/pprof")
+	{
+		debug.GET("/", gin.WrapF(pprof.Index))
+		debug.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+		debug.GET("/profile", gin.WrapF(pprof.Profile))
+		debug.GET("/symbol", gin.WrapF(pprof.Symbol))
+		debug.POST("/symbol", gin.WrapF(pprof.Symbol))
+		debug.GET("/trace", gin.WrapF(pprof.Trace))
+		debug.GET("/:profile", gin.WrapF(pprof.Index))
+	}
+
+	r.registerAdminRoutes(router)
+
+	return router
+}
+
+// newEngine creates a Gin engine with the shared middleware chain
+func (r *Router) newEngine() *gin.Engine {
+	// Register custom tags on Gin's binding validator so binding:"currency" works too
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		_ = validation.RegisterBindingValidations(engine)
+	}
+
 	// Create Gin router without default logger (we'll use our structured logger)
 	router := gin.New()
 
@@ -31,56 +193,384 @@ func (r *Router) SetupRoutes() *gin.Engine {
 
 	// Add custom middleware with structured logging
 	router.Use(middleware.RequestIDMiddleware(r.logger))
+	router.Use(middleware.ErrorReporting(r.errorReporter))
 	router.Use(middleware.LoggingMiddleware(r.logger))
 	router.Use(middleware.ErrorLoggingMiddleware(r.logger))
 	router.Use(middleware.CORS())
 	router.Use(middleware.ErrorHandler())
+	router.Use(r.payloads.Handler())
+	router.Use(r.contract.Handler())
 
-	// Health check endpoint for Docker
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":    "healthy",
-			"service":   "purchase-transaction-api",
-			"timestamp": gin.H{"unix": gin.H{}},
-		})
-	})
+	return router
+}
+
+// registerBusinessRoutes adds the public API and its documentation endpoint
+// withOps lists the health, metrics and admin endpoints in the documentation when they share the engine
+func (r *Router) registerBusinessRoutes(router *gin.Engine, withOps bool) {
+	// v2 is registered first so the v1 routes it replaces can link to their successors
+	v2Routes := r.registerV2Routes(router)
 
-	// API v1 routes
-	v1 := router.Group("/api/v1")
+	// API v1 routes, announcing their deprecation and sunset once a policy is configured
+	v1 := router.Group("/api/"+middleware.VersionV1,
+		middleware.APIVersion(middleware.VersionV1),
+		r.v1Deprecation.Deprecate(r.activity),
+		middleware.SuccessorVersion(middleware.VersionV1, middleware.VersionV2, v2Routes),
+		r.auth.RequireByMethod(),
+	)
 	{
 		// Transaction routes
 		transactions := v1.Group("/transactions")
 		{
 			// POST /api/v1/transactions - Create a new transaction
-			transactions.POST("", r.transactionHandler.CreateTransaction)
+			transactions.POST("", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.transactionHandler.CreateTransaction)
 
 			// GET /api/v1/transactions - List transactions with pagination
-			transactions.GET("", r.transactionHandler.ListTransactions)
+			transactions.GET("", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.transactionHandler.ListTransactions)
+
+			// POST /api/v1/transactions/import - Import transactions from a CSV upload
+			transactions.POST("/import", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.transactionHandler.ImportTransactions)
+
+			// GET /api/v1/transactions/descriptions - Suggest descriptions by prefix
+			transactions.GET("/descriptions", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.transactionHandler.SuggestDescriptions)
 
 			// GET /api/v1/transactions/:id - Get a specific transaction
-			transactions.GET("/:id", r.transactionHandler.GetTransaction)
+			transactions.GET("/:id", r.limiter.Limit(profileRead), r.timeouts.Limit(profileRead), r.transactionHandler.GetTransaction)
+
+			// PATCH /api/v1/transactions/:id - Change the category and tags of a transaction
+			transactions.PATCH("/:id", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.transactionHandler.UpdateTransactionCategory)
+
+			// DELETE /api/v1/transactions/:id - Move a transaction to the trash
+			transactions.DELETE("/:id", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.transactionHandler.DeleteTransaction)
+
+			// POST /api/v1/transactions/convert-batch - Convert several transactions to one currency
+			transactions.POST("/convert-batch", r.limiter.Limit(profileConvert), r.timeouts.Limit(profileConvert), r.transactionHandler.ConvertTransactionsBatch)
 
 			// POST /api/v1/transactions/:id/convert - Convert transaction currency
-			transactions.POST("/:id/convert", r.transactionHandler.ConvertTransaction)
+			transactions.POST("/:id/convert", r.limiter.Limit(profileConvert), r.timeouts.Limit(profileConvert), middleware.CountConversions(r.activity), r.transactionHandler.ConvertTransaction)
+
+			// GET /api/v1/transactions/:id/conversions - Conversion history, most recent first
+			transactions.GET("/:id/conversions", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.transactionHandler.ListConversions)
+
+			// POST /api/v1/transactions/:id/restore - Restore a soft-deleted transaction
+			transactions.POST("/:id/restore", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.transactionHandler.RestoreTransaction)
+		}
+
+		// Currency routes
+		currencies := v1.Group("/currencies")
+		{
+			// GET /api/v1/currencies/:code - Get currency metadata
+			currencies.GET("/:code", r.limiter.Limit(profileRead), r.timeouts.Limit(profileRead), r.currencyHandler.GetCurrency)
+		}
+
+		// Budget routes
+		budgets := v1.Group("/budgets")
+		{
+			// POST /api/v1/budgets - Create a category budget
+			budgets.POST("", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.budgetHandler.CreateBudget)
+
+			// GET /api/v1/budgets - List budgets
+			budgets.GET("", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.budgetHandler.ListBudgets)
+
+			// GET /api/v1/budgets/:id - Get a budget
+			budgets.GET("/:id", r.limiter.Limit(profileRead), r.timeouts.Limit(profileRead), r.budgetHandler.GetBudget)
+
+			// PUT /api/v1/budgets/:id - Replace a budget
+			budgets.PUT("/:id", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.budgetHandler.UpdateBudget)
+
+			// DELETE /api/v1/budgets/:id - Delete a budget
+			budgets.DELETE("/:id", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.budgetHandler.DeleteBudget)
+		}
+
+		// Category routes
+		categories := v1.Group("/categories")
+		{
+			// POST /api/v1/categories - Create a category
+			categories.POST("", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.categoryHandler.CreateCategory)
+
+			// GET /api/v1/categories - List categories
+			categories.GET("", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.categoryHandler.ListCategories)
+
+			// GET /api/v1/categories/:id - Get a category
+			categories.GET("/:id", r.limiter.Limit(profileRead), r.timeouts.Limit(profileRead), r.categoryHandler.GetCategory)
+
+			// PUT /api/v1/categories/:id - Replace or rename a category
+			categories.PUT("/:id", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.categoryHandler.UpdateCategory)
+
+			// DELETE /api/v1/categories/:id - Delete an unused category
+			categories.DELETE("/:id", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.categoryHandler.DeleteCategory)
+		}
+
+		// GET /api/v1/reports/summary - Spending totals per day, month or year
+		v1.GET("/reports/summary", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.reportHandler.GetSummary)
+
+		// Rate subscription routes
+		rateSubscriptions := v1.Group("/rates/subscriptions")
+		{
+			// POST /api/v1/rates/subscriptions - Ask the background sync to keep currencies fresh
+			rateSubscriptions.POST("", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.rateSubscriptionHandler.Subscribe)
+
+			// GET /api/v1/rates/subscriptions - List subscriptions with rate freshness
+			rateSubscriptions.GET("", r.limiter.Limit(profileRead), r.timeouts.Limit(profileRead), r.rateSubscriptionHandler.ListSubscriptions)
+
+			// DELETE /api/v1/rates/subscriptions/:currency - Stop keeping a currency fresh
+			rateSubscriptions.DELETE("/:currency", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.rateSubscriptionHandler.Unsubscribe)
+		}
+
+		// Webhook routes
+		webhooks := v1.Group("/webhooks")
+		{
+			// POST /api/v1/webhooks - Register a URL notified of transaction events
+			webhooks.POST("", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.webhookHandler.CreateWebhook)
+
+			// GET /api/v1/webhooks - List webhooks
+			webhooks.GET("", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.webhookHandler.ListWebhooks)
+
+			// GET /api/v1/webhooks/:id - Get a webhook
+			webhooks.GET("/:id", r.limiter.Limit(profileRead), r.timeouts.Limit(profileRead), r.webhookHandler.GetWebhook)
+
+			// DELETE /api/v1/webhooks/:id - Delete a webhook and its pending deliveries
+			webhooks.DELETE("/:id", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.webhookHandler.DeleteWebhook)
+
+			// GET /api/v1/webhooks/:id/deliveries - Recent deliveries with their status
+			webhooks.GET("/:id/deliveries", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.webhookHandler.ListDeliveries)
+		}
+
+		// GET /api/v1/audit - Audit trail of transaction changes, for compliance reviews
+		v1.GET("/audit", r.auth.Require(entities.RoleAdmin), r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.auditHandler.ListAuditLogs)
+
+		// POST /api/v1/convert - Convert an arbitrary USD amount at a given date
+		v1.POST("/convert", r.limiter.Limit(profileConvert), r.timeouts.Limit(profileConvert), middleware.CountConversions(r.activity), r.conversionHandler.ConvertAmount)
+
+		// POST /api/v1/quotes - Lock an exchange rate for a short period
+		v1.POST("/quotes", r.limiter.Limit(profileConvert), r.timeouts.Limit(profileConvert), r.conversionHandler.CreateQuote)
+
+		// GET /api/v1/exchange-rates - Preview the rate a conversion on a date would use
+		v1.GET("/exchange-rates", r.limiter.Limit(profileConvert), r.timeouts.Limit(profileConvert), r.conversionHandler.GetExchangeRate)
+	}
+
+	// GraphQL queries over transactions; POST only reads, so both methods need the read role
+	graphql := router.Group("/graphql", r.auth.Require(entities.RoleRead))
+	{
+		// POST /graphql - Run a query
+		graphql.POST("", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.graphqlHandler.Query)
+
+		// GET /graphql - Run a query passed in the query string
+		graphql.GET("", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.graphqlHandler.Query)
+
+		// GET /graphql/schema - The schema in SDL, for client code generation
+		graphql.GET("/schema", r.graphqlHandler.Schema)
+	}
+
+	endpoints := gin.H{
+		"transactions": gin.H{
+			"create":       "POST /api/v1/transactions",
+			"list":         "GET /api/v1/transactions?page=1&size=20",
+			"trash":        "GET /api/v1/transactions?trash=true",
+			"get":          "GET /api/v1/transactions/{id}",
+			"categorize":   "PATCH /api/v1/transactions/{id}",
+			"delete":       "DELETE /api/v1/transactions/{id}",
+			"convert":      "POST /api/v1/transactions/{id}/convert",
+			"convertBatch": "POST /api/v1/transactions/convert-batch",
+			"conversions":  "GET /api/v1/transactions/{id}/conversions",
+			"restore":      "POST /api/v1/transactions/{id}/restore",
+			"import":       "POST /api/v1/transactions/import",
+			"descriptions": "GET /api/v1/transactions/descriptions?prefix=off",
+		},
+		"currencies": gin.H{
+			"get": "GET /api/v1/currencies/{code}",
+		},
+		"budgets": gin.H{
+			"create": "POST /api/v1/budgets",
+			"list":   "GET /api/v1/budgets",
+			"get":    "GET /api/v1/budgets/{id}",
+			"update": "PUT /api/v1/budgets/{id}",
+			"delete": "DELETE /api/v1/budgets/{id}",
+		},
+		"categories": gin.H{
+			"create": "POST /api/v1/categories",
+			"list":   "GET /api/v1/categories",
+			"get":    "GET /api/v1/categories/{id}",
+			"update": "PUT /api/v1/categories/{id}",
+			"delete": "DELETE /api/v1/categories/{id}",
+		},
+		"reports": gin.H{
+			"summary": "GET /api/v1/reports/summary?from=2024-01-01&to=2024-12-31&group_by=month",
+		},
+		"rate_subscriptions": gin.H{
+			"subscribe":   "POST /api/v1/rates/subscriptions",
+			"list":        "GET /api/v1/rates/subscriptions",
+			"unsubscribe": "DELETE /api/v1/rates/subscriptions/{currency}",
+		},
+		"webhooks": gin.H{
+			"create":     "POST /api/v1/webhooks",
+			"list":       "GET /api/v1/webhooks",
+			"get":        "GET /api/v1/webhooks/{id}",
+			"delete":     "DELETE /api/v1/webhooks/{id}",
+			"deliveries": "GET /api/v1/webhooks/{id}/deliveries",
+		},
+		"v2": gin.H{
+			"transactions": gin.H{
+				"create":     "POST /api/v2/transactions",
+				"list":       "GET /api/v2/transactions?size=20&cursor={next_cursor}",
+				"get":        "GET /api/v2/transactions/{id}",
+				"categorize": "PATCH /api/v2/transactions/{id}",
+				"delete":     "DELETE /api/v2/transactions/{id}",
+				"convert":    "POST /api/v2/transactions/{id}/convert",
+				"restore":    "POST /api/v2/transactions/{id}/restore",
+			},
+		},
+		"audit":   "GET /api/v1/audit?entity_id={id}&from=2024-01-01&to=2024-12-31",
+		"convert": "POST /api/v1/convert",
+		"quotes":  "POST /api/v1/quotes",
+		"rates":   "GET /api/v1/exchange-rates?from=USD&to=EUR&date=2024-01-15",
+		"graphql": gin.H{
+			"query":  "POST /graphql",
+			"schema": "GET /graphql/schema",
+		},
+		"docs": gin.H{
+			"openapi":    "GET /openapi.json",
+			"swagger_ui": "GET /swagger/index.html",
+		},
+	}
+	if withOps {
+		endpoints["health"] = "GET /health"
+		endpoints["metrics"] = "GET /metrics"
+	}
+	if withOps && r.auth != nil {
+		endpoints["admin"] = gin.H{
+			"export":   "GET /api/v1/admin/export",
+			"import":   "POST /api/v1/admin/import?strategy=skip|overwrite|fail",
+			"database": "GET /api/v1/admin/database",
+			"log_level": gin.H{
+				"get": "GET /api/v1/admin/loglevel",
+				"set": "PUT /api/v1/admin/loglevel",
+			},
+			"batch_conversion": gin.H{
+				"start":   "POST /api/v1/admin/conversions",
+				"status":  "GET /api/v1/admin/conversions/{id}",
+				"records": "GET /api/v1/admin/conversions/{id}/records",
+			},
+			"rate_cache": gin.H{
+				"inspect": "GET /api/v1/admin/cache/rates?currency=EUR",
+				"evict":   "DELETE /api/v1/admin/cache/rates?currency=EUR&effective_date=2025-03-31",
+			},
+			"tokens": gin.H{
+				"create": "POST /api/v1/admin/tokens",
+				"list":   "GET /api/v1/admin/tokens",
+				"get":    "GET /api/v1/admin/tokens/{id}",
+				"rotate": "POST /api/v1/admin/tokens/{id}/rotate",
+				"revoke": "DELETE /api/v1/admin/tokens/{id}",
+			},
 		}
 	}
 
+	// OpenAPI contract and Swagger UI, so consumers can discover request and response shapes
+	router.GET("/openapi.json", r.docsHandler.OpenAPISpec)
+	router.GET("/swagger", r.docsHandler.RedirectToSwaggerUI)
+	router.GET("/swagger/", r.docsHandler.RedirectToSwaggerUI)
+	router.GET("/swagger/index.html", r.docsHandler.SwaggerUI)
+
 	// API documentation endpoint
 	router.GET("/", func(c *gin.Context) {
 		c.JSON(200, gin.H{
-			"service": "Purchase Transaction API",
-			"version": "1.0.0",
-			"endpoints": gin.H{
-				"health": "GET /health",
-				"transactions": gin.H{
-					"create":  "POST /api/v1/transactions",
-					"list":    "GET /api/v1/transactions?page=1&size=20",
-					"get":     "GET /api/v1/transactions/{id}",
-					"convert": "POST /api/v1/transactions/{id}/convert",
-				},
-			},
+			"service":   "Purchase Transaction API",
+			"version":   "1.0.0",
+			"endpoints": endpoints,
 		})
 	})
+}
 
-	return router
+// registerV2Routes adds the /api/v2 routes and returns their patterns
+// v2 lists by cursor only and always answers errors as application/problem+json; routes move over from v1 as they are reworked
+func (r *Router) registerV2Routes(router *gin.Engine) []string {
+	v2 := router.Group("/api/"+middleware.VersionV2, middleware.APIVersion(middleware.VersionV2), r.auth.RequireByMethod())
+	{
+		transactions := v2.Group("/transactions")
+		{
+			// POST /api/v2/transactions - Create a new transaction
+			transactions.POST("", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.transactionHandler.CreateTransaction)
+
+			// GET /api/v2/transactions - List transactions, one cursor page at a time
+			transactions.GET("", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.transactionHandler.ListTransactionsByCursor)
+
+			// GET /api/v2/transactions/:id - Get a specific transaction
+			transactions.GET("/:id", r.limiter.Limit(profileRead), r.timeouts.Limit(profileRead), r.transactionHandler.GetTransaction)
+
+			// PATCH /api/v2/transactions/:id - Change the category and tags of a transaction
+			transactions.PATCH("/:id", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.transactionHandler.UpdateTransactionCategory)
+
+			// DELETE /api/v2/transactions/:id - Move a transaction to the trash
+			transactions.DELETE("/:id", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.transactionHandler.DeleteTransaction)
+
+			// POST /api/v2/transactions/:id/convert - Convert transaction currency
+			transactions.POST("/:id/convert", r.limiter.Limit(profileConvert), r.timeouts.Limit(profileConvert), middleware.CountConversions(r.activity), r.transactionHandler.ConvertTransaction)
+
+			// POST /api/v2/transactions/:id/restore - Restore a soft-deleted transaction
+			transactions.POST("/:id/restore", r.limiter.Limit(profileWrite), r.timeouts.Limit(profileWrite), r.transactionHandler.RestoreTransaction)
+		}
+	}
+
+	var routes []string
+	for _, route := range router.Routes() {
+		if strings.HasPrefix(route.Path, v2.BasePath()+"/") {
+			routes = append(routes, route.Path)
+		}
+	}
+	return routes
+}
+
+// registerAdminRoutes adds the /api/v1/admin routes
+// They change tokens, log levels and the whole dataset, so they are left out unless an authenticator is configured
+func (r *Router) registerAdminRoutes(router *gin.Engine) {
+	if r.auth == nil {
+		return
+	}
+
+	admin := router.Group("/api/v1/admin", r.auth.Require(entities.RoleAdmin))
+	{
+		// GET /api/v1/admin/export - Export the full dataset as a versioned archive
+		admin.GET("/export", r.limiter.Limit(profileAdmin), r.timeouts.Limit(profileAdmin), r.adminHandler.ExportDataset)
+
+		// POST /api/v1/admin/import - Import a dataset archive
+		admin.POST("/import", r.limiter.Limit(profileAdmin), r.timeouts.Limit(profileAdmin), r.adminHandler.ImportDataset)
+
+		// GET /api/v1/admin/database - Database size, row counts and soft quota status
+		admin.GET("/database", r.limiter.Limit(profileAdmin), r.timeouts.Limit(profileAdmin), r.adminHandler.DatabaseUsage)
+
+		// POST /api/v1/admin/conversions - Convert every transaction in a date range in the background
+		admin.POST("/conversions", r.limiter.Limit(profileAdmin), r.timeouts.Limit(profileAdmin), r.adminHandler.StartBatchConversion)
+
+		// GET /api/v1/admin/conversions/:id - Batch conversion status and progress
+		admin.GET("/conversions/:id", r.limiter.Limit(profileRead), r.timeouts.Limit(profileRead), r.adminHandler.GetBatchConversion)
+
+		// GET /api/v1/admin/conversions/:id/records - Conversion records produced by a batch
+		admin.GET("/conversions/:id/records", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.adminHandler.ListBatchConversionRecords)
+
+		// GET /api/v1/admin/cache/rates - Cached rates per currency with hit statistics
+		admin.GET("/cache/rates", r.limiter.Limit(profileAdmin), r.timeouts.Limit(profileAdmin), r.adminHandler.RateCache)
+
+		// DELETE /api/v1/admin/cache/rates - Evict cached rates by currency (and date) or entirely
+		admin.DELETE("/cache/rates", r.limiter.Limit(profileAdmin), r.timeouts.Limit(profileAdmin), r.adminHandler.EvictRateCache)
+
+		// GET /api/v1/admin/loglevel - Log level in effect
+		admin.GET("/loglevel", r.limiter.Limit(profileAdmin), r.timeouts.Limit(profileAdmin), r.adminHandler.GetLogLevel)
+
+		// PUT /api/v1/admin/loglevel - Change the log level without restarting
+		admin.PUT("/loglevel", r.limiter.Limit(profileAdmin), r.timeouts.Limit(profileAdmin), r.adminHandler.SetLogLevel)
+
+		// POST /api/v1/admin/tokens - Issue an API token; the secret is only returned here
+		admin.POST("/tokens", r.limiter.Limit(profileAdmin), r.timeouts.Limit(profileAdmin), r.apiTokenHandler.CreateToken)
+
+		// GET /api/v1/admin/tokens - List API tokens with their status
+		admin.GET("/tokens", r.limiter.Limit(profileList), r.timeouts.Limit(profileList), r.apiTokenHandler.ListTokens)
+
+		// GET /api/v1/admin/tokens/:id - Get an API token
+		admin.GET("/tokens/:id", r.limiter.Limit(profileRead), r.timeouts.Limit(profileRead), r.apiTokenHandler.GetToken)
+
+		// POST /api/v1/admin/tokens/:id/rotate - Replace a token's secret, optionally keeping the old one for a grace period
+		admin.POST("/tokens/:id/rotate", r.limiter.Limit(profileAdmin), r.timeouts.Limit(profileAdmin), r.apiTokenHandler.RotateToken)
+
+		// DELETE /api/v1/admin/tokens/:id - Revoke an API token
+		admin.DELETE("/tokens/:id", r.limiter.Limit(profileAdmin), r.timeouts.Limit(profileAdmin), r.apiTokenHandler.RevokeToken)
+	}
 }
@@ -2,8 +2,10 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,12 +13,22 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/lifecycle"
 )
 
+// writeMargin is the time left after a request budget to write the response
+const writeMargin = 5 * time.Second
+
 // Server represents the HTTP server
 type Server struct {
-	router *gin.Engine
-	server *http.Server
+	router    *gin.Engine
+	server    *http.Server
+	ops       *http.Server // Optional internal listener for health, metrics and admin routes
+	grpc      *http.Server // Optional cleartext HTTP/2 listener for the gRPC service
+	reusePort bool
+
+	drainTimeout time.Duration      // Time in-flight requests get to finish once shutdown starts
+	lifecycle    *lifecycle.Manager // Optional background workers and components stopped after the listeners
 }
 
 // NewServer creates a new HTTP server
@@ -31,19 +43,120 @@ func NewServer(router *gin.Engine, port string) *Server {
 			IdleTimeout:    60 * time.Second,
 			MaxHeaderBytes: 1 << 20, // 1 MB
 		},
+		drainTimeout: 30 * time.Second,
+	}
+}
+
+// WithOpsListener also serves handler on addr, typically bound to localhost or an internal interface
+func (s *Server) WithOpsListener(handler http.Handler, addr string) *Server {
+	s.ops = &http.Server{
+		Addr:           addr,
+		Handler:        handler,
+		ReadTimeout:    15 * time.Second,
+		WriteTimeout:   2 * time.Minute, // CPU profiles and traces stream for up to their requested duration
+		IdleTimeout:    60 * time.Second,
+		MaxHeaderBytes: 1 << 20, // 1 MB
 	}
+	return s
+}
+
+// WithGRPCListener also serves the gRPC handler on addr over cleartext HTTP/2 (h2c)
+// gRPC calls are long-lived streams, so only the header read has a deadline
+func (s *Server) WithGRPCListener(handler http.Handler, addr string) *Server {
+	s.grpc = &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 15 * time.Second,
+		IdleTimeout:       60 * time.Second,
+		MaxHeaderBytes:    1 << 20, // 1 MB
+		Protocols:         new(http.Protocols),
+	}
+	s.grpc.Protocols.SetUnencryptedHTTP2(true)
+	return s
+}
+
+// WithRequestTimeouts lets the main listener write responses for as long as the longest request budget,
+// plus writeMargin so a request answered 504 at its deadline still gets the response
+func (s *Server) WithRequestTimeouts(longest time.Duration) *Server {
+	if timeout := longest + writeMargin; timeout > s.server.WriteTimeout {
+		s.server.WriteTimeout = timeout
+	}
+	return s
+}
+
+// WithReusePort binds with SO_REUSEPORT so a new process can take over the port before this one drains
+func (s *Server) WithReusePort(enabled bool) *Server {
+	s.reusePort = enabled
+	return s
+}
+
+// WithDrainTimeout sets how long in-flight requests get to finish once shutdown starts
+func (s *Server) WithDrainTimeout(timeout time.Duration) *Server {
+	s.drainTimeout = timeout
+	return s
+}
+
+// WithLifecycle stops the manager's background workers and runs its shutdown hooks once the listeners have drained
+func (s *Server) WithLifecycle(manager *lifecycle.Manager) *Server {
+	s.lifecycle = manager
+	return s
 }
 
 // Start starts the HTTP server with graceful shutdown
 func (s *Server) Start() error {
+	// Bind before serving so a port conflict is reported to the caller
+	listener, err := Listen(s.server.Addr, ListenerPublic, s.reusePort)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.server.Addr, err)
+	}
+
+	var opsListener net.Listener
+	if s.ops != nil {
+		opsListener, err = Listen(s.ops.Addr, ListenerOps, s.reusePort)
+		if err != nil {
+			_ = listener.Close()
+			return fmt.Errorf("failed to listen on %s: %w", s.ops.Addr, err)
+		}
+	}
+
+	var grpcListener net.Listener
+	if s.grpc != nil {
+		grpcListener, err = Listen(s.grpc.Addr, ListenerGRPC, s.reusePort)
+		if err != nil {
+			_ = listener.Close()
+			if opsListener != nil {
+				_ = opsListener.Close()
+			}
+			return fmt.Errorf("failed to listen on %s: %w", s.grpc.Addr, err)
+		}
+	}
+
 	// Start server in a goroutine
 	go func() {
-		log.Printf("Starting HTTP server on %s", s.server.Addr)
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Printf("Starting HTTP server on %s", listener.Addr())
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start server: %v", err)
 		}
 	}()
 
+	if opsListener != nil {
+		go func() {
+			log.Printf("Starting ops HTTP server on %s", opsListener.Addr())
+			if err := s.ops.Serve(opsListener); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Failed to start ops server: %v", err)
+			}
+		}()
+	}
+
+	if grpcListener != nil {
+		go func() {
+			log.Printf("Starting gRPC server on %s", grpcListener.Addr())
+			if err := s.grpc.Serve(grpcListener); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -51,19 +164,43 @@ func (s *Server) Start() error {
 
 	log.Println("Shutting down server...")
 
-	// Give outstanding requests 30 seconds to complete
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Give outstanding requests the drain timeout to complete
+	ctx, cancel := context.WithTimeout(context.Background(), s.drainTimeout)
 	defer cancel()
 
-	if err := s.server.Shutdown(ctx); err != nil {
-		return fmt.Errorf("server forced to shutdown: %w", err)
+	if err := s.Stop(ctx); err != nil {
+		return fmt.Errorf("server did not shut down cleanly: %w", err)
 	}
 
 	log.Println("Server exited")
 	return nil
 }
 
-// Stop gracefully stops the HTTP server
+// Stop gracefully stops the HTTP server and the ops and gRPC listeners, waiting for in-flight requests until ctx is done,
+// then stops the background workers and components of the lifecycle manager
 func (s *Server) Stop(ctx context.Context) error {
-	return s.server.Shutdown(ctx)
+	err := s.server.Shutdown(ctx)
+	if s.ops != nil {
+		err = errors.Join(err, s.ops.Shutdown(ctx))
+	}
+	if s.grpc != nil {
+		err = errors.Join(err, s.grpc.Shutdown(ctx))
+	}
+
+	// Requests still running past the drain timeout are abandoned so the components they use can be released
+	if err != nil {
+		err = errors.Join(err, s.server.Close())
+		if s.ops != nil {
+			err = errors.Join(err, s.ops.Close())
+		}
+		if s.grpc != nil {
+			err = errors.Join(err, s.grpc.Close())
+		}
+	}
+
+	if s.lifecycle != nil {
+		log.Println("Stopping background workers...")
+		err = errors.Join(err, s.lifecycle.Shutdown())
+	}
+	return err
 }
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// apiTokenRepository implements APITokenRepository interface using an in-process map
+type apiTokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[uuid.UUID]entities.APIToken
+}
+
+// NewAPITokenRepository creates a new in-memory implementation of APITokenRepository
+func NewAPITokenRepository() repositories.APITokenRepository {
+	return &apiTokenRepository{
+		tokens: make(map[uuid.UUID]entities.APIToken),
+	}
+}
+
+// Create persists a new token in memory
+func (r *apiTokenRepository) Create(token *entities.APIToken) error {
+	if token == nil {
+		return errors.New("api token cannot be nil")
+	}
+
+	if err := token.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tokens[token.ID]; exists {
+		return errors.New("api token already exists")
+	}
+	for _, existing := range r.tokens {
+		if existing.TokenHash == token.TokenHash {
+			return errors.New("api token hash already exists")
+		}
+	}
+
+	token.CreatedAt = time.Now()
+	r.tokens[token.ID] = *token
+	return nil
+}
+
+// Update saves changes to an existing token, keeping its creation time
+func (r *apiTokenRepository) Update(token *entities.APIToken) error {
+	if token == nil {
+		return errors.New("api token cannot be nil")
+	}
+
+	if err := token.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.tokens[token.ID]
+	if !exists {
+		return errs.Newf(errs.ErrNotFound, "api token with ID %s not found", token.ID)
+	}
+
+	token.CreatedAt = existing.CreatedAt
+	r.tokens[token.ID] = *token
+	return nil
+}
+
+// GetByID retrieves a token by its unique identifier
+func (r *apiTokenRepository) GetByID(id uuid.UUID) (*entities.APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, exists := r.tokens[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &token, nil
+}
+
+// GetByHash retrieves a token by the hash of its secret
+func (r *apiTokenRepository) GetByHash(tokenHash string) (*entities.APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			return &token, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// List retrieves every token, newest first
+func (r *apiTokenRepository) List() ([]entities.APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tokens := make([]entities.APIToken, 0, len(r.tokens))
+	for _, token := range r.tokens {
+		tokens = append(tokens, token)
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		if !tokens[i].CreatedAt.Equal(tokens[j].CreatedAt) {
+			return tokens[i].CreatedAt.After(tokens[j].CreatedAt)
+		}
+		return tokens[i].ID.String() < tokens[j].ID.String()
+	})
+	return tokens, nil
+}
+
+// CountActive returns how many tokens with the role are neither revoked nor expired
+func (r *apiTokenRepository) CountActive(role entities.APIRole) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	var count int64
+	for _, token := range r.tokens {
+		if token.Role == role && token.IsActive(now) {
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// auditLogRepository implements AuditLogRepository interface using an in-process slice
+type auditLogRepository struct {
+	mu      sync.RWMutex
+	entries []entities.AuditLog // In insertion order
+}
+
+// NewAuditLogRepository creates a new in-memory implementation of AuditLogRepository
+func NewAuditLogRepository() repositories.AuditLogRepository {
+	return &auditLogRepository{}
+}
+
+// Save appends an entry in memory
+func (r *auditLogRepository) Save(entry *entities.AuditLog) error {
+	if entry == nil {
+		return errors.New("audit log entry cannot be nil")
+	}
+
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+// FindPaginated retrieves matching entries, most recent first
+func (r *auditLogRepository) FindPaginated(filter entities.AuditLogFilter, page, size int) ([]entities.AuditLog, int64, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []entities.AuditLog
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		entry := r.entries[i]
+		switch {
+		case filter.EntityID != nil && entry.EntityID != *filter.EntityID,
+			filter.From != nil && entry.CreatedAt.Before(*filter.From),
+			filter.To != nil && !entry.CreatedAt.Before(*filter.To):
+			continue
+		}
+		matched = append(matched, entry)
+	}
+
+	offset := (page - 1) * size
+	if offset >= len(matched) {
+		return []entities.AuditLog{}, int64(len(matched)), nil
+	}
+	return matched[offset:min(offset+size, len(matched))], int64(len(matched)), nil
+}
@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// budgetRepository implements BudgetRepository interface using an in-process map
+type budgetRepository struct {
+	mu      sync.RWMutex
+	budgets map[uuid.UUID]entities.Budget
+}
+
+// NewBudgetRepository creates a new in-memory implementation of BudgetRepository
+func NewBudgetRepository() repositories.BudgetRepository {
+	return &budgetRepository{
+		budgets: make(map[uuid.UUID]entities.Budget),
+	}
+}
+
+// Save persists a new budget in memory
+func (r *budgetRepository) Save(budget *entities.Budget) error {
+	if budget == nil {
+		return errors.New("budget cannot be nil")
+	}
+
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.budgets[budget.ID]; exists {
+		return errors.New("budget already exists")
+	}
+
+	now := time.Now()
+	budget.CreatedAt = now
+	budget.UpdatedAt = now
+	r.budgets[budget.ID] = *budget
+	return nil
+}
+
+// GetByID retrieves a budget by its unique identifier
+func (r *budgetRepository) GetByID(id uuid.UUID) (*entities.Budget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	budget, exists := r.budgets[id]
+	if !exists {
+		return nil, nil // Return nil, nil when not found (as per interface contract)
+	}
+
+	return &budget, nil
+}
+
+// GetAll retrieves every budget ordered by category, then period
+func (r *budgetRepository) GetAll() ([]entities.Budget, error) {
+	return r.find(func(entities.Budget) bool { return true }), nil
+}
+
+// FindByCategory retrieves the budgets that track a category
+func (r *budgetRepository) FindByCategory(category string) ([]entities.Budget, error) {
+	return r.find(func(budget entities.Budget) bool { return budget.Category == category }), nil
+}
+
+// RenameCategory moves every budget of a category to another category name
+func (r *budgetRepository) RenameCategory(from, to string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var moved int64
+	for id, budget := range r.budgets {
+		if budget.Category == from {
+			budget.Category = to
+			budget.UpdatedAt = time.Now()
+			r.budgets[id] = budget
+			moved++
+		}
+	}
+	return moved, nil
+}
+
+// Update replaces a stored budget, keeping its creation time
+func (r *budgetRepository) Update(budget *entities.Budget) error {
+	if budget == nil {
+		return errors.New("budget cannot be nil")
+	}
+
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.budgets[budget.ID]
+	if !exists {
+		return errs.Newf(errs.ErrNotFound, "budget with ID %s not found", budget.ID)
+	}
+
+	budget.CreatedAt = existing.CreatedAt
+	budget.UpdatedAt = time.Now()
+	r.budgets[budget.ID] = *budget
+	return nil
+}
+
+// Delete removes a budget
+func (r *budgetRepository) Delete(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.budgets[id]; !exists {
+		return errs.Newf(errs.ErrNotFound, "budget with ID %s not found", id)
+	}
+
+	delete(r.budgets, id)
+	return nil
+}
+
+// find returns matching budgets ordered like the GORM implementation
+func (r *budgetRepository) find(match func(entities.Budget) bool) []entities.Budget {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var budgets []entities.Budget
+	for _, budget := range r.budgets {
+		if match(budget) {
+			budgets = append(budgets, budget)
+		}
+	}
+
+	sort.Slice(budgets, func(i, j int) bool {
+		if budgets[i].Category != budgets[j].Category {
+			return budgets[i].Category < budgets[j].Category
+		}
+		if budgets[i].Period != budgets[j].Period {
+			return budgets[i].Period < budgets[j].Period
+		}
+		return budgets[i].CreatedAt.Before(budgets[j].CreatedAt)
+	})
+	return budgets
+}
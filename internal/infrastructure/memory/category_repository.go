@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// categoryRepository implements CategoryRepository interface using an in-process map
+type categoryRepository struct {
+	mu         sync.RWMutex
+	categories map[uuid.UUID]entities.Category
+}
+
+// NewCategoryRepository creates a new in-memory implementation of CategoryRepository
+func NewCategoryRepository() repositories.CategoryRepository {
+	return &categoryRepository{
+		categories: make(map[uuid.UUID]entities.Category),
+	}
+}
+
+// Save persists a new category in memory
+func (r *categoryRepository) Save(category *entities.Category) error {
+	if category == nil {
+		return errors.New("category cannot be nil")
+	}
+
+	if err := category.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.categories[category.ID]; exists {
+		return errors.New("category already exists")
+	}
+	if existing := r.byName(category.Name); existing != nil {
+		return errs.Newf(errs.ErrConflict, "category %q already exists", existing.Name)
+	}
+
+	now := time.Now()
+	category.CreatedAt = now
+	category.UpdatedAt = now
+	r.categories[category.ID] = *category
+	return nil
+}
+
+// GetByID retrieves a category by its unique identifier
+func (r *categoryRepository) GetByID(id uuid.UUID) (*entities.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, exists := r.categories[id]
+	if !exists {
+		return nil, nil // Return nil, nil when not found (as per interface contract)
+	}
+
+	return &category, nil
+}
+
+// GetByName retrieves a category by name, ignoring case
+func (r *categoryRepository) GetByName(name string) (*entities.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.byName(name), nil
+}
+
+// GetAll retrieves every category ordered by name
+func (r *categoryRepository) GetAll() ([]entities.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make([]entities.Category, 0, len(r.categories))
+	for _, category := range r.categories {
+		categories = append(categories, category)
+	}
+
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
+
+// Update replaces a stored category, keeping its creation time
+func (r *categoryRepository) Update(category *entities.Category) error {
+	if category == nil {
+		return errors.New("category cannot be nil")
+	}
+
+	if err := category.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.categories[category.ID]
+	if !exists {
+		return errs.Newf(errs.ErrNotFound, "category with ID %s not found", category.ID)
+	}
+	if other := r.byName(category.Name); other != nil && other.ID != category.ID {
+		return errs.Newf(errs.ErrConflict, "category %q already exists", other.Name)
+	}
+
+	category.CreatedAt = existing.CreatedAt
+	category.UpdatedAt = time.Now()
+	r.categories[category.ID] = *category
+	return nil
+}
+
+// Delete removes a category
+func (r *categoryRepository) Delete(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.categories[id]; !exists {
+		return errs.Newf(errs.ErrNotFound, "category with ID %s not found", id)
+	}
+
+	delete(r.categories, id)
+	return nil
+}
+
+// byName finds a category by name ignoring case; the caller holds the lock
+func (r *categoryRepository) byName(name string) *entities.Category {
+	for _, category := range r.categories {
+		if strings.EqualFold(category.Name, name) {
+			return &category
+		}
+	}
+	return nil
+}
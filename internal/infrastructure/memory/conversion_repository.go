@@ -0,0 +1,316 @@
+package memory
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// conversionRecordRepository implements ConversionRecordRepository interface using an in-process map
+type conversionRecordRepository struct {
+	mu      sync.RWMutex
+	records map[uuid.UUID]entities.ConversionRecord
+}
+
+// NewConversionRecordRepository creates a new in-memory implementation of ConversionRecordRepository
+func NewConversionRecordRepository() repositories.ConversionRecordRepository {
+	return &conversionRecordRepository{
+		records: make(map[uuid.UUID]entities.ConversionRecord),
+	}
+}
+
+// SaveAll stores the records, rejecting the whole set if any ID already exists
+func (r *conversionRecordRepository) SaveAll(records []entities.ConversionRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, record := range records {
+		if _, exists := r.records[record.ID]; exists {
+			return errors.New("conversion record already exists")
+		}
+	}
+	for _, record := range records {
+		r.records[record.ID] = record
+	}
+	return nil
+}
+
+// GetByBatchPaginated retrieves the records produced by a batch run, ordered by transaction date
+func (r *conversionRecordRepository) GetByBatchPaginated(batchID uuid.UUID, page, size int) ([]entities.ConversionRecord, int64, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	r.mu.RLock()
+	matching := make([]entities.ConversionRecord, 0)
+	for _, record := range r.records {
+		if record.BatchID != nil && *record.BatchID == batchID && !record.IsSuperseded() {
+			matching = append(matching, record)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matching, func(i, j int) bool {
+		if !matching[i].TransactionDate.Equal(matching[j].TransactionDate) {
+			return matching[i].TransactionDate.Before(matching[j].TransactionDate)
+		}
+		return matching[i].ID.String() < matching[j].ID.String()
+	})
+
+	start := (page - 1) * size
+	if start >= len(matching) {
+		return []entities.ConversionRecord{}, int64(len(matching)), nil
+	}
+	end := start + size
+	if end > len(matching) {
+		end = len(matching)
+	}
+	return matching[start:end], int64(len(matching)), nil
+}
+
+// FindSupersedable returns current records in currency that a rate effective on effectiveDate would improve
+func (r *conversionRecordRepository) FindSupersedable(currency entities.CurrencyCode, effectiveDate time.Time) ([]entities.ConversionRecord, error) {
+	r.mu.RLock()
+	matching := make([]entities.ConversionRecord, 0)
+	for _, record := range r.records {
+		if record.TargetCurrency == currency && !record.IsSuperseded() &&
+			record.EffectiveDate.Before(effectiveDate) && !record.TransactionDate.Before(effectiveDate) {
+			matching = append(matching, record)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matching, func(i, j int) bool {
+		if !matching[i].TransactionDate.Equal(matching[j].TransactionDate) {
+			return matching[i].TransactionDate.Before(matching[j].TransactionDate)
+		}
+		return matching[i].ID.String() < matching[j].ID.String()
+	})
+	return matching, nil
+}
+
+// Supersede stores replacement and marks previous as superseded by it
+func (r *conversionRecordRepository) Supersede(previous *entities.ConversionRecord, replacement *entities.ConversionRecord) error {
+	if previous == nil || replacement == nil {
+		return errors.New("conversion records cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.records[previous.ID]
+	if !exists || stored.IsSuperseded() {
+		return errs.Newf(errs.ErrNotFound, "conversion record not found or already superseded")
+	}
+	if _, exists := r.records[replacement.ID]; exists {
+		return errors.New("conversion record already exists")
+	}
+
+	supersededAt := time.Now().UTC()
+	stored.SupersededAt = &supersededAt
+	stored.SupersededBy = &replacement.ID
+	r.records[previous.ID] = stored
+	r.records[replacement.ID] = *replacement
+
+	previous.SupersededAt = stored.SupersededAt
+	previous.SupersededBy = stored.SupersededBy
+	return nil
+}
+
+// Sample returns up to limit current records in random order
+func (r *conversionRecordRepository) Sample(limit int) ([]entities.ConversionRecord, error) {
+	r.mu.RLock()
+	current := make([]entities.ConversionRecord, 0, len(r.records))
+	for _, record := range r.records {
+		if !record.IsSuperseded() {
+			current = append(current, record)
+		}
+	}
+	r.mu.RUnlock()
+
+	rand.Shuffle(len(current), func(i, j int) { current[i], current[j] = current[j], current[i] })
+	if limit >= 0 && len(current) > limit {
+		current = current[:limit]
+	}
+	return current, nil
+}
+
+// conversionBatchRepository implements ConversionBatchRepository interface using an in-process map
+type conversionBatchRepository struct {
+	mu      sync.RWMutex
+	batches map[uuid.UUID]entities.ConversionBatch
+}
+
+// NewConversionBatchRepository creates a new in-memory implementation of ConversionBatchRepository
+func NewConversionBatchRepository() repositories.ConversionBatchRepository {
+	return &conversionBatchRepository{
+		batches: make(map[uuid.UUID]entities.ConversionBatch),
+	}
+}
+
+// Save persists a new batch in memory
+func (r *conversionBatchRepository) Save(batch *entities.ConversionBatch) error {
+	if batch == nil {
+		return errors.New("batch cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.batches[batch.ID]; exists {
+		return errors.New("conversion batch already exists")
+	}
+	r.batches[batch.ID] = *batch
+	return nil
+}
+
+// Update stores the batch's current status and progress counters
+func (r *conversionBatchRepository) Update(batch *entities.ConversionBatch) error {
+	if batch == nil {
+		return errors.New("batch cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.batches[batch.ID]; !exists {
+		return errs.Newf(errs.ErrNotFound, "conversion batch not found")
+	}
+	r.batches[batch.ID] = *batch
+	return nil
+}
+
+// GetByID retrieves a batch by its unique identifier
+func (r *conversionBatchRepository) GetByID(id uuid.UUID) (*entities.ConversionBatch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	batch, exists := r.batches[id]
+	if !exists {
+		return nil, nil
+	}
+	return &batch, nil
+}
+
+// conversionHistoryRepository implements ConversionHistoryRepository interface using an in-process slice
+type conversionHistoryRepository struct {
+	mu          sync.RWMutex
+	conversions []entities.Conversion // In insertion order
+}
+
+// NewConversionHistoryRepository creates a new in-memory implementation of ConversionHistoryRepository
+func NewConversionHistoryRepository() repositories.ConversionHistoryRepository {
+	return &conversionHistoryRepository{}
+}
+
+// SaveAll appends the conversions, rejecting the whole set if any ID already exists
+func (r *conversionHistoryRepository) SaveAll(conversions []entities.Conversion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, conversion := range conversions {
+		for _, stored := range r.conversions {
+			if stored.ID == conversion.ID {
+				return errors.New("conversion already exists")
+			}
+		}
+	}
+	r.conversions = append(r.conversions, conversions...)
+	return nil
+}
+
+// GetByTransactionPaginated retrieves the conversions of a transaction, most recent first
+func (r *conversionHistoryRepository) GetByTransactionPaginated(transactionID uuid.UUID, page, size int) ([]entities.Conversion, int64, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	r.mu.RLock()
+	matching := make([]entities.Conversion, 0)
+	for _, conversion := range r.conversions {
+		if conversion.TransactionID == transactionID {
+			matching = append(matching, conversion)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].CreatedAt.After(matching[j].CreatedAt)
+	})
+
+	start := (page - 1) * size
+	if start >= len(matching) {
+		return []entities.Conversion{}, int64(len(matching)), nil
+	}
+	return matching[start:min(start+size, len(matching))], int64(len(matching)), nil
+}
+
+// ForEach streams all conversions in insertion order, in batches of batchSize
+func (r *conversionHistoryRepository) ForEach(batchSize int, fn func(batch []entities.Conversion) error) error {
+	if fn == nil {
+		return errors.New("batch callback cannot be nil")
+	}
+	if batchSize < 1 {
+		batchSize = defaultBatchSize
+	}
+
+	r.mu.RLock()
+	all := slices.Clone(r.conversions)
+	r.mu.RUnlock()
+
+	for start := 0; start < len(all); start += batchSize {
+		end := min(start+batchSize, len(all))
+		if err := fn(all[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update replaces a stored conversion
+func (r *conversionHistoryRepository) Update(conversion *entities.Conversion) error {
+	if conversion == nil {
+		return errors.New("conversion cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.conversions {
+		if r.conversions[i].ID == conversion.ID {
+			r.conversions[i] = *conversion
+			return nil
+		}
+	}
+	return errs.Newf(errs.ErrNotFound, "conversion not found")
+}
+
+// Exists checks if a conversion with the given ID exists
+func (r *conversionHistoryRepository) Exists(id uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, conversion := range r.conversions {
+		if conversion.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,279 @@
+package memory
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// exchangeRateRepository implements ExchangeRateRepository interface using an in-process map
+type exchangeRateRepository struct {
+	mu    sync.RWMutex
+	rates map[uuid.UUID]entities.ExchangeRate
+}
+
+// NewExchangeRateRepository creates a new in-memory implementation of ExchangeRateRepository
+func NewExchangeRateRepository() repositories.ExchangeRateRepository {
+	return &exchangeRateRepository{
+		rates: make(map[uuid.UUID]entities.ExchangeRate),
+	}
+}
+
+// Save persists an exchange rate in memory
+func (r *exchangeRateRepository) Save(exchangeRate *entities.ExchangeRate) error {
+	if exchangeRate == nil {
+		return errors.New("exchange rate cannot be nil")
+	}
+
+	// Validate exchange rate before saving
+	if err := exchangeRate.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.rates[exchangeRate.ID]; exists {
+		return errors.New("exchange rate already exists")
+	}
+
+	if exchangeRate.CreatedAt.IsZero() {
+		exchangeRate.CreatedAt = time.Now()
+	}
+
+	r.rates[exchangeRate.ID] = *exchangeRate
+	return nil
+}
+
+// SaveAll stores the exchange rates, rejecting the whole set if any is invalid or its ID already exists
+func (r *exchangeRateRepository) SaveAll(exchangeRates []entities.ExchangeRate) error {
+	for i := range exchangeRates {
+		if err := exchangeRates[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[uuid.UUID]bool, len(exchangeRates))
+	for _, exchangeRate := range exchangeRates {
+		if _, exists := r.rates[exchangeRate.ID]; exists || seen[exchangeRate.ID] {
+			return errors.New("exchange rate already exists")
+		}
+		seen[exchangeRate.ID] = true
+	}
+
+	now := time.Now()
+	for i := range exchangeRates {
+		if exchangeRates[i].CreatedAt.IsZero() {
+			exchangeRates[i].CreatedAt = now
+		}
+		r.rates[exchangeRates[i].ID] = exchangeRates[i]
+	}
+	return nil
+}
+
+// GetByID retrieves an exchange rate by its unique identifier
+func (r *exchangeRateRepository) GetByID(id uuid.UUID) (*entities.ExchangeRate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	exchangeRate, exists := r.rates[id]
+	if !exists {
+		return nil, nil // Return nil, nil when not found (as per interface contract)
+	}
+
+	return &exchangeRate, nil
+}
+
+// FindRateForConversion finds the most suitable exchange rate for currency conversion
+// Must comply with the lookback rule: rate date <= transaction date and within window before it
+func (r *exchangeRateRepository) FindRateForConversion(from, to entities.CurrencyCode, transactionDate time.Time, window entities.RateWindow) (*entities.ExchangeRate, error) {
+	windowStart := window.Start(transactionDate)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var best *entities.ExchangeRate
+	for _, rate := range r.rates {
+		if rate.FromCurrency != from || rate.ToCurrency != to {
+			continue
+		}
+		if rate.EffectiveDate.After(transactionDate) || rate.EffectiveDate.Before(windowStart) {
+			continue
+		}
+		if best == nil || rate.EffectiveDate.After(best.EffectiveDate) {
+			candidate := rate
+			best = &candidate
+		}
+	}
+
+	return best, nil
+}
+
+// FindSurroundingRates finds the nearest rates on either side of a date within a window of months
+func (r *exchangeRateRepository) FindSurroundingRates(from, to entities.CurrencyCode, date time.Time, windowMonths int) (*entities.ExchangeRate, *entities.ExchangeRate, error) {
+	earliest := date.AddDate(0, -windowMonths, 0)
+	latest := date.AddDate(0, windowMonths, 0)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var before, after *entities.ExchangeRate
+	for _, rate := range r.rates {
+		if rate.FromCurrency != from || rate.ToCurrency != to {
+			continue
+		}
+
+		candidate := rate
+		switch {
+		case !rate.EffectiveDate.After(date) && !rate.EffectiveDate.Before(earliest):
+			if before == nil || rate.EffectiveDate.After(before.EffectiveDate) {
+				before = &candidate
+			}
+		case rate.EffectiveDate.After(date) && !rate.EffectiveDate.After(latest):
+			if after == nil || rate.EffectiveDate.Before(after.EffectiveDate) {
+				after = &candidate
+			}
+		}
+	}
+
+	return before, after, nil
+}
+
+// ForEach streams all exchange rates in batches ordered by ID
+func (r *exchangeRateRepository) ForEach(batchSize int, fn func(batch []entities.ExchangeRate) error) error {
+	if fn == nil {
+		return errors.New("batch callback cannot be nil")
+	}
+	if batchSize < 1 {
+		batchSize = defaultBatchSize
+	}
+
+	r.mu.RLock()
+	all := make([]entities.ExchangeRate, 0, len(r.rates))
+	for _, rate := range r.rates {
+		all = append(all, rate)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(all, func(i, j int) bool { return all[i].ID.String() < all[j].ID.String() })
+
+	for start := 0; start < len(all); start += batchSize {
+		end := min(start+batchSize, len(all))
+		if err := fn(all[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update modifies an existing exchange rate
+func (r *exchangeRateRepository) Update(exchangeRate *entities.ExchangeRate) error {
+	if exchangeRate == nil {
+		return errors.New("exchange rate cannot be nil")
+	}
+
+	// Validate exchange rate before updating
+	if err := exchangeRate.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.rates[exchangeRate.ID]; !exists {
+		return errs.Newf(errs.ErrNotFound, "exchange rate not found")
+	}
+
+	r.rates[exchangeRate.ID] = *exchangeRate
+	return nil
+}
+
+// Delete removes an exchange rate by ID
+func (r *exchangeRateRepository) Delete(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.rates[id]; !exists {
+		return errs.Newf(errs.ErrNotFound, "exchange rate not found")
+	}
+
+	delete(r.rates, id)
+	return nil
+}
+
+// SummarizeByCurrency counts the cached rates of each target currency, ordered by currency
+func (r *exchangeRateRepository) SummarizeByCurrency() ([]entities.RateCacheSummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byCurrency := make(map[entities.CurrencyCode]*entities.RateCacheSummary)
+	for _, rate := range r.rates {
+		summary, exists := byCurrency[rate.ToCurrency]
+		if !exists {
+			summary = &entities.RateCacheSummary{
+				Currency:            rate.ToCurrency,
+				OldestEffectiveDate: rate.EffectiveDate,
+				NewestEffectiveDate: rate.EffectiveDate,
+			}
+			byCurrency[rate.ToCurrency] = summary
+		}
+		summary.Rates++
+		if rate.EffectiveDate.Before(summary.OldestEffectiveDate) {
+			summary.OldestEffectiveDate = rate.EffectiveDate
+		}
+		if rate.EffectiveDate.After(summary.NewestEffectiveDate) {
+			summary.NewestEffectiveDate = rate.EffectiveDate
+		}
+	}
+
+	summaries := make([]entities.RateCacheSummary, 0, len(byCurrency))
+	for _, summary := range byCurrency {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Currency < summaries[j].Currency })
+	return summaries, nil
+}
+
+// Purge deletes cached rates to a currency, or to every currency when currency is empty
+func (r *exchangeRateRepository) Purge(currency entities.CurrencyCode, effectiveDate *time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var day time.Time
+	if effectiveDate != nil {
+		day = effectiveDate.UTC().Truncate(24 * time.Hour)
+	}
+
+	var purged int64
+	for id, rate := range r.rates {
+		if currency != "" && rate.ToCurrency != currency {
+			continue
+		}
+		if effectiveDate != nil && (rate.EffectiveDate.Before(day) || !rate.EffectiveDate.Before(day.AddDate(0, 0, 1))) {
+			continue
+		}
+		delete(r.rates, id)
+		purged++
+	}
+
+	return purged, nil
+}
+
+// Exists checks if an exchange rate with the given ID exists
+func (r *exchangeRateRepository) Exists(id uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.rates[id]
+	return exists, nil
+}
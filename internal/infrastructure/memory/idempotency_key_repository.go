@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// idempotencyKeyRepository implements IdempotencyKeyRepository interface using an in-process map
+type idempotencyKeyRepository struct {
+	mu   sync.Mutex
+	keys map[[2]string]entities.IdempotencyKey // (scope, key) -> record
+}
+
+// NewIdempotencyKeyRepository creates a new in-memory implementation of IdempotencyKeyRepository
+func NewIdempotencyKeyRepository() repositories.IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{
+		keys: make(map[[2]string]entities.IdempotencyKey),
+	}
+}
+
+// Reserve stores the key unless its scope already holds it
+func (r *idempotencyKeyRepository) Reserve(key *entities.IdempotencyKey) (bool, error) {
+	if key == nil {
+		return false, errors.New("idempotency key cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := [2]string{key.Scope, key.Key}
+	if _, exists := r.keys[index]; exists {
+		return false, nil
+	}
+
+	r.keys[index] = *key
+	return true, nil
+}
+
+// Find retrieves a key by scope and header value
+func (r *idempotencyKeyRepository) Find(scope, key string) (*entities.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, exists := r.keys[[2]string{scope, key}]
+	if !exists {
+		return nil, nil // Return nil, nil when not found (as per interface contract)
+	}
+
+	return &record, nil
+}
+
+// Complete stores the transaction and response of a reserved key
+func (r *idempotencyKeyRepository) Complete(key *entities.IdempotencyKey) error {
+	if key == nil {
+		return errors.New("idempotency key cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := [2]string{key.Scope, key.Key}
+	stored, exists := r.keys[index]
+	if !exists || stored.ID != key.ID {
+		return errs.Newf(errs.ErrNotFound, "idempotency key with ID %s not found", key.ID)
+	}
+
+	stored.TransactionID = key.TransactionID
+	stored.Response = key.Response
+	r.keys[index] = stored
+	return nil
+}
+
+// Delete removes a key
+func (r *idempotencyKeyRepository) Delete(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for index, record := range r.keys {
+		if record.ID == id {
+			delete(r.keys, index)
+		}
+	}
+	return nil
+}
+
+// DeleteExpired removes keys that expired before the given time
+func (r *idempotencyKeyRepository) DeleteExpired(before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var removed int64
+	for index, record := range r.keys {
+		if record.ExpiresAt.Before(before) {
+			delete(r.keys, index)
+			removed++
+		}
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// outboxRepository implements OutboxRepository interface using an in-process map
+type outboxRepository struct {
+	mu       sync.Mutex
+	messages map[uuid.UUID]entities.OutboxMessage
+}
+
+// NewOutboxRepository creates a new in-memory implementation of OutboxRepository
+func NewOutboxRepository() repositories.OutboxRepository {
+	return &outboxRepository{
+		messages: make(map[uuid.UUID]entities.OutboxMessage),
+	}
+}
+
+// Save persists a new outbox message in memory
+func (r *outboxRepository) Save(message *entities.OutboxMessage) error {
+	if message == nil {
+		return errors.New("outbox message cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.messages[message.ID]; exists {
+		return errors.New("outbox message already exists")
+	}
+
+	message.CreatedAt = time.Now()
+	r.messages[message.ID] = *message
+	return nil
+}
+
+// ClaimDue leases due unpublished messages, oldest first
+func (r *outboxRepository) ClaimDue(now time.Time, leaseUntil time.Time, limit int) ([]entities.OutboxMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []entities.OutboxMessage
+	for _, message := range r.messages {
+		if !message.IsPublished() && !message.NextAttemptAt.After(now) {
+			due = append(due, message)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].CreatedAt.Before(due[j].CreatedAt)
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	for i := range due {
+		due[i].NextAttemptAt = leaseUntil
+		r.messages[due[i].ID] = due[i]
+	}
+	return due, nil
+}
+
+// MarkPublished records that the broker accepted the message
+func (r *outboxRepository) MarkPublished(id uuid.UUID, publishedAt time.Time) error {
+	return r.update(id, func(message *entities.OutboxMessage) {
+		message.PublishedAt = &publishedAt
+		message.Attempts++
+		message.LastError = ""
+	})
+}
+
+// MarkFailed records a failed attempt and when the message is due again
+func (r *outboxRepository) MarkFailed(id uuid.UUID, reason string, retryAt time.Time) error {
+	return r.update(id, func(message *entities.OutboxMessage) {
+		message.NextAttemptAt = retryAt
+		message.Attempts++
+		message.LastError = reason
+	})
+}
+
+// Reschedule makes a claimed message due again at without counting an attempt
+func (r *outboxRepository) Reschedule(id uuid.UUID, at time.Time) error {
+	return r.update(id, func(message *entities.OutboxMessage) {
+		message.NextAttemptAt = at
+	})
+}
+
+// CountPending counts the messages not yet published
+func (r *outboxRepository) CountPending() (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for _, message := range r.messages {
+		if !message.IsPublished() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// DeletePublishedBefore removes messages published before cutoff
+func (r *outboxRepository) DeletePublishedBefore(cutoff time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for id, message := range r.messages {
+		if message.IsPublished() && message.PublishedAt.Before(cutoff) {
+			delete(r.messages, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// update applies change to a stored message
+func (r *outboxRepository) update(id uuid.UUID, change func(message *entities.OutboxMessage)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message, exists := r.messages[id]
+	if !exists {
+		return errs.Newf(errs.ErrNotFound, "outbox message with ID %s not found", id)
+	}
+	change(&message)
+	r.messages[id] = message
+	return nil
+}
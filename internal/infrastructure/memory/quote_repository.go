@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// quoteRepository implements QuoteRepository interface using an in-process map
+type quoteRepository struct {
+	mu     sync.RWMutex
+	quotes map[uuid.UUID]entities.RateQuote
+}
+
+// NewQuoteRepository creates a new in-memory implementation of QuoteRepository
+func NewQuoteRepository() repositories.QuoteRepository {
+	return &quoteRepository{
+		quotes: make(map[uuid.UUID]entities.RateQuote),
+	}
+}
+
+// Save persists a rate quote in memory
+func (r *quoteRepository) Save(quote *entities.RateQuote) error {
+	if quote == nil {
+		return errors.New("quote cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.quotes[quote.ID]; exists {
+		return errors.New("quote already exists")
+	}
+
+	r.quotes[quote.ID] = *quote
+	return nil
+}
+
+// GetByID retrieves a rate quote by its unique identifier
+func (r *quoteRepository) GetByID(id uuid.UUID) (*entities.RateQuote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	quote, exists := r.quotes[id]
+	if !exists {
+		return nil, nil // Return nil, nil when not found (as per interface contract)
+	}
+
+	return &quote, nil
+}
+
+// DeleteExpired removes quotes that expired before the given time
+func (r *quoteRepository) DeleteExpired(before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var removed int64
+	for id, quote := range r.quotes {
+		if quote.ExpiresAt.Before(before) {
+			delete(r.quotes, id)
+			removed++
+		}
+	}
+
+	return removed, nil
+}
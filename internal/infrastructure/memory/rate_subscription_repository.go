@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// rateSubscriptionKey identifies a subscription the way the GORM unique index does
+type rateSubscriptionKey struct {
+	subscriber string
+	currency   entities.CurrencyCode
+}
+
+// rateSubscriptionRepository implements RateSubscriptionRepository interface using an in-process map
+type rateSubscriptionRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[rateSubscriptionKey]entities.RateSubscription
+}
+
+// NewRateSubscriptionRepository creates a new in-memory implementation of RateSubscriptionRepository
+func NewRateSubscriptionRepository() repositories.RateSubscriptionRepository {
+	return &rateSubscriptionRepository{
+		subscriptions: make(map[rateSubscriptionKey]entities.RateSubscription),
+	}
+}
+
+// Save persists a subscription, loading the stored one into it when the subscriber is already subscribed
+func (r *rateSubscriptionRepository) Save(subscription *entities.RateSubscription) error {
+	if subscription == nil {
+		return errors.New("rate subscription cannot be nil")
+	}
+
+	if err := subscription.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := rateSubscriptionKey{subscriber: subscription.Subscriber, currency: subscription.Currency}
+	if existing, exists := r.subscriptions[key]; exists {
+		*subscription = existing
+		return nil
+	}
+
+	if subscription.ID == uuid.Nil {
+		subscription.ID = uuid.New()
+	}
+	subscription.CreatedAt = time.Now()
+	r.subscriptions[key] = *subscription
+	return nil
+}
+
+// FindBySubscriber retrieves a subscriber's subscriptions ordered by currency
+func (r *rateSubscriptionRepository) FindBySubscriber(subscriber string) ([]entities.RateSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var subscriptions []entities.RateSubscription
+	for key, subscription := range r.subscriptions {
+		if key.subscriber == subscriber {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].Currency < subscriptions[j].Currency
+	})
+	return subscriptions, nil
+}
+
+// SubscribedCurrencies returns every currency with at least one subscriber, ordered by code
+func (r *rateSubscriptionRepository) SubscribedCurrencies() ([]entities.CurrencyCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[entities.CurrencyCode]bool)
+	var currencies []entities.CurrencyCode
+	for key := range r.subscriptions {
+		if !seen[key.currency] {
+			seen[key.currency] = true
+			currencies = append(currencies, key.currency)
+		}
+	}
+
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i] < currencies[j] })
+	return currencies, nil
+}
+
+// LastSynced returns when each subscribed currency was last synced
+func (r *rateSubscriptionRepository) LastSynced() (map[entities.CurrencyCode]time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	synced := make(map[entities.CurrencyCode]time.Time)
+	for key, subscription := range r.subscriptions {
+		if subscription.LastSyncedAt == nil {
+			continue
+		}
+		if last, ok := synced[key.currency]; !ok || subscription.LastSyncedAt.After(last) {
+			synced[key.currency] = *subscription.LastSyncedAt
+		}
+	}
+	return synced, nil
+}
+
+// RecordSync stores the outcome of a sync on every subscription to the currency
+func (r *rateSubscriptionRepository) RecordSync(currency entities.CurrencyCode, syncedAt time.Time, syncError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, subscription := range r.subscriptions {
+		if key.currency == currency {
+			synced := syncedAt
+			subscription.LastSyncedAt = &synced
+			subscription.LastError = syncError
+			r.subscriptions[key] = subscription
+		}
+	}
+	return nil
+}
+
+// Delete removes a subscriber's subscription to a currency
+func (r *rateSubscriptionRepository) Delete(subscriber string, currency entities.CurrencyCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := rateSubscriptionKey{subscriber: subscriber, currency: currency}
+	if _, exists := r.subscriptions[key]; !exists {
+		return errs.Newf(errs.ErrNotFound, "rate subscription to %s not found", currency)
+	}
+
+	delete(r.subscriptions, key)
+	return nil
+}
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// reportRepository implements ReportRepository interface by aggregating an in-memory transaction store
+type reportRepository struct {
+	transactions repositories.TransactionRepository
+}
+
+// NewReportRepository creates a new in-memory implementation of ReportRepository reading transactions
+func NewReportRepository(transactions repositories.TransactionRepository) repositories.ReportRepository {
+	return &reportRepository{
+		transactions: transactions,
+	}
+}
+
+// SummarizeByPeriod aggregates the transactions purchased in [from, to) per period of grouping
+func (r *reportRepository) SummarizeByPeriod(from, to time.Time, grouping entities.SummaryGrouping) ([]entities.PeriodSummary, error) {
+	transactions, err := r.transactions.FindByDateBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transactions come oldest first, so each period's transactions are adjacent
+	summaries := make([]entities.PeriodSummary, 0)
+	for _, transaction := range transactions {
+		period := transaction.Date.UTC().Format(grouping.Layout())
+		last := len(summaries) - 1
+		if last < 0 || summaries[last].Period != period {
+			summaries = append(summaries, entities.PeriodSummary{Period: period, Min: transaction.Amount, Max: transaction.Amount})
+			last++
+		}
+
+		summary := &summaries[last]
+		summary.Count++
+		summary.Total += transaction.Amount
+		summary.Min = min(summary.Min, transaction.Amount)
+		summary.Max = max(summary.Max, transaction.Amount)
+	}
+
+	for i := range summaries {
+		summaries[i].Average = entities.AverageAmount(summaries[i].Total, summaries[i].Count)
+	}
+	return summaries, nil
+}
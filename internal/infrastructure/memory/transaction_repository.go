@@ -0,0 +1,601 @@
+package memory
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// defaultBatchSize is used by ForEach when the caller does not provide a positive batch size
+const defaultBatchSize = 500
+
+// transactionRepository implements TransactionRepository interface using an in-process map
+type transactionRepository struct {
+	mu           sync.RWMutex
+	transactions map[uuid.UUID]entities.Transaction
+	archived     map[uuid.UUID]entities.ArchivedTransaction
+}
+
+// NewTransactionRepository creates a new in-memory implementation of TransactionRepository
+func NewTransactionRepository() repositories.TransactionRepository {
+	return &transactionRepository{
+		transactions: make(map[uuid.UUID]entities.Transaction),
+		archived:     make(map[uuid.UUID]entities.ArchivedTransaction),
+	}
+}
+
+// Save persists a transaction in memory
+func (r *transactionRepository) Save(transaction *entities.Transaction) error {
+	if transaction == nil {
+		return errors.New("transaction cannot be nil")
+	}
+
+	// Validate transaction before saving
+	if err := transaction.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.transactions[transaction.ID]; exists {
+		return errors.New("transaction already exists")
+	}
+
+	now := time.Now()
+	if transaction.CreatedAt.IsZero() {
+		transaction.CreatedAt = now
+	}
+	transaction.UpdatedAt = now
+	if transaction.Version == 0 {
+		transaction.Version = 1
+	}
+
+	r.transactions[transaction.ID] = detach(*transaction)
+	return nil
+}
+
+// SaveAll stores the transactions, rejecting the whole set if any is invalid or its ID already exists
+func (r *transactionRepository) SaveAll(transactions []entities.Transaction) error {
+	for i := range transactions {
+		if err := transactions[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[uuid.UUID]bool, len(transactions))
+	for _, transaction := range transactions {
+		if _, exists := r.transactions[transaction.ID]; exists || seen[transaction.ID] {
+			return errors.New("transaction already exists")
+		}
+		seen[transaction.ID] = true
+	}
+
+	now := time.Now()
+	for i := range transactions {
+		if transactions[i].CreatedAt.IsZero() {
+			transactions[i].CreatedAt = now
+		}
+		transactions[i].UpdatedAt = now
+		if transactions[i].Version == 0 {
+			transactions[i].Version = 1
+		}
+		r.transactions[transactions[i].ID] = detach(transactions[i])
+	}
+	return nil
+}
+
+// GetByID retrieves a transaction by its unique identifier
+func (r *transactionRepository) GetByID(id uuid.UUID) (*entities.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transaction, exists := r.transactions[id]
+	if !exists || transaction.IsDeleted() {
+		return nil, nil // Return nil, nil when not found (as per interface contract)
+	}
+
+	transaction = detach(transaction)
+	return &transaction, nil
+}
+
+// GetByExternalID retrieves a transaction, deleted or not, by its source system ID
+func (r *transactionRepository) GetByExternalID(externalID string) (*entities.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, transaction := range r.transactions {
+		if transaction.ExternalID != nil && *transaction.ExternalID == externalID {
+			transaction = detach(transaction)
+			return &transaction, nil
+		}
+	}
+
+	for _, archived := range r.archived {
+		if archived.ExternalID != nil && *archived.ExternalID == externalID {
+			transaction := detach(archived.Transaction())
+			return &transaction, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetAll retrieves all transactions
+func (r *transactionRepository) GetAll() ([]entities.Transaction, error) {
+	return r.sorted(), nil
+}
+
+// ForEach streams all transactions in batches ordered by ID
+func (r *transactionRepository) ForEach(batchSize int, fn func(batch []entities.Transaction) error) error {
+	if fn == nil {
+		return errors.New("batch callback cannot be nil")
+	}
+	if batchSize < 1 {
+		batchSize = defaultBatchSize
+	}
+
+	all := r.snapshot(false)
+	sort.Slice(all, func(i, j int) bool { return all[i].ID.String() < all[j].ID.String() })
+
+	for start := 0; start < len(all); start += batchSize {
+		end := min(start+batchSize, len(all))
+		if err := fn(all[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetAllPaginated retrieves transactions with pagination support
+func (r *transactionRepository) GetAllPaginated(page, size int) ([]entities.Transaction, int64, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	all := r.sorted()
+	return paginate(all, page, size), int64(len(all)), nil
+}
+
+// FindPaginated retrieves the transactions matching filter, most recent first
+func (r *transactionRepository) FindPaginated(filter entities.TransactionFilter, page, size int) ([]entities.Transaction, int64, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	var matched []entities.Transaction
+	for _, transaction := range r.sorted() {
+		if matches(filter, transaction) {
+			matched = append(matched, transaction)
+		}
+	}
+
+	return paginate(matched, page, size), int64(len(matched)), nil
+}
+
+// FindAfter retrieves up to size transactions matching filter after cursor, ordered by created_at DESC, id DESC
+func (r *transactionRepository) FindAfter(filter entities.TransactionFilter, cursor *entities.TransactionCursor, size int) ([]entities.Transaction, error) {
+	if size < 1 {
+		size = 20 // Default size
+	}
+
+	page := make([]entities.Transaction, 0, size)
+	for _, transaction := range r.sorted() {
+		if len(page) == size {
+			break
+		}
+		if (cursor == nil || cursor.Precedes(transaction)) && matches(filter, transaction) {
+			page = append(page, transaction)
+		}
+	}
+	return page, nil
+}
+
+// SuggestDescriptions returns the most frequent distinct descriptions matching a prefix (case-insensitive)
+func (r *transactionRepository) SuggestDescriptions(prefix string, limit int) ([]entities.DescriptionSuggestion, error) {
+	lowerPrefix := strings.ToLower(prefix)
+	counts := make(map[string]int64)
+
+	for _, transaction := range r.snapshot(false) {
+		if strings.HasPrefix(strings.ToLower(transaction.Description), lowerPrefix) {
+			counts[transaction.Description]++
+		}
+	}
+
+	suggestions := make([]entities.DescriptionSuggestion, 0, len(counts))
+	for description, count := range counts {
+		suggestions = append(suggestions, entities.DescriptionSuggestion{Description: description, Count: count})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Count != suggestions[j].Count {
+			return suggestions[i].Count > suggestions[j].Count
+		}
+		return suggestions[i].Description < suggestions[j].Description
+	})
+
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions, nil
+}
+
+// Update modifies an existing transaction, provided it is still at transaction.Version
+// The stored version is incremented and copied back to transaction
+func (r *transactionRepository) Update(transaction *entities.Transaction) error {
+	if transaction == nil {
+		return errors.New("transaction cannot be nil")
+	}
+
+	// Validate transaction before updating
+	if err := transaction.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.transactions[transaction.ID]
+	if !exists || existing.IsDeleted() {
+		return errs.Newf(errs.ErrNotFound, "transaction not found")
+	}
+	if existing.Version != transaction.Version {
+		return errs.Newf(errs.ErrConflict, "transaction %s was modified since version %d", transaction.ID, transaction.Version)
+	}
+
+	transaction.Version++
+	transaction.UpdatedAt = time.Now()
+	r.transactions[transaction.ID] = detach(*transaction)
+	return nil
+}
+
+// Delete soft-deletes a transaction by ID
+func (r *transactionRepository) Delete(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	transaction, exists := r.transactions[id]
+	if !exists || transaction.IsDeleted() {
+		return errs.Newf(errs.ErrNotFound, "transaction not found")
+	}
+
+	deletedAt := time.Now()
+	transaction.DeletedAt = &deletedAt
+	r.transactions[id] = transaction
+	return nil
+}
+
+// Restore clears the soft-delete marker of a deleted transaction
+func (r *transactionRepository) Restore(id uuid.UUID) (*entities.Transaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	transaction, exists := r.transactions[id]
+	if !exists || !transaction.IsDeleted() {
+		return nil, errs.Newf(errs.ErrNotFound, "deleted transaction not found")
+	}
+
+	transaction.DeletedAt = nil
+	transaction.UpdatedAt = time.Now()
+	r.transactions[id] = transaction
+
+	transaction = detach(transaction)
+	return &transaction, nil
+}
+
+// GetDeletedByID retrieves a soft-deleted transaction by its unique identifier
+func (r *transactionRepository) GetDeletedByID(id uuid.UUID) (*entities.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transaction, exists := r.transactions[id]
+	if !exists || !transaction.IsDeleted() {
+		return nil, nil // Return nil, nil when not found (as per interface contract)
+	}
+
+	transaction = detach(transaction)
+	return &transaction, nil
+}
+
+// GetDeletedPaginated retrieves soft-deleted transactions, most recently deleted first
+func (r *transactionRepository) GetDeletedPaginated(page, size int) ([]entities.Transaction, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	deleted := r.snapshot(true)
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i].DeletedAt.After(*deleted[j].DeletedAt) })
+
+	return paginate(deleted, page, size), int64(len(deleted)), nil
+}
+
+// FindByDateBetween retrieves transactions whose purchase date is in [from, to), oldest first
+func (r *transactionRepository) FindByDateBetween(from, to time.Time) ([]entities.Transaction, error) {
+	matching := make([]entities.Transaction, 0)
+	for _, transaction := range r.snapshot(false) {
+		if !transaction.Date.Before(from) && transaction.Date.Before(to) {
+			matching = append(matching, transaction)
+		}
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		if !matching[i].Date.Equal(matching[j].Date) {
+			return matching[i].Date.Before(matching[j].Date)
+		}
+		return matching[i].ID.String() < matching[j].ID.String()
+	})
+	return matching, nil
+}
+
+// SummarizeCreatedBetween counts and sums transactions created in [from, to)
+func (r *transactionRepository) SummarizeCreatedBetween(from, to time.Time) (entities.TransactionSummary, error) {
+	var summary entities.TransactionSummary
+	for _, transaction := range r.snapshot(false) {
+		if !transaction.CreatedAt.Before(from) && transaction.CreatedAt.Before(to) {
+			summary.Count++
+			summary.Total += transaction.Amount
+		}
+	}
+	return summary, nil
+}
+
+// SummarizeCategoryBetween counts and sums a category's transactions dated in [from, to)
+func (r *transactionRepository) SummarizeCategoryBetween(category string, from, to time.Time) (entities.TransactionSummary, error) {
+	var summary entities.TransactionSummary
+	for _, transaction := range r.snapshot(false) {
+		if transaction.Category == category && !transaction.Date.Before(from) && transaction.Date.Before(to) {
+			summary.Count++
+			summary.Total += transaction.Amount
+		}
+	}
+	return summary, nil
+}
+
+// CountByCategory counts a category's transactions, including soft-deleted and archived ones
+func (r *transactionRepository) CountByCategory(category string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, transaction := range r.transactions {
+		if transaction.Category == category {
+			count++
+		}
+	}
+	for _, archived := range r.archived {
+		if archived.Category == category {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// RenameCategory moves a category's transactions, including soft-deleted and archived ones, to another name
+func (r *transactionRepository) RenameCategory(from, to string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var moved int64
+	now := time.Now()
+	for id, transaction := range r.transactions {
+		if transaction.Category == from {
+			transaction.Category = to
+			transaction.UpdatedAt = now
+			r.transactions[id] = transaction
+			moved++
+		}
+	}
+	for id, archived := range r.archived {
+		if archived.Category == from {
+			archived.Category = to
+			r.archived[id] = archived
+			moved++
+		}
+	}
+	return moved, nil
+}
+
+// LastModified returns the newest update, deletion or archive time across all stored transactions
+func (r *transactionRepository) LastModified() (time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest time.Time
+	for _, transaction := range r.transactions {
+		if transaction.UpdatedAt.After(latest) {
+			latest = transaction.UpdatedAt
+		}
+		if transaction.IsDeleted() && transaction.DeletedAt.After(latest) {
+			latest = *transaction.DeletedAt
+		}
+	}
+	for _, archived := range r.archived {
+		if archived.ArchivedAt.After(latest) {
+			latest = archived.ArchivedAt
+		}
+	}
+	return latest, nil
+}
+
+// ArchiveDatedBefore moves the oldest live transactions dated before cutoff into the archive
+func (r *transactionRepository) ArchiveDatedBefore(cutoff time.Time, limit int) (int64, error) {
+	if limit < 1 {
+		limit = defaultBatchSize
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []entities.Transaction
+	for _, transaction := range r.transactions {
+		if !transaction.IsDeleted() && transaction.Date.Before(cutoff) {
+			due = append(due, transaction)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].Date.Before(due[j].Date) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	archivedAt := time.Now().UTC()
+	for _, transaction := range due {
+		r.archived[transaction.ID] = entities.NewArchivedTransaction(transaction, archivedAt)
+		delete(r.transactions, transaction.ID)
+	}
+	return int64(len(due)), nil
+}
+
+// PurgeDeletedBefore removes soft-deleted transactions whose deletion happened before cutoff
+func (r *transactionRepository) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for id, transaction := range r.transactions {
+		if transaction.IsDeleted() && transaction.DeletedAt.Before(cutoff) {
+			delete(r.transactions, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// GetArchivedByID retrieves an archived transaction by its unique identifier
+func (r *transactionRepository) GetArchivedByID(id uuid.UUID) (*entities.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	archived, exists := r.archived[id]
+	if !exists {
+		return nil, nil // Return nil, nil when not found (as per interface contract)
+	}
+
+	transaction := detach(archived.Transaction())
+	return &transaction, nil
+}
+
+// GetAllPaginatedWithArchived pages through live transactions followed by archived ones, each most recent first
+func (r *transactionRepository) GetAllPaginatedWithArchived(page, size int) ([]entities.Transaction, int64, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20 // Default size
+	}
+
+	r.mu.RLock()
+	archived := make([]entities.Transaction, 0, len(r.archived))
+	for _, record := range r.archived {
+		archived = append(archived, detach(record.Transaction()))
+	}
+	r.mu.RUnlock()
+	sort.Slice(archived, func(i, j int) bool { return archived[i].CreatedAt.After(archived[j].CreatedAt) })
+
+	all := append(r.sorted(), archived...)
+	return paginate(all, page, size), int64(len(all)), nil
+}
+
+// Exists checks if a transaction with the given ID is stored, soft-deleted ones included
+func (r *transactionRepository) Exists(id uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.transactions[id]
+	return exists, nil
+}
+
+// Count returns the total number of transactions
+func (r *transactionRepository) Count() (int64, error) {
+	return int64(len(r.snapshot(false))), nil
+}
+
+// snapshot copies either the live or the soft-deleted transactions out of the map
+func (r *transactionRepository) snapshot(deleted bool) []entities.Transaction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make([]entities.Transaction, 0, len(r.transactions))
+	for _, transaction := range r.transactions {
+		if transaction.IsDeleted() == deleted {
+			all = append(all, detach(transaction))
+		}
+	}
+	return all
+}
+
+// sorted returns live transactions ordered by created_at DESC (most recent first), then by ID DESC
+func (r *transactionRepository) sorted() []entities.Transaction {
+	all := r.snapshot(false)
+	sort.Slice(all, func(i, j int) bool {
+		if !all[i].CreatedAt.Equal(all[j].CreatedAt) {
+			return all[i].CreatedAt.After(all[j].CreatedAt)
+		}
+		return all[i].ID.String() > all[j].ID.String()
+	})
+	return all
+}
+
+// matches reports whether a transaction satisfies every bound of filter
+func matches(filter entities.TransactionFilter, transaction entities.Transaction) bool {
+	switch {
+	case filter.DateFrom != nil && transaction.Date.Before(*filter.DateFrom),
+		filter.DateTo != nil && !transaction.Date.Before(*filter.DateTo),
+		filter.MinAmount != nil && transaction.Amount < *filter.MinAmount,
+		filter.MaxAmount != nil && transaction.Amount > *filter.MaxAmount,
+		!strings.Contains(strings.ToLower(transaction.Description), strings.ToLower(filter.DescriptionContains)),
+		filter.Category != "" && transaction.Category != filter.Category,
+		filter.Tag != "" && !transaction.HasTag(filter.Tag):
+		return false
+	}
+	return true
+}
+
+// detach copies the tags and external ID of a transaction so the stored copy and the caller's never share memory
+func detach(transaction entities.Transaction) entities.Transaction {
+	transaction.Tags = slices.Clone(transaction.Tags)
+	if transaction.ExternalID != nil {
+		externalID := *transaction.ExternalID
+		transaction.ExternalID = &externalID
+	}
+	if transaction.DeletedAt != nil {
+		deletedAt := *transaction.DeletedAt
+		transaction.DeletedAt = &deletedAt
+	}
+	return transaction
+}
+
+// paginate returns the page of transactions for a 1-based page number
+func paginate(transactions []entities.Transaction, page, size int) []entities.Transaction {
+	offset := (page - 1) * size
+	if offset >= len(transactions) {
+		return []entities.Transaction{}
+	}
+
+	end := min(offset+size, len(transactions))
+	return transactions[offset:end]
+}
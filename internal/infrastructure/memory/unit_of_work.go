@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// unitOfWork serializes units of work over the memory repositories
+// The memory repositories have no transactions, so writes made before fn fails are kept
+type unitOfWork struct {
+	mu    sync.Mutex
+	repos repositories.Repositories
+}
+
+// NewUnitOfWork creates a unit of work over repos
+func NewUnitOfWork(repos repositories.Repositories) repositories.UnitOfWork {
+	return &unitOfWork{repos: repos}
+}
+
+// Do calls fn with the repositories, one unit of work at a time
+func (u *unitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return fn(u.repos)
+}
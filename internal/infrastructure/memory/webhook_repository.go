@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// webhookRepository implements WebhookRepository interface using in-process maps
+type webhookRepository struct {
+	mu         sync.RWMutex
+	webhooks   map[uuid.UUID]entities.Webhook
+	deliveries map[uuid.UUID]entities.WebhookDelivery
+}
+
+// NewWebhookRepository creates a new in-memory implementation of WebhookRepository
+func NewWebhookRepository() repositories.WebhookRepository {
+	return &webhookRepository{
+		webhooks:   make(map[uuid.UUID]entities.Webhook),
+		deliveries: make(map[uuid.UUID]entities.WebhookDelivery),
+	}
+}
+
+// Save persists a new webhook in memory
+func (r *webhookRepository) Save(webhook *entities.Webhook) error {
+	if webhook == nil {
+		return errors.New("webhook cannot be nil")
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.webhooks[webhook.ID]; exists {
+		return errors.New("webhook already exists")
+	}
+
+	webhook.CreatedAt = time.Now()
+	stored := *webhook
+	stored.Events = append([]string(nil), webhook.Events...)
+	r.webhooks[webhook.ID] = stored
+	return nil
+}
+
+// GetByID retrieves a webhook by its unique identifier
+func (r *webhookRepository) GetByID(id uuid.UUID) (*entities.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, exists := r.webhooks[id]
+	if !exists {
+		return nil, nil // Return nil, nil when not found (as per interface contract)
+	}
+
+	return &webhook, nil
+}
+
+// GetAll retrieves every webhook ordered by creation time
+func (r *webhookRepository) GetAll() ([]entities.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks := make([]entities.Webhook, 0, len(r.webhooks))
+	for _, webhook := range r.webhooks {
+		webhooks = append(webhooks, webhook)
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+	return webhooks, nil
+}
+
+// Delete removes a webhook together with its deliveries
+func (r *webhookRepository) Delete(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.webhooks[id]; !exists {
+		return errs.Newf(errs.ErrNotFound, "webhook with ID %s not found", id)
+	}
+
+	delete(r.webhooks, id)
+	for deliveryID, delivery := range r.deliveries {
+		if delivery.WebhookID == id {
+			delete(r.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+// SaveDeliveries persists new deliveries in memory
+func (r *webhookRepository) SaveDeliveries(deliveries []entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for i := range deliveries {
+		deliveries[i].CreatedAt = now
+		r.deliveries[deliveries[i].ID] = deliveries[i]
+	}
+	return nil
+}
+
+// UpdateDelivery stores the outcome of a delivery attempt
+func (r *webhookRepository) UpdateDelivery(delivery *entities.WebhookDelivery) error {
+	if delivery == nil {
+		return errors.New("webhook delivery cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.deliveries[delivery.ID]; !exists {
+		return errs.Newf(errs.ErrNotFound, "webhook delivery with ID %s not found", delivery.ID)
+	}
+
+	r.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+// ClaimDueDeliveries leases due pending deliveries, oldest first
+func (r *webhookRepository) ClaimDueDeliveries(now time.Time, leaseUntil time.Time, limit int) ([]entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []entities.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status == entities.DeliveryPending && delivery.NextAttemptAt != nil && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(*due[j].NextAttemptAt)
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	for i := range due {
+		lease := leaseUntil
+		due[i].NextAttemptAt = &lease
+		r.deliveries[due[i].ID] = due[i]
+	}
+	return due, nil
+}
+
+// FindDeliveries retrieves up to limit deliveries of a webhook, newest first
+func (r *webhookRepository) FindDeliveries(webhookID uuid.UUID, limit int) ([]entities.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var deliveries []entities.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// ArchiveJob periodically moves old transactions into cold storage
+type ArchiveJob struct {
+	useCase  *usecases.ArchiveTransactionsUseCase
+	interval time.Duration
+	logger   *logger.Logger
+}
+
+// NewArchiveJob creates a job that archives due transactions every interval
+func NewArchiveJob(useCase *usecases.ArchiveTransactionsUseCase, interval time.Duration, log *logger.Logger) *ArchiveJob {
+	return &ArchiveJob{
+		useCase:  useCase,
+		interval: interval,
+		logger:   log,
+	}
+}
+
+// Run blocks, archiving immediately and then every interval until ctx is cancelled
+func (j *ArchiveJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.archive(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// archive runs one archival pass and logs how many transactions were moved
+func (j *ArchiveJob) archive(ctx context.Context) {
+	result, err := j.useCase.Execute(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			j.logger.LogError(err, "Transaction archival failed")
+		}
+		return
+	}
+
+	j.logger.LogOperation("archive_transactions", "", true,
+		"cutoff", result.Cutoff.Format(time.DateOnly),
+		"archived", result.Archived,
+	)
+}
@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// BankSyncJob periodically imports purchases from every connected bank account
+type BankSyncJob struct {
+	useCase     *usecases.IngestBankTransactionsUseCase
+	connections []dto.BankConnection
+	interval    time.Duration
+	logger      *logger.Logger
+}
+
+// NewBankSyncJob creates a job that syncs the connections every interval
+func NewBankSyncJob(useCase *usecases.IngestBankTransactionsUseCase, connections []dto.BankConnection, interval time.Duration, log *logger.Logger) *BankSyncJob {
+	return &BankSyncJob{
+		useCase:     useCase,
+		connections: connections,
+		interval:    interval,
+		logger:      log,
+	}
+}
+
+// Run blocks, syncing immediately and then every interval until ctx is cancelled
+func (j *BankSyncJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		for _, connection := range j.connections {
+			if ctx.Err() != nil {
+				return
+			}
+			j.sync(ctx, connection)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync imports one connection; a failing connection does not hold up the others
+func (j *BankSyncJob) sync(ctx context.Context, connection dto.BankConnection) {
+	result, err := j.useCase.Execute(ctx, connection)
+	if err != nil {
+		j.logger.LogError(err, "Bank sync failed", "connection", connection.Name)
+		return
+	}
+
+	j.logger.LogOperation("bank_sync", connection.Name, true,
+		"fetched", result.Fetched,
+		"created", result.Created,
+		"duplicates", result.Duplicates,
+		"skipped", result.Skipped,
+		"failed", result.Failed,
+	)
+}
@@ -0,0 +1,178 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchLimit bounds how far ahead Next looks for a matching minute (a little over four years, for Feb 29)
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// cronField describes the allowed range of one cron field
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// CronSchedule is a parsed five-field cron expression evaluated in UTC
+// Fields are minute, hour, day of month, month and day of week (0 is Sunday; 7 is accepted as Sunday too)
+// Each field accepts *, numbers, ranges (1-5), lists (1,15) and steps (*/15, 0-30/10)
+// As in standard cron, when both day fields are restricted (do not start with *) a day matching either one runs
+type CronSchedule struct {
+	expression string
+	fields     [5]map[int]bool
+	anyDOM     bool
+	anyDOW     bool
+}
+
+// ParseCron parses a five-field cron expression
+func ParseCron(expression string) (*CronSchedule, error) {
+	parts := strings.Fields(expression)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expression, len(parts))
+	}
+
+	schedule := &CronSchedule{
+		expression: strings.Join(parts, " "),
+		anyDOM:     strings.HasPrefix(parts[2], "*"),
+		anyDOW:     strings.HasPrefix(parts[4], "*"),
+	}
+	for i, part := range parts {
+		field := cronFields[i]
+		if i == 4 {
+			field.max = 7 // Sunday as 7
+		}
+		values, err := parseCronField(part, field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+		}
+		if i == 4 && values[7] {
+			values[0] = true
+			delete(values, 7)
+		}
+		schedule.fields[i] = values
+	}
+
+	return schedule, nil
+}
+
+// String returns the normalised expression
+func (s *CronSchedule) String() string {
+	return s.expression
+}
+
+// Next returns the first scheduled minute strictly after t, in UTC
+// It returns the zero time when nothing matches, e.g. for "0 0 30 2 *"
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	next := t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := next.Add(cronSearchLimit)
+
+	for next.Before(limit) {
+		if !s.fields[3][int(next.Month())] {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.fields[1][next.Hour()] {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.fields[0][next.Minute()] {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+
+	return time.Time{}
+}
+
+// matchesDay applies the day of month and day of week fields
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dom := s.fields[2][t.Day()]
+	dow := s.fields[4][int(t.Weekday())]
+	switch {
+	case s.anyDOM && s.anyDOW:
+		return true
+	case s.anyDOM:
+		return dow
+	case s.anyDOW:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// parseCronField expands one field into the set of values it allows
+func parseCronField(part string, field cronField) (map[int]bool, error) {
+	values := make(map[int]bool)
+	for _, item := range strings.Split(part, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			parsed, err := strconv.Atoi(stepPart)
+			if err != nil || parsed < 1 {
+				return nil, fmt.Errorf("%s: invalid step %q", field.name, stepPart)
+			}
+			step = parsed
+		}
+
+		low, high := field.min, field.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseCronValue(from, field); err != nil {
+				return nil, err
+			}
+			if high, err = parseCronValue(to, field); err != nil {
+				return nil, err
+			}
+			if low > high {
+				return nil, fmt.Errorf("%s: range %q is inverted", field.name, rangePart)
+			}
+		default:
+			value, err := parseCronValue(rangePart, field)
+			if err != nil {
+				return nil, err
+			}
+			low = value
+			if !hasStep {
+				high = value
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			values[value] = true
+		}
+	}
+
+	return values, nil
+}
+
+// parseCronValue parses a single number within the field's range
+func parseCronValue(text string, field cronField) (int, error) {
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid value %q", field.name, text)
+	}
+	if value < field.min || value > field.max {
+		return 0, fmt.Errorf("%s: %d is outside %d-%d", field.name, value, field.min, field.max)
+	}
+	return value, nil
+}
@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// DatabaseMonitorJob periodically records database size and row counts
+type DatabaseMonitorJob struct {
+	useCase  *usecases.MonitorDatabaseUseCase
+	interval time.Duration
+	logger   *logger.Logger
+}
+
+// NewDatabaseMonitorJob creates a job that measures the database every interval
+func NewDatabaseMonitorJob(useCase *usecases.MonitorDatabaseUseCase, interval time.Duration, log *logger.Logger) *DatabaseMonitorJob {
+	return &DatabaseMonitorJob{
+		useCase:  useCase,
+		interval: interval,
+		logger:   log,
+	}
+}
+
+// Run blocks, measuring the database immediately and then every interval until ctx is cancelled
+func (j *DatabaseMonitorJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.measure(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// measure logs one set of database metrics
+func (j *DatabaseMonitorJob) measure(ctx context.Context) {
+	usage, err := j.useCase.Execute(ctx)
+	if err != nil {
+		j.logger.LogError(err, "Failed to measure database")
+		return
+	}
+
+	attrs := []any{
+		"size_bytes", usage.SizeBytes,
+		"quota_status", usage.QuotaStatus,
+	}
+	for table, rows := range usage.RowCounts {
+		attrs = append(attrs, "rows_"+table, rows)
+	}
+	j.logger.LogOperation("database_metrics", "", true, attrs...)
+}
@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// DigestJob sends the activity digest on a daily or weekly schedule
+type DigestJob struct {
+	useCase *usecases.SendDigestUseCase
+	period  string
+	hour    int
+	logger  *logger.Logger
+}
+
+// NewDigestJob creates a job that runs at hour (UTC) every day, or every Monday for weekly digests
+func NewDigestJob(useCase *usecases.SendDigestUseCase, period string, hour int, log *logger.Logger) *DigestJob {
+	return &DigestJob{
+		useCase: useCase,
+		period:  period,
+		hour:    hour,
+		logger:  log,
+	}
+}
+
+// Run blocks, sending a digest at every scheduled time until ctx is cancelled
+func (j *DigestJob) Run(ctx context.Context) {
+	last := PreviousRun(time.Now().UTC(), j.period, j.hour)
+
+	for {
+		next := NextRun(time.Now().UTC(), j.period, j.hour)
+		j.logger.Info("Digest scheduled", "period", j.period, "next_run", next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		report, err := j.useCase.Execute(j.period, last, next)
+		if err != nil {
+			j.logger.LogError(err, "Failed to send digest", "period", j.period)
+		} else {
+			j.logger.LogOperation("send_digest", "", true,
+				"period", report.Period,
+				"new_transactions", report.NewTransactions,
+				"conversions", report.Conversions,
+			)
+		}
+		last = next
+	}
+}
+
+// NextRun returns the first scheduled time strictly after now
+func NextRun(now time.Time, period string, hour int) time.Time {
+	run := PreviousRun(now, period, hour)
+	if period == dto.DigestWeekly {
+		return run.AddDate(0, 0, 7)
+	}
+	return run.AddDate(0, 0, 1)
+}
+
+// PreviousRun returns the latest scheduled time at or before now
+func PreviousRun(now time.Time, period string, hour int) time.Time {
+	now = now.UTC()
+	run := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, time.UTC)
+	if run.After(now) {
+		run = run.AddDate(0, 0, -1)
+	}
+
+	if period == dto.DigestWeekly {
+		// Step back to Monday
+		offset := (int(run.Weekday()) + 6) % 7
+		run = run.AddDate(0, 0, -offset)
+	}
+
+	return run
+}
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// OutboxRelayJob periodically publishes the events stored in the outbox to the message broker
+type OutboxRelayJob struct {
+	useCase  *usecases.RelayOutboxUseCase
+	interval time.Duration
+	logger   *logger.Logger
+}
+
+// NewOutboxRelayJob creates a job that relays the outbox every interval
+func NewOutboxRelayJob(useCase *usecases.RelayOutboxUseCase, interval time.Duration, log *logger.Logger) *OutboxRelayJob {
+	return &OutboxRelayJob{
+		useCase:  useCase,
+		interval: interval,
+		logger:   log,
+	}
+}
+
+// Run blocks, relaying immediately and then every interval until ctx is cancelled
+func (j *OutboxRelayJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.relay(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relay runs one pass, logging it only when something was published, failed or purged
+func (j *OutboxRelayJob) relay(ctx context.Context) {
+	result, err := j.useCase.Execute(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			j.logger.LogError(err, "Outbox relay failed")
+		}
+		return
+	}
+	if result.Published == 0 && result.Failed == 0 && result.Purged == 0 {
+		return
+	}
+
+	j.logger.LogOperation("outbox_relay", "", result.Failed == 0,
+		"published", result.Published,
+		"failed", result.Failed,
+		"pending", result.Pending,
+		"purged", result.Purged,
+	)
+}
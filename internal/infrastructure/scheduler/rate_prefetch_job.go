@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// RatePrefetchJob stores the latest rates of the configured currencies on a cron schedule
+type RatePrefetchJob struct {
+	useCase  *usecases.PrefetchRatesUseCase
+	schedule *CronSchedule
+	logger   *logger.Logger
+}
+
+// NewRatePrefetchJob creates a job that prefetches rates at every time matched by schedule
+func NewRatePrefetchJob(useCase *usecases.PrefetchRatesUseCase, schedule *CronSchedule, log *logger.Logger) *RatePrefetchJob {
+	return &RatePrefetchJob{
+		useCase:  useCase,
+		schedule: schedule,
+		logger:   log,
+	}
+}
+
+// Run blocks, prefetching at every scheduled time until ctx is cancelled
+func (j *RatePrefetchJob) Run(ctx context.Context) {
+	for {
+		next := j.schedule.Next(time.Now())
+		if next.IsZero() {
+			j.logger.Warn("Rate prefetch schedule never runs", "schedule", j.schedule.String())
+			return
+		}
+		j.logger.Info("Rate prefetch scheduled", "schedule", j.schedule.String(), "next_run", next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		j.prefetch(ctx)
+	}
+}
+
+// prefetch runs one prefetch and logs its outcome
+func (j *RatePrefetchJob) prefetch(ctx context.Context) {
+	result, err := j.useCase.Execute(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			j.logger.LogError(err, "Rate prefetch failed")
+		}
+		return
+	}
+
+	if result.Failed > 0 {
+		j.logger.Warn("Some rates could not be prefetched", "errors", result.Errors)
+	}
+	j.logger.LogOperation("rate_prefetch", "", result.Failed == 0,
+		"currencies", result.Currencies,
+		"stored", result.Stored,
+		"up_to_date", result.UpToDate,
+		"failed", result.Failed,
+	)
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// RateSyncJob periodically refreshes the exchange rates of subscribed currencies
+type RateSyncJob struct {
+	useCase  *usecases.SyncSubscribedRatesUseCase
+	interval time.Duration
+	logger   *logger.Logger
+}
+
+// NewRateSyncJob creates a job that syncs subscribed rates every interval
+func NewRateSyncJob(useCase *usecases.SyncSubscribedRatesUseCase, interval time.Duration, log *logger.Logger) *RateSyncJob {
+	return &RateSyncJob{
+		useCase:  useCase,
+		interval: interval,
+		logger:   log,
+	}
+}
+
+// Run blocks, syncing immediately and then every interval until ctx is cancelled
+func (j *RateSyncJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.sync(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync runs one refresh and logs its outcome
+func (j *RateSyncJob) sync(ctx context.Context) {
+	result, err := j.useCase.Execute(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			j.logger.LogError(err, "Rate sync failed")
+		}
+		return
+	}
+
+	j.logger.LogOperation("rate_sync", "", true,
+		"subscribed", result.Subscribed,
+		"checked", result.Checked,
+		"refreshed", result.Refreshed,
+		"fresh", result.Fresh,
+		"deferred", result.Deferred,
+		"failed", result.Failed,
+	)
+}
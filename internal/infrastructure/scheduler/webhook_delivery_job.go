@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// WebhookDeliveryJob periodically retries webhook deliveries whose next attempt is due
+type WebhookDeliveryJob struct {
+	useCase  *usecases.DispatchWebhooksUseCase
+	interval time.Duration
+	logger   *logger.Logger
+}
+
+// NewWebhookDeliveryJob creates a job that retries due webhook deliveries every interval
+func NewWebhookDeliveryJob(useCase *usecases.DispatchWebhooksUseCase, interval time.Duration, log *logger.Logger) *WebhookDeliveryJob {
+	return &WebhookDeliveryJob{
+		useCase:  useCase,
+		interval: interval,
+		logger:   log,
+	}
+}
+
+// Run blocks, retrying immediately and then every interval until ctx is cancelled
+func (j *WebhookDeliveryJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.deliver(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliver runs one retry pass, logging it only when something was attempted
+func (j *WebhookDeliveryJob) deliver(ctx context.Context) {
+	result, err := j.useCase.DeliverDue(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			j.logger.LogError(err, "Webhook delivery retry failed")
+		}
+		return
+	}
+	if result.Attempted == 0 {
+		return
+	}
+
+	j.logger.LogOperation("webhook_retry", "", true,
+		"attempted", result.Attempted,
+		"succeeded", result.Succeeded,
+		"retrying", result.Retrying,
+		"failed", result.Failed,
+	)
+}
@@ -0,0 +1,331 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database/migrations"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"gorm.io/gorm"
+)
+
+// Supported storage drivers (DB_DRIVER values)
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+	DriverMemory   = "memory"
+)
+
+// Storage bundles the repositories built for the configured driver
+type Storage struct {
+	Driver                      string
+	TransactionRepository       repositories.TransactionRepository
+	ExchangeRateRepository      repositories.ExchangeRateRepository
+	QuoteRepository             repositories.QuoteRepository
+	ConversionRecordRepository  repositories.ConversionRecordRepository
+	ConversionBatchRepository   repositories.ConversionBatchRepository
+	ConversionHistoryRepository repositories.ConversionHistoryRepository
+	BudgetRepository            repositories.BudgetRepository
+	CategoryRepository          repositories.CategoryRepository
+	ReportRepository            repositories.ReportRepository
+	RateSubscriptionRepository  repositories.RateSubscriptionRepository
+	APITokenRepository          repositories.APITokenRepository
+	IdempotencyKeyRepository    repositories.IdempotencyKeyRepository
+	WebhookRepository           repositories.WebhookRepository
+	OutboxRepository            repositories.OutboxRepository
+	AuditLogRepository          repositories.AuditLogRepository
+	UnitOfWork                  repositories.UnitOfWork
+
+	db    *gorm.DB
+	ping  func(ctx context.Context) error
+	size  func(ctx context.Context) (int64, error)
+	pools func() []database.NamedPool
+	close func() error
+}
+
+// NewStorage instantiates repositories for cfg.Driver
+// SQL databases get their pending migrations applied, or are rejected while behind when cfg.ManualMigrations is set
+func NewStorage(cfg *config.DatabaseConfig) (*Storage, error) {
+	driver := normalizeDriver(cfg.Driver)
+
+	switch driver {
+	case DriverSQLite, DriverPostgres, DriverMySQL:
+		db, err := openSQLDatabase(cfg, driver)
+		if err != nil {
+			return nil, err
+		}
+		if err := prepareSchema(db, cfg.ManualMigrations); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+
+		if postgresDB, ok := db.(*database.PostgresDB); ok {
+			stickyWindow := time.Duration(cfg.ReplicaStickySeconds) * time.Second
+			if err := postgresDB.UseReplicas(cfg.ReplicaDSNs, stickyWindow); err != nil {
+				_ = postgresDB.Close()
+				return nil, err
+			}
+		}
+		return newGormStorage(driver, db.GetDB(), db.Ping, db.Size, db.Pools, db.Close), nil
+
+	case DriverMemory:
+		if cfg.EncryptionKey != "" || cfg.EncryptionKeyFile != "" {
+			return nil, fmt.Errorf("database encryption is only supported by the %s driver", DriverSQLite)
+		}
+		transactionRepository := memory.NewTransactionRepository()
+		store := &Storage{
+			Driver:                      driver,
+			TransactionRepository:       transactionRepository,
+			ExchangeRateRepository:      memory.NewExchangeRateRepository(),
+			QuoteRepository:             memory.NewQuoteRepository(),
+			ConversionRecordRepository:  memory.NewConversionRecordRepository(),
+			ConversionBatchRepository:   memory.NewConversionBatchRepository(),
+			ConversionHistoryRepository: memory.NewConversionHistoryRepository(),
+			BudgetRepository:            memory.NewBudgetRepository(),
+			CategoryRepository:          memory.NewCategoryRepository(),
+			ReportRepository:            memory.NewReportRepository(transactionRepository),
+			RateSubscriptionRepository:  memory.NewRateSubscriptionRepository(),
+			APITokenRepository:          memory.NewAPITokenRepository(),
+			IdempotencyKeyRepository:    memory.NewIdempotencyKeyRepository(),
+			WebhookRepository:           memory.NewWebhookRepository(),
+			OutboxRepository:            memory.NewOutboxRepository(),
+			AuditLogRepository:          memory.NewAuditLogRepository(),
+			ping:                        func(context.Context) error { return nil },
+			size:                        func(context.Context) (int64, error) { return 0, nil },
+			pools:                       func() []database.NamedPool { return nil },
+			close:                       func() error { return nil },
+		}
+		store.UnitOfWork = memory.NewUnitOfWork(store.repositories())
+		return store, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Driver)
+	}
+}
+
+// NewMigrator opens the SQL database of cfg without migrating it, for the migrate command
+// The returned function closes the connection
+func NewMigrator(cfg *config.DatabaseConfig) (*migrations.Migrator, func() error, error) {
+	driver := normalizeDriver(cfg.Driver)
+	if driver != DriverSQLite && driver != DriverPostgres && driver != DriverMySQL {
+		return nil, nil, fmt.Errorf("the %s driver has no schema to migrate", driver)
+	}
+
+	db, err := openSQLDatabase(cfg, driver)
+	if err != nil {
+		return nil, nil, err
+	}
+	return migrations.NewMigrator(db.GetDB(), db.Migrations()), db.Close, nil
+}
+
+// sqlDatabase is a GORM-backed database connection
+// Each driver lists the migrations its schema is built from; statements that differ per dialect
+// branch inside the migration, so drivers share the same versions and only add optional ones
+type sqlDatabase interface {
+	GetDB() *gorm.DB
+	Migrations() []migrations.Migration
+	Ping(ctx context.Context) error
+	Size(ctx context.Context) (int64, error)
+	Pools() []database.NamedPool
+	Close() error
+}
+
+// normalizeDriver returns the lower-case driver name, defaulting to SQLite; mariadb is served by the MySQL driver
+func normalizeDriver(driver string) string {
+	driver = strings.ToLower(strings.TrimSpace(driver))
+	switch driver {
+	case "":
+		return DriverSQLite
+	case "mariadb":
+		return DriverMySQL
+	}
+	return driver
+}
+
+// openSQLDatabase connects to the SQLite, PostgreSQL or MySQL database of cfg without touching its schema
+// Statements are logged through slog at the configured level
+func openSQLDatabase(cfg *config.DatabaseConfig, driver string) (sqlDatabase, error) {
+	db, err := connectSQLDatabase(cfg, driver)
+	if err != nil {
+		return nil, err
+	}
+	database.UseSQLLogger(db.GetDB(), database.SQLLogConfig{
+		Level:         cfg.LogLevel,
+		SlowThreshold: time.Duration(cfg.SlowQueryMs) * time.Millisecond,
+	})
+	return db, nil
+}
+
+// connectSQLDatabase opens the connection for driver
+func connectSQLDatabase(cfg *config.DatabaseConfig, driver string) (sqlDatabase, error) {
+	encryptionKey, err := database.ResolveEncryptionKey(cfg.EncryptionKey, cfg.EncryptionKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if encryptionKey != "" && driver != DriverSQLite {
+		return nil, fmt.Errorf("database encryption is only supported by the %s driver", DriverSQLite)
+	}
+
+	pool := database.PoolConfig{
+		MaxOpenConns:    cfg.MaxOpenConns,
+		MaxIdleConns:    cfg.MaxIdleConns,
+		ConnMaxLifetime: time.Duration(cfg.ConnMaxLifetimeMins) * time.Minute,
+		ConnMaxIdleTime: time.Duration(cfg.ConnMaxIdleMins) * time.Minute,
+	}
+	if driver == DriverSQLite {
+		tuning := database.SQLiteConfig{
+			JournalMode:     cfg.SQLiteJournalMode,
+			BusyTimeout:     time.Duration(cfg.SQLiteBusyTimeoutMs) * time.Millisecond,
+			Synchronous:     cfg.SQLiteSynchronous,
+			SerializeWrites: cfg.SQLiteSerializeWrites,
+			Pool:            pool,
+		}
+		if encryptionKey != "" {
+			return database.NewEncryptedSQLiteDB(cfg.Path, encryptionKey, tuning)
+		}
+		return database.NewSQLiteDB(cfg.Path, tuning)
+	}
+	if driver == DriverMySQL {
+		if len(cfg.ReplicaDSNs) > 0 || cfg.PartitionTransactions {
+			return nil, fmt.Errorf("read replicas and partitioning are only supported by the %s driver", DriverPostgres)
+		}
+		return database.NewMySQLDB(cfg.DSN, pool)
+	}
+	return database.NewPostgresDB(cfg.DSN, cfg.PartitionTransactions, pool)
+}
+
+// prepareSchema applies pending migrations, or with manual migrations fails while any are pending
+func prepareSchema(db sqlDatabase, manual bool) error {
+	migrator := migrations.NewMigrator(db.GetDB(), db.Migrations())
+	if !manual {
+		if _, err := migrator.Up(); err != nil {
+			return fmt.Errorf("failed to run database migrations: %w", err)
+		}
+		return nil
+	}
+
+	pending, err := migrator.Pending()
+	if err != nil {
+		return fmt.Errorf("failed to check database migrations: %w", err)
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is %d migration(s) behind, starting with %04d_%s: run `server migrate up` or unset DB_MANUAL_MIGRATIONS",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
+
+// newGormStorage builds GORM-backed repositories sharing a single connection
+func newGormStorage(
+	driver string,
+	db *gorm.DB,
+	pingFn func(ctx context.Context) error,
+	sizeFn func(ctx context.Context) (int64, error),
+	poolsFn func() []database.NamedPool,
+	closeFn func() error,
+) *Storage {
+	return &Storage{
+		Driver:                      driver,
+		TransactionRepository:       database.NewTransactionRepository(db),
+		ExchangeRateRepository:      database.NewExchangeRateRepository(db),
+		QuoteRepository:             database.NewQuoteRepository(db),
+		ConversionRecordRepository:  database.NewConversionRecordRepository(db),
+		ConversionBatchRepository:   database.NewConversionBatchRepository(db),
+		ConversionHistoryRepository: database.NewConversionHistoryRepository(db),
+		BudgetRepository:            database.NewBudgetRepository(db),
+		CategoryRepository:          database.NewCategoryRepository(db),
+		ReportRepository:            database.NewReportRepository(db),
+		RateSubscriptionRepository:  database.NewRateSubscriptionRepository(db),
+		APITokenRepository:          database.NewAPITokenRepository(db),
+		IdempotencyKeyRepository:    database.NewIdempotencyKeyRepository(db),
+		WebhookRepository:           database.NewWebhookRepository(db),
+		OutboxRepository:            database.NewOutboxRepository(db),
+		AuditLogRepository:          database.NewAuditLogRepository(db),
+		UnitOfWork:                  database.NewUnitOfWork(db),
+		db:                          db,
+		ping:                        pingFn,
+		size:                        sizeFn,
+		pools:                       poolsFn,
+		close:                       closeFn,
+	}
+}
+
+// repositories returns the repositories a unit of work hands out
+func (s *Storage) repositories() repositories.Repositories {
+	return repositories.Repositories{
+		Transactions:      s.TransactionRepository,
+		ExchangeRates:     s.ExchangeRateRepository,
+		ConversionHistory: s.ConversionHistoryRepository,
+		Categories:        s.CategoryRepository,
+		Budgets:           s.BudgetRepository,
+		Outbox:            s.OutboxRepository,
+		AuditLog:          s.AuditLogRepository,
+	}
+}
+
+// DB returns the underlying GORM connection, or nil for the memory driver
+func (s *Storage) DB() *gorm.DB {
+	return s.db
+}
+
+// Ping checks that the storage backend is reachable
+func (s *Storage) Ping(ctx context.Context) error {
+	return s.ping(ctx)
+}
+
+// Stats reports the storage size in bytes and the row count of each table
+// The memory driver reports no size and only counts transactions
+func (s *Storage) Stats(ctx context.Context) (dto.DatabaseStats, error) {
+	size, err := s.size(ctx)
+	if err != nil {
+		return dto.DatabaseStats{}, err
+	}
+
+	if s.db == nil {
+		count, err := s.TransactionRepository.Count()
+		if err != nil {
+			return dto.DatabaseStats{}, err
+		}
+		return dto.DatabaseStats{SizeBytes: size, RowCounts: map[string]int64{"transactions": count}}, nil
+	}
+
+	counts, err := database.RowCounts(ctx, s.db)
+	if err != nil {
+		return dto.DatabaseStats{}, err
+	}
+	return dto.DatabaseStats{SizeBytes: size, RowCounts: counts}, nil
+}
+
+// PoolStats reports the connection pools of the primary and any replicas; the memory driver has none
+func (s *Storage) PoolStats() []dto.ConnectionPoolStats {
+	pools := s.pools()
+	stats := make([]dto.ConnectionPoolStats, 0, len(pools))
+	for _, pool := range pools {
+		poolStats := pool.DB.Stats()
+		stats = append(stats, dto.ConnectionPoolStats{
+			Pool:              pool.Name,
+			MaxOpen:           poolStats.MaxOpenConnections,
+			Open:              poolStats.OpenConnections,
+			InUse:             poolStats.InUse,
+			Idle:              poolStats.Idle,
+			WaitCount:         poolStats.WaitCount,
+			WaitSeconds:       poolStats.WaitDuration.Seconds(),
+			MaxIdleClosed:     poolStats.MaxIdleClosed,
+			MaxIdleTimeClosed: poolStats.MaxIdleTimeClosed,
+			MaxLifetimeClosed: poolStats.MaxLifetimeClosed,
+		})
+	}
+	return stats
+}
+
+// Close releases the resources held by the storage backend
+func (s *Storage) Close() error {
+	return s.close()
+}
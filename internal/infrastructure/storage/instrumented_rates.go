@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
+)
+
+// instrumentedExchangeRateRepository records rate cache hits and misses on an activity recorder
+type instrumentedExchangeRateRepository struct {
+	repositories.ExchangeRateRepository
+	recorder *activity.Recorder
+}
+
+// NewInstrumentedExchangeRateRepository wraps an ExchangeRateRepository so conversion lookups are counted
+func NewInstrumentedExchangeRateRepository(inner repositories.ExchangeRateRepository, recorder *activity.Recorder) repositories.ExchangeRateRepository {
+	return &instrumentedExchangeRateRepository{
+		ExchangeRateRepository: inner,
+		recorder:               recorder,
+	}
+}
+
+// FindRateForConversion delegates to the wrapped repository and counts whether a cached rate was found
+func (r *instrumentedExchangeRateRepository) FindRateForConversion(from, to entities.CurrencyCode, transactionDate time.Time, window entities.RateWindow) (*entities.ExchangeRate, error) {
+	exchangeRate, err := r.ExchangeRateRepository.FindRateForConversion(from, to, transactionDate, window)
+	if err == nil {
+		r.recorder.RecordRateCacheLookup(string(to), exchangeRate != nil)
+	}
+	return exchangeRate, err
+}
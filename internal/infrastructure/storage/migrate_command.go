@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+)
+
+// RunMigrateCommand implements `<program> migrate up|down|status` against the configured database
+// It is shared by the server and admin binaries and exits the process on failure
+func RunMigrateCommand(program string, cfg *config.DatabaseConfig, args []string) {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	steps := flags.Int("steps", 1, "Number of migrations to revert with down")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s migrate up | down [-steps N] | status\n", program)
+		flags.PrintDefaults()
+	}
+	if len(args) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	command := args[0]
+	if command != "up" && command != "down" && command != "status" {
+		fmt.Fprintf(os.Stderr, "invalid migrate command %q, expected up, down or status\n", command)
+		flags.Usage()
+		os.Exit(2)
+	}
+	_ = flags.Parse(args[1:])
+
+	migrator, closeDB, err := NewMigrator(cfg)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			fmt.Printf("Applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("Schema is up to date")
+		}
+
+	case "down":
+		reverted, err := migrator.Down(*steps)
+		for _, migration := range reverted {
+			fmt.Printf("Reverted %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("Revert failed: %v", err)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("No applied migrations to revert")
+		}
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		out := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(out, "VERSION\tNAME\tAPPLIED")
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(out, "%04d\t%s\t%s\n", status.Version, status.Name, applied)
+		}
+		_ = out.Flush()
+	}
+}
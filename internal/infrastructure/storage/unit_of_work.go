@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+)
+
+// ExchangeRateDecorator wraps an exchange rate repository, e.g. to cache lookups or notify listeners of stored rates
+type ExchangeRateDecorator func(inner repositories.ExchangeRateRepository) repositories.ExchangeRateRepository
+
+// decoratedUnitOfWork lets the exchange rate decorators see the rates written by units of work
+type decoratedUnitOfWork struct {
+	repositories.UnitOfWork
+	decorate ExchangeRateDecorator
+}
+
+// NewDecoratedUnitOfWork wraps inner so the exchange rate writes of a unit of work reach decorate once it commits
+// Units of work write through bare repositories sharing their transaction, so decorators reacting to stored rates,
+// such as invalidating cached lookups or refreshing conversions, would miss them or react to a rollback
+// The writes of a committed unit are replayed through decorate over a repository storing nothing
+func NewDecoratedUnitOfWork(inner repositories.UnitOfWork, decorate ExchangeRateDecorator) repositories.UnitOfWork {
+	return &decoratedUnitOfWork{
+		UnitOfWork: inner,
+		decorate:   decorate,
+	}
+}
+
+// Do calls fn like the wrapped unit of work, then replays its exchange rate writes through the decorators
+func (u *decoratedUnitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	var written *recordingExchangeRateRepository
+	err := u.UnitOfWork.Do(func(repos repositories.Repositories) error {
+		written = &recordingExchangeRateRepository{ExchangeRateRepository: repos.ExchangeRates}
+		repos.ExchangeRates = written
+		return fn(repos)
+	})
+	if err != nil || written == nil {
+		return err
+	}
+
+	replay := u.decorate(committedExchangeRateRepository{written.ExchangeRateRepository})
+	for _, write := range written.writes {
+		_ = write(replay) // The committed repository stores nothing, so replays cannot fail
+	}
+	return nil
+}
+
+// recordingExchangeRateRepository remembers each successful write so it can be replayed on another repository
+type recordingExchangeRateRepository struct {
+	repositories.ExchangeRateRepository
+	writes []func(repo repositories.ExchangeRateRepository) error
+}
+
+// Save stores the rate and records the write
+func (r *recordingExchangeRateRepository) Save(exchangeRate *entities.ExchangeRate) error {
+	if err := r.ExchangeRateRepository.Save(exchangeRate); err != nil {
+		return err
+	}
+	saved := *exchangeRate
+	r.record(func(repo repositories.ExchangeRateRepository) error { return repo.Save(&saved) })
+	return nil
+}
+
+// SaveAll stores the rates and records the write
+func (r *recordingExchangeRateRepository) SaveAll(exchangeRates []entities.ExchangeRate) error {
+	if err := r.ExchangeRateRepository.SaveAll(exchangeRates); err != nil {
+		return err
+	}
+	saved := append([]entities.ExchangeRate(nil), exchangeRates...)
+	r.record(func(repo repositories.ExchangeRateRepository) error { return repo.SaveAll(saved) })
+	return nil
+}
+
+// Update modifies the rate and records the write
+func (r *recordingExchangeRateRepository) Update(exchangeRate *entities.ExchangeRate) error {
+	if err := r.ExchangeRateRepository.Update(exchangeRate); err != nil {
+		return err
+	}
+	updated := *exchangeRate
+	r.record(func(repo repositories.ExchangeRateRepository) error { return repo.Update(&updated) })
+	return nil
+}
+
+// Delete removes the rate and records the write
+func (r *recordingExchangeRateRepository) Delete(id uuid.UUID) error {
+	if err := r.ExchangeRateRepository.Delete(id); err != nil {
+		return err
+	}
+	r.record(func(repo repositories.ExchangeRateRepository) error { return repo.Delete(id) })
+	return nil
+}
+
+// Purge deletes rates and records the write
+func (r *recordingExchangeRateRepository) Purge(currency entities.CurrencyCode, effectiveDate *time.Time) (int64, error) {
+	purged, err := r.ExchangeRateRepository.Purge(currency, effectiveDate)
+	if err != nil {
+		return purged, err
+	}
+	r.record(func(repo repositories.ExchangeRateRepository) error {
+		_, err := repo.Purge(currency, effectiveDate)
+		return err
+	})
+	return purged, nil
+}
+
+// record appends a write to replay
+func (r *recordingExchangeRateRepository) record(write func(repo repositories.ExchangeRateRepository) error) {
+	r.writes = append(r.writes, write)
+}
+
+// committedExchangeRateRepository accepts the writes of a committed unit of work without storing them again
+// Reads reach the unit's repository, whose transaction is over
+type committedExchangeRateRepository struct {
+	repositories.ExchangeRateRepository
+}
+
+func (committedExchangeRateRepository) Save(*entities.ExchangeRate) error { return nil }
+
+func (committedExchangeRateRepository) SaveAll([]entities.ExchangeRate) error { return nil }
+
+func (committedExchangeRateRepository) Update(*entities.ExchangeRate) error { return nil }
+
+func (committedExchangeRateRepository) Delete(uuid.UUID) error { return nil }
+
+func (committedExchangeRateRepository) Purge(entities.CurrencyCode, *time.Time) (int64, error) {
+	return 0, nil
+}
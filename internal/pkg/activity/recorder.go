@@ -0,0 +1,119 @@
+package activity
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Snapshot holds activity counts accumulated since the previous drain
+type Snapshot struct {
+	Conversions      int64
+	TreasuryFailures int64
+}
+
+// Recorder counts in-process activity that is not persisted (conversions, Treasury failures)
+// A nil Recorder ignores all calls
+type Recorder struct {
+	conversions      atomic.Int64
+	treasuryFailures atomic.Int64
+
+	mu              sync.Mutex
+	deprecatedCalls map[string]int64           // "METHOD route" -> calls since start; never drained
+	rateCache       map[string]RateCacheCounts // Currency -> local rate lookups since start; never drained
+}
+
+// RateCacheCounts holds local exchange rate lookups that found a cached rate (hits) or none (misses)
+type RateCacheCounts struct {
+	Hits   int64
+	Misses int64
+}
+
+// NewRecorder creates a new Recorder with zeroed counters
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// RecordConversion counts a successful currency conversion
+func (r *Recorder) RecordConversion() {
+	if r != nil {
+		r.conversions.Add(1)
+	}
+}
+
+// RecordTreasuryFailure counts a failed call to the Treasury API
+func (r *Recorder) RecordTreasuryFailure() {
+	if r != nil {
+		r.treasuryFailures.Add(1)
+	}
+}
+
+// Drain returns the current counts and resets them to zero
+func (r *Recorder) Drain() Snapshot {
+	if r == nil {
+		return Snapshot{}
+	}
+	return Snapshot{
+		Conversions:      r.conversions.Swap(0),
+		TreasuryFailures: r.treasuryFailures.Swap(0),
+	}
+}
+
+// RecordDeprecatedCall counts a request to a deprecated route, identified by method and route pattern
+func (r *Recorder) RecordDeprecatedCall(method, route string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.deprecatedCalls == nil {
+		r.deprecatedCalls = make(map[string]int64)
+	}
+	r.deprecatedCalls[method+" "+route]++
+}
+
+// DeprecatedCalls returns the number of calls per deprecated route since the process started
+func (r *Recorder) DeprecatedCalls() map[string]int64 {
+	calls := make(map[string]int64)
+	if r == nil {
+		return calls
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for route, count := range r.deprecatedCalls {
+		calls[route] = count
+	}
+	return calls
+}
+
+// RecordRateCacheLookup counts a local exchange rate lookup for a currency
+func (r *Recorder) RecordRateCacheLookup(currency string, hit bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.rateCache == nil {
+		r.rateCache = make(map[string]RateCacheCounts)
+	}
+	counts := r.rateCache[currency]
+	if hit {
+		counts.Hits++
+	} else {
+		counts.Misses++
+	}
+	r.rateCache[currency] = counts
+}
+
+// RateCacheStats returns the rate cache hits and misses per currency since the process started
+func (r *Recorder) RateCacheStats() map[string]RateCacheCounts {
+	stats := make(map[string]RateCacheCounts)
+	if r == nil {
+		return stats
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for currency, counts := range r.rateCache {
+		stats[currency] = counts
+	}
+	return stats
+}
@@ -0,0 +1,219 @@
+package errortracking
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+)
+
+// Delivery limits
+const (
+	maxQueuedEvents = 100
+	sendTimeout     = 5 * time.Second
+)
+
+// Event levels understood by Sentry
+const (
+	LevelError = "error"
+	LevelFatal = "fatal"
+)
+
+// Config enables error reporting to a Sentry-compatible backend
+type Config struct {
+	DSN         string // https://<public key>@<host>/<project id>; empty disables reporting
+	Environment string // Reported as the event environment, e.g. production
+	Release     string // Reported as the event release, usually the build version
+	ServerName  string // Reported as the event server_name; defaults to the hostname
+}
+
+// Reporter sends errors and panics to Sentry in the background
+// A nil Reporter discards everything, so callers need not check whether reporting is enabled
+type Reporter struct {
+	client     *http.Client
+	storeURL   string
+	authHeader string
+
+	environment string
+	release     string
+	serverName  string
+
+	mu     sync.Mutex
+	closed bool
+	events chan *event
+	wg     sync.WaitGroup
+}
+
+// NewReporter starts a reporter for cfg, or returns nil when no DSN is configured
+func NewReporter(cfg Config, clientName string) (*Reporter, error) {
+	if cfg.DSN == "" {
+		return nil, nil
+	}
+	dsn, err := ParseDSN(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	serverName := cfg.ServerName
+	if serverName == "" {
+		serverName, _ = os.Hostname()
+	}
+
+	r := &Reporter{
+		client:      &http.Client{Timeout: sendTimeout},
+		storeURL:    dsn.StoreURL(),
+		authHeader:  dsn.authHeader(clientName),
+		environment: cfg.Environment,
+		release:     cfg.Release,
+		serverName:  serverName,
+		events:      make(chan *event, maxQueuedEvents),
+	}
+	r.wg.Add(1)
+	go r.loop()
+	return r, nil
+}
+
+// CaptureError reports err with tags; the request ID in ctx, if any, is added as a tag
+func (r *Reporter) CaptureError(ctx context.Context, err error, tags map[string]string) {
+	if r == nil || err == nil {
+		return
+	}
+	e := r.newEvent(ctx, LevelError, tags)
+	e.Exception = exceptionOf(err, stacktrace(1))
+	r.enqueue(e)
+}
+
+// CaptureRequestError reports err raised while serving req, attaching the method, URL and non-sensitive headers
+func (r *Reporter) CaptureRequestError(req *http.Request, err error, tags map[string]string) {
+	if r == nil || err == nil {
+		return
+	}
+	e := r.newEvent(req.Context(), LevelError, tags)
+	e.Request = requestOf(req)
+	e.Exception = exceptionOf(err, stacktrace(1))
+	r.enqueue(e)
+}
+
+// CapturePanic reports a value recovered while serving req; call it from the deferred function that recovered
+func (r *Reporter) CapturePanic(req *http.Request, recovered interface{}, tags map[string]string) {
+	if r == nil {
+		return
+	}
+	e := r.newEvent(req.Context(), LevelFatal, tags)
+	e.Request = requestOf(req)
+	e.Exception = &exceptionList{Values: []exception{{
+		Type:       fmt.Sprintf("panic: %T", recovered),
+		Value:      fmt.Sprint(recovered),
+		Stacktrace: stacktrace(1),
+	}}}
+	r.enqueue(e)
+}
+
+// Close sends the queued events and stops the reporter
+func (r *Reporter) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.events)
+	}
+	r.mu.Unlock()
+
+	r.wg.Wait()
+	return nil
+}
+
+// newEvent creates an event carrying the release, environment and tags
+func (r *Reporter) newEvent(ctx context.Context, level string, tags map[string]string) *event {
+	merged := make(map[string]string, len(tags)+1)
+	for name, value := range tags {
+		merged[name] = value
+	}
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
+		merged["request_id"] = requestID
+	}
+
+	return &event{
+		EventID:     newEventID(),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339Nano),
+		Level:       level,
+		Platform:    "go",
+		Release:     r.release,
+		Environment: r.environment,
+		ServerName:  r.serverName,
+		Tags:        merged,
+	}
+}
+
+// enqueue hands e to the sender, dropping it when Sentry has fallen too far behind or the reporter is closed
+func (r *Reporter) enqueue(e *event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return
+	}
+	select {
+	case r.events <- e:
+	default:
+		fmt.Fprintf(os.Stderr, "error reporting queue full, dropped event %s\n", e.EventID)
+	}
+}
+
+// loop sends events until the reporter is closed; failures go to stderr so they cannot be reported again
+func (r *Reporter) loop() {
+	defer r.wg.Done()
+
+	for e := range r.events {
+		if err := r.send(e); err != nil {
+			fmt.Fprintf(os.Stderr, "error reporting failed, dropped event %s: %v\n", e.EventID, err)
+		}
+	}
+}
+
+// newEventID returns 32 random hex digits, the format Sentry expects
+func newEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// sensitiveHeaders are never sent with a request
+var sensitiveHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"x-api-key":           true,
+}
+
+// requestOf describes req without credentials
+func requestOf(req *http.Request) *requestInfo {
+	headers := make(map[string]string, len(req.Header))
+	for name, values := range req.Header {
+		if sensitiveHeaders[strings.ToLower(name)] {
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	return &requestInfo{
+		Method:      req.Method,
+		URL:         scheme + "://" + req.Host + req.URL.Path,
+		QueryString: req.URL.RawQuery,
+		Headers:     headers,
+	}
+}
@@ -0,0 +1,192 @@
+package errortracking
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"runtime"
+	"strings"
+)
+
+// DSN is a parsed Sentry data source name
+type DSN struct {
+	Scheme    string
+	PublicKey string
+	SecretKey string
+	Host      string
+	Path      string // Prefix before the project ID when Sentry is served below the root
+	ProjectID string
+}
+
+// ParseDSN parses a DSN of the form https://<public key>[:<secret key>]@<host>[/<path>]/<project id>
+func ParseDSN(raw string) (*DSN, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Sentry DSN: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid Sentry DSN: scheme must be http or https")
+	}
+	if parsed.User == nil || parsed.User.Username() == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN: missing public key")
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN: missing host")
+	}
+
+	prefix, projectID := path.Split(strings.TrimRight(parsed.Path, "/"))
+	if projectID == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN: missing project ID")
+	}
+
+	secretKey, _ := parsed.User.Password()
+	return &DSN{
+		Scheme:    parsed.Scheme,
+		PublicKey: parsed.User.Username(),
+		SecretKey: secretKey,
+		Host:      parsed.Host,
+		Path:      strings.TrimRight(prefix, "/"),
+		ProjectID: projectID,
+	}, nil
+}
+
+// StoreURL returns the endpoint events are posted to
+func (d *DSN) StoreURL() string {
+	return fmt.Sprintf("%s://%s%s/api/%s/store/", d.Scheme, d.Host, d.Path, d.ProjectID)
+}
+
+// authHeader returns the X-Sentry-Auth header identifying the project and client
+func (d *DSN) authHeader(clientName string) string {
+	header := fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", clientName, d.PublicKey)
+	if d.SecretKey != "" {
+		header += ", sentry_secret=" + d.SecretKey
+	}
+	return header
+}
+
+// event is the JSON payload of Sentry's store endpoint
+type event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Release     string            `json:"release,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Request     *requestInfo      `json:"request,omitempty"`
+	Exception   *exceptionList    `json:"exception,omitempty"`
+}
+
+type requestInfo struct {
+	Method      string            `json:"method"`
+	URL         string            `json:"url"`
+	QueryString string            `json:"query_string,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
+
+type exceptionList struct {
+	Values []exception `json:"values"`
+}
+
+type exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Stacktrace *stackTrace `json:"stacktrace,omitempty"`
+}
+
+type stackTrace struct {
+	Frames []frame `json:"frames"`
+}
+
+type frame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// exceptionOf lists err and the errors it wraps, innermost first as Sentry expects, with the stack on the outermost
+func exceptionOf(err error, stack *stackTrace) *exceptionList {
+	var chain []exception
+	for current := err; current != nil; current = errors.Unwrap(current) {
+		chain = append([]exception{{Type: fmt.Sprintf("%T", current), Value: current.Error()}}, chain...)
+	}
+	chain[len(chain)-1].Stacktrace = stack
+	return &exceptionList{Values: chain}
+}
+
+// stacktrace captures the stack above its caller's skip innermost frames, oldest call first as Sentry expects
+func stacktrace(skip int) *stackTrace {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var result []frame
+	for {
+		f, more := frames.Next()
+		module, function := splitFunction(f.Function)
+		result = append([]frame{{
+			Function: function,
+			Module:   module,
+			Filename: path.Base(f.File),
+			AbsPath:  f.File,
+			Lineno:   f.Line,
+			InApp:    isApplicationCode(module, f.File),
+		}}, result...)
+		if !more {
+			break
+		}
+	}
+	return &stackTrace{Frames: result}
+}
+
+// isApplicationCode reports whether a frame belongs to this module rather than the standard library or a dependency
+// Standard library packages have no dot in their first path element, and dependencies live in the module cache
+func isApplicationCode(module, file string) bool {
+	first, _, _ := strings.Cut(module, "/")
+	return strings.Contains(first, ".") && !strings.Contains(file, "/pkg/mod/")
+}
+
+// splitFunction splits a qualified function name such as example.com/pkg.(*T).Method into package and function
+func splitFunction(qualified string) (string, string) {
+	lastSlash := strings.LastIndex(qualified, "/")
+	dot := strings.Index(qualified[lastSlash+1:], ".")
+	if dot < 0 {
+		return "", qualified
+	}
+	dot += lastSlash + 1
+	return qualified[:dot], qualified[dot+1:]
+}
+
+// send posts e to the store endpoint and treats any non-2xx status as an error
+func (r *Reporter) send(e *event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.storeURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", r.authHeader)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned status %d", r.storeURL, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Hook releases a component on shutdown; it should return once ctx is done
+type Hook func(ctx context.Context) error
+
+// CloseHook adapts a component with a Close method to a Hook
+func CloseHook(closer io.Closer) Hook {
+	return func(context.Context) error {
+		return closer.Close()
+	}
+}
+
+// WaitHook adapts a blocking wait for background work to a Hook that gives up once ctx is done
+func WaitHook(wait func()) Hook {
+	return func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// namedHook is a registered hook with the name it is reported under
+type namedHook struct {
+	name string
+	hook Hook
+}
+
+// Manager runs background workers until shutdown and then releases registered components
+// Shutdown stops the workers first and runs the hooks in reverse registration order,
+// so a component is released after everything registered later, which may depend on it
+type Manager struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+	workers sync.WaitGroup
+
+	mu       sync.Mutex
+	hooks    []namedHook
+	stopping bool
+}
+
+// NewManager creates a Manager whose Shutdown gives workers and hooks timeout to finish
+func NewManager(timeout time.Duration) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		ctx:     ctx,
+		cancel:  cancel,
+		timeout: timeout,
+	}
+}
+
+// Go runs a background worker until shutdown cancels its context
+func (m *Manager) Go(name string, run func(ctx context.Context)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopping {
+		slog.Warn("Background worker not started during shutdown", "worker", name)
+		return
+	}
+
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		run(m.ctx)
+	}()
+}
+
+// OnShutdown registers a hook that runs after the background workers have stopped
+func (m *Manager) OnShutdown(name string, hook Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, namedHook{name: name, hook: hook})
+}
+
+// Shutdown cancels the workers, waits for them and runs every hook, all within the timeout
+// Hooks run even when the workers did not stop in time; failures are joined into the returned error
+func (m *Manager) Shutdown() error {
+	m.mu.Lock()
+	if m.stopping {
+		m.mu.Unlock()
+		return nil
+	}
+	m.stopping = true
+	hooks := m.hooks
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	m.cancel()
+	var err error
+	if waitErr := WaitHook(m.workers.Wait)(ctx); waitErr != nil {
+		err = fmt.Errorf("background workers did not stop: %w", waitErr)
+	}
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if hookErr := hooks[i].hook(ctx); hookErr != nil {
+			err = errors.Join(err, fmt.Errorf("shutdown hook %s failed: %w", hooks[i].name, hookErr))
+		}
+	}
+	return err
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// contextKey is unexported so only this package can set logging metadata on a context
+type contextKey string
+
+// Context keys and the log field each one is attached as
+const (
+	requestIDKey contextKey = "request_id"
+	traceIDKey   contextKey = "trace_id"
+	tenantIDKey  contextKey = "tenant_id"
+)
+
+// contextFields lists the context values extracted as log fields, in output order
+var contextFields = []contextKey{requestIDKey, traceIDKey, tenantIDKey}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// ContextWithTraceID returns a copy of ctx carrying a distributed trace ID
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// ContextWithTenantID returns a copy of ctx carrying the tenant the request acts for
+func ContextWithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey, tenantID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or "" when absent
+func RequestIDFromContext(ctx context.Context) string {
+	return stringValue(ctx, requestIDKey)
+}
+
+// fieldsFromContext returns key/value pairs for every non-empty metadata value in ctx
+func fieldsFromContext(ctx context.Context) []interface{} {
+	if ctx == nil {
+		return nil
+	}
+
+	var fields []interface{}
+	for _, key := range contextFields {
+		if value := stringValue(ctx, key); value != "" {
+			fields = append(fields, string(key), value)
+		}
+	}
+	return fields
+}
+
+// stringValue reads a string context value, ignoring values of other types
+func stringValue(ctx context.Context, key contextKey) string {
+	value, _ := ctx.Value(key).(string)
+	return value
+}
+
+// contextHandler adds context metadata to records logged through the *Context slog methods
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle attaches fields from ctx before delegating to the wrapped handler
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if fields := fieldsFromContext(ctx); len(fields) > 0 {
+		record.Add(fields...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs keeps the wrapper on derived handlers
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup keeps the wrapper on derived handlers
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,278 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Supported log export targets
+const (
+	ExportLoki   = "loki"
+	ExportOTLP   = "otlp"
+	ExportSyslog = "syslog"
+)
+
+// Export batching limits
+const (
+	exportBatchSize     = 100
+	exportFlushInterval = 2 * time.Second
+	exportMaxBuffered   = 10000
+	exportHTTPTimeout   = 5 * time.Second
+)
+
+// ExportConfig selects where logs are shipped in addition to stdout
+type ExportConfig struct {
+	Target      string `json:"target"`       // "", "loki", "otlp" or "syslog"
+	Endpoint    string `json:"endpoint"`     // Base URL for loki/otlp; "" (local) or "udp://host:514" for syslog
+	ServiceName string `json:"service_name"` // Reported as the service label/resource attribute
+}
+
+// exportEntry is a log record flattened for shipping
+type exportEntry struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	Attrs   map[string]interface{}
+}
+
+// shipper delivers a batch of entries to a log backend
+type shipper interface {
+	ship(entries []exportEntry) error
+	close() error
+}
+
+// newShipper builds the shipper for the configured target
+func newShipper(cfg ExportConfig) (shipper, error) {
+	switch strings.ToLower(cfg.Target) {
+	case ExportLoki:
+		if cfg.Endpoint == "" {
+			return nil, fmt.Errorf("loki export requires an endpoint")
+		}
+		return newLokiShipper(cfg), nil
+	case ExportOTLP:
+		if cfg.Endpoint == "" {
+			return nil, fmt.Errorf("otlp export requires an endpoint")
+		}
+		return newOTLPShipper(cfg), nil
+	case ExportSyslog:
+		return newSyslogShipper(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported log export target: %s", cfg.Target)
+	}
+}
+
+// batcher buffers entries and ships them in the background
+type batcher struct {
+	shipper shipper
+
+	mu      sync.Mutex
+	pending []exportEntry
+
+	flush chan struct{}
+	done  chan struct{}
+	once  sync.Once
+	wg    sync.WaitGroup
+}
+
+// newBatcher starts the background flush loop for s
+func newBatcher(s shipper) *batcher {
+	b := &batcher{
+		shipper: s,
+		flush:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	b.wg.Add(1)
+	go b.loop()
+	return b
+}
+
+// add queues an entry, dropping it when the backend has fallen too far behind
+func (b *batcher) add(entry exportEntry) {
+	b.mu.Lock()
+	if len(b.pending) < exportMaxBuffered {
+		b.pending = append(b.pending, entry)
+	}
+	full := len(b.pending) >= exportBatchSize
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// loop ships pending entries on every tick or when a batch fills up
+func (b *batcher) loop() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(exportFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.shipPending()
+		case <-b.flush:
+			b.shipPending()
+		case <-b.done:
+			b.shipPending()
+			return
+		}
+	}
+}
+
+// shipPending sends everything buffered so far; failures go to stderr since the logger cannot log them
+func (b *batcher) shipPending() {
+	b.mu.Lock()
+	entries := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	if len(entries) == 0 {
+		return
+	}
+	if err := b.shipper.ship(entries); err != nil {
+		fmt.Fprintf(os.Stderr, "log export failed, dropped %d entries: %v\n", len(entries), err)
+	}
+}
+
+// Close flushes pending entries and releases the shipper
+func (b *batcher) Close() error {
+	var err error
+	b.once.Do(func() {
+		close(b.done)
+		b.wg.Wait()
+		err = b.shipper.close()
+	})
+	return err
+}
+
+// exportHandler is a slog.Handler that queues records on a batcher
+type exportHandler struct {
+	batcher *batcher
+	level   slog.Leveler
+	attrs   []slog.Attr
+	group   string
+}
+
+// Enabled reports whether records at level are exported
+func (h *exportHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle flattens the record (groups become dotted keys) and queues it
+func (h *exportHandler) Handle(_ context.Context, record slog.Record) error {
+	attrs := make(map[string]interface{}, len(h.attrs)+record.NumAttrs())
+	for _, attr := range h.attrs {
+		flattenAttr(attrs, "", attr)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		flattenAttr(attrs, h.group, attr)
+		return true
+	})
+
+	h.batcher.add(exportEntry{
+		Time:    record.Time,
+		Level:   record.Level,
+		Message: record.Message,
+		Attrs:   attrs,
+	})
+	return nil
+}
+
+// WithAttrs returns a handler that adds attrs to every record
+func (h *exportHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, attr := range attrs {
+		clone.attrs = append(clone.attrs, slog.Attr{Key: joinKey(h.group, attr.Key), Value: attr.Value})
+	}
+	return &clone
+}
+
+// WithGroup returns a handler that nests subsequent attributes under name
+func (h *exportHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.group = joinKey(h.group, name)
+	return &clone
+}
+
+// flattenAttr writes attr into dst, expanding groups into dotted keys
+func flattenAttr(dst map[string]interface{}, prefix string, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		for _, nested := range value.Group() {
+			flattenAttr(dst, joinKey(prefix, attr.Key), nested)
+		}
+		return
+	}
+	if attr.Key == "" {
+		return
+	}
+	dst[joinKey(prefix, attr.Key)] = value.Any()
+}
+
+// joinKey joins a group prefix and key with a dot
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// multiHandler fans records out to several handlers
+type multiHandler struct {
+	handlers []slog.Handler
+}
+
+// Enabled reports whether any handler accepts the level
+func (m multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range m.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle passes the record to every handler that accepts its level
+func (m multiHandler) Handle(ctx context.Context, record slog.Record) error {
+	var firstErr error
+	for _, handler := range m.handlers {
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, record.Clone()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// WithAttrs applies attrs to every handler
+func (m multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(m.handlers))
+	for i, handler := range m.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return multiHandler{handlers: handlers}
+}
+
+// WithGroup applies the group to every handler
+func (m multiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(m.handlers))
+	for i, handler := range m.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return multiHandler{handlers: handlers}
+}
@@ -0,0 +1,197 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// postJSON sends payload to url and treats any non-2xx status as an error
+func postJSON(client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// lokiShipper pushes entries to Loki's HTTP push API, one stream per level
+type lokiShipper struct {
+	client      *http.Client
+	url         string
+	serviceName string
+}
+
+func newLokiShipper(cfg ExportConfig) *lokiShipper {
+	return &lokiShipper{
+		client:      &http.Client{Timeout: exportHTTPTimeout},
+		url:         strings.TrimRight(cfg.Endpoint, "/") + "/loki/api/v1/push",
+		serviceName: cfg.ServiceName,
+	}
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (s *lokiShipper) ship(entries []exportEntry) error {
+	streams := make(map[string]*lokiStream)
+	order := make([]string, 0)
+
+	for _, entry := range entries {
+		level := strings.ToLower(entry.Level.String())
+		stream, ok := streams[level]
+		if !ok {
+			stream = &lokiStream{Stream: map[string]string{"service": s.serviceName, "level": level}}
+			streams[level] = stream
+			order = append(order, level)
+		}
+
+		// The log line is the message plus attributes as JSON, so LogQL's json parser can read it
+		line := make(map[string]interface{}, len(entry.Attrs)+1)
+		for k, v := range entry.Attrs {
+			line[k] = v
+		}
+		line["msg"] = entry.Message
+		encoded, err := json.Marshal(line)
+		if err != nil {
+			return err
+		}
+
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(entry.Time.UnixNano(), 10), string(encoded)})
+	}
+
+	payload := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{}
+	for _, level := range order {
+		payload.Streams = append(payload.Streams, streams[level])
+	}
+
+	return postJSON(s.client, s.url, payload)
+}
+
+func (s *lokiShipper) close() error { return nil }
+
+// otlpShipper sends entries to an OpenTelemetry collector using OTLP/HTTP with JSON encoding
+type otlpShipper struct {
+	client      *http.Client
+	url         string
+	serviceName string
+}
+
+func newOTLPShipper(cfg ExportConfig) *otlpShipper {
+	return &otlpShipper{
+		client:      &http.Client{Timeout: exportHTTPTimeout},
+		url:         strings.TrimRight(cfg.Endpoint, "/") + "/v1/logs",
+		serviceName: cfg.ServiceName,
+	}
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           otlpValue      `json:"body"`
+	Attributes     []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+func (s *otlpShipper) ship(entries []exportEntry) error {
+	records := make([]otlpLogRecord, 0, len(entries))
+	for _, entry := range entries {
+		attributes := make([]otlpKeyValue, 0, len(entry.Attrs))
+		for k, v := range entry.Attrs {
+			attributes = append(attributes, otlpKeyValue{Key: k, Value: toOTLPValue(v)})
+		}
+
+		records = append(records, otlpLogRecord{
+			TimeUnixNano:   strconv.FormatInt(entry.Time.UnixNano(), 10),
+			SeverityNumber: otlpSeverity(entry.Level),
+			SeverityText:   entry.Level.String(),
+			Body:           toOTLPValue(entry.Message),
+			Attributes:     attributes,
+		})
+	}
+
+	payload := map[string]interface{}{
+		"resourceLogs": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": []otlpKeyValue{{Key: "service.name", Value: toOTLPValue(s.serviceName)}},
+				},
+				"scopeLogs": []interface{}{
+					map[string]interface{}{
+						"scope":      map[string]string{"name": "purchase-transaction-api/logger"},
+						"logRecords": records,
+					},
+				},
+			},
+		},
+	}
+
+	return postJSON(s.client, s.url, payload)
+}
+
+func (s *otlpShipper) close() error { return nil }
+
+// toOTLPValue maps a Go value to an OTLP AnyValue; unknown types are sent as strings
+func toOTLPValue(v interface{}) otlpValue {
+	switch value := v.(type) {
+	case string:
+		return otlpValue{StringValue: &value}
+	case bool:
+		return otlpValue{BoolValue: &value}
+	case int64:
+		s := strconv.FormatInt(value, 10)
+		return otlpValue{IntValue: &s}
+	case uint64:
+		s := strconv.FormatUint(value, 10)
+		return otlpValue{IntValue: &s}
+	case float64:
+		return otlpValue{DoubleValue: &value}
+	default:
+		s := fmt.Sprint(value)
+		return otlpValue{StringValue: &s}
+	}
+}
+
+// otlpSeverity maps slog levels onto the OTLP severity number ranges
+func otlpSeverity(level slog.Level) int {
+	switch {
+	case level >= slog.LevelError:
+		return 17
+	case level >= slog.LevelWarn:
+		return 13
+	case level >= slog.LevelInfo:
+		return 9
+	default:
+		return 5
+	}
+}
@@ -0,0 +1,67 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"log/syslog"
+	"net/url"
+)
+
+// syslogShipper writes entries to a local or remote syslog daemon
+type syslogShipper struct {
+	writer *syslog.Writer
+}
+
+// newSyslogShipper dials the endpoint ("udp://host:514", "tcp://host:514") or the local daemon when empty
+func newSyslogShipper(cfg ExportConfig) (shipper, error) {
+	network, addr := "", ""
+	if cfg.Endpoint != "" {
+		u, err := url.Parse(cfg.Endpoint)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid syslog endpoint: %s", cfg.Endpoint)
+		}
+		network, addr = u.Scheme, u.Host
+	}
+
+	writer, err := syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_DAEMON, cfg.ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return &syslogShipper{writer: writer}, nil
+}
+
+func (s *syslogShipper) ship(entries []exportEntry) error {
+	for _, entry := range entries {
+		line := entry.Message
+		if len(entry.Attrs) > 0 {
+			attrs, err := json.Marshal(entry.Attrs)
+			if err != nil {
+				return err
+			}
+			line += " " + string(attrs)
+		}
+
+		var err error
+		switch {
+		case entry.Level >= slog.LevelError:
+			err = s.writer.Err(line)
+		case entry.Level >= slog.LevelWarn:
+			err = s.writer.Warning(line)
+		case entry.Level >= slog.LevelInfo:
+			err = s.writer.Info(line)
+		default:
+			err = s.writer.Debug(line)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *syslogShipper) close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,10 @@
+//go:build windows || plan9
+
+package logger
+
+import "fmt"
+
+// newSyslogShipper reports that syslog is unavailable on this platform
+func newSyslogShipper(cfg ExportConfig) (shipper, error) {
+	return nil, fmt.Errorf("syslog export is not supported on this platform")
+}
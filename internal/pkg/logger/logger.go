@@ -2,6 +2,7 @@ package logger
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"strings"
@@ -18,28 +19,39 @@ const (
 // Logger wraps slog.Logger with additional functionality
 type Logger struct {
 	*slog.Logger
+	exporter *batcher       // Set on the root logger when logs are exported; flushed by Close
+	level    *slog.LevelVar // Shared by the root logger and the loggers derived from it
 }
 
 // LoggerConfig holds logger configuration
 type LoggerConfig struct {
-	Level  string `json:"level"`
-	Format string `json:"format"` // "json" or "text"
+	Level  string       `json:"level"`
+	Format string       `json:"format"` // "json" or "text"
+	Export ExportConfig `json:"export"` // Optional shipping to Loki, OTLP or syslog
 }
 
-// NewLogger creates a new structured logger
-func NewLogger(cfg LoggerConfig) *Logger {
-	var level slog.Level
-	switch strings.ToUpper(cfg.Level) {
+// ParseLevel returns the slog level named DEBUG, INFO, WARN or ERROR, ignoring case
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
 	case LevelDebug:
-		level = slog.LevelDebug
+		return slog.LevelDebug, nil
 	case LevelInfo:
-		level = slog.LevelInfo
+		return slog.LevelInfo, nil
 	case LevelWarn:
-		level = slog.LevelWarn
+		return slog.LevelWarn, nil
 	case LevelError:
-		level = slog.LevelError
+		return slog.LevelError, nil
 	default:
-		level = slog.LevelInfo
+		return 0, fmt.Errorf("unknown log level %q, expected %s, %s, %s or %s", name, LevelDebug, LevelInfo, LevelWarn, LevelError)
+	}
+}
+
+// NewLogger creates a new structured logger
+// An unknown level logs at INFO; the level can be changed later with SetLevel
+func NewLogger(cfg LoggerConfig) *Logger {
+	level := new(slog.LevelVar)
+	if parsed, err := ParseLevel(cfg.Level); err == nil {
+		level.Set(parsed)
 	}
 
 	var handler slog.Handler
@@ -54,18 +66,71 @@ func NewLogger(cfg LoggerConfig) *Logger {
 		handler = slog.NewTextHandler(os.Stdout, opts)
 	}
 
-	logger := slog.New(handler)
-	return &Logger{Logger: logger}
+	// Ship logs to the configured backend alongside stdout
+	var exporter *batcher
+	var exportErr error
+	if cfg.Export.Target != "" {
+		var s shipper
+		if s, exportErr = newShipper(cfg.Export); exportErr == nil {
+			exporter = newBatcher(s)
+			handler = multiHandler{handlers: []slog.Handler{handler, &exportHandler{batcher: exporter, level: level}}}
+		}
+	}
+
+	logger := &Logger{Logger: slog.New(contextHandler{Handler: handler}), exporter: exporter, level: level}
+
+	// Export problems must not stop the service; report them on stdout instead
+	if exportErr != nil {
+		logger.LogError(exportErr, "Log export disabled", "target", cfg.Export.Target)
+	}
+
+	return logger
 }
 
-// WithContext adds context to logger
+// Level returns the name of the level records must reach to be logged
+func (l *Logger) Level() string {
+	if l.level == nil {
+		return LevelInfo
+	}
+	return l.level.Level().String()
+}
+
+// SetLevel changes the level of this logger and every logger sharing its handler, returning the previous level
+// It takes effect immediately, for stdout and log export alike
+func (l *Logger) SetLevel(name string) (string, error) {
+	parsed, err := ParseLevel(name)
+	if err != nil {
+		return "", err
+	}
+	if l.level == nil {
+		return "", fmt.Errorf("logger has no adjustable level")
+	}
+
+	previous := l.Level()
+	l.level.Set(parsed)
+	return previous, nil
+}
+
+// Close flushes and stops log export; it is a no-op when export is disabled
+func (l *Logger) Close() error {
+	if l.exporter == nil {
+		return nil
+	}
+	return l.exporter.Close()
+}
+
+// WithContext attaches request metadata (request ID, trace ID, tenant) found in ctx as fields
 func (l *Logger) WithContext(ctx context.Context) *Logger {
-	return &Logger{Logger: l.Logger.With("context", ctx)}
+	fields := fieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return &Logger{Logger: l.Logger.With(fields...), level: l.level}
 }
 
 // WithField adds a single field to logger
 func (l *Logger) WithField(key string, value interface{}) *Logger {
-	return &Logger{Logger: l.Logger.With(key, value)}
+	return &Logger{Logger: l.Logger.With(key, value), level: l.level}
 }
 
 // WithFields adds multiple fields to logger
@@ -74,7 +139,7 @@ func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
 	for k, v := range fields {
 		args = append(args, k, v)
 	}
-	return &Logger{Logger: l.Logger.With(args...)}
+	return &Logger{Logger: l.Logger.With(args...), level: l.level}
 }
 
 // Request logging helper
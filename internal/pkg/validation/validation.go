@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+)
+
+// CurrencyTag is the struct tag used to declare currency code fields, e.g. validate:"required,currency"
+const CurrencyTag = "currency"
+
+// MoneyTag is the struct tag used to declare amounts in dollars that must fit in entities.Money, e.g. validate:"required,money"
+const MoneyTag = "money"
+
+// NewValidator creates a validator with the application's custom tags registered
+func NewValidator() *validator.Validate {
+	v := validator.New()
+	if err := RegisterCustomValidations(v); err != nil {
+		panic(err) // Registration only fails on programmer error (empty tag or nil func)
+	}
+	return v
+}
+
+// RegisterCustomValidations registers the application's custom tags on an existing validator
+func RegisterCustomValidations(v *validator.Validate) error {
+	if err := v.RegisterValidation(CurrencyTag, validateCurrency); err != nil {
+		return err
+	}
+	return v.RegisterValidation(MoneyTag, validateMoney)
+}
+
+// RegisterBindingValidations registers the application's custom tags on the validator Gin binds request bodies with
+// Handlers normalize the codes they bind, so the currency tag accepts known codes in any case there
+func RegisterBindingValidations(v *validator.Validate) error {
+	if err := RegisterCustomValidations(v); err != nil {
+		return err
+	}
+	return v.RegisterValidation(CurrencyTag, validateCurrencyInput)
+}
+
+// validateCurrency accepts string fields holding a known, upper-case ISO 4217 currency code
+func validateCurrency(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+
+	_, known := entities.CurrencyCode(field.String()).Info()
+	return known
+}
+
+// validateCurrencyInput accepts string fields holding a known ISO 4217 currency code once normalized by NewCurrencyCode
+func validateCurrencyInput(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+
+	code, err := entities.NewCurrencyCode(field.String())
+	if err != nil {
+		return false
+	}
+	_, known := code.Info()
+	return known
+}
+
+// validateMoney accepts float fields holding a finite amount in dollars that Money can represent
+func validateMoney(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.Float32 && field.Kind() != reflect.Float64 {
+		return false
+	}
+
+	_, err := entities.NewMoneyFromFloat(field.Float())
+	return err == nil
+}
@@ -118,6 +118,17 @@ func InvalidTransactions() []struct {
 			},
 			ExpectedErr: "purchase amount must be positive",
 		},
+		{
+			Name: "Malformed currency",
+			Transaction: entities.Transaction{
+				ID:          uuid.New(),
+				Description: "Valid description",
+				Date:        time.Now(),
+				Amount:      entities.NewMoney(10.00),
+				Currency:    entities.CurrencyCode("usd"),
+			},
+			ExpectedErr: "invalid transaction currency: usd",
+		},
 	}
 }
 
@@ -139,6 +150,9 @@ func MoneyTestCases() []struct {
 		{"Large amount", 1234.56, entities.Money(123456)},
 		{"Rounding down", 19.994, entities.Money(1999)},
 		{"Rounding up", 19.996, entities.Money(2000)},
+		{"Half cent rounds away from zero", 1.005, entities.Money(101)},
+		{"Negative amount", -19.99, entities.Money(-1999)},
+		{"Negative half cent", -0.125, entities.Money(-13)},
 	}
 }
 
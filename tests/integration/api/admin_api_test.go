@@ -0,0 +1,358 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasetArchiveAPI(t *testing.T) {
+	source, sourceTreasury, cleanupSource := setupAdminTestRouter(t)
+	defer cleanupSource()
+	target, _, cleanupTarget := setupAdminTestRouter(t)
+	defer cleanupTarget()
+
+	jsonBody, _ := json.Marshal(map[string]interface{}{
+		"description": "Cloned purchase",
+		"date":        "2024-01-15T10:30:00Z",
+		"amount":      42.00,
+	})
+	req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	source.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	transactionID := created["id"].(string)
+
+	// Convert it so the archive carries a conversion history
+	transactionDate := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	sourceTreasury.On("SupportsCurrency", mock.Anything).Return(true).Maybe()
+	sourceTreasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, transactionDate).Return(&entities.ExchangeRate{
+		ID:            uuid.New(),
+		FromCurrency:  entities.USD,
+		ToCurrency:    entities.EUR,
+		Rate:          0.85,
+		EffectiveDate: transactionDate,
+	}, nil).Maybe()
+	jsonBody, _ = json.Marshal(map[string]interface{}{"target_currency": "EUR"})
+	req = httptest.NewRequest("POST", "/api/v1/transactions/"+transactionID+"/convert", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	source.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Export from the source environment
+	req = httptest.NewRequest("GET", "/api/v1/admin/export", nil)
+	w = httptest.NewRecorder()
+	source.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	archive := w.Body.Bytes()
+
+	var exported map[string]interface{}
+	require.NoError(t, json.Unmarshal(archive, &exported))
+	assert.Equal(t, float64(2), exported["schema_version"])
+	assert.Len(t, exported["transactions"], 1)
+	assert.Len(t, exported["conversions"], 1)
+
+	importArchive := func(router http.Handler, body []byte, strategy string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest("POST", "/api/v1/admin/import?strategy="+strategy, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	t.Run("Import into empty environment", func(t *testing.T) {
+		// Act
+		w, response := importArchive(target, archive, "fail")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		transactions := response["transactions"].(map[string]interface{})
+		assert.Equal(t, float64(1), transactions["created"])
+		conversions := response["conversions"].(map[string]interface{})
+		assert.Equal(t, float64(1), conversions["created"])
+
+		req := httptest.NewRequest("GET", "/api/v1/transactions", nil)
+		rec := httptest.NewRecorder()
+		target.ServeHTTP(rec, req)
+		var list map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		assert.Equal(t, float64(1), list["total"])
+
+		req = httptest.NewRequest("GET", "/api/v1/transactions/"+transactionID+"/conversions", nil)
+		rec = httptest.NewRecorder()
+		target.ServeHTTP(rec, req)
+		var history map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &history))
+		assert.Equal(t, float64(1), history["total"])
+	})
+
+	t.Run("Fail strategy rejects existing records", func(t *testing.T) {
+		w, _ := importArchive(target, archive, "fail")
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Skip strategy leaves existing records", func(t *testing.T) {
+		w, response := importArchive(target, archive, "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "skip", response["strategy"])
+		transactions := response["transactions"].(map[string]interface{})
+		assert.Equal(t, float64(1), transactions["skipped"])
+		assert.Equal(t, float64(1), response["conversions"].(map[string]interface{})["skipped"])
+	})
+
+	t.Run("Overwrite strategy replaces existing records", func(t *testing.T) {
+		w, response := importArchive(target, archive, "overwrite")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		transactions := response["transactions"].(map[string]interface{})
+		assert.Equal(t, float64(1), transactions["overwritten"])
+	})
+
+	t.Run("Transactions in the trash keep their ID", func(t *testing.T) {
+		// Arrange
+		id := exported["transactions"].([]interface{})[0].(map[string]interface{})["id"].(string)
+		req := httptest.NewRequest("DELETE", "/api/v1/transactions/"+id, nil)
+		rec := httptest.NewRecorder()
+		target.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+
+		// Act
+		skipped, skipResponse := importArchive(target, archive, "skip")
+		overwritten, overwriteResponse := importArchive(target, archive, "overwrite")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, skipped.Code)
+		assert.Equal(t, float64(1), skipResponse["transactions"].(map[string]interface{})["skipped"])
+		assert.Equal(t, http.StatusOK, overwritten.Code)
+		assert.Equal(t, float64(1), overwriteResponse["transactions"].(map[string]interface{})["overwritten"])
+
+		req = httptest.NewRequest("GET", "/api/v1/transactions/"+id, nil)
+		rec = httptest.NewRecorder()
+		target.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code, "overwriting brings the transaction back from the trash")
+	})
+
+	t.Run("Archives of schema version 1 have no conversions and are still accepted", func(t *testing.T) {
+		w, response := importArchive(target, []byte(`{"schema_version": 1, "transactions": [], "exchange_rates": []}`), "skip")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(0), response["conversions"].(map[string]interface{})["created"])
+	})
+
+	t.Run("Unsupported schema version", func(t *testing.T) {
+		w, response := importArchive(target, []byte(`{"schema_version": 99, "transactions": []}`), "skip")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, response["detail"], "schema version")
+	})
+
+	t.Run("Unknown strategy", func(t *testing.T) {
+		w, _ := importArchive(target, archive, "merge")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestBatchConversionAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupAdminTestRouter(t)
+	defer cleanup()
+
+	mockTreasuryService.On("SupportsCurrency", entities.EUR).Return(true).Maybe()
+	rate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.5, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+	mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, mock.Anything).Return(rate, nil).Maybe()
+	mockTreasuryService.On("FetchExchangeRates", mock.Anything, entities.USD, entities.EUR, mock.Anything, mock.Anything).Return([]entities.ExchangeRate{*rate}, nil).Maybe()
+
+	for _, date := range []string{"2024-01-15T10:00:00Z", "2024-02-20T10:00:00Z", "2024-05-01T10:00:00Z"} {
+		jsonBody, _ := json.Marshal(map[string]interface{}{"description": "Quarterly purchase", "date": date, "amount": 10.0})
+		req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	get := func(path string) map[string]interface{} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("Run a quarter and read its records", func(t *testing.T) {
+		// Act
+		jsonBody, _ := json.Marshal(map[string]interface{}{"target_currency": "eur", "from": "2024-01-01", "to": "2024-03-31"})
+		req := httptest.NewRequest("POST", "/api/v1/admin/conversions", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, http.StatusAccepted, w.Code)
+		var started map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+		batchID := started["id"].(string)
+		assert.Equal(t, "/api/v1/admin/conversions/"+batchID, w.Header().Get("Location"))
+
+		var status map[string]interface{}
+		require.Eventually(t, func() bool {
+			status = get("/api/v1/admin/conversions/" + batchID)
+			return status["status"] == "completed" || status["status"] == "failed"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		assert.Equal(t, "completed", status["status"])
+		assert.Equal(t, float64(2), status["total"])
+		assert.Equal(t, float64(2), status["succeeded"])
+		assert.Equal(t, float64(100), status["progress_percent"])
+
+		records := get("/api/v1/admin/conversions/" + batchID + "/records")
+		assert.Equal(t, float64(2), records["total"])
+		first := records["data"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, 5.0, first["converted_amount"])
+		assert.Equal(t, "EUR", first["target_currency"])
+	})
+
+	t.Run("Invalid range", func(t *testing.T) {
+		for _, body := range []map[string]interface{}{
+			{"target_currency": "EUR", "from": "2024-03-31", "to": "2024-01-01"},
+			{"target_currency": "EUR", "from": "01/01/2024", "to": "2024-03-31"},
+			{"target_currency": "EUR", "from": "2024-01-01"},
+		} {
+			jsonBody, _ := json.Marshal(body)
+			req := httptest.NewRequest("POST", "/api/v1/admin/conversions", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+
+	t.Run("Unknown batch", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/admin/conversions/"+uuid.New().String(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		req = httptest.NewRequest("GET", "/api/v1/admin/conversions/not-a-uuid/records", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestDatabaseUsageAPI(t *testing.T) {
+	// Setup
+	router, _, cleanup := setupAdminTestRouter(t)
+	defer cleanup()
+
+	jsonBody, _ := json.Marshal(map[string]interface{}{
+		"description": "Measured purchase",
+		"date":        "2024-01-15T10:30:00Z",
+		"amount":      42.00,
+	})
+	req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// Act
+	req = httptest.NewRequest("GET", "/api/v1/admin/database", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Greater(t, response["size_bytes"], float64(0))
+	assert.Equal(t, "disabled", response["quota_status"])
+
+	rowCounts, ok := response["row_counts"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(1), rowCounts["transactions"])
+	assert.Equal(t, float64(0), rowCounts["exchange_rates"])
+}
+
+func TestLogLevelAPI(t *testing.T) {
+	// Setup
+	router, _, cleanup := setupAdminTestRouter(t)
+	defer cleanup()
+
+	send := func(method, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest(method, "/api/v1/admin/loglevel", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	t.Run("Reports the level in effect", func(t *testing.T) {
+		w, response := send("GET", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ERROR", response["level"])
+	})
+
+	t.Run("Changes the level without a restart", func(t *testing.T) {
+		// Act
+		w, response := send("PUT", `{"level":"debug"}`)
+		_, current := send("GET", "")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "DEBUG", response["level"])
+		assert.Equal(t, "ERROR", response["previous"])
+		assert.Equal(t, "DEBUG", current["level"])
+	})
+
+	t.Run("Rejects unknown levels", func(t *testing.T) {
+		w, _ := send("PUT", `{"level":"verbose"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Admin routes are not served without an authenticator", func(t *testing.T) {
+		// Arrange
+		open, cleanupOpen := setupTestRouter(t)
+		defer cleanupOpen()
+		serve := func(method, path, body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			open.ServeHTTP(w, req)
+			return w
+		}
+
+		// Act & Assert
+		assert.Equal(t, http.StatusNotFound, serve("PUT", "/api/v1/admin/loglevel", `{"level":"debug"}`).Code)
+		assert.Equal(t, http.StatusNotFound, serve("POST", "/api/v1/admin/tokens", `{"name":"ops","role":"admin"}`).Code)
+		assert.NotContains(t, serve("GET", "/", "").Body.String(), "/api/v1/admin")
+	})
+}
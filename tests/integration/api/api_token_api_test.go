@@ -0,0 +1,216 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bootstrapSecret is the admin secret seeded the way API_BOOTSTRAP_TOKEN does
+const bootstrapSecret = "bootstrap-secret-0123456789abcdefghij"
+
+// setupAdminTestRouter creates a test router requiring API tokens, as admin routes are only registered then,
+// and sends requests that carry no X-API-Key with the bootstrap admin token
+func setupAdminTestRouter(t *testing.T) (http.Handler, *mocks.MockTreasuryService, func()) {
+	app := buildTestApp(t)
+	require.NoError(t, app.apiTokens.EnsureBootstrapToken(bootstrapSecret))
+	return asAdmin(app.router.WithTokenAuth(middleware.NewTokenAuth(app.apiTokens)).SetupRoutes()), app.treasury, app.cleanup
+}
+
+// asAdmin authenticates requests that carry no X-API-Key with the bootstrap admin token
+func asAdmin(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-API-Key") == "" {
+			req.Header.Set("X-API-Key", bootstrapSecret)
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// tokenClient sends JSON requests with an optional X-API-Key and decodes the response
+func tokenClient(t *testing.T, router *gin.Engine) func(method, path, apiKey string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+	return func(method, path, apiKey string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if w.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w, response
+	}
+}
+
+func TestAPITokenAPI(t *testing.T) {
+	app := buildTestApp(t)
+	defer app.cleanup()
+
+	require.NoError(t, app.apiTokens.EnsureBootstrapToken(bootstrapSecret))
+	router := app.router.WithTokenAuth(middleware.NewTokenAuth(app.apiTokens)).SetupRoutes()
+	send := tokenClient(t, router)
+
+	t.Run("Requests without a valid token are rejected", func(t *testing.T) {
+		w, _ := send("GET", "/api/v1/budgets", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+
+		w, _ = send("GET", "/api/v1/budgets", "pta_unknown", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// The GraphQL schema is protected like the queries
+		w, _ = send("GET", "/graphql/schema", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// Health stays public
+		w, _ = send("GET", "/health", "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Token lifecycle with scoped roles", func(t *testing.T) {
+		// Arrange - issue a read token with the bootstrap admin token
+		expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+		w, issued := send("POST", "/api/v1/admin/tokens", bootstrapSecret, map[string]interface{}{
+			"name":       "reporting",
+			"role":       "read",
+			"expires_at": expiresAt,
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		secret := issued["token"].(string)
+		tokenID := issued["id"].(string)
+		assert.Equal(t, "active", issued["status"])
+		assert.Equal(t, secret[:12], issued["prefix"])
+
+		// Act & Assert - the read role can read but not write
+		w, _ = send("GET", "/api/v1/budgets", secret, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w, _ = send("POST", "/api/v1/budgets", secret, map[string]interface{}{
+			"category": "travel", "period": "monthly", "limit": 100,
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w, _ = send("GET", "/api/v1/admin/tokens", secret, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// The listing never exposes secrets or hashes
+		w, listed := send("GET", "/api/v1/admin/tokens", bootstrapSecret, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, listed["data"], 2)
+		assert.NotContains(t, w.Body.String(), secret)
+		assert.NotContains(t, w.Body.String(), "token_hash")
+
+		// Rotate with a grace period: both secrets work until it ends
+		w, rotated := send("POST", "/api/v1/admin/tokens/"+tokenID+"/rotate", bootstrapSecret, map[string]interface{}{
+			"grace_period_minutes": 5,
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+		newSecret := rotated["token"].(string)
+		assert.NotEqual(t, secret, newSecret)
+		assert.Equal(t, "reporting", rotated["name"])
+		assert.Equal(t, "read", rotated["role"])
+
+		w, _ = send("GET", "/api/v1/budgets", secret, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w, _ = send("GET", "/api/v1/budgets", newSecret, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w, old := send("GET", "/api/v1/admin/tokens/"+tokenID, bootstrapSecret, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, rotated["id"], old["replaced_by_id"])
+		assert.NotEmpty(t, old["last_used_at"])
+
+		// A rotated token cannot be rotated again
+		w, _ = send("POST", "/api/v1/admin/tokens/"+tokenID+"/rotate", bootstrapSecret, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		// Revoke the new token
+		w, _ = send("DELETE", "/api/v1/admin/tokens/"+rotated["id"].(string), bootstrapSecret, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w, response := send("GET", "/api/v1/budgets", newSecret, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "api token revoked", response["detail"])
+	})
+
+	t.Run("Rotation without a grace period revokes the old secret at once", func(t *testing.T) {
+		w, issued := send("POST", "/api/v1/admin/tokens", bootstrapSecret, map[string]interface{}{
+			"name": "ingest", "role": "write",
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		w, _ = send("POST", "/api/v1/admin/tokens/"+issued["id"].(string)+"/rotate", bootstrapSecret, nil)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		w, _ = send("GET", "/api/v1/budgets", issued["token"].(string), nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Validates requests", func(t *testing.T) {
+		w, _ := send("POST", "/api/v1/admin/tokens", bootstrapSecret, map[string]interface{}{
+			"name": "bad", "role": "owner",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w, _ = send("POST", "/api/v1/admin/tokens", bootstrapSecret, map[string]interface{}{
+			"name": "expired", "role": "read", "expires_at": time.Now().Add(-time.Hour),
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w, _ = send("GET", "/api/v1/admin/tokens/not-a-uuid", bootstrapSecret, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w, _ = send("DELETE", "/api/v1/admin/tokens/00000000-0000-0000-0000-000000000000", bootstrapSecret, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("The last admin token cannot be revoked", func(t *testing.T) {
+		w, listed := send("GET", "/api/v1/admin/tokens", bootstrapSecret, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var bootstrapID string
+		for _, item := range listed["data"].([]interface{}) {
+			token := item.(map[string]interface{})
+			if token["name"] == "bootstrap" {
+				bootstrapID = token["id"].(string)
+			}
+		}
+		require.NotEmpty(t, bootstrapID)
+
+		w, _ = send("DELETE", "/api/v1/admin/tokens/"+bootstrapID, bootstrapSecret, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		// Rotating replaces it with a new admin token instead
+		w, rotated := send("POST", "/api/v1/admin/tokens/"+bootstrapID+"/rotate", bootstrapSecret, nil)
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "admin", rotated["role"])
+
+		w, _ = send("GET", "/api/v1/admin/tokens", bootstrapSecret, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w, _ = send("GET", "/api/v1/admin/tokens", rotated["token"].(string), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// Seeding the rotated bootstrap secret again does not bring it back
+		require.NoError(t, app.apiTokens.EnsureBootstrapToken(bootstrapSecret))
+		w, _ = send("GET", "/api/v1/admin/tokens", bootstrapSecret, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,146 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditAPI(t *testing.T) {
+	app := buildTestApp(t)
+	defer app.cleanup()
+
+	require.NoError(t, app.apiTokens.EnsureBootstrapToken(bootstrapSecret))
+	router := app.router.WithTokenAuth(middleware.NewTokenAuth(app.apiTokens)).SetupRoutes()
+	writer, err := app.apiTokens.Create(&dto.CreateAPITokenRequest{Name: "bookkeeping", Role: entities.RoleWrite})
+	require.NoError(t, err)
+
+	app.treasury.On("SupportsCurrency", mock.Anything).Return(true).Maybe()
+
+	send := func(method, path, apiKey, requestID string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-API-Key", apiKey)
+		if requestID != "" {
+			req.Header.Set("X-Request-ID", requestID)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if w.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w, response
+	}
+
+	transactionDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	app.treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, transactionDate).Return(&entities.ExchangeRate{
+		FromCurrency:  entities.USD,
+		ToCurrency:    entities.EUR,
+		Rate:          0.9,
+		EffectiveDate: transactionDate,
+	}, nil).Once()
+
+	// Changes made with the write token, each in its own request
+	w, created := send("POST", "/api/v1/transactions", writer.Token, "req-create", map[string]interface{}{
+		"description": "Hotel",
+		"date":        "2024-01-15T00:00:00Z",
+		"amount":      100.00,
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	transactionID := created["id"].(string)
+
+	w, _ = send("PATCH", "/api/v1/transactions/"+transactionID, writer.Token, "req-update", map[string]interface{}{
+		"tags": []string{"trip"},
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	w, _ = send("POST", "/api/v1/transactions/"+transactionID+"/convert", writer.Token, "req-convert", map[string]interface{}{
+		"target_currency": "EUR",
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	w, _ = send("DELETE", "/api/v1/transactions/"+transactionID, writer.Token, "req-delete", nil)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	w, _ = send("POST", "/api/v1/transactions/"+transactionID+"/restore", writer.Token, "req-restore", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Failed changes are not recorded
+	w, _ = send("DELETE", "/api/v1/transactions/"+uuid.New().String(), writer.Token, "req-missing", nil)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	t.Run("Every change is recorded with who made it and in which request", func(t *testing.T) {
+		// Act
+		w, trail := send("GET", "/api/v1/audit?entity_id="+transactionID, bootstrapSecret, "", nil)
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 5.0, trail["total"])
+		entries := trail["data"].([]interface{})
+		require.Len(t, entries, 5)
+
+		var actions, requestIDs []string
+		for _, item := range entries {
+			entry := item.(map[string]interface{})
+			actions = append(actions, entry["action"].(string))
+			requestIDs = append(requestIDs, entry["request_id"].(string))
+			assert.Equal(t, "transaction", entry["entity_type"])
+			assert.Equal(t, transactionID, entry["entity_id"])
+			assert.Equal(t, "token:"+writer.ID.String(), entry["actor"])
+		}
+		assert.Equal(t, []string{"restore", "delete", "convert", "update", "create"}, actions)
+		assert.Equal(t, []string{"req-restore", "req-delete", "req-convert", "req-update", "req-create"}, requestIDs)
+
+		update := entries[3].(map[string]interface{})["changes"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"tags": []interface{}{"trip"}}, update)
+		conversion := entries[2].(map[string]interface{})["changes"].(map[string]interface{})
+		assert.Equal(t, "EUR", conversion["target_currency"])
+		assert.Equal(t, 90.0, conversion["converted_amount"])
+		creation := entries[4].(map[string]interface{})["changes"].(map[string]interface{})
+		assert.Equal(t, "Hotel", creation["description"])
+		assert.Nil(t, entries[1].(map[string]interface{})["changes"])
+	})
+
+	t.Run("The trail is filtered by date and paginated", func(t *testing.T) {
+		today := time.Now().UTC().Format(time.DateOnly)
+		yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+
+		w, trail := send("GET", "/api/v1/audit?from="+today+"&to="+today+"&size=2&page=2", bootstrapSecret, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 5.0, trail["total"])
+		assert.Equal(t, 3.0, trail["total_pages"])
+		require.Len(t, trail["data"], 2)
+		assert.Equal(t, "convert", trail["data"].([]interface{})[0].(map[string]interface{})["action"])
+
+		w, trail = send("GET", "/api/v1/audit?to="+yesterday, bootstrapSecret, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 0.0, trail["total"])
+		assert.Empty(t, trail["data"])
+	})
+
+	t.Run("Reviewing the trail requires the admin role and valid filters", func(t *testing.T) {
+		w, _ := send("GET", "/api/v1/audit", writer.Token, "", nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w, problem := send("GET", "/api/v1/audit?entity_id=abc&from=2024-13-01", bootstrapSecret, "", nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Len(t, problem["invalid_params"], 2)
+
+		w, _ = send("GET", "/api/v1/audit?from=2024-02-01&to=2024-01-01", bootstrapSecret, "", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,125 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(true).Maybe()
+
+	send := func(method, path string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if w.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w, response
+	}
+
+	t.Run("Budget lifecycle", func(t *testing.T) {
+		// Create
+		w, created := send("POST", "/api/v1/budgets", map[string]interface{}{
+			"category": "travel",
+			"period":   "Monthly",
+			"limit":    500,
+			"currency": "eur",
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "travel", created["category"])
+		assert.Equal(t, "monthly", created["period"])
+		assert.Equal(t, "EUR", created["currency"])
+		assert.Equal(t, []interface{}{80.0, 100.0}, created["thresholds"])
+		path := "/api/v1/budgets/" + created["id"].(string)
+
+		// Read
+		w, fetched := send("GET", path, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 500.0, fetched["limit"])
+
+		// Replace
+		w, updated := send("PUT", path, map[string]interface{}{
+			"category":   "travel",
+			"period":     "weekly",
+			"limit":      150,
+			"thresholds": []int{100, 50},
+		})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "weekly", updated["period"])
+		assert.Equal(t, "USD", updated["currency"])
+		assert.Equal(t, []interface{}{50.0, 100.0}, updated["thresholds"])
+
+		// List
+		w, list := send("GET", "/api/v1/budgets", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, list["data"], 1)
+
+		// Delete
+		w, _ = send("DELETE", path, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w, _ = send("GET", path, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w, _ = send("DELETE", path, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid budgets are rejected", func(t *testing.T) {
+		testCases := map[string]map[string]interface{}{
+			"unknown period":    {"category": "travel", "period": "daily", "limit": 100},
+			"negative limit":    {"category": "travel", "period": "monthly", "limit": -5},
+			"unknown currency":  {"category": "travel", "period": "monthly", "limit": 100, "currency": "XYZ"},
+			"threshold too big": {"category": "travel", "period": "monthly", "limit": 100, "thresholds": []int{5000}},
+			"missing category":  {"period": "monthly", "limit": 100},
+		}
+
+		for name, body := range testCases {
+			w, response := send("POST", "/api/v1/budgets", body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, name)
+			assert.Equal(t, "Failed to create budget", response["title"], name)
+		}
+	})
+
+	t.Run("Invalid budget ID", func(t *testing.T) {
+		w, response := send("GET", "/api/v1/budgets/not-a-uuid", nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "Invalid budget ID format", response["title"])
+	})
+
+	t.Run("Transactions carry their category", func(t *testing.T) {
+		w, _ := send("POST", "/api/v1/categories", map[string]interface{}{"name": "travel"})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		w, created := send("POST", "/api/v1/transactions", map[string]interface{}{
+			"description": "Hotel",
+			"date":        "2024-05-10T00:00:00Z",
+			"amount":      120.5,
+			"category":    " travel ",
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "travel", created["category"])
+
+		w, fetched := send("GET", "/api/v1/transactions/"+created["id"].(string), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "travel", fetched["category"])
+	})
+}
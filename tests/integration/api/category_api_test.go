@@ -0,0 +1,132 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(true).Maybe()
+
+	send := func(method, path string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if w.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w, response
+	}
+
+	t.Run("Category lifecycle", func(t *testing.T) {
+		// Create
+		w, created := send("POST", "/api/v1/categories", map[string]interface{}{"name": "Office", "description": "Supplies"})
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "Office", created["name"])
+		path := "/api/v1/categories/" + created["id"].(string)
+
+		w, response := send("POST", "/api/v1/categories", map[string]interface{}{"name": "office"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "Failed to create category", response["title"])
+
+		// Read
+		w, fetched := send("GET", path, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Supplies", fetched["description"])
+
+		// List
+		w, list := send("GET", "/api/v1/categories", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, list["data"], 1)
+
+		// Delete
+		w, _ = send("DELETE", path, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w, _ = send("GET", path, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Transactions are categorized and tagged", func(t *testing.T) {
+		w, category := send("POST", "/api/v1/categories", map[string]interface{}{"name": "Meals"})
+		require.Equal(t, http.StatusCreated, w.Code)
+		categoryPath := "/api/v1/categories/" + category["id"].(string)
+
+		// Unknown categories are rejected
+		w, response := send("POST", "/api/v1/transactions", map[string]interface{}{
+			"description": "Lunch", "date": "2024-05-10T00:00:00Z", "amount": 15, "category": "Snacks",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, response["detail"], `category "Snacks" does not exist`)
+
+		w, created := send("POST", "/api/v1/transactions", map[string]interface{}{
+			"description": "Lunch", "date": "2024-05-10T00:00:00Z", "amount": 15, "category": "meals",
+			"tags": []string{"Client", "q2"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "Meals", created["category"])
+		assert.Equal(t, []interface{}{"client", "q2"}, created["tags"])
+		transactionPath := "/api/v1/transactions/" + created["id"].(string)
+
+		w, _ = send("POST", "/api/v1/transactions", map[string]interface{}{
+			"description": "Taxi", "date": "2024-05-10T00:00:00Z", "amount": 30,
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		// Filters
+		w, list := send("GET", "/api/v1/transactions?category=Meals", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, list["data"], 1)
+		w, list = send("GET", "/api/v1/transactions?tag=CLIENT", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, list["data"], 1)
+
+		// Categories in use cannot be deleted
+		w, response = send("DELETE", categoryPath, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, response["detail"], "used by 1 transactions")
+
+		// Renames follow through to transactions
+		w, _ = send("PUT", categoryPath, map[string]interface{}{"name": "Dining"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		w, fetched := send("GET", transactionPath, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Dining", fetched["category"])
+
+		// Recategorize
+		w, updated := send("PATCH", transactionPath, map[string]interface{}{"category": "", "tags": []string{"personal"}})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, updated["category"])
+		assert.Equal(t, []interface{}{"personal"}, updated["tags"])
+
+		w, response = send("PATCH", transactionPath, map[string]interface{}{"tags": []string{"not valid"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, response["detail"], `tag "not valid"`)
+
+		w, _ = send("DELETE", categoryPath, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Invalid category ID", func(t *testing.T) {
+		w, response := send("GET", "/api/v1/categories/not-a-uuid", nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "Invalid category ID format", response["title"])
+	})
+}
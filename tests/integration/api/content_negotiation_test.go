@@ -0,0 +1,160 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentNegotiationAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Arrange - two transactions, one with characters that need CSV quoting
+	var ids []string
+	for _, description := range []string{"Office supplies", `Desk, "standing"`} {
+		body, _ := json.Marshal(map[string]interface{}{
+			"description": description,
+			"date":        "2024-01-15T10:30:00Z",
+			"amount":      42.5,
+		})
+		req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		ids = append(ids, created["id"].(string))
+	}
+
+	t.Run("JSON remains the default, including for browsers", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "application/json", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"} {
+			w := get("/api/v1/transactions/"+ids[0], accept)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), "application/json", accept)
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+		}
+	})
+
+	t.Run("Transaction as XML", func(t *testing.T) {
+		// Act
+		w := get("/api/v1/transactions/"+ids[0], "application/xml")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/xml")
+
+		var transaction struct {
+			XMLName     xml.Name `xml:"transaction"`
+			ID          string   `xml:"id"`
+			Description string   `xml:"description"`
+			Amount      float64  `xml:"amount"`
+		}
+		require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &transaction))
+		assert.Equal(t, ids[0], transaction.ID)
+		assert.Equal(t, "Office supplies", transaction.Description)
+		assert.Equal(t, 42.5, transaction.Amount)
+	})
+
+	t.Run("Transaction list as XML", func(t *testing.T) {
+		// Act - text/xml is accepted too, and the higher quality wins
+		w := get("/api/v1/transactions?size=10", "application/json;q=0.5, text/xml")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var list struct {
+			XMLName      xml.Name `xml:"transaction_list"`
+			Transactions []struct {
+				ID string `xml:"id"`
+			} `xml:"transactions>transaction"`
+			Total int `xml:"total"`
+		}
+		require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &list))
+		assert.Len(t, list.Transactions, 2)
+		assert.Equal(t, 2, list.Total)
+	})
+
+	t.Run("Transaction list as CSV", func(t *testing.T) {
+		// Act
+		w := get("/api/v1/transactions?size=1", "text/csv")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+		assert.Equal(t, "2", w.Header().Get("X-Total-Pages"))
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, []string{"id", "description", "date", "amount", "currency", "category", "created_at", "updated_at"}, records[0])
+		assert.Equal(t, "42.5", records[1][3])
+		assert.Equal(t, "2024-01-15T10:30:00Z", records[1][2])
+	})
+
+	t.Run("Descriptions as CSV are quoted", func(t *testing.T) {
+		// Act
+		w := get("/api/v1/transactions/descriptions?prefix=desk", "text/csv")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"Desk, ""standing"""`)
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"description", "count"}, {`Desk, "standing"`, "1"}}, records)
+	})
+
+	t.Run("Currency as CSV", func(t *testing.T) {
+		// Arrange
+		mockTreasuryService.On("SupportsCurrency", entities.JPY).Return(true).Once()
+		mockTreasuryService.On("ProviderName").Return("us_treasury").Once()
+
+		// Act
+		w := get("/api/v1/currencies/JPY", "text/csv")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"JPY", "Japanese Yen", "¥", "0", "true", "us_treasury"}, records[1])
+	})
+
+	t.Run("Errors fall back to problem+json for CSV clients and render as problem+xml for XML clients", func(t *testing.T) {
+		// Act
+		csvError := get("/api/v1/transactions/not-a-uuid", "text/csv")
+		xmlError := get("/api/v1/transactions/not-a-uuid", "application/xml")
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, csvError.Code)
+		assert.Contains(t, csvError.Header().Get("Content-Type"), "application/problem+json")
+
+		assert.Equal(t, http.StatusBadRequest, xmlError.Code)
+		assert.Contains(t, xmlError.Header().Get("Content-Type"), "application/problem+xml")
+		assert.Contains(t, xmlError.Body.String(), `<problem xmlns="urn:ietf:rfc:7807">`)
+		assert.Contains(t, xmlError.Body.String(), "<title>Invalid transaction ID format</title>")
+		assert.NotContains(t, xmlError.Body.String(), "<invalid_param>")
+	})
+}
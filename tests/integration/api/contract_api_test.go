@@ -0,0 +1,169 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIContractValidation(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	enforcing, err := middleware.NewContractValidator(spec, middleware.ContractEnforce)
+	require.NoError(t, err)
+
+	serve := func(engine http.Handler, method, path string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var reader *bytes.Buffer
+		if body != nil {
+			jsonBody, _ := json.Marshal(body)
+			reader = bytes.NewBuffer(jsonBody)
+		} else {
+			reader = &bytes.Buffer{}
+		}
+		req := httptest.NewRequest(method, path, reader)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response
+	}
+
+	t.Run("Handlers honour the published contract", func(t *testing.T) {
+		// Arrange
+		router, mockTreasuryService, cleanup := buildTestRouter(t)
+		defer cleanup()
+		engine := router.WithContractValidator(enforcing).SetupRoutes()
+
+		date := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(true).Maybe()
+		mockTreasuryService.On("ProviderName").Return("us_treasury").Maybe()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(&entities.ExchangeRate{
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.EUR,
+			Rate:          0.85,
+			EffectiveDate: date.AddDate(0, 0, -15),
+		}, nil).Maybe()
+
+		// Act
+		created, transaction := serve(engine, "POST", "/api/v1/transactions", map[string]interface{}{
+			"description": "Office supplies",
+			"date":        "2024-01-15T10:30:00Z",
+			"amount":      42.50,
+		})
+		require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+		id := transaction["id"].(string)
+
+		responses := map[string]*httptest.ResponseRecorder{}
+		responses["get"], _ = serve(engine, "GET", "/api/v1/transactions/"+id, nil)
+		responses["get missing"], _ = serve(engine, "GET", "/api/v1/transactions/"+uuid.NewString(), nil)
+		responses["list"], _ = serve(engine, "GET", "/api/v1/transactions?page=1&size=10", nil)
+		responses["list converted"], _ = serve(engine, "GET", "/api/v1/transactions?currency=EUR", nil)
+		responses["descriptions"], _ = serve(engine, "GET", "/api/v1/transactions/descriptions?prefix=off", nil)
+		responses["convert"], _ = serve(engine, "POST", "/api/v1/transactions/"+id+"/convert", map[string]interface{}{"target_currency": "EUR"})
+		responses["convert amount"], _ = serve(engine, "POST", "/api/v1/convert", map[string]interface{}{
+			"amount":          10.00,
+			"target_currency": "EUR",
+			"date":            "2024-01-15T10:30:00Z",
+		})
+		responses["currency"], _ = serve(engine, "GET", "/api/v1/currencies/EUR", nil)
+		responses["health"], _ = serve(engine, "GET", "/health", nil)
+
+		// Assert
+		for name, w := range responses {
+			assert.NotContains(t, w.Body.String(), "does not match API contract", name)
+			assert.Less(t, w.Code, http.StatusInternalServerError, name)
+		}
+		assert.Equal(t, http.StatusOK, responses["convert"].Code)
+		assert.Equal(t, http.StatusNotFound, responses["get missing"].Code)
+	})
+
+	t.Run("Requests deviating from the contract are rejected", func(t *testing.T) {
+		// Arrange
+		router, _, cleanup := buildTestRouter(t)
+		defer cleanup()
+		engine := router.WithContractValidator(enforcing).SetupRoutes()
+
+		// Act
+		undocumented, undocumentedResponse := serve(engine, "POST", "/api/v1/transactions", map[string]interface{}{
+			"description": "Office supplies",
+			"date":        "2024-01-15T10:30:00Z",
+			"amount":      42.50,
+			"merchant":    "Staples",
+		})
+		wrongType, wrongTypeResponse := serve(engine, "POST", "/api/v1/transactions", map[string]interface{}{
+			"description": "Office supplies",
+			"date":        "15/01/2024",
+			"amount":      "42.50",
+		})
+		badQuery, badQueryResponse := serve(engine, "GET", "/api/v1/transactions?size=500", nil)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, undocumented.Code)
+		assert.Equal(t, "Request does not match API contract", undocumentedResponse["title"])
+		assert.Contains(t, undocumentedResponse["violations"], "merchant: is not a documented property")
+
+		assert.Equal(t, http.StatusBadRequest, wrongType.Code)
+		assert.Contains(t, wrongTypeResponse["violations"], "amount: must be a number")
+		assert.Contains(t, wrongTypeResponse["violations"], "date: must be an RFC 3339 date-time")
+
+		assert.Equal(t, http.StatusBadRequest, badQuery.Code)
+		assert.Contains(t, badQueryResponse["violations"], "query parameter size: must be at most 100")
+	})
+
+	t.Run("Responses deviating from the contract are replaced when enforcing", func(t *testing.T) {
+		// Arrange - a handler that has drifted from the documented Transaction schema
+		drifted := func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"id": c.Param("id"), "amount": "12.00"})
+		}
+		newEngine := func(validator *middleware.ContractValidator) *gin.Engine {
+			engine := gin.New()
+			engine.Use(validator.Handler())
+			engine.GET("/api/v1/transactions/:id", drifted)
+			return engine
+		}
+		reporting, err := middleware.NewContractValidator(spec, middleware.ContractReport)
+		require.NoError(t, err)
+		path := "/api/v1/transactions/7d0c5f5e-8d4e-4bb8-9d4a-3d6e3e0c8f11"
+
+		// Act
+		enforced, enforcedResponse := serve(newEngine(enforcing), "GET", path, nil)
+		reported, reportedResponse := serve(newEngine(reporting), "GET", path, nil)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, enforced.Code)
+		assert.Equal(t, "Response does not match API contract", enforcedResponse["title"])
+		assert.Contains(t, enforcedResponse["violations"], "amount: must be a number")
+		assert.Contains(t, enforcedResponse["violations"], "description: is required")
+
+		assert.Equal(t, http.StatusOK, reported.Code)
+		assert.Equal(t, "12.00", reportedResponse["amount"])
+	})
+
+	t.Run("Unknown modes are rejected and off disables validation", func(t *testing.T) {
+		// Act
+		off, offErr := middleware.NewContractValidator(spec, middleware.ContractOff)
+		_, invalidErr := middleware.NewContractValidator(spec, "strict")
+
+		// Assert
+		assert.NoError(t, offErr)
+		assert.Nil(t, off)
+		assert.Error(t, invalidErr)
+	})
+}
@@ -0,0 +1,222 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAmountAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	isKnown := func(code entities.CurrencyCode) bool { _, known := code.Info(); return known }
+	mockTreasuryService.On("SupportsCurrency", entities.CLP).Return(false).Maybe()
+	mockTreasuryService.On("SupportsCurrency", mock.MatchedBy(isKnown)).Return(true).Maybe()
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(false).Maybe()
+
+	post := func(body map[string]interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/v1/convert", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	t.Run("Successful conversion", func(t *testing.T) {
+		// Arrange
+		date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		exchangeRate := &entities.ExchangeRate{
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.EUR,
+			Rate:          0.90,
+			EffectiveDate: date.AddDate(0, -1, 0),
+		}
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(exchangeRate, nil).Once()
+
+		// Act
+		w, response := post(map[string]interface{}{
+			"amount":          100.00,
+			"target_currency": "eur",
+			"date":            "2024-03-01T00:00:00Z",
+		})
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "EUR", response["target_currency"])
+		assert.Equal(t, 0.90, response["exchange_rate"])
+		assert.Equal(t, 90.00, response["converted_amount"])
+	})
+
+	t.Run("Unknown currency code", func(t *testing.T) {
+		w, response := post(map[string]interface{}{
+			"amount":          10.00,
+			"target_currency": "XYZ",
+			"date":            "2024-03-01T00:00:00Z",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "Currency must be a supported 3-letter ISO 4217 code (e.g., EUR)", response["detail"])
+	})
+
+	t.Run("Unsupported currency", func(t *testing.T) {
+		w, response := post(map[string]interface{}{
+			"amount":          10.00,
+			"target_currency": "CLP",
+			"date":            "2024-03-01T00:00:00Z",
+		})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.NotEmpty(t, response["supported_currencies"])
+	})
+
+	t.Run("Missing amount", func(t *testing.T) {
+		w, _ := post(map[string]interface{}{
+			"target_currency": "EUR",
+			"date":            "2024-03-01T00:00:00Z",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Missing date", func(t *testing.T) {
+		w, _ := post(map[string]interface{}{
+			"amount":          10.00,
+			"target_currency": "EUR",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestExchangeRateAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	isKnown := func(code entities.CurrencyCode) bool { _, known := code.Info(); return known }
+	mockTreasuryService.On("SupportsCurrency", mock.MatchedBy(isKnown)).Return(true).Maybe()
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(false).Maybe()
+
+	get := func(query string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest("GET", "/api/v1/exchange-rates?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	t.Run("Returns the rate a conversion would use, caching it", func(t *testing.T) {
+		// Arrange
+		date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(&entities.ExchangeRate{
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.EUR,
+			Rate:          0.92,
+			EffectiveDate: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+		}, nil).Once()
+
+		// Act
+		w, response := get("from=USD&to=eur&date=2024-01-15")
+		cached, cachedResponse := get("to=EUR&date=2024-01-15")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "USD", response["from"])
+		assert.Equal(t, "EUR", response["to"])
+		assert.Equal(t, "2024-01-15T00:00:00Z", response["date"])
+		assert.Equal(t, 0.92, response["raw_exchange_rate"])
+		assert.Equal(t, 0.92, response["exchange_rate"])
+		assert.Equal(t, "2023-12-31T00:00:00Z", response["effective_date"])
+		assert.Equal(t, http.StatusOK, cached.Code)
+		assert.Equal(t, response, cachedResponse, "the second lookup is answered from the cache")
+		mockTreasuryService.AssertExpectations(t)
+	})
+
+	t.Run("No rate within 6 months of the date", func(t *testing.T) {
+		// Arrange
+		date := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.JPY, date).Return(&entities.ExchangeRate{
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.JPY,
+			Rate:          150,
+			EffectiveDate: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+		}, nil).Once()
+
+		// Act
+		w, _ := get("to=JPY&date=2024-09-01")
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("Invalid parameters are reported", func(t *testing.T) {
+		for _, query := range []string{"", "to=XYZ", "from=EUR&to=GBP", "to=EUR&date=15/01/2024"} {
+			w, response := get(query)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+			assert.NotEmpty(t, response["invalid_params"], query)
+		}
+	})
+}
+
+func TestRequestTimeoutAPI(t *testing.T) {
+	// Setup
+	router, mockTreasuryService, cleanup := buildTestRouter(t)
+	defer cleanup()
+	engine := router.WithRequestTimeouts(middleware.NewRequestTimeouts(time.Second, map[string]time.Duration{
+		"convert": 50 * time.Millisecond,
+	})).SetupRoutes()
+
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(true).Maybe()
+
+	t.Run("A stalled Treasury call is cancelled and answered 504", func(t *testing.T) {
+		// Arrange
+		cancelled := make(chan struct{})
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, mock.Anything).
+			Run(func(args mock.Arguments) {
+				<-args.Get(0).(context.Context).Done()
+				close(cancelled)
+			}).
+			Return(nil, fmt.Errorf("failed to fetch from Treasury API: %w", context.DeadlineExceeded)).Once()
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"amount":          100.00,
+			"target_currency": "EUR",
+			"date":            "2024-03-01T00:00:00Z",
+		})
+		req := httptest.NewRequest("POST", "/api/v1/convert", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		engine.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), problem.TypeTimeout)
+		select {
+		case <-cancelled:
+		default:
+			t.Fatal("Treasury call was not cancelled")
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCurrencyAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	t.Run("Supported currency", func(t *testing.T) {
+		mockTreasuryService.On("SupportsCurrency", entities.JPY).Return(true).Once()
+		mockTreasuryService.On("ProviderName").Return("us_treasury").Once()
+
+		// Act - lowercase codes are normalized
+		req := httptest.NewRequest("GET", "/api/v1/currencies/jpy", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "JPY", response["code"])
+		assert.Equal(t, "Japanese Yen", response["name"])
+		assert.Equal(t, float64(0), response["minor_units"])
+		assert.Equal(t, true, response["conversion_supported"])
+		assert.Equal(t, "us_treasury", response["provider"])
+	})
+
+	t.Run("Unknown currency", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/currencies/XYZ", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Malformed currency code", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/currencies/EURO", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	mockTreasuryService.AssertExpectations(t)
+}
@@ -0,0 +1,74 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIDocsEndpoints(t *testing.T) {
+	// Setup
+	router, _, cleanup := buildTestRouter(t)
+	defer cleanup()
+	engine := router.SetupRoutes()
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	t.Run("Serves the OpenAPI document", func(t *testing.T) {
+		// Act
+		w := serve("/openapi.json")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+		var document map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &document))
+		assert.Equal(t, "3.0.3", document["openapi"])
+		assert.Contains(t, document["paths"], "/api/v1/transactions")
+	})
+
+	t.Run("Serves Swagger UI pointing at the document", func(t *testing.T) {
+		// Act
+		w := serve("/swagger/index.html")
+		redirect := serve("/swagger")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, w.Body.String(), `url: "/openapi.json"`)
+		assert.Equal(t, http.StatusMovedPermanently, redirect.Code)
+		assert.Equal(t, "/swagger/index.html", redirect.Header().Get("Location"))
+	})
+
+	t.Run("Docs are served on the public listener", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.SetupPublicRoutes().ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Every business route is documented", func(t *testing.T) {
+		// Arrange
+		spec, err := openapi.Load()
+		require.NoError(t, err)
+
+		// Act & Assert - admin routes are internal and intentionally left out of the contract
+		for _, route := range engine.Routes() {
+			if !strings.HasPrefix(route.Path, "/api/") || strings.HasPrefix(route.Path, "/api/v1/admin/") {
+				continue
+			}
+			assert.NotNil(t, spec.Operation(route.Method, route.Path), "%s %s is missing from openapi.json", route.Method, route.Path)
+		}
+	})
+}
@@ -0,0 +1,240 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	mockTreasuryService.On("SupportsCurrency", entities.EUR).Return(true).Maybe()
+	mockTreasuryService.On("SupportsCurrency", entities.BRL).Return(true).Maybe()
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(false).Maybe()
+
+	query := func(body map[string]interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/graphql", bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	var ids []string
+	for _, purchase := range []map[string]interface{}{
+		{"description": "Hotel", "date": "2024-03-02T00:00:00Z", "amount": 200, "tags": []string{"trip"}},
+		{"description": "Taxi", "date": "2024-03-05T00:00:00Z", "amount": 25.5},
+		{"description": "Lunch", "date": "2024-05-15T00:00:00Z", "amount": 12.25},
+	} {
+		body, _ := json.Marshal(purchase)
+		req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		ids = append(ids, created["id"].(string))
+	}
+
+	t.Run("Only the selected fields are returned, in selection order", func(t *testing.T) {
+		// Act
+		w, response := query(map[string]interface{}{
+			"query": `{ transaction(id: "` + ids[0] + `") { description amount currency tags } }`,
+		})
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, response, "errors")
+		assert.Equal(t, map[string]interface{}{
+			"description": "Hotel",
+			"amount":      200.0,
+			"currency":    "USD",
+			"tags":        []interface{}{"trip"},
+		}, response["data"].(map[string]interface{})["transaction"])
+		assert.Contains(t, w.Body.String(), `{"description":"Hotel","amount":200,"currency":"USD","tags":["trip"]}`)
+	})
+
+	t.Run("Transactions are paged and filtered", func(t *testing.T) {
+		// Act
+		w, response := query(map[string]interface{}{
+			"query": `query Page($size: Int, $filter: TransactionFilter) {
+				transactions(size: $size, filter: $filter) {
+					total totalPages page size
+					items { ...summary }
+				}
+			}
+			fragment summary on Transaction { id description date }`,
+			"variables": map[string]interface{}{
+				"size":   1,
+				"filter": map[string]interface{}{"dateFrom": "2024-03-01", "dateTo": "2024-03-31"},
+			},
+		})
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		page := response["data"].(map[string]interface{})["transactions"].(map[string]interface{})
+		assert.Equal(t, 2.0, page["total"])
+		assert.Equal(t, 2.0, page["totalPages"])
+		assert.Equal(t, 1.0, page["page"])
+		assert.Equal(t, 1.0, page["size"])
+		items := page["items"].([]interface{})
+		require.Len(t, items, 1)
+		assert.Equal(t, "Taxi", items[0].(map[string]interface{})["description"])
+		assert.Equal(t, "2024-03-05T00:00:00Z", items[0].(map[string]interface{})["date"])
+	})
+
+	t.Run("convertedAmount converts each transaction", func(t *testing.T) {
+		// Arrange
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, mock.Anything).Return(&entities.ExchangeRate{
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.EUR,
+			Rate:          0.5,
+			EffectiveDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		}, nil)
+
+		// Act
+		w, response := query(map[string]interface{}{
+			"query": `{ transactions(filter: {tag: "trip"}) { items { amount eur: convertedAmount(currency: "eur") __typename } } }`,
+		})
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, response, "errors")
+		item := response["data"].(map[string]interface{})["transactions"].(map[string]interface{})["items"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, 200.0, item["amount"])
+		assert.Equal(t, 100.0, item["eur"])
+		assert.Equal(t, "Transaction", item["__typename"])
+	})
+
+	t.Run("A failed conversion only nulls its field", func(t *testing.T) {
+		// Arrange
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.BRL, mock.Anything).Return(nil, errs.Newf(errs.ErrRateUnavailable, "no exchange rate for BRL"))
+
+		// Act
+		w, response := query(map[string]interface{}{
+			"query":     `query($id: ID!) { transaction(id: $id) { description brl: convertedAmount(currency: "BRL") } }`,
+			"variables": map[string]interface{}{"id": ids[2]},
+		})
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		transaction := response["data"].(map[string]interface{})["transaction"].(map[string]interface{})
+		assert.Equal(t, "Lunch", transaction["description"])
+		assert.Nil(t, transaction["brl"])
+		assert.Contains(t, transaction, "brl")
+
+		graphqlErrors := response["errors"].([]interface{})
+		require.Len(t, graphqlErrors, 1)
+		graphqlError := graphqlErrors[0].(map[string]interface{})
+		assert.Equal(t, []interface{}{"transaction", "brl"}, graphqlError["path"])
+		assert.Equal(t, "RATE_UNAVAILABLE", graphqlError["extensions"].(map[string]interface{})["code"])
+	})
+
+	t.Run("An unknown transaction is null with a NOT_FOUND error", func(t *testing.T) {
+		// Act
+		w, response := query(map[string]interface{}{
+			"query": `{ transaction(id: "00000000-0000-0000-0000-000000000001") { id } }`,
+		})
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, response["data"].(map[string]interface{})["transaction"])
+		graphqlError := response["errors"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "NOT_FOUND", graphqlError["extensions"].(map[string]interface{})["code"])
+	})
+
+	t.Run("Directives include and skip fields", func(t *testing.T) {
+		// Act
+		_, response := query(map[string]interface{}{
+			"query":     `query($full: Boolean!) { transaction(id: "` + ids[1] + `") { id @skip(if: true) description amount @include(if: $full) } }`,
+			"variables": map[string]interface{}{"full": false},
+		})
+
+		// Assert
+		assert.Equal(t, map[string]interface{}{"description": "Taxi"}, response["data"].(map[string]interface{})["transaction"])
+	})
+
+	t.Run("Queries can be sent with GET", func(t *testing.T) {
+		// Arrange
+		params := url.Values{
+			"query":     {`query($id: ID!) { transaction(id: $id) { description } }`},
+			"variables": {`{"id": "` + ids[0] + `"}`},
+		}
+		req := httptest.NewRequest("GET", "/graphql?"+params.Encode(), nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data":{"transaction":{"description":"Hotel"}}}`, w.Body.String())
+	})
+
+	t.Run("Invalid documents are rejected before execution", func(t *testing.T) {
+		testCases := map[string]struct {
+			query string
+			code  string
+		}{
+			"syntax error":         {`{ transaction(id: "x") { id }`, "GRAPHQL_PARSE_FAILED"},
+			"unknown field":        {`{ transactions { items { secret } } }`, "GRAPHQL_VALIDATION_FAILED"},
+			"missing selection":    {`{ transactions }`, "GRAPHQL_VALIDATION_FAILED"},
+			"missing argument":     {`{ transaction { id } }`, "GRAPHQL_VALIDATION_FAILED"},
+			"undefined variable":   {`{ transaction(id: $id) { id } }`, "GRAPHQL_VALIDATION_FAILED"},
+			"mutation":             {`mutation { transaction(id: "x") { id } }`, "GRAPHQL_VALIDATION_FAILED"},
+			"self-spread fragment": {`{ transactions { ...page } } fragment page on TransactionPage { ...page }`, "GRAPHQL_VALIDATION_FAILED"},
+		}
+
+		for name, tc := range testCases {
+			w, response := query(map[string]interface{}{"query": tc.query})
+			assert.Equal(t, http.StatusBadRequest, w.Code, name)
+			assert.NotContains(t, response, "data", name)
+			graphqlError := response["errors"].([]interface{})[0].(map[string]interface{})
+			assert.Equal(t, tc.code, graphqlError["extensions"].(map[string]interface{})["code"], name)
+		}
+	})
+
+	t.Run("Invalid argument values are field errors", func(t *testing.T) {
+		// Act
+		w, response := query(map[string]interface{}{
+			"query": `{ transactions(size: 500) { total } }`,
+		})
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, response["data"])
+		graphqlError := response["errors"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, []interface{}{"transactions"}, graphqlError["path"])
+		assert.Equal(t, "BAD_USER_INPUT", graphqlError["extensions"].(map[string]interface{})["code"])
+	})
+
+	t.Run("The schema is published in SDL", func(t *testing.T) {
+		// Act
+		req := httptest.NewRequest("GET", "/graphql/schema", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.Contains(w.Body.String(), "convertedAmount(currency: String!): Float"))
+	})
+}
@@ -0,0 +1,98 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransactionIdempotencyAPI(t *testing.T) {
+	// Setup
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	create := func(key string, body map[string]interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response
+	}
+	count := func() float64 {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/transactions", nil))
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response["total"].(float64)
+	}
+	body := map[string]interface{}{"description": "Office supplies", "date": "2024-01-15T10:30:00Z", "amount": 42.50}
+
+	t.Run("A retry returns the same transaction instead of a duplicate", func(t *testing.T) {
+		// Act
+		first, created := create("retry-1", body)
+		second, replayed := create("retry-1", body)
+
+		// Assert
+		require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+		require.Equal(t, http.StatusCreated, second.Code, second.Body.String())
+		assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, created, replayed)
+		assert.Equal(t, float64(1), count())
+	})
+
+	t.Run("Concurrent retries create one transaction", func(t *testing.T) {
+		before := count()
+
+		var wg sync.WaitGroup
+		codes := make([]int, 5)
+		for i := range codes {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				w, _ := create("concurrent-1", body)
+				codes[i] = w.Code
+			}(i)
+		}
+		wg.Wait()
+
+		for _, code := range codes {
+			assert.Contains(t, []int{http.StatusCreated, http.StatusConflict}, code)
+		}
+		assert.Equal(t, before+1, count())
+	})
+
+	t.Run("Reusing a key with another body is rejected", func(t *testing.T) {
+		_, _ = create("reuse-1", body)
+
+		changed := map[string]interface{}{"description": "Office supplies", "date": "2024-01-15T10:30:00Z", "amount": 99}
+		w, response := create("reuse-1", changed)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, response["detail"], "different request body")
+	})
+
+	t.Run("Requests without a key are not deduplicated and invalid keys are rejected", func(t *testing.T) {
+		before := count()
+		_, first := create("", body)
+		_, second := create("", body)
+		assert.NotEqual(t, first["id"], second["id"])
+		assert.Equal(t, before+2, count())
+
+		w, _ := create("   ", body)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,158 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONAPIRepresentation(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	get := func(path, accept string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest("GET", path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var document map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &document))
+		return w, document
+	}
+
+	// Arrange - three transactions on the same day
+	var ids []string
+	for _, description := range []string{"Coffee", "Lunch", "Dinner"} {
+		body, _ := json.Marshal(map[string]interface{}{
+			"description": description,
+			"date":        "2024-01-15T10:30:00Z",
+			"amount":      10.0,
+		})
+		req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		ids = append(ids, created["id"].(string))
+	}
+
+	t.Run("Single transaction as a resource object", func(t *testing.T) {
+		// Act
+		w, document := get("/api/v1/transactions/"+ids[0], "application/vnd.api+json")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/vnd.api+json", w.Header().Get("Content-Type"))
+
+		data := document["data"].(map[string]interface{})
+		assert.Equal(t, "transactions", data["type"])
+		assert.Equal(t, ids[0], data["id"])
+		assert.Equal(t, "/api/v1/transactions/"+ids[0], data["links"].(map[string]interface{})["self"])
+
+		attributes := data["attributes"].(map[string]interface{})
+		assert.Equal(t, "Coffee", attributes["description"])
+		assert.Equal(t, 10.0, attributes["amount"])
+		assert.NotContains(t, attributes, "id")
+	})
+
+	t.Run("Collections carry pagination links and meta", func(t *testing.T) {
+		// Act - the query flag works without an Accept header
+		w, document := get("/api/v1/transactions?page=2&size=1&format=jsonapi", "")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, document["data"], 1)
+
+		meta := document["meta"].(map[string]interface{})
+		assert.Equal(t, 3.0, meta["total"])
+		assert.Equal(t, 3.0, meta["total_pages"])
+
+		links := document["links"].(map[string]interface{})
+		assert.Equal(t, "/api/v1/transactions?format=jsonapi&page=2&size=1", links["self"])
+		assert.Equal(t, "/api/v1/transactions?format=jsonapi&page=1&size=1", links["first"])
+		assert.Equal(t, "/api/v1/transactions?format=jsonapi&page=1&size=1", links["prev"])
+		assert.Equal(t, "/api/v1/transactions?format=jsonapi&page=3&size=1", links["next"])
+		assert.Equal(t, "/api/v1/transactions?format=jsonapi&page=3&size=1", links["last"])
+	})
+
+	t.Run("Last page has no next link", func(t *testing.T) {
+		_, document := get("/api/v1/transactions?page=3&size=1", "application/vnd.api+json")
+
+		links := document["links"].(map[string]interface{})
+		assert.NotContains(t, links, "next")
+		assert.Contains(t, links, "prev")
+	})
+
+	t.Run("Converted transactions relate to their currency", func(t *testing.T) {
+		// Arrange
+		date := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		mockTreasuryService.On("SupportsCurrency", entities.EUR).Return(true).Maybe()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(&entities.ExchangeRate{
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.EUR,
+			Rate:          0.9,
+			EffectiveDate: date.AddDate(0, 0, -15),
+		}, nil).Maybe()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError).Maybe()
+
+		// Act
+		_, document := get("/api/v1/transactions?currency=EUR&size=1", "application/vnd.api+json")
+
+		// Assert
+		resource := document["data"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, 9.0, resource["attributes"].(map[string]interface{})["converted_amount"])
+
+		currency := resource["relationships"].(map[string]interface{})["currency"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"type": "currencies", "id": "EUR"}, currency["data"])
+		assert.Equal(t, "/api/v1/currencies/EUR", currency["links"].(map[string]interface{})["related"])
+		assert.Equal(t, "EUR", document["meta"].(map[string]interface{})["currency"])
+	})
+
+	t.Run("Errors become error objects", func(t *testing.T) {
+		// Act
+		notFound, notFoundDocument := get("/api/v1/transactions/not-a-uuid", "application/vnd.api+json")
+		badQuery, badQueryDocument := get("/api/v1/transactions?page=0&size=abc", "application/vnd.api+json")
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, notFound.Code)
+		assert.NotContains(t, notFoundDocument, "data")
+		notFoundErrors := notFoundDocument["errors"].([]interface{})
+		require.Len(t, notFoundErrors, 1)
+		assert.Equal(t, "400", notFoundErrors[0].(map[string]interface{})["status"])
+		assert.Equal(t, "Invalid transaction ID format", notFoundErrors[0].(map[string]interface{})["title"])
+
+		assert.Equal(t, http.StatusBadRequest, badQuery.Code)
+		badQueryErrors := badQueryDocument["errors"].([]interface{})
+		require.Len(t, badQueryErrors, 2)
+		assert.Equal(t, map[string]interface{}{"parameter": "page"}, badQueryErrors[0].(map[string]interface{})["source"])
+		assert.Equal(t, map[string]interface{}{"parameter": "size"}, badQueryErrors[1].(map[string]interface{})["source"])
+	})
+
+	t.Run("Currency as a resource object", func(t *testing.T) {
+		// Arrange
+		mockTreasuryService.On("ProviderName").Return("us_treasury").Maybe()
+
+		// Act
+		_, document := get("/api/v1/currencies/EUR", "application/vnd.api+json")
+
+		// Assert
+		data := document["data"].(map[string]interface{})
+		assert.Equal(t, "currencies", data["type"])
+		assert.Equal(t, "EUR", data["id"])
+		assert.Equal(t, true, data["attributes"].(map[string]interface{})["conversion_supported"])
+	})
+}
@@ -0,0 +1,106 @@
+package api_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/auth"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jwtSecret = "jwt-secret-0123456789abcdefghijklmn"
+
+// issueJWT signs an HS256 token for the test identity provider
+func issueJWT(t *testing.T, roles ...string) string {
+	segment := func(value any) string {
+		data, err := json.Marshal(value)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	input := segment(map[string]any{"alg": "HS256", "typ": "JWT"}) + "." + segment(map[string]any{
+		"sub":   "user-42",
+		"iss":   "https://id.example.com",
+		"aud":   "purchase-api",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": roles,
+	})
+	mac := hmac.New(sha256.New, []byte(jwtSecret))
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWTBearerAuthAPI(t *testing.T) {
+	// Setup
+	app := buildTestApp(t)
+	defer app.cleanup()
+
+	verifier, err := auth.NewJWTVerifier(&config.JWTConfig{
+		Algorithm:  "HS256",
+		Secret:     jwtSecret,
+		Issuer:     "https://id.example.com",
+		Audience:   "purchase-api",
+		RolesClaim: "roles",
+	})
+	require.NoError(t, err)
+	require.NoError(t, app.apiTokens.EnsureBootstrapToken(bootstrapSecret))
+	router := app.router.WithTokenAuth(middleware.NewTokenAuth(app.apiTokens).WithBearer(verifier)).SetupRoutes()
+
+	send := func(method, path, bearer string, body interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	transaction := map[string]interface{}{"description": "Office supplies", "date": "2024-01-15T10:30:00Z", "amount": 42.50}
+
+	t.Run("Requests without a valid bearer token are rejected", func(t *testing.T) {
+		w := send("GET", "/api/v1/transactions", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+
+		w = send("GET", "/api/v1/transactions", issueJWT(t, "reader")+"x", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Readers can read but not write", func(t *testing.T) {
+		reader := issueJWT(t, "reader")
+
+		assert.Equal(t, http.StatusOK, send("GET", "/api/v1/transactions", reader, nil).Code)
+		assert.Equal(t, http.StatusForbidden, send("POST", "/api/v1/transactions", reader, transaction).Code)
+		assert.Equal(t, http.StatusForbidden, send("GET", "/api/v1/admin/tokens", reader, nil).Code)
+	})
+
+	t.Run("Writers can create and delete but not administer", func(t *testing.T) {
+		writer := issueJWT(t, "writer")
+
+		created := send("POST", "/api/v1/transactions", writer, transaction)
+		require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(created.Body.Bytes(), &body))
+
+		assert.Equal(t, http.StatusNoContent, send("DELETE", "/api/v1/transactions/"+body["id"].(string), writer, nil).Code)
+		assert.Equal(t, http.StatusForbidden, send("GET", "/api/v1/admin/tokens", writer, nil).Code)
+	})
+
+	t.Run("Admins reach admin routes and API keys keep working", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send("GET", "/api/v1/admin/tokens", issueJWT(t, "admin"), nil).Code)
+
+		w, _ := tokenClient(t, router)("GET", "/api/v1/transactions", bootstrapSecret, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
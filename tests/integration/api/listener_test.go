@@ -0,0 +1,46 @@
+package api_test
+
+import (
+	"net"
+	"runtime"
+	"testing"
+
+	httpInfra "github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListen(t *testing.T) {
+	t.Run("Two processes can share a port with SO_REUSEPORT", func(t *testing.T) {
+		if runtime.GOOS != "linux" && runtime.GOOS != "darwin" && runtime.GOOS != "freebsd" {
+			t.Skip("SO_REUSEPORT is not available on " + runtime.GOOS)
+		}
+
+		// Arrange
+		old, err := httpInfra.Listen("127.0.0.1:0", httpInfra.ListenerPublic, true)
+		require.NoError(t, err)
+		defer old.Close()
+
+		// Act
+		replacement, err := httpInfra.Listen(old.Addr().String(), httpInfra.ListenerPublic, true)
+
+		// Assert
+		require.NoError(t, err)
+		defer replacement.Close()
+		assert.Equal(t, old.Addr().String(), replacement.Addr().String())
+	})
+
+	t.Run("Without SO_REUSEPORT the port is exclusive", func(t *testing.T) {
+		// Arrange
+		old, err := httpInfra.Listen("127.0.0.1:0", httpInfra.ListenerPublic, false)
+		require.NoError(t, err)
+		defer old.Close()
+
+		// Act
+		_, err = httpInfra.Listen(old.Addr().String(), httpInfra.ListenerPublic, false)
+
+		// Assert
+		var opErr *net.OpError
+		assert.ErrorAs(t, err, &opErr)
+	})
+}
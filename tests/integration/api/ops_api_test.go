@@ -0,0 +1,93 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpsListenerRoutes(t *testing.T) {
+	// Setup
+	app := buildTestApp(t)
+	defer app.cleanup()
+	require.NoError(t, app.apiTokens.EnsureBootstrapToken(bootstrapSecret))
+	router := app.router.WithTokenAuth(middleware.NewTokenAuth(app.apiTokens))
+
+	public := asAdmin(router.SetupPublicRoutes())
+	ops := asAdmin(router.SetupOpsRoutes())
+
+	serve := func(engine http.Handler, method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	t.Run("Public listener only serves the business API", func(t *testing.T) {
+		// Act & Assert
+		assert.Equal(t, http.StatusOK, serve(public, "GET", "/api/v1/transactions").Code)
+		assert.Equal(t, http.StatusNotFound, serve(public, "GET", "/health").Code)
+		assert.Equal(t, http.StatusNotFound, serve(public, "GET", "/metrics").Code)
+		assert.Equal(t, http.StatusNotFound, serve(public, "GET", "/debug/pprof/").Code)
+		assert.Equal(t, http.StatusNotFound, serve(public, "GET", "/api/v1/admin/export").Code)
+		assert.NotContains(t, serve(public, "GET", "/").Body.String(), "/api/v1/admin")
+	})
+
+	t.Run("Ops listener serves health, metrics, profiling and admin", func(t *testing.T) {
+		// Act & Assert
+		assert.Equal(t, http.StatusOK, serve(ops, "GET", "/health").Code)
+		assert.Equal(t, http.StatusOK, serve(ops, "GET", "/api/v1/admin/export").Code)
+		assert.Equal(t, http.StatusOK, serve(ops, "GET", "/debug/pprof/").Code)
+		assert.Equal(t, http.StatusOK, serve(ops, "GET", "/debug/pprof/goroutine?debug=1").Code)
+		assert.Equal(t, http.StatusNotFound, serve(ops, "GET", "/api/v1/transactions").Code)
+	})
+
+	t.Run("Metrics are exposed in the Prometheus text format", func(t *testing.T) {
+		// Act
+		w := serve(ops, "GET", "/metrics")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+		assert.Contains(t, w.Body.String(), "purchase_api_database_up 1")
+		assert.Contains(t, w.Body.String(), "purchase_api_database_size_bytes ")
+		assert.Contains(t, w.Body.String(), `purchase_api_database_rows{table="transactions"} 0`)
+		assert.Contains(t, w.Body.String(), "purchase_api_uptime_seconds ")
+		assert.Contains(t, w.Body.String(), "# TYPE purchase_api_db_pool_wait_count_total counter")
+		assert.Contains(t, w.Body.String(), `purchase_api_db_pool_in_use_connections{pool="primary"} `)
+	})
+
+	t.Run("Deprecated v1 routes carry Deprecation headers; ops routes don't", func(t *testing.T) {
+		// Arrange
+		policy, err := middleware.ParseDeprecationPolicy("2025-01-01", "2025-06-30", "")
+		require.NoError(t, err)
+		engine := asAdmin(router.WithV1Deprecation(policy).SetupRoutes())
+
+		// Act
+		v1 := serve(engine, "GET", "/api/v1/transactions")
+		health := serve(engine, "GET", "/health")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, v1.Code)
+		assert.Equal(t, "@1735689600", v1.Header().Get("Deprecation"))
+		assert.Equal(t, "Mon, 30 Jun 2025 00:00:00 GMT", v1.Header().Get("Sunset"))
+		assert.Empty(t, health.Header().Get("Deprecation"))
+	})
+
+	t.Run("Without an ops listener metrics are served with the business API, profiling is not", func(t *testing.T) {
+		// Arrange
+		engine := asAdmin(router.SetupRoutes())
+
+		// Act
+		metrics := serve(engine, "GET", "/metrics")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, metrics.Code)
+		assert.Contains(t, metrics.Body.String(), "purchase_api_uptime_seconds ")
+		assert.Equal(t, http.StatusNotFound, serve(engine, "GET", "/debug/pprof/").Code)
+		assert.Contains(t, serve(engine, "GET", "/").Body.String(), "GET /metrics")
+	})
+}
@@ -0,0 +1,76 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblemDetailsAPI(t *testing.T) {
+	// Setup
+	app := buildTestApp(t)
+	defer app.cleanup()
+
+	require.NoError(t, app.apiTokens.EnsureBootstrapToken(bootstrapSecret))
+	router := app.router.WithTokenAuth(middleware.NewTokenAuth(app.apiTokens)).SetupRoutes()
+	send := tokenClient(t, router)
+
+	t.Run("Use case errors carry the type of their kind", func(t *testing.T) {
+		// Arrange
+		path := "/api/v1/transactions/" + uuid.New().String()
+
+		// Act
+		w, response := send("GET", path, bootstrapSecret, nil)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, problem.TypeNotFound, response["type"])
+		assert.Equal(t, "Failed to retrieve transaction", response["title"])
+		assert.Equal(t, float64(http.StatusNotFound), response["status"])
+		assert.Contains(t, response["detail"], "not found")
+		assert.Equal(t, path, response["instance"])
+		assert.Equal(t, w.Header().Get("X-Request-ID"), response["request_id"])
+		assert.NotEmpty(t, response["request_id"])
+	})
+
+	t.Run("Malformed requests are validation problems", func(t *testing.T) {
+		// Act
+		w, response := send("DELETE", "/api/v1/transactions/not-a-uuid", bootstrapSecret, nil)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, problem.TypeValidation, response["type"])
+		assert.Equal(t, "Transaction ID must be a valid UUID", response["detail"])
+	})
+
+	t.Run("Invalid query parameters are listed one by one", func(t *testing.T) {
+		// Act
+		w, response := send("GET", "/api/v1/transactions?page=x&size=1000", bootstrapSecret, nil)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "Invalid query parameters", response["title"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "page", "reason": `page must be an integer, got "x"`},
+			map[string]interface{}{"name": "size", "reason": "size must be between 1 and 100, got 1000"},
+		}, response["invalid_params"])
+	})
+
+	t.Run("Middleware rejections are problems too", func(t *testing.T) {
+		// Act
+		w, response := send("GET", "/api/v1/transactions", "pta_unknown", nil)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, problem.TypeUnauthorized, response["type"])
+		assert.Equal(t, "invalid api token", response["detail"])
+		assert.Equal(t, "/api/v1/transactions", response["instance"])
+	})
+}
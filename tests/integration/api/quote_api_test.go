@@ -0,0 +1,137 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateQuoteAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	isKnown := func(code entities.CurrencyCode) bool { _, known := code.Info(); return known }
+	mockTreasuryService.On("SupportsCurrency", mock.MatchedBy(isKnown)).Return(true).Maybe()
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(false).Maybe()
+
+	post := func(path string, body map[string]interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", path, bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	date := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.CAD, date).Return(&entities.ExchangeRate{
+		FromCurrency:  entities.USD,
+		ToCurrency:    entities.CAD,
+		Rate:          1.35,
+		EffectiveDate: date.AddDate(0, 0, -1),
+	}, nil).Once()
+
+	// Create the quote
+	w, quote := post("/api/v1/quotes", map[string]interface{}{
+		"target_currency": "CAD",
+		"date":            "2024-04-01T00:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	quoteID := quote["quote_id"].(string)
+	assert.Equal(t, 1.35, quote["exchange_rate"])
+	assert.NotEmpty(t, quote["expires_at"])
+
+	t.Run("Convert amount with quote uses the quoted rate", func(t *testing.T) {
+		// Act - the Treasury mock is not configured again, so the rate must come from the quote
+		w, response := post("/api/v1/convert", map[string]interface{}{
+			"amount":          100.00,
+			"target_currency": "CAD",
+			"date":            "2024-04-01T00:00:00Z",
+			"quote_id":        quoteID,
+		})
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1.35, response["exchange_rate"])
+		assert.Equal(t, 135.00, response["converted_amount"])
+		assert.Equal(t, quoteID, response["quote_id"])
+	})
+
+	t.Run("Convert transaction with quote", func(t *testing.T) {
+		// Arrange
+		w, created := post("/api/v1/transactions", map[string]interface{}{
+			"description": "Quoted purchase",
+			"date":        "2024-04-01T00:00:00Z",
+			"amount":      10.00,
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		// Act
+		w, response := post("/api/v1/transactions/"+created["id"].(string)+"/convert", map[string]interface{}{
+			"target_currency": "CAD",
+			"quote_id":        quoteID,
+		})
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 13.50, response["converted_amount"])
+		assert.Equal(t, quoteID, response["quote_id"])
+	})
+
+	t.Run("Quote for a different currency", func(t *testing.T) {
+		w, _ := post("/api/v1/convert", map[string]interface{}{
+			"amount":          100.00,
+			"target_currency": "EUR",
+			"date":            "2024-04-01T00:00:00Z",
+			"quote_id":        quoteID,
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Unknown quote", func(t *testing.T) {
+		w, _ := post("/api/v1/convert", map[string]interface{}{
+			"amount":          100.00,
+			"target_currency": "CAD",
+			"date":            "2024-04-01T00:00:00Z",
+			"quote_id":        uuid.New().String(),
+		})
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Malformed quote ID", func(t *testing.T) {
+		w, _ := post("/api/v1/convert", map[string]interface{}{
+			"amount":          100.00,
+			"target_currency": "CAD",
+			"date":            "2024-04-01T00:00:00Z",
+			"quote_id":        "not-a-uuid",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Unknown currency code", func(t *testing.T) {
+		w, response := post("/api/v1/quotes", map[string]interface{}{
+			"target_currency": "XYZ",
+			"date":            "2024-04-01T00:00:00Z",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "Currency must be a supported 3-letter ISO 4217 code (e.g., EUR)", response["detail"])
+	})
+
+	mockTreasuryService.AssertExpectations(t)
+}
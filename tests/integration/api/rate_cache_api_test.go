@@ -0,0 +1,130 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateCacheAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupAdminTestRouter(t)
+	defer cleanup()
+
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(true).Maybe()
+
+	send := func(method, path string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if w.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w, response
+	}
+
+	convert := func(currency string) {
+		w, _ := send("POST", "/api/v1/convert", map[string]interface{}{
+			"amount":          100.00,
+			"target_currency": currency,
+			"date":            "2024-03-01T00:00:00Z",
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for currency, rate := range map[entities.CurrencyCode]float64{entities.EUR: 0.9, entities.GBP: 0.8} {
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, currency, date).Return(&entities.ExchangeRate{
+			ID:            uuid.New(),
+			FromCurrency:  entities.USD,
+			ToCurrency:    currency,
+			Rate:          rate,
+			EffectiveDate: date.AddDate(0, -1, 0),
+		}, nil).Once()
+	}
+
+	t.Run("Reports cached rates with hits and misses", func(t *testing.T) {
+		// Arrange - the first conversion misses and caches the rate, the second hits it
+		convert("EUR")
+		convert("EUR")
+		convert("GBP")
+
+		// Act
+		w, response := send("GET", "/api/v1/admin/cache/rates", nil)
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		totals := response["totals"].(map[string]interface{})
+		assert.Equal(t, 2.0, totals["cached_rates"])
+		assert.Equal(t, 1.0, totals["hits"])
+		assert.Equal(t, 2.0, totals["misses"])
+
+		currencies := response["currencies"].([]interface{})
+		require.Len(t, currencies, 2)
+		eur := currencies[0].(map[string]interface{})
+		assert.Equal(t, "EUR", eur["currency"])
+		assert.Equal(t, 1.0, eur["cached_rates"])
+		assert.Equal(t, 0.5, eur["hit_ratio"])
+		assert.Equal(t, "2024-02-01T00:00:00Z", eur["newest_effective_date"])
+
+		w, filtered := send("GET", "/api/v1/admin/cache/rates?currency=gbp", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, filtered["currencies"], 1)
+
+		w, _ = send("GET", "/api/v1/admin/cache/rates?currency=EURO", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Evicts by currency and date", func(t *testing.T) {
+		w, _ := send("DELETE", "/api/v1/admin/cache/rates", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "evicting everything must be explicit")
+
+		w, _ = send("DELETE", "/api/v1/admin/cache/rates?currency=EUR&effective_date=01-02-2024", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w, response := send("DELETE", "/api/v1/admin/cache/rates?currency=EUR&effective_date=2024-01-31", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 0.0, response["evicted"])
+
+		w, response = send("DELETE", "/api/v1/admin/cache/rates?currency=eur&effective_date=2024-02-01", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "EUR", response["currency"])
+		assert.Equal(t, 1.0, response["evicted"])
+
+		// The next conversion goes back to the Treasury
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(&entities.ExchangeRate{
+			ID:            uuid.New(),
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.EUR,
+			Rate:          0.91,
+			EffectiveDate: date.AddDate(0, -1, 0),
+		}, nil).Once()
+		convert("EUR")
+		mockTreasuryService.AssertExpectations(t)
+	})
+
+	t.Run("Evicts everything when asked explicitly", func(t *testing.T) {
+		w, response := send("DELETE", "/api/v1/admin/cache/rates?all=true", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 2.0, response["evicted"])
+
+		w, inspected := send("GET", "/api/v1/admin/cache/rates", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 0.0, inspected["totals"].(map[string]interface{})["cached_rates"])
+	})
+}
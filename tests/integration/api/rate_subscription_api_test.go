@@ -0,0 +1,88 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateSubscriptionAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	mockTreasuryService.On("SupportsCurrency", entities.CurrencyCode("XXX")).Return(false).Maybe()
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(true).Maybe()
+
+	send := func(method, path, apiKey string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if w.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w, response
+	}
+
+	t.Run("Subscription lifecycle is scoped to the API key", func(t *testing.T) {
+		// Subscribe, repeating a currency
+		w, subscribed := send("POST", "/api/v1/rates/subscriptions", "key-a", map[string]interface{}{
+			"currencies": []string{"eur", "BRL", "EUR"},
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		data := subscribed["data"].([]interface{})
+		require.Len(t, data, 2)
+		assert.Equal(t, 100.0, subscribed["fresh_for_days"])
+
+		first := data[0].(map[string]interface{})
+		assert.Equal(t, "BRL", first["currency"])
+		assert.Equal(t, "missing", first["status"])
+
+		// Another key sees none of them
+		w, other := send("GET", "/api/v1/rates/subscriptions", "key-b", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, other["data"])
+
+		// Unsubscribe
+		w, _ = send("DELETE", "/api/v1/rates/subscriptions/brl", "key-a", nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w, listed := send("GET", "/api/v1/rates/subscriptions", "key-a", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, listed["data"], 1)
+
+		w, _ = send("DELETE", "/api/v1/rates/subscriptions/BRL", "key-a", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Rejects unsupported currencies", func(t *testing.T) {
+		w, response := send("POST", "/api/v1/rates/subscriptions", "key-a", map[string]interface{}{
+			"currencies": []string{"EUR", "XXX"},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, response["detail"], "XXX")
+	})
+
+	t.Run("Rejects an empty currency list", func(t *testing.T) {
+		w, _ := send("POST", "/api/v1/rates/subscriptions", "key-a", map[string]interface{}{
+			"currencies": []string{},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
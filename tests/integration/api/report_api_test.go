@@ -0,0 +1,123 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(true).Maybe()
+
+	get := func(path string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	for _, purchase := range []map[string]interface{}{
+		{"description": "Hotel", "date": "2024-03-02T00:00:00Z", "amount": 200},
+		{"description": "Taxi", "date": "2024-03-31T23:30:00Z", "amount": 25.5},
+		{"description": "Lunch", "date": "2024-05-15T12:00:00Z", "amount": 12.25},
+		{"description": "Flight", "date": "2023-12-20T00:00:00Z", "amount": 480},
+	} {
+		body, _ := json.Marshal(purchase)
+		req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	t.Run("Monthly summary is aggregated per period", func(t *testing.T) {
+		// Act
+		w, response := get("/api/v1/reports/summary?from=2024-01-01&to=2024-12-31&group_by=month")
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2024-01-01", response["from"])
+		assert.Equal(t, "2024-12-31", response["to"])
+		assert.Equal(t, "USD", response["currency"])
+
+		periods := response["periods"].([]interface{})
+		require.Len(t, periods, 2)
+		march := periods[0].(map[string]interface{})
+		assert.Equal(t, "2024-03", march["period"])
+		assert.Equal(t, 2.0, march["count"])
+		assert.Equal(t, 225.5, march["total"])
+		assert.Equal(t, 112.75, march["average"])
+		assert.Equal(t, 25.5, march["min"])
+		assert.Equal(t, 200.0, march["max"])
+		assert.Equal(t, "2024-05", periods[1].(map[string]interface{})["period"])
+
+		totals := response["totals"].(map[string]interface{})
+		assert.Equal(t, 3.0, totals["count"])
+		assert.Equal(t, 237.75, totals["total"])
+		assert.Equal(t, 79.25, totals["average"])
+		assert.NotContains(t, totals, "period")
+	})
+
+	t.Run("Yearly summary", func(t *testing.T) {
+		// Act
+		w, response := get("/api/v1/reports/summary?from=2023-01-01&to=2024-12-31&group_by=year")
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		periods := response["periods"].([]interface{})
+		require.Len(t, periods, 2)
+		assert.Equal(t, "2023", periods[0].(map[string]interface{})["period"])
+		assert.Equal(t, 480.0, periods[0].(map[string]interface{})["total"])
+	})
+
+	t.Run("Summary converted to another currency", func(t *testing.T) {
+		// Arrange
+		end := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, end).Return(&entities.ExchangeRate{
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.EUR,
+			Rate:          0.9,
+			EffectiveDate: end,
+		}, nil).Once()
+
+		// Act
+		w, response := get("/api/v1/reports/summary?from=2024-01-01&to=2024-12-31&currency=eur")
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "EUR", response["currency"])
+		assert.Equal(t, 0.9, response["exchange_rate"])
+		march := response["periods"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, 202.95, march["total"])
+		assert.Equal(t, 180.0, march["max"])
+		assert.Equal(t, 213.98, response["totals"].(map[string]interface{})["total"])
+	})
+
+	t.Run("Invalid parameters are rejected", func(t *testing.T) {
+		testCases := map[string]string{
+			"unknown grouping": "/api/v1/reports/summary?group_by=week",
+			"malformed date":   "/api/v1/reports/summary?from=03/01/2024",
+			"reversed range":   "/api/v1/reports/summary?from=2024-05-01&to=2024-01-01",
+			"bad currency":     "/api/v1/reports/summary?currency=EURO",
+		}
+
+		for name, path := range testCases {
+			w, _ := get(path)
+			assert.Equal(t, http.StatusBadRequest, w.Code, name)
+		}
+	})
+}
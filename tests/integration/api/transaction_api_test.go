@@ -2,96 +2,164 @@ package api_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/events"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/graphql"
 	httpInfra "github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/handlers"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/storage"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
 	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
-// setupTestRouter creates a test router with real dependencies
-func setupTestRouter(t *testing.T) (*gin.Engine, func()) {
-	// Create in-memory database
-	db, err := database.NewSQLiteDB(":memory:")
-	require.NoError(t, err)
-
-	// Initialize repositories
-	transactionRepo := database.NewTransactionRepository(db.GetDB())
-	exchangeRateRepo := database.NewExchangeRateRepository(db.GetDB())
-
-	// Initialize validator
-	validator := validator.New()
-
-	// Initialize mock treasury service for tests
-	mockTreasuryService := &mocks.MockTreasuryService{}
-
-	// Initialize use cases
-	createTransactionUseCase := usecases.NewCreateTransactionUseCase(transactionRepo, validator)
-	getTransactionUseCase := usecases.NewGetTransactionUseCase(transactionRepo)
-	listTransactionsUseCase := usecases.NewListTransactionsUseCase(transactionRepo, validator)
-	convertTransactionUseCase := usecases.NewConvertTransactionUseCase(transactionRepo, exchangeRateRepo, mockTreasuryService, validator)
-
-	// Initialize handlers
-	transactionHandler := handlers.NewTransactionHandler(
-		createTransactionUseCase,
-		getTransactionUseCase,
-		listTransactionsUseCase,
-		convertTransactionUseCase,
-	)
-
-	// Initialize test logger (silent for tests)
-	testLogger := logger.NewLogger(logger.LoggerConfig{
-		Level:  "ERROR",
-		Format: "text",
-	})
+// sqliteStats measures the test database the way storage.Storage does
+type sqliteStats struct {
+	db *database.SQLiteDB
+}
 
-	// Initialize router
-	router := httpInfra.NewRouter(transactionHandler, testLogger)
-	ginRouter := router.SetupRoutes()
+func (s sqliteStats) Stats(ctx context.Context) (dto.DatabaseStats, error) {
+	size, err := s.db.Size(ctx)
+	if err != nil {
+		return dto.DatabaseStats{}, err
+	}
+	counts, err := database.RowCounts(ctx, s.db.GetDB())
+	if err != nil {
+		return dto.DatabaseStats{}, err
+	}
+	return dto.DatabaseStats{SizeBytes: size, RowCounts: counts}, nil
+}
 
-	// Cleanup function
-	cleanup := func() {
-		db.Close()
+func (s sqliteStats) PoolStats() []dto.ConnectionPoolStats {
+	sqlDB, err := s.db.GetDB().DB()
+	if err != nil {
+		return nil
 	}
+	stats := sqlDB.Stats()
+	return []dto.ConnectionPoolStats{{Pool: "primary", MaxOpen: stats.MaxOpenConnections, Open: stats.OpenConnections, InUse: stats.InUse, Idle: stats.Idle}}
+}
 
-	return ginRouter, cleanup
+// setupTestRouter creates a test router with real dependencies
+func setupTestRouter(t *testing.T) (*gin.Engine, func()) {
+	router, _, cleanup := setupTestRouterWithMock(t)
+	return router, cleanup
 }
 
 // setupTestRouterWithMock creates a test router and returns the mock treasury service for configuration
 func setupTestRouterWithMock(t *testing.T) (*gin.Engine, *mocks.MockTreasuryService, func()) {
+	router, mockTreasuryService, cleanup := buildTestRouter(t)
+	return router.SetupRoutes(), mockTreasuryService, cleanup
+}
+
+// buildTestRouter wires real dependencies into a Router so tests can choose which engine to set up
+func buildTestRouter(t *testing.T) (*httpInfra.Router, *mocks.MockTreasuryService, func()) {
+	app := buildTestApp(t)
+	return app.router, app.treasury, app.cleanup
+}
+
+// testApp is a wired Router together with the dependencies tests reach into
+type testApp struct {
+	router    *httpInfra.Router
+	treasury  *mocks.MockTreasuryService
+	apiTokens *usecases.ManageAPITokensUseCase
+	events    *events.Bus
+	cleanup   func()
+}
+
+// buildTestApp wires real dependencies the way cmd/server does
+func buildTestApp(t *testing.T) *testApp {
 	// Create in-memory database
-	db, err := database.NewSQLiteDB(":memory:")
+	db, err := database.NewSQLiteDB(":memory:", database.SQLiteConfig{})
 	require.NoError(t, err)
+	require.NoError(t, db.Migrate())
 
 	// Initialize repositories
 	transactionRepo := database.NewTransactionRepository(db.GetDB())
-	exchangeRateRepo := database.NewExchangeRateRepository(db.GetDB())
+	rateCacheRecorder := activity.NewRecorder()
+	exchangeRateRepo := storage.NewInstrumentedExchangeRateRepository(database.NewExchangeRateRepository(db.GetDB()), rateCacheRecorder)
+	quoteRepo := database.NewQuoteRepository(db.GetDB())
+	conversionRecordRepo := database.NewConversionRecordRepository(db.GetDB())
+	conversionBatchRepo := database.NewConversionBatchRepository(db.GetDB())
+	budgetRepo := database.NewBudgetRepository(db.GetDB())
+	categoryRepo := database.NewCategoryRepository(db.GetDB())
+	reportRepo := database.NewReportRepository(db.GetDB())
+	rateSubscriptionRepo := database.NewRateSubscriptionRepository(db.GetDB())
+	apiTokenRepo := database.NewAPITokenRepository(db.GetDB())
+	webhookRepo := database.NewWebhookRepository(db.GetDB())
+	auditLogRepo := database.NewAuditLogRepository(db.GetDB())
+	conversionHistoryRepo := database.NewConversionHistoryRepository(db.GetDB())
 
 	// Initialize validator
-	validator := validator.New()
+	validator := validation.NewValidator()
 
 	// Initialize mock treasury service for tests
 	mockTreasuryService := &mocks.MockTreasuryService{}
 
 	// Initialize use cases
-	createTransactionUseCase := usecases.NewCreateTransactionUseCase(transactionRepo, validator)
+	eventBus := events.NewBus()
+	convertTransactionUseCase := usecases.NewConvertTransactionUseCase(transactionRepo, exchangeRateRepo, quoteRepo, mockTreasuryService, nil, validator).
+		WithEventPublisher(eventBus).
+		WithConversionHistory(conversionHistoryRepo)
+	dispatchWebhooksUseCase := usecases.NewDispatchWebhooksUseCase(webhookRepo, external.NewWebhookSender(&config.WebhookConfig{TimeoutSeconds: 5}), 3, time.Minute, 10*time.Second)
+	eventBus.Subscribe(entities.EventTransactionCreated, dispatchWebhooksUseCase.OnTransactionEvent)
+	eventBus.Subscribe(entities.EventTransactionConverted, dispatchWebhooksUseCase.OnTransactionEvent)
+	transactionRepo = usecases.NewPublishingTransactionRepository(transactionRepo, eventBus, clock.System())
+
+	createTransactionUseCase := usecases.NewCreateTransactionUseCase(transactionRepo, validator).
+		WithCategories(categoryRepo).
+		WithCurrencies(convertTransactionUseCase).
+		WithIdempotency(database.NewIdempotencyKeyRepository(db.GetDB()), 24*time.Hour)
 	getTransactionUseCase := usecases.NewGetTransactionUseCase(transactionRepo)
-	listTransactionsUseCase := usecases.NewListTransactionsUseCase(transactionRepo, validator)
-	convertTransactionUseCase := usecases.NewConvertTransactionUseCase(transactionRepo, exchangeRateRepo, mockTreasuryService, validator)
+	listTransactionsUseCase := usecases.NewListTransactionsUseCase(transactionRepo, convertTransactionUseCase, validator)
+	suggestDescriptionsUseCase := usecases.NewSuggestDescriptionsUseCase(transactionRepo, validator)
+	restoreTransactionUseCase := usecases.NewRestoreTransactionUseCase(transactionRepo)
+	deleteTransactionUseCase := usecases.NewDeleteTransactionUseCase(transactionRepo)
+	convertAmountUseCase := usecases.NewConvertAmountUseCase(convertTransactionUseCase, convertTransactionUseCase, nil, validator)
+	getExchangeRateUseCase := usecases.NewGetExchangeRateUseCase(convertTransactionUseCase, nil, validator)
+	createQuoteUseCase := usecases.NewCreateQuoteUseCase(quoteRepo, convertTransactionUseCase, 15*time.Minute, validator)
+	exportDatasetUseCase := usecases.NewExportDatasetUseCase(transactionRepo, exchangeRateRepo, conversionHistoryRepo)
+	monitorDatabaseUseCase := usecases.NewMonitorDatabaseUseCase(sqliteStats{db}, 0, 80, false)
+	importDatasetUseCase := usecases.NewImportDatasetUseCase(transactionRepo, exchangeRateRepo, conversionHistoryRepo, monitorDatabaseUseCase, validator).
+		WithUnitOfWork(database.NewUnitOfWork(db.GetDB()))
+	importTransactionsUseCase := usecases.NewImportTransactionsUseCase(transactionRepo, monitorDatabaseUseCase, validator).
+		WithCategories(categoryRepo)
+	updateTransactionCategoryUseCase := usecases.NewUpdateTransactionCategoryUseCase(transactionRepo, categoryRepo, validator)
+	batchConversionUseCase := usecases.NewBatchConversionUseCase(transactionRepo, conversionBatchRepo, conversionRecordRepo, convertTransactionUseCase, 2, validator)
+	getCurrencyUseCase := usecases.NewGetCurrencyUseCase(mockTreasuryService)
+	manageBudgetsUseCase := usecases.NewManageBudgetsUseCase(budgetRepo, convertTransactionUseCase, validator)
+	manageCategoriesUseCase := usecases.NewManageCategoriesUseCase(categoryRepo, transactionRepo, budgetRepo, validator)
+	summarizeSpendingUseCase := usecases.NewSummarizeSpendingUseCase(reportRepo, convertTransactionUseCase, validator)
+	manageRateSubscriptionsUseCase := usecases.NewManageRateSubscriptionsUseCase(rateSubscriptionRepo, exchangeRateRepo, convertTransactionUseCase, 100*24*time.Hour)
+	manageWebhooksUseCase := usecases.NewManageWebhooksUseCase(webhookRepo, validator)
+	auditLogUseCase := usecases.NewAuditLogUseCase(auditLogRepo)
+	listConversionsUseCase := usecases.NewListConversionsUseCase(transactionRepo, conversionHistoryRepo)
+	manageAPITokensUseCase := usecases.NewManageAPITokensUseCase(apiTokenRepo, validator)
+	manageRateCacheUseCase := usecases.NewManageRateCacheUseCase(exchangeRateRepo, rateCacheRecorder, time.Now())
+	checkHealthUseCase := usecases.NewCheckHealthUseCase("test", time.Now(), usecases.HealthDependency{Name: "database", Pinger: db})
 
 	// Initialize handlers
 	transactionHandler := handlers.NewTransactionHandler(
@@ -99,7 +167,27 @@ func setupTestRouterWithMock(t *testing.T) (*gin.Engine, *mocks.MockTreasuryServ
 		getTransactionUseCase,
 		listTransactionsUseCase,
 		convertTransactionUseCase,
+		suggestDescriptionsUseCase,
+		restoreTransactionUseCase,
+		deleteTransactionUseCase,
+		importTransactionsUseCase,
+		updateTransactionCategoryUseCase,
+		auditLogUseCase,
+		listConversionsUseCase,
 	)
+	currencyHandler := handlers.NewCurrencyHandler(getCurrencyUseCase)
+	conversionHandler := handlers.NewConversionHandler(convertAmountUseCase, createQuoteUseCase, getExchangeRateUseCase)
+	budgetHandler := handlers.NewBudgetHandler(manageBudgetsUseCase)
+	categoryHandler := handlers.NewCategoryHandler(manageCategoriesUseCase)
+	reportHandler := handlers.NewReportHandler(summarizeSpendingUseCase)
+	graphqlHandler := handlers.NewGraphQLHandler(graphql.NewExecutor(getTransactionUseCase, listTransactionsUseCase, convertTransactionUseCase))
+	rateSubscriptionHandler := handlers.NewRateSubscriptionHandler(manageRateSubscriptionsUseCase)
+	webhookHandler := handlers.NewWebhookHandler(manageWebhooksUseCase)
+	auditHandler := handlers.NewAuditHandler(auditLogUseCase)
+	adminHandler := handlers.NewAdminHandler(exportDatasetUseCase, importDatasetUseCase, batchConversionUseCase, monitorDatabaseUseCase, manageRateCacheUseCase)
+	apiTokenHandler := handlers.NewAPITokenHandler(manageAPITokensUseCase)
+	healthHandler := handlers.NewHealthHandler(checkHealthUseCase)
+	metricsHandler := handlers.NewMetricsHandler(monitorDatabaseUseCase, nil, time.Now())
 
 	// Initialize test logger (silent for tests)
 	testLogger := logger.NewLogger(logger.LoggerConfig{
@@ -108,15 +196,22 @@ func setupTestRouterWithMock(t *testing.T) (*gin.Engine, *mocks.MockTreasuryServ
 	})
 
 	// Initialize router
-	router := httpInfra.NewRouter(transactionHandler, testLogger)
-	ginRouter := router.SetupRoutes()
+	adminHandler.WithLogLevel(testLogger)
+	router := httpInfra.NewRouter(transactionHandler, currencyHandler, conversionHandler, budgetHandler, categoryHandler, reportHandler, graphqlHandler, rateSubscriptionHandler, webhookHandler, auditHandler, adminHandler, apiTokenHandler, healthHandler, metricsHandler, nil, nil, testLogger)
 
 	// Cleanup function
 	cleanup := func() {
+		eventBus.Wait()
 		db.Close()
 	}
 
-	return ginRouter, mockTreasuryService, cleanup
+	return &testApp{
+		router:    router,
+		treasury:  mockTreasuryService,
+		apiTokens: manageAPITokensUseCase,
+		events:    eventBus,
+		cleanup:   cleanup,
+	}
 }
 
 func TestCreateTransactionAPI(t *testing.T) {
@@ -148,6 +243,7 @@ func TestCreateTransactionAPI(t *testing.T) {
 		assert.NotEmpty(t, response["id"])
 		assert.Equal(t, "Test Purchase", response["description"])
 		assert.Equal(t, 99.99, response["amount"])
+		assert.Equal(t, "USD", response["currency"])
 		assert.NotEmpty(t, response["created_at"])
 	})
 
@@ -173,7 +269,7 @@ func TestCreateTransactionAPI(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		assert.Contains(t, response["error"], "Failed to create transaction")
+		assert.Contains(t, response["title"], "Failed to create transaction")
 	})
 
 	t.Run("Invalid request - negative amount", func(t *testing.T) {
@@ -198,7 +294,28 @@ func TestCreateTransactionAPI(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		assert.Contains(t, response["error"], "Failed to create transaction")
+		assert.Contains(t, response["title"], "Failed to create transaction")
+	})
+
+	t.Run("Non-USD currency is rejected with cross rates disabled", func(t *testing.T) {
+		// Arrange
+		requestBody := map[string]interface{}{
+			"description": "Paris hotel",
+			"date":        "2024-01-15T10:30:00Z",
+			"amount":      120.00,
+			"currency":    "EUR",
+		}
+		jsonBody, _ := json.Marshal(requestBody)
+
+		// Act
+		req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "problem:validation")
 	})
 
 	t.Run("Invalid JSON format", func(t *testing.T) {
@@ -271,8 +388,8 @@ func TestGetTransactionAPI(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		assert.Contains(t, response["error"], "Failed to retrieve transaction")
-		assert.Contains(t, response["details"], "not found")
+		assert.Contains(t, response["title"], "Failed to retrieve transaction")
+		assert.Contains(t, response["detail"], "not found")
 	})
 
 	t.Run("Invalid UUID format", func(t *testing.T) {
@@ -288,7 +405,7 @@ func TestGetTransactionAPI(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		assert.Contains(t, response["error"], "Invalid transaction ID format")
+		assert.Contains(t, response["title"], "Invalid transaction ID format")
 	})
 }
 
@@ -359,6 +476,53 @@ func TestListTransactionsAPI(t *testing.T) {
 		assert.LessOrEqual(t, len(data), 2) // Should have at most 2 items
 	})
 
+	t.Run("List transactions - invalid pagination parameters", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			query     string
+			parameter string
+		}{
+			{"Size above maximum", "size=500", "size"},
+			{"Zero page", "page=0", "page"},
+			{"Non-numeric page", "page=abc", "page"},
+			{"Negative size", "size=-5", "size"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// Act
+				req := httptest.NewRequest("GET", "/api/v1/transactions?"+tc.query, nil)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				// Assert
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+
+				var response map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, "Invalid query parameters", response["title"])
+				invalidParams := response["invalid_params"].([]interface{})
+				require.Len(t, invalidParams, 1)
+				assert.Equal(t, tc.parameter, invalidParams[0].(map[string]interface{})["name"])
+			})
+		}
+	})
+
+	t.Run("List transactions - reports every invalid parameter", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/transactions?page=x&size=1000", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Len(t, response["invalid_params"], 2)
+	})
+
 	t.Run("List transactions - empty result", func(t *testing.T) {
 		// Create fresh router with empty database
 		freshRouter, cleanup := setupTestRouter(t)
@@ -382,6 +546,563 @@ func TestListTransactionsAPI(t *testing.T) {
 	})
 }
 
+func TestListTransactionsFilterAPI(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	for _, tx := range []struct {
+		description string
+		date        string
+		amount      float64
+	}{
+		{"Coffee beans", "2024-01-05T09:00:00Z", 12.50},
+		{"Office coffee machine", "2024-02-10T15:00:00Z", 349.99},
+		{"Train ticket", "2024-02-29T23:30:00Z", 48.00},
+		{"Hotel", "2024-03-15T12:00:00Z", 220.00},
+	} {
+		body, _ := json.Marshal(map[string]interface{}{"description": tx.description, "date": tx.date, "amount": tx.amount})
+		req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	list := func(query string) (int, []string) {
+		req := httptest.NewRequest("GET", "/api/v1/transactions?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			return w.Code, nil
+		}
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		var descriptions []string
+		for _, item := range response["data"].([]interface{}) {
+			descriptions = append(descriptions, item.(map[string]interface{})["description"].(string))
+		}
+		assert.Equal(t, float64(len(descriptions)), response["total"])
+		return w.Code, descriptions
+	}
+
+	t.Run("Filters by date range, amount and description", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			query    string
+			expected []string
+		}{
+			{"Date range includes both days", "date_from=2024-02-10&date_to=2024-02-29", []string{"Train ticket", "Office coffee machine"}},
+			{"Open-ended date range", "date_from=2024-03-01", []string{"Hotel"}},
+			{"Amount range", "min_amount=48&max_amount=220", []string{"Hotel", "Train ticket"}},
+			{"Description is case-insensitive", "description_contains=COFFEE", []string{"Office coffee machine", "Coffee beans"}},
+			{"Filters combine", "description_contains=coffee&max_amount=100", []string{"Coffee beans"}},
+			{"LIKE wildcards are literal", "description_contains=%25", nil},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				code, descriptions := list(tc.query)
+
+				assert.Equal(t, http.StatusOK, code)
+				assert.Equal(t, tc.expected, descriptions)
+			})
+		}
+	})
+
+	t.Run("Rejects invalid filters", func(t *testing.T) {
+		for _, query := range []string{
+			"date_from=2024-13-01",
+			"min_amount=-5",
+			"max_amount=abc",
+			"date_from=2024-03-01&date_to=2024-02-01",
+			"min_amount=100&max_amount=10",
+			"trash=true&description_contains=coffee",
+		} {
+			code, _ := list(query)
+			assert.Equal(t, http.StatusBadRequest, code, query)
+		}
+	})
+}
+
+func TestListTransactionsCursorAPI(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	for i := range 5 {
+		body, _ := json.Marshal(map[string]interface{}{"description": fmt.Sprintf("Cursor %d", i), "date": "2024-01-05T09:00:00Z", "amount": 10})
+		req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	list := func(query string) (*httptest.ResponseRecorder, dto.ListTransactionsResponse) {
+		req := httptest.NewRequest("GET", "/api/v1/transactions?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response dto.ListTransactionsResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w, response
+	}
+
+	t.Run("Cursor pages continue the first page without repeats", func(t *testing.T) {
+		w, first := list("size=2")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NotEmpty(t, first.NextCursor)
+		assert.Equal(t, first.NextCursor, w.Header().Get("X-Next-Cursor"))
+
+		seen := map[uuid.UUID]bool{}
+		for _, item := range first.Data {
+			seen[item.ID] = true
+		}
+		cursor := first.NextCursor
+		for cursor != "" {
+			w, page := list("size=2&cursor=" + cursor)
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Zero(t, page.Page)
+			assert.Empty(t, w.Header().Get("X-Total-Count"))
+			for _, item := range page.Data {
+				assert.False(t, seen[item.ID], "transaction listed twice")
+				seen[item.ID] = true
+			}
+			cursor = page.NextCursor
+		}
+		assert.Len(t, seen, 5)
+	})
+
+	t.Run("JSON:API cursor pages link to the next cursor", func(t *testing.T) {
+		_, first := list("size=2")
+
+		req := httptest.NewRequest("GET", "/api/v1/transactions?size=2&format=jsonapi&cursor="+first.NextCursor, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var document dto.JSONAPIDocument
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &document))
+		assert.Contains(t, document.Links["next"], "cursor=")
+		assert.NotContains(t, document.Links, "last")
+		assert.NotContains(t, document.Meta, "total")
+	})
+
+	t.Run("Invalid cursors are rejected", func(t *testing.T) {
+		_, first := list("size=2")
+
+		for _, query := range []string{"cursor=garbage", "page=2&cursor=" + first.NextCursor, "trash=true&cursor=" + first.NextCursor} {
+			w, _ := list(query)
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
+
+func TestListTransactionsConditionalAPI(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	list := func(ifModifiedSince string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/transactions", nil)
+		if ifModifiedSince != "" {
+			req.Header.Set("If-Modified-Since", ifModifiedSince)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Empty database has no Last-Modified", func(t *testing.T) {
+		w := list("")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Last-Modified"))
+	})
+
+	// Arrange - store one transaction
+	requestBody, _ := json.Marshal(map[string]interface{}{
+		"description": "Polled purchase",
+		"date":        time.Now().Format(time.RFC3339),
+		"amount":      12.5,
+	})
+	req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	first := list("")
+	require.Equal(t, http.StatusOK, first.Code)
+	lastModified := first.Header().Get("Last-Modified")
+	require.NotEmpty(t, lastModified)
+	lastModifiedTime, err := http.ParseTime(lastModified)
+	require.NoError(t, err)
+
+	t.Run("Unchanged since Last-Modified returns 304", func(t *testing.T) {
+		w := list(lastModified)
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("Older If-Modified-Since returns the page", func(t *testing.T) {
+		w := list(lastModifiedTime.Add(-time.Second).Format(http.TimeFormat))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, lastModified, w.Header().Get("Last-Modified"))
+		assert.Contains(t, w.Body.String(), "Polled purchase")
+	})
+
+	t.Run("Invalid If-Modified-Since is ignored", func(t *testing.T) {
+		w := list("yesterday")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestGetTransactionConditionalAPI(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	// Arrange - store one transaction
+	requestBody, _ := json.Marshal(map[string]interface{}{
+		"description": "Polled purchase",
+		"date":        "2024-01-15T10:30:00Z",
+		"amount":      12.5,
+	})
+	req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	transactionPath := "/api/v1/transactions/" + created["id"].(string)
+
+	get := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", transactionPath, nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get(nil)
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	lastModified := first.Header().Get("Last-Modified")
+
+	t.Run("Validators are sent with the transaction", func(t *testing.T) {
+		assert.True(t, strings.HasPrefix(etag, `W/"`), etag)
+		assert.NotEmpty(t, lastModified)
+		assert.Equal(t, "no-cache", first.Header().Get("Cache-Control"))
+		assert.Equal(t, etag, get(nil).Header().Get("ETag"), "ETag must be stable")
+	})
+
+	t.Run("Matching If-None-Match returns 304", func(t *testing.T) {
+		for _, header := range []string{etag, "*", `"other", ` + etag, strings.TrimPrefix(etag, "W/")} {
+			w := get(map[string]string{"If-None-Match": header})
+
+			assert.Equal(t, http.StatusNotModified, w.Code, header)
+			assert.Empty(t, w.Body.String())
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+		}
+	})
+
+	t.Run("Different If-None-Match returns the transaction", func(t *testing.T) {
+		w := get(map[string]string{"If-None-Match": `W/"0000000000000000"`})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Polled purchase")
+	})
+
+	t.Run("If-None-Match takes precedence over If-Modified-Since", func(t *testing.T) {
+		w := get(map[string]string{"If-None-Match": `W/"0000000000000000"`, "If-Modified-Since": lastModified})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Unchanged since Last-Modified returns 304", func(t *testing.T) {
+		w := get(map[string]string{"If-Modified-Since": lastModified})
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("Each representation has its own ETag", func(t *testing.T) {
+		w := get(map[string]string{"Accept": "application/xml"})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+		assert.Equal(t, http.StatusOK, get(map[string]string{"Accept": "application/xml", "If-None-Match": etag}).Code)
+	})
+
+	t.Run("Updating the transaction changes the ETag", func(t *testing.T) {
+		// Arrange
+		patchBody, _ := json.Marshal(map[string]interface{}{"tags": []string{"polled"}})
+		req := httptest.NewRequest("PATCH", transactionPath, bytes.NewBuffer(patchBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// Act
+		w = get(map[string]string{"If-None-Match": etag})
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+		assert.Contains(t, w.Body.String(), "polled")
+	})
+}
+
+func TestUpdateTransactionOptimisticLockingAPI(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(method, path string, headers map[string]string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var reader *bytes.Buffer
+		if body != nil {
+			payload, _ := json.Marshal(body)
+			reader = bytes.NewBuffer(payload)
+		} else {
+			reader = &bytes.Buffer{}
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response
+	}
+
+	// Arrange
+	w, created := send("POST", "/api/v1/transactions", nil, map[string]interface{}{
+		"description": "Shared purchase", "date": "2024-01-15T10:30:00Z", "amount": 12.5,
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	transactionPath := "/api/v1/transactions/" + created["id"].(string)
+
+	w, fetched := send("GET", transactionPath, nil, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, float64(1), fetched["version"])
+	etag := w.Header().Get("ETag")
+
+	t.Run("expected_version of the current version updates and bumps it", func(t *testing.T) {
+		w, updated := send("PATCH", transactionPath, nil, map[string]interface{}{"tags": []string{"first"}, "expected_version": 1})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(2), updated["version"])
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+	})
+
+	t.Run("A stale expected_version is a conflict", func(t *testing.T) {
+		w, response := send("PATCH", transactionPath, nil, map[string]interface{}{"tags": []string{"second"}, "expected_version": 1})
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "urn:purchase-transaction-api:problem:conflict", response["type"])
+		assert.Contains(t, response["detail"], "is at version 2, not 1")
+	})
+
+	t.Run("A stale If-Match fails the precondition", func(t *testing.T) {
+		w, response := send("PATCH", transactionPath, map[string]string{"If-Match": etag}, map[string]interface{}{"tags": []string{"second"}})
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		assert.Equal(t, "urn:purchase-transaction-api:problem:precondition-failed", response["type"])
+
+		_, current := send("GET", transactionPath, nil, nil)
+		assert.Equal(t, []interface{}{"first"}, current["tags"])
+	})
+
+	t.Run("The current ETag of any format satisfies If-Match", func(t *testing.T) {
+		// Arrange
+		w, _ := send("GET", transactionPath, map[string]string{"Accept": "application/xml"}, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// Act
+		w, updated := send("PATCH", transactionPath, map[string]string{"If-Match": w.Header().Get("ETag")}, map[string]interface{}{"tags": []string{"third"}})
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(3), updated["version"])
+	})
+
+	t.Run("If-Match on a missing transaction is not found", func(t *testing.T) {
+		w, _ := send("PATCH", "/api/v1/transactions/"+uuid.New().String(), map[string]string{"If-Match": "*"}, map[string]interface{}{"tags": []string{"x"}})
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestSuggestDescriptionsAPI(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	for _, description := range []string{"Office supplies", "Office supplies", "Office chair"} {
+		jsonBody, _ := json.Marshal(map[string]interface{}{
+			"description": description,
+			"date":        "2024-01-15T10:30:00Z",
+			"amount":      10.00,
+		})
+		req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	t.Run("Suggestions by prefix", func(t *testing.T) {
+		// Act
+		req := httptest.NewRequest("GET", "/api/v1/transactions/descriptions?prefix=off", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		data := response["data"].([]interface{})
+		require.Len(t, data, 2)
+		first := data[0].(map[string]interface{})
+		assert.Equal(t, "Office supplies", first["description"])
+		assert.Equal(t, float64(2), first["count"])
+	})
+
+	t.Run("Missing prefix", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/transactions/descriptions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid limit", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/transactions/descriptions?prefix=off&limit=500", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestDeleteTransactionAPI(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(method, path string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Delete, find in trash and restore", func(t *testing.T) {
+		// Arrange
+		body, _ := json.Marshal(map[string]interface{}{
+			"description": "Office chair",
+			"date":        "2024-01-15T10:30:00Z",
+			"amount":      150.00,
+		})
+		w := send("POST", "/api/v1/transactions", body)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		path := "/api/v1/transactions/" + created["id"].(string)
+
+		// Act
+		w = send("DELETE", path, nil)
+
+		// Assert - hidden from reads unless the trash is asked for
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, http.StatusNotFound, send("GET", path, nil).Code)
+
+		w = send("GET", path+"?include_deleted=true", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var deleted map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &deleted))
+		assert.Equal(t, "Office chair", deleted["description"])
+		assert.NotEmpty(t, deleted["deleted_at"])
+
+		var list map[string]interface{}
+		w = send("GET", "/api/v1/transactions", nil)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Equal(t, float64(0), list["total"])
+
+		w = send("GET", "/api/v1/transactions?trash=true", nil)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Equal(t, float64(1), list["total"])
+
+		// Deleting twice is not found
+		assert.Equal(t, http.StatusNotFound, send("DELETE", path, nil).Code)
+
+		// Restoring brings it back without deleted_at
+		require.Equal(t, http.StatusOK, send("POST", path+"/restore", nil).Code)
+		w = send("GET", path, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "deleted_at")
+	})
+
+	t.Run("Unknown or invalid ID", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, send("DELETE", "/api/v1/transactions/"+uuid.New().String(), nil).Code)
+		assert.Equal(t, http.StatusBadRequest, send("DELETE", "/api/v1/transactions/not-a-uuid", nil).Code)
+		assert.Equal(t, http.StatusBadRequest, send("GET", "/api/v1/transactions/"+uuid.New().String()+"?include_deleted=maybe", nil).Code)
+	})
+}
+
+func TestRestoreTransactionAPI(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	t.Run("Restore non-deleted transaction", func(t *testing.T) {
+		// Act
+		req := httptest.NewRequest("POST", "/api/v1/transactions/"+uuid.New().String()+"/restore", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid transaction ID", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/transactions/not-a-uuid/restore", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Empty trash", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/transactions?trash=true", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, float64(0), response["total"])
+	})
+
+	t.Run("Invalid trash flag", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/transactions?trash=maybe", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
 func TestConvertTransactionAPI(t *testing.T) {
 	// Setup test router with mock access
 	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
@@ -407,10 +1128,16 @@ func TestConvertTransactionAPI(t *testing.T) {
 	require.NoError(t, err)
 	transactionID := createResponse["id"].(string)
 
+	// The provider covers every known currency but CLP in these scenarios
+	isKnown := func(code entities.CurrencyCode) bool { _, known := code.Info(); return known }
+	mockTreasuryService.On("SupportsCurrency", entities.CLP).Return(false).Maybe()
+	mockTreasuryService.On("SupportsCurrency", mock.MatchedBy(isKnown)).Return(true).Maybe()
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(false).Maybe()
+
 	t.Run("Convert transaction - no exchange rate available", func(t *testing.T) {
 		// Configure mock to return error (no exchange rate available)
 		transactionDate := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
-		mockTreasuryService.On("FetchExchangeRate", entities.USD, entities.BRL, transactionDate).Return(nil, errors.New("exchange rate not available")).Once()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.BRL, transactionDate).Return(nil, errors.New("exchange rate not available")).Once()
 
 		// Act - Try to convert (should fail - no exchange rate)
 		convertReq := map[string]interface{}{
@@ -430,8 +1157,8 @@ func TestConvertTransactionAPI(t *testing.T) {
 		err = json.Unmarshal(convertW.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		assert.Contains(t, response["error"], "Failed to convert transaction")
-		assert.Contains(t, response["details"], "exchange rate not available")
+		assert.Contains(t, response["title"], "Failed to convert transaction")
+		assert.Contains(t, response["detail"], "exchange rate not available")
 
 		// Verify mock was called as expected
 		mockTreasuryService.AssertExpectations(t)
@@ -446,7 +1173,7 @@ func TestConvertTransactionAPI(t *testing.T) {
 			Rate:          0.85,
 			EffectiveDate: transactionDate,
 		}
-		mockTreasuryService.On("FetchExchangeRate", entities.USD, entities.EUR, transactionDate).Return(exchangeRate, nil).Once()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, transactionDate).Return(exchangeRate, nil).Once()
 
 		// Act - Convert to EUR
 		convertReq := map[string]interface{}{
@@ -488,13 +1215,85 @@ func TestConvertTransactionAPI(t *testing.T) {
 		router.ServeHTTP(convertW, convertHttpReq)
 
 		// Assert
-		assert.Equal(t, http.StatusBadRequest, convertW.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, convertW.Code)
 
 		var response map[string]interface{}
 		err = json.Unmarshal(convertW.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		assert.Contains(t, response["error"], "Failed to convert transaction")
+		assert.Contains(t, response["title"], "Failed to convert transaction")
+	})
+
+	t.Run("Convert transaction - unknown currency code is rejected", func(t *testing.T) {
+		// Arrange
+		convertJsonBody, _ := json.Marshal(map[string]interface{}{"target_currency": "XYZ"})
+
+		// Act
+		convertHttpReq := httptest.NewRequest("POST", "/api/v1/transactions/"+transactionID+"/convert", bytes.NewBuffer(convertJsonBody))
+		convertHttpReq.Header.Set("Content-Type", "application/json")
+		convertW := httptest.NewRecorder()
+		router.ServeHTTP(convertW, convertHttpReq)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, convertW.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(convertW.Body.Bytes(), &response))
+		assert.Equal(t, "Currency must be a supported 3-letter ISO 4217 code (e.g., EUR)", response["detail"])
+	})
+
+	t.Run("Convert transaction - unsupported currency lists supported set", func(t *testing.T) {
+		// Arrange
+		convertReq := map[string]interface{}{
+			"target_currency": "CLP",
+		}
+		convertJsonBody, _ := json.Marshal(convertReq)
+
+		// Act
+		convertHttpReq := httptest.NewRequest("POST", "/api/v1/transactions/"+transactionID+"/convert", bytes.NewBuffer(convertJsonBody))
+		convertHttpReq.Header.Set("Content-Type", "application/json")
+		convertW := httptest.NewRecorder()
+		router.ServeHTTP(convertW, convertHttpReq)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, convertW.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(convertW.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Contains(t, response["detail"], "Unsupported target currency: CLP")
+		supported := response["supported_currencies"].([]interface{})
+		assert.Contains(t, supported, "EUR")
+		assert.NotContains(t, supported, "USD")
+	})
+
+	t.Run("Convert transaction - lowercase currency is normalized", func(t *testing.T) {
+		// Arrange
+		transactionDate := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		exchangeRate := &entities.ExchangeRate{
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.CAD,
+			Rate:          1.35,
+			EffectiveDate: transactionDate,
+		}
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.CAD, transactionDate).Return(exchangeRate, nil).Once()
+
+		convertJsonBody, _ := json.Marshal(map[string]interface{}{"target_currency": "cad"})
+
+		// Act
+		convertHttpReq := httptest.NewRequest("POST", "/api/v1/transactions/"+transactionID+"/convert", bytes.NewBuffer(convertJsonBody))
+		convertHttpReq.Header.Set("Content-Type", "application/json")
+		convertW := httptest.NewRecorder()
+		router.ServeHTTP(convertW, convertHttpReq)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, convertW.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(convertW.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "CAD", response["target_currency"])
 	})
 
 	t.Run("Convert transaction - invalid UUID", func(t *testing.T) {
@@ -517,7 +1316,196 @@ func TestConvertTransactionAPI(t *testing.T) {
 		err := json.Unmarshal(convertW.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		assert.Contains(t, response["error"], "Invalid transaction ID format")
+		assert.Contains(t, response["title"], "Invalid transaction ID format")
+	})
+}
+
+func TestConvertTransactionsBatchAPI(t *testing.T) {
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	isKnown := func(code entities.CurrencyCode) bool { _, known := code.Info(); return known }
+	mockTreasuryService.On("SupportsCurrency", mock.MatchedBy(isKnown)).Return(true).Maybe()
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(false).Maybe()
+
+	send := func(body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		jsonBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/v1/transactions/convert-batch", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	var ids []string
+	for _, tx := range []struct {
+		date   string
+		amount float64
+	}{
+		{"2024-02-10T00:00:00Z", 100.00},
+		{"2024-01-15T00:00:00Z", 10.00},
+		{"2024-01-15T00:00:00Z", 20.00},
+	} {
+		body, _ := json.Marshal(map[string]interface{}{"description": "Batch item", "date": tx.date, "amount": tx.amount})
+		req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		ids = append(ids, created["id"].(string))
+	}
+
+	t.Run("Converts every transaction with a single Treasury call", func(t *testing.T) {
+		// Arrange - the rate fetched for the oldest date also covers the later one once cached
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, mock.Anything).Return(&entities.ExchangeRate{
+			ID:            uuid.New(),
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.EUR,
+			Rate:          0.9,
+			EffectiveDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			RecordDate:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}, nil).Once()
+		missing := uuid.New().String()
+
+		// Act
+		w, response := send(map[string]interface{}{
+			"transaction_ids": append(append([]string{}, ids...), missing),
+			"target_currency": "eur",
+		})
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "EUR", response["target_currency"])
+		assert.Equal(t, float64(3), response["converted"])
+		assert.Equal(t, float64(1), response["failed"])
+
+		data := response["data"].([]interface{})
+		require.Len(t, data, 4)
+		for i, expected := range []float64{90, 9, 18} {
+			item := data[i].(map[string]interface{})
+			assert.Equal(t, ids[i], item["transaction_id"])
+			assert.Equal(t, expected, item["converted_amount"])
+			assert.Nil(t, item["error"])
+		}
+		last := data[3].(map[string]interface{})
+		assert.Equal(t, missing, last["transaction_id"])
+		assert.Equal(t, "transaction not found", last["error"])
+
+		mockTreasuryService.AssertNumberOfCalls(t, "FetchExchangeRate", 1)
+	})
+
+	t.Run("Rejects invalid requests", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			body map[string]interface{}
+		}{
+			{"No IDs", map[string]interface{}{"transaction_ids": []string{}, "target_currency": "EUR"}},
+			{"Malformed ID", map[string]interface{}{"transaction_ids": []string{"not-a-uuid"}, "target_currency": "EUR"}},
+			{"Duplicate IDs", map[string]interface{}{"transaction_ids": []string{ids[0], ids[0]}, "target_currency": "EUR"}},
+			{"Missing currency", map[string]interface{}{"transaction_ids": ids}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				w, _ := send(tc.body)
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			})
+		}
+	})
+
+	t.Run("Rejects unsupported currencies as unprocessable", func(t *testing.T) {
+		w, response := send(map[string]interface{}{"transaction_ids": ids, "target_currency": "USD"})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, "urn:purchase-transaction-api:problem:unsupported-currency", response["type"])
+		assert.NotEmpty(t, response["supported_currencies"])
+	})
+}
+
+func TestConversionHistoryAPI(t *testing.T) {
+	// Arrange
+	router, mockTreasuryService, cleanup := setupTestRouterWithMock(t)
+	defer cleanup()
+
+	transactionDate := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(true).Maybe()
+	mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, transactionDate).Return(&entities.ExchangeRate{
+		FromCurrency:  entities.USD,
+		ToCurrency:    entities.EUR,
+		Rate:          0.85,
+		EffectiveDate: transactionDate,
+	}, nil).Maybe()
+
+	serve := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	created := serve("POST", "/api/v1/transactions", map[string]interface{}{
+		"description": "Audited purchase",
+		"date":        "2024-01-15T10:30:00Z",
+		"amount":      100.00,
+	})
+	require.Equal(t, http.StatusCreated, created.Code)
+	var transaction map[string]interface{}
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &transaction))
+	transactionID := transaction["id"].(string)
+
+	t.Run("A transaction never converted has an empty history", func(t *testing.T) {
+		w := serve("GET", "/api/v1/transactions/"+transactionID+"/conversions", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var history map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		assert.Equal(t, transactionID, history["transaction_id"])
+		assert.Empty(t, history["data"])
+		assert.Equal(t, float64(0), history["total"])
+	})
+
+	t.Run("Every successful conversion is recorded, most recent first", func(t *testing.T) {
+		// Act
+		require.Equal(t, http.StatusOK, serve("POST", "/api/v1/transactions/"+transactionID+"/convert", map[string]interface{}{"target_currency": "EUR"}).Code)
+		require.Equal(t, http.StatusOK, serve("GET", "/api/v1/transactions/"+transactionID+"?currency=EUR", nil).Code)
+		require.Equal(t, http.StatusOK, serve("POST", "/api/v1/transactions/convert-batch", map[string]interface{}{
+			"transaction_ids": []string{transactionID, uuid.New().String()},
+			"target_currency": "EUR",
+		}).Code)
+		require.Equal(t, http.StatusUnprocessableEntity, serve("POST", "/api/v1/transactions/"+transactionID+"/convert", map[string]interface{}{"target_currency": "USD"}).Code)
+
+		w := serve("GET", "/api/v1/transactions/"+transactionID+"/conversions?size=2", nil)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		var history map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		assert.Equal(t, float64(2), history["total"], "failed conversions and reads are not recorded")
+		assert.Equal(t, float64(1), history["total_pages"])
+		data := history["data"].([]interface{})
+		require.Len(t, data, 2)
+		latest := data[0].(map[string]interface{})
+		assert.Equal(t, "EUR", latest["target_currency"])
+		assert.Equal(t, 0.85, latest["exchange_rate"])
+		assert.Equal(t, 0.85, latest["raw_exchange_rate"])
+		assert.InDelta(t, 85.0, latest["converted_amount"], 0.01)
+		assert.Equal(t, "2024-01-15T10:30:00Z", latest["effective_date"])
+		assert.NotEmpty(t, latest["converted_at"])
+		assert.GreaterOrEqual(t, latest["converted_at"], data[1].(map[string]interface{})["converted_at"])
+	})
+
+	t.Run("Unknown transactions and invalid IDs are rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve("GET", "/api/v1/transactions/"+uuid.New().String()+"/conversions", nil).Code)
+		assert.Equal(t, http.StatusBadRequest, serve("GET", "/api/v1/transactions/not-a-uuid/conversions", nil).Code)
+		assert.Equal(t, http.StatusBadRequest, serve("GET", "/api/v1/transactions/"+transactionID+"/conversions?size=500", nil).Code)
 	})
 }
 
@@ -540,6 +1528,14 @@ func TestHealthCheckAPI(t *testing.T) {
 
 		assert.Equal(t, "healthy", response["status"])
 		assert.Equal(t, "purchase-transaction-api", response["service"])
+		assert.Equal(t, "test", response["version"])
+		assert.NotEmpty(t, response["timestamp"])
+		assert.Contains(t, response, "uptime_seconds")
+
+		dependencies := response["dependencies"].(map[string]interface{})
+		database := dependencies["database"].(map[string]interface{})
+		assert.Equal(t, "up", database["status"])
+		assert.Contains(t, database, "latency_ms")
 	})
 }
 
@@ -565,3 +1561,105 @@ func TestAPIDocumentationEndpoint(t *testing.T) {
 		assert.NotEmpty(t, response["endpoints"])
 	})
 }
+
+func TestGetTransactionConvertedAPI(t *testing.T) {
+	app := buildTestApp(t)
+	defer app.cleanup()
+	router, mockTreasuryService := app.router.SetupRoutes(), app.treasury
+
+	isKnown := func(code entities.CurrencyCode) bool { _, known := code.Info(); return known }
+	mockTreasuryService.On("SupportsCurrency", mock.MatchedBy(isKnown)).Return(true).Maybe()
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(false).Maybe()
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"description": "Hotel", "date": "2024-02-10T00:00:00Z", "amount": 100.00})
+	req := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var created map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	id := created["id"].(string)
+
+	t.Run("Returns the transaction with the converted amount inline", func(t *testing.T) {
+		// Arrange
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, mock.Anything).Return(&entities.ExchangeRate{
+			ID:            uuid.New(),
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.EUR,
+			Rate:          0.9,
+			EffectiveDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			RecordDate:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}, nil).Once()
+
+		// Act
+		w := get("/api/v1/transactions/"+id+"?currency=eur", "")
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, id, response["id"])
+		assert.Equal(t, "Hotel", response["description"])
+		assert.Equal(t, 100.0, response["amount"])
+		assert.Equal(t, "EUR", response["currency"])
+		assert.Equal(t, 90.0, response["converted_amount"])
+		assert.Equal(t, 0.9, response["exchange_rate"])
+		assert.NotEmpty(t, response["effective_date"])
+
+		// The cached rate serves CSV without another Treasury call
+		w = get("/api/v1/transactions/"+id+"?currency=EUR", "text/csv")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "currency,converted_amount,exchange_rate,effective_date")
+		assert.Contains(t, w.Body.String(), "EUR,90")
+		mockTreasuryService.AssertNumberOfCalls(t, "FetchExchangeRate", 1)
+	})
+
+	t.Run("Reads neither record the conversion nor announce it", func(t *testing.T) {
+		// Arrange
+		var converted atomic.Int32
+		app.events.Subscribe(entities.EventTransactionConverted, func(context.Context, entities.DomainEvent) error {
+			converted.Add(1)
+			return nil
+		})
+
+		// Act
+		w := get("/api/v1/transactions/"+id+"?currency=EUR", "")
+		app.events.Wait()
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Zero(t, converted.Load(), "no transaction.converted event is published")
+		w = get("/api/v1/transactions/"+id+"/conversions", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var history map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		assert.Equal(t, float64(0), history["total"], "no history row is stored")
+	})
+
+	t.Run("Rejects invalid combinations and unknown currencies", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("/api/v1/transactions/"+id+"?currency=XYZ", "").Code)
+		assert.Equal(t, http.StatusBadRequest, get("/api/v1/transactions/"+id+"?currency=EUR&include_deleted=true", "").Code)
+		assert.Equal(t, http.StatusNotFound, get("/api/v1/transactions/"+uuid.New().String()+"?currency=EUR", "").Code)
+	})
+
+	t.Run("Answers 422 when no rate exists within 6 months", func(t *testing.T) {
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.JPY, mock.Anything).
+			Return(nil, errs.Newf(errs.ErrRateUnavailable, "no suitable exchange rate found for JPY")).Once()
+
+		w := get("/api/v1/transactions/"+id+"?currency=JPY", "")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
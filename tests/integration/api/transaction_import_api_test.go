@@ -0,0 +1,81 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportTransactionsAPI(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	upload := func(field, content string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile(field, "transactions.csv")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		req := httptest.NewRequest("POST", "/api/v1/transactions/import", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	t.Run("Valid rows are imported and invalid rows reported", func(t *testing.T) {
+		// Act
+		w, response := upload("file", "description,date,amount\nCoffee,2024-03-01,4.5\nTaxi,2024-03-02,-1\n")
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(2), response["rows"])
+		assert.Equal(t, float64(1), response["imported"])
+		assert.Equal(t, float64(1), response["rejected"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"line": float64(3), "messages": []interface{}{"amount must be greater than 0"}},
+		}, response["errors"])
+
+		req := httptest.NewRequest("GET", "/api/v1/transactions", nil)
+		list := httptest.NewRecorder()
+		router.ServeHTTP(list, req)
+		var listed map[string]interface{}
+		require.NoError(t, json.Unmarshal(list.Body.Bytes(), &listed))
+		data := listed["data"].([]interface{})
+		require.Len(t, data, 1)
+		assert.Equal(t, "Coffee", data[0].(map[string]interface{})["description"])
+	})
+
+	t.Run("A file without the required columns is a validation problem", func(t *testing.T) {
+		// Act
+		w, response := upload("file", "description,amount\nCoffee,4.5\n")
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, problem.TypeValidation, response["type"])
+		assert.Contains(t, response["detail"], "missing date")
+	})
+
+	t.Run("The upload must be in the file field", func(t *testing.T) {
+		// Act
+		w, response := upload("attachment", "description,date,amount\n")
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "Invalid upload", response["title"])
+	})
+}
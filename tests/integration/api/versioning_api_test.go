@@ -0,0 +1,115 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIV2(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	serve := func(method, path, accept string, body interface{}) *httptest.ResponseRecorder {
+		var reader *bytes.Buffer
+		if body != nil {
+			payload, _ := json.Marshal(body)
+			reader = bytes.NewBuffer(payload)
+		} else {
+			reader = &bytes.Buffer{}
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Arrange - three transactions created through v2
+	var ids []string
+	for i := range 3 {
+		w := serve("POST", "/api/v2/transactions", "", map[string]interface{}{
+			"description": fmt.Sprintf("Versioned purchase %d", i),
+			"date":        "2024-01-15T10:30:00Z",
+			"amount":      10.00,
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "v2", w.Header().Get("API-Version"))
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		ids = append(ids, created["id"].(string))
+	}
+
+	t.Run("The list is paged by cursor only", func(t *testing.T) {
+		// Act
+		first := serve("GET", "/api/v2/transactions?size=2", "", nil)
+
+		// Assert
+		require.Equal(t, http.StatusOK, first.Code)
+		var page map[string]interface{}
+		require.NoError(t, json.Unmarshal(first.Body.Bytes(), &page))
+		assert.Len(t, page["data"], 2)
+		assert.NotContains(t, page, "page")
+		assert.NotContains(t, page, "total")
+		assert.Empty(t, first.Header().Get("X-Total-Count"))
+		nextCursor, _ := page["next_cursor"].(string)
+		require.NotEmpty(t, nextCursor)
+		assert.Equal(t, nextCursor, first.Header().Get("X-Next-Cursor"))
+
+		last := serve("GET", "/api/v2/transactions?size=2&cursor="+nextCursor, "", nil)
+		require.Equal(t, http.StatusOK, last.Code)
+		var lastPage map[string]interface{}
+		require.NoError(t, json.Unmarshal(last.Body.Bytes(), &lastPage))
+		assert.Len(t, lastPage["data"], 1)
+		assert.NotContains(t, lastPage, "next_cursor")
+	})
+
+	t.Run("Page numbers and the trash are rejected", func(t *testing.T) {
+		for _, param := range []string{"page=2", "trash=true", "include_archived=true"} {
+			w := serve("GET", "/api/v2/transactions?"+param, "", nil)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, param)
+			assert.Contains(t, w.Body.String(), "follow next_cursor")
+		}
+	})
+
+	t.Run("Errors are problem details whatever the Accept header", func(t *testing.T) {
+		for _, accept := range []string{"application/xml", "application/vnd.api+json", ""} {
+			w := serve("GET", "/api/v2/transactions/not-a-uuid", accept, nil)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"), accept)
+		}
+
+		// v1 keeps negotiating the error format
+		w := serve("GET", "/api/v1/transactions/not-a-uuid", "application/xml", nil)
+		assert.Equal(t, "application/problem+xml", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("Successful responses are still negotiated", func(t *testing.T) {
+		w := serve("GET", "/api/v2/transactions/"+ids[0], "application/xml", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), gin.MIMEXML)
+	})
+
+	t.Run("v1 routes served by v2 link to their successor", func(t *testing.T) {
+		get := serve("GET", "/api/v1/transactions/"+ids[0], "", nil)
+		assert.Equal(t, "v1", get.Header().Get("API-Version"))
+		assert.Equal(t, fmt.Sprintf(`</api/v2/transactions/%s>; rel="successor-version"`, ids[0]), get.Header().Get("Link"))
+
+		suggestions := serve("GET", "/api/v1/transactions/descriptions?prefix=Ver", "", nil)
+		assert.Equal(t, http.StatusOK, suggestions.Code)
+		assert.Empty(t, suggestions.Header().Get("Link"))
+	})
+}
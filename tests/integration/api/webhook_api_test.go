@@ -0,0 +1,189 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookReceiver records the events posted to it whose signature verifies against secret
+type webhookReceiver struct {
+	mu       sync.Mutex
+	secret   string
+	events   []map[string]interface{}
+	rejected int
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	payload, err := webhook.VerifyRequest(r.secret, req, time.Minute)
+	if err != nil {
+		r.rejected++
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var event map[string]interface{}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	event["header_event"] = req.Header.Get("X-Webhook-Event")
+	r.events = append(r.events, event)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *webhookReceiver) received() []map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]map[string]interface{}(nil), r.events...)
+}
+
+func TestWebhookAPI(t *testing.T) {
+	app := buildTestApp(t)
+	defer app.cleanup()
+	router := app.router.SetupRoutes()
+
+	app.treasury.On("SupportsCurrency", mock.Anything).Return(true).Maybe()
+
+	send := func(method, path string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if w.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w, response
+	}
+
+	t.Run("Webhook lifecycle", func(t *testing.T) {
+		// Create
+		w, created := send("POST", "/api/v1/webhooks", map[string]interface{}{
+			"url":    "https://example.com/hooks",
+			"events": []string{"Transaction.Created"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "https://example.com/hooks", created["url"])
+		assert.Equal(t, []interface{}{"transaction.created"}, created["events"])
+		assert.NotEmpty(t, created["secret"])
+		path := "/api/v1/webhooks/" + created["id"].(string)
+
+		// Read, without the secret
+		w, fetched := send("GET", path, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, fetched, "secret")
+
+		// List
+		w, list := send("GET", "/api/v1/webhooks", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, list["data"], 1)
+
+		// Deliveries
+		w, deliveries := send("GET", path+"/deliveries", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, deliveries["data"])
+
+		// Delete
+		w, _ = send("DELETE", path, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w, _ = send("GET", path, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w, _ = send("GET", path+"/deliveries", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid webhooks are rejected", func(t *testing.T) {
+		for name, body := range map[string]map[string]interface{}{
+			"relative URL":  {"url": "/hooks", "events": []string{"transaction.created"}},
+			"ftp URL":       {"url": "ftp://example.com/hooks", "events": []string{"transaction.created"}},
+			"unknown event": {"url": "https://example.com/hooks", "events": []string{"transaction.deleted"}},
+			"no events":     {"url": "https://example.com/hooks", "events": []string{}},
+		} {
+			w, _ := send("POST", "/api/v1/webhooks", body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, name)
+		}
+
+		w, _ := send("GET", "/api/v1/webhooks/not-a-uuid", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Transaction events are delivered signed", func(t *testing.T) {
+		// Arrange
+		receiver := &webhookReceiver{}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		w, created := send("POST", "/api/v1/webhooks", map[string]interface{}{
+			"url":    server.URL,
+			"events": []string{"transaction.created", "transaction.converted"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+		receiver.mu.Lock()
+		receiver.secret = created["secret"].(string)
+		receiver.mu.Unlock()
+
+		transactionDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		app.treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, transactionDate).Return(&entities.ExchangeRate{
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.EUR,
+			Rate:          0.9,
+			EffectiveDate: transactionDate,
+		}, nil).Once()
+
+		// Act
+		w, transaction := send("POST", "/api/v1/transactions", map[string]interface{}{
+			"description": "Hotel",
+			"date":        "2024-01-15T00:00:00Z",
+			"amount":      100.00,
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		w, _ = send("POST", "/api/v1/transactions/"+transaction["id"].(string)+"/convert", map[string]interface{}{
+			"target_currency": "EUR",
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		app.events.Wait()
+
+		// Assert
+		events := receiver.received()
+		require.Len(t, events, 2)
+		assert.Zero(t, receiver.rejected)
+		byEvent := map[string]map[string]interface{}{}
+		for _, event := range events {
+			assert.Equal(t, event["event"], event["header_event"])
+			byEvent[event["event"].(string)] = event["data"].(map[string]interface{})
+		}
+		assert.Equal(t, transaction["id"], byEvent["transaction.created"]["id"])
+		assert.Equal(t, 90.0, byEvent["transaction.converted"]["converted_amount"])
+
+		w, deliveries := send("GET", "/api/v1/webhooks/"+created["id"].(string)+"/deliveries", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, deliveries["data"], 2)
+		for _, item := range deliveries["data"].([]interface{}) {
+			delivery := item.(map[string]interface{})
+			assert.Equal(t, "succeeded", delivery["status"])
+			assert.Equal(t, 1.0, delivery["attempts"])
+			assert.Equal(t, 204.0, delivery["last_status_code"])
+		}
+	})
+}
@@ -0,0 +1,267 @@
+package broker_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/broker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// natsMessage is a message received by fakeNATS
+type natsMessage struct {
+	subject string
+	headers string
+	payload string
+}
+
+// fakeNATS is a minimal NATS server supporting what the publisher sends
+type fakeNATS struct {
+	listener net.Listener
+	headers  bool
+	user     string
+	password string
+
+	mu          sync.Mutex
+	connects    []map[string]interface{}
+	messages    []natsMessage
+	rejectNext  string // Sent as -ERR instead of the PONG of the next publish
+	dropNext    bool   // Closes the connection instead of answering the next publish
+	connections int
+}
+
+func startFakeNATS(t *testing.T, headers bool, user, password string) *fakeNATS {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeNATS{listener: listener, headers: headers, user: user, password: password}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeNATS) url(credentials string) string {
+	return "nats://" + credentials + s.listener.Addr().String()
+}
+
+func (s *fakeNATS) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	s.mu.Lock()
+	s.connections++
+	s.mu.Unlock()
+	fmt.Fprintf(conn, "INFO {\"server_id\":\"fake\",\"headers\":%t,\"max_payload\":1048576}\r\n", s.headers)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(strings.TrimSpace(line))
+		if len(fields) == 0 {
+			continue
+		}
+
+		var reply string
+		switch fields[0] {
+		case "CONNECT":
+			var connect map[string]interface{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")), &connect); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.connects = append(s.connects, connect)
+			s.mu.Unlock()
+			if s.user != "" && (connect["user"] != s.user || connect["pass"] != s.password) {
+				io.WriteString(conn, "-ERR 'Authorization Violation'\r\n")
+				return
+			}
+		case "PING":
+			reply = "PONG\r\n"
+			s.mu.Lock()
+			if s.rejectNext != "" && len(s.messages) > 0 {
+				reply = "-ERR '" + s.rejectNext + "'\r\n"
+				s.rejectNext = ""
+			}
+			s.mu.Unlock()
+		case "PUB", "HPUB":
+			message, ok := readMessage(reader, fields)
+			if !ok {
+				return
+			}
+			s.mu.Lock()
+			drop := s.dropNext
+			s.dropNext = false
+			if !drop {
+				s.messages = append(s.messages, message)
+			}
+			s.mu.Unlock()
+			if drop {
+				return
+			}
+		}
+
+		if reply != "" {
+			if _, err := io.WriteString(conn, reply); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readMessage reads the body of PUB subject size or HPUB subject header_size total_size
+func readMessage(reader *bufio.Reader, fields []string) (natsMessage, bool) {
+	if len(fields) < 3 {
+		return natsMessage{}, false
+	}
+	total, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil {
+		return natsMessage{}, false
+	}
+	headerSize := 0
+	if fields[0] == "HPUB" {
+		if headerSize, err = strconv.Atoi(fields[2]); err != nil {
+			return natsMessage{}, false
+		}
+	}
+	body := make([]byte, total+2)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return natsMessage{}, false
+	}
+	return natsMessage{
+		subject: fields[1],
+		headers: string(body[:headerSize]),
+		payload: string(body[headerSize:total]),
+	}, true
+}
+
+func (s *fakeNATS) received() []natsMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]natsMessage(nil), s.messages...)
+}
+
+func TestNATSPublisher(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+
+	t.Run("Messages carry the event ID header and the CONNECT carries the credentials", func(t *testing.T) {
+		// Arrange
+		server := startFakeNATS(t, true, "relay", "s3cret")
+		publisher, err := broker.NewNATSPublisher(server.url("relay:s3cret@"))
+		require.NoError(t, err)
+		defer publisher.Close()
+
+		// Act
+		require.NoError(t, publisher.Publish(ctx, "transactions.events", "evt-1", []byte(`{"event":"transaction.created"}`)))
+		require.NoError(t, publisher.Publish(ctx, "transactions.events", "evt-2", []byte(`{"event":"transaction.deleted"}`)))
+
+		// Assert
+		messages := server.received()
+		require.Len(t, messages, 2)
+		assert.Equal(t, "transactions.events", messages[0].subject)
+		assert.Equal(t, "NATS/1.0\r\nNats-Msg-Id: evt-1\r\n\r\n", messages[0].headers)
+		assert.Equal(t, `{"event":"transaction.created"}`, messages[0].payload)
+		assert.Contains(t, messages[1].headers, "Nats-Msg-Id: evt-2")
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Equal(t, 1, server.connections, "the connection is reused between messages")
+		assert.Equal(t, "relay", server.connects[0]["user"])
+		assert.Equal(t, true, server.connects[0]["headers"])
+	})
+
+	t.Run("Servers without header support receive plain PUB", func(t *testing.T) {
+		// Arrange
+		server := startFakeNATS(t, false, "", "")
+		publisher, err := broker.NewNATSPublisher(server.url(""))
+		require.NoError(t, err)
+		defer publisher.Close()
+
+		// Act
+		err = publisher.Publish(ctx, "transactions.events", "evt-1", []byte("{}"))
+
+		// Assert
+		require.NoError(t, err)
+		messages := server.received()
+		require.Len(t, messages, 1)
+		assert.Empty(t, messages[0].headers)
+		assert.Equal(t, "{}", messages[0].payload)
+	})
+
+	t.Run("Rejected credentials and server errors fail the publish", func(t *testing.T) {
+		// Arrange
+		server := startFakeNATS(t, true, "relay", "s3cret")
+		wrong, err := broker.NewNATSPublisher(server.url("relay:wrong@"))
+		require.NoError(t, err)
+		defer wrong.Close()
+		publisher, err := broker.NewNATSPublisher(server.url("relay:s3cret@"))
+		require.NoError(t, err)
+		defer publisher.Close()
+		require.NoError(t, publisher.Publish(ctx, "transactions.events", "evt-1", []byte("{}")))
+		server.mu.Lock()
+		server.rejectNext = "Maximum Payload Violation"
+		server.mu.Unlock()
+
+		// Act
+		authErr := wrong.Publish(ctx, "transactions.events", "evt-1", []byte("{}"))
+		publishErr := publisher.Publish(ctx, "transactions.events", "evt-2", []byte("{}"))
+
+		// Assert
+		require.Error(t, authErr)
+		assert.Contains(t, authErr.Error(), "Authorization Violation")
+		require.Error(t, publishErr)
+		assert.Contains(t, publishErr.Error(), "Maximum Payload Violation")
+	})
+
+	t.Run("A dropped connection fails the publish and the next one reconnects", func(t *testing.T) {
+		// Arrange
+		server := startFakeNATS(t, true, "", "")
+		publisher, err := broker.NewNATSPublisher(server.url(""))
+		require.NoError(t, err)
+		defer publisher.Close()
+		server.mu.Lock()
+		server.dropNext = true
+		server.mu.Unlock()
+
+		// Act
+		dropped := publisher.Publish(ctx, "transactions.events", "evt-1", []byte("{}"))
+		retried := publisher.Publish(ctx, "transactions.events", "evt-1", []byte("{}"))
+
+		// Assert
+		assert.Error(t, dropped)
+		require.NoError(t, retried)
+		assert.Len(t, server.received(), 1)
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Equal(t, 2, server.connections)
+	})
+
+	t.Run("Invalid URLs and subjects are rejected", func(t *testing.T) {
+		_, err := broker.NewNATSPublisher("http://localhost:4222")
+		assert.Error(t, err)
+		_, err = broker.NewNATSPublisher("nats://")
+		assert.Error(t, err)
+
+		publisher, err := broker.NewNATSPublisher("nats://localhost")
+		require.NoError(t, err)
+		assert.Error(t, publisher.Publish(ctx, "transactions events", "evt-1", []byte("{}")))
+	})
+}
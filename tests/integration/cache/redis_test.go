@@ -0,0 +1,268 @@
+package cache_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/cache"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis is a minimal RESP server supporting the commands the rate cache sends
+type fakeRedis struct {
+	listener net.Listener
+	password string
+
+	mu       sync.Mutex
+	values   map[string]string
+	expiries map[string]time.Time
+	commands []string
+}
+
+func startFakeRedis(t *testing.T, password string) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeRedis{
+		listener: listener,
+		password: password,
+		values:   make(map[string]string),
+		expiries: make(map[string]time.Time),
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeRedis) addr() string { return s.listener.Addr().String() }
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authenticated := s.password == ""
+
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.ToUpper(args[0]))
+		reply := s.execute(args, &authenticated)
+		s.mu.Unlock()
+
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+// execute runs a command; callers hold the lock
+func (s *fakeRedis) execute(args []string, authenticated *bool) string {
+	command := strings.ToUpper(args[0])
+	if command == "AUTH" {
+		if args[1] != s.password {
+			return "-WRONGPASS invalid password\r\n"
+		}
+		*authenticated = true
+		return "+OK\r\n"
+	}
+	if !*authenticated {
+		return "-NOAUTH Authentication required\r\n"
+	}
+
+	switch command {
+	case "PING":
+		return "+PONG\r\n"
+	case "GET":
+		value, found := s.values[args[1]]
+		if expiry, set := s.expiries[args[1]]; found && set && time.Now().After(expiry) {
+			delete(s.values, args[1])
+			found = false
+		}
+		if !found {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		s.values[args[1]] = args[2]
+		delete(s.expiries, args[1])
+		if len(args) == 5 && strings.ToUpper(args[3]) == "PX" {
+			ms, _ := strconv.Atoi(args[4])
+			s.expiries[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		return "+OK\r\n"
+	case "INCR":
+		current, _ := strconv.ParseInt(s.values[args[1]], 10, 64)
+		current++
+		s.values[args[1]] = strconv.FormatInt(current, 10)
+		return fmt.Sprintf(":%d\r\n", current)
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+func (s *fakeRedis) count(command string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, sent := range s.commands {
+		if sent == command {
+			count++
+		}
+	}
+	return count
+}
+
+// readCommand parses a RESP array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		lengthLine, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(lengthLine[1:]))
+		if err != nil {
+			return nil, err
+		}
+		value := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+		args = append(args, string(value[:length]))
+	}
+	return args, nil
+}
+
+func TestRedisBackend(t *testing.T) {
+	t.Run("Stores, expires and counts values", func(t *testing.T) {
+		// Arrange
+		server := startFakeRedis(t, "secret")
+		backend := cache.NewRedisBackend(server.addr(), "secret", 0)
+		defer backend.Close()
+
+		// Act & Assert
+		require.NoError(t, backend.Ping(context.Background()))
+
+		_, found, err := backend.Get("missing")
+		require.NoError(t, err)
+		assert.False(t, found)
+
+		require.NoError(t, backend.Set("rate", []byte(`{"rate":0.92}`), 20*time.Millisecond))
+		value, found, err := backend.Get("rate")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, `{"rate":0.92}`, string(value))
+
+		time.Sleep(30 * time.Millisecond)
+		_, found, err = backend.Get("rate")
+		require.NoError(t, err)
+		assert.False(t, found)
+
+		counter, err := backend.Counter("gen")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), counter)
+		incremented, err := backend.Incr("gen")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), incremented)
+		counter, err = backend.Counter("gen")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), counter)
+
+		assert.Equal(t, 1, server.count("AUTH"), "the connection is reused")
+	})
+
+	t.Run("Reports a wrong password and an unreachable server", func(t *testing.T) {
+		server := startFakeRedis(t, "secret")
+
+		err := cache.NewRedisBackend(server.addr(), "wrong", 0).Ping(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "redis authentication failed")
+
+		unreachable := startFakeRedis(t, "")
+		addr := unreachable.addr()
+		unreachable.listener.Close()
+		err = cache.NewRedisBackend(addr, "", 0).Ping(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to connect to redis")
+	})
+
+	t.Run("Rate lookups fall through to the database when Redis is down", func(t *testing.T) {
+		// Arrange
+		server := startFakeRedis(t, "")
+		addr := server.addr()
+		server.listener.Close()
+
+		purchase := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+		rate := &entities.ExchangeRate{FromCurrency: entities.USD, ToCurrency: entities.EUR, Rate: 0.92, EffectiveDate: purchase, RecordDate: purchase}
+		inner := new(mocks.MockExchangeRateRepository)
+		inner.On("FindRateForConversion", entities.USD, entities.EUR, mock.Anything).Return(rate, nil)
+		repo := cache.NewCachingExchangeRateRepository(inner, cache.NewRateCache(cache.NewRedisBackend(addr, "", 0), "pta:", time.Hour))
+
+		// Act
+		found, err := repo.FindRateForConversion(entities.USD, entities.EUR, purchase, entities.RateWindow{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0.92, found.Rate)
+	})
+
+	t.Run("Instances sharing Redis share cached lookups", func(t *testing.T) {
+		// Arrange
+		server := startFakeRedis(t, "")
+		purchase := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+		rate := &entities.ExchangeRate{FromCurrency: entities.USD, ToCurrency: entities.EUR, Rate: 0.92, EffectiveDate: purchase, RecordDate: purchase}
+
+		first := new(mocks.MockExchangeRateRepository)
+		first.On("FindRateForConversion", entities.USD, entities.EUR, mock.Anything).Return(rate, nil).Once()
+		second := new(mocks.MockExchangeRateRepository)
+
+		newRepo := func(inner *mocks.MockExchangeRateRepository) func() (*entities.ExchangeRate, error) {
+			repo := cache.NewCachingExchangeRateRepository(inner, cache.NewRateCache(cache.NewRedisBackend(server.addr(), "", 0), "pta:", time.Hour))
+			return func() (*entities.ExchangeRate, error) {
+				return repo.FindRateForConversion(entities.USD, entities.EUR, purchase, entities.RateWindow{})
+			}
+		}
+
+		// Act
+		_, err := newRepo(first)()
+		require.NoError(t, err)
+		found, err := newRepo(second)()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0.92, found.Rate)
+		second.AssertNotCalled(t, "FindRateForConversion", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,66 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogRepository(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewAuditLogRepository(db.GetDB())
+	transactionID := uuid.New()
+	otherID := uuid.New()
+	start := time.Now().UTC()
+
+	record := func(entityID uuid.UUID, action string, changes interface{}, at time.Time) {
+		entry, err := entities.NewAuditLog(entities.AuditEntityTransaction, entityID, action, "token:1", "req-1", changes, clock.System())
+		require.NoError(t, err)
+		entry.CreatedAt = at
+		require.NoError(t, repo.Save(entry))
+	}
+	record(transactionID, entities.AuditActionCreate, map[string]string{"description": "Hotel"}, start)
+	record(otherID, entities.AuditActionCreate, nil, start.Add(time.Second))
+	record(transactionID, entities.AuditActionDelete, nil, start.Add(2*time.Second))
+
+	t.Run("Entries of an entity are listed most recent first", func(t *testing.T) {
+		// Act
+		entries, total, err := repo.FindPaginated(entities.AuditLogFilter{EntityID: &transactionID}, 1, 20)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		require.Len(t, entries, 2)
+		assert.Equal(t, entities.AuditActionDelete, entries[0].Action)
+		assert.Empty(t, entries[0].Changes)
+		assert.Equal(t, `{"description":"Hotel"}`, entries[1].Changes)
+		assert.Equal(t, "token:1", entries[1].Actor)
+		assert.Equal(t, "req-1", entries[1].RequestID)
+	})
+
+	t.Run("Entries are filtered by recording time", func(t *testing.T) {
+		// Act
+		from := start.Add(time.Second)
+		to := start.Add(2 * time.Second)
+		entries, total, err := repo.FindPaginated(entities.AuditLogFilter{From: &from, To: &to}, 1, 20)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, entries, 1)
+		assert.Equal(t, otherID, entries[0].EntityID)
+	})
+
+	t.Run("Entries without an entity are rejected", func(t *testing.T) {
+		assert.Error(t, repo.Save(&entities.AuditLog{ID: uuid.New(), EntityType: entities.AuditEntityTransaction, Action: entities.AuditActionCreate}))
+	})
+}
@@ -0,0 +1,181 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversionRecordRepository_Supersede(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewConversionRecordRepository(db.GetDB())
+	batchID := uuid.New()
+
+	oldRate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.90, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+	closerRate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.95, time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+
+	converted, err := entities.NewConvertedTransaction(entities.Transaction{
+		ID:          uuid.New(),
+		Description: "Stored purchase",
+		Date:        time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC),
+		Amount:      entities.NewMoney(100),
+	}, entities.EUR, oldRate, entities.RateWindow{}, entities.RoundHalfUp)
+	require.NoError(t, err)
+	previous, err := entities.NewConversionRecord(converted, &batchID, clock.System())
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveAll([]entities.ConversionRecord{*previous}))
+
+	t.Run("Finds records a closer rate improves", func(t *testing.T) {
+		// Act
+		candidates, err := repo.FindSupersedable(entities.EUR, closerRate.EffectiveDate)
+		tooLate, lateErr := repo.FindSupersedable(entities.EUR, time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC))
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, lateErr)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, previous.ID, candidates[0].ID)
+		assert.Empty(t, tooLate, "rates after the purchase date never apply")
+	})
+
+	t.Run("Replaces the record within its batch", func(t *testing.T) {
+		// Arrange
+		replacement, err := previous.Reprice(closerRate, entities.RateWindow{}, entities.RoundHalfUp, clock.System())
+		require.NoError(t, err)
+
+		// Act
+		err = repo.Supersede(previous, replacement)
+
+		// Assert
+		require.NoError(t, err)
+
+		records, total, err := repo.GetByBatchPaginated(batchID, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, records, 1)
+		assert.Equal(t, replacement.ID, records[0].ID)
+		assert.Equal(t, 0.95, records[0].ExchangeRate)
+
+		candidates, err := repo.FindSupersedable(entities.EUR, closerRate.EffectiveDate)
+		require.NoError(t, err)
+		assert.Empty(t, candidates)
+	})
+
+	t.Run("Rejects superseding a record twice", func(t *testing.T) {
+		// Arrange
+		replacement, err := previous.Reprice(closerRate, entities.RateWindow{}, entities.RoundHalfUp, clock.System())
+		require.NoError(t, err)
+
+		// Act
+		err = repo.Supersede(previous, replacement)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already superseded")
+	})
+}
+
+func TestConversionRecordRepository_Sample(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewConversionRecordRepository(db.GetDB())
+	rate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.90, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+
+	records := make([]entities.ConversionRecord, 0, 5)
+	for i := 0; i < 5; i++ {
+		converted, err := entities.NewConvertedTransaction(entities.Transaction{
+			ID:          uuid.New(),
+			Description: "Stored purchase",
+			Date:        time.Date(2024, 4, 1+i, 0, 0, 0, 0, time.UTC),
+			Amount:      entities.NewMoney(10),
+		}, entities.EUR, rate, entities.RateWindow{}, entities.RoundHalfUp)
+		require.NoError(t, err)
+		record, err := entities.NewConversionRecord(converted, nil, clock.System())
+		require.NoError(t, err)
+		records = append(records, *record)
+	}
+	require.NoError(t, repo.SaveAll(records))
+
+	replacement, err := records[0].Reprice(rate, entities.RateWindow{}, entities.RoundHalfUp, clock.System())
+	require.NoError(t, err)
+	require.NoError(t, repo.Supersede(&records[0], replacement))
+
+	// Act
+	sampled, err := repo.Sample(3)
+	all, allErr := repo.Sample(100)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, allErr)
+	assert.Len(t, sampled, 3)
+	assert.Len(t, all, 5, "superseded records are never sampled")
+	for _, record := range all {
+		assert.NotEqual(t, records[0].ID, record.ID)
+	}
+}
+
+func TestConversionHistoryRepository_GetByTransactionPaginated(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewConversionHistoryRepository(db.GetDB())
+	rate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.90, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+
+	converted, err := entities.NewConvertedTransaction(entities.Transaction{
+		ID:          uuid.New(),
+		Description: "Audited purchase",
+		Date:        time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+		Amount:      entities.NewMoney(100),
+	}, entities.EUR, rate, entities.RateWindow{}, entities.RoundHalfUp)
+	require.NoError(t, err)
+	other, err := entities.NewConvertedTransaction(entities.Transaction{
+		ID:          uuid.New(),
+		Description: "Other purchase",
+		Date:        time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+		Amount:      entities.NewMoney(10),
+	}, entities.EUR, rate, entities.RateWindow{}, entities.RoundHalfUp)
+	require.NoError(t, err)
+
+	conversions := make([]entities.Conversion, 0, 4)
+	for i := 0; i < 3; i++ {
+		conversion, err := entities.NewConversion(converted, 0.90, 0, nil, clock.System())
+		require.NoError(t, err)
+		conversion.CreatedAt = time.Date(2024, 5, 1+i, 0, 0, 0, 0, time.UTC)
+		conversions = append(conversions, *conversion)
+	}
+	unrelated, err := entities.NewConversion(other, 0.90, 0, nil, clock.System())
+	require.NoError(t, err)
+	conversions = append(conversions, *unrelated)
+	require.NoError(t, repo.SaveAll(conversions))
+
+	// Act
+	first, total, err := repo.GetByTransactionPaginated(converted.Transaction.ID, 1, 2)
+	second, _, secondErr := repo.GetByTransactionPaginated(converted.Transaction.ID, 2, 2)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, secondErr)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, first, 2)
+	assert.Equal(t, conversions[2].ID, first[0].ID, "most recent first")
+	assert.Equal(t, conversions[1].ID, first[1].ID)
+	require.Len(t, second, 1)
+	assert.Equal(t, conversions[0].ID, second[0].ID)
+	assert.Equal(t, entities.NewMoney(90), second[0].ConvertedAmount)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
 	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
@@ -61,6 +62,49 @@ func TestExchangeRateRepository_Save_Validation(t *testing.T) {
 	})
 }
 
+func TestExchangeRateRepository_SaveAll(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewExchangeRateRepository(db.GetDB())
+
+	t.Run("Inserts every rate", func(t *testing.T) {
+		// Arrange
+		exchangeRates := make([]entities.ExchangeRate, 3)
+		for i := range exchangeRates {
+			exchangeRates[i] = fixtures.ValidExchangeRate()
+		}
+
+		// Act
+		err := repo.SaveAll(exchangeRates)
+
+		// Assert
+		require.NoError(t, err)
+		for _, exchangeRate := range exchangeRates {
+			exists, err := repo.Exists(exchangeRate.ID)
+			require.NoError(t, err)
+			assert.True(t, exists)
+		}
+	})
+
+	t.Run("Stores nothing when one row fails", func(t *testing.T) {
+		// Arrange - both rates share an ID
+		first := fixtures.ValidExchangeRate()
+		second := fixtures.ValidExchangeRate()
+		second.ID = first.ID
+
+		// Act
+		err := repo.SaveAll([]entities.ExchangeRate{first, second})
+
+		// Assert
+		assert.Error(t, err)
+		exists, err := repo.Exists(first.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
 func TestExchangeRateRepository_GetByID(t *testing.T) {
 	// Setup
 	db, cleanup := setupInMemoryTestDB(t)
@@ -116,7 +160,7 @@ func TestExchangeRateRepository_FindRateForConversion(t *testing.T) {
 		require.NoError(t, repo.Save(&exchangeRate))
 
 		// Act
-		found, err := repo.FindRateForConversion(entities.USD, entities.BRL, transactionDate)
+		found, err := repo.FindRateForConversion(entities.USD, entities.BRL, transactionDate, entities.RateWindow{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -137,7 +181,7 @@ func TestExchangeRateRepository_FindRateForConversion(t *testing.T) {
 		require.NoError(t, repo.Save(&exchangeRate))
 
 		// Act
-		found, err := repo.FindRateForConversion(entities.USD, entities.EUR, transactionDate)
+		found, err := repo.FindRateForConversion(entities.USD, entities.EUR, transactionDate, entities.RateWindow{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -156,7 +200,7 @@ func TestExchangeRateRepository_FindRateForConversion(t *testing.T) {
 		require.NoError(t, repo.Save(&exchangeRate))
 
 		// Act
-		found, err := repo.FindRateForConversion(entities.USD, entities.GBP, transactionDate)
+		found, err := repo.FindRateForConversion(entities.USD, entities.GBP, transactionDate, entities.RateWindow{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -174,7 +218,7 @@ func TestExchangeRateRepository_FindRateForConversion(t *testing.T) {
 		require.NoError(t, repo.Save(&exchangeRate))
 
 		// Act
-		found, err := repo.FindRateForConversion(entities.USD, entities.JPY, transactionDate)
+		found, err := repo.FindRateForConversion(entities.USD, entities.JPY, transactionDate, entities.RateWindow{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -201,7 +245,7 @@ func TestExchangeRateRepository_FindRateForConversion(t *testing.T) {
 		require.NoError(t, repo.Save(&newerRate))
 
 		// Act
-		found, err := repo.FindRateForConversion(entities.USD, entities.CAD, transactionDate)
+		found, err := repo.FindRateForConversion(entities.USD, entities.CAD, transactionDate, entities.RateWindow{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -220,7 +264,7 @@ func TestExchangeRateRepository_FindRateForConversion(t *testing.T) {
 		require.NoError(t, repo.Save(&exchangeRate))
 
 		// Act - search for different currency pair
-		found, err := repo.FindRateForConversion(entities.USD, entities.AUD, transactionDate)
+		found, err := repo.FindRateForConversion(entities.USD, entities.AUD, transactionDate, entities.RateWindow{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -228,6 +272,84 @@ func TestExchangeRateRepository_FindRateForConversion(t *testing.T) {
 	})
 }
 
+func TestExchangeRateRepository_FindSurroundingRates(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewExchangeRateRepository(db.GetDB())
+	date := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, effectiveDate := range []time.Time{
+		date.AddDate(0, -9, 0),
+		date.AddDate(0, -8, 0),
+		date.AddDate(0, 1, 0),
+		date.AddDate(0, 2, 0),
+		date.AddDate(2, 0, 0),
+	} {
+		exchangeRate := fixtures.ExchangeRateWithDate(effectiveDate)
+		exchangeRate.FromCurrency = entities.USD
+		exchangeRate.ToCurrency = entities.CAD
+		require.NoError(t, repo.Save(&exchangeRate))
+	}
+
+	t.Run("Nearest rate on each side", func(t *testing.T) {
+		// Act
+		before, after, err := repo.FindSurroundingRates(entities.USD, entities.CAD, date, 12)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, before)
+		require.NotNil(t, after)
+		assert.True(t, before.EffectiveDate.Equal(date.AddDate(0, -8, 0)))
+		assert.True(t, after.EffectiveDate.Equal(date.AddDate(0, 1, 0)))
+	})
+
+	t.Run("Window excludes distant rates", func(t *testing.T) {
+		// Act
+		before, after, err := repo.FindSurroundingRates(entities.USD, entities.CAD, date, 6)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, before)
+		require.NotNil(t, after)
+	})
+
+	t.Run("No rates for currency pair", func(t *testing.T) {
+		// Act
+		before, after, err := repo.FindSurroundingRates(entities.USD, entities.JPY, date, 12)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, before)
+		assert.Nil(t, after)
+	})
+}
+
+func TestExchangeRateRepository_ForEach(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewExchangeRateRepository(db.GetDB())
+
+	for i := 0; i < 3; i++ {
+		rate := fixtures.ExchangeRateWithDate(time.Date(2024, 1, i+1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, repo.Save(&rate))
+	}
+
+	// Act
+	total := 0
+	err := repo.ForEach(0, func(batch []entities.ExchangeRate) error {
+		total += len(batch)
+		return nil
+	})
+
+	// Assert - non-positive batch size falls back to the default
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+}
+
 func TestExchangeRateRepository_Update(t *testing.T) {
 	// Setup
 	db, cleanup := setupInMemoryTestDB(t)
@@ -341,3 +463,53 @@ func TestExchangeRateRepository_Exists(t *testing.T) {
 		assert.False(t, exists)
 	})
 }
+
+func TestQuoteRepository(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewQuoteRepository(db.GetDB())
+	exchangeRate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.92, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+
+	t.Run("Save and retrieve quote", func(t *testing.T) {
+		quote, err := entities.NewRateQuote(exchangeRate, exchangeRate.EffectiveDate, 15*time.Minute, clock.System())
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, repo.Save(quote))
+		found, err := repo.GetByID(quote.ID)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, quote.Rate, found.Rate)
+		assert.Equal(t, entities.EUR, found.ToCurrency)
+	})
+
+	t.Run("Unknown quote", func(t *testing.T) {
+		found, err := repo.GetByID(uuid.New())
+
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Delete expired quotes", func(t *testing.T) {
+		expired, err := entities.NewRateQuote(exchangeRate, exchangeRate.EffectiveDate, time.Minute, clock.System())
+		require.NoError(t, err)
+		expired.ExpiresAt = time.Now().Add(-time.Hour)
+		require.NoError(t, repo.Save(expired))
+
+		// Act
+		removed, err := repo.DeleteExpired(time.Now())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), removed)
+
+		found, err := repo.GetByID(expired.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
@@ -0,0 +1,273 @@
+package database_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// openUnmigratedDB opens an in-memory SQLite database without applying any migration
+func openUnmigratedDB(t *testing.T) *gorm.DB {
+	db, err := database.NewSQLiteDB(":memory:", database.SQLiteConfig{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db.GetDB()
+}
+
+// noteMigration returns a migration that adds a notes table, or fails with failUp
+func noteMigration(version int, failUp error) migrations.Migration {
+	type note struct {
+		ID   int
+		Text string
+	}
+	return migrations.Migration{
+		Version: version,
+		Name:    "create_notes",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Migrator().CreateTable(&note{}); err != nil {
+				return err
+			}
+			return failUp
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&note{})
+		},
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	t.Run("The application schema is created once and recorded", func(t *testing.T) {
+		// Arrange
+		db := openUnmigratedDB(t)
+		migrator := migrations.New(db)
+
+		// Act
+		applied, err := migrator.Up()
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, applied, len(migrations.All()))
+		assert.True(t, db.Migrator().HasTable("transactions"))
+		assert.True(t, db.Migrator().HasTable(&entities.AuditLog{}))
+		assert.True(t, db.Migrator().HasIndex("transactions", "idx_transactions_created_at_id"))
+		assert.True(t, db.Migrator().HasColumn("transactions", "version"))
+		assert.True(t, db.Migrator().HasColumn("transactions", "currency"))
+		assert.True(t, db.Migrator().HasIndex(&entities.Conversion{}, "idx_conversions_transaction"))
+		assert.True(t, db.Migrator().HasIndex(&entities.ExchangeRate{}, "idx_exchange_rates_pair_effective_date"))
+		assert.True(t, db.Migrator().HasIndex("transactions", "idx_transactions_live_created_at_id"))
+		assert.True(t, db.Migrator().HasColumn(&entities.ArchivedTransaction{}, "Currency"))
+		assert.True(t, db.Migrator().HasColumn(&entities.ArchivedTransaction{}, "Version"))
+		assert.True(t, db.Migrator().HasIndex("transactions", "idx_transactions_description_prefix"))
+
+		again, err := migrator.Up()
+		require.NoError(t, err)
+		assert.Empty(t, again)
+
+		pending, err := migrator.Pending()
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		statuses, err := migrator.Status()
+		require.NoError(t, err)
+		require.NotEmpty(t, statuses)
+		assert.Equal(t, 1, statuses[0].Version)
+		assert.Equal(t, "initial_schema", statuses[0].Name)
+		assert.NotNil(t, statuses[0].AppliedAt)
+	})
+
+	t.Run("A database created before versioning adopts the baseline", func(t *testing.T) {
+		// Arrange: tables created by the former AutoMigrate at startup, holding data
+		db := openUnmigratedDB(t)
+		require.NoError(t, db.Exec("CREATE TABLE transactions (id uuid PRIMARY KEY, description text NOT NULL, date datetime NOT NULL, "+
+			"amount integer NOT NULL, category text, tags text, external_id text, created_at datetime, updated_at datetime, deleted_at datetime)").Error)
+		require.NoError(t, db.AutoMigrate(&entities.ExchangeRate{}))
+		require.NoError(t, db.Exec("INSERT INTO transactions (id, description, date, amount) VALUES ('6f1c2b9e-8a44-4a0e-9f0e-3c1b5e7d2a10', 'Hotel', '2024-01-15', 10000)").Error)
+
+		// Act
+		_, err := migrations.New(db).Up()
+
+		// Assert
+		require.NoError(t, err)
+		var count int64
+		require.NoError(t, db.Table("transactions").Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+
+		var version int64
+		require.NoError(t, db.Table("transactions").Select("version").Scan(&version).Error)
+		assert.Equal(t, int64(1), version, "existing rows start at version 1")
+	})
+
+	t.Run("The baseline does not follow the entities", func(t *testing.T) {
+		// Arrange
+		db := openUnmigratedDB(t)
+
+		// Act
+		_, err := migrations.NewMigrator(db, migrations.All()[:1]).Up()
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, db.Migrator().HasTable("transactions"))
+		assert.False(t, db.Migrator().HasColumn("transactions", "version"), "added by migration 3")
+		assert.False(t, db.Migrator().HasColumn("transactions", "currency"), "added by migration 5")
+		assert.False(t, db.Migrator().HasTable(&entities.Conversion{}), "created by migration 4")
+	})
+
+	t.Run("Every migration can be reverted and applied again", func(t *testing.T) {
+		// Arrange
+		db := openUnmigratedDB(t)
+		migrator := migrations.New(db)
+		_, err := migrator.Up()
+		require.NoError(t, err)
+
+		// Act
+		reverted, err := migrator.Down(len(migrations.All()))
+		require.NoError(t, err)
+		tablesLeft := db.Migrator().HasTable("transactions")
+		applied, err := migrator.Up()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, reverted, len(migrations.All()))
+		assert.False(t, tablesLeft)
+		assert.Len(t, applied, len(migrations.All()))
+		for _, model := range []interface{}{
+			"transactions", &entities.ArchivedTransaction{}, &entities.ExchangeRate{}, &entities.RateQuote{},
+			&entities.ConversionRecord{}, &entities.ConversionBatch{}, &entities.Conversion{}, &entities.Budget{},
+			&entities.Category{}, &entities.RateSubscription{}, &entities.APIToken{}, &entities.IdempotencyKey{},
+			&entities.Webhook{}, &entities.WebhookDelivery{}, &entities.OutboxMessage{}, &entities.AuditLog{},
+		} {
+			assert.True(t, db.Migrator().HasTable(model), "%T", model)
+		}
+		assert.True(t, db.Migrator().HasColumn("transactions", "version"))
+		assert.True(t, db.Migrator().HasColumn(&entities.ArchivedTransaction{}, "Currency"))
+	})
+
+	t.Run("Down reverts the latest migrations only", func(t *testing.T) {
+		// Arrange
+		db := openUnmigratedDB(t)
+		migrator := migrations.NewMigrator(db, append(migrations.All(), noteMigration(1000, nil)))
+		_, err := migrator.Up()
+		require.NoError(t, err)
+		require.True(t, db.Migrator().HasTable("notes"))
+
+		// Act
+		reverted, err := migrator.Down(1)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, reverted, 1)
+		assert.Equal(t, 1000, reverted[0].Version)
+		assert.False(t, db.Migrator().HasTable("notes"))
+		assert.True(t, db.Migrator().HasTable("transactions"))
+
+		pending, err := migrator.Pending()
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, 1000, pending[0].Version)
+	})
+
+	t.Run("A failed migration is rolled back and not recorded", func(t *testing.T) {
+		// Arrange
+		db := openUnmigratedDB(t)
+		migrator := migrations.NewMigrator(db, []migrations.Migration{noteMigration(1, errors.New("boom"))})
+
+		// Act
+		applied, err := migrator.Up()
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "migration 0001_create_notes failed: boom")
+		assert.Empty(t, applied)
+		assert.False(t, db.Migrator().HasTable("notes"))
+
+		pending, err := migrator.Pending()
+		require.NoError(t, err)
+		assert.Len(t, pending, 1)
+	})
+
+	t.Run("Transactions are only partitioned on PostgreSQL", func(t *testing.T) {
+		// Arrange
+		db := openUnmigratedDB(t)
+		migrator := migrations.NewMigrator(db, append(migrations.All(), migrations.PartitionedTransactions()))
+
+		// Act
+		applied, err := migrator.Up()
+
+		// Assert
+		assert.ErrorContains(t, err, "transactions can only be partitioned on PostgreSQL")
+		assert.Len(t, applied, 7, "migrations up to version 7 are applied before it")
+	})
+
+	t.Run("Invalid migration lists and irreversible migrations are rejected", func(t *testing.T) {
+		db := openUnmigratedDB(t)
+
+		_, err := migrations.NewMigrator(db, []migrations.Migration{noteMigration(1, nil), noteMigration(1, nil)}).Up()
+		assert.ErrorContains(t, err, "duplicate migration version 1")
+
+		irreversible := noteMigration(1, nil)
+		irreversible.Down = nil
+		migrator := migrations.NewMigrator(db, []migrations.Migration{irreversible})
+		_, err = migrator.Up()
+		require.NoError(t, err)
+		_, err = migrator.Down(1)
+		assert.ErrorContains(t, err, "cannot be reverted")
+	})
+}
+
+// queryPlan returns the SQLite query plan details of query
+func queryPlan(t *testing.T, db *gorm.DB, query string, args ...interface{}) string {
+	rows, err := db.Raw("EXPLAIN QUERY PLAN "+query, args...).Rows()
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var details []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		require.NoError(t, rows.Scan(&id, &parent, &notUsed, &detail))
+		details = append(details, detail)
+	}
+	require.NoError(t, rows.Err())
+	return strings.Join(details, "\n")
+}
+
+func TestMigratedIndexes(t *testing.T) {
+	// Arrange
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	t.Run("Conversion rate lookups seek the pair index", func(t *testing.T) {
+		// Act
+		plan := queryPlan(t, db.GetDB(),
+			"SELECT * FROM exchange_rates WHERE from_currency = ? AND to_currency = ? AND effective_date <= ? AND effective_date >= ? ORDER BY effective_date DESC LIMIT 1",
+			"USD", "EUR", "2024-06-30", "2023-12-30")
+
+		// Assert
+		assert.Contains(t, plan, "USING INDEX idx_exchange_rates_pair_effective_date")
+		assert.NotContains(t, plan, "TEMP B-TREE", "rows come out of the index already ordered")
+	})
+
+	t.Run("Paginated listings walk the listing index", func(t *testing.T) {
+		// Act
+		plan := queryPlan(t, db.GetDB(), "SELECT * FROM transactions WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT 20 OFFSET 40")
+
+		// Assert
+		assert.Contains(t, plan, "USING INDEX idx_transactions_live_created_at_id")
+		assert.NotContains(t, plan, "TEMP B-TREE", "live rows come out of the index already ordered")
+	})
+
+	t.Run("Description suggestions seek the prefix index", func(t *testing.T) {
+		// Act
+		plan := queryPlan(t, db.GetDB(), "SELECT description, COUNT(*) FROM transactions WHERE description LIKE ? ESCAPE '\\' GROUP BY description", "off%")
+
+		// Assert
+		assert.Contains(t, plan, "INDEX idx_transactions_description_prefix (description>? AND description<?)")
+	})
+}
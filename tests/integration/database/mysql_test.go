@@ -0,0 +1,186 @@
+//go:build mysql
+
+package database_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database/migrations"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupMySQLTestDB connects to MYSQL_TEST_DSN and recreates the schema from scratch
+// Run with: MYSQL_TEST_DSN='user:pass@tcp(localhost:3306)/transactions_test' go test -tags mysql ./tests/integration/database/
+func setupMySQLTestDB(t *testing.T) *database.MySQLDB {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN not set")
+	}
+
+	db, err := database.NewMySQLDB(dsn, database.PoolConfig{})
+	require.NoError(t, err, "Failed to connect to the MySQL test database")
+
+	resetSchema(t, db.GetDB())
+	require.NoError(t, db.Migrate(), "Failed to migrate the MySQL test database")
+
+	t.Cleanup(func() {
+		resetSchema(t, db.GetDB())
+		require.NoError(t, db.Close())
+	})
+	return db
+}
+
+// resetSchema reverts every applied migration
+func resetSchema(t *testing.T, db *gorm.DB) {
+	_, err := migrations.New(db).Down(len(migrations.All()))
+	require.NoError(t, err)
+}
+
+func TestMySQL_Migrations(t *testing.T) {
+	// Setup
+	db := setupMySQLTestDB(t)
+	migrator := migrations.New(db.GetDB())
+
+	// Assert - every migration is recorded and applying again is a no-op
+	pending, err := migrator.Pending()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	assert.True(t, db.GetDB().Migrator().HasTable("transactions"))
+
+	applied, err := migrator.Up()
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	// Act - revert and reapply
+	reverted, err := migrator.Down(len(migrations.All()))
+	require.NoError(t, err)
+	assert.Len(t, reverted, len(migrations.All()))
+	assert.False(t, db.GetDB().Migrator().HasTable("transactions"))
+
+	_, err = migrator.Up()
+	require.NoError(t, err)
+	assert.True(t, db.GetDB().Migrator().HasTable("transactions"))
+}
+
+func TestMySQL_TransactionRepository(t *testing.T) {
+	// Setup
+	db := setupMySQLTestDB(t)
+	repo := database.NewTransactionRepository(db.GetDB())
+
+	t.Run("Round trip keeps the ID, amount and UTC date", func(t *testing.T) {
+		transaction := fixtures.ValidTransaction()
+		transaction.Tags = []string{"travel"}
+		require.NoError(t, repo.Save(&transaction))
+
+		saved, err := repo.GetByID(transaction.ID)
+
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, transaction.ID, saved.ID)
+		assert.Equal(t, transaction.Amount, saved.Amount)
+		assert.True(t, transaction.Date.Equal(saved.Date))
+		assert.Equal(t, []string{"travel"}, saved.Tags)
+	})
+
+	t.Run("Description and tag filters match wildcards literally", func(t *testing.T) {
+		for _, description := range []string{"100%_off deal", "1000 offers", `C:\temp purchase`} {
+			tx := fixtures.TransactionWithDescription(description)
+			tx.Tags = []string{"sale_2024"}
+			require.NoError(t, repo.Save(&tx))
+		}
+
+		matches, total, err := repo.FindPaginated(entities.TransactionFilter{DescriptionContains: "0%_"}, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, matches, 1)
+		assert.Equal(t, "100%_off deal", matches[0].Description)
+
+		matches, _, err = repo.FindPaginated(entities.TransactionFilter{DescriptionContains: `c:\temp`}, 1, 10)
+		require.NoError(t, err)
+		assert.Len(t, matches, 1)
+
+		_, total, err = repo.FindPaginated(entities.TransactionFilter{Tag: "sale_2024"}, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+
+		_, total, err = repo.FindPaginated(entities.TransactionFilter{Tag: "sale%"}, 1, 10)
+		require.NoError(t, err)
+		assert.Zero(t, total)
+	})
+
+	t.Run("Suggestions are case-insensitive and literal", func(t *testing.T) {
+		suggestions, err := repo.SuggestDescriptions("100%", 10)
+		require.NoError(t, err)
+		upper, err := repo.SuggestDescriptions("1000 OFF", 10)
+		require.NoError(t, err)
+
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, "100%_off deal", suggestions[0].Description)
+		require.Len(t, upper, 1)
+		assert.Equal(t, "1000 offers", upper[0].Description)
+	})
+}
+
+func TestMySQL_ReportRepository_SummarizeByPeriod(t *testing.T) {
+	// Setup
+	db := setupMySQLTestDB(t)
+	transactions := database.NewTransactionRepository(db.GetDB())
+	reports := database.NewReportRepository(db.GetDB())
+
+	for _, date := range []time.Time{
+		time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		tx := fixtures.TransactionWithDate(date)
+		require.NoError(t, transactions.Save(&tx))
+	}
+
+	// Act
+	summaries, err := reports.SummarizeByPeriod(
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), entities.GroupByMonth)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, "2024-01", summaries[0].Period)
+	assert.Equal(t, int64(2), summaries[0].Count)
+	assert.Equal(t, "2024-02", summaries[1].Period)
+}
+
+func TestMySQL_CategoryRepository_Conflict(t *testing.T) {
+	// Setup
+	db := setupMySQLTestDB(t)
+	repo := database.NewCategoryRepository(db.GetDB())
+
+	require.NoError(t, repo.Save(&entities.Category{ID: uuid.New(), Name: "Travel"}))
+
+	// Act
+	err := repo.Save(&entities.Category{ID: uuid.New(), Name: "travel"})
+
+	// Assert
+	assert.ErrorIs(t, err, errs.ErrConflict)
+}
+
+func TestMySQL_Size(t *testing.T) {
+	// Setup
+	db := setupMySQLTestDB(t)
+
+	// Act
+	size, err := db.Size(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Positive(t, size)
+	assert.NoError(t, db.Ping(context.Background()))
+}
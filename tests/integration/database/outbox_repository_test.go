@@ -0,0 +1,85 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewOutboxRepository(db.GetDB())
+	now := time.Now().UTC()
+	newMessage := func() *entities.OutboxMessage {
+		event := entities.TransactionDeletedEvent{EventMeta: entities.NewEventMeta(clock.System()), TransactionID: uuid.New()}
+		return entities.NewOutboxMessage(event, "transactions.events", []byte(`{}`))
+	}
+
+	first, second := newMessage(), newMessage()
+	require.NoError(t, repo.Save(first))
+	require.NoError(t, repo.Save(second))
+
+	t.Run("Claimed messages are leased until the lease expires", func(t *testing.T) {
+		// Act
+		claimed, err := repo.ClaimDue(now.Add(time.Second), now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		again, err := repo.ClaimDue(now.Add(2*time.Second), now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		expired, err := repo.ClaimDue(now.Add(2*time.Minute), now.Add(3*time.Minute), 1)
+		require.NoError(t, err)
+
+		// Assert
+		require.Len(t, claimed, 2)
+		assert.Equal(t, first.ID, claimed[0].ID)
+		assert.Equal(t, `{}`, claimed[0].Payload)
+		assert.Empty(t, again)
+		require.Len(t, expired, 1, "the limit applies")
+	})
+
+	t.Run("Failures count attempts and wait until the retry time", func(t *testing.T) {
+		// Act
+		require.NoError(t, repo.MarkFailed(first.ID, "connection refused", now.Add(10*time.Minute)))
+		require.NoError(t, repo.Reschedule(second.ID, now.Add(10*time.Minute)))
+		early, err := repo.ClaimDue(now.Add(5*time.Minute), now.Add(6*time.Minute), 10)
+		require.NoError(t, err)
+		due, err := repo.ClaimDue(now.Add(11*time.Minute), now.Add(12*time.Minute), 10)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Empty(t, early)
+		require.Len(t, due, 2)
+		assert.Equal(t, 1, due[0].Attempts)
+		assert.Equal(t, "connection refused", due[0].LastError)
+		assert.Zero(t, due[1].Attempts)
+		assert.Error(t, repo.MarkFailed(uuid.New(), "boom", now))
+	})
+
+	t.Run("Published messages are no longer pending and are purged after the cutoff", func(t *testing.T) {
+		// Act
+		require.NoError(t, repo.MarkPublished(first.ID, now))
+		pending, err := repo.CountPending()
+		require.NoError(t, err)
+		claimed, err := repo.ClaimDue(now.Add(time.Hour), now.Add(2*time.Hour), 10)
+		require.NoError(t, err)
+		kept, err := repo.DeletePublishedBefore(now.Add(-time.Minute))
+		require.NoError(t, err)
+		purged, err := repo.DeletePublishedBefore(now.Add(time.Minute))
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, int64(1), pending)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, second.ID, claimed[0].ID)
+		assert.Zero(t, kept)
+		assert.Equal(t, int64(1), purged)
+	})
+}
@@ -0,0 +1,45 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolConfig_Apply(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	sqlDB, err := db.GetDB().DB()
+	require.NoError(t, err)
+
+	// Act
+	database.PoolConfig{
+		MaxOpenConns:    7,
+		MaxIdleConns:    3,
+		ConnMaxLifetime: 30 * time.Minute,
+		ConnMaxIdleTime: 5 * time.Minute,
+	}.Apply(sqlDB)
+
+	// Assert - the pool limit is reported by the driver and queries still run
+	assert.Equal(t, 7, sqlDB.Stats().MaxOpenConnections)
+	assert.NoError(t, sqlDB.Ping())
+}
+
+func TestNewPostgresDB_RequiresDSN(t *testing.T) {
+	_, err := database.NewPostgresDB("", false, database.PoolConfig{})
+
+	assert.EqualError(t, err, "postgres DSN is required")
+}
+
+func TestNewMySQLDB_RejectsInvalidDSN(t *testing.T) {
+	_, err := database.NewMySQLDB("", database.PoolConfig{})
+	assert.EqualError(t, err, "mysql DSN is required")
+
+	_, err = database.NewMySQLDB("localhost:3306", database.PoolConfig{})
+	assert.ErrorContains(t, err, "invalid mysql DSN")
+}
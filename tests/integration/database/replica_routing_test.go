@@ -0,0 +1,112 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupReplicatedTestDB routes reads on a primary to a separate database standing in for a lagging replica
+func setupReplicatedTestDB(t *testing.T, stickyWindow time.Duration) (primary, replica *database.SQLiteDB, cleanup func()) {
+	primary, cleanupPrimary := setupInMemoryTestDB(t)
+	replica, cleanupReplica := setupInMemoryTestDB(t)
+
+	replicaPool, err := replica.GetDB().DB()
+	require.NoError(t, err)
+	require.NoError(t, database.RouteReadsToReplicas(primary.GetDB(), []gorm.ConnPool{replicaPool}, stickyWindow))
+
+	return primary, replica, func() {
+		cleanupPrimary()
+		cleanupReplica()
+	}
+}
+
+func TestReplicaRouting(t *testing.T) {
+	t.Run("Reads are served by the replica", func(t *testing.T) {
+		// Arrange
+		primary, replica, cleanup := setupReplicatedTestDB(t, 0)
+		defer cleanup()
+
+		replicated := fixtures.ValidTransaction()
+		require.NoError(t, database.NewTransactionRepository(replica.GetDB()).Save(&replicated))
+		repo := database.NewTransactionRepository(primary.GetDB())
+
+		// Act
+		all, err := repo.GetAll()
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, replicated.ID, all[0].ID)
+	})
+
+	t.Run("Writes go to the primary", func(t *testing.T) {
+		// Arrange
+		primary, replica, cleanup := setupReplicatedTestDB(t, 0)
+		defer cleanup()
+
+		repo := database.NewTransactionRepository(primary.GetDB())
+		transaction := fixtures.ValidTransaction()
+
+		// Act
+		err := repo.Save(&transaction)
+
+		// Assert
+		require.NoError(t, err)
+		replicaCount, err := database.NewTransactionRepository(replica.GetDB()).Count()
+		require.NoError(t, err)
+		assert.Zero(t, replicaCount)
+
+		primaryCount, err := database.NewTransactionRepository(database.UsePrimary(primary.GetDB())).Count()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), primaryCount)
+	})
+
+	t.Run("Created transaction is readable before it reaches the replica", func(t *testing.T) {
+		// Arrange
+		primary, _, cleanup := setupReplicatedTestDB(t, 0)
+		defer cleanup()
+
+		repo := database.NewTransactionRepository(primary.GetDB())
+		transaction := fixtures.ValidTransaction()
+		require.NoError(t, repo.Save(&transaction))
+
+		// Act
+		found, err := repo.GetByID(transaction.ID)
+		exists, existsErr := repo.Exists(transaction.ID)
+		missing, missingErr := repo.GetByID(uuid.New())
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, transaction.ID, found.ID)
+		require.NoError(t, existsErr)
+		assert.True(t, exists)
+		require.NoError(t, missingErr)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("Reads stick to the primary right after a write", func(t *testing.T) {
+		// Arrange
+		primary, _, cleanup := setupReplicatedTestDB(t, time.Minute)
+		defer cleanup()
+
+		repo := database.NewTransactionRepository(primary.GetDB())
+		transaction := fixtures.ValidTransaction()
+		require.NoError(t, repo.Save(&transaction))
+
+		// Act
+		all, err := repo.GetAll()
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, transaction.ID, all[0].ID)
+	})
+}
@@ -10,8 +10,9 @@ import (
 // setupInMemoryTestDB creates an in-memory SQLite database for faster tests
 func setupInMemoryTestDB(t *testing.T) (*database.SQLiteDB, func()) {
 	// Use in-memory SQLite database (faster for tests)
-	db, err := database.NewSQLiteDB(":memory:")
+	db, err := database.NewSQLiteDB(":memory:", database.SQLiteConfig{})
 	require.NoError(t, err, "Failed to create in-memory test database")
+	require.NoError(t, db.Migrate(), "Failed to migrate in-memory test database")
 
 	// Return cleanup function
 	cleanup := func() {
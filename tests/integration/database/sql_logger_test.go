@@ -0,0 +1,97 @@
+package database_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureSlog sends the default slog logger to a buffer for the rest of the test
+func captureSlog(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+// logRecords decodes the JSON records written to buf
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestSQLLogger(t *testing.T) {
+	t.Run("Slow statements are logged with their SQL, duration and rows", func(t *testing.T) {
+		// Arrange
+		db, cleanup := setupInMemoryTestDB(t)
+		defer cleanup()
+		database.UseSQLLogger(db.GetDB(), database.SQLLogConfig{Level: database.SQLLogWarn, SlowThreshold: time.Nanosecond})
+		logs := captureSlog(t)
+
+		// Act
+		require.NoError(t, db.GetDB().Exec("CREATE TABLE notes (id INTEGER)").Error)
+
+		// Assert
+		records := logRecords(t, logs)
+		require.Len(t, records, 1)
+		assert.Equal(t, "WARN", records[0]["level"])
+		assert.Equal(t, "Slow SQL statement", records[0]["msg"])
+		assert.Equal(t, "CREATE TABLE notes (id INTEGER)", records[0]["sql"])
+		assert.Equal(t, "1ns", records[0]["threshold"])
+		assert.Contains(t, records[0], "duration")
+		assert.Contains(t, records[0], "rows")
+	})
+
+	t.Run("Failed statements are logged, missing records are not", func(t *testing.T) {
+		// Arrange
+		db, cleanup := setupInMemoryTestDB(t)
+		defer cleanup()
+		database.UseSQLLogger(db.GetDB(), database.SQLLogConfig{Level: database.SQLLogError})
+		logs := captureSlog(t)
+
+		// Act
+		_ = db.GetDB().Exec("SELECT * FROM missing_table").Error
+		var id int
+		_ = db.GetDB().Table("transactions").Select("1").Where("1 = 0").Take(&id).Error
+
+		// Assert
+		records := logRecords(t, logs)
+		require.Len(t, records, 1)
+		assert.Equal(t, "ERROR", records[0]["level"])
+		assert.Equal(t, "SQL statement failed", records[0]["msg"])
+		assert.Contains(t, records[0]["error"], "missing_table")
+	})
+
+	t.Run("Info logs every statement and silent none", func(t *testing.T) {
+		// Arrange
+		db, cleanup := setupInMemoryTestDB(t)
+		defer cleanup()
+		logs := captureSlog(t)
+
+		// Act
+		database.UseSQLLogger(db.GetDB(), database.SQLLogConfig{Level: database.SQLLogInfo})
+		require.NoError(t, db.GetDB().Exec("SELECT 1").Error)
+		database.UseSQLLogger(db.GetDB(), database.SQLLogConfig{Level: database.SQLLogSilent, SlowThreshold: time.Nanosecond})
+		_ = db.GetDB().Exec("SELECT * FROM missing_table").Error
+
+		// Assert
+		records := logRecords(t, logs)
+		require.Len(t, records, 1)
+		assert.Equal(t, "INFO", records[0]["level"])
+		assert.Equal(t, "SELECT 1", records[0]["sql"])
+	})
+}
@@ -0,0 +1,99 @@
+package database_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveEncryptionKey(t *testing.T) {
+	t.Run("Inline key", func(t *testing.T) {
+		// Act
+		key, err := database.ResolveEncryptionKey("secret", "")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "secret", key)
+	})
+
+	t.Run("Key file is trimmed", func(t *testing.T) {
+		// Arrange
+		keyFile := filepath.Join(t.TempDir(), "db.key")
+		require.NoError(t, os.WriteFile(keyFile, []byte("  from-kms\n"), 0o600))
+
+		// Act
+		key, err := database.ResolveEncryptionKey("", keyFile)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "from-kms", key)
+	})
+
+	t.Run("Both sources are rejected", func(t *testing.T) {
+		// Act
+		_, err := database.ResolveEncryptionKey("secret", "db.key")
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Empty key file is rejected", func(t *testing.T) {
+		// Arrange
+		keyFile := filepath.Join(t.TempDir(), "db.key")
+		require.NoError(t, os.WriteFile(keyFile, []byte("\n"), 0o600))
+
+		// Act
+		_, err := database.ResolveEncryptionKey("", keyFile)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestEncryptedSQLiteDB(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "encrypted.db")
+
+	db, err := database.NewEncryptedSQLiteDB(dbPath, "first-key", database.SQLiteConfig{})
+	if errors.Is(err, database.ErrSQLCipherUnavailable) {
+		// Default builds bundle plain SQLite, which must refuse to run unencrypted when a key is configured
+		t.Skip("SQLCipher not linked; build with the libsqlite3 tag against SQLCipher to run")
+	}
+	require.NoError(t, err)
+	require.NoError(t, db.Migrate())
+
+	transaction := fixtures.ValidTransaction()
+	require.NoError(t, database.NewTransactionRepository(db.GetDB()).Save(&transaction))
+	require.NoError(t, db.Close())
+
+	t.Run("Wrong key is rejected", func(t *testing.T) {
+		// Act
+		_, err := database.NewEncryptedSQLiteDB(dbPath, "other-key", database.SQLiteConfig{})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Rotated key opens the data", func(t *testing.T) {
+		// Act
+		require.NoError(t, database.RekeySQLite(dbPath, "first-key", "second-key"))
+		reopened, err := database.NewEncryptedSQLiteDB(dbPath, "second-key", database.SQLiteConfig{})
+
+		// Assert
+		require.NoError(t, err)
+		defer reopened.Close()
+
+		found, err := database.NewTransactionRepository(reopened.GetDB()).GetByID(transaction.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, transaction.Description, found.Description)
+
+		_, err = database.NewEncryptedSQLiteDB(dbPath, "first-key", database.SQLiteConfig{})
+		assert.Error(t, err)
+	})
+}
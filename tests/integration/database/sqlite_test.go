@@ -0,0 +1,113 @@
+package database_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTunedSQLite opens a migrated SQLite file database with the production tuning
+func openTunedSQLite(t *testing.T, serializeWrites bool) *database.SQLiteDB {
+	db, err := database.NewSQLiteDB(filepath.Join(t.TempDir(), "tuned.db"), database.SQLiteConfig{
+		JournalMode:     "wal",
+		BusyTimeout:     3 * time.Second,
+		Synchronous:     "normal",
+		SerializeWrites: serializeWrites,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.Migrate())
+	return db
+}
+
+func TestNewSQLiteDB_Tuning(t *testing.T) {
+	t.Run("Pragmas are applied to every connection", func(t *testing.T) {
+		// Arrange
+		db := openTunedSQLite(t, true)
+
+		// Act
+		var journalMode string
+		var busyTimeout, synchronous int
+		require.NoError(t, db.GetDB().Raw("PRAGMA journal_mode").Scan(&journalMode).Error)
+		require.NoError(t, db.GetDB().Raw("PRAGMA busy_timeout").Scan(&busyTimeout).Error)
+		require.NoError(t, db.GetDB().Raw("PRAGMA synchronous").Scan(&synchronous).Error)
+
+		// Assert - these reads are served by the reader pool, which is configured like the writer
+		assert.Equal(t, "wal", journalMode)
+		assert.Equal(t, 3000, busyTimeout)
+		assert.Equal(t, 1, synchronous) // NORMAL
+	})
+
+	t.Run("Serialized writes use one writer connection and a reader pool", func(t *testing.T) {
+		// Arrange
+		db := openTunedSQLite(t, true)
+
+		// Act
+		pools := db.Pools()
+
+		// Assert
+		require.Len(t, pools, 2)
+		assert.Equal(t, database.PoolPrimary, pools[0].Name)
+		assert.Equal(t, 1, pools[0].DB.Stats().MaxOpenConnections)
+		assert.Equal(t, database.PoolReader, pools[1].Name)
+	})
+
+	t.Run("In-memory databases keep a single pool", func(t *testing.T) {
+		// Arrange
+		db, err := database.NewSQLiteDB(":memory:", database.SQLiteConfig{SerializeWrites: true})
+		require.NoError(t, err)
+		defer db.Close()
+
+		// Act
+		pools := db.Pools()
+
+		// Assert
+		require.Len(t, pools, 1)
+		assert.Equal(t, database.PoolPrimary, pools[0].Name)
+	})
+}
+
+func TestNewSQLiteDB_ConcurrentWrites(t *testing.T) {
+	for _, serializeWrites := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Serialized writes %t", serializeWrites), func(t *testing.T) {
+			// Arrange
+			db := openTunedSQLite(t, serializeWrites)
+			repo := database.NewTransactionRepository(db.GetDB())
+			const writers = 20
+
+			// Act
+			var wg sync.WaitGroup
+			errs := make(chan error, writers)
+			for i := 0; i < writers; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					transaction := fixtures.TransactionWithDescription(fmt.Sprintf("Concurrent purchase %d", i))
+					if err := repo.Save(&transaction); err != nil {
+						errs <- err
+						return
+					}
+					_, err := repo.GetByID(transaction.ID)
+					errs <- err
+				}(i)
+			}
+			wg.Wait()
+			close(errs)
+
+			// Assert - no writer sees "database is locked"
+			for err := range errs {
+				assert.NoError(t, err)
+			}
+			var count int64
+			require.NoError(t, db.GetDB().Table("transactions").Count(&count).Error)
+			assert.Equal(t, int64(writers), count)
+		})
+	}
+}
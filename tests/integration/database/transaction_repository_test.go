@@ -1,10 +1,14 @@
 package database_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
 	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
 	"github.com/stretchr/testify/assert"
@@ -60,6 +64,68 @@ func TestTransactionRepository_Save_Validation(t *testing.T) {
 	})
 }
 
+func TestTransactionRepository_SaveAll(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+
+	t.Run("Inserts more rows than one batch", func(t *testing.T) {
+		// Arrange
+		transactions := make([]entities.Transaction, 1200)
+		for i := range transactions {
+			transactions[i] = fixtures.ValidTransaction()
+		}
+
+		// Act
+		err := repo.SaveAll(transactions)
+
+		// Assert
+		require.NoError(t, err)
+		count, err := repo.Count()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1200), count)
+		assert.False(t, transactions[0].CreatedAt.IsZero())
+	})
+
+	t.Run("Stores nothing when one row fails", func(t *testing.T) {
+		// Arrange - the last row reuses an ID that is already stored
+		existing := fixtures.ValidTransaction()
+		require.NoError(t, repo.Save(&existing))
+		before, err := repo.Count()
+		require.NoError(t, err)
+
+		fresh := fixtures.ValidTransaction()
+		transactions := []entities.Transaction{fresh, fixtures.TransactionWithID(existing.ID)}
+
+		// Act
+		err = repo.SaveAll(transactions)
+
+		// Assert
+		assert.Error(t, err)
+		after, err := repo.Count()
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+		exists, err := repo.Exists(fresh.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Rejects invalid rows before writing", func(t *testing.T) {
+		invalid := fixtures.ValidTransaction()
+		invalid.Description = ""
+
+		err := repo.SaveAll([]entities.Transaction{fixtures.ValidTransaction(), invalid})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Empty set", func(t *testing.T) {
+		assert.NoError(t, repo.SaveAll(nil))
+	})
+}
+
 func TestTransactionRepository_GetByID(t *testing.T) {
 	// Setup
 	db, cleanup := setupInMemoryTestDB(t)
@@ -94,6 +160,50 @@ func TestTransactionRepository_GetByID(t *testing.T) {
 	})
 }
 
+func TestTransactionRepository_GetByExternalID(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+	transaction := fixtures.ValidTransaction()
+	externalID := "plaid:tx-1"
+	transaction.ExternalID = &externalID
+	require.NoError(t, repo.Save(&transaction))
+
+	t.Run("Imported transaction", func(t *testing.T) {
+		// Act
+		found, err := repo.GetByExternalID(externalID)
+
+		// Assert
+		assert.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, transaction.ID, found.ID)
+	})
+
+	t.Run("Deleted imports are still found", func(t *testing.T) {
+		// Arrange
+		require.NoError(t, repo.Delete(transaction.ID))
+
+		// Act
+		found, err := repo.GetByExternalID(externalID)
+
+		// Assert
+		assert.NoError(t, err)
+		require.NotNil(t, found)
+		assert.True(t, found.IsDeleted())
+	})
+
+	t.Run("Unknown external ID", func(t *testing.T) {
+		// Act
+		found, err := repo.GetByExternalID("plaid:unknown")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
 func TestTransactionRepository_GetAll(t *testing.T) {
 	// Setup
 	db, cleanup := setupInMemoryTestDB(t)
@@ -138,6 +248,216 @@ func TestTransactionRepository_GetAll(t *testing.T) {
 	})
 }
 
+func TestTransactionRepository_ForEach(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+
+	t.Run("Empty database", func(t *testing.T) {
+		calls := 0
+		err := repo.ForEach(2, func(batch []entities.Transaction) error {
+			calls++
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("Streams all rows in batches", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			tx := fixtures.ValidTransaction()
+			require.NoError(t, repo.Save(&tx))
+		}
+
+		var batchSizes []int
+		ids := make(map[uuid.UUID]bool)
+		err := repo.ForEach(2, func(batch []entities.Transaction) error {
+			batchSizes = append(batchSizes, len(batch))
+			for _, tx := range batch {
+				ids[tx.ID] = true
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{2, 2, 1}, batchSizes)
+		assert.Len(t, ids, 5)
+	})
+
+	t.Run("Callback error stops iteration", func(t *testing.T) {
+		calls := 0
+		err := repo.ForEach(2, func(batch []entities.Transaction) error {
+			calls++
+			return errors.New("stop")
+		})
+
+		assert.EqualError(t, err, "stop")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Nil callback", func(t *testing.T) {
+		err := repo.ForEach(2, nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be nil")
+	})
+}
+
+func TestTransactionRepository_FindPaginated(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+
+	january := fixtures.TransactionWithDate(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))
+	january.Description = "Coffee 50%_off"
+	january.Amount = entities.NewMoney(5)
+	february := fixtures.TransactionWithDate(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC))
+	february.Description = "Laptop"
+	february.Amount = entities.NewMoney(1500)
+	for _, tx := range []*entities.Transaction{&january, &february} {
+		require.NoError(t, repo.Save(tx))
+	}
+
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	minAmount := entities.NewMoney(5)
+	maxAmount := entities.NewMoney(10)
+
+	testCases := []struct {
+		name     string
+		filter   entities.TransactionFilter
+		expected []uuid.UUID
+	}{
+		{"Date lower bound", entities.TransactionFilter{DateFrom: &from}, []uuid.UUID{february.ID}},
+		{"Date upper bound is exclusive", entities.TransactionFilter{DateTo: &february.Date}, []uuid.UUID{january.ID}},
+		{"Amount bounds are inclusive", entities.TransactionFilter{MinAmount: &minAmount, MaxAmount: &maxAmount}, []uuid.UUID{january.ID}},
+		{"Description escapes wildcards", entities.TransactionFilter{DescriptionContains: "50%_"}, []uuid.UUID{january.ID}},
+		{"Percent sign is literal", entities.TransactionFilter{DescriptionContains: "%"}, []uuid.UUID{january.ID}},
+		{"Underscore is literal", entities.TransactionFilter{DescriptionContains: "p_"}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			transactions, total, err := repo.FindPaginated(tc.filter, 1, 20)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tc.expected)), total)
+			var ids []uuid.UUID
+			for _, tx := range transactions {
+				ids = append(ids, tx.ID)
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+func TestTransactionRepository_FindAfter(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+
+	// Five transactions, three of them created at the same instant so the ID breaks the tie
+	createdAt := time.Now().Truncate(time.Second)
+	var expected []uuid.UUID
+	for i, offset := range []time.Duration{time.Minute, 0, 0, 0, -time.Minute} {
+		tx := fixtures.TransactionWithDescription(fmt.Sprintf("Keyset %d", i))
+		tx.CreatedAt = createdAt.Add(offset)
+		require.NoError(t, repo.Save(&tx))
+	}
+	all, _, err := repo.GetAllPaginated(1, 10)
+	require.NoError(t, err)
+	for _, tx := range all {
+		expected = append(expected, tx.ID)
+	}
+
+	t.Run("Walking the cursors visits every transaction once, in listing order", func(t *testing.T) {
+		var visited []uuid.UUID
+		var cursor *entities.TransactionCursor
+		for range 10 {
+			page, err := repo.FindAfter(entities.TransactionFilter{}, cursor, 2)
+			require.NoError(t, err)
+			if len(page) == 0 {
+				break
+			}
+			for _, tx := range page {
+				visited = append(visited, tx.ID)
+			}
+			next := entities.CursorOf(page[len(page)-1])
+			cursor = &next
+		}
+
+		assert.Equal(t, expected, visited)
+	})
+
+	t.Run("A parsed cursor continues where it was issued", func(t *testing.T) {
+		parsed, err := entities.ParseTransactionCursor(entities.CursorOf(all[1]).Encode())
+		require.NoError(t, err)
+
+		page, err := repo.FindAfter(entities.TransactionFilter{}, &parsed, 10)
+
+		require.NoError(t, err)
+		require.Len(t, page, 3)
+		assert.Equal(t, expected[2], page[0].ID)
+	})
+
+	t.Run("Filters apply to cursor pages", func(t *testing.T) {
+		page, err := repo.FindAfter(entities.TransactionFilter{DescriptionContains: "keyset 4"}, nil, 10)
+
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, "Keyset 4", page[0].Description)
+	})
+}
+
+func TestTransactionRepository_SuggestDescriptions(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+
+	for _, description := range []string{"Office supplies", "Office supplies", "Office chair", "office lunch", "Coffee", "100%_off deal"} {
+		tx := fixtures.TransactionWithDescription(description)
+		require.NoError(t, repo.Save(&tx))
+	}
+
+	t.Run("Most frequent first, case-insensitive prefix", func(t *testing.T) {
+		suggestions, err := repo.SuggestDescriptions("off", 10)
+
+		require.NoError(t, err)
+		require.Len(t, suggestions, 3)
+		assert.Equal(t, entities.DescriptionSuggestion{Description: "Office supplies", Count: 2}, suggestions[0])
+		assert.Equal(t, "Office chair", suggestions[1].Description)
+		assert.Equal(t, "office lunch", suggestions[2].Description)
+	})
+
+	t.Run("Limit is applied", func(t *testing.T) {
+		suggestions, err := repo.SuggestDescriptions("off", 1)
+
+		require.NoError(t, err)
+		assert.Len(t, suggestions, 1)
+	})
+
+	t.Run("Wildcards are matched literally", func(t *testing.T) {
+		suggestions, err := repo.SuggestDescriptions("100%_", 10)
+
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, "100%_off deal", suggestions[0].Description)
+
+		none, err := repo.SuggestDescriptions("%", 10)
+		require.NoError(t, err)
+		assert.Empty(t, none)
+	})
+}
+
 func TestTransactionRepository_Update(t *testing.T) {
 	// Setup
 	db, cleanup := setupInMemoryTestDB(t)
@@ -204,10 +524,13 @@ func TestTransactionRepository_Delete(t *testing.T) {
 		// Assert
 		assert.NoError(t, err)
 
-		// Verify it was deleted
+		// Verify it was deleted; its ID stays taken while it is in the trash
+		found, err := repo.GetByID(transaction.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found)
 		exists, err = repo.Exists(transaction.ID)
 		require.NoError(t, err)
-		assert.False(t, exists)
+		assert.True(t, exists)
 	})
 
 	t.Run("Delete non-existing transaction", func(t *testing.T) {
@@ -221,6 +544,312 @@ func TestTransactionRepository_Delete(t *testing.T) {
 	})
 }
 
+func TestTransactionRepository_Update_OptimisticLocking(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+	transaction := fixtures.ValidTransaction()
+	require.NoError(t, repo.Save(&transaction))
+	assert.Equal(t, int64(1), transaction.Version)
+
+	// Two editors read the same version
+	first, err := repo.GetByID(transaction.ID)
+	require.NoError(t, err)
+	second, err := repo.GetByID(transaction.ID)
+	require.NoError(t, err)
+
+	t.Run("The first update bumps the version", func(t *testing.T) {
+		first.Category = "Travel"
+
+		require.NoError(t, repo.Update(first))
+
+		assert.Equal(t, int64(2), first.Version)
+		stored, err := repo.GetByID(transaction.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), stored.Version)
+		assert.Equal(t, "Travel", stored.Category)
+	})
+
+	t.Run("An update of the stale version is a conflict and changes nothing", func(t *testing.T) {
+		second.Category = "Food"
+
+		err := repo.Update(second)
+
+		assert.ErrorIs(t, err, errs.ErrConflict)
+		assert.Equal(t, int64(1), second.Version)
+		stored, err := repo.GetByID(transaction.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Travel", stored.Category)
+	})
+}
+
+func TestTransactionRepository_Restore(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+	transaction := fixtures.ValidTransaction()
+	require.NoError(t, repo.Save(&transaction))
+	require.NoError(t, repo.Delete(transaction.ID))
+
+	t.Run("Deleted transaction is listed in trash", func(t *testing.T) {
+		// Act
+		deleted, total, err := repo.GetDeletedPaginated(1, 20)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, deleted, 1)
+		assert.Equal(t, transaction.ID, deleted[0].ID)
+		assert.True(t, deleted[0].IsDeleted())
+
+		found, err := repo.GetByID(transaction.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Restore deleted transaction", func(t *testing.T) {
+		// Act
+		restored, err := repo.Restore(transaction.ID)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, restored)
+		assert.Equal(t, transaction.ID, restored.ID)
+		assert.False(t, restored.IsDeleted())
+
+		exists, err := repo.Exists(transaction.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		_, total, err := repo.GetDeletedPaginated(1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), total)
+	})
+
+	t.Run("Restore live transaction", func(t *testing.T) {
+		// Act
+		_, err := repo.Restore(transaction.ID)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("Restore non-existing transaction", func(t *testing.T) {
+		// Act
+		_, err := repo.Restore(uuid.New())
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestTransactionRepository_ArchiveDatedBefore(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+	cutoff := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	save := func(t *testing.T, date time.Time) entities.Transaction {
+		t.Helper()
+		transaction := fixtures.ValidTransaction()
+		transaction.ID = uuid.New()
+		transaction.Date = date
+		require.NoError(t, repo.Save(&transaction))
+		return transaction
+	}
+
+	oldest := save(t, cutoff.AddDate(-3, 0, 0))
+	old := save(t, cutoff.AddDate(-1, 0, 0))
+	recent := save(t, cutoff.AddDate(1, 0, 0))
+	deletedOld := save(t, cutoff.AddDate(-2, 0, 0))
+	require.NoError(t, repo.Delete(deletedOld.ID))
+
+	t.Run("Moves the oldest due transactions, one batch at a time", func(t *testing.T) {
+		// Act
+		moved, err := repo.ArchiveDatedBefore(cutoff, 1)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), moved)
+
+		found, err := repo.GetByID(oldest.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		archived, err := repo.GetArchivedByID(oldest.ID)
+		require.NoError(t, err)
+		require.NotNil(t, archived)
+		assert.True(t, archived.IsArchived())
+		assert.Equal(t, oldest.Description, archived.Description)
+		assert.Equal(t, oldest.Amount, archived.Amount)
+	})
+
+	t.Run("Leaves recent and soft-deleted transactions in place", func(t *testing.T) {
+		// Act
+		moved, err := repo.ArchiveDatedBefore(cutoff, 100)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), moved)
+
+		count, err := repo.Count()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		_, deletedTotal, err := repo.GetDeletedPaginated(1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deletedTotal)
+
+		archived, err := repo.GetArchivedByID(recent.ID)
+		require.NoError(t, err)
+		assert.Nil(t, archived)
+	})
+
+	t.Run("Lists archived transactions after active ones", func(t *testing.T) {
+		// Act
+		firstPage, total, err := repo.GetAllPaginatedWithArchived(1, 2)
+		require.NoError(t, err)
+		secondPage, _, err := repo.GetAllPaginatedWithArchived(2, 2)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, int64(3), total)
+		require.Len(t, firstPage, 2)
+		assert.Equal(t, recent.ID, firstPage[0].ID)
+		assert.False(t, firstPage[0].IsArchived())
+		assert.True(t, firstPage[1].IsArchived())
+		require.Len(t, secondPage, 1)
+		assert.True(t, secondPage[0].IsArchived())
+		assert.ElementsMatch(t, []uuid.UUID{oldest.ID, old.ID}, []uuid.UUID{firstPage[1].ID, secondPage[0].ID})
+	})
+
+	t.Run("Archiving changes the last modification time", func(t *testing.T) {
+		// Act
+		lastModified, err := repo.LastModified()
+
+		// Assert
+		require.NoError(t, err)
+		archived, err := repo.GetArchivedByID(old.ID)
+		require.NoError(t, err)
+		assert.False(t, lastModified.Before(*archived.ArchivedAt))
+	})
+}
+
+func TestTransactionRepository_ArchiveKeepsCurrencyAndVersion(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+	cutoff := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Arrange
+	transaction := fixtures.ValidTransaction()
+	transaction.Date = cutoff.AddDate(-1, 0, 0)
+	transaction.Currency = entities.EUR
+	transaction.Version = 3
+	require.NoError(t, repo.Save(&transaction))
+
+	// Act
+	moved, err := repo.ArchiveDatedBefore(cutoff, 10)
+	require.NoError(t, err)
+	archived, err := repo.GetArchivedByID(transaction.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), moved)
+	require.NotNil(t, archived)
+	assert.Equal(t, entities.EUR, archived.Currency)
+	assert.Equal(t, entities.EUR, archived.SourceCurrency())
+	assert.Equal(t, int64(3), archived.Version)
+	assert.Equal(t, transaction.Amount, archived.Amount)
+}
+
+func TestTransactionRepository_SummarizeCreatedBetween(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+
+	first := fixtures.ValidTransaction()
+	first.Amount = entities.NewMoney(10.25)
+	second := fixtures.ValidTransaction()
+	second.Amount = entities.NewMoney(5.50)
+	deleted := fixtures.ValidTransaction()
+	require.NoError(t, repo.Save(&first))
+	require.NoError(t, repo.Save(&second))
+	require.NoError(t, repo.Save(&deleted))
+	require.NoError(t, repo.Delete(deleted.ID))
+
+	now := time.Now()
+
+	t.Run("Counts and sums live transactions in range", func(t *testing.T) {
+		// Act
+		summary, err := repo.SummarizeCreatedBetween(now.Add(-time.Hour), now.Add(time.Hour))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), summary.Count)
+		assert.Equal(t, 15.75, summary.Total.Dollars())
+	})
+
+	t.Run("Empty range", func(t *testing.T) {
+		// Act
+		summary, err := repo.SummarizeCreatedBetween(now.Add(time.Hour), now.Add(2*time.Hour))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), summary.Count)
+		assert.Equal(t, 0.0, summary.Total.Dollars())
+	})
+}
+
+func TestTransactionRepository_LastModified(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+
+	t.Run("Empty database", func(t *testing.T) {
+		lastModified, err := repo.LastModified()
+
+		require.NoError(t, err)
+		assert.True(t, lastModified.IsZero())
+	})
+
+	transaction := fixtures.ValidTransaction()
+	require.NoError(t, repo.Save(&transaction))
+
+	t.Run("Reflects the latest save", func(t *testing.T) {
+		lastModified, err := repo.LastModified()
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, transaction.UpdatedAt, lastModified, time.Millisecond)
+	})
+
+	t.Run("Soft delete advances it", func(t *testing.T) {
+		before, err := repo.LastModified()
+		require.NoError(t, err)
+
+		time.Sleep(5 * time.Millisecond)
+		require.NoError(t, repo.Delete(transaction.ID))
+
+		after, err := repo.LastModified()
+		require.NoError(t, err)
+		assert.True(t, after.After(before))
+	})
+}
+
 func TestTransactionRepository_Exists(t *testing.T) {
 	// Setup
 	db, cleanup := setupInMemoryTestDB(t)
@@ -286,3 +915,38 @@ func TestTransactionRepository_Count(t *testing.T) {
 		assert.Equal(t, int64(3), count)
 	})
 }
+
+func TestTransactionRepository_PurgeDeletedBefore(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	repo := database.NewTransactionRepository(db.GetDB())
+	now := time.Now().UTC()
+
+	live, old, recent := fixtures.ValidTransaction(), fixtures.ValidTransaction(), fixtures.ValidTransaction()
+	for _, transaction := range []*entities.Transaction{&live, &old, &recent} {
+		require.NoError(t, repo.Save(transaction))
+	}
+	require.NoError(t, repo.Delete(old.ID))
+	require.NoError(t, repo.Delete(recent.ID))
+	require.NoError(t, db.GetDB().Table("transactions").Where("id = ?", old.ID).
+		Update("deleted_at", now.AddDate(0, 0, -60)).Error)
+
+	// Act
+	purged, err := repo.PurgeDeletedBefore(now.AddDate(0, 0, -30))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	gone, err := repo.GetDeletedByID(old.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+	kept, err := repo.GetDeletedByID(recent.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, kept, "transactions deleted within the retention stay in the trash")
+	exists, err := repo.Exists(live.ID)
+	require.NoError(t, err)
+	assert.True(t, exists, "live transactions are never purged")
+}
@@ -0,0 +1,219 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/database"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/events"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitOfWork(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	unitOfWork := database.NewUnitOfWork(db.GetDB())
+	transactionRepo := database.NewTransactionRepository(db.GetDB())
+	budgetRepo := database.NewBudgetRepository(db.GetDB())
+
+	newTransaction := func(category string) *entities.Transaction {
+		return &entities.Transaction{ID: uuid.New(), Description: "Hotel", Date: time.Now(), Amount: 12000, Category: category}
+	}
+
+	t.Run("Commits every write when fn succeeds", func(t *testing.T) {
+		// Arrange
+		transaction := newTransaction("travel")
+		budget := &entities.Budget{ID: uuid.New(), Category: "travel", Period: entities.BudgetMonthly, Limit: 50000, Currency: entities.USD}
+
+		// Act
+		err := unitOfWork.Do(func(repos repositories.Repositories) error {
+			if err := repos.Transactions.Save(transaction); err != nil {
+				return err
+			}
+			return repos.Budgets.Save(budget)
+		})
+
+		// Assert
+		require.NoError(t, err)
+		stored, err := transactionRepo.GetByID(transaction.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored)
+		budgets, err := budgetRepo.FindByCategory("travel")
+		require.NoError(t, err)
+		assert.Len(t, budgets, 1)
+	})
+
+	t.Run("Rolls back every write when fn fails", func(t *testing.T) {
+		// Arrange
+		transaction := newTransaction("office")
+		failure := errors.New("budget rejected")
+
+		// Act
+		err := unitOfWork.Do(func(repos repositories.Repositories) error {
+			if err := repos.Transactions.Save(transaction); err != nil {
+				return err
+			}
+			if _, err := repos.Transactions.RenameCategory("office", "workspace"); err != nil {
+				return err
+			}
+			return failure
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, failure)
+		stored, err := transactionRepo.GetByID(transaction.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+}
+
+func TestOutboxTransactionRepository(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	outboxRepo := database.NewOutboxRepository(db.GetDB())
+	inner := database.NewTransactionRepository(db.GetDB())
+	transactionRepo := usecases.NewOutboxTransactionRepository(inner, database.NewUnitOfWork(db.GetDB()), events.NewBus(), "transactions.events", clock.System())
+
+	newTransaction := func() *entities.Transaction {
+		return &entities.Transaction{ID: uuid.New(), Description: "Hotel", Date: time.Now(), Amount: 12000}
+	}
+
+	t.Run("Stores the change and its outbox message together", func(t *testing.T) {
+		// Arrange
+		transaction := newTransaction()
+
+		// Act
+		err := transactionRepo.Save(transaction)
+
+		// Assert
+		require.NoError(t, err)
+		stored, err := inner.GetByID(transaction.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored)
+		messages, err := outboxRepo.ClaimDue(time.Now().Add(time.Minute), time.Now().Add(time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, entities.EventTransactionCreated, messages[0].Event)
+	})
+
+	t.Run("A failed outbox insert rolls back the change", func(t *testing.T) {
+		// Arrange
+		transaction := newTransaction()
+		require.NoError(t, db.GetDB().Migrator().DropTable(&entities.OutboxMessage{}))
+
+		// Act
+		err := transactionRepo.Save(transaction)
+
+		// Assert
+		assert.Error(t, err)
+		stored, err := inner.GetByID(transaction.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+}
+
+func TestImportDatasetUseCase_UnitOfWork(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	transactionRepo := database.NewTransactionRepository(db.GetDB())
+	exchangeRateRepo := database.NewExchangeRateRepository(db.GetDB())
+	useCase := usecases.NewImportDatasetUseCase(transactionRepo, exchangeRateRepo, database.NewConversionHistoryRepository(db.GetDB()), nil, validation.NewValidator()).
+		WithUnitOfWork(database.NewUnitOfWork(db.GetDB()))
+
+	t.Run("A failed write rolls back the whole archive", func(t *testing.T) {
+		// Arrange
+		transaction := entities.Transaction{ID: uuid.New(), Description: "Hotel", Date: time.Now(), Amount: 12000, Currency: entities.USD}
+		rate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.9, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), clock.System())
+		require.NoError(t, err)
+		require.NoError(t, db.GetDB().Migrator().DropTable(&entities.ExchangeRate{}))
+
+		// Act
+		_, err = useCase.Execute(&dto.ImportArchiveRequest{Archive: &dto.DatasetArchive{
+			SchemaVersion: dto.ArchiveSchemaVersion,
+			Transactions:  []entities.Transaction{transaction},
+			ExchangeRates: []entities.ExchangeRate{*rate},
+		}})
+
+		// Assert
+		assert.Error(t, err)
+		exists, err := transactionRepo.Exists(transaction.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestConvertTransactionUseCase_UnitOfWork(t *testing.T) {
+	// Setup
+	db, cleanup := setupInMemoryTestDB(t)
+	defer cleanup()
+
+	transactionRepo := database.NewTransactionRepository(db.GetDB())
+	exchangeRateRepo := database.NewExchangeRateRepository(db.GetDB())
+	historyRepo := database.NewConversionHistoryRepository(db.GetDB())
+
+	convert := func(t *testing.T) (*entities.Transaction, error) {
+		transaction := &entities.Transaction{ID: uuid.New(), Description: "Hotel", Date: time.Now().UTC(), Amount: 12000, Currency: entities.USD}
+		require.NoError(t, transactionRepo.Save(transaction))
+
+		rate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.9, transaction.Date.AddDate(0, 0, -1), clock.System())
+		require.NoError(t, err)
+		treasury := new(mocks.MockTreasuryService)
+		treasury.On("SupportsCurrency", entities.EUR).Return(true).Maybe()
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, transaction.Date).Return(rate, nil).Once()
+
+		useCase := usecases.NewConvertTransactionUseCase(transactionRepo, exchangeRateRepo, memory.NewQuoteRepository(), treasury, nil, validation.NewValidator()).
+			WithConversionHistory(historyRepo).
+			WithUnitOfWork(database.NewUnitOfWork(db.GetDB()))
+		_, err = useCase.Execute(context.Background(), &dto.ConvertTransactionRequest{TransactionID: transaction.ID, TargetCurrency: entities.EUR})
+		return transaction, err
+	}
+
+	t.Run("A fetched rate is cached together with the conversion", func(t *testing.T) {
+		// Act
+		transaction, err := convert(t)
+
+		// Assert
+		require.NoError(t, err)
+		cached, err := exchangeRateRepo.FindRateForConversion(entities.USD, entities.EUR, transaction.Date, entities.RateWindow{})
+		require.NoError(t, err)
+		assert.NotNil(t, cached)
+		conversions, total, err := historyRepo.GetByTransactionPaginated(transaction.ID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Len(t, conversions, 1)
+	})
+
+	t.Run("A failed history write does not cache the rate", func(t *testing.T) {
+		// Arrange
+		require.NoError(t, db.GetDB().Exec("DELETE FROM exchange_rates").Error)
+		require.NoError(t, db.GetDB().Migrator().DropTable(&entities.Conversion{}))
+
+		// Act
+		transaction, err := convert(t)
+
+		// Assert
+		require.NoError(t, err, "the conversion itself succeeds")
+		cached, err := exchangeRateRepo.FindRateForConversion(entities.USD, entities.EUR, transaction.Date, entities.RateWindow{})
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+	})
+}
@@ -0,0 +1,136 @@
+package external_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreakerTreasuryService(t *testing.T) {
+	date := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+	outage := errors.New("failed to fetch from Treasury API: connection refused")
+	rate := &entities.ExchangeRate{FromCurrency: entities.USD, ToCurrency: entities.EUR, Rate: 0.92, EffectiveDate: date, RecordDate: date}
+	fakeClock := clock.NewFake(date)
+
+	newService := func(openFor time.Duration) (*mocks.MockTreasuryService, *external.CircuitBreaker, func() error) {
+		inner := new(mocks.MockTreasuryService)
+		breaker := external.NewCircuitBreaker("treasury", external.CircuitBreakerSettings{
+			FailureThreshold: 2,
+			OpenDuration:     openFor,
+			HalfOpenMaxCalls: 1,
+		}).WithClock(fakeClock)
+		service := external.NewCircuitBreakerTreasuryService(inner, breaker)
+		fetch := func() error {
+			_, err := service.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+			return err
+		}
+		return inner, breaker, fetch
+	}
+
+	t.Run("Opens after consecutive outages and fails fast", func(t *testing.T) {
+		// Arrange
+		inner, breaker, fetch := newService(time.Hour)
+		inner.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(nil, outage).Twice()
+
+		// Act
+		require.Error(t, fetch())
+		assert.Equal(t, external.BreakerClosed, breaker.State())
+		require.Error(t, fetch())
+		err := fetch()
+
+		// Assert
+		assert.Equal(t, external.BreakerOpen, breaker.State())
+		assert.Equal(t, 2, breaker.ConsecutiveFailures())
+		require.NotNil(t, breaker.RetryAt())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "treasury unavailable: circuit breaker open until")
+		inner.AssertNumberOfCalls(t, "FetchExchangeRate", 2)
+	})
+
+	t.Run("Missing rates are not outages", func(t *testing.T) {
+		inner, breaker, fetch := newService(time.Hour)
+		inner.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).
+			Return(nil, errors.New("no suitable exchange rate found for EUR within 6 months of 2024-02-15"))
+
+		for i := 0; i < 5; i++ {
+			require.Error(t, fetch())
+		}
+
+		assert.Equal(t, external.BreakerClosed, breaker.State())
+		inner.AssertNumberOfCalls(t, "FetchExchangeRate", 5)
+	})
+
+	t.Run("A success resets the failure count", func(t *testing.T) {
+		inner, breaker, fetch := newService(time.Hour)
+		inner.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(nil, outage).Once()
+		inner.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(rate, nil).Once()
+		inner.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(nil, outage).Once()
+
+		require.Error(t, fetch())
+		require.NoError(t, fetch())
+		require.Error(t, fetch())
+
+		assert.Equal(t, external.BreakerClosed, breaker.State())
+		assert.Equal(t, 1, breaker.ConsecutiveFailures())
+	})
+
+	t.Run("Half-open trial call closes or reopens the breaker", func(t *testing.T) {
+		// Arrange - open the breaker
+		inner, breaker, fetch := newService(20 * time.Millisecond)
+		inner.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(nil, outage).Times(3)
+		require.Error(t, fetch())
+		require.Error(t, fetch())
+		require.Equal(t, external.BreakerOpen, breaker.State())
+
+		// Act & Assert - a failed trial reopens it
+		fakeClock.Advance(30 * time.Millisecond)
+		assert.Equal(t, external.BreakerHalfOpen, breaker.State())
+		require.Error(t, fetch())
+		assert.Equal(t, external.BreakerOpen, breaker.State())
+		inner.AssertNumberOfCalls(t, "FetchExchangeRate", 3)
+
+		// A successful trial closes it
+		inner.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(rate, nil)
+		fakeClock.Advance(30 * time.Millisecond)
+		require.NoError(t, fetch())
+		assert.Equal(t, external.BreakerClosed, breaker.State())
+		assert.Equal(t, 0, breaker.ConsecutiveFailures())
+		assert.Nil(t, breaker.RetryAt())
+	})
+
+	t.Run("Only one trial call goes through while half-open", func(t *testing.T) {
+		breaker := external.NewCircuitBreaker("treasury", external.CircuitBreakerSettings{
+			FailureThreshold: 2,
+			OpenDuration:     time.Millisecond,
+		}).WithClock(fakeClock)
+		breaker.Record(true)
+		breaker.Record(true)
+		fakeClock.Advance(5 * time.Millisecond)
+
+		require.NoError(t, breaker.Allow())
+		err := breaker.Allow()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "circuit breaker half-open")
+		breaker.Record(false)
+		assert.Equal(t, external.BreakerClosed, breaker.State())
+	})
+
+	t.Run("Other calls are not guarded", func(t *testing.T) {
+		inner, _, _ := newService(time.Hour)
+		inner.On("SupportsCurrency", mock.Anything).Return(true)
+
+		service := external.NewCircuitBreakerTreasuryService(inner, external.NewCircuitBreaker("treasury", external.CircuitBreakerSettings{}))
+
+		assert.True(t, service.SupportsCurrency(entities.EUR))
+	})
+}
@@ -0,0 +1,138 @@
+package external_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ecbHistory is a trimmed eurofxref-hist.xml
+const ecbHistory = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2024-02-15">
+			<Cube currency="USD" rate="1.0759"/>
+			<Cube currency="BRL" rate="5.3439"/>
+		</Cube>
+		<Cube time="2024-02-14">
+			<Cube currency="USD" rate="1.0713"/>
+			<Cube currency="BRL" rate="5.3360"/>
+		</Cube>
+		<Cube time="2023-06-01">
+			<Cube currency="USD" rate="1.0762"/>
+			<Cube currency="BRL" rate="5.3800"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+// ecbServer serves ecbHistory and counts the downloads
+func ecbServer(t *testing.T) (*httptest.Server, *int) {
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(ecbHistory))
+	}))
+	t.Cleanup(server.Close)
+	return server, &downloads
+}
+
+func TestECBClient(t *testing.T) {
+	window, err := entities.NewRateWindow(6)
+	require.NoError(t, err)
+	newClient := func(url string, clk clock.Clock) *external.ECBClient {
+		return external.NewECBClient(&config.RateProvidersConfig{
+			ECBRatesURL:       url,
+			ECBTimeoutSeconds: 5,
+			ECBRefreshMinutes: 60,
+		}, window).WithClock(clk)
+	}
+
+	t.Run("Derives USD rates from the latest day on or before the date", func(t *testing.T) {
+		// Arrange
+		server, _ := ecbServer(t)
+		client := newClient(server.URL, clock.System())
+
+		// Act
+		brl, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.BRL, time.Date(2024, 2, 14, 12, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		eur, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, 4.9809, brl.Rate, "5.3360 BRL per EUR over 1.0713 USD per EUR")
+		assert.Equal(t, time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC), brl.EffectiveDate)
+		assert.Equal(t, "ecb", brl.Source, "ECB rates are not stored as Treasury rates")
+		assert.Equal(t, 0.9295, eur.Rate)
+		assert.Equal(t, time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), eur.EffectiveDate)
+	})
+
+	t.Run("Rates outside the lookback window are unavailable", func(t *testing.T) {
+		// Arrange
+		server, _ := ecbServer(t)
+		client := newClient(server.URL, clock.System())
+
+		// Act
+		_, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.BRL, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+
+		// Assert
+		assert.ErrorIs(t, err, errs.ErrRateUnavailable)
+	})
+
+	t.Run("Returns the days of a range newest first", func(t *testing.T) {
+		// Arrange
+		server, _ := ecbServer(t)
+		client := newClient(server.URL, clock.System())
+
+		// Act
+		rates, err := client.FetchExchangeRates(context.Background(), entities.USD, entities.BRL,
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC))
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, rates, 2)
+		assert.Equal(t, time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), rates[0].EffectiveDate)
+		assert.Equal(t, time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC), rates[1].EffectiveDate)
+	})
+
+	t.Run("Downloads are reused until the refresh interval passes", func(t *testing.T) {
+		// Arrange
+		server, downloads := ecbServer(t)
+		clk := clock.NewFake(time.Date(2024, 2, 16, 9, 0, 0, 0, time.UTC))
+		client := newClient(server.URL, clk)
+		date := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+
+		// Act
+		_, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.BRL, date)
+		require.NoError(t, err)
+		_, err = client.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+		require.NoError(t, err)
+		clk.Advance(time.Hour)
+		_, err = client.FetchExchangeRate(context.Background(), entities.USD, entities.BRL, date)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, 2, *downloads)
+	})
+
+	t.Run("Supports the euro and the ECB reference currencies", func(t *testing.T) {
+		client := newClient("http://unused", clock.System())
+
+		assert.True(t, client.SupportsCurrency(entities.EUR))
+		assert.True(t, client.SupportsCurrency(entities.BRL))
+		assert.False(t, client.SupportsCurrency(entities.CLP))
+		assert.False(t, client.SupportsCurrency(entities.USD))
+		assert.Equal(t, "ecb", client.ProviderName())
+	})
+}
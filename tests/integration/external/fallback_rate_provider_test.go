@@ -0,0 +1,135 @@
+package external_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFallbackRateProvider(t *testing.T) {
+	date := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Falls back to the next provider when one has no rate", func(t *testing.T) {
+		// Arrange
+		treasury := new(mocks.MockTreasuryService)
+		treasury.On("SupportsCurrency", entities.EUR).Return(true)
+		treasury.On("ProviderName").Return("us_treasury")
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).
+			Return(nil, errs.Newf(errs.ErrRateUnavailable, "no exchange rate found for EUR")).Once()
+		fixed, err := external.NewFixedRateProvider(map[string]float64{"eur": 0.92})
+		require.NoError(t, err)
+		provider := external.NewFallbackRateProvider(treasury, fixed)
+
+		// Act
+		exchangeRate, err := provider.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0.92, exchangeRate.Rate)
+		assert.Equal(t, date, exchangeRate.EffectiveDate)
+		assert.Equal(t, "us_treasury,fixed", provider.ProviderName())
+		treasury.AssertExpectations(t)
+	})
+
+	t.Run("Providers not supporting the currency are skipped", func(t *testing.T) {
+		// Arrange
+		treasury := new(mocks.MockTreasuryService)
+		treasury.On("SupportsCurrency", entities.EUR).Return(false)
+		treasury.On("SupportsCurrency", entities.CLP).Return(false)
+		fixed, err := external.NewFixedRateProvider(map[string]float64{"EUR": 0.92})
+		require.NoError(t, err)
+		provider := external.NewFallbackRateProvider(treasury, fixed)
+
+		// Act
+		_, eurErr := provider.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+		_, clpErr := provider.FetchExchangeRate(context.Background(), entities.USD, entities.CLP, date)
+
+		// Assert
+		assert.NoError(t, eurErr)
+		assert.ErrorIs(t, clpErr, errs.ErrRateUnavailable)
+		assert.False(t, provider.SupportsCurrency(entities.CLP))
+		treasury.AssertNotCalled(t, "FetchExchangeRate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("The last error is returned when every provider fails", func(t *testing.T) {
+		// Arrange
+		first, second := new(mocks.MockTreasuryService), new(mocks.MockTreasuryService)
+		for _, provider := range []*mocks.MockTreasuryService{first, second} {
+			provider.On("SupportsCurrency", entities.EUR).Return(true)
+			provider.On("ProviderName").Return("mock")
+		}
+		first.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(nil, errors.New("Treasury API returned status 503"))
+		second.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(nil, errors.New("ECB returned status 503"))
+		provider := external.NewFallbackRateProvider(first, second)
+
+		// Act
+		_, err := provider.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+
+		// Assert
+		assert.EqualError(t, err, "ECB returned status 503")
+	})
+
+	t.Run("Ranges fall back when a provider has no rates in them", func(t *testing.T) {
+		// Arrange
+		treasury := new(mocks.MockTreasuryService)
+		treasury.On("SupportsCurrency", entities.EUR).Return(true)
+		treasury.On("FetchExchangeRates", mock.Anything, entities.USD, entities.EUR, date.AddDate(0, -1, 0), date).
+			Return([]entities.ExchangeRate{}, nil)
+		fixed, err := external.NewFixedRateProvider(map[string]float64{"EUR": 0.92})
+		require.NoError(t, err)
+		provider := external.NewFallbackRateProvider(treasury, fixed)
+
+		// Act
+		rates, err := provider.FetchExchangeRates(context.Background(), entities.USD, entities.EUR, date.AddDate(0, -1, 0), date)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, rates, 1)
+		assert.Equal(t, date, rates[0].EffectiveDate)
+	})
+
+	t.Run("A single provider is not wrapped", func(t *testing.T) {
+		treasury := new(mocks.MockTreasuryService)
+		assert.Same(t, treasury, external.NewFallbackRateProvider(treasury))
+	})
+}
+
+func TestNewConfiguredRateProvider(t *testing.T) {
+	window, err := entities.NewRateWindow(6)
+	require.NoError(t, err)
+	treasury := new(mocks.MockTreasuryService)
+	treasury.On("ProviderName").Return("us_treasury")
+
+	t.Run("Chains the providers in the configured order", func(t *testing.T) {
+		// Act
+		provider, err := external.NewConfiguredRateProvider(&config.RateProvidersConfig{
+			Order:      []string{"ecb", "treasury", "fixed"},
+			FixedRates: map[string]float64{"BRL": 5.1},
+		}, treasury, window)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "ecb,us_treasury,fixed", provider.ProviderName())
+	})
+
+	t.Run("Invalid fixed rates fail", func(t *testing.T) {
+		// Act
+		_, err := external.NewConfiguredRateProvider(&config.RateProvidersConfig{
+			Order:      []string{"fixed"},
+			FixedRates: map[string]float64{"euro": 0.92},
+		}, treasury, window)
+
+		// Assert
+		assert.ErrorContains(t, err, `invalid fixed rate currency "euro"`)
+	})
+}
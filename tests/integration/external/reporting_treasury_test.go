@@ -0,0 +1,69 @@
+package external_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/errortracking"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportingTreasuryService(t *testing.T) {
+	date := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+
+	// Arrange
+	var mu sync.Mutex
+	var tags []map[string]interface{}
+	sentry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		mu.Lock()
+		tags = append(tags, event["tags"].(map[string]interface{}))
+		mu.Unlock()
+	}))
+	t.Cleanup(sentry.Close)
+
+	reporter, err := errortracking.NewReporter(errortracking.Config{
+		DSN: strings.Replace(sentry.URL, "://", "://key@", 1) + "/1",
+	}, "test/1.0")
+	require.NoError(t, err)
+
+	inner := new(mocks.MockTreasuryService)
+	inner.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).
+		Return(nil, errors.New("Treasury API returned status 503")).Once()
+	inner.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).
+		Return(nil, errors.New("no suitable exchange rate found for EUR within 6 months of 2024-02-15")).Once()
+	service := external.NewReportingTreasuryService(inner, reporter)
+
+	// Act
+	_, outageErr := service.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+	_, missingErr := service.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+	require.NoError(t, reporter.Close())
+
+	// Assert
+	require.Error(t, outageErr)
+	require.Error(t, missingErr)
+	require.Len(t, tags, 1, "only outages are reported; a missing rate is a normal answer")
+	assert.Equal(t, map[string]interface{}{
+		"dependency": "treasury",
+		"from":       "USD",
+		"to":         "EUR",
+		"date":       "2024-02-15",
+	}, tags[0])
+
+	t.Run("Without a reporter the service is not wrapped", func(t *testing.T) {
+		assert.Same(t, inner, external.NewReportingTreasuryService(inner, nil))
+	})
+}
@@ -0,0 +1,111 @@
+package external_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCurrencyMap writes a currency map file and returns its path
+func writeCurrencyMap(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "currencies.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadTreasuryCurrencies(t *testing.T) {
+	t.Run("No file uses the built-in mapping", func(t *testing.T) {
+		currencies, err := external.LoadTreasuryCurrencies("")
+
+		require.NoError(t, err)
+		assert.Equal(t, external.DefaultTreasuryCurrencies(), currencies)
+	})
+
+	t.Run("File entries extend and replace the built-in mapping", func(t *testing.T) {
+		// Arrange
+		path := writeCurrencyMap(t, `{"currencies": [
+			{"code": "mxn", "descriptors": ["Mexico-Peso"], "name": "Mexican Peso", "symbol": "MX$"},
+			{"code": "EUR", "descriptors": ["Euro Zone-Euro", "Germany-Euro", "Euro Zone-Euro"]}
+		]}`)
+
+		// Act
+		currencies, err := external.LoadTreasuryCurrencies(path)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Mexico-Peso"}, currencies["MXN"])
+		assert.Equal(t, []string{"Euro Zone-Euro", "Germany-Euro"}, currencies[entities.EUR])
+		assert.Equal(t, []string{"Japan-Yen"}, currencies[entities.JPY])
+		assert.Contains(t, entities.KnownCurrencies(), entities.CurrencyCode("MXN"))
+
+		info, known := entities.CurrencyCode("MXN").Info()
+		require.True(t, known)
+		assert.Equal(t, "Mexican Peso", info.Name)
+		assert.Equal(t, 2, info.MinorUnits)
+	})
+
+	t.Run("Rejects invalid entries", func(t *testing.T) {
+		testCases := map[string]string{
+			"Malformed JSON":  `{"currencies": [`,
+			"Invalid code":    `{"currencies": [{"code": "EURO", "descriptors": ["Euro Zone-Euro"]}]}`,
+			"USD":             `{"currencies": [{"code": "USD", "descriptors": ["United States-Dollar"]}]}`,
+			"No descriptors":  `{"currencies": [{"code": "EUR", "descriptors": []}]}`,
+			"Bad minor units": `{"currencies": [{"code": "XAG", "descriptors": ["Silver"], "minor_units": 9}]}`,
+		}
+
+		for name, content := range testCases {
+			t.Run(name, func(t *testing.T) {
+				_, err := external.LoadTreasuryCurrencies(writeCurrencyMap(t, content))
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := external.LoadTreasuryCurrencies(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+}
+
+func TestTreasuryAPIClient_Currencies(t *testing.T) {
+	date := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Filters on every descriptor of the currency", func(t *testing.T) {
+		// Arrange
+		var filter string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			filter = r.URL.Query().Get("filter")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(treasuryBody))
+		}))
+		t.Cleanup(server.Close)
+
+		currencies := external.TreasuryCurrencies{entities.EUR: {"Euro Zone-Euro", "Germany-Euro"}}
+		client := external.NewTreasuryAPIClientWithCurrencies(retryConfig(server.URL, 1), entities.RateWindow{}, currencies)
+
+		// Act
+		rate, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0.92, rate.Rate)
+		assert.Contains(t, filter, "country_currency_desc:in:(Euro Zone-Euro,Germany-Euro)")
+	})
+
+	t.Run("Supports only mapped currencies", func(t *testing.T) {
+		currencies := external.TreasuryCurrencies{entities.EUR: {"Euro Zone-Euro"}}
+		client := external.NewTreasuryAPIClientWithCurrencies(retryConfig("http://localhost", 1), entities.RateWindow{}, currencies)
+
+		assert.True(t, client.SupportsCurrency(entities.EUR))
+		assert.False(t, client.SupportsCurrency(entities.JPY))
+	})
+}
@@ -0,0 +1,91 @@
+package external_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedTreasury serves one page of records per body, reporting the page count in the metadata
+func pagedTreasury(t *testing.T, pages ...string) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		page, err := strconv.Atoi(r.URL.Query().Get("page[number]"))
+		if err != nil || page < 1 || page > len(pages) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"data":[%s],"meta":{"count":1,"total-count":%d,"total-pages":%d}}`, pages[page-1], len(pages), len(pages))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestTreasuryAPIClient_Paging(t *testing.T) {
+	date := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+	window, err := entities.NewRateWindow(6)
+	require.NoError(t, err)
+
+	unusable := `{"country_currency_desc":"Euro Zone-Euro","exchange_rate":"n/a","record_date":"2024-01-31"}`
+	usable := `{"country_currency_desc":"Euro Zone-Euro","exchange_rate":"0.91","record_date":"2023-12-31"}`
+
+	t.Run("Reads later pages until a usable rate is found", func(t *testing.T) {
+		// Arrange
+		server, calls := pagedTreasury(t, unusable, usable, usable)
+		cfg := retryConfig(server.URL, 1)
+		cfg.PageSize = 1
+		client := external.NewTreasuryAPIClient(cfg, window)
+
+		// Act
+		rate, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0.91, rate.Rate)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("Stops once the window is exhausted", func(t *testing.T) {
+		// Arrange
+		server, calls := pagedTreasury(t, unusable, unusable)
+		cfg := retryConfig(server.URL, 1)
+		cfg.PageSize = 1
+		client := external.NewTreasuryAPIClient(cfg, window)
+
+		// Act
+		_, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+
+		// Assert
+		assert.ErrorIs(t, err, errs.ErrRateUnavailable)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("Stops at the page limit", func(t *testing.T) {
+		// Arrange
+		server, calls := pagedTreasury(t, unusable, unusable, usable)
+		cfg := retryConfig(server.URL, 1)
+		cfg.PageSize = 1
+		cfg.MaxPages = 2
+		client := external.NewTreasuryAPIClient(cfg, window)
+
+		// Act
+		_, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+
+		// Assert
+		assert.ErrorIs(t, err, errs.ErrRateUnavailable)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+}
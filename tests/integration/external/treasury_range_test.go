@@ -0,0 +1,93 @@
+package external_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTreasuryAPIClient_FetchExchangeRates(t *testing.T) {
+	startDate := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	window, err := entities.NewRateWindow(6)
+	require.NoError(t, err)
+
+	t.Run("Returns every rate in the range from one call", func(t *testing.T) {
+		// Arrange
+		var filters []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			filters = append(filters, r.URL.Query().Get("filter"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":[
+				{"country_currency_desc":"Euro Zone-Euro","exchange_rate":"0.92","record_date":"2024-03-31"},
+				{"country_currency_desc":"Euro Zone-Euro","exchange_rate":"n/a","record_date":"2024-01-31"},
+				{"country_currency_desc":"Euro Zone-Euro","exchange_rate":"0.91","record_date":"2023-12-31"},
+				{"country_currency_desc":"Euro Zone-Euro","exchange_rate":"0.93","record_date":"2023-09-30"}
+			],"meta":{"count":4,"total-count":4,"total-pages":1}}`))
+		}))
+		t.Cleanup(server.Close)
+		client := external.NewTreasuryAPIClient(retryConfig(server.URL, 1), window)
+
+		// Act
+		rates, err := client.FetchExchangeRates(context.Background(), entities.USD, entities.EUR, startDate, endDate)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, filters, 1)
+		assert.Contains(t, filters[0], "record_date:gte:2023-07-01,record_date:lte:2024-03-31")
+		require.Len(t, rates, 3, "unparseable records are skipped")
+		assert.Equal(t, 0.92, rates[0].Rate)
+		assert.Equal(t, time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC), rates[2].EffectiveDate)
+		assert.Equal(t, entities.EUR, rates[2].ToCurrency)
+	})
+
+	t.Run("Reads every page of a long range", func(t *testing.T) {
+		// Arrange
+		server, calls := pagedTreasury(t,
+			`{"country_currency_desc":"Euro Zone-Euro","exchange_rate":"0.92","record_date":"2024-03-31"}`,
+			`{"country_currency_desc":"Euro Zone-Euro","exchange_rate":"0.91","record_date":"2023-12-31"}`,
+		)
+		cfg := retryConfig(server.URL, 1)
+		cfg.PageSize = 1
+		client := external.NewTreasuryAPIClient(cfg, window)
+
+		// Act
+		rates, err := client.FetchExchangeRates(context.Background(), entities.USD, entities.EUR, startDate, endDate)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, rates, 2)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("An empty range is not an error", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":[],"meta":{"count":0,"total-count":0,"total-pages":0}}`))
+		}))
+		t.Cleanup(server.Close)
+		client := external.NewTreasuryAPIClient(retryConfig(server.URL, 1), window)
+
+		// Act
+		rates, err := client.FetchExchangeRates(context.Background(), entities.USD, entities.EUR, startDate, endDate)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, rates)
+	})
+
+	t.Run("Only USD is a base currency", func(t *testing.T) {
+		client := external.NewTreasuryAPIClient(retryConfig("http://127.0.0.1:0", 1), window)
+
+		_, err := client.FetchExchangeRates(context.Background(), entities.EUR, entities.BRL, startDate, endDate)
+
+		assert.ErrorContains(t, err, "only supports USD")
+	})
+}
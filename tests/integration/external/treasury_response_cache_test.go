@@ -0,0 +1,153 @@
+package external_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validatingTreasury serves one rate with the given validator headers, answering 304 to a matching conditional request
+type validatingTreasury struct {
+	mu          sync.Mutex
+	conditional []string // Conditional headers of each request, empty for unconditional ones
+	notModified int
+}
+
+func newValidatingTreasury(t *testing.T, etag, lastModified string) (*httptest.Server, *validatingTreasury) {
+	state := &validatingTreasury{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+
+		ifNoneMatch, ifModifiedSince := r.Header.Get("If-None-Match"), r.Header.Get("If-Modified-Since")
+		state.conditional = append(state.conditional, ifNoneMatch+ifModifiedSince)
+		if (etag != "" && ifNoneMatch == etag) || (lastModified != "" && ifModifiedSince == lastModified) {
+			state.notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+		if lastModified != "" {
+			w.Header().Set("Last-Modified", lastModified)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"country_currency_desc":"Euro Zone-Euro","exchange_rate":"0.92","record_date":"2023-12-31"}],"meta":{"count":1,"total-count":1,"total-pages":1}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, state
+}
+
+// cachingClient returns a Treasury client caching responses for a minute on a fake clock
+func cachingClient(baseURL string, clk clock.Clock) *external.TreasuryAPIClient {
+	cfg := retryConfig(baseURL, 1)
+	cfg.ResponseCacheSeconds = 60
+	cfg.ResponseCacheMaxEntries = 10
+	return external.NewTreasuryAPIClient(cfg, entities.RateWindow{}).(*external.TreasuryAPIClient).WithClock(clk)
+}
+
+func TestTreasuryAPIClient_ResponseCache(t *testing.T) {
+	date := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	t.Run("Repeated lookups within the TTL make one call", func(t *testing.T) {
+		// Arrange
+		server, state := newValidatingTreasury(t, "", "")
+		client := cachingClient(server.URL, clock.NewFake(date))
+
+		// Act
+		first, err := client.FetchExchangeRate(ctx, entities.USD, entities.EUR, date)
+		require.NoError(t, err)
+		second, err := client.FetchExchangeRate(ctx, entities.USD, entities.EUR, date)
+		require.NoError(t, err)
+		_, err = client.FetchExchangeRate(ctx, entities.USD, entities.EUR, date.AddDate(0, 0, 1))
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, 0.92, second.Rate)
+		assert.NotEqual(t, first.ID, second.ID, "each lookup gets its own rate entity")
+		assert.Len(t, state.conditional, 2, "another date is another request")
+	})
+
+	t.Run("Stale responses are revalidated with their ETag", func(t *testing.T) {
+		// Arrange
+		server, state := newValidatingTreasury(t, `"rates-v1"`, "")
+		clk := clock.NewFake(date)
+		client := cachingClient(server.URL, clk)
+		_, err := client.FetchExchangeRate(ctx, entities.USD, entities.EUR, date)
+		require.NoError(t, err)
+
+		// Act
+		clk.Advance(2 * time.Minute)
+		rate, err := client.FetchExchangeRate(ctx, entities.USD, entities.EUR, date)
+		require.NoError(t, err)
+		_, err = client.FetchExchangeRate(ctx, entities.USD, entities.EUR, date)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, 0.92, rate.Rate)
+		assert.Equal(t, []string{"", `"rates-v1"`}, state.conditional, "a 304 restarts the TTL")
+		assert.Equal(t, 1, state.notModified)
+	})
+
+	t.Run("Stale responses are revalidated with their Last-Modified date", func(t *testing.T) {
+		// Arrange
+		lastModified := "Thu, 15 Feb 2024 00:00:00 GMT"
+		server, state := newValidatingTreasury(t, "", lastModified)
+		clk := clock.NewFake(date)
+		client := cachingClient(server.URL, clk)
+		_, err := client.FetchExchangeRate(ctx, entities.USD, entities.EUR, date)
+		require.NoError(t, err)
+
+		// Act
+		clk.Advance(2 * time.Minute)
+		_, err = client.FetchExchangeRate(ctx, entities.USD, entities.EUR, date)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", lastModified}, state.conditional)
+	})
+
+	t.Run("Stale responses without validators are fetched again", func(t *testing.T) {
+		// Arrange
+		server, state := newValidatingTreasury(t, "", "")
+		clk := clock.NewFake(date)
+		client := cachingClient(server.URL, clk)
+		_, err := client.FetchExchangeRate(ctx, entities.USD, entities.EUR, date)
+		require.NoError(t, err)
+
+		// Act
+		clk.Advance(2 * time.Minute)
+		_, err = client.FetchExchangeRate(ctx, entities.USD, entities.EUR, date)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", ""}, state.conditional)
+	})
+
+	t.Run("A zero TTL disables the cache", func(t *testing.T) {
+		// Arrange
+		server, state := newValidatingTreasury(t, `"rates-v1"`, "")
+		client := external.NewTreasuryAPIClient(retryConfig(server.URL, 1), entities.RateWindow{})
+
+		// Act
+		for i := 0; i < 2; i++ {
+			_, err := client.FetchExchangeRate(ctx, entities.USD, entities.EUR, date)
+			require.NoError(t, err)
+		}
+
+		// Assert
+		assert.Equal(t, []string{"", ""}, state.conditional)
+	})
+}
@@ -0,0 +1,146 @@
+package external_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const treasuryBody = `{"data":[{"country_currency_desc":"Euro Zone-Euro","exchange_rate":"0.92","record_date":"2024-01-31"}],"meta":{"count":1,"total-count":1}}`
+
+// flakyTreasury answers with the given statuses in turn, then with a valid rate
+func flakyTreasury(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(calls.Add(1))
+		if call <= len(statuses) {
+			w.WriteHeader(statuses[call-1])
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(treasuryBody))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func retryConfig(baseURL string, maxAttempts int) *config.TreasuryConfig {
+	return &config.TreasuryConfig{
+		BaseURL:            baseURL,
+		TimeoutSeconds:     5,
+		RetryMaxAttempts:   maxAttempts,
+		RetryBaseDelayMs:   1,
+		RetryMaxDelayMs:    10,
+		RetryJitterPercent: 20,
+		RetryStatusCodes:   []int{429, 500, 502, 503, 504},
+	}
+}
+
+func TestTreasuryAPIClient_Retry(t *testing.T) {
+	date := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Retries transient failures until a rate is returned", func(t *testing.T) {
+		// Arrange
+		server, calls := flakyTreasury(t, http.StatusServiceUnavailable, http.StatusBadGateway)
+		client := external.NewTreasuryAPIClient(retryConfig(server.URL, 3), entities.RateWindow{})
+
+		// Act
+		rate, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0.92, rate.Rate)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("Gives up after the maximum number of attempts", func(t *testing.T) {
+		server, calls := flakyTreasury(t, 500, 500, 500, 500)
+		client := external.NewTreasuryAPIClient(retryConfig(server.URL, 3), entities.RateWindow{})
+
+		_, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Treasury API returned status 500 (after 3 attempts)")
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("Does not retry statuses outside the retryable list", func(t *testing.T) {
+		server, calls := flakyTreasury(t, http.StatusBadRequest)
+		client := external.NewTreasuryAPIClient(retryConfig(server.URL, 3), entities.RateWindow{})
+
+		_, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+
+		require.Error(t, err)
+		assert.Equal(t, "Treasury API returned status 400", err.Error())
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("A single attempt disables retries", func(t *testing.T) {
+		server, calls := flakyTreasury(t, http.StatusServiceUnavailable)
+		client := external.NewTreasuryAPIClient(retryConfig(server.URL, 1), entities.RateWindow{})
+
+		_, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+
+		require.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("Retries network errors", func(t *testing.T) {
+		server, _ := flakyTreasury(t)
+		url := server.URL
+		server.Close()
+		client := external.NewTreasuryAPIClient(retryConfig(url, 2), entities.RateWindow{})
+
+		_, err := client.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, date)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to fetch from Treasury API")
+		assert.Contains(t, err.Error(), "(after 2 attempts)")
+	})
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := external.RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    time.Second,
+	}
+
+	t.Run("Doubles the delay up to the maximum", func(t *testing.T) {
+		assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+		assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+		assert.Equal(t, 400*time.Millisecond, policy.Backoff(3))
+		assert.Equal(t, 800*time.Millisecond, policy.Backoff(4))
+		assert.Equal(t, time.Second, policy.Backoff(5))
+		assert.Equal(t, time.Second, policy.Backoff(50))
+	})
+
+	t.Run("Jitter only shortens the delay by up to its share", func(t *testing.T) {
+		jittered := policy
+		jittered.Jitter = 0.5
+		for i := 0; i < 100; i++ {
+			delay := jittered.Backoff(2)
+			assert.LessOrEqual(t, delay, 200*time.Millisecond)
+			assert.GreaterOrEqual(t, delay, 100*time.Millisecond)
+		}
+	})
+
+	t.Run("Builds from configuration", func(t *testing.T) {
+		built := external.NewRetryPolicy(retryConfig("", 4))
+
+		assert.Equal(t, 4, built.MaxAttempts)
+		assert.Equal(t, time.Millisecond, built.BaseDelay)
+		assert.Equal(t, 0.2, built.Jitter)
+		assert.True(t, built.ShouldRetry(http.StatusTooManyRequests))
+		assert.False(t, built.ShouldRetry(http.StatusNotFound))
+	})
+}
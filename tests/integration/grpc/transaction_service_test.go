@@ -0,0 +1,308 @@
+package grpc_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/grpc"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcClient calls unary RPCs over cleartext HTTP/2 the way a gRPC client does
+type grpcClient struct {
+	t       *testing.T
+	baseURL string
+	client  *http.Client
+}
+
+// newGRPCClient serves handler over h2c and returns a client for it
+func newGRPCClient(t *testing.T, handler http.Handler) *grpcClient {
+	srv := httptest.NewUnstartedServer(handler)
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	return &grpcClient{
+		t:       t,
+		baseURL: srv.URL,
+		client:  &http.Client{Transport: &http.Transport{Protocols: protocols}},
+	}
+}
+
+// invoke calls method with req, decodes a successful reply into resp and returns the status from the trailers
+func (c *grpcClient) invoke(method string, md http.Header, req, resp grpc.Message) (grpc.Code, string) {
+	payload, err := proto.Marshal(req)
+	require.NoError(c.t, err)
+	frame := make([]byte, 5, 5+len(payload))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(payload)))
+
+	httpReq, err := http.NewRequest(http.MethodPost, c.baseURL+"/"+grpc.ServiceName+"/"+method, bytes.NewReader(append(frame, payload...)))
+	require.NoError(c.t, err)
+	httpReq.Header = md.Clone()
+	if httpReq.Header == nil {
+		httpReq.Header = http.Header{}
+	}
+	httpReq.Header.Set("Content-Type", "application/grpc")
+	httpReq.Header.Set("TE", "trailers")
+
+	httpResp, err := c.client.Do(httpReq)
+	require.NoError(c.t, err)
+	defer httpResp.Body.Close()
+	require.Equal(c.t, 2, httpResp.ProtoMajor, "gRPC needs HTTP/2")
+	require.Equal(c.t, http.StatusOK, httpResp.StatusCode)
+	assert.Equal(c.t, "application/grpc", httpResp.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(httpResp.Body)
+	require.NoError(c.t, err)
+	if len(body) > 0 {
+		require.GreaterOrEqual(c.t, len(body), 5)
+		require.NoError(c.t, proto.Unmarshal(body[5:5+binary.BigEndian.Uint32(body[1:5])], resp))
+	}
+
+	code, err := strconv.ParseUint(httpResp.Trailer.Get("Grpc-Status"), 10, 32)
+	require.NoError(c.t, err)
+	message, err := url.PathUnescape(httpResp.Trailer.Get("Grpc-Message"))
+	require.NoError(c.t, err)
+	return grpc.Code(code), message
+}
+
+// newTransactionServer wires the transaction use cases over memory repositories
+func newTransactionServer() (*grpc.Server, *mocks.MockTreasuryService) {
+	validator := validation.NewValidator()
+	treasury := &mocks.MockTreasuryService{}
+	transactionRepo := memory.NewTransactionRepository()
+
+	convertTransactionUseCase := usecases.NewConvertTransactionUseCase(transactionRepo, memory.NewExchangeRateRepository(), memory.NewQuoteRepository(), treasury, nil, validator)
+	server := grpc.NewServer(
+		usecases.NewCreateTransactionUseCase(transactionRepo, validator),
+		usecases.NewGetTransactionUseCase(transactionRepo),
+		usecases.NewListTransactionsUseCase(transactionRepo, convertTransactionUseCase, validator),
+		convertTransactionUseCase,
+	)
+	return server, treasury
+}
+
+func TestTransactionService(t *testing.T) {
+	server, treasury := newTransactionServer()
+	client := newGRPCClient(t, server)
+
+	treasury.On("SupportsCurrency", entities.EUR).Return(true).Maybe()
+	treasury.On("SupportsCurrency", mock.Anything).Return(false).Maybe()
+
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	created := &grpc.Transaction{}
+	code, message := client.invoke("CreateTransaction", nil, &grpc.CreateTransactionRequest{
+		Description: "Hotel",
+		Date:        timestamppb.New(date),
+		Amount:      123.456,
+		Tags:        []string{"trip"},
+	}, created)
+	require.Equal(t, grpc.OK, code, message)
+
+	t.Run("CreateTransaction stores the rounded amount", func(t *testing.T) {
+		// Assert
+		assert.NotEmpty(t, created.Id)
+		assert.Equal(t, "Hotel", created.Description)
+		assert.Equal(t, date, created.Date.AsTime())
+		assert.Equal(t, 123.46, created.Amount)
+		assert.Equal(t, "USD", created.Currency)
+		assert.Equal(t, []string{"trip"}, created.Tags)
+		assert.NotNil(t, created.CreatedAt)
+	})
+
+	t.Run("GetTransaction returns the stored transaction", func(t *testing.T) {
+		// Act
+		fetched := &grpc.Transaction{}
+		code, message := client.invoke("GetTransaction", nil, &grpc.GetTransactionRequest{Id: created.Id}, fetched)
+
+		// Assert
+		require.Equal(t, grpc.OK, code, message)
+		assert.Equal(t, created.Id, fetched.Id)
+		assert.Equal(t, 123.46, fetched.Amount)
+	})
+
+	t.Run("ListTransactions pages and filters", func(t *testing.T) {
+		// Arrange
+		code, message := client.invoke("CreateTransaction", nil, &grpc.CreateTransactionRequest{
+			Description: "Taxi", Date: timestamppb.New(date.AddDate(0, 1, 0)), Amount: 20,
+		}, &grpc.Transaction{})
+		require.Equal(t, grpc.OK, code, message)
+
+		// Act
+		all := &grpc.ListTransactionsResponse{}
+		allCode, _ := client.invoke("ListTransactions", nil, &grpc.ListTransactionsRequest{}, all)
+		filtered := &grpc.ListTransactionsResponse{}
+		filteredCode, _ := client.invoke("ListTransactions", nil, &grpc.ListTransactionsRequest{Tag: "trip", Size: 1}, filtered)
+
+		// Assert
+		require.Equal(t, grpc.OK, allCode)
+		assert.Equal(t, int64(2), all.Total)
+		assert.Equal(t, int32(1), all.Page)
+		assert.Equal(t, int32(20), all.Size)
+		require.Len(t, all.Transactions, 2)
+
+		require.Equal(t, grpc.OK, filteredCode)
+		require.Len(t, filtered.Transactions, 1)
+		assert.Equal(t, created.Id, filtered.Transactions[0].Id)
+	})
+
+	t.Run("ConvertTransaction applies the Treasury rate", func(t *testing.T) {
+		// Arrange
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(&entities.ExchangeRate{
+			FromCurrency:  entities.USD,
+			ToCurrency:    entities.EUR,
+			Rate:          0.9,
+			EffectiveDate: date.AddDate(0, -1, 0),
+		}, nil).Once()
+
+		// Act
+		converted := &grpc.ConvertTransactionResponse{}
+		code, message := client.invoke("ConvertTransaction", nil, &grpc.ConvertTransactionRequest{Id: created.Id, TargetCurrency: "eur"}, converted)
+
+		// Assert
+		require.Equal(t, grpc.OK, code, message)
+		assert.Equal(t, "EUR", converted.TargetCurrency)
+		assert.Equal(t, 0.9, converted.ExchangeRate)
+		assert.Equal(t, 111.11, converted.ConvertedAmount)
+		assert.Equal(t, date.AddDate(0, -1, 0), converted.EffectiveDate.AsTime())
+		require.NotNil(t, converted.Transaction)
+		assert.Equal(t, created.Id, converted.Transaction.Id)
+	})
+
+	t.Run("Errors map to status codes", func(t *testing.T) {
+		testCases := map[string]struct {
+			method string
+			req    grpc.Message
+			code   grpc.Code
+		}{
+			"unknown transaction":  {"GetTransaction", &grpc.GetTransactionRequest{Id: "00000000-0000-0000-0000-000000000001"}, grpc.NotFound},
+			"malformed ID":         {"GetTransaction", &grpc.GetTransactionRequest{Id: "abc"}, grpc.InvalidArgument},
+			"invalid transaction":  {"CreateTransaction", &grpc.CreateTransactionRequest{Description: "No amount", Date: timestamppb.New(date)}, grpc.InvalidArgument},
+			"invalid page size":    {"ListTransactions", &grpc.ListTransactionsRequest{Size: 500}, grpc.InvalidArgument},
+			"unsupported currency": {"ConvertTransaction", &grpc.ConvertTransactionRequest{Id: created.Id, TargetCurrency: "XYZ"}, grpc.InvalidArgument},
+			"unknown method":       {"DeleteTransaction", &grpc.GetTransactionRequest{Id: created.Id}, grpc.Unimplemented},
+		}
+
+		for name, tc := range testCases {
+			code, message := client.invoke(tc.method, nil, tc.req, &grpc.Transaction{})
+			assert.Equal(t, tc.code, code, name)
+			assert.NotEmpty(t, message, name)
+		}
+	})
+}
+
+func TestTransactionService_Auth(t *testing.T) {
+	server, _ := newTransactionServer()
+	tokens := usecases.NewManageAPITokensUseCase(memory.NewAPITokenRepository(), validation.NewValidator())
+	auditLog := usecases.NewAuditLogUseCase(memory.NewAuditLogRepository())
+	server.WithAuditLog(auditLog).WithInterceptors(grpc.AuthInterceptor(tokens, nil))
+	client := newGRPCClient(t, server)
+
+	var writerID string
+	issue := func(role entities.APIRole) http.Header {
+		issued, err := tokens.Create(&dto.CreateAPITokenRequest{Name: string(role), Role: role})
+		require.NoError(t, err)
+		if role == entities.RoleWrite {
+			writerID = issued.ID.String()
+		}
+		return http.Header{"X-Api-Key": {issued.Token}}
+	}
+	reader := issue(entities.RoleRead)
+	writer := issue(entities.RoleWrite)
+
+	create := &grpc.CreateTransactionRequest{Description: "Lunch", Date: timestamppb.New(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)), Amount: 12}
+
+	t.Run("A missing or unknown key is Unauthenticated", func(t *testing.T) {
+		// Act
+		missingCode, _ := client.invoke("ListTransactions", nil, &grpc.ListTransactionsRequest{}, &grpc.ListTransactionsResponse{})
+		unknownCode, _ := client.invoke("ListTransactions", http.Header{"X-Api-Key": {"not-a-token"}}, &grpc.ListTransactionsRequest{}, &grpc.ListTransactionsResponse{})
+
+		// Assert
+		assert.Equal(t, grpc.Unauthenticated, missingCode)
+		assert.Equal(t, grpc.Unauthenticated, unknownCode)
+	})
+
+	t.Run("A read token cannot create transactions", func(t *testing.T) {
+		// Act
+		code, _ := client.invoke("CreateTransaction", reader, create, &grpc.Transaction{})
+
+		// Assert
+		assert.Equal(t, grpc.PermissionDenied, code)
+	})
+
+	t.Run("Roles include the ones below them", func(t *testing.T) {
+		// Act
+		createCode, message := client.invoke("CreateTransaction", writer, create, &grpc.Transaction{})
+		list := &grpc.ListTransactionsResponse{}
+		listCode, _ := client.invoke("ListTransactions", reader, &grpc.ListTransactionsRequest{}, list)
+
+		// Assert
+		require.Equal(t, grpc.OK, createCode, message)
+		require.Equal(t, grpc.OK, listCode)
+		assert.Len(t, list.Transactions, 1)
+	})
+
+	t.Run("Created transactions are audited with the authenticated token", func(t *testing.T) {
+		// Act
+		md := http.Header{"X-Api-Key": writer["X-Api-Key"], "X-Request-Id": {"grpc-req-1"}}
+		created := &grpc.Transaction{}
+		code, message := client.invoke("CreateTransaction", md, create, created)
+		require.Equal(t, grpc.OK, code, message)
+		trail, err := auditLog.List(&dto.ListAuditLogsRequest{Page: 1, Size: 10})
+
+		// Assert
+		require.NoError(t, err)
+		require.NotEmpty(t, trail.Data)
+		latest := trail.Data[0]
+		assert.Equal(t, created.Id, latest.EntityID.String())
+		assert.Equal(t, entities.AuditActionCreate, latest.Action)
+		assert.Equal(t, "token:"+writerID, latest.Actor)
+		assert.Equal(t, "grpc-req-1", latest.RequestID)
+	})
+}
+
+func TestTransactionService_RejectsNonGRPCRequests(t *testing.T) {
+	server, _ := newTransactionServer()
+
+	t.Run("GET is not allowed", func(t *testing.T) {
+		// Act
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/"+grpc.ServiceName+"/GetTransaction", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("JSON bodies are unsupported", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodPost, "/"+grpc.ServiceName+"/GetTransaction", bytes.NewBufferString("{}"))
+		req.Header.Set("Content-Type", "application/json")
+
+		// Act
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+}
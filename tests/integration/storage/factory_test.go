@@ -0,0 +1,153 @@
+package storage_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/storage"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStorage(t *testing.T) {
+	t.Run("SQLite driver", func(t *testing.T) {
+		store, err := storage.NewStorage(&config.DatabaseConfig{Driver: "sqlite", Path: ":memory:"})
+		require.NoError(t, err)
+		defer store.Close()
+
+		assert.Equal(t, storage.DriverSQLite, store.Driver)
+		assert.NotNil(t, store.DB())
+
+		// Repositories share the migrated connection
+		tx := fixtures.ValidTransaction()
+		require.NoError(t, store.TransactionRepository.Save(&tx))
+
+		count, err := store.TransactionRepository.Count()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		stats, err := store.Stats(context.Background())
+		require.NoError(t, err)
+		assert.Positive(t, stats.SizeBytes)
+		assert.Equal(t, int64(1), stats.RowCounts["transactions"])
+		assert.Contains(t, stats.RowCounts, "conversion_records")
+
+		pools := store.PoolStats()
+		require.Len(t, pools, 1)
+		assert.Equal(t, "primary", pools[0].Pool)
+		assert.Positive(t, pools[0].Open)
+	})
+
+	t.Run("Empty driver defaults to SQLite", func(t *testing.T) {
+		store, err := storage.NewStorage(&config.DatabaseConfig{Path: ":memory:"})
+		require.NoError(t, err)
+		defer store.Close()
+
+		assert.Equal(t, storage.DriverSQLite, store.Driver)
+	})
+
+	t.Run("SQLite file databases honour the pool settings", func(t *testing.T) {
+		// Arrange
+		shared := &config.DatabaseConfig{Driver: "sqlite", Path: filepath.Join(t.TempDir(), "shared.db"), MaxOpenConns: 7}
+		serialized := &config.DatabaseConfig{Driver: "sqlite", Path: filepath.Join(t.TempDir(), "serialized.db"), MaxOpenConns: 7, SQLiteSerializeWrites: true}
+
+		// Act
+		sharedStore, err := storage.NewStorage(shared)
+		require.NoError(t, err)
+		defer sharedStore.Close()
+		serializedStore, err := storage.NewStorage(serialized)
+		require.NoError(t, err)
+		defer serializedStore.Close()
+
+		// Assert
+		pools := sharedStore.PoolStats()
+		require.Len(t, pools, 1)
+		assert.Equal(t, 7, pools[0].MaxOpen)
+
+		pools = serializedStore.PoolStats()
+		require.Len(t, pools, 2)
+		assert.Equal(t, 1, pools[0].MaxOpen, "the writer keeps its single connection")
+		assert.Equal(t, "reader", pools[1].Pool)
+		assert.Equal(t, 7, pools[1].MaxOpen)
+	})
+
+	t.Run("Manual migrations refuse a schema that is behind", func(t *testing.T) {
+		// Arrange
+		cfg := &config.DatabaseConfig{Driver: "sqlite", Path: filepath.Join(t.TempDir(), "manual.db"), ManualMigrations: true}
+
+		// Act
+		_, err := storage.NewStorage(cfg)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "migrate up")
+
+		migrator, closeDB, err := storage.NewMigrator(cfg)
+		require.NoError(t, err)
+		_, err = migrator.Up()
+		require.NoError(t, err)
+		require.NoError(t, closeDB())
+
+		store, err := storage.NewStorage(cfg)
+		require.NoError(t, err)
+		defer store.Close()
+		tx := fixtures.ValidTransaction()
+		assert.NoError(t, store.TransactionRepository.Save(&tx))
+	})
+
+	t.Run("The memory driver has no schema to migrate", func(t *testing.T) {
+		_, _, err := storage.NewMigrator(&config.DatabaseConfig{Driver: "memory"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Memory driver", func(t *testing.T) {
+		store, err := storage.NewStorage(&config.DatabaseConfig{Driver: "MEMORY"})
+		require.NoError(t, err)
+		defer store.Close()
+
+		assert.Equal(t, storage.DriverMemory, store.Driver)
+		assert.Nil(t, store.DB())
+
+		rate := fixtures.ValidExchangeRate()
+		require.NoError(t, store.ExchangeRateRepository.Save(&rate))
+
+		exists, err := store.ExchangeRateRepository.Exists(rate.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("Postgres driver requires DSN", func(t *testing.T) {
+		store, err := storage.NewStorage(&config.DatabaseConfig{Driver: "postgres"})
+		assert.Error(t, err)
+		assert.Nil(t, store)
+		assert.Contains(t, err.Error(), "DSN is required")
+	})
+
+	t.Run("MySQL and MariaDB drivers require DSN", func(t *testing.T) {
+		for _, driver := range []string{"mysql", "MariaDB"} {
+			store, err := storage.NewStorage(&config.DatabaseConfig{Driver: driver})
+			assert.Nil(t, store)
+			assert.ErrorContains(t, err, "mysql DSN is required")
+		}
+	})
+
+	t.Run("MySQL driver rejects PostgreSQL-only options", func(t *testing.T) {
+		store, err := storage.NewStorage(&config.DatabaseConfig{
+			Driver:      "mysql",
+			DSN:         "user:pass@tcp(localhost:3306)/transactions",
+			ReplicaDSNs: []string{"user:pass@tcp(replica:3306)/transactions"},
+		})
+		assert.Nil(t, store)
+		assert.ErrorContains(t, err, "only supported by the postgres driver")
+	})
+
+	t.Run("Unsupported driver", func(t *testing.T) {
+		store, err := storage.NewStorage(&config.DatabaseConfig{Driver: "oracle"})
+		assert.Error(t, err)
+		assert.Nil(t, store)
+		assert.Contains(t, err.Error(), "unsupported database driver")
+	})
+}
@@ -0,0 +1,95 @@
+package storage_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/cache"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/storage"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ingestedRates records the rates a notifying repository announces
+type ingestedRates struct {
+	mu    sync.Mutex
+	rates []entities.ExchangeRate
+}
+
+func (l *ingestedRates) OnRateIngested(rate *entities.ExchangeRate) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rates = append(l.rates, *rate)
+}
+
+func TestDecoratedUnitOfWork(t *testing.T) {
+	// Setup: the decorators cmd/server wraps around the exchange rate repository
+	store, err := storage.NewStorage(&config.DatabaseConfig{Driver: "sqlite", Path: ":memory:"})
+	require.NoError(t, err)
+	defer store.Close()
+
+	rateCache := cache.NewRateCache(cache.NewLRUBackend(100), "", time.Hour)
+	listener := &ingestedRates{}
+	decorate := func(repo repositories.ExchangeRateRepository) repositories.ExchangeRateRepository {
+		return usecases.NewNotifyingExchangeRateRepository(cache.NewCachingExchangeRateRepository(repo, rateCache), listener)
+	}
+	exchangeRateRepo := decorate(store.ExchangeRateRepository)
+	unitOfWork := storage.NewDecoratedUnitOfWork(store.UnitOfWork, decorate)
+
+	purchaseDate := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	older := fixtures.ExchangeRateWithDate(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, exchangeRateRepo.Save(&older))
+	cached, err := exchangeRateRepo.FindRateForConversion(entities.USD, entities.BRL, purchaseDate, entities.RateWindow{})
+	require.NoError(t, err)
+	require.Equal(t, older.ID, cached.ID)
+	listener.rates = nil
+
+	t.Run("A rolled back unit reaches no decorator", func(t *testing.T) {
+		// Arrange
+		closer := fixtures.ExchangeRateWithDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+
+		// Act
+		err := unitOfWork.Do(func(repos repositories.Repositories) error {
+			if err := repos.ExchangeRates.SaveAll([]entities.ExchangeRate{closer}); err != nil {
+				return err
+			}
+			return errors.New("boom")
+		})
+
+		// Assert
+		require.Error(t, err)
+		assert.Empty(t, listener.rates)
+		found, err := exchangeRateRepo.FindRateForConversion(entities.USD, entities.BRL, purchaseDate, entities.RateWindow{})
+		require.NoError(t, err)
+		assert.Equal(t, older.ID, found.ID)
+	})
+
+	t.Run("A committed unit invalidates cached lookups and notifies listeners", func(t *testing.T) {
+		// Arrange
+		closer := fixtures.ExchangeRateWithDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+
+		// Act
+		err := unitOfWork.Do(func(repos repositories.Repositories) error {
+			return repos.ExchangeRates.SaveAll([]entities.ExchangeRate{closer})
+		})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, listener.rates, 1)
+		assert.Equal(t, closer.ID, listener.rates[0].ID)
+		found, err := exchangeRateRepo.FindRateForConversion(entities.USD, entities.BRL, purchaseDate, entities.RateWindow{})
+		require.NoError(t, err)
+		assert.Equal(t, closer.ID, found.ID, "the closer rate is not hidden behind the cached one")
+		summary, err := store.ExchangeRateRepository.SummarizeByCurrency()
+		require.NoError(t, err)
+		require.Len(t, summary, 1)
+		assert.Equal(t, int64(2), summary[0].Rates, "replayed writes are not stored twice")
+	})
+}
@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +19,11 @@ func (m *MockTransactionRepository) Save(transaction *entities.Transaction) erro
 	return args.Error(0)
 }
 
+func (m *MockTransactionRepository) SaveAll(transactions []entities.Transaction) error {
+	args := m.Called(transactions)
+	return args.Error(0)
+}
+
 func (m *MockTransactionRepository) GetByID(id uuid.UUID) (*entities.Transaction, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -26,6 +32,14 @@ func (m *MockTransactionRepository) GetByID(id uuid.UUID) (*entities.Transaction
 	return args.Get(0).(*entities.Transaction), args.Error(1)
 }
 
+func (m *MockTransactionRepository) GetByExternalID(externalID string) (*entities.Transaction, error) {
+	args := m.Called(externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transaction), args.Error(1)
+}
+
 func (m *MockTransactionRepository) GetAll() ([]entities.Transaction, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
@@ -34,6 +48,11 @@ func (m *MockTransactionRepository) GetAll() ([]entities.Transaction, error) {
 	return args.Get(0).([]entities.Transaction), args.Error(1)
 }
 
+func (m *MockTransactionRepository) ForEach(batchSize int, fn func(batch []entities.Transaction) error) error {
+	args := m.Called(batchSize, fn)
+	return args.Error(0)
+}
+
 func (m *MockTransactionRepository) GetAllPaginated(page, size int) ([]entities.Transaction, int64, error) {
 	args := m.Called(page, size)
 	if args.Get(0) == nil {
@@ -42,6 +61,30 @@ func (m *MockTransactionRepository) GetAllPaginated(page, size int) ([]entities.
 	return args.Get(0).([]entities.Transaction), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockTransactionRepository) FindPaginated(filter entities.TransactionFilter, page, size int) ([]entities.Transaction, int64, error) {
+	args := m.Called(filter, page, size)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entities.Transaction), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTransactionRepository) FindAfter(filter entities.TransactionFilter, cursor *entities.TransactionCursor, size int) ([]entities.Transaction, error) {
+	args := m.Called(filter, cursor, size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SuggestDescriptions(prefix string, limit int) ([]entities.DescriptionSuggestion, error) {
+	args := m.Called(prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DescriptionSuggestion), args.Error(1)
+}
+
 func (m *MockTransactionRepository) Update(transaction *entities.Transaction) error {
 	args := m.Called(transaction)
 	return args.Error(0)
@@ -52,6 +95,89 @@ func (m *MockTransactionRepository) Delete(id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockTransactionRepository) Restore(id uuid.UUID) (*entities.Transaction, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetDeletedPaginated(page, size int) ([]entities.Transaction, int64, error) {
+	args := m.Called(page, size)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entities.Transaction), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTransactionRepository) FindByDateBetween(from, to time.Time) ([]entities.Transaction, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SummarizeCreatedBetween(from, to time.Time) (entities.TransactionSummary, error) {
+	args := m.Called(from, to)
+	return args.Get(0).(entities.TransactionSummary), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SummarizeCategoryBetween(category string, from, to time.Time) (entities.TransactionSummary, error) {
+	args := m.Called(category, from, to)
+	return args.Get(0).(entities.TransactionSummary), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountByCategory(category string) (int64, error) {
+	args := m.Called(category)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTransactionRepository) RenameCategory(from, to string) (int64, error) {
+	args := m.Called(from, to)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTransactionRepository) LastModified() (time.Time, error) {
+	args := m.Called()
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ArchiveDatedBefore(cutoff time.Time, limit int) (int64, error) {
+	args := m.Called(cutoff, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTransactionRepository) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	args := m.Called(cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetDeletedByID(id uuid.UUID) (*entities.Transaction, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetArchivedByID(id uuid.UUID) (*entities.Transaction, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetAllPaginatedWithArchived(page, size int) ([]entities.Transaction, int64, error) {
+	args := m.Called(page, size)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entities.Transaction), args.Get(1).(int64), args.Error(2)
+}
+
 func (m *MockTransactionRepository) Exists(id uuid.UUID) (bool, error) {
 	args := m.Called(id)
 	return args.Bool(0), args.Error(1)
@@ -72,6 +198,11 @@ func (m *MockExchangeRateRepository) Save(exchangeRate *entities.ExchangeRate) e
 	return args.Error(0)
 }
 
+func (m *MockExchangeRateRepository) SaveAll(exchangeRates []entities.ExchangeRate) error {
+	args := m.Called(exchangeRates)
+	return args.Error(0)
+}
+
 func (m *MockExchangeRateRepository) GetByID(id uuid.UUID) (*entities.ExchangeRate, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -80,7 +211,7 @@ func (m *MockExchangeRateRepository) GetByID(id uuid.UUID) (*entities.ExchangeRa
 	return args.Get(0).(*entities.ExchangeRate), args.Error(1)
 }
 
-func (m *MockExchangeRateRepository) FindRateForConversion(from, to entities.CurrencyCode, transactionDate time.Time) (*entities.ExchangeRate, error) {
+func (m *MockExchangeRateRepository) FindRateForConversion(from, to entities.CurrencyCode, transactionDate time.Time, window entities.RateWindow) (*entities.ExchangeRate, error) {
 	args := m.Called(from, to, transactionDate)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -88,6 +219,23 @@ func (m *MockExchangeRateRepository) FindRateForConversion(from, to entities.Cur
 	return args.Get(0).(*entities.ExchangeRate), args.Error(1)
 }
 
+func (m *MockExchangeRateRepository) FindSurroundingRates(from, to entities.CurrencyCode, date time.Time, windowMonths int) (*entities.ExchangeRate, *entities.ExchangeRate, error) {
+	args := m.Called(from, to, date, windowMonths)
+	var before, after *entities.ExchangeRate
+	if args.Get(0) != nil {
+		before = args.Get(0).(*entities.ExchangeRate)
+	}
+	if args.Get(1) != nil {
+		after = args.Get(1).(*entities.ExchangeRate)
+	}
+	return before, after, args.Error(2)
+}
+
+func (m *MockExchangeRateRepository) ForEach(batchSize int, fn func(batch []entities.ExchangeRate) error) error {
+	args := m.Called(batchSize, fn)
+	return args.Error(0)
+}
+
 func (m *MockExchangeRateRepository) Update(exchangeRate *entities.ExchangeRate) error {
 	args := m.Called(exchangeRate)
 	return args.Error(0)
@@ -98,20 +246,99 @@ func (m *MockExchangeRateRepository) Delete(id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockExchangeRateRepository) SummarizeByCurrency() ([]entities.RateCacheSummary, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.RateCacheSummary), args.Error(1)
+}
+
+func (m *MockExchangeRateRepository) Purge(currency entities.CurrencyCode, effectiveDate *time.Time) (int64, error) {
+	args := m.Called(currency, effectiveDate)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockExchangeRateRepository) Exists(id uuid.UUID) (bool, error) {
 	args := m.Called(id)
 	return args.Bool(0), args.Error(1)
 }
 
+// MockQuoteRepository is a mock implementation of QuoteRepository
+type MockQuoteRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuoteRepository) Save(quote *entities.RateQuote) error {
+	args := m.Called(quote)
+	return args.Error(0)
+}
+
+func (m *MockQuoteRepository) GetByID(id uuid.UUID) (*entities.RateQuote, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RateQuote), args.Error(1)
+}
+
+func (m *MockQuoteRepository) DeleteExpired(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // MockTreasuryService is a mock implementation of TreasuryService
 type MockTreasuryService struct {
 	mock.Mock
 }
 
-func (m *MockTreasuryService) FetchExchangeRate(from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
-	args := m.Called(from, to, date)
+func (m *MockTreasuryService) FetchExchangeRate(ctx context.Context, from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	args := m.Called(ctx, from, to, date)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entities.ExchangeRate), args.Error(1)
 }
+
+func (m *MockTreasuryService) FetchExchangeRates(ctx context.Context, from, to entities.CurrencyCode, startDate, endDate time.Time) ([]entities.ExchangeRate, error) {
+	args := m.Called(ctx, from, to, startDate, endDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ExchangeRate), args.Error(1)
+}
+
+func (m *MockTreasuryService) SupportsCurrency(code entities.CurrencyCode) bool {
+	args := m.Called(code)
+	return args.Bool(0)
+}
+
+func (m *MockTreasuryService) ProviderName() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+// MockEmailSender is a mock implementation of EmailSender
+type MockEmailSender struct {
+	mock.Mock
+}
+
+func (m *MockEmailSender) Send(recipients []string, subject, body string) error {
+	args := m.Called(recipients, subject, body)
+	return args.Error(0)
+}
+
+// MockBankAggregator is a mock implementation of BankAggregator
+type MockBankAggregator struct {
+	mock.Mock
+}
+
+func (m *MockBankAggregator) FetchTransactions(ctx context.Context, accessToken string, from, to time.Time) ([]entities.BankTransaction, error) {
+	args := m.Called(ctx, accessToken, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.BankTransaction), args.Error(1)
+}
+
+func (m *MockBankAggregator) ProviderName() string {
+	args := m.Called()
+	return args.String(0)
+}
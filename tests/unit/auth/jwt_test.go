@@ -0,0 +1,347 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func encodeSegment(t *testing.T, value any) string {
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signHS256(t *testing.T, secret string, claims map[string]any) string {
+	input := encodeSegment(t, map[string]any{"alg": "HS256", "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	input := encodeSegment(t, map[string]any{"alg": "RS256", "typ": "JWT", "kid": kid}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(input))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims(roles ...string) map[string]any {
+	return map[string]any{
+		"sub":   "user-42",
+		"iss":   "https://id.example.com",
+		"aud":   []string{"purchase-api", "other"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": roles,
+	}
+}
+
+func TestJWTVerifierHS256(t *testing.T) {
+	verifier, err := auth.NewJWTVerifier(&config.JWTConfig{
+		Algorithm:  "HS256",
+		Secret:     testSecret,
+		Issuer:     "https://id.example.com",
+		Audience:   "purchase-api",
+		RolesClaim: "roles",
+	})
+	require.NoError(t, err)
+
+	t.Run("Accepts a valid token and maps the highest role", func(t *testing.T) {
+		// Act
+		claims, err := verifier.Verify(signHS256(t, testSecret, validClaims("reader", "writer", "unknown")))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "user-42", claims.Subject)
+		assert.Equal(t, entities.RoleWrite, claims.Role)
+		assert.Equal(t, []string{"purchase-api", "other"}, claims.Audience)
+	})
+
+	t.Run("A single role may be a plain string", func(t *testing.T) {
+		claims := validClaims()
+		claims["roles"] = "admin"
+
+		subject, role, err := verifier.AuthenticateBearer(signHS256(t, testSecret, claims))
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-42", subject)
+		assert.Equal(t, entities.RoleAdmin, role)
+	})
+
+	t.Run("A string audience is a single value", func(t *testing.T) {
+		claims := validClaims("reader")
+		claims["aud"] = "purchase-api other"
+
+		_, err := verifier.Verify(signHS256(t, testSecret, claims))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "audience")
+
+		claims["aud"] = "purchase-api"
+		verified, err := verifier.Verify(signHS256(t, testSecret, claims))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"purchase-api"}, verified.Audience)
+	})
+
+	t.Run("Space separated roles in a string are all read", func(t *testing.T) {
+		claims := validClaims()
+		claims["roles"] = "reader admin"
+
+		_, role, err := verifier.AuthenticateBearer(signHS256(t, testSecret, claims))
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.RoleAdmin, role)
+	})
+
+	t.Run("A token without known roles grants no role", func(t *testing.T) {
+		claims, err := verifier.Verify(signHS256(t, testSecret, validClaims("guest")))
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.APIRole(""), claims.Role)
+	})
+
+	t.Run("Rejects invalid tokens", func(t *testing.T) {
+		expired := validClaims("reader")
+		expired["exp"] = time.Now().Add(-2 * time.Minute).Unix()
+		notYet := validClaims("reader")
+		notYet["nbf"] = time.Now().Add(10 * time.Minute).Unix()
+		wrongIssuer := validClaims("reader")
+		wrongIssuer["iss"] = "https://evil.example.com"
+		wrongAudience := validClaims("reader")
+		wrongAudience["aud"] = "someone-else"
+		noExpiry := validClaims("reader")
+		delete(noExpiry, "exp")
+		unsigned := encodeSegment(t, map[string]any{"alg": "none"}) + "." + encodeSegment(t, validClaims("admin")) + "."
+
+		cases := map[string]struct {
+			token    string
+			contains string
+		}{
+			"expired":        {signHS256(t, testSecret, expired), "expired"},
+			"not yet valid":  {signHS256(t, testSecret, notYet), "not valid yet"},
+			"wrong issuer":   {signHS256(t, testSecret, wrongIssuer), "issuer"},
+			"wrong audience": {signHS256(t, testSecret, wrongAudience), "audience"},
+			"no expiry":      {signHS256(t, testSecret, noExpiry), "exp claim"},
+			"wrong secret":   {signHS256(t, "ffffffffffffffffffffffffffffffff", validClaims("reader")), "signature"},
+			"alg none":       {unsigned, "algorithm"},
+			"malformed":      {"not-a-jwt", "malformed"},
+		}
+		for name, tc := range cases {
+			_, err := verifier.Verify(tc.token)
+			require.Error(t, err, name)
+			assert.Contains(t, err.Error(), tc.contains, name)
+			assert.NotContains(t, err.Error(), "failed to", name)
+		}
+	})
+
+	t.Run("Expiry is checked against the injected clock", func(t *testing.T) {
+		// Arrange
+		clocked, err := auth.NewJWTVerifier(&config.JWTConfig{Algorithm: "HS256", Secret: testSecret, RolesClaim: "roles"})
+		require.NoError(t, err)
+		clk := clock.NewFake(time.Now().Add(2 * time.Hour))
+		clocked.WithClock(clk)
+		token := signHS256(t, testSecret, validClaims("reader"))
+
+		// Act
+		_, expiredErr := clocked.Verify(token)
+		clk.Set(time.Now())
+		_, validErr := clocked.Verify(token)
+
+		// Assert
+		assert.ErrorContains(t, expiredErr, "expired")
+		assert.NoError(t, validErr)
+	})
+}
+
+// jwk encodes an RSA public key as a JSON Web Key
+func jwk(kid string, public *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA", "kid": kid, "use": "sig", "alg": "RS256",
+		"n": base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+		"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+	}
+}
+
+func TestJWTVerifierRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rotated, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	t.Run("Verifies with a PEM public key", func(t *testing.T) {
+		// Arrange
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "jwt.pem")
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+		verifier, err := auth.NewJWTVerifier(&config.JWTConfig{Algorithm: "RS256", PublicKeyFile: path, RolesClaim: "roles"})
+		require.NoError(t, err)
+
+		// Act & Assert
+		claims, err := verifier.Verify(signRS256(t, key, "", validClaims("reader")))
+		require.NoError(t, err)
+		assert.Equal(t, entities.RoleRead, claims.Role)
+
+		_, err = verifier.Verify(signRS256(t, rotated, "", validClaims("reader")))
+		assert.ErrorContains(t, err, "signature")
+
+		_, err = verifier.Verify(signHS256(t, testSecret, validClaims("admin")))
+		assert.ErrorContains(t, err, "algorithm")
+	})
+
+	t.Run("Fetches keys from a JWKS URL and refetches after a rotation", func(t *testing.T) {
+		// Arrange
+		var fetches atomic.Int32
+		var keys atomic.Value
+		keys.Store([]map[string]string{jwk("k1", &key.PublicKey)})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fetches.Add(1)
+			_ = json.NewEncoder(w).Encode(map[string]any{"keys": keys.Load()})
+		}))
+		defer server.Close()
+
+		verifier, err := auth.NewJWTVerifier(&config.JWTConfig{Algorithm: "RS256", JWKSURL: server.URL, JWKSRefreshMins: 60, RolesClaim: "roles"})
+		require.NoError(t, err)
+
+		// Act & Assert - the key set is fetched once and cached
+		_, err = verifier.Verify(signRS256(t, key, "k1", validClaims("writer")))
+		require.NoError(t, err)
+		_, err = verifier.Verify(signRS256(t, key, "k1", validClaims("writer")))
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), fetches.Load())
+
+		// Unknown kids right after a fetch are rejected without hammering the provider
+		keys.Store([]map[string]string{jwk("k1", &key.PublicKey), jwk("k2", &rotated.PublicKey)})
+		_, err = verifier.Verify(signRS256(t, rotated, "k2", validClaims("writer")))
+		assert.ErrorContains(t, err, "unknown signing key")
+		assert.Equal(t, int32(1), fetches.Load())
+	})
+
+	t.Run("Concurrent requests share one fetch", func(t *testing.T) {
+		// Arrange
+		var fetches atomic.Int32
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fetches.Add(1)
+			<-release
+			_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{jwk("k1", &key.PublicKey)}})
+		}))
+		defer server.Close()
+
+		verifier, err := auth.NewJWTVerifier(&config.JWTConfig{Algorithm: "RS256", JWKSURL: server.URL, JWKSRefreshMins: 60, RolesClaim: "roles"})
+		require.NoError(t, err)
+		token := signRS256(t, key, "k1", validClaims("reader"))
+
+		// Act
+		var wg sync.WaitGroup
+		results := make(chan error, 10)
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := verifier.Verify(token)
+				results <- err
+			}()
+		}
+		require.Eventually(t, func() bool { return fetches.Load() == 1 }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+		close(results)
+
+		// Assert
+		for err := range results {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), fetches.Load())
+	})
+
+	t.Run("Failed fetches back off before retrying", func(t *testing.T) {
+		// Arrange
+		var fetches atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fetches.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		clk := clock.NewFake(time.Now())
+		verifier, err := auth.NewJWTVerifier(&config.JWTConfig{Algorithm: "RS256", JWKSURL: server.URL, JWKSRefreshMins: 60, RolesClaim: "roles"})
+		require.NoError(t, err)
+		verifier.WithClock(clk)
+		token := signRS256(t, key, "k1", validClaims("reader"))
+
+		// Act & Assert - the failure is returned again without calling the provider
+		_, err = verifier.Verify(token)
+		assert.ErrorContains(t, err, "status 503")
+		_, err = verifier.Verify(token)
+		assert.ErrorContains(t, err, "status 503")
+		assert.Equal(t, int32(1), fetches.Load())
+
+		// The wait doubles with every failure in a row
+		clk.Advance(5 * time.Second)
+		_, _ = verifier.Verify(token)
+		assert.Equal(t, int32(2), fetches.Load())
+		clk.Advance(5 * time.Second)
+		_, _ = verifier.Verify(token)
+		assert.Equal(t, int32(2), fetches.Load())
+		clk.Advance(5 * time.Second)
+		_, _ = verifier.Verify(token)
+		assert.Equal(t, int32(3), fetches.Load())
+	})
+
+	t.Run("An unreachable JWKS is reported as a server-side failure", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		verifier, err := auth.NewJWTVerifier(&config.JWTConfig{Algorithm: "RS256", JWKSURL: server.URL, JWKSRefreshMins: 60, RolesClaim: "roles"})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(signRS256(t, key, "k1", validClaims("writer")))
+		assert.ErrorContains(t, err, "failed to fetch JWKS")
+	})
+}
+
+func TestNewJWTVerifier(t *testing.T) {
+	t.Run("No algorithm disables bearer tokens", func(t *testing.T) {
+		verifier, err := auth.NewJWTVerifier(&config.JWTConfig{})
+		require.NoError(t, err)
+		assert.Nil(t, verifier)
+	})
+
+	t.Run("Rejects incomplete configurations", func(t *testing.T) {
+		for _, cfg := range []config.JWTConfig{
+			{Algorithm: "HS256", Secret: "short"},
+			{Algorithm: "RS256"},
+			{Algorithm: "RS256", PublicKeyFile: "/does/not/exist.pem"},
+			{Algorithm: "ES256"},
+		} {
+			_, err := auth.NewJWTVerifier(&cfg)
+			assert.Error(t, err, cfg.Algorithm)
+		}
+	})
+}
@@ -0,0 +1,258 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/cache"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUBackend(t *testing.T) {
+	t.Run("Evicts the least recently used entry once full", func(t *testing.T) {
+		// Arrange
+		backend := cache.NewLRUBackend(2)
+		require.NoError(t, backend.Set("a", []byte("1"), 0))
+		require.NoError(t, backend.Set("b", []byte("2"), 0))
+
+		// Act - reading a makes b the least recently used
+		_, found, _ := backend.Get("a")
+		require.True(t, found)
+		require.NoError(t, backend.Set("c", []byte("3"), 0))
+
+		// Assert
+		assert.Equal(t, 2, backend.Len())
+		_, found, _ = backend.Get("b")
+		assert.False(t, found)
+		value, found, _ := backend.Get("a")
+		assert.True(t, found)
+		assert.Equal(t, []byte("1"), value)
+	})
+
+	t.Run("Expires entries after their TTL", func(t *testing.T) {
+		backend := cache.NewLRUBackend(10)
+		require.NoError(t, backend.Set("short", []byte("x"), 10*time.Millisecond))
+		require.NoError(t, backend.Set("forever", []byte("y"), 0))
+
+		time.Sleep(20 * time.Millisecond)
+
+		_, found, _ := backend.Get("short")
+		assert.False(t, found)
+		_, found, _ = backend.Get("forever")
+		assert.True(t, found)
+		assert.Equal(t, 1, backend.Len())
+	})
+
+	t.Run("Counters are never evicted", func(t *testing.T) {
+		backend := cache.NewLRUBackend(1)
+
+		first, _ := backend.Incr("gen")
+		second, _ := backend.Incr("gen")
+		require.NoError(t, backend.Set("a", []byte("1"), 0))
+		require.NoError(t, backend.Set("b", []byte("2"), 0))
+		current, err := backend.Counter("gen")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), first)
+		assert.Equal(t, int64(2), second)
+		assert.Equal(t, int64(2), current)
+		assert.NoError(t, backend.Ping(context.Background()))
+	})
+}
+
+func TestCachingExchangeRateRepository(t *testing.T) {
+	purchase := time.Date(2024, 3, 10, 14, 30, 0, 0, time.UTC)
+	rate := &entities.ExchangeRate{
+		ID:            uuid.New(),
+		FromCurrency:  entities.USD,
+		ToCurrency:    entities.EUR,
+		Rate:          0.92,
+		EffectiveDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		RecordDate:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	setup := func() (*mocks.MockExchangeRateRepository, *mocks.MockTreasuryService, *cache.RateCache) {
+		return new(mocks.MockExchangeRateRepository), new(mocks.MockTreasuryService),
+			cache.NewRateCache(cache.NewLRUBackend(100), "", time.Hour)
+	}
+
+	t.Run("Repeated lookups for the same pair and day hit the cache", func(t *testing.T) {
+		// Arrange
+		inner, _, rates := setup()
+		inner.On("FindRateForConversion", entities.USD, entities.EUR, mock.Anything).Return(rate, nil).Once()
+		repo := cache.NewCachingExchangeRateRepository(inner, rates)
+
+		// Act
+		first, err := repo.FindRateForConversion(entities.USD, entities.EUR, purchase, entities.RateWindow{})
+		require.NoError(t, err)
+		second, err := repo.FindRateForConversion(entities.USD, entities.EUR, purchase.Add(-4*time.Hour), entities.RateWindow{})
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, rate.ID, first.ID)
+		assert.Equal(t, rate.ID, second.ID)
+		assert.Equal(t, 0.92, second.Rate)
+		inner.AssertNumberOfCalls(t, "FindRateForConversion", 1)
+	})
+
+	t.Run("Misses and errors are not cached", func(t *testing.T) {
+		inner, _, rates := setup()
+		inner.On("FindRateForConversion", entities.USD, entities.CAD, mock.Anything).Return(nil, nil).Once()
+		inner.On("FindRateForConversion", entities.USD, entities.CAD, mock.Anything).Return(nil, errors.New("database locked")).Once()
+		inner.On("FindRateForConversion", entities.USD, entities.CAD, mock.Anything).Return(rate, nil).Once()
+		repo := cache.NewCachingExchangeRateRepository(inner, rates)
+
+		missing, err := repo.FindRateForConversion(entities.USD, entities.CAD, purchase, entities.RateWindow{})
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+		_, err = repo.FindRateForConversion(entities.USD, entities.CAD, purchase, entities.RateWindow{})
+		assert.Error(t, err)
+		found, err := repo.FindRateForConversion(entities.USD, entities.CAD, purchase, entities.RateWindow{})
+		require.NoError(t, err)
+
+		assert.NotNil(t, found)
+		inner.AssertNumberOfCalls(t, "FindRateForConversion", 3)
+	})
+
+	t.Run("Saving a rate invalidates its pair only", func(t *testing.T) {
+		// Arrange
+		inner, _, rates := setup()
+		closer := *rate
+		closer.ID = uuid.New()
+		closer.EffectiveDate = time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+		other := *rate
+		other.ToCurrency = entities.GBP
+		inner.On("FindRateForConversion", entities.USD, entities.EUR, mock.Anything).Return(rate, nil).Once()
+		inner.On("FindRateForConversion", entities.USD, entities.EUR, mock.Anything).Return(&closer, nil).Once()
+		inner.On("FindRateForConversion", entities.USD, entities.GBP, mock.Anything).Return(&other, nil).Once()
+		inner.On("Save", &closer).Return(nil)
+		repo := cache.NewCachingExchangeRateRepository(inner, rates)
+
+		_, _ = repo.FindRateForConversion(entities.USD, entities.EUR, purchase, entities.RateWindow{})
+		_, _ = repo.FindRateForConversion(entities.USD, entities.GBP, purchase, entities.RateWindow{})
+
+		// Act
+		require.NoError(t, repo.Save(&closer))
+		eur, err := repo.FindRateForConversion(entities.USD, entities.EUR, purchase, entities.RateWindow{})
+		require.NoError(t, err)
+		_, err = repo.FindRateForConversion(entities.USD, entities.GBP, purchase, entities.RateWindow{})
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, closer.ID, eur.ID)
+		inner.AssertNumberOfCalls(t, "FindRateForConversion", 3)
+	})
+
+	t.Run("A cached rate outside the 6-month window of a later time is not served", func(t *testing.T) {
+		// Arrange - the rate is exactly six months before midnight, so it stops being valid later that day
+		inner, _, rates := setup()
+		edge := *rate
+		edge.EffectiveDate = time.Date(2023, 9, 10, 0, 0, 0, 0, time.UTC)
+		midnight := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+		inner.On("FindRateForConversion", entities.USD, entities.EUR, midnight).Return(&edge, nil).Once()
+		inner.On("FindRateForConversion", entities.USD, entities.EUR, purchase).Return(nil, nil).Once()
+		repo := cache.NewCachingExchangeRateRepository(inner, rates)
+
+		// Act
+		atMidnight, _ := repo.FindRateForConversion(entities.USD, entities.EUR, midnight, entities.RateWindow{})
+		later, _ := repo.FindRateForConversion(entities.USD, entities.EUR, purchase, entities.RateWindow{})
+
+		// Assert
+		assert.NotNil(t, atMidnight)
+		assert.Nil(t, later)
+	})
+
+	t.Run("Purging a currency also drops rates fetched from the Treasury", func(t *testing.T) {
+		// Arrange
+		inner, treasury, rates := setup()
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, purchase).Return(rate, nil).Twice()
+		inner.On("Purge", entities.EUR, (*time.Time)(nil)).Return(int64(1), nil)
+		repo := cache.NewCachingExchangeRateRepository(inner, rates)
+		service := cache.NewCachingTreasuryService(treasury, rates, entities.RateWindow{})
+
+		_, err := service.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, purchase)
+		require.NoError(t, err)
+		_, err = service.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, purchase)
+		require.NoError(t, err)
+		treasury.AssertNumberOfCalls(t, "FetchExchangeRate", 1)
+
+		// Act
+		_, err = repo.Purge(entities.EUR, nil)
+		require.NoError(t, err)
+		_, err = service.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, purchase)
+
+		// Assert
+		require.NoError(t, err)
+		treasury.AssertNumberOfCalls(t, "FetchExchangeRate", 2)
+	})
+
+	t.Run("Treasury errors are not cached", func(t *testing.T) {
+		_, treasury, rates := setup()
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.JPY, purchase).Return(nil, errors.New("Treasury API returned status 503")).Twice()
+		service := cache.NewCachingTreasuryService(treasury, rates, entities.RateWindow{})
+
+		_, err := service.FetchExchangeRate(context.Background(), entities.USD, entities.JPY, purchase)
+		assert.Error(t, err)
+		_, err = service.FetchExchangeRate(context.Background(), entities.USD, entities.JPY, purchase)
+		assert.Error(t, err)
+
+		treasury.AssertNumberOfCalls(t, "FetchExchangeRate", 2)
+	})
+
+	t.Run("Fallback provider rates are not cached", func(t *testing.T) {
+		// Arrange - the Treasury is down, so the chain answers with an ECB rate, then recovers
+		_, treasury, rates := setup()
+		fallback := *rate
+		fallback.Source = "ecb"
+		fallback.Rate = 0.91
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, purchase).Return(&fallback, nil).Once()
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, purchase).Return(rate, nil).Once()
+		service := cache.NewCachingTreasuryService(treasury, rates, entities.RateWindow{})
+
+		// Act
+		first, err := service.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, purchase)
+		require.NoError(t, err)
+		second, err := service.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, purchase)
+		require.NoError(t, err)
+		third, err := service.FetchExchangeRate(context.Background(), entities.USD, entities.EUR, purchase)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, "ecb", first.Source)
+		assert.True(t, second.IsAuthoritative())
+		assert.Equal(t, rate.ID, third.ID)
+		treasury.AssertNumberOfCalls(t, "FetchExchangeRate", 2)
+	})
+}
+
+func TestNewRateCacheFromConfig(t *testing.T) {
+	t.Run("Builds the configured backend", func(t *testing.T) {
+		rates, backend, err := cache.NewRateCacheFromConfig(&config.RateCacheConfig{Backend: "memory", TTLSeconds: 60, MaxEntries: 10})
+		require.NoError(t, err)
+		assert.NotNil(t, rates)
+		assert.IsType(t, &cache.LRUBackend{}, backend)
+
+		rates, backend, err = cache.NewRateCacheFromConfig(&config.RateCacheConfig{Backend: "redis", RedisAddr: "localhost:6379"})
+		require.NoError(t, err)
+		assert.NotNil(t, rates)
+		assert.IsType(t, &cache.RedisBackend{}, backend)
+	})
+
+	t.Run("Off disables the cache and unknown backends are rejected", func(t *testing.T) {
+		rates, backend, err := cache.NewRateCacheFromConfig(&config.RateCacheConfig{Backend: "off"})
+		require.NoError(t, err)
+		assert.Nil(t, rates)
+		assert.Nil(t, backend)
+
+		_, _, err = cache.NewRateCacheFromConfig(&config.RateCacheConfig{Backend: "memcached"})
+		assert.Error(t, err)
+	})
+}
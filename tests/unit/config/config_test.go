@@ -0,0 +1,182 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFile writes a YAML configuration file and points CONFIG_FILE at it
+func writeConfigFile(t *testing.T, content string) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv(config.FileEnv, path)
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Unset keys get their defaults", func(t *testing.T) {
+		// Act
+		cfg, err := config.Load()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 25, cfg.Database.MaxOpenConns)
+		assert.Equal(t, "warn", cfg.Database.LogLevel)
+		assert.Equal(t, 200, cfg.Database.SlowQueryMs)
+		assert.Equal(t, "wal", cfg.Database.SQLiteJournalMode)
+		assert.Equal(t, 5000, cfg.Database.SQLiteBusyTimeoutMs)
+		assert.True(t, cfg.Database.SQLiteSerializeWrites)
+		assert.Equal(t, []int{429, 500, 502, 503, 504}, cfg.Treasury.RetryStatusCodes)
+		assert.True(t, cfg.Treasury.BreakerEnabled)
+		assert.Equal(t, 300, cfg.Treasury.ResponseCacheSeconds)
+		assert.Equal(t, []string{"treasury"}, cfg.Providers.Order)
+		assert.False(t, cfg.Conversion.StaleFallback)
+		assert.Equal(t, 12, cfg.Conversion.StaleMaxMonths)
+		assert.Equal(t, 10, cfg.Server.RequestTimeoutSecs)
+		assert.Equal(t, map[string]int{"convert": 25, "admin": 0}, cfg.Server.RequestTimeoutSecsByProfile)
+	})
+
+	t.Run("Request timeout profiles override the built-in budgets", func(t *testing.T) {
+		// Arrange
+		t.Setenv("REQUEST_TIMEOUT_PROFILES", "convert:40,list:5")
+
+		// Act
+		cfg, err := config.Load()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"convert": 40, "list": 5, "admin": 0}, cfg.Server.RequestTimeoutSecsByProfile)
+	})
+
+	t.Run("Values are parsed into their types", func(t *testing.T) {
+		// Arrange
+		t.Setenv("RATE_SYNC_MAX_PER_RUN", "0")
+		t.Setenv("LOG_LEVEL", "debug")
+		t.Setenv("TREASURY_BREAKER_ENABLED", "false")
+		t.Setenv("CONVERSION_MARGIN_BPS_BY_API_KEY", "partner:25, internal:0")
+		t.Setenv("BANK_ACCOUNTS_BY_CONNECTION", "chase:acc-1|acc-2")
+		t.Setenv("RATE_PROVIDERS", "Treasury, ecb,fixed")
+		t.Setenv("FIXED_RATES", "EUR:0.92,BRL:5.1")
+
+		// Act
+		cfg, err := config.Load()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0, cfg.RateSync.MaxPerRun, "zero is a valid value, not a fallback to the default")
+		assert.Equal(t, "DEBUG", cfg.Logger.Level)
+		assert.False(t, cfg.Treasury.BreakerEnabled)
+		assert.Equal(t, map[string]int{"partner": 25, "internal": 0}, cfg.Conversion.MarginBpsByAPIKey)
+		assert.Equal(t, []string{"acc-1", "acc-2"}, cfg.Bank.AccountsByConnection["chase"])
+		assert.Equal(t, []string{"treasury", "ecb", "fixed"}, cfg.Providers.Order)
+		assert.Equal(t, map[string]float64{"EUR": 0.92, "BRL": 5.1}, cfg.Providers.FixedRates)
+	})
+
+	t.Run("Every invalid key is reported at once", func(t *testing.T) {
+		// Arrange
+		t.Setenv("DB_MAX_OPEN_CONNS", "many")
+		t.Setenv("LOG_LEVEL", "verbose")
+		t.Setenv("TREASURY_BREAKER_ENABLED", "maybe")
+		t.Setenv("DIGEST_HOUR_UTC", "25")
+		t.Setenv("TREASURY_BASE_URL", "fiscaldata.treasury.gov")
+		t.Setenv("RATE_LIMIT_PROFILES", "default")
+		t.Setenv("RATE_PROVIDERS", "treasury,oanda")
+		t.Setenv("FIXED_RATES", "EUR:-1")
+
+		// Act
+		_, err := config.Load()
+
+		// Assert
+		var configErr *config.Error
+		require.True(t, errors.As(err, &configErr))
+		assert.ElementsMatch(t, []string{"DB_MAX_OPEN_CONNS", "LOG_LEVEL", "TREASURY_BREAKER_ENABLED", "DIGEST_HOUR_UTC",
+			"TREASURY_BASE_URL", "RATE_LIMIT_PROFILES", "RATE_PROVIDERS", "FIXED_RATES"}, configErr.Keys())
+		assert.Contains(t, err.Error(), `RATE_PROVIDERS: "oanda" is not one of treasury, ecb, fixed`)
+		assert.Contains(t, err.Error(), `DB_MAX_OPEN_CONNS: "many" is not an integer`)
+		assert.Contains(t, err.Error(), "DIGEST_HOUR_UTC: 25 must be between 0 and 23")
+	})
+
+	t.Run("Settings that depend on each other are checked", func(t *testing.T) {
+		// Arrange
+		t.Setenv("DB_DRIVER", "postgres")
+		t.Setenv("JWT_ALGORITHM", "hs256")
+		t.Setenv("SENTRY_DSN", "https://sentry.example.com/42")
+		t.Setenv("RATE_PROVIDERS", "treasury,fixed")
+		t.Setenv("CONVERSION_STALE_MAX_MONTHS", "6")
+
+		// Act
+		_, err := config.Load()
+
+		// Assert
+		var configErr *config.Error
+		require.True(t, errors.As(err, &configErr))
+		assert.ElementsMatch(t, []string{"DB_DSN", "JWT_SECRET", "SENTRY_DSN", "FIXED_RATES", "CONVERSION_STALE_MAX_MONTHS"}, configErr.Keys())
+		assert.Contains(t, err.Error(), "missing public key")
+	})
+}
+
+func TestLoad_File(t *testing.T) {
+	t.Run("File values apply and environment variables override them", func(t *testing.T) {
+		// Arrange
+		writeConfigFile(t, `
+PORT: ":9090"
+DB_DRIVER: memory
+DB_MAX_OPEN_CONNS: 40
+DIGEST_RECIPIENTS:
+  - ops@example.com
+  - finance@example.com
+RATE_LIMIT_PROFILES:
+  default: 100/min
+  admin: 10/min
+BANK_ACCOUNTS_BY_CONNECTION:
+  chase: [acc-1, acc-2]
+`)
+		t.Setenv("DB_MAX_OPEN_CONNS", "50")
+
+		// Act
+		cfg, err := config.Load()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, ":9090", cfg.Server.Port)
+		assert.Equal(t, "memory", cfg.Database.Driver)
+		assert.Equal(t, 50, cfg.Database.MaxOpenConns)
+		assert.Equal(t, []string{"ops@example.com", "finance@example.com"}, cfg.Digest.Recipients)
+		assert.Equal(t, map[string]string{"default": "100/min", "admin": "10/min"}, cfg.RateLimit.Profiles)
+		assert.Equal(t, []string{"acc-1", "acc-2"}, cfg.Bank.AccountsByConnection["chase"])
+	})
+
+	t.Run("Unknown and invalid file keys are reported", func(t *testing.T) {
+		// Arrange
+		writeConfigFile(t, "DB_MAX_OPEN_CON: 40\nQUOTE_TTL_MINUTES: soon\n")
+
+		// Act
+		_, err := config.Load()
+
+		// Assert
+		var configErr *config.Error
+		require.True(t, errors.As(err, &configErr))
+		assert.ElementsMatch(t, []string{"DB_MAX_OPEN_CON", "QUOTE_TTL_MINUTES"}, configErr.Keys())
+	})
+
+	t.Run("Unreadable files fail", func(t *testing.T) {
+		t.Setenv(config.FileEnv, filepath.Join(t.TempDir(), "missing.yaml"))
+
+		_, err := config.Load()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Malformed files fail", func(t *testing.T) {
+		writeConfigFile(t, "PORT: [unterminated\n")
+
+		_, err := config.Load()
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,62 @@
+package email_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestRenderer(t *testing.T) {
+	report := &dto.DigestReport{
+		Period:           "weekly",
+		From:             time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC),
+		To:               time.Date(2024, 5, 13, 8, 0, 0, 0, time.UTC),
+		NewTransactions:  12,
+		TotalSpend:       980.5,
+		Conversions:      4,
+		TreasuryFailures: 1,
+	}
+
+	t.Run("Built-in template", func(t *testing.T) {
+		renderer, err := email.NewDigestRenderer("")
+		require.NoError(t, err)
+
+		subject, body, err := renderer.Render(report)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Purchase Transaction API weekly digest: 2024-05-06 to 2024-05-13", subject)
+		assert.Contains(t, body, "New transactions:        12")
+		assert.Contains(t, body, "Total spend (USD):       980.50")
+		assert.Contains(t, body, "Failed Treasury calls:   1")
+	})
+
+	t.Run("Custom template", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "digest.tmpl")
+		content := `{{define "subject"}}Spend {{.Period}}{{end}}{{define "body"}}{{.NewTransactions}} new{{end}}`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		renderer, err := email.NewDigestRenderer(path)
+		require.NoError(t, err)
+
+		subject, body, err := renderer.Render(report)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Spend weekly", subject)
+		assert.Equal(t, "12 new", body)
+	})
+
+	t.Run("Template missing a section", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "digest.tmpl")
+		require.NoError(t, os.WriteFile(path, []byte(`{{define "subject"}}x{{end}}`), 0o600))
+
+		_, err := email.NewDigestRenderer(path)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,103 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudgetPeriodBounds(t *testing.T) {
+	// A Wednesday afternoon, east of UTC
+	date := time.Date(2024, 5, 15, 23, 30, 0, 0, time.FixedZone("UTC-3", -3*3600))
+
+	testCases := []struct {
+		name          string
+		period        entities.BudgetPeriod
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{"Weekly starts on Monday", entities.BudgetWeekly, time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)},
+		{"Monthly", entities.BudgetMonthly, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"Yearly", entities.BudgetYearly, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			budget := entities.Budget{Period: tc.period}
+
+			start, end := budget.PeriodBounds(date)
+
+			assert.Equal(t, tc.expectedStart, start)
+			assert.Equal(t, tc.expectedEnd, end)
+		})
+	}
+
+	t.Run("Sunday belongs to the week that started on Monday", func(t *testing.T) {
+		budget := entities.Budget{Period: entities.BudgetWeekly}
+
+		start, _ := budget.PeriodBounds(time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), start)
+	})
+}
+
+func TestBudgetCrossedThresholds(t *testing.T) {
+	budget := entities.Budget{Limit: entities.NewMoney(100), Thresholds: []int{50, 80, 100}}
+
+	t.Run("Thresholds reached by the new spend", func(t *testing.T) {
+		assert.Equal(t, []int{50, 80}, budget.CrossedThresholds(entities.NewMoney(40), entities.NewMoney(80)))
+	})
+
+	t.Run("Thresholds already passed are not repeated", func(t *testing.T) {
+		assert.Empty(t, budget.CrossedThresholds(entities.NewMoney(85), entities.NewMoney(95)))
+	})
+
+	t.Run("Reaching the limit exactly crosses it", func(t *testing.T) {
+		assert.Equal(t, []int{100}, budget.CrossedThresholds(entities.NewMoney(99.99), entities.NewMoney(100)))
+	})
+}
+
+func TestBudgetValidate(t *testing.T) {
+	valid := func() entities.Budget {
+		return entities.Budget{
+			ID:         uuid.New(),
+			Category:   "travel",
+			Period:     entities.BudgetMonthly,
+			Limit:      entities.NewMoney(500),
+			Currency:   entities.USD,
+			Thresholds: []int{80, 100},
+		}
+	}
+
+	t.Run("Valid budget", func(t *testing.T) {
+		budget := valid()
+		assert.NoError(t, budget.Validate())
+	})
+
+	t.Run("Invalid budgets", func(t *testing.T) {
+		testCases := map[string]func(*entities.Budget){
+			"missing category":      func(b *entities.Budget) { b.Category = "" },
+			"unknown period":        func(b *entities.Budget) { b.Period = "daily" },
+			"zero limit":            func(b *entities.Budget) { b.Limit = 0 },
+			"unknown currency":      func(b *entities.Budget) { b.Currency = "XYZ" },
+			"descending thresholds": func(b *entities.Budget) { b.Thresholds = []int{100, 80} },
+			"threshold out of range": func(b *entities.Budget) {
+				b.Thresholds = []int{0}
+			},
+		}
+
+		for name, mutate := range testCases {
+			budget := valid()
+			mutate(&budget)
+			assert.Error(t, budget.Validate(), name)
+		}
+	})
+
+	t.Run("Thresholds are normalized with a default", func(t *testing.T) {
+		assert.Equal(t, []int{80, 100}, entities.NormalizeThresholds(nil))
+		assert.Equal(t, []int{50, 90}, entities.NormalizeThresholds([]int{90, 50, 90}))
+	})
+}
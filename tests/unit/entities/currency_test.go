@@ -4,9 +4,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
 	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCurrencyCodeValidation(t *testing.T) {
@@ -78,12 +81,53 @@ func TestExchangeRateDateRange(t *testing.T) {
 		t.Run(tc.Name, func(t *testing.T) {
 			exchangeRate := fixtures.ExchangeRateWithDate(tc.EffectiveDate)
 
-			result := exchangeRate.IsWithinDateRange(tc.TransactionDate)
+			result := exchangeRate.IsWithinDateRange(tc.TransactionDate, entities.RateWindow{})
 			assert.Equal(t, tc.ShouldBeValid, result)
 		})
 	}
 }
 
+func TestRateWindow(t *testing.T) {
+	t.Run("Zero value is the 6-month default", func(t *testing.T) {
+		var window entities.RateWindow
+
+		assert.Equal(t, entities.DefaultRateWindowMonths, window.Months())
+		assert.Equal(t, "6 months", window.String())
+	})
+
+	t.Run("Rejects windows outside 1 to 24 months", func(t *testing.T) {
+		for _, months := range []int{-1, 0, 25} {
+			_, err := entities.NewRateWindow(months)
+			assert.Error(t, err, "months %d", months)
+		}
+	})
+
+	t.Run("A longer window accepts older rates", func(t *testing.T) {
+		window, err := entities.NewRateWindow(12)
+		require.NoError(t, err)
+		transactionDate := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+		exchangeRate := fixtures.ExchangeRateWithDate(time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC), window.Start(transactionDate))
+		assert.Equal(t, time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC), window.End(exchangeRate.EffectiveDate))
+		assert.True(t, exchangeRate.IsWithinDateRange(transactionDate, window))
+		assert.False(t, exchangeRate.IsWithinDateRange(transactionDate, entities.RateWindow{}))
+	})
+
+	t.Run("A shorter window rejects rates the default accepts", func(t *testing.T) {
+		window, err := entities.NewRateWindow(1)
+		require.NoError(t, err)
+		transaction := fixtures.TransactionWithDate(time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC))
+		exchangeRate := fixtures.ExchangeRateWithDate(time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC))
+
+		convertedTx, err := entities.NewConvertedTransaction(transaction, entities.BRL, &exchangeRate, window, entities.RoundHalfUp)
+
+		assert.ErrorIs(t, err, errs.ErrRateUnavailable)
+		assert.Nil(t, convertedTx)
+		assert.Contains(t, err.Error(), "not within 1 month")
+	})
+}
+
 func TestExchangeRateConvertAmount(t *testing.T) {
 	conversionCases := fixtures.ConversionTestCases()
 
@@ -99,11 +143,21 @@ func TestExchangeRateConvertAmount(t *testing.T) {
 	}
 }
 
+func TestExchangeRateConvertToZeroDecimalCurrency(t *testing.T) {
+	exchangeRate, _ := entities.NewExchangeRate(entities.USD, entities.JPY, 151.234, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), clock.System())
+
+	converted, err := exchangeRate.Convert(entities.NewMoney(19.99), entities.RoundHalfUp)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3023.0, converted.Dollars(), "19.99 USD is 3023.16766 JPY, rounded to whole yen")
+	assert.Equal(t, converted, exchangeRate.ConvertAmount(entities.NewMoney(19.99)))
+}
+
 func TestNewExchangeRate(t *testing.T) {
 	t.Run("Valid exchange rate creation", func(t *testing.T) {
 		effectiveDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
 
-		exchangeRate, err := entities.NewExchangeRate(entities.USD, entities.BRL, 5.20, effectiveDate)
+		exchangeRate, err := entities.NewExchangeRate(entities.USD, entities.BRL, 5.20, effectiveDate, clock.System())
 
 		assert.NoError(t, err)
 		assert.NotNil(t, exchangeRate)
@@ -115,9 +169,18 @@ func TestNewExchangeRate(t *testing.T) {
 		assert.False(t, exchangeRate.RecordDate.IsZero())
 	})
 
+	t.Run("The record date comes from the clock", func(t *testing.T) {
+		clk := clock.NewFake(time.Date(2024, 1, 16, 9, 30, 0, 0, time.UTC))
+
+		exchangeRate, err := entities.NewExchangeRate(entities.USD, entities.BRL, 5.20, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), clk)
+
+		require.NoError(t, err)
+		assert.Equal(t, clk.Now(), exchangeRate.RecordDate)
+	})
+
 	t.Run("Invalid exchange rate creation", func(t *testing.T) {
 		// Try to create with invalid rate
-		exchangeRate, err := entities.NewExchangeRate(entities.USD, entities.EUR, -1.0, time.Now())
+		exchangeRate, err := entities.NewExchangeRate(entities.USD, entities.EUR, -1.0, time.Now(), clock.System())
 
 		assert.Error(t, err)
 		assert.Nil(t, exchangeRate)
@@ -125,6 +188,114 @@ func TestNewExchangeRate(t *testing.T) {
 	})
 }
 
+func TestInterpolateExchangeRate(t *testing.T) {
+	before, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.90, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), clock.System())
+	after, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 1.00, time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), clock.System())
+
+	t.Run("Linear interpolation between quotes", func(t *testing.T) {
+		date := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+		rate, err := entities.InterpolateExchangeRate(before, after, date)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.94, rate.Rate, 0.000001)
+		assert.Equal(t, date, rate.EffectiveDate)
+		assert.Equal(t, entities.EUR, rate.ToCurrency)
+	})
+
+	t.Run("Date outside the quotes", func(t *testing.T) {
+		_, err := entities.InterpolateExchangeRate(before, after, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not between")
+	})
+
+	t.Run("Missing quote", func(t *testing.T) {
+		_, err := entities.InterpolateExchangeRate(before, nil, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Mismatched currency pairs", func(t *testing.T) {
+		other, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 5.0, after.EffectiveDate, clock.System())
+
+		_, err := entities.InterpolateExchangeRate(before, other, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCrossRate(t *testing.T) {
+	usdEUR, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.80, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), clock.System())
+	usdBRL, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 5.00, time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), clock.System())
+
+	t.Run("Crosses both legs through USD", func(t *testing.T) {
+		rate, err := entities.CrossRate(entities.EUR, entities.BRL, usdEUR, usdBRL)
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.EUR, rate.FromCurrency)
+		assert.Equal(t, entities.BRL, rate.ToCurrency)
+		assert.InDelta(t, 6.25, rate.Rate, 0.000001)
+		assert.Equal(t, usdEUR.EffectiveDate, rate.EffectiveDate, "the older leg dates the cross rate")
+	})
+
+	t.Run("USD target inverts the source leg", func(t *testing.T) {
+		rate, err := entities.CrossRate(entities.EUR, entities.USD, usdEUR, nil)
+
+		require.NoError(t, err)
+		assert.InDelta(t, 1.25, rate.Rate, 0.000001)
+		assert.Equal(t, usdEUR.EffectiveDate, rate.EffectiveDate)
+	})
+
+	t.Run("Missing leg", func(t *testing.T) {
+		_, err := entities.CrossRate(entities.EUR, entities.BRL, usdEUR, nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "requires the USD/BRL rate")
+	})
+
+	t.Run("Leg for the wrong currency", func(t *testing.T) {
+		_, err := entities.CrossRate(entities.EUR, entities.BRL, usdBRL, usdBRL)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must be USD/EUR")
+	})
+}
+
+func TestNewRateQuote(t *testing.T) {
+	exchangeRate, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.92, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), clock.System())
+
+	t.Run("Quote locks the rate until expiry", func(t *testing.T) {
+		now := time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)
+		fakeClock := clock.NewFake(now)
+
+		quote, err := entities.NewRateQuote(exchangeRate, exchangeRate.EffectiveDate, 15*time.Minute, fakeClock)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.92, quote.ExchangeRate().Rate)
+		assert.Equal(t, exchangeRate.EffectiveDate, quote.ExchangeRate().EffectiveDate)
+		assert.Equal(t, now, quote.CreatedAt)
+		assert.Equal(t, now.Add(15*time.Minute), quote.ExpiresAt)
+
+		fakeClock.Advance(14 * time.Minute)
+		assert.False(t, quote.IsExpired(fakeClock.Now()))
+		fakeClock.Advance(time.Minute)
+		assert.True(t, quote.IsExpired(fakeClock.Now()))
+	})
+
+	t.Run("Non-positive ttl", func(t *testing.T) {
+		_, err := entities.NewRateQuote(exchangeRate, exchangeRate.EffectiveDate, 0, clock.System())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestApplyMargin(t *testing.T) {
+	assert.Equal(t, 5.20, entities.ApplyMargin(5.20, 0))
+	assert.Equal(t, 5.148, entities.ApplyMargin(5.20, 100))
+	assert.Equal(t, 0.9, entities.ApplyMargin(1.0, 1000))
+}
+
 func TestNewConvertedTransaction(t *testing.T) {
 	t.Run("Valid converted transaction", func(t *testing.T) {
 		// Setup transaction and exchange rate with compatible dates
@@ -133,7 +304,7 @@ func TestNewConvertedTransaction(t *testing.T) {
 		exchangeRate.FromCurrency = entities.USD
 		exchangeRate.ToCurrency = entities.BRL
 
-		convertedTx, err := entities.NewConvertedTransaction(transaction, entities.BRL, &exchangeRate)
+		convertedTx, err := entities.NewConvertedTransaction(transaction, entities.BRL, &exchangeRate, entities.RateWindow{}, entities.RoundHalfUp)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, convertedTx)
@@ -152,7 +323,7 @@ func TestNewConvertedTransaction(t *testing.T) {
 		transaction := fixtures.TransactionWithDate(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC))
 		exchangeRate := fixtures.ExchangeRateWithDate(time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC))
 
-		convertedTx, err := entities.NewConvertedTransaction(transaction, entities.BRL, &exchangeRate)
+		convertedTx, err := entities.NewConvertedTransaction(transaction, entities.BRL, &exchangeRate, entities.RateWindow{}, entities.RoundHalfUp)
 
 		assert.Error(t, err)
 		assert.Nil(t, convertedTx)
@@ -163,19 +334,31 @@ func TestNewConvertedTransaction(t *testing.T) {
 		transaction := fixtures.ValidTransaction()
 		exchangeRate := fixtures.ExchangeRateWithCurrencies(entities.EUR, entities.BRL)
 
-		convertedTx, err := entities.NewConvertedTransaction(transaction, entities.BRL, &exchangeRate)
+		convertedTx, err := entities.NewConvertedTransaction(transaction, entities.BRL, &exchangeRate, entities.RateWindow{}, entities.RoundHalfUp)
 
 		assert.Error(t, err)
 		assert.Nil(t, convertedTx)
 		assert.Contains(t, err.Error(), "conversion must be from USD")
 	})
 
+	t.Run("Non-USD transaction converts from its own currency", func(t *testing.T) {
+		transaction := fixtures.TransactionWithDate(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC))
+		transaction.Currency = entities.EUR
+		exchangeRate := fixtures.ExchangeRateWithCurrencies(entities.EUR, entities.BRL)
+		exchangeRate.EffectiveDate = time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+		convertedTx, err := entities.NewConvertedTransaction(transaction, entities.BRL, &exchangeRate, entities.RateWindow{}, entities.RoundHalfUp)
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.EUR, convertedTx.Transaction.SourceCurrency())
+	})
+
 	t.Run("Exchange rate currency mismatch", func(t *testing.T) {
 		transaction := fixtures.ValidTransaction()
 		exchangeRate := fixtures.ExchangeRateWithCurrencies(entities.USD, entities.EUR)
 
 		// Try to convert to BRL but exchange rate is for EUR
-		convertedTx, err := entities.NewConvertedTransaction(transaction, entities.BRL, &exchangeRate)
+		convertedTx, err := entities.NewConvertedTransaction(transaction, entities.BRL, &exchangeRate, entities.RateWindow{}, entities.RoundHalfUp)
 
 		assert.Error(t, err)
 		assert.Nil(t, convertedTx)
@@ -0,0 +1,179 @@
+package entities_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMoneyFromString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   string
+		expected entities.Money
+	}{
+		{"Whole dollars", "10", 1000},
+		{"With cents", "19.99", 1999},
+		{"Leading point", ".5", 50},
+		{"Trailing point", "7.", 700},
+		{"Half cent rounds up", "0.125", 13},
+		{"Negative half cent rounds away from zero", "-0.125", -13},
+		{"Explicit sign", "+1.10", 110},
+		{"Surrounding spaces", " 42.00 ", 4200},
+		{"Beyond float precision", "90071992547409.93", 9007199254740993},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			money, err := entities.NewMoneyFromString(tc.amount)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, money)
+		})
+	}
+
+	t.Run("Rejects anything but plain decimals", func(t *testing.T) {
+		for _, amount := range []string{"", ".", "-", "1e3", "1/3", "1,000.00", "$5", "--1", "NaN"} {
+			_, err := entities.NewMoneyFromString(amount)
+			assert.Error(t, err, "amount %q", amount)
+		}
+	})
+
+	t.Run("Rejects amounts Money cannot hold", func(t *testing.T) {
+		_, err := entities.NewMoneyFromString("92233720368547758.08")
+
+		assert.ErrorIs(t, err, entities.ErrMoneyOverflow)
+	})
+}
+
+func TestNewMoneyFromFloat(t *testing.T) {
+	t.Run("Reads the shortest decimal form", func(t *testing.T) {
+		money, err := entities.NewMoneyFromFloat(1.005)
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.FromCents(101), money)
+	})
+
+	t.Run("Rejects non-finite and out-of-range amounts", func(t *testing.T) {
+		for _, dollars := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1e18} {
+			_, err := entities.NewMoneyFromFloat(dollars)
+			assert.Error(t, err, "dollars %v", dollars)
+		}
+	})
+
+	t.Run("NewMoney saturates instead", func(t *testing.T) {
+		assert.Equal(t, entities.Money(math.MaxInt64), entities.NewMoney(1e18))
+		assert.Equal(t, entities.Money(math.MinInt64), entities.NewMoney(-1e18))
+		assert.Equal(t, entities.Money(0), entities.NewMoney(math.NaN()))
+	})
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	t.Run("Add and Sub", func(t *testing.T) {
+		sum, err := entities.FromCents(1050).Add(entities.FromCents(-75))
+		require.NoError(t, err)
+		assert.Equal(t, entities.FromCents(975), sum)
+
+		difference, err := entities.FromCents(100).Sub(entities.FromCents(250))
+		require.NoError(t, err)
+		assert.Equal(t, entities.FromCents(-150), difference)
+	})
+
+	t.Run("Overflow is reported", func(t *testing.T) {
+		_, err := entities.FromCents(math.MaxInt64).Add(1)
+		assert.ErrorIs(t, err, entities.ErrMoneyOverflow)
+
+		_, err = entities.FromCents(math.MinInt64).Add(-1)
+		assert.ErrorIs(t, err, entities.ErrMoneyOverflow)
+
+		_, err = entities.FromCents(math.MinInt64).Sub(1)
+		assert.ErrorIs(t, err, entities.ErrMoneyOverflow)
+
+		_, err = entities.FromCents(0).Sub(math.MinInt64)
+		assert.ErrorIs(t, err, entities.ErrMoneyOverflow)
+
+		_, err = entities.FromCents(math.MaxInt64).MulRate(2, entities.RoundHalfUp)
+		assert.ErrorIs(t, err, entities.ErrMoneyOverflow)
+	})
+
+	t.Run("MulRate rounds ties by mode", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			cents    int64
+			rate     float64
+			mode     entities.RoundingMode
+			expected entities.Money
+		}{
+			{"Half up", 25, 0.5, entities.RoundHalfUp, 13},
+			{"Half up on negatives", -25, 0.5, entities.RoundHalfUp, -13},
+			{"Zero value is half up", 25, 0.5, "", 13},
+			{"Half even to an even cent", 25, 0.5, entities.RoundHalfEven, 12},
+			{"Half even away from an odd cent", 35, 0.5, entities.RoundHalfEven, 18},
+			{"Half even on negatives", -25, 0.5, entities.RoundHalfEven, -12},
+			{"Down", 19, 0.5, entities.RoundDown, 9},
+			{"Down on negatives", -19, 0.5, entities.RoundDown, -9},
+			{"Nearest cent regardless of mode", 1000, 5.2049, entities.RoundHalfEven, 5205},
+			{"Decimal rate, not its binary approximation", 1000, 1.0005, entities.RoundHalfUp, 1001},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				result, err := entities.FromCents(tc.cents).MulRate(tc.rate, tc.mode)
+
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			})
+		}
+	})
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	for name, expected := range map[string]entities.RoundingMode{
+		"":          entities.RoundHalfUp,
+		"half_up":   entities.RoundHalfUp,
+		"HALF_EVEN": entities.RoundHalfEven,
+		" down ":    entities.RoundDown,
+	} {
+		mode, err := entities.ParseRoundingMode(name)
+
+		require.NoError(t, err, "name %q", name)
+		assert.Equal(t, expected, mode, "name %q", name)
+	}
+
+	_, err := entities.ParseRoundingMode("ceiling")
+	assert.ErrorContains(t, err, "unknown rounding mode")
+}
+
+func TestMoneyMinorUnits(t *testing.T) {
+	t.Run("Currencies round to their minor unit", func(t *testing.T) {
+		assert.Equal(t, 2, entities.USD.MinorUnits())
+		assert.Equal(t, 0, entities.JPY.MinorUnits())
+		assert.Equal(t, 0, entities.KRW.MinorUnits())
+		assert.Equal(t, 2, entities.CurrencyCode("XYZ").MinorUnits(), "unknown currencies use cents")
+	})
+
+	t.Run("MulRateIn rounds once, to whole yen", func(t *testing.T) {
+		// 100.00 USD at 151.234 is 15123.40 JPY
+		yen, err := entities.FromCents(10000).MulRateIn(151.234, entities.JPY, entities.RoundHalfUp)
+		require.NoError(t, err)
+		assert.Equal(t, entities.FromCents(1512300), yen)
+
+		// 0.83 at 15.0602 is 12.499966: rounding to cents first would give 12.50 and then 13
+		yen, err = entities.FromCents(83).MulRateIn(15.0602, entities.JPY, entities.RoundHalfUp)
+		require.NoError(t, err)
+		assert.Equal(t, entities.FromCents(1200), yen)
+	})
+
+	t.Run("Round and Format", func(t *testing.T) {
+		rounded, err := entities.FromCents(123450).Round(entities.JPY, entities.RoundHalfEven)
+		require.NoError(t, err)
+		assert.Equal(t, entities.FromCents(123400), rounded)
+
+		assert.Equal(t, "1234.50", entities.FromCents(123450).Format(entities.USD))
+		assert.Equal(t, "1235", entities.FromCents(123450).Format(entities.JPY))
+		assert.Equal(t, "-7", entities.FromCents(-650).Format(entities.KRW))
+	})
+}
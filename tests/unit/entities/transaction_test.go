@@ -3,7 +3,9 @@ package entities_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
 	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
 	"github.com/stretchr/testify/assert"
@@ -95,6 +97,14 @@ func TestTransactionCreationWithFixtures(t *testing.T) {
 	})
 }
 
+func TestTransactionSourceCurrency(t *testing.T) {
+	tx := fixtures.ValidTransaction()
+	assert.Equal(t, entities.USD, tx.SourceCurrency(), "transactions without a currency are USD")
+
+	tx.Currency = entities.EUR
+	assert.Equal(t, entities.EUR, tx.SourceCurrency())
+}
+
 func TestMinimalTransaction(t *testing.T) {
 	tx := fixtures.MinimalTransaction()
 
@@ -133,3 +143,40 @@ func TestMoneyRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionCursor(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 123456789, time.UTC)
+	cursor := entities.TransactionCursor{CreatedAt: createdAt, ID: uuid.MustParse("6f1c2b9e-8a44-4a0e-9f0e-3c1b5e7d2a10")}
+
+	t.Run("Encoded cursor parses back", func(t *testing.T) {
+		parsed, err := entities.ParseTransactionCursor(cursor.Encode())
+
+		assert.NoError(t, err)
+		assert.True(t, createdAt.Equal(parsed.CreatedAt))
+		assert.Equal(t, cursor.ID, parsed.ID)
+	})
+
+	t.Run("Malformed tokens are rejected", func(t *testing.T) {
+		for _, token := range []string{"", "not base64!", "bm8tc2VwYXJhdG9y", "YWJjOjZmMWMyYjllLThhNDQtNGEwZS05ZjBlLTNjMWI1ZTdkMmExMA"} {
+			_, err := entities.ParseTransactionCursor(token)
+			assert.EqualError(t, err, "invalid cursor", token)
+		}
+	})
+
+	t.Run("Precedes older transactions and equal times with a lower ID", func(t *testing.T) {
+		older := fixtures.ValidTransaction()
+		older.CreatedAt = createdAt.Add(-time.Second)
+		newer := fixtures.ValidTransaction()
+		newer.CreatedAt = createdAt.Add(time.Second)
+		tiedLower := fixtures.TransactionWithID(uuid.MustParse("00000000-0000-4000-8000-000000000000"))
+		tiedLower.CreatedAt = createdAt
+		tiedHigher := fixtures.TransactionWithID(uuid.MustParse("ffffffff-ffff-4fff-bfff-ffffffffffff"))
+		tiedHigher.CreatedAt = createdAt
+
+		assert.True(t, cursor.Precedes(older))
+		assert.False(t, cursor.Precedes(newer))
+		assert.True(t, cursor.Precedes(tiedLower))
+		assert.False(t, cursor.Precedes(tiedHigher))
+		assert.Equal(t, cursor, entities.CursorOf(entities.Transaction{ID: cursor.ID, CreatedAt: createdAt}))
+	})
+}
@@ -0,0 +1,188 @@
+package errortracking_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/errortracking"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sentryServer records the store requests it receives
+type sentryServer struct {
+	mu      sync.Mutex
+	paths   []string
+	auth    []string
+	events  []map[string]interface{}
+	baseURL string
+}
+
+func newSentryServer(t *testing.T) *sentryServer {
+	capture := &sentryServer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var event map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &event))
+
+		capture.mu.Lock()
+		capture.paths = append(capture.paths, r.URL.Path)
+		capture.auth = append(capture.auth, r.Header.Get("X-Sentry-Auth"))
+		capture.events = append(capture.events, event)
+		capture.mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	capture.baseURL = server.URL
+	return capture
+}
+
+// dsn returns a DSN pointing at the capture server
+func (s *sentryServer) dsn() string {
+	return strings.Replace(s.baseURL, "://", "://public-key@", 1) + "/42"
+}
+
+func TestReporter(t *testing.T) {
+	t.Run("Without a DSN reporting is disabled", func(t *testing.T) {
+		// Act
+		reporter, err := errortracking.NewReporter(errortracking.Config{}, "test/1.0")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, reporter)
+		reporter.CaptureError(context.Background(), errors.New("ignored"), nil)
+		assert.NoError(t, reporter.Close())
+	})
+
+	t.Run("Request errors carry the release, request and tags", func(t *testing.T) {
+		// Arrange
+		sentry := newSentryServer(t)
+		reporter, err := errortracking.NewReporter(errortracking.Config{
+			DSN:         sentry.dsn(),
+			Environment: "production",
+			Release:     "1.2.3",
+			ServerName:  "api-1",
+		}, "test/1.0")
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions?dry_run=true", nil)
+		req.Header.Set("X-API-Key", "secret")
+		req.Header.Set("User-Agent", "client/2.0")
+		req = req.WithContext(logger.ContextWithRequestID(req.Context(), "req-1"))
+		cause := errors.New("disk full")
+
+		// Act
+		reporter.CaptureRequestError(req, fmt.Errorf("failed to save transaction: %w", cause), map[string]string{"route": "POST /api/v1/transactions"})
+		require.NoError(t, reporter.Close())
+
+		// Assert
+		require.Len(t, sentry.events, 1)
+		assert.Equal(t, "/api/42/store/", sentry.paths[0])
+		assert.Contains(t, sentry.auth[0], "sentry_key=public-key")
+		assert.Contains(t, sentry.auth[0], "sentry_client=test/1.0")
+
+		event := sentry.events[0]
+		assert.Len(t, event["event_id"], 32)
+		assert.Equal(t, "error", event["level"])
+		assert.Equal(t, "go", event["platform"])
+		assert.Equal(t, "1.2.3", event["release"])
+		assert.Equal(t, "production", event["environment"])
+		assert.Equal(t, "api-1", event["server_name"])
+		assert.Equal(t, map[string]interface{}{"route": "POST /api/v1/transactions", "request_id": "req-1"}, event["tags"])
+
+		request := event["request"].(map[string]interface{})
+		assert.Equal(t, "POST", request["method"])
+		assert.Equal(t, "http://example.com/api/v1/transactions", request["url"])
+		assert.Equal(t, "dry_run=true", request["query_string"])
+		headers := request["headers"].(map[string]interface{})
+		assert.Equal(t, "client/2.0", headers["User-Agent"])
+		assert.NotContains(t, headers, "X-Api-Key", "credentials are never reported")
+
+		values := event["exception"].(map[string]interface{})["values"].([]interface{})
+		require.Len(t, values, 2)
+		assert.Equal(t, "disk full", values[0].(map[string]interface{})["value"], "the innermost error comes first")
+		outer := values[1].(map[string]interface{})
+		assert.Equal(t, "failed to save transaction: disk full", outer["value"])
+		frames := outer["stacktrace"].(map[string]interface{})["frames"].([]interface{})
+		caller := frames[len(frames)-1].(map[string]interface{})
+		assert.Equal(t, "reporter_test.go", caller["filename"], "the stack starts at the caller")
+		assert.Equal(t, true, caller["in_app"])
+	})
+
+	t.Run("Panics are reported as fatal", func(t *testing.T) {
+		// Arrange
+		sentry := newSentryServer(t)
+		reporter, err := errortracking.NewReporter(errortracking.Config{DSN: sentry.dsn()}, "test/1.0")
+		require.NoError(t, err)
+
+		// Act
+		func() {
+			defer func() {
+				reporter.CapturePanic(httptest.NewRequest(http.MethodGet, "/boom", nil), recover(), nil)
+			}()
+			panic("boom")
+		}()
+		require.NoError(t, reporter.Close())
+
+		// Assert
+		require.Len(t, sentry.events, 1)
+		assert.Equal(t, "fatal", sentry.events[0]["level"])
+		values := sentry.events[0]["exception"].(map[string]interface{})["values"].([]interface{})
+		assert.Equal(t, "boom", values[0].(map[string]interface{})["value"])
+	})
+
+	t.Run("Events captured after Close are dropped", func(t *testing.T) {
+		// Arrange
+		sentry := newSentryServer(t)
+		reporter, err := errortracking.NewReporter(errortracking.Config{DSN: sentry.dsn()}, "test/1.0")
+		require.NoError(t, err)
+		require.NoError(t, reporter.Close())
+
+		// Act
+		reporter.CaptureError(context.Background(), errors.New("late"), nil)
+
+		// Assert
+		assert.Empty(t, sentry.events)
+	})
+}
+
+func TestParseDSN(t *testing.T) {
+	t.Run("Valid DSN", func(t *testing.T) {
+		// Act
+		dsn, err := errortracking.ParseDSN("https://abc123@o1.ingest.sentry.io/prefix/42")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", dsn.PublicKey)
+		assert.Equal(t, "42", dsn.ProjectID)
+		assert.Equal(t, "https://o1.ingest.sentry.io/prefix/api/42/store/", dsn.StoreURL())
+	})
+
+	invalid := map[string]string{
+		"missing public key": "https://sentry.example.com/42",
+		"missing project ID": "https://abc@sentry.example.com/",
+		"scheme must be":     "ftp://abc@sentry.example.com/42",
+	}
+	for message, raw := range invalid {
+		t.Run("Rejects "+raw, func(t *testing.T) {
+			// Act
+			_, err := errortracking.ParseDSN(raw)
+
+			// Assert
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), message)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainErrors(t *testing.T) {
+	t.Run("Newf keeps the message and marks the kind", func(t *testing.T) {
+		// Act
+		err := errs.Newf(errs.ErrNotFound, "transaction with ID %s not found", "42")
+
+		// Assert
+		assert.Equal(t, "transaction with ID 42 not found", err.Error())
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+		assert.NotErrorIs(t, err, errs.ErrValidation)
+	})
+
+	t.Run("The kind survives further wrapping", func(t *testing.T) {
+		// Arrange
+		cause := errs.Newf(errs.ErrRateUnavailable, "no exchange rate found for EUR")
+
+		// Act
+		err := fmt.Errorf("failed to find exchange rate: %w", cause)
+
+		// Assert
+		assert.ErrorIs(t, err, errs.ErrRateUnavailable)
+		assert.Equal(t, "failed to find exchange rate: no exchange rate found for EUR", err.Error())
+	})
+
+	t.Run("Newf keeps wrapping the %w cause", func(t *testing.T) {
+		// Arrange
+		cause := errors.New("description is required")
+
+		// Act
+		err := errs.Newf(errs.ErrValidation, "validation failed: %w", cause)
+
+		// Assert
+		assert.ErrorIs(t, err, errs.ErrValidation)
+		assert.ErrorIs(t, err, cause)
+	})
+
+	t.Run("A message mentioning a kind does not carry it", func(t *testing.T) {
+		// Act
+		err := errors.New("upstream said: not found")
+
+		// Assert
+		assert.NotErrorIs(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("Wrap leaves nil alone", func(t *testing.T) {
+		assert.NoError(t, errs.Wrap(errs.ErrConflict, nil))
+	})
+}
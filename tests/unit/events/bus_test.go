@@ -0,0 +1,96 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contextKey string
+
+func TestBus(t *testing.T) {
+	// Setup
+	created := entities.TransactionCreatedEvent{
+		EventMeta:   entities.NewEventMeta(clock.System()),
+		Transaction: entities.Transaction{ID: uuid.New()},
+	}
+
+	t.Run("Events reach only the handlers subscribed to their name", func(t *testing.T) {
+		// Arrange
+		bus := events.NewBus()
+		var mu sync.Mutex
+		var received []string
+		record := func(name string) func(context.Context, entities.DomainEvent) error {
+			return func(ctx context.Context, event entities.DomainEvent) error {
+				mu.Lock()
+				defer mu.Unlock()
+				received = append(received, name+":"+event.EventName())
+				return nil
+			}
+		}
+		bus.Subscribe(entities.EventTransactionCreated, record("budgets"))
+		bus.Subscribe(entities.EventTransactionCreated, record("webhooks"))
+		bus.Subscribe(entities.EventTransactionConverted, record("audit"))
+
+		// Act
+		require.NoError(t, bus.Publish(context.Background(), created))
+		bus.Wait()
+
+		// Assert
+		assert.ElementsMatch(t, []string{"budgets:transaction.created", "webhooks:transaction.created"}, received)
+	})
+
+	t.Run("Handlers outlive the publishing context and keep its values", func(t *testing.T) {
+		// Arrange
+		bus := events.NewBus()
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), contextKey("request_id"), "req-1"))
+		release := make(chan struct{})
+		var handlerErr error
+		var value interface{}
+		bus.Subscribe(entities.EventTransactionCreated, func(ctx context.Context, event entities.DomainEvent) error {
+			<-release
+			handlerErr = ctx.Err()
+			value = ctx.Value(contextKey("request_id"))
+			return nil
+		})
+
+		// Act
+		require.NoError(t, bus.Publish(ctx, created))
+		cancel()
+		close(release)
+		bus.Wait()
+
+		// Assert
+		assert.NoError(t, handlerErr)
+		assert.Equal(t, "req-1", value)
+	})
+
+	t.Run("A failing handler does not stop the others", func(t *testing.T) {
+		// Arrange
+		bus := events.NewBus()
+		handled := false
+		bus.Subscribe(entities.EventTransactionCreated, func(ctx context.Context, event entities.DomainEvent) error {
+			return errors.New("receiver down")
+		})
+		bus.Subscribe(entities.EventTransactionCreated, func(ctx context.Context, event entities.DomainEvent) error {
+			handled = true
+			return nil
+		})
+
+		// Act
+		err := bus.Publish(context.Background(), created)
+		bus.Wait()
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, handled)
+	})
+}
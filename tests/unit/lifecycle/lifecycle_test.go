@@ -0,0 +1,134 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stopOrder records the order in which workers and hooks finish
+type stopOrder struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (o *stopOrder) add(step string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.steps = append(o.steps, step)
+}
+
+func (o *stopOrder) get() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]string(nil), o.steps...)
+}
+
+func TestManager(t *testing.T) {
+	t.Run("Workers stop before hooks, which run in reverse registration order", func(t *testing.T) {
+		// Arrange
+		manager := lifecycle.NewManager(time.Second)
+		order := &stopOrder{}
+
+		manager.OnShutdown("database", func(context.Context) error {
+			order.add("database")
+			return nil
+		})
+		manager.OnShutdown("event handlers", func(context.Context) error {
+			order.add("event handlers")
+			return nil
+		})
+		started := make(chan struct{})
+		manager.Go("relay", func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			order.add("relay")
+		})
+		<-started
+
+		// Act
+		err := manager.Shutdown()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"relay", "event handlers", "database"}, order.get())
+	})
+
+	t.Run("Hooks still run when a worker overruns the timeout and failures are reported", func(t *testing.T) {
+		// Arrange
+		manager := lifecycle.NewManager(20 * time.Millisecond)
+		release := make(chan struct{})
+		defer close(release)
+
+		closed := false
+		manager.OnShutdown("database", func(context.Context) error {
+			closed = true
+			return nil
+		})
+		manager.OnShutdown("broker", func(context.Context) error {
+			return errors.New("connection reset")
+		})
+		manager.Go("stuck", func(context.Context) { <-release })
+
+		// Act
+		err := manager.Shutdown()
+
+		// Assert
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "shutdown hook broker failed: connection reset")
+		assert.True(t, closed)
+	})
+
+	t.Run("Shutdown runs once and no worker starts afterwards", func(t *testing.T) {
+		manager := lifecycle.NewManager(time.Second)
+		calls := 0
+		manager.OnShutdown("cache", func(context.Context) error {
+			calls++
+			return nil
+		})
+
+		require.NoError(t, manager.Shutdown())
+		require.NoError(t, manager.Shutdown())
+		assert.Equal(t, 1, calls)
+
+		ran := make(chan struct{}, 1)
+		manager.Go("late", func(context.Context) { ran <- struct{}{} })
+		select {
+		case <-ran:
+			t.Fatal("worker started after shutdown")
+		case <-time.After(20 * time.Millisecond):
+		}
+	})
+}
+
+func TestWaitHook(t *testing.T) {
+	t.Run("Returns once the wait finishes", func(t *testing.T) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			time.Sleep(5 * time.Millisecond)
+			wg.Done()
+		}()
+
+		assert.NoError(t, lifecycle.WaitHook(wg.Wait)(context.Background()))
+	})
+
+	t.Run("Gives up when the context is done", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := lifecycle.WaitHook(func() { <-block })(ctx)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
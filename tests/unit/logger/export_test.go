@@ -0,0 +1,116 @@
+package logger_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureServer records request paths and decoded JSON bodies
+type captureServer struct {
+	mu       sync.Mutex
+	paths    []string
+	payloads []map[string]interface{}
+}
+
+func newCaptureServer(t *testing.T) (*captureServer, *httptest.Server) {
+	capture := &captureServer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &payload))
+
+		capture.mu.Lock()
+		capture.paths = append(capture.paths, r.URL.Path)
+		capture.payloads = append(capture.payloads, payload)
+		capture.mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return capture, server
+}
+
+func TestLogger_Export(t *testing.T) {
+	t.Run("Loki push", func(t *testing.T) {
+		// Arrange
+		capture, server := newCaptureServer(t)
+		log := logger.NewLogger(logger.LoggerConfig{
+			Level:  logger.LevelInfo,
+			Format: "json",
+			Export: logger.ExportConfig{Target: logger.ExportLoki, Endpoint: server.URL, ServiceName: "test-api"},
+		})
+
+		// Act
+		log.WithField("request_id", "req-1").Info("transaction stored", "amount", 10.5)
+		log.Debug("filtered out by level")
+		require.NoError(t, log.Close())
+
+		// Assert
+		require.Len(t, capture.payloads, 1)
+		assert.Equal(t, "/loki/api/v1/push", capture.paths[0])
+
+		streams := capture.payloads[0]["streams"].([]interface{})
+		require.Len(t, streams, 1)
+		stream := streams[0].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"service": "test-api", "level": "info"}, stream["stream"])
+
+		values := stream["values"].([]interface{})
+		require.Len(t, values, 1)
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(values[0].([]interface{})[1].(string)), &line))
+		assert.Equal(t, "transaction stored", line["msg"])
+		assert.Equal(t, "req-1", line["request_id"])
+		assert.Equal(t, 10.5, line["amount"])
+	})
+
+	t.Run("OTLP logs", func(t *testing.T) {
+		// Arrange
+		capture, server := newCaptureServer(t)
+		log := logger.NewLogger(logger.LoggerConfig{
+			Level:  logger.LevelInfo,
+			Format: "json",
+			Export: logger.ExportConfig{Target: logger.ExportOTLP, Endpoint: server.URL + "/", ServiceName: "test-api"},
+		})
+
+		// Act
+		log.Warn("treasury slow", "attempt", 2)
+		require.NoError(t, log.Close())
+
+		// Assert
+		require.Len(t, capture.payloads, 1)
+		assert.Equal(t, "/v1/logs", capture.paths[0])
+
+		resourceLogs := capture.payloads[0]["resourceLogs"].([]interface{})[0].(map[string]interface{})
+		resourceAttrs := resourceLogs["resource"].(map[string]interface{})["attributes"].([]interface{})
+		assert.Equal(t, "service.name", resourceAttrs[0].(map[string]interface{})["key"])
+
+		records := resourceLogs["scopeLogs"].([]interface{})[0].(map[string]interface{})["logRecords"].([]interface{})
+		require.Len(t, records, 1)
+		record := records[0].(map[string]interface{})
+		assert.Equal(t, "WARN", record["severityText"])
+		assert.Equal(t, float64(13), record["severityNumber"])
+		assert.Equal(t, "treasury slow", record["body"].(map[string]interface{})["stringValue"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"key": "attempt", "value": map[string]interface{}{"intValue": "2"}}}, record["attributes"])
+	})
+
+	t.Run("Misconfigured export keeps stdout logging", func(t *testing.T) {
+		log := logger.NewLogger(logger.LoggerConfig{
+			Level:  logger.LevelInfo,
+			Export: logger.ExportConfig{Target: logger.ExportLoki},
+		})
+
+		log.Info("still works")
+
+		assert.NoError(t, log.Close())
+	})
+}
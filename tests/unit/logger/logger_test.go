@@ -0,0 +1,127 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBufferLogger returns a JSON logger writing to buf
+func newBufferLogger(buf *bytes.Buffer) *logger.Logger {
+	return &logger.Logger{Logger: slog.New(slog.NewJSONHandler(buf, nil))}
+}
+
+// lastEntry decodes the last JSON log line in buf
+func lastEntry(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(lines[len(lines)-1], &entry))
+	return entry
+}
+
+func TestLogger_WithContext(t *testing.T) {
+	t.Run("Attaches metadata from context", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		ctx := logger.ContextWithRequestID(context.Background(), "req-123")
+		ctx = logger.ContextWithTraceID(ctx, "trace-abc")
+		ctx = logger.ContextWithTenantID(ctx, "tenant-1")
+
+		// Act
+		newBufferLogger(&buf).WithContext(ctx).Info("hello")
+
+		// Assert
+		entry := lastEntry(t, &buf)
+		assert.Equal(t, "req-123", entry["request_id"])
+		assert.Equal(t, "trace-abc", entry["trace_id"])
+		assert.Equal(t, "tenant-1", entry["tenant_id"])
+		assert.NotContains(t, entry, "context")
+	})
+
+	t.Run("Empty context adds no fields", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+
+		// Act
+		newBufferLogger(&buf).WithContext(context.Background()).Info("hello")
+
+		// Assert
+		entry := lastEntry(t, &buf)
+		assert.NotContains(t, entry, "request_id")
+		assert.NotContains(t, entry, "trace_id")
+		assert.NotContains(t, entry, "tenant_id")
+	})
+
+	t.Run("RequestIDFromContext", func(t *testing.T) {
+		ctx := logger.ContextWithRequestID(context.Background(), "req-456")
+
+		assert.Equal(t, "req-456", logger.RequestIDFromContext(ctx))
+		assert.Equal(t, "", logger.RequestIDFromContext(context.Background()))
+	})
+}
+
+func TestRequestIDMiddleware_StoresIDInRequestContext(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+
+	router := gin.New()
+	router.Use(middleware.RequestIDMiddleware(newBufferLogger(&buf)))
+
+	var contextID string
+	router.GET("/ping", func(c *gin.Context) {
+		contextID = logger.RequestIDFromContext(c.Request.Context())
+		log, _ := c.Get("logger")
+		log.(*logger.Logger).Info("handled")
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("X-Request-ID", "client-supplied")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, "client-supplied", contextID)
+	assert.Equal(t, "client-supplied", w.Header().Get("X-Request-ID"))
+	assert.Equal(t, "client-supplied", lastEntry(t, &buf)["request_id"])
+}
+
+func TestLogger_SetLevel(t *testing.T) {
+	t.Run("Changes the level of the logger and the loggers derived from it", func(t *testing.T) {
+		// Arrange
+		root := logger.NewLogger(logger.LoggerConfig{Level: "INFO", Format: "json"})
+		derived := root.WithField("component", "test")
+		require.False(t, derived.Enabled(context.Background(), slog.LevelDebug))
+
+		// Act
+		previous, err := root.SetLevel("debug")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "INFO", previous)
+		assert.Equal(t, "DEBUG", root.Level())
+		assert.True(t, derived.Enabled(context.Background(), slog.LevelDebug))
+	})
+
+	t.Run("Rejects unknown levels", func(t *testing.T) {
+		root := logger.NewLogger(logger.LoggerConfig{Level: "WARN", Format: "json"})
+
+		_, err := root.SetLevel("verbose")
+
+		assert.Error(t, err)
+		assert.Equal(t, "WARN", root.Level())
+	})
+}
@@ -0,0 +1,119 @@
+package memory_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchangeRateRepository_FindRateForConversion(t *testing.T) {
+	// Arrange
+	repo := memory.NewExchangeRateRepository()
+	transactionDate := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	for _, effective := range []time.Time{
+		time.Date(2023, 12, 14, 0, 0, 0, 0, time.UTC), // More than 6 months old
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), // After the purchase
+	} {
+		rate := fixtures.ExchangeRateWithDate(effective)
+		require.NoError(t, repo.Save(&rate))
+	}
+
+	t.Run("Most recent rate on or before the purchase date", func(t *testing.T) {
+		rate, err := repo.FindRateForConversion(entities.USD, entities.BRL, transactionDate, entities.RateWindow{})
+
+		require.NoError(t, err)
+		require.NotNil(t, rate)
+		assert.Equal(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), rate.EffectiveDate)
+	})
+
+	t.Run("Rates older than 6 months are ignored", func(t *testing.T) {
+		rate, err := repo.FindRateForConversion(entities.USD, entities.BRL, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), entities.RateWindow{})
+
+		require.NoError(t, err)
+		require.NotNil(t, rate)
+		assert.Equal(t, time.Date(2023, 12, 14, 0, 0, 0, 0, time.UTC), rate.EffectiveDate)
+
+		none, err := repo.FindRateForConversion(entities.USD, entities.BRL, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), entities.RateWindow{})
+		require.NoError(t, err)
+		assert.Nil(t, none)
+	})
+
+	t.Run("A longer window reaches older rates", func(t *testing.T) {
+		window, err := entities.NewRateWindow(12)
+		require.NoError(t, err)
+
+		rate, err := repo.FindRateForConversion(entities.USD, entities.BRL, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), window)
+
+		require.NoError(t, err)
+		require.NotNil(t, rate)
+		assert.Equal(t, time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), rate.EffectiveDate)
+	})
+
+	t.Run("Other currency pairs are not matched", func(t *testing.T) {
+		rate, err := repo.FindRateForConversion(entities.USD, entities.EUR, transactionDate, entities.RateWindow{})
+
+		require.NoError(t, err)
+		assert.Nil(t, rate)
+	})
+}
+
+func TestExchangeRateRepository_UpdateAndDelete(t *testing.T) {
+	// Arrange
+	repo := memory.NewExchangeRateRepository()
+	rate := fixtures.ValidExchangeRate()
+	require.NoError(t, repo.Save(&rate))
+
+	// Act
+	rate.Rate = 5.5
+	require.NoError(t, repo.Update(&rate))
+
+	// Assert
+	saved, err := repo.GetByID(rate.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 5.5, saved.Rate)
+
+	require.NoError(t, repo.Delete(rate.ID))
+	assert.ErrorIs(t, repo.Delete(rate.ID), errs.ErrNotFound)
+	assert.ErrorIs(t, repo.Update(&rate), errs.ErrNotFound)
+}
+
+func TestExchangeRateRepository_ConcurrentAccess(t *testing.T) {
+	// Arrange
+	repo := memory.NewExchangeRateRepository()
+	const writers, perWriter = 8, 50
+
+	// Act
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range perWriter {
+				rate := fixtures.ValidExchangeRate()
+				assert.NoError(t, repo.Save(&rate))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range perWriter {
+				_, err := repo.FindRateForConversion(entities.USD, entities.BRL, time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), entities.RateWindow{})
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	summaries, err := repo.SummarizeByCurrency()
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, int64(writers*perWriter), summaries[0].Rates)
+}
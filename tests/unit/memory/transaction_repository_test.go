@@ -0,0 +1,212 @@
+package memory_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionRepository_SaveAndGet(t *testing.T) {
+	repo := memory.NewTransactionRepository()
+
+	t.Run("Saved transaction is returned by ID", func(t *testing.T) {
+		// Arrange
+		transaction := fixtures.ValidTransaction()
+
+		// Act
+		require.NoError(t, repo.Save(&transaction))
+		saved, err := repo.GetByID(transaction.ID)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, transaction.Description, saved.Description)
+		assert.Equal(t, transaction.Amount, saved.Amount)
+		assert.False(t, saved.UpdatedAt.IsZero())
+	})
+
+	t.Run("Duplicate ID is rejected", func(t *testing.T) {
+		transaction := fixtures.ValidTransaction()
+		require.NoError(t, repo.Save(&transaction))
+
+		duplicate := fixtures.TransactionWithID(transaction.ID)
+		assert.Error(t, repo.Save(&duplicate))
+	})
+
+	t.Run("Invalid and nil transactions are rejected", func(t *testing.T) {
+		invalid := fixtures.TransactionWithDescription("")
+
+		assert.Error(t, repo.Save(&invalid))
+		assert.Error(t, repo.Save(nil))
+	})
+
+	t.Run("Unknown ID returns nil", func(t *testing.T) {
+		saved, err := repo.GetByID(uuid.New())
+
+		require.NoError(t, err)
+		assert.Nil(t, saved)
+	})
+}
+
+func TestTransactionRepository_StoredCopiesAreIsolated(t *testing.T) {
+	// Arrange
+	repo := memory.NewTransactionRepository()
+	externalID := "plaid:abc"
+	transaction := fixtures.ValidTransaction()
+	transaction.Tags = []string{"travel", "work"}
+	transaction.ExternalID = &externalID
+	require.NoError(t, repo.Save(&transaction))
+
+	// Act - mutate both the caller's value and a value read back
+	transaction.Tags[0] = "changed"
+	externalID = "changed"
+	read, err := repo.GetByID(transaction.ID)
+	require.NoError(t, err)
+	read.Tags[1] = "changed"
+
+	// Assert
+	stored, err := repo.GetByID(transaction.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"travel", "work"}, stored.Tags)
+	assert.Equal(t, "plaid:abc", *stored.ExternalID)
+}
+
+func TestTransactionRepository_UpdateRejectsStaleVersion(t *testing.T) {
+	// Arrange
+	repo := memory.NewTransactionRepository()
+	transaction := fixtures.ValidTransaction()
+	require.NoError(t, repo.Save(&transaction))
+	stale := transaction
+
+	// Act
+	transaction.Category = "Travel"
+	require.NoError(t, repo.Update(&transaction))
+	stale.Category = "Food"
+	err := repo.Update(&stale)
+
+	// Assert
+	assert.ErrorIs(t, err, errs.ErrConflict)
+	stored, err := repo.GetByID(transaction.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stored.Version)
+	assert.Equal(t, "Travel", stored.Category)
+}
+
+func TestTransactionRepository_FindPaginated(t *testing.T) {
+	// Arrange
+	repo := memory.NewTransactionRepository()
+	lunch := fixtures.TransactionWithDescription("Team lunch")
+	lunch.Tags = []string{"food"}
+	lunch.CreatedAt = time.Now().Add(-time.Minute)
+	hotel := fixtures.TransactionWithAmount(250)
+	hotel.Description = "Hotel"
+	hotel.Category = "Travel"
+	for _, transaction := range []*entities.Transaction{&lunch, &hotel} {
+		require.NoError(t, repo.Save(transaction))
+	}
+	minimum := entities.NewMoney(200)
+
+	testCases := []struct {
+		name     string
+		filter   entities.TransactionFilter
+		expected []uuid.UUID
+	}{
+		{"No filter, most recent first", entities.TransactionFilter{}, []uuid.UUID{hotel.ID, lunch.ID}},
+		{"Description is case-insensitive", entities.TransactionFilter{DescriptionContains: "LUNCH"}, []uuid.UUID{lunch.ID}},
+		{"Category", entities.TransactionFilter{Category: "Travel"}, []uuid.UUID{hotel.ID}},
+		{"Tag", entities.TransactionFilter{Tag: "food"}, []uuid.UUID{lunch.ID}},
+		{"Minimum amount", entities.TransactionFilter{MinAmount: &minimum}, []uuid.UUID{hotel.ID}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			page, total, err := repo.FindPaginated(tc.filter, 1, 10)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tc.expected)), total)
+			ids := make([]uuid.UUID, len(page))
+			for i, transaction := range page {
+				ids[i] = transaction.ID
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+func TestTransactionRepository_DeleteAndRestore(t *testing.T) {
+	// Arrange
+	repo := memory.NewTransactionRepository()
+	transaction := fixtures.ValidTransaction()
+	require.NoError(t, repo.Save(&transaction))
+
+	// Act
+	require.NoError(t, repo.Delete(transaction.ID))
+
+	// Assert - hidden from reads, listed in the trash
+	live, err := repo.GetByID(transaction.ID)
+	require.NoError(t, err)
+	assert.Nil(t, live)
+	count, err := repo.Count()
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.ErrorIs(t, repo.Delete(transaction.ID), errs.ErrNotFound)
+
+	deleted, total, err := repo.GetDeletedPaginated(1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, transaction.ID, deleted[0].ID)
+
+	restored, err := repo.Restore(transaction.ID)
+	require.NoError(t, err)
+	assert.False(t, restored.IsDeleted())
+	exists, err := repo.Exists(transaction.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestTransactionRepository_ConcurrentAccess(t *testing.T) {
+	// Arrange
+	repo := memory.NewTransactionRepository()
+	const writers, perWriter = 8, 50
+
+	// Act - writers, readers and updaters run at once; `go test -race` flags unsynchronized access
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range perWriter {
+				transaction := fixtures.ValidTransaction()
+				transaction.Tags = []string{"concurrent"}
+				if !assert.NoError(t, repo.Save(&transaction)) {
+					return
+				}
+				transaction.Description = "Updated"
+				assert.NoError(t, repo.Update(&transaction))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range perWriter {
+				_, _, err := repo.FindPaginated(entities.TransactionFilter{Tag: "concurrent"}, 1, 20)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	count, err := repo.Count()
+	require.NoError(t, err)
+	assert.Equal(t, int64(writers*perWriter), count)
+}
@@ -0,0 +1,105 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeprecationPolicy(t *testing.T) {
+	t.Run("Dates and RFC 3339 timestamps", func(t *testing.T) {
+		policy, err := middleware.ParseDeprecationPolicy("2025-01-01", "2025-06-30T12:00:00Z", "https://example.com/v2")
+		require.NoError(t, err)
+
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), policy.DeprecatedAt)
+		assert.Equal(t, time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC), policy.SunsetAt)
+		assert.Equal(t, "https://example.com/v2", policy.Link)
+	})
+
+	t.Run("No deprecation date disables the policy", func(t *testing.T) {
+		policy, err := middleware.ParseDeprecationPolicy("", "", "https://example.com/v2")
+		require.NoError(t, err)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("Invalid configurations", func(t *testing.T) {
+		for name, dates := range map[string][2]string{
+			"sunset without deprecation": {"", "2025-06-30"},
+			"malformed deprecation date": {"01/01/2025", ""},
+			"malformed sunset date":      {"2025-01-01", "soon"},
+			"sunset before deprecation":  {"2025-06-30", "2025-01-01"},
+		} {
+			_, err := middleware.ParseDeprecationPolicy(dates[0], dates[1], "")
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func TestDeprecate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	newRouter := func(policy *middleware.DeprecationPolicy, recorder *activity.Recorder) *gin.Engine {
+		router := gin.New()
+		v1 := router.Group("/api/v1", policy.Deprecate(recorder))
+		v1.GET("/transactions/:id", ok)
+		v1.POST("/convert", ok)
+		router.GET("/api/v2/transactions/:id", ok)
+		return router
+	}
+
+	t.Run("Deprecated routes announce their timeline and are counted", func(t *testing.T) {
+		// Arrange
+		policy, err := middleware.ParseDeprecationPolicy("2025-01-01", "2025-06-30", "https://example.com/migrate")
+		require.NoError(t, err)
+		recorder := activity.NewRecorder()
+		router := newRouter(policy, recorder)
+
+		// Act
+		w := send(router, "GET", "/api/v1/transactions/1", "")
+		send(router, "GET", "/api/v1/transactions/2", "")
+		send(router, "POST", "/api/v1/convert", "")
+		current := send(router, "GET", "/api/v2/transactions/1", "")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "@1735689600", w.Header().Get("Deprecation"))
+		assert.Equal(t, "Mon, 30 Jun 2025 00:00:00 GMT", w.Header().Get("Sunset"))
+		assert.Equal(t, `<https://example.com/migrate>; rel="deprecation"; type="text/html", <https://example.com/migrate>; rel="sunset"; type="text/html"`, w.Header().Get("Link"))
+
+		assert.Empty(t, current.Header().Get("Deprecation"))
+		assert.Equal(t, map[string]int64{
+			"GET /api/v1/transactions/:id": 2,
+			"POST /api/v1/convert":         1,
+		}, recorder.DeprecatedCalls())
+	})
+
+	t.Run("Sunset and Link are omitted when not configured", func(t *testing.T) {
+		// Arrange
+		policy, err := middleware.ParseDeprecationPolicy("2025-01-01", "", "")
+		require.NoError(t, err)
+
+		// Act
+		w := send(newRouter(policy, nil), "GET", "/api/v1/transactions/1", "")
+
+		// Assert
+		assert.Equal(t, "@1735689600", w.Header().Get("Deprecation"))
+		assert.Empty(t, w.Header().Get("Sunset"))
+		assert.Empty(t, w.Header().Get("Link"))
+	})
+
+	t.Run("A nil policy adds nothing", func(t *testing.T) {
+		recorder := activity.NewRecorder()
+		w := send(newRouter(nil, recorder), "GET", "/api/v1/transactions/1", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Deprecation"))
+		assert.Empty(t, recorder.DeprecatedCalls())
+	})
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/errortracking"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReporter returns a reporter whose events are decoded into the returned slice once it is closed
+func newReporter(t *testing.T) (*errortracking.Reporter, *[]map[string]interface{}) {
+	var mu sync.Mutex
+	events := []map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+
+	reporter, err := errortracking.NewReporter(errortracking.Config{
+		DSN: strings.Replace(server.URL, "://", "://key@", 1) + "/1",
+	}, "test/1.0")
+	require.NoError(t, err)
+	return reporter, &events
+}
+
+func TestErrorReporting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reporter, events := newReporter(t)
+
+	router := gin.New()
+	router.Use(gin.Recovery(), middleware.ErrorReporting(reporter))
+	router.GET("/panic/:id", func(c *gin.Context) { panic("boom") })
+	router.GET("/failure", func(c *gin.Context) {
+		_ = c.Error(errors.New("database unreachable"))
+		c.Status(http.StatusInternalServerError)
+	})
+	router.GET("/rejected", func(c *gin.Context) {
+		_ = c.Error(errors.New("bad input"))
+		c.Status(http.StatusBadRequest)
+	})
+
+	// Act
+	panicked := send(router, http.MethodGet, "/panic/7", "")
+	failed := send(router, http.MethodGet, "/failure", "")
+	rejected := send(router, http.MethodGet, "/rejected", "")
+	require.NoError(t, reporter.Close())
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, panicked.Code, "the recovery middleware still answers")
+	assert.Equal(t, http.StatusInternalServerError, failed.Code)
+	assert.Equal(t, http.StatusBadRequest, rejected.Code)
+
+	require.Len(t, *events, 2, "client errors are not reported")
+	byLevel := map[string]map[string]interface{}{}
+	for _, event := range *events {
+		byLevel[event["level"].(string)] = event
+	}
+	assert.Equal(t, "GET /panic/:id", byLevel["fatal"]["tags"].(map[string]interface{})["route"])
+	assert.Equal(t, "GET /failure", byLevel["error"]["tags"].(map[string]interface{})["route"])
+	assert.Equal(t, "500", byLevel["error"]["tags"].(map[string]interface{})["status_code"])
+}
+
+func TestErrorReporting_NilReporter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorReporting(nil))
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	assert.Equal(t, http.StatusOK, send(router, http.MethodGet, "/ok", "").Code)
+}
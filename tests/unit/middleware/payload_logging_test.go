@@ -0,0 +1,114 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// newRouter echoes the request body back and logs payloads into buf at the given level
+	newRouter := func(buf *bytes.Buffer, level slog.Level, enabled bool) *gin.Engine {
+		log := &logger.Logger{Logger: slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: level}))}
+		var payloads *middleware.PayloadLogger
+		if enabled {
+			payloads = middleware.NewPayloadLogger(log, 64, []string{"card_number"})
+		}
+		router := gin.New()
+		router.Use(payloads.Handler())
+		router.POST("/echo", func(c *gin.Context) {
+			body, _ := io.ReadAll(c.Request.Body)
+			c.Data(http.StatusCreated, c.ContentType(), body)
+		})
+		return router
+	}
+
+	send := func(router *gin.Engine, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-API-Key", "ptx_secret")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	entry := func(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+		var logged map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &logged))
+		return logged
+	}
+
+	t.Run("Sensitive fields and headers are redacted at any depth", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		router := newRouter(&buf, slog.LevelDebug, true)
+		body := `{"description":"Hotel","Password":"hunter2","payment":{"card_number":"4111"},"items":[{"token":"abc"}]}`
+
+		// Act
+		w := send(router, "application/json", body)
+
+		// Assert
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, body, w.Body.String(), "the handler and client see the original body")
+
+		logged := entry(t, &buf)
+		assert.Equal(t, "HTTP payload", logged["msg"])
+		assert.Equal(t, 201.0, logged["status"])
+		assert.Equal(t, "[REDACTED]", logged["request_headers"].(map[string]interface{})["X-Api-Key"])
+		for _, field := range []string{"request_body", "response_body"} {
+			logged := logged[field].(string)
+			assert.NotContains(t, logged, "hunter2")
+			assert.NotContains(t, logged, "4111")
+			assert.NotContains(t, logged, "abc")
+			assert.Contains(t, logged, `"description":"Hotel"`)
+		}
+	})
+
+	t.Run("Bodies are truncated to the size limit", func(t *testing.T) {
+		var buf bytes.Buffer
+		router := newRouter(&buf, slog.LevelDebug, true)
+
+		send(router, "text/plain", strings.Repeat("a", 100))
+
+		logged := entry(t, &buf)
+		assert.Equal(t, strings.Repeat("a", 64)+"...[truncated]", logged["request_body"])
+	})
+
+	t.Run("Bodies that cannot be redacted are described by size only", func(t *testing.T) {
+		var buf bytes.Buffer
+		router := newRouter(&buf, slog.LevelDebug, true)
+
+		send(router, "application/json", `{"password":"hunter2"`)
+		logged := entry(t, &buf)
+		assert.Equal(t, "[21 bytes of malformed JSON omitted]", logged["request_body"])
+
+		buf.Reset()
+		send(router, "application/octet-stream", "binary")
+		logged = entry(t, &buf)
+		assert.Equal(t, `[6 bytes of "application/octet-stream" omitted]`, logged["request_body"])
+	})
+
+	t.Run("Nothing is logged above debug level or when disabled", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := send(newRouter(&buf, slog.LevelInfo, true), "application/json", `{"a":1}`)
+		assert.Equal(t, `{"a":1}`, w.Body.String())
+		assert.Empty(t, buf.String())
+
+		w = send(newRouter(&buf, slog.LevelDebug, false), "application/json", `{"a":1}`)
+		assert.Equal(t, `{"a":1}`, w.Body.String())
+		assert.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,127 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLimitedRouter registers one route per profile behind the limiter
+func newLimitedRouter(limiter *middleware.RateLimiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.POST("/convert", limiter.Limit("convert"), ok)
+	router.GET("/list", limiter.Limit("list"), ok)
+	router.GET("/other", limiter.Limit("unconfigured"), ok)
+	return router
+}
+
+// send performs a request with an optional API key and returns the recorder
+func send(router *gin.Engine, method, path, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestParseRateLimit(t *testing.T) {
+	t.Run("Valid limits", func(t *testing.T) {
+		limit, err := middleware.ParseRateLimit("10/min")
+		require.NoError(t, err)
+		assert.Equal(t, middleware.RateLimit{Requests: 10, Period: time.Minute}, limit)
+
+		limit, err = middleware.ParseRateLimit(" 5 / s ")
+		require.NoError(t, err)
+		assert.Equal(t, middleware.RateLimit{Requests: 5, Period: time.Second}, limit)
+
+		limit, err = middleware.ParseRateLimit("1000/hour")
+		require.NoError(t, err)
+		assert.Equal(t, middleware.RateLimit{Requests: 1000, Period: time.Hour}, limit)
+	})
+
+	t.Run("Invalid limits", func(t *testing.T) {
+		for _, spec := range []string{"10", "0/min", "-1/min", "ten/min", "10/day"} {
+			_, err := middleware.ParseRateLimit(spec)
+			assert.Error(t, err, spec)
+		}
+	})
+}
+
+func TestRateLimiter_Limit(t *testing.T) {
+	t.Run("Profiles are enforced independently", func(t *testing.T) {
+		// Arrange
+		limiter, err := middleware.NewRateLimiter(map[string]string{"convert": "2/hour", "list": "300/min"})
+		require.NoError(t, err)
+		router := newLimitedRouter(limiter)
+
+		// Act & Assert
+		first := send(router, http.MethodPost, "/convert", "")
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "2", first.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "1", first.Header().Get("X-RateLimit-Remaining"))
+
+		assert.Equal(t, http.StatusOK, send(router, http.MethodPost, "/convert", "").Code)
+
+		limited := send(router, http.MethodPost, "/convert", "")
+		assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+		assert.NotEmpty(t, limited.Header().Get("Retry-After"))
+		assert.Contains(t, limited.Body.String(), "Rate limit exceeded")
+
+		list := send(router, http.MethodGet, "/list", "")
+		assert.Equal(t, http.StatusOK, list.Code)
+		assert.Equal(t, "300", list.Header().Get("X-RateLimit-Limit"))
+	})
+
+	t.Run("API keys get separate buckets", func(t *testing.T) {
+		// Arrange
+		limiter, err := middleware.NewRateLimiter(map[string]string{"convert": "1/hour"})
+		require.NoError(t, err)
+		router := newLimitedRouter(limiter)
+
+		// Act & Assert
+		assert.Equal(t, http.StatusOK, send(router, http.MethodPost, "/convert", "key-a").Code)
+		assert.Equal(t, http.StatusTooManyRequests, send(router, http.MethodPost, "/convert", "key-a").Code)
+		assert.Equal(t, http.StatusOK, send(router, http.MethodPost, "/convert", "key-b").Code)
+	})
+
+	t.Run("Unconfigured profile falls back to default", func(t *testing.T) {
+		// Arrange
+		limiter, err := middleware.NewRateLimiter(map[string]string{middleware.DefaultRateLimitProfile: "1/hour"})
+		require.NoError(t, err)
+		router := newLimitedRouter(limiter)
+
+		// Act & Assert
+		assert.Equal(t, http.StatusOK, send(router, http.MethodGet, "/other", "").Code)
+		assert.Equal(t, http.StatusTooManyRequests, send(router, http.MethodGet, "/other", "").Code)
+	})
+
+	t.Run("No matching profile or nil limiter allows everything", func(t *testing.T) {
+		limiter, err := middleware.NewRateLimiter(map[string]string{"convert": "1/hour"})
+		require.NoError(t, err)
+
+		for _, router := range []*gin.Engine{newLimitedRouter(limiter), newLimitedRouter(nil)} {
+			for i := 0; i < 3; i++ {
+				w := send(router, http.MethodGet, "/other", "")
+				assert.Equal(t, http.StatusOK, w.Code)
+				assert.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+			}
+		}
+	})
+
+	t.Run("Invalid profile is rejected", func(t *testing.T) {
+		_, err := middleware.NewRateLimiter(map[string]string{"convert": "lots"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "profile convert")
+	})
+}
@@ -0,0 +1,87 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTimedRouter registers routes that wait for their context, and one that ignores it, behind the timeouts
+func newTimedRouter(timeouts *middleware.RequestTimeouts) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	waitForContext := func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+			c.Status(http.StatusOK)
+		}
+	}
+	ignoreContext := func(c *gin.Context) {
+		time.Sleep(50 * time.Millisecond)
+		c.Status(http.StatusCreated)
+	}
+	router.GET("/list", timeouts.Limit("list"), waitForContext)
+	router.POST("/convert", timeouts.Limit("convert"), waitForContext)
+	router.POST("/write", timeouts.Limit("write"), ignoreContext)
+	router.GET("/admin", timeouts.Limit("admin"), waitForContext)
+	return router
+}
+
+func TestRequestTimeouts_Limit(t *testing.T) {
+	timeouts := middleware.NewRequestTimeouts(20*time.Millisecond, map[string]time.Duration{
+		"convert": time.Second,
+		"admin":   0,
+	})
+	router := newTimedRouter(timeouts)
+
+	t.Run("Requests past the default budget are answered 504", func(t *testing.T) {
+		// Act
+		w := send(router, http.MethodGet, "/list", "")
+
+		// Assert
+		require.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+		var body problem.Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, problem.TypeTimeout, body.Type)
+		assert.Equal(t, "/list", body.Instance)
+		assert.Contains(t, body.Detail, "20ms")
+	})
+
+	t.Run("Profiles get their own budget", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send(router, http.MethodPost, "/convert", "").Code)
+	})
+
+	t.Run("A zero budget leaves the route unbounded", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send(router, http.MethodGet, "/admin", "").Code)
+	})
+
+	t.Run("A late response from a handler ignoring its context is kept", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, send(router, http.MethodPost, "/write", "").Code)
+	})
+
+	t.Run("A nil RequestTimeouts bounds nothing", func(t *testing.T) {
+		var unbounded *middleware.RequestTimeouts
+
+		assert.Equal(t, http.StatusOK, send(newTimedRouter(unbounded), http.MethodGet, "/list", "").Code)
+		assert.Zero(t, unbounded.Longest())
+	})
+}
+
+func TestRequestTimeouts_Budget(t *testing.T) {
+	timeouts := middleware.NewRequestTimeouts(10*time.Second, map[string]time.Duration{"convert": 25 * time.Second, "admin": 0})
+
+	assert.Equal(t, 25*time.Second, timeouts.Budget("convert"))
+	assert.Equal(t, time.Duration(0), timeouts.Budget("admin"))
+	assert.Equal(t, 10*time.Second, timeouts.Budget("list"))
+	assert.Equal(t, 25*time.Second, timeouts.Longest())
+}
@@ -0,0 +1,76 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/http/middleware"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v2/transactions", middleware.APIVersion(middleware.VersionV2), func(c *gin.Context) {
+		c.String(http.StatusOK, middleware.APIVersionOf(c))
+	})
+	router.GET("/health", func(c *gin.Context) {
+		c.String(http.StatusOK, middleware.APIVersionOf(c))
+	})
+
+	// Act
+	versioned := send(router, "GET", "/api/v2/transactions", "")
+	unversioned := send(router, "GET", "/health", "")
+
+	// Assert
+	assert.Equal(t, "v2", versioned.Header().Get("API-Version"))
+	assert.Equal(t, "v2", versioned.Body.String())
+	assert.Empty(t, unversioned.Header().Get("API-Version"))
+	assert.Empty(t, unversioned.Body.String())
+}
+
+func TestSuccessorVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	newRouter := func(policy *middleware.DeprecationPolicy) *gin.Engine {
+		router := gin.New()
+		v1 := router.Group("/api/v1",
+			policy.Deprecate(activity.NewRecorder()),
+			middleware.SuccessorVersion("v1", "v2", []string{"/api/v2/transactions/:id"}),
+		)
+		v1.GET("/transactions/:id", ok)
+		v1.POST("/convert", ok)
+		return router
+	}
+
+	t.Run("Routes served by the newer version link to it", func(t *testing.T) {
+		w := send(newRouter(nil), "GET", "/api/v1/transactions/42", "")
+
+		assert.Equal(t, `</api/v2/transactions/42>; rel="successor-version"`, w.Header().Get("Link"))
+	})
+
+	t.Run("Routes the newer version does not serve have no link", func(t *testing.T) {
+		w := send(newRouter(nil), "POST", "/api/v1/convert", "")
+
+		assert.Empty(t, w.Header().Values("Link"))
+	})
+
+	t.Run("The deprecation link is kept", func(t *testing.T) {
+		// Arrange
+		policy, err := middleware.ParseDeprecationPolicy("2025-01-01", "", "https://example.com/migrate")
+		require.NoError(t, err)
+
+		// Act
+		w := send(newRouter(policy), "GET", "/api/v1/transactions/42", "")
+
+		// Assert
+		assert.Equal(t, []string{
+			`<https://example.com/migrate>; rel="deprecation"; type="text/html"`,
+			`</api/v2/transactions/42>; rel="successor-version"`,
+		}, w.Header().Values("Link"))
+	})
+}
@@ -0,0 +1,62 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronSchedule(t *testing.T) {
+	// Wednesday 2024-05-15 10:30 UTC
+	now := time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)
+
+	next := func(t *testing.T, expression string, from time.Time) time.Time {
+		t.Helper()
+		schedule, err := scheduler.ParseCron(expression)
+		require.NoError(t, err)
+		return schedule.Next(from)
+	}
+
+	t.Run("Daily at a fixed time", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 5, 16, 6, 0, 0, 0, time.UTC), next(t, "0 6 * * *", now))
+		assert.Equal(t, time.Date(2024, 5, 15, 12, 15, 0, 0, time.UTC), next(t, "15 12 * * *", now))
+	})
+
+	t.Run("Next is strictly after the given time", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 5, 15, 10, 31, 0, 0, time.UTC), next(t, "* * * * *", now))
+		assert.Equal(t, time.Date(2024, 5, 16, 10, 30, 0, 0, time.UTC), next(t, "30 10 * * *", now))
+	})
+
+	t.Run("Steps, ranges and lists", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 5, 15, 10, 45, 0, 0, time.UTC), next(t, "*/15 * * * *", now))
+		assert.Equal(t, time.Date(2024, 5, 15, 14, 0, 0, 0, time.UTC), next(t, "0 9-17/5 * * *", now))
+		assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), next(t, "0 0 1,15 * *", now))
+	})
+
+	t.Run("Day of week, with 7 as Sunday", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 5, 20, 6, 0, 0, 0, time.UTC), next(t, "0 6 * * 1", now))
+		assert.Equal(t, time.Date(2024, 5, 19, 6, 0, 0, 0, time.UTC), next(t, "0 6 * * 7", now))
+		assert.Equal(t, time.Date(2024, 5, 16, 6, 0, 0, 0, time.UTC), next(t, "0 6 * * 1-5", now))
+	})
+
+	t.Run("Restricted day of month and day of week match either", func(t *testing.T) {
+		// The 20th or any Friday, whichever comes first
+		assert.Equal(t, time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC), next(t, "0 0 20 * 5", now))
+	})
+
+	t.Run("Quarterly runs and impossible dates", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 7, 1, 6, 0, 0, 0, time.UTC), next(t, "0 6 1 1,4,7,10 *", now))
+		assert.Equal(t, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), next(t, "0 0 29 2 *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, next(t, "0 0 30 2 *", now).IsZero())
+	})
+
+	t.Run("Rejects invalid expressions", func(t *testing.T) {
+		for _, expression := range []string{"", "0 6 * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+			_, err := scheduler.ParseCron(expression)
+			assert.Error(t, err, expression)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigestSchedule(t *testing.T) {
+	// Wednesday 2024-05-15 10:30 UTC
+	now := time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)
+
+	t.Run("Daily after the scheduled hour", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 5, 15, 8, 0, 0, 0, time.UTC), scheduler.PreviousRun(now, "daily", 8))
+		assert.Equal(t, time.Date(2024, 5, 16, 8, 0, 0, 0, time.UTC), scheduler.NextRun(now, "daily", 8))
+	})
+
+	t.Run("Daily before the scheduled hour", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 5, 14, 12, 0, 0, 0, time.UTC), scheduler.PreviousRun(now, "daily", 12))
+		assert.Equal(t, time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC), scheduler.NextRun(now, "daily", 12))
+	})
+
+	t.Run("Weekly runs on Mondays", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 5, 13, 8, 0, 0, 0, time.UTC), scheduler.PreviousRun(now, "weekly", 8))
+		assert.Equal(t, time.Date(2024, 5, 20, 8, 0, 0, 0, time.UTC), scheduler.NextRun(now, "weekly", 8))
+	})
+
+	t.Run("Weekly early on Monday", func(t *testing.T) {
+		monday := time.Date(2024, 5, 13, 6, 0, 0, 0, time.UTC)
+		assert.Equal(t, time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC), scheduler.PreviousRun(monday, "weekly", 8))
+		assert.Equal(t, time.Date(2024, 5, 13, 8, 0, 0, 0, time.UTC), scheduler.NextRun(monday, "weekly", 8))
+	})
+}
@@ -0,0 +1,63 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveTransactionsUseCase(t *testing.T) {
+	t.Run("Archives batches until none are left", func(t *testing.T) {
+		// Arrange
+		mockRepo := &mocks.MockTransactionRepository{}
+		expectedCutoff := time.Now().UTC().Truncate(24*time.Hour).AddDate(-7, 0, 0)
+		mockRepo.On("ArchiveDatedBefore", expectedCutoff, 2).Return(int64(2), nil).Twice()
+		mockRepo.On("ArchiveDatedBefore", expectedCutoff, 2).Return(int64(1), nil).Once()
+
+		useCase := usecases.NewArchiveTransactionsUseCase(mockRepo, 7, 2)
+
+		// Act
+		result, err := useCase.Execute(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, expectedCutoff, result.Cutoff)
+		assert.Equal(t, int64(5), result.Archived)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reports what was moved before a failure", func(t *testing.T) {
+		// Arrange
+		mockRepo := &mocks.MockTransactionRepository{}
+		mockRepo.On("ArchiveDatedBefore", mock.Anything, 10).Return(int64(10), nil).Once()
+		mockRepo.On("ArchiveDatedBefore", mock.Anything, 10).Return(int64(0), errors.New("disk full")).Once()
+
+		useCase := usecases.NewArchiveTransactionsUseCase(mockRepo, 7, 10)
+
+		// Act
+		result, err := useCase.Execute(context.Background())
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "disk full")
+		assert.Equal(t, int64(10), result.Archived)
+	})
+
+	t.Run("Rejects a retention below one year", func(t *testing.T) {
+		// Arrange
+		useCase := usecases.NewArchiveTransactionsUseCase(&mocks.MockTransactionRepository{}, 0, 10)
+
+		// Act
+		_, err := useCase.Execute(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
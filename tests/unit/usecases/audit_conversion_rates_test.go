@@ -0,0 +1,117 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditConversionRatesUseCase_Execute(t *testing.T) {
+	storedRate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.90, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+	revisedRate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.91, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+
+	matchingDate := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+	revisedDate := time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC)
+	failingDate := time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Reports mismatched and unavailable records", func(t *testing.T) {
+		// Arrange
+		recordRepo := memory.NewConversionRecordRepository()
+		mockTreasuryService := new(mocks.MockTreasuryService)
+		usecase := usecases.NewAuditConversionRatesUseCase(recordRepo, mockTreasuryService)
+
+		revised := storedConversion(t, revisedDate, 100, storedRate)
+		unavailable := storedConversion(t, failingDate, 100, storedRate)
+		require.NoError(t, recordRepo.SaveAll([]entities.ConversionRecord{
+			storedConversion(t, matchingDate, 100, storedRate),
+			storedConversion(t, matchingDate, 50, storedRate), // Same date reuses the fetched rate
+			revised,
+			unavailable,
+		}))
+
+		mockTreasuryService.On("ProviderName").Return("us_treasury")
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, matchingDate).Return(storedRate, nil).Once()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, revisedDate).Return(revisedRate, nil).Once()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, failingDate).Return(nil, errors.New("treasury unavailable")).Once()
+
+		// Act
+		report, err := usecase.Execute(context.Background(), 10)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "us_treasury", report.Provider)
+		assert.Equal(t, 4, report.Sampled)
+		assert.Equal(t, 2, report.Matched)
+		assert.Equal(t, 1, report.Mismatched)
+		assert.Equal(t, 1, report.Unavailable)
+		require.Len(t, report.Entries, 2)
+
+		byRecord := make(map[string]dto.RateAuditEntry)
+		for _, entry := range report.Entries {
+			byRecord[entry.RecordID.String()] = entry
+		}
+
+		mismatch := byRecord[revised.ID.String()]
+		assert.Equal(t, dto.AuditMismatched, mismatch.Outcome)
+		assert.Equal(t, 0.90, mismatch.StoredRate)
+		assert.Equal(t, 0.91, mismatch.AuthoritativeRate)
+		assert.Equal(t, 90.0, mismatch.StoredConvertedAmount)
+		assert.Equal(t, 91.0, mismatch.AuthoritativeConvertedAmount)
+
+		failure := byRecord[unavailable.ID.String()]
+		assert.Equal(t, dto.AuditUnavailable, failure.Outcome)
+		assert.Contains(t, failure.Error, "treasury unavailable")
+
+		mockTreasuryService.AssertExpectations(t)
+	})
+
+	t.Run("Sample size is bounded", func(t *testing.T) {
+		// Arrange
+		recordRepo := memory.NewConversionRecordRepository()
+		mockTreasuryService := new(mocks.MockTreasuryService)
+		usecase := usecases.NewAuditConversionRatesUseCase(recordRepo, mockTreasuryService)
+		require.NoError(t, recordRepo.SaveAll([]entities.ConversionRecord{
+			storedConversion(t, matchingDate, 100, storedRate),
+			storedConversion(t, matchingDate, 200, storedRate),
+			storedConversion(t, matchingDate, 300, storedRate),
+		}))
+
+		mockTreasuryService.On("ProviderName").Return("us_treasury")
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, matchingDate).Return(storedRate, nil)
+
+		// Act
+		report, err := usecase.Execute(context.Background(), 2)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Sampled)
+		assert.Equal(t, 2, report.Matched)
+		assert.Empty(t, report.Entries)
+	})
+
+	t.Run("Invalid sample size", func(t *testing.T) {
+		// Arrange
+		usecase := usecases.NewAuditConversionRatesUseCase(memory.NewConversionRecordRepository(), new(mocks.MockTreasuryService))
+
+		// Act
+		report, err := usecase.Execute(context.Background(), 0)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, report)
+		assert.Contains(t, err.Error(), "validation failed")
+	})
+}
@@ -0,0 +1,79 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillRatesUseCase(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("Looks up every quarter end of the range and its last day", func(t *testing.T) {
+		// Arrange
+		exchangeRateRepo := memory.NewExchangeRateRepository()
+		treasury := &mocks.MockTreasuryService{}
+
+		storedQ4, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.9, day(2023, 12, 31), clock.System())
+		require.NoError(t, exchangeRateRepo.Save(storedQ4))
+		q1, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.92, day(2024, 3, 31), clock.System())
+		q2, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.93, day(2024, 6, 30), clock.System())
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, day(2024, 3, 31)).Return(q1, nil).Once()
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, day(2024, 6, 30)).Return(q2, nil).Once()
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, day(2024, 8, 15)).Return(q2, nil).Once()
+
+		useCase := usecases.NewBackfillRatesUseCase(exchangeRateRepo, treasury)
+
+		// Act
+		result, err := useCase.Execute(context.Background(), []entities.CurrencyCode{entities.EUR}, day(2023, 12, 31), day(2024, 8, 15))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Dates)
+		assert.Equal(t, 2, result.Stored)
+		assert.Equal(t, 2, result.Cached, "the stored Q4 rate and the repeated Q2 rate")
+		assert.Zero(t, result.Failed)
+		treasury.AssertExpectations(t)
+	})
+
+	t.Run("Reports failed lookups and carries on", func(t *testing.T) {
+		// Arrange
+		treasury := &mocks.MockTreasuryService{}
+		q1, _ := entities.NewExchangeRate(entities.USD, entities.CAD, 1.35, day(2024, 3, 31), clock.System())
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.CAD, day(2024, 3, 31)).Return(q1, nil).Once()
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.CAD, day(2024, 4, 10)).
+			Return(nil, errors.New("Treasury API returned status 503")).Once()
+
+		useCase := usecases.NewBackfillRatesUseCase(memory.NewExchangeRateRepository(), treasury)
+
+		// Act
+		result, err := useCase.Execute(context.Background(), []entities.CurrencyCode{entities.CAD}, day(2024, 3, 1), day(2024, 4, 10))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Stored)
+		assert.Equal(t, 1, result.Failed)
+		assert.Contains(t, result.Errors["CAD 2024-04-10"], "status 503")
+	})
+
+	t.Run("Rejects a reversed range", func(t *testing.T) {
+		useCase := usecases.NewBackfillRatesUseCase(memory.NewExchangeRateRepository(), &mocks.MockTreasuryService{})
+
+		_, err := useCase.Execute(context.Background(), []entities.CurrencyCode{entities.EUR}, day(2024, 6, 1), day(2024, 1, 1))
+
+		assert.ErrorIs(t, err, errs.ErrValidation)
+	})
+}
@@ -0,0 +1,186 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchConversionUseCase(t *testing.T) {
+	// Setup
+	validator := validation.NewValidator()
+	transactionRepo := memory.NewTransactionRepository()
+	exchangeRateRepo := memory.NewExchangeRateRepository()
+	batchRepo := memory.NewConversionBatchRepository()
+	recordRepo := memory.NewConversionRecordRepository()
+	mockTreasuryService := new(mocks.MockTreasuryService)
+	rateFinder := usecases.NewConvertTransactionUseCase(transactionRepo, exchangeRateRepo, memory.NewQuoteRepository(), mockTreasuryService, nil, validator)
+	usecase := usecases.NewBatchConversionUseCase(transactionRepo, batchRepo, recordRepo, rateFinder, 3, validator)
+
+	mockTreasuryService.On("SupportsCurrency", entities.EUR).Return(true).Maybe()
+	mockTreasuryService.On("SupportsCurrency", mock.Anything).Return(false).Maybe()
+	mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, mock.Anything).
+		Return(nil, errors.New("no suitable exchange rate found within 6 months")).Maybe()
+	mockTreasuryService.On("FetchExchangeRates", mock.Anything, entities.USD, entities.EUR, mock.Anything, mock.Anything).
+		Return([]entities.ExchangeRate{}, nil).Maybe()
+
+	// Q1 2024 has a rate; a 2022 transaction inside the range has none
+	rate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.9, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+	require.NoError(t, exchangeRateRepo.Save(rate))
+
+	save := func(date time.Time, amount float64) {
+		require.NoError(t, transactionRepo.Save(&entities.Transaction{
+			ID:          uuid.New(),
+			Description: "Quarter purchase",
+			Date:        date,
+			Amount:      entities.NewMoney(amount),
+		}))
+	}
+	for day := 1; day <= 60; day++ {
+		save(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).AddDate(0, 0, day-1), 10)
+	}
+	save(time.Date(2024, 3, 31, 23, 0, 0, 0, time.UTC), 20) // Last day is inclusive
+	save(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), 30)   // Outside the range
+
+	t.Run("Converts every transaction in range", func(t *testing.T) {
+		// Act
+		started, err := usecase.Start(&dto.StartBatchConversionRequest{
+			TargetCurrency: entities.EUR,
+			From:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:             time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entities.BatchStatusPending, started.Status)
+		usecase.Wait()
+
+		// Assert
+		status, err := usecase.GetStatus(started.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.BatchStatusCompleted, status.Status)
+		assert.Equal(t, 61, status.Total)
+		assert.Equal(t, 61, status.Processed)
+		assert.Equal(t, 61, status.Succeeded)
+		assert.Equal(t, 0, status.Failed)
+		assert.Equal(t, 100.0, status.ProgressPercent)
+		assert.NotNil(t, status.CompletedAt)
+
+		records, err := usecase.GetRecords(started.ID, 1, 100)
+		require.NoError(t, err)
+		assert.Equal(t, int64(61), records.Total)
+		assert.Equal(t, 9.0, records.Data[0].ConvertedAmount)
+		assert.Equal(t, 18.0, records.Data[60].ConvertedAmount)
+	})
+
+	t.Run("Transactions without a rate are counted as failed", func(t *testing.T) {
+		// Arrange
+		save(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC), 15)
+
+		// Act
+		started, err := usecase.Start(&dto.StartBatchConversionRequest{
+			TargetCurrency: entities.EUR,
+			From:           time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:             time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		usecase.Wait()
+
+		// Assert
+		status, err := usecase.GetStatus(started.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.BatchStatusCompleted, status.Status)
+		assert.Equal(t, 6, status.Total)
+		assert.Equal(t, 5, status.Succeeded)
+		assert.Equal(t, 1, status.Failed)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		_, err := usecase.Start(&dto.StartBatchConversionRequest{
+			TargetCurrency: entities.EUR,
+			From:           time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			To:             time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.ErrorContains(t, err, "validation failed")
+
+		_, err = usecase.Start(&dto.StartBatchConversionRequest{
+			TargetCurrency: entities.JPY,
+			From:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:             time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.ErrorContains(t, err, "unsupported target currency")
+	})
+
+	t.Run("Unknown batch", func(t *testing.T) {
+		_, err := usecase.GetStatus(uuid.New())
+		assert.ErrorContains(t, err, "not found")
+
+		_, err = usecase.GetRecords(uuid.New(), 1, 20)
+		assert.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestBatchConversionUseCase_PrefetchesRangeRates(t *testing.T) {
+	// Arrange
+	validator := validation.NewValidator()
+	transactionRepo := memory.NewTransactionRepository()
+	exchangeRateRepo := memory.NewExchangeRateRepository()
+	mockTreasuryService := new(mocks.MockTreasuryService)
+	rateFinder := usecases.NewConvertTransactionUseCase(transactionRepo, exchangeRateRepo, memory.NewQuoteRepository(), mockTreasuryService, nil, validator)
+	usecase := usecases.NewBatchConversionUseCase(transactionRepo, memory.NewConversionBatchRepository(), memory.NewConversionRecordRepository(), rateFinder, 2, validator)
+
+	december, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.9, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+	march, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.8, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+	require.NoError(t, exchangeRateRepo.Save(december)) // Already stored, so only March is added
+
+	mockTreasuryService.On("SupportsCurrency", entities.EUR).Return(true)
+	mockTreasuryService.On("FetchExchangeRates", mock.Anything, entities.USD, entities.EUR,
+		time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)).
+		Return([]entities.ExchangeRate{*march, *december}, nil).Once()
+
+	for _, date := range []time.Time{
+		time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC),
+	} {
+		require.NoError(t, transactionRepo.Save(&entities.Transaction{ID: uuid.New(), Description: "Purchase", Date: date, Amount: entities.NewMoney(10)}))
+	}
+
+	// Act
+	started, err := usecase.Start(&dto.StartBatchConversionRequest{
+		TargetCurrency: entities.EUR,
+		From:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	usecase.Wait()
+
+	// Assert - one range call answers every purchase date and no per-date lookup is made
+	status, err := usecase.GetStatus(started.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, status.Succeeded)
+	mockTreasuryService.AssertExpectations(t)
+	mockTreasuryService.AssertNotCalled(t, "FetchExchangeRate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	stored, err := exchangeRateRepo.FindRateForConversion(entities.USD, entities.EUR, time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), entities.RateWindow{})
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, 0.8, stored.Rate)
+	summary, err := exchangeRateRepo.SummarizeByCurrency()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), summary[0].Rates, "the stored December rate is not duplicated")
+}
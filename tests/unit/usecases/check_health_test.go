@@ -0,0 +1,93 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pingerFunc adapts a function to the Pinger interface
+type pingerFunc func(ctx context.Context) error
+
+func (f pingerFunc) Ping(ctx context.Context) error { return f(ctx) }
+
+// breakerStub reports a fixed circuit breaker state
+type breakerStub struct {
+	state    string
+	failures int
+	retryAt  *time.Time
+}
+
+func (b breakerStub) State() string            { return b.state }
+func (b breakerStub) ConsecutiveFailures() int { return b.failures }
+func (b breakerStub) RetryAt() *time.Time      { return b.retryAt }
+
+func TestCheckHealthUseCase_Execute(t *testing.T) {
+	startedAt := time.Now().Add(-90 * time.Second)
+
+	t.Run("All dependencies up", func(t *testing.T) {
+		// Arrange
+		usecase := usecases.NewCheckHealthUseCase("1.2.3", startedAt,
+			usecases.HealthDependency{Name: "database", Pinger: pingerFunc(func(context.Context) error { return nil })},
+		)
+
+		// Act
+		response := usecase.Execute(context.Background())
+
+		// Assert
+		assert.Equal(t, dto.HealthStatusHealthy, response.Status)
+		assert.Equal(t, "1.2.3", response.Version)
+		assert.GreaterOrEqual(t, response.UptimeSeconds, int64(90))
+		assert.WithinDuration(t, time.Now(), response.Timestamp, time.Second)
+		require.Contains(t, response.Dependencies, "database")
+		assert.Equal(t, dto.DependencyStatusUp, response.Dependencies["database"].Status)
+		assert.Empty(t, response.Dependencies["database"].Error)
+	})
+
+	t.Run("Dependency down makes the service unhealthy", func(t *testing.T) {
+		// Arrange
+		usecase := usecases.NewCheckHealthUseCase("1.2.3", startedAt,
+			usecases.HealthDependency{Name: "database", Pinger: pingerFunc(func(context.Context) error {
+				return errors.New("connection refused")
+			})},
+		)
+
+		// Act
+		response := usecase.Execute(context.Background())
+
+		// Assert
+		assert.Equal(t, dto.HealthStatusUnhealthy, response.Status)
+		assert.Equal(t, dto.DependencyStatusDown, response.Dependencies["database"].Status)
+		assert.Equal(t, "connection refused", response.Dependencies["database"].Error)
+	})
+
+	t.Run("Reports circuit breakers without affecting the status", func(t *testing.T) {
+		// Arrange
+		retryAt := time.Now().Add(time.Minute)
+		usecase := usecases.NewCheckHealthUseCase("1.2.3", startedAt).
+			WithCircuitBreaker("treasury", breakerStub{state: "open", failures: 5, retryAt: &retryAt})
+
+		// Act
+		response := usecase.Execute(context.Background())
+
+		// Assert
+		assert.Equal(t, dto.HealthStatusHealthy, response.Status)
+		require.Contains(t, response.CircuitBreakers, "treasury")
+		assert.Equal(t, "open", response.CircuitBreakers["treasury"].State)
+		assert.Equal(t, 5, response.CircuitBreakers["treasury"].ConsecutiveFailures)
+		assert.Equal(t, &retryAt, response.CircuitBreakers["treasury"].RetryAt)
+	})
+
+	t.Run("No dependencies", func(t *testing.T) {
+		response := usecases.NewCheckHealthUseCase("1.2.3", startedAt).Execute(context.Background())
+
+		assert.Equal(t, dto.HealthStatusHealthy, response.Status)
+		assert.Empty(t, response.Dependencies)
+	})
+}
@@ -0,0 +1,124 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAmountUseCase_Execute(t *testing.T) {
+	// Setup
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockExchangeRateRepo := new(mocks.MockExchangeRateRepository)
+	mockQuoteRepo := new(mocks.MockQuoteRepository)
+	mockTreasuryService := new(mocks.MockTreasuryService)
+	validator := validation.NewValidator()
+	rateFinder := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, mockQuoteRepo, mockTreasuryService, nil, validator)
+	usecase := usecases.NewConvertAmountUseCase(rateFinder, rateFinder, nil, validator)
+
+	mockTreasuryService.On("SupportsCurrency", entities.BRL).Return(true).Maybe()
+	date := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Successful conversion", func(t *testing.T) {
+		// Arrange
+		exchangeRate, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 5.00, date.AddDate(0, -2, 0), clock.System())
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, date).Return(exchangeRate, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertAmountRequest{
+			Amount:         12.345,
+			TargetCurrency: entities.BRL,
+			Date:           date,
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 12.35, response.Amount) // Rounded to cents before conversion
+		assert.Equal(t, 61.75, response.ConvertedAmount)
+		assert.Equal(t, exchangeRate.EffectiveDate, response.EffectiveDate)
+		mockExchangeRateRepo.AssertExpectations(t)
+	})
+
+	t.Run("No exchange rate available", func(t *testing.T) {
+		// Arrange
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, date).Return(nil, nil).Once()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.BRL, date).Return(nil, fmt.Errorf("no suitable exchange rate found within 6 months")).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertAmountRequest{
+			Amount:         10,
+			TargetCurrency: entities.BRL,
+			Date:           date,
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "failed to find exchange rate")
+	})
+
+	t.Run("USD target is rejected", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertAmountRequest{
+			Amount:         10,
+			TargetCurrency: entities.USD,
+			Date:           date,
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, errs.ErrUnsupportedCurrency)
+	})
+
+	t.Run("Invalid amount", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertAmountRequest{
+			Amount:         -5,
+			TargetCurrency: entities.BRL,
+			Date:           date,
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "validation failed")
+	})
+
+	t.Run("Margin for API key is applied and reported", func(t *testing.T) {
+		// Arrange
+		margins, err := usecases.NewMarginPolicy(0, map[string]int{"partner": 100})
+		require.NoError(t, err)
+		pricedUsecase := usecases.NewConvertAmountUseCase(rateFinder, rateFinder, margins, validator)
+
+		exchangeRate, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 5.00, date.AddDate(0, -1, 0), clock.System())
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, date).Return(exchangeRate, nil).Once()
+
+		// Act
+		response, err := pricedUsecase.Execute(context.Background(), &dto.ConvertAmountRequest{
+			Amount:         100,
+			TargetCurrency: entities.BRL,
+			Date:           date,
+			APIKey:         "partner",
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 5.00, response.RawExchangeRate)
+		assert.Equal(t, 100, response.MarginBps)
+		assert.Equal(t, 4.95, response.ExchangeRate)
+		assert.Equal(t, 495.00, response.ConvertedAmount)
+	})
+}
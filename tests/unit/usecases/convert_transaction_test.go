@@ -1,19 +1,23 @@
 package usecases_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
 	"time"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
 	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
 	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,9 +25,10 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 	// Setup
 	mockTransactionRepo := new(mocks.MockTransactionRepository)
 	mockExchangeRateRepo := new(mocks.MockExchangeRateRepository)
+	mockQuoteRepo := new(mocks.MockQuoteRepository)
 	mockTreasuryService := new(mocks.MockTreasuryService)
-	validator := validator.New()
-	usecase := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, mockTreasuryService, validator)
+	validator := validation.NewValidator()
+	usecase := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, mockQuoteRepo, mockTreasuryService, nil, validator)
 
 	t.Run("Successful currency conversion", func(t *testing.T) {
 		// Arrange
@@ -48,7 +53,7 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, transaction.Date).Return(&exchangeRate, nil).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.NoError(t, err)
@@ -71,7 +76,7 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 
 	t.Run("Nil request", func(t *testing.T) {
 		// Act
-		response, err := usecase.Execute(nil)
+		response, err := usecase.Execute(context.Background(), nil)
 
 		// Assert
 		assert.Error(t, err)
@@ -88,7 +93,7 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 		}
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -104,7 +109,7 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 		}
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -123,7 +128,7 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 		mockTransactionRepo.On("GetByID", request.TransactionID).Return(nil, nil).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -145,7 +150,7 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 		mockTransactionRepo.On("GetByID", request.TransactionID).Return(nil, repositoryError).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -156,6 +161,23 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 		mockTransactionRepo.AssertExpectations(t)
 	})
 
+	t.Run("Invalid target currency - unknown currency code", func(t *testing.T) {
+		// Arrange
+		request := &dto.ConvertTransactionRequest{
+			TransactionID:  uuid.New(),
+			TargetCurrency: "XYZ", // Well-formed but not a known currency
+		}
+
+		// Act
+		response, err := usecase.Execute(context.Background(), request)
+
+		// Assert - rejected by the currency tag before touching repositories
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "validation failed")
+		assert.Contains(t, err.Error(), "'currency' tag")
+	})
+
 	t.Run("Invalid target currency - USD to USD conversion", func(t *testing.T) {
 		// Arrange
 		transaction := fixtures.ValidTransaction()
@@ -167,7 +189,7 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 		mockTransactionRepo.On("GetByID", request.TransactionID).Return(&transaction, nil).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -190,10 +212,10 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 		// Mock exchange rate repository to return nil (no rate found)
 		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, transaction.Date).Return(nil, nil).Once()
 		// Mock treasury service to return error (no rate found)
-		mockTreasuryService.On("FetchExchangeRate", entities.USD, entities.BRL, transaction.Date).Return(nil, fmt.Errorf("no suitable exchange rate found within 6 months")).Once()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.BRL, transaction.Date).Return(nil, fmt.Errorf("no suitable exchange rate found within 6 months")).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -205,6 +227,131 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 		mockTreasuryService.AssertExpectations(t)
 	})
 
+	t.Run("Interpolate mode uses surrounding rates", func(t *testing.T) {
+		// Arrange
+		transaction := fixtures.ValidTransaction()
+		transaction.Date = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+		request := &dto.ConvertTransactionRequest{
+			TransactionID:  transaction.ID,
+			TargetCurrency: entities.BRL,
+			Mode:           dto.ConversionModeInterpolate,
+		}
+
+		before, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 5.00, transaction.Date.AddDate(-1, 0, 0), clock.System())
+		after, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 6.00, transaction.Date.AddDate(0, 1, 0), clock.System())
+
+		mockTransactionRepo.On("GetByID", request.TransactionID).Return(&transaction, nil).Once()
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, transaction.Date).Return(nil, nil).Once()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.BRL, transaction.Date).Return(nil, errs.Newf(errs.ErrRateUnavailable, "no suitable exchange rate found within 6 months")).Once()
+		mockExchangeRateRepo.On("FindSurroundingRates", entities.USD, entities.BRL, transaction.Date, 12).Return(before, after, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), request)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, response.Interpolated)
+		assert.Equal(t, []time.Time{before.EffectiveDate, after.EffectiveDate}, response.RateBounds)
+		assert.Equal(t, transaction.Date, response.EffectiveDate)
+		assert.True(t, response.ExchangeRate > 5.00 && response.ExchangeRate < 6.00)
+
+		mockTransactionRepo.AssertExpectations(t)
+		mockExchangeRateRepo.AssertExpectations(t)
+		mockTreasuryService.AssertExpectations(t)
+	})
+
+	t.Run("Interpolate mode without surrounding rates", func(t *testing.T) {
+		// Arrange
+		transaction := fixtures.ValidTransaction()
+		request := &dto.ConvertTransactionRequest{
+			TransactionID:  transaction.ID,
+			TargetCurrency: entities.BRL,
+			Mode:           dto.ConversionModeInterpolate,
+		}
+
+		mockTransactionRepo.On("GetByID", request.TransactionID).Return(&transaction, nil).Once()
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, transaction.Date).Return(nil, nil).Once()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.BRL, transaction.Date).Return(nil, errs.Newf(errs.ErrRateUnavailable, "no suitable exchange rate found within 6 months")).Once()
+		mockExchangeRateRepo.On("FindSurroundingRates", entities.USD, entities.BRL, transaction.Date, 12).Return(nil, nil, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), request)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "within 6 months")
+	})
+
+	t.Run("Interpolate mode returns provider failures", func(t *testing.T) {
+		// Arrange
+		transaction := fixtures.ValidTransaction()
+		request := &dto.ConvertTransactionRequest{
+			TransactionID:  transaction.ID,
+			TargetCurrency: entities.BRL,
+			Mode:           dto.ConversionModeInterpolate,
+		}
+		outage := errors.New("Treasury API returned status 503")
+
+		transactionRepo := new(mocks.MockTransactionRepository)
+		exchangeRateRepo := new(mocks.MockExchangeRateRepository)
+		treasuryService := new(mocks.MockTreasuryService)
+		transactionRepo.On("GetByID", request.TransactionID).Return(&transaction, nil).Once()
+		exchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, transaction.Date).Return(nil, nil).Once()
+		treasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.BRL, transaction.Date).Return(nil, outage).Once()
+		usecase := usecases.NewConvertTransactionUseCase(transactionRepo, exchangeRateRepo, mockQuoteRepo, treasuryService, nil, validator)
+
+		// Act
+		response, err := usecase.Execute(context.Background(), request)
+
+		// Assert
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, outage)
+		exchangeRateRepo.AssertNotCalled(t, "FindSurroundingRates", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid conversion mode", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertTransactionRequest{
+			TransactionID:  uuid.New(),
+			TargetCurrency: entities.BRL,
+			Mode:           "guess",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "validation failed")
+	})
+
+	t.Run("Expired quote is rejected", func(t *testing.T) {
+		// Arrange
+		transaction := fixtures.ValidTransaction()
+		exchangeRate, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 5.00, transaction.Date, clock.System())
+		quote, _ := entities.NewRateQuote(exchangeRate, transaction.Date, time.Minute, clock.System())
+		quote.ExpiresAt = time.Now().Add(-time.Second)
+
+		request := &dto.ConvertTransactionRequest{
+			TransactionID:  transaction.ID,
+			TargetCurrency: entities.BRL,
+			QuoteID:        &quote.ID,
+		}
+
+		mockTransactionRepo.On("GetByID", request.TransactionID).Return(&transaction, nil).Once()
+		mockQuoteRepo.On("GetByID", quote.ID).Return(quote, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), request)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "expired")
+
+		mockTransactionRepo.AssertExpectations(t)
+		mockQuoteRepo.AssertExpectations(t)
+	})
+
 	t.Run("Exchange rate repository error", func(t *testing.T) {
 		// Arrange
 		transaction := fixtures.ValidTransaction()
@@ -218,7 +365,7 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, transaction.Date).Return(nil, repositoryError).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -250,7 +397,7 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, transaction.Date).Return(&invalidExchangeRate, nil).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -294,7 +441,7 @@ func TestConvertTransactionUseCase_Execute(t *testing.T) {
 				mockExchangeRateRepo.On("FindRateForConversion", entities.USD, tc.targetCurrency, transaction.Date).Return(&exchangeRate, nil).Once()
 
 				// Act
-				response, err := usecase.Execute(request)
+				response, err := usecase.Execute(context.Background(), request)
 
 				// Assert
 				assert.NoError(t, err)
@@ -318,13 +465,316 @@ func TestConvertTransactionUseCase_Constructor(t *testing.T) {
 		// Arrange
 		mockTransactionRepo := new(mocks.MockTransactionRepository)
 		mockExchangeRateRepo := new(mocks.MockExchangeRateRepository)
+		mockQuoteRepo := new(mocks.MockQuoteRepository)
 		mockTreasuryService := new(mocks.MockTreasuryService)
-		validator := validator.New()
+		validator := validation.NewValidator()
 
 		// Act
-		usecase := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, mockTreasuryService, validator)
+		usecase := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, mockQuoteRepo, mockTreasuryService, nil, validator)
 
 		// Assert
 		assert.NotNil(t, usecase)
 	})
 }
+
+func TestConvertTransactionUseCase_CrossRates(t *testing.T) {
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockExchangeRateRepo := new(mocks.MockExchangeRateRepository)
+	mockTreasuryService := new(mocks.MockTreasuryService)
+	validator := validation.NewValidator()
+	mockTreasuryService.On("SupportsCurrency", entities.EUR).Return(true).Maybe()
+
+	transaction := fixtures.TransactionWithAmount(100.00)
+	transaction.Date = time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+	transaction.Currency = entities.EUR
+	request := &dto.ConvertTransactionRequest{TransactionID: transaction.ID, TargetCurrency: entities.BRL}
+
+	t.Run("EUR transaction converts through the USD legs", func(t *testing.T) {
+		// Arrange
+		usecase := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, new(mocks.MockQuoteRepository), mockTreasuryService, nil, validator).
+			WithCrossRates(true)
+		usdEUR, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.80, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), clock.System())
+		usdBRL, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 5.00, time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), clock.System())
+		mockTransactionRepo.On("GetByID", transaction.ID).Return(&transaction, nil).Once()
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.EUR, transaction.Date).Return(usdEUR, nil).Once()
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, transaction.Date).Return(usdBRL, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), request)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, entities.EUR, response.SourceCurrency)
+		assert.InDelta(t, 6.25, response.ExchangeRate, 0.000001)
+		assert.Equal(t, 625.00, response.ConvertedAmount)
+		assert.Equal(t, usdEUR.EffectiveDate, response.EffectiveDate)
+		mockExchangeRateRepo.AssertExpectations(t)
+	})
+
+	t.Run("EUR transaction is rejected with cross rates disabled", func(t *testing.T) {
+		// Arrange
+		usecase := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, new(mocks.MockQuoteRepository), mockTreasuryService, nil, validator)
+		mockTransactionRepo.On("GetByID", transaction.ID).Return(&transaction, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), request)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "cannot convert EUR transactions")
+	})
+
+	t.Run("Batches report transactions breaking the conversion rules in their items", func(t *testing.T) {
+		// Arrange - a EUR transaction cannot be converted to EUR, nor from a currency without cross rates;
+		// only the USD transaction looks up a rate
+		sameCurrency := transaction
+		sameCurrency.ID = uuid.New()
+		usd := fixtures.TransactionWithAmount(50.00)
+		usd.Date = transaction.Date
+		usdEUR, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.80, time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), clock.System())
+		mockTransactionRepo.On("GetByID", sameCurrency.ID).Return(&sameCurrency, nil)
+		mockTransactionRepo.On("GetByID", usd.ID).Return(&usd, nil)
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.EUR, usd.Date).Return(usdEUR, nil).Twice()
+		batch := &dto.ConvertTransactionsBatchRequest{TransactionIDs: []uuid.UUID{sameCurrency.ID, usd.ID}, TargetCurrency: entities.EUR}
+
+		for _, tc := range []struct {
+			name       string
+			crossRates bool
+			message    string
+		}{
+			{"Same currency", true, "cannot convert EUR transaction to EUR"},
+			{"Cross rates disabled", false, "cannot convert EUR transactions"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				usecase := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, new(mocks.MockQuoteRepository), mockTreasuryService, nil, validator).
+					WithCrossRates(tc.crossRates)
+
+				// Act
+				response, err := usecase.ExecuteBatch(context.Background(), batch)
+
+				// Assert
+				require.NoError(t, err)
+				assert.Equal(t, 1, response.Converted)
+				assert.Equal(t, 1, response.Failed)
+				assert.Contains(t, response.Data[0].Error, tc.message)
+				assert.Nil(t, response.Data[0].ConvertTransactionResponse)
+				assert.Empty(t, response.Data[1].Error)
+				assert.Equal(t, 40.00, response.Data[1].ConvertedAmount)
+			})
+		}
+	})
+}
+
+func TestConvertTransactionUseCase_Rounding(t *testing.T) {
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockExchangeRateRepo := new(mocks.MockExchangeRateRepository)
+	validator := validation.NewValidator()
+
+	transaction := fixtures.TransactionWithAmount(0.25)
+	transaction.Date = time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+	exchangeRate, _ := entities.NewExchangeRate(entities.USD, entities.GBP, 0.5, time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), clock.System())
+	request := &dto.ConvertTransactionRequest{TransactionID: transaction.ID, TargetCurrency: entities.GBP}
+
+	testCases := []struct {
+		name     string
+		rounding entities.RoundingMode
+		expected float64
+	}{
+		{"Half up by default", "", 0.13},
+		{"Half even", entities.RoundHalfEven, 0.12},
+		{"Down", entities.RoundDown, 0.12},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			usecase := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, new(mocks.MockQuoteRepository), new(mocks.MockTreasuryService), nil, validator).
+				WithRounding(tc.rounding)
+			mockTransactionRepo.On("GetByID", transaction.ID).Return(&transaction, nil).Once()
+			mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.GBP, transaction.Date).Return(exchangeRate, nil).Once()
+
+			// Act
+			response, err := usecase.Execute(context.Background(), request)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, response.ConvertedAmount)
+		})
+	}
+
+	t.Run("Zero-decimal currencies round to whole units", func(t *testing.T) {
+		// Arrange
+		usecase := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, new(mocks.MockQuoteRepository), new(mocks.MockTreasuryService), nil, validator)
+		yenRate, _ := entities.NewExchangeRate(entities.USD, entities.JPY, 151.234, exchangeRate.EffectiveDate, clock.System())
+		mockTransactionRepo.On("GetByID", transaction.ID).Return(&transaction, nil).Once()
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.JPY, transaction.Date).Return(yenRate, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertTransactionRequest{TransactionID: transaction.ID, TargetCurrency: entities.JPY})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 38.0, response.ConvertedAmount, "0.25 USD is 37.8085 JPY")
+	})
+}
+
+func TestConvertTransactionUseCase_StaleFallback(t *testing.T) {
+	transaction := fixtures.ValidTransaction()
+	transaction.Date = time.Date(2024, 9, 10, 0, 0, 0, 0, time.UTC)
+	staleWindow, err := entities.NewRateWindow(12)
+	require.NoError(t, err)
+	missingRate := errs.Newf(errs.ErrRateUnavailable, "no suitable exchange rate found within 6 months")
+
+	// setup returns a use case whose strict lookup for BRL finds nothing
+	setup := func(byDefault bool) (*usecases.ConvertTransactionUseCase, *mocks.MockExchangeRateRepository, *mocks.MockTreasuryService) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockExchangeRateRepo := new(mocks.MockExchangeRateRepository)
+		mockTreasuryService := new(mocks.MockTreasuryService)
+		mockTransactionRepo.On("GetByID", transaction.ID).Return(&transaction, nil).Once()
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, transaction.Date).Return(nil, nil).Once()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.BRL, transaction.Date).Return(nil, missingRate).Once()
+		usecase := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, new(mocks.MockQuoteRepository), mockTreasuryService, nil, validation.NewValidator()).
+			WithStaleFallback(byDefault, staleWindow)
+		return usecase, mockExchangeRateRepo, mockTreasuryService
+	}
+
+	t.Run("Stale mode converts with the nearest older stored rate", func(t *testing.T) {
+		// Arrange
+		usecase, mockExchangeRateRepo, _ := setup(false)
+		older, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 5.00, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), clock.System())
+		mockExchangeRateRepo.On("FindSurroundingRates", entities.USD, entities.BRL, transaction.Date, 12).Return(older, nil, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertTransactionRequest{
+			TransactionID:  transaction.ID,
+			TargetCurrency: entities.BRL,
+			Mode:           dto.ConversionModeStale,
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, response.RateStale)
+		assert.Equal(t, older.EffectiveDate, response.EffectiveDate, "the actual effective date is reported")
+		assert.Equal(t, 5.00, response.ExchangeRate)
+		assert.False(t, response.Interpolated)
+		mockExchangeRateRepo.AssertExpectations(t)
+	})
+
+	t.Run("Configured fallback fetches an older published rate when none is stored", func(t *testing.T) {
+		// Arrange
+		usecase, mockExchangeRateRepo, mockTreasuryService := setup(true)
+		published, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 4.90, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), clock.System())
+		mockExchangeRateRepo.On("FindSurroundingRates", entities.USD, entities.BRL, transaction.Date, 12).Return(nil, nil, nil).Once()
+		mockTreasuryService.On("FetchExchangeRates", mock.Anything, entities.USD, entities.BRL, staleWindow.Start(transaction.Date), transaction.Date).
+			Return([]entities.ExchangeRate{*published}, nil).Once()
+		mockExchangeRateRepo.On("Save", mock.AnythingOfType("*entities.ExchangeRate")).Return(nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertTransactionRequest{TransactionID: transaction.ID, TargetCurrency: entities.BRL})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, response.RateStale)
+		assert.Equal(t, published.EffectiveDate, response.EffectiveDate)
+		mockExchangeRateRepo.AssertExpectations(t)
+		mockTreasuryService.AssertExpectations(t)
+	})
+
+	t.Run("Strict mode still fails when stale rates are the default", func(t *testing.T) {
+		// Arrange
+		usecase, mockExchangeRateRepo, _ := setup(true)
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertTransactionRequest{
+			TransactionID:  transaction.ID,
+			TargetCurrency: entities.BRL,
+			Mode:           dto.ConversionModeStrict,
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		mockExchangeRateRepo.AssertNotCalled(t, "FindSurroundingRates", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Provider failures are not hidden behind an older rate", func(t *testing.T) {
+		// Arrange
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockExchangeRateRepo := new(mocks.MockExchangeRateRepository)
+		mockTreasuryService := new(mocks.MockTreasuryService)
+		outage := errors.New("Treasury API returned status 503")
+		mockTransactionRepo.On("GetByID", transaction.ID).Return(&transaction, nil).Once()
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, transaction.Date).Return(nil, nil).Once()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.BRL, transaction.Date).Return(nil, outage).Once()
+		usecase := usecases.NewConvertTransactionUseCase(mockTransactionRepo, mockExchangeRateRepo, new(mocks.MockQuoteRepository), mockTreasuryService, nil, validation.NewValidator()).
+			WithStaleFallback(true, staleWindow)
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertTransactionRequest{TransactionID: transaction.ID, TargetCurrency: entities.BRL})
+
+		// Assert
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, outage)
+		mockExchangeRateRepo.AssertNotCalled(t, "FindSurroundingRates", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("A failed fetch of older rates is returned", func(t *testing.T) {
+		// Arrange
+		usecase, mockExchangeRateRepo, mockTreasuryService := setup(true)
+		outage := errors.New("Treasury API returned status 503")
+		mockExchangeRateRepo.On("FindSurroundingRates", entities.USD, entities.BRL, transaction.Date, 12).Return(nil, nil, nil).Once()
+		mockTreasuryService.On("FetchExchangeRates", mock.Anything, entities.USD, entities.BRL, staleWindow.Start(transaction.Date), transaction.Date).
+			Return(nil, outage).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertTransactionRequest{TransactionID: transaction.ID, TargetCurrency: entities.BRL})
+
+		// Assert
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, outage)
+		assert.NotErrorIs(t, err, errs.ErrRateUnavailable)
+	})
+
+	t.Run("Without an older rate the strict error is returned", func(t *testing.T) {
+		// Arrange
+		usecase, mockExchangeRateRepo, mockTreasuryService := setup(false)
+		mockExchangeRateRepo.On("FindSurroundingRates", entities.USD, entities.BRL, transaction.Date, 12).Return(nil, nil, nil).Once()
+		mockTreasuryService.On("FetchExchangeRates", mock.Anything, entities.USD, entities.BRL, staleWindow.Start(transaction.Date), transaction.Date).
+			Return([]entities.ExchangeRate{}, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ConvertTransactionRequest{
+			TransactionID:  transaction.ID,
+			TargetCurrency: entities.BRL,
+			Mode:           dto.ConversionModeStale,
+		})
+
+		// Assert
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, missingRate)
+	})
+}
+
+func TestConvertTransactionUseCase_FallbackProviderRates(t *testing.T) {
+	t.Run("Rates from fallback providers are used but not stored", func(t *testing.T) {
+		// Arrange
+		mockExchangeRateRepo := new(mocks.MockExchangeRateRepository)
+		mockTreasuryService := new(mocks.MockTreasuryService)
+		usecase := usecases.NewConvertTransactionUseCase(new(mocks.MockTransactionRepository), mockExchangeRateRepo, new(mocks.MockQuoteRepository), mockTreasuryService, nil, validation.NewValidator())
+		date := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+		ecbRate, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.9295, date, clock.System())
+		ecbRate.Source = "ecb"
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.EUR, date).Return(nil, nil).Once()
+		mockTreasuryService.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, date).Return(ecbRate, nil).Once()
+
+		// Act
+		exchangeRate, err := usecase.FindExchangeRate(context.Background(), entities.EUR, date)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0.9295, exchangeRate.Rate)
+		mockExchangeRateRepo.AssertNotCalled(t, "Save", mock.Anything)
+		mockExchangeRateRepo.AssertExpectations(t)
+	})
+}
@@ -2,14 +2,18 @@ package usecases_test
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
 	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -19,7 +23,7 @@ import (
 func TestCreateTransactionUseCase_Execute(t *testing.T) {
 	// Setup
 	mockRepo := new(mocks.MockTransactionRepository)
-	validator := validator.New()
+	validator := validation.NewValidator()
 	usecase := usecases.NewCreateTransactionUseCase(mockRepo, validator)
 
 	t.Run("Successful transaction creation", func(t *testing.T) {
@@ -78,6 +82,24 @@ func TestCreateTransactionUseCase_Execute(t *testing.T) {
 		assert.Contains(t, err.Error(), "validation failed")
 	})
 
+	t.Run("Non-USD currency without cross rates", func(t *testing.T) {
+		// Arrange
+		request := &dto.CreateTransactionRequest{
+			Description: "Paris hotel",
+			Date:        time.Now(),
+			Amount:      99.99,
+			Currency:    entities.EUR,
+		}
+
+		// Act
+		response, err := usecase.Execute(request)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "unsupported transaction currency: EUR")
+	})
+
 	t.Run("Invalid request - description too long", func(t *testing.T) {
 		// Arrange
 		request := &dto.CreateTransactionRequest{
@@ -194,7 +216,7 @@ func TestCreateTransactionUseCase_Constructor(t *testing.T) {
 	t.Run("Valid constructor", func(t *testing.T) {
 		// Arrange
 		mockRepo := new(mocks.MockTransactionRepository)
-		validator := validator.New()
+		validator := validation.NewValidator()
 
 		// Act
 		usecase := usecases.NewCreateTransactionUseCase(mockRepo, validator)
@@ -214,7 +236,7 @@ func TestCreateTransactionUseCase_DTOConversion(t *testing.T) {
 		}
 
 		// Act
-		entity := request.ToEntity()
+		entity := request.ToEntity(clock.System())
 
 		// Assert
 		assert.NotNil(t, entity)
@@ -246,3 +268,135 @@ func TestCreateTransactionUseCase_DTOConversion(t *testing.T) {
 		assert.True(t, entity.CreatedAt.Equal(response.CreatedAt))
 	})
 }
+
+func TestCreateTransactionUseCase_ExecuteIdempotent(t *testing.T) {
+	request := &dto.CreateTransactionRequest{
+		Description: "Test Purchase",
+		Date:        time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		Amount:      99.99,
+	}
+
+	setup := func() (*usecases.CreateTransactionUseCase, repositories.TransactionRepository, repositories.IdempotencyKeyRepository) {
+		transactionRepo := memory.NewTransactionRepository()
+		keyRepo := memory.NewIdempotencyKeyRepository()
+		usecase := usecases.NewCreateTransactionUseCase(transactionRepo, validation.NewValidator()).WithIdempotency(keyRepo, time.Hour)
+		return usecase, transactionRepo, keyRepo
+	}
+
+	t.Run("Retries with the same key return the original transaction", func(t *testing.T) {
+		// Arrange
+		usecase, transactionRepo, _ := setup()
+
+		// Act
+		first, replayedFirst, err := usecase.ExecuteIdempotent("", "order-1", request)
+		require.NoError(t, err)
+		second, replayedSecond, err := usecase.ExecuteIdempotent("", "order-1", request)
+		require.NoError(t, err)
+
+		// Assert
+		assert.False(t, replayedFirst)
+		assert.True(t, replayedSecond)
+		assert.Equal(t, first.ID, second.ID)
+		assert.True(t, first.CreatedAt.Equal(second.CreatedAt))
+		count, err := transactionRepo.Count()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Keys are scoped per caller", func(t *testing.T) {
+		usecase, transactionRepo, _ := setup()
+
+		first, _, err := usecase.ExecuteIdempotent("token:a", "order-1", request)
+		require.NoError(t, err)
+		second, replayed, err := usecase.ExecuteIdempotent("token:b", "order-1", request)
+		require.NoError(t, err)
+
+		assert.False(t, replayed)
+		assert.NotEqual(t, first.ID, second.ID)
+		count, _ := transactionRepo.Count()
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("Reusing a key with a different body is rejected", func(t *testing.T) {
+		usecase, _, _ := setup()
+		_, _, err := usecase.ExecuteIdempotent("", "order-1", request)
+		require.NoError(t, err)
+
+		changed := *request
+		changed.Amount = 10
+		_, _, err = usecase.ExecuteIdempotent("", "order-1", &changed)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "different request body")
+	})
+
+	t.Run("A key still in progress is a conflict; an abandoned one is reclaimed", func(t *testing.T) {
+		// Arrange - a pending key as left by a concurrent or crashed first attempt
+		usecase, transactionRepo, keyRepo := setup()
+		probe, _, err := usecase.ExecuteIdempotent("", "probe", request)
+		require.NoError(t, err)
+		stored, err := keyRepo.Find("", "probe")
+		require.NoError(t, err)
+
+		pending, err := entities.NewIdempotencyKey("", "order-1", stored.RequestHash, time.Hour, clock.System())
+		require.NoError(t, err)
+		reserved, err := keyRepo.Reserve(pending)
+		require.NoError(t, err)
+		require.True(t, reserved)
+
+		// Act & Assert
+		_, _, err = usecase.ExecuteIdempotent("", "order-1", request)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "still in progress")
+
+		abandoned, err := entities.NewIdempotencyKey("", "order-2", stored.RequestHash, time.Hour, clock.System())
+		require.NoError(t, err)
+		abandoned.CreatedAt = time.Now().Add(-5 * time.Minute)
+		_, err = keyRepo.Reserve(abandoned)
+		require.NoError(t, err)
+
+		response, replayed, err := usecase.ExecuteIdempotent("", "order-2", request)
+		require.NoError(t, err)
+		assert.False(t, replayed)
+		assert.NotEqual(t, probe.ID, response.ID)
+		count, _ := transactionRepo.Count()
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("A failed attempt releases the key", func(t *testing.T) {
+		usecase, _, keyRepo := setup()
+		invalid := *request
+		invalid.Amount = -1
+
+		_, _, err := usecase.ExecuteIdempotent("", "order-1", &invalid)
+		require.Error(t, err)
+
+		stored, err := keyRepo.Find("", "order-1")
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("Invalid keys are rejected", func(t *testing.T) {
+		usecase, _, _ := setup()
+
+		for _, key := range []string{"   ", strings.Repeat("k", 256), "café"} {
+			_, _, err := usecase.ExecuteIdempotent("", key, request)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "validation failed")
+		}
+	})
+
+	t.Run("Without a repository every call creates a transaction", func(t *testing.T) {
+		mockRepo := new(mocks.MockTransactionRepository)
+		mockRepo.On("Save", mock.AnythingOfType("*entities.Transaction")).Return(nil).Twice()
+		usecase := usecases.NewCreateTransactionUseCase(mockRepo, validation.NewValidator())
+
+		_, _, err := usecase.ExecuteIdempotent("", "order-1", request)
+		require.NoError(t, err)
+		_, replayed, err := usecase.ExecuteIdempotent("", "order-1", request)
+		require.NoError(t, err)
+
+		assert.False(t, replayed)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,224 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/events"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedWebhookSender answers deliveries with the queued responses, then with the last one
+type scriptedWebhookSender struct {
+	mu        sync.Mutex
+	responses []int
+	err       error
+	sent      []string
+}
+
+func (s *scriptedWebhookSender) Send(ctx context.Context, hook *entities.Webhook, delivery *entities.WebhookDelivery) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, hook.URL)
+	if s.err != nil {
+		return 0, s.err
+	}
+	status := s.responses[0]
+	if len(s.responses) > 1 {
+		s.responses = s.responses[1:]
+	}
+	return status, nil
+}
+
+func (s *scriptedWebhookSender) urls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.sent...)
+}
+
+func TestDispatchWebhooksUseCase(t *testing.T) {
+	// Setup
+	created := func() entities.TransactionCreatedEvent {
+		return entities.TransactionCreatedEvent{
+			EventMeta: entities.NewEventMeta(clock.System()),
+			Transaction: entities.Transaction{
+				ID:          uuid.New(),
+				Description: "Flight",
+				Date:        time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC),
+				Amount:      entities.NewMoney(120),
+			},
+		}
+	}
+
+	register := func(t *testing.T, repo interface{ Save(*entities.Webhook) error }, url string, events ...string) entities.Webhook {
+		t.Helper()
+		hook := entities.Webhook{ID: uuid.New(), URL: url, Events: events, Secret: "whsec_test"}
+		require.NoError(t, repo.Save(&hook))
+		return hook
+	}
+
+	t.Run("Only webhooks subscribed to the event receive it", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewWebhookRepository()
+		sender := &scriptedWebhookSender{responses: []int{204}}
+		usecase := usecases.NewDispatchWebhooksUseCase(repo, sender, 3, time.Minute, time.Minute)
+		hook := register(t, repo, "https://a.example.com/hook", entities.WebhookEventTransactionCreated)
+		register(t, repo, "https://b.example.com/hook", entities.WebhookEventTransactionConverted)
+		event := created()
+
+		// Act
+		deliveries, err := usecase.Publish(context.Background(), event)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, []string{"https://a.example.com/hook"}, sender.urls())
+		assert.Equal(t, entities.DeliverySucceeded, deliveries[0].Status)
+		assert.Equal(t, 1, deliveries[0].Attempts)
+		assert.Nil(t, deliveries[0].NextAttemptAt)
+
+		var payload struct {
+			dto.EventMessage
+			Data dto.GetTransactionResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &payload))
+		assert.Equal(t, event.ID, payload.ID)
+		assert.Equal(t, event.ID, deliveries[0].EventID)
+		assert.Equal(t, entities.WebhookEventTransactionCreated, payload.Event)
+		assert.Equal(t, event.Transaction.ID, payload.Data.ID)
+
+		stored, err := repo.FindDeliveries(hook.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.Equal(t, entities.DeliverySucceeded, stored[0].Status)
+		assert.Equal(t, 204, stored[0].LastStatusCode)
+	})
+
+	t.Run("Failed deliveries are retried with exponential backoff", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewWebhookRepository()
+		sender := &scriptedWebhookSender{responses: []int{500, 503, 200}}
+		usecase := usecases.NewDispatchWebhooksUseCase(repo, sender, 5, 20*time.Millisecond, time.Minute)
+		hook := register(t, repo, "https://a.example.com/hook", entities.WebhookEventTransactionCreated)
+
+		// Act
+		publishedAt := time.Now()
+		deliveries, err := usecase.Publish(context.Background(), created())
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		first := deliveries[0]
+
+		early, err := usecase.DeliverDue(context.Background())
+		require.NoError(t, err)
+
+		time.Sleep(25 * time.Millisecond)
+		second, err := usecase.DeliverDue(context.Background())
+		require.NoError(t, err)
+
+		stored, err := repo.FindDeliveries(hook.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		afterSecond := stored[0]
+
+		time.Sleep(45 * time.Millisecond)
+		third, err := usecase.DeliverDue(context.Background())
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, entities.DeliveryPending, first.Status)
+		assert.Equal(t, "receiver answered 500", first.LastError)
+		require.NotNil(t, first.NextAttemptAt)
+		assert.WithinDuration(t, publishedAt.Add(20*time.Millisecond), *first.NextAttemptAt, 10*time.Millisecond)
+
+		assert.Equal(t, dto.WebhookDeliveryResult{}, *early)
+		assert.Equal(t, dto.WebhookDeliveryResult{Attempted: 1, Retrying: 1}, *second)
+		assert.Equal(t, 2, afterSecond.Attempts)
+		require.NotNil(t, afterSecond.NextAttemptAt)
+		assert.Greater(t, afterSecond.NextAttemptAt.Sub(*first.NextAttemptAt), 30*time.Millisecond)
+
+		assert.Equal(t, dto.WebhookDeliveryResult{Attempted: 1, Succeeded: 1}, *third)
+		stored, err = repo.FindDeliveries(hook.ID, 10)
+		require.NoError(t, err)
+		assert.Equal(t, entities.DeliverySucceeded, stored[0].Status)
+		assert.Equal(t, 3, stored[0].Attempts)
+		assert.NotNil(t, stored[0].DeliveredAt)
+	})
+
+	t.Run("Deliveries fail for good after the last attempt", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewWebhookRepository()
+		sender := &scriptedWebhookSender{err: errors.New("connection refused")}
+		usecase := usecases.NewDispatchWebhooksUseCase(repo, sender, 2, time.Millisecond, time.Minute)
+		hook := register(t, repo, "https://a.example.com/hook", entities.WebhookEventTransactionConverted)
+
+		// Act
+		event := entities.TransactionConvertedEvent{EventMeta: entities.NewEventMeta(clock.System()), Conversion: entities.ConvertedTransaction{
+			Transaction:     created().Transaction,
+			TargetCurrency:  entities.EUR,
+			ExchangeRate:    0.9,
+			ConvertedAmount: entities.NewMoney(108),
+		}}
+		_, err := usecase.Publish(context.Background(), event)
+		require.NoError(t, err)
+
+		time.Sleep(5 * time.Millisecond)
+		result, err := usecase.DeliverDue(context.Background())
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, dto.WebhookDeliveryResult{Attempted: 1, Failed: 1}, *result)
+
+		stored, err := repo.FindDeliveries(hook.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.Equal(t, entities.DeliveryFailed, stored[0].Status)
+		assert.Equal(t, 2, stored[0].Attempts)
+		assert.Nil(t, stored[0].NextAttemptAt)
+		assert.Contains(t, stored[0].LastError, "connection refused")
+
+		time.Sleep(5 * time.Millisecond)
+		result, err = usecase.DeliverDue(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, result.Attempted)
+		assert.Len(t, sender.urls(), 2)
+	})
+
+	t.Run("Transactions saved through the publishing repository reach webhooks", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewWebhookRepository()
+		sender := &scriptedWebhookSender{responses: []int{200}}
+		usecase := usecases.NewDispatchWebhooksUseCase(repo, sender, 3, time.Minute, time.Minute)
+		hook := register(t, repo, "https://a.example.com/hook", entities.WebhookEventTransactionCreated)
+		bus := events.NewBus()
+		bus.Subscribe(entities.EventTransactionCreated, usecase.OnTransactionEvent)
+		transactionRepo := usecases.NewPublishingTransactionRepository(memory.NewTransactionRepository(), bus, clock.System())
+		transaction := created().Transaction
+
+		// Act
+		require.NoError(t, transactionRepo.Save(&transaction))
+		bus.Wait()
+
+		// Assert
+		stored, err := repo.FindDeliveries(hook.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.Equal(t, entities.DeliverySucceeded, stored[0].Status)
+
+		var payload struct {
+			Data dto.GetTransactionResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(stored[0].Payload), &payload))
+		assert.Equal(t, transaction.ID, payload.Data.ID)
+	})
+}
@@ -0,0 +1,200 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/events"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingBudgetPublisher captures published budget alerts
+type recordingBudgetPublisher struct {
+	mu     sync.Mutex
+	events []entities.BudgetThresholdCrossedEvent
+}
+
+func (p *recordingBudgetPublisher) PublishBudgetThresholdCrossed(event entities.BudgetThresholdCrossedEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingBudgetPublisher) published() []entities.BudgetThresholdCrossedEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]entities.BudgetThresholdCrossedEvent(nil), p.events...)
+}
+
+// fixedRateFinder answers every lookup with the same rate, or err
+type fixedRateFinder struct {
+	rate float64
+	err  error
+}
+
+func (f fixedRateFinder) SupportsCurrency(code entities.CurrencyCode) bool {
+	return true
+}
+
+func (f fixedRateFinder) FindExchangeRate(_ context.Context, targetCurrency entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return entities.NewExchangeRate(entities.USD, targetCurrency, f.rate, date, clock.System())
+}
+
+func (f fixedRateFinder) SupportsSourceCurrency(code entities.CurrencyCode) bool {
+	return code == entities.USD
+}
+
+func (f fixedRateFinder) FindConversionRate(ctx context.Context, from, to entities.CurrencyCode, date time.Time) (*entities.ExchangeRate, error) {
+	return f.FindExchangeRate(ctx, to, date)
+}
+
+func (f fixedRateFinder) RateWindow() entities.RateWindow {
+	return entities.RateWindow{}
+}
+
+func (f fixedRateFinder) Rounding() entities.RoundingMode {
+	return entities.RoundHalfUp
+}
+
+func TestEvaluateBudgetsUseCase(t *testing.T) {
+	// Setup
+	may := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	newBudget := func(t *testing.T, repo interface{ Save(*entities.Budget) error }, currency entities.CurrencyCode, limit float64) entities.Budget {
+		t.Helper()
+		budget := entities.Budget{
+			ID:         uuid.New(),
+			Category:   "travel",
+			Period:     entities.BudgetMonthly,
+			Limit:      entities.NewMoney(limit),
+			Currency:   currency,
+			Thresholds: []int{80, 100},
+		}
+		require.NoError(t, repo.Save(&budget))
+		return budget
+	}
+
+	purchase := func(category string, amount float64, date time.Time) *entities.Transaction {
+		return &entities.Transaction{
+			ID:          uuid.New(),
+			Description: "Flight",
+			Date:        date,
+			Amount:      entities.NewMoney(amount),
+			Category:    category,
+		}
+	}
+
+	t.Run("Each threshold is announced once as spend grows", func(t *testing.T) {
+		// Arrange
+		budgetRepo := memory.NewBudgetRepository()
+		transactionRepo := memory.NewTransactionRepository()
+		publisher := &recordingBudgetPublisher{}
+		usecase := usecases.NewEvaluateBudgetsUseCase(budgetRepo, transactionRepo, fixedRateFinder{}, publisher)
+		budget := newBudget(t, budgetRepo, entities.USD, 100)
+
+		var thresholds [][]int
+		for _, amount := range []float64{50, 35, 10, 20} {
+			transaction := purchase("travel", amount, may)
+			require.NoError(t, transactionRepo.Save(transaction))
+
+			// Act
+			events, err := usecase.Execute(context.Background(), transaction)
+			require.NoError(t, err)
+
+			crossed := []int{}
+			for _, event := range events {
+				crossed = append(crossed, event.Threshold)
+			}
+			thresholds = append(thresholds, crossed)
+		}
+
+		// Assert
+		assert.Equal(t, [][]int{{}, {80}, {}, {100}}, thresholds)
+
+		events := publisher.published()
+		require.Len(t, events, 2)
+		assert.Equal(t, budget.ID, events[1].Budget.ID)
+		assert.Equal(t, entities.NewMoney(115), events[1].Spent)
+		assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), events[1].PeriodStart)
+	})
+
+	t.Run("Spend is converted to the budget currency", func(t *testing.T) {
+		// Arrange
+		budgetRepo := memory.NewBudgetRepository()
+		transactionRepo := memory.NewTransactionRepository()
+		publisher := &recordingBudgetPublisher{}
+		usecase := usecases.NewEvaluateBudgetsUseCase(budgetRepo, transactionRepo, fixedRateFinder{rate: 0.9}, publisher)
+		newBudget(t, budgetRepo, entities.EUR, 90)
+
+		transaction := purchase("travel", 100, may)
+		require.NoError(t, transactionRepo.Save(transaction))
+
+		// Act
+		events, err := usecase.Execute(context.Background(), transaction)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, entities.NewMoney(90), events[0].Spent)
+	})
+
+	t.Run("Other categories, other periods and missing rates raise nothing", func(t *testing.T) {
+		// Arrange
+		budgetRepo := memory.NewBudgetRepository()
+		transactionRepo := memory.NewTransactionRepository()
+		publisher := &recordingBudgetPublisher{}
+		usecase := usecases.NewEvaluateBudgetsUseCase(budgetRepo, transactionRepo, fixedRateFinder{err: errors.New("no suitable exchange rate found")}, publisher)
+		newBudget(t, budgetRepo, entities.USD, 100)
+		newBudget(t, budgetRepo, entities.EUR, 10)
+
+		april := purchase("travel", 90, may.AddDate(0, -1, 0))
+		require.NoError(t, transactionRepo.Save(april))
+		meals := purchase("meals", 500, may)
+		require.NoError(t, transactionRepo.Save(meals))
+		small := purchase("travel", 10, may)
+		require.NoError(t, transactionRepo.Save(small))
+
+		// Act
+		events, err := usecase.Execute(context.Background(), small)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("Saving through the publishing repository evaluates on the event bus", func(t *testing.T) {
+		// Arrange
+		budgetRepo := memory.NewBudgetRepository()
+		publisher := &recordingBudgetPublisher{}
+		inner := memory.NewTransactionRepository()
+		usecase := usecases.NewEvaluateBudgetsUseCase(budgetRepo, inner, fixedRateFinder{}, publisher)
+		bus := events.NewBus()
+		bus.Subscribe(entities.EventTransactionCreated, usecase.OnTransactionCreated)
+		transactionRepo := usecases.NewPublishingTransactionRepository(inner, bus, clock.System())
+		newBudget(t, budgetRepo, entities.USD, 100)
+
+		// Act
+		require.NoError(t, transactionRepo.Save(purchase("travel", 120, may)))
+		require.NoError(t, transactionRepo.Save(purchase("", 500, may)))
+		bus.Wait()
+
+		// Assert
+		events := publisher.published()
+		require.Len(t, events, 2)
+		assert.Equal(t, 80, events[0].Threshold)
+		assert.Equal(t, 100, events[1].Threshold)
+	})
+}
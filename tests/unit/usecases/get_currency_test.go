@@ -0,0 +1,87 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCurrencyUseCase_Execute(t *testing.T) {
+	t.Run("Currency covered by provider", func(t *testing.T) {
+		// Arrange
+		mockTreasury := new(mocks.MockTreasuryService)
+		usecase := usecases.NewGetCurrencyUseCase(mockTreasury)
+
+		mockTreasury.On("SupportsCurrency", entities.EUR).Return(true).Once()
+		mockTreasury.On("ProviderName").Return("us_treasury").Once()
+
+		// Act
+		response, err := usecase.Execute(" eur ")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, entities.EUR, response.Code)
+		assert.Equal(t, "Euro", response.Name)
+		assert.Equal(t, "€", response.Symbol)
+		assert.Equal(t, 2, response.MinorUnits)
+		assert.True(t, response.ConversionSupported)
+		assert.Equal(t, "us_treasury", response.Provider)
+		mockTreasury.AssertExpectations(t)
+	})
+
+	t.Run("Currency not covered by provider", func(t *testing.T) {
+		// Arrange
+		mockTreasury := new(mocks.MockTreasuryService)
+		usecase := usecases.NewGetCurrencyUseCase(mockTreasury)
+
+		mockTreasury.On("SupportsCurrency", entities.GBP).Return(false).Once()
+
+		// Act
+		response, err := usecase.Execute("GBP")
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, response.ConversionSupported)
+		assert.Empty(t, response.Provider)
+		mockTreasury.AssertExpectations(t)
+	})
+
+	t.Run("USD is not a conversion target", func(t *testing.T) {
+		// Arrange - provider is not consulted for the base currency
+		mockTreasury := new(mocks.MockTreasuryService)
+		usecase := usecases.NewGetCurrencyUseCase(mockTreasury)
+
+		// Act
+		response, err := usecase.Execute("USD")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "US Dollar", response.Name)
+		assert.False(t, response.ConversionSupported)
+		mockTreasury.AssertNotCalled(t, "SupportsCurrency", entities.USD)
+	})
+
+	t.Run("Unknown currency", func(t *testing.T) {
+		usecase := usecases.NewGetCurrencyUseCase(new(mocks.MockTreasuryService))
+
+		response, err := usecase.Execute("XYZ")
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "currency not found")
+	})
+
+	t.Run("Invalid code format", func(t *testing.T) {
+		usecase := usecases.NewGetCurrencyUseCase(new(mocks.MockTreasuryService))
+
+		response, err := usecase.Execute("EURO")
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "validation failed")
+	})
+}
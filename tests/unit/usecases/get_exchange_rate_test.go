@@ -0,0 +1,111 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetExchangeRateUseCase_Execute(t *testing.T) {
+	// Setup
+	mockExchangeRateRepo := new(mocks.MockExchangeRateRepository)
+	mockTreasuryService := new(mocks.MockTreasuryService)
+	validator := validation.NewValidator()
+	rateFinder := usecases.NewConvertTransactionUseCase(new(mocks.MockTransactionRepository), mockExchangeRateRepo, new(mocks.MockQuoteRepository), mockTreasuryService, nil, validator)
+	margins, err := usecases.NewMarginPolicy(0, map[string]int{"partner": 100})
+	require.NoError(t, err)
+	usecase := usecases.NewGetExchangeRateUseCase(rateFinder, margins, validator)
+
+	mockTreasuryService.On("SupportsCurrency", entities.BRL).Return(true).Maybe()
+	date := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Prices the rate with the caller's margin", func(t *testing.T) {
+		// Arrange
+		exchangeRate, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 5.00, date.AddDate(0, -2, 0), clock.System())
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, date).Return(exchangeRate, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.GetExchangeRateRequest{
+			From:   entities.USD,
+			To:     entities.BRL,
+			Date:   date,
+			APIKey: "partner",
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 5.00, response.RawExchangeRate)
+		assert.Equal(t, 100, response.MarginBps)
+		assert.Equal(t, entities.ApplyMargin(5.00, 100), response.ExchangeRate)
+		assert.Equal(t, exchangeRate.EffectiveDate, response.EffectiveDate)
+		mockExchangeRateRepo.AssertExpectations(t)
+	})
+
+	t.Run("A rate older than 6 months is unavailable", func(t *testing.T) {
+		// Arrange
+		stale, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 5.00, date.AddDate(0, -7, 0), clock.System())
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, date).Return(stale, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.GetExchangeRateRequest{From: entities.USD, To: entities.BRL, Date: date})
+
+		// Assert
+		assert.ErrorIs(t, err, errs.ErrRateUnavailable)
+		assert.Nil(t, response)
+	})
+
+	t.Run("A configured window accepts older rates", func(t *testing.T) {
+		// Arrange
+		window, err := entities.NewRateWindow(12)
+		require.NoError(t, err)
+		rateFinder := usecases.NewConvertTransactionUseCase(new(mocks.MockTransactionRepository), mockExchangeRateRepo, new(mocks.MockQuoteRepository), mockTreasuryService, nil, validator).
+			WithRateWindow(window)
+		usecase := usecases.NewGetExchangeRateUseCase(rateFinder, margins, validator)
+		older, _ := entities.NewExchangeRate(entities.USD, entities.BRL, 5.00, date.AddDate(0, -9, 0), clock.System())
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.BRL, date).Return(older, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.GetExchangeRateRequest{From: entities.USD, To: entities.BRL, Date: date})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, older.EffectiveDate, response.EffectiveDate)
+	})
+
+	t.Run("Non-USD sources need cross rates", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.GetExchangeRateRequest{From: entities.EUR, To: entities.BRL, Date: date})
+
+		// Assert
+		assert.ErrorIs(t, err, errs.ErrValidation)
+		assert.Nil(t, response)
+	})
+
+	t.Run("Cross rates price non-USD sources", func(t *testing.T) {
+		// Arrange
+		mockTreasuryService.On("SupportsCurrency", entities.EUR).Return(true).Maybe()
+		rateFinder := usecases.NewConvertTransactionUseCase(new(mocks.MockTransactionRepository), mockExchangeRateRepo, new(mocks.MockQuoteRepository), mockTreasuryService, nil, validator).
+			WithCrossRates(true)
+		usecase := usecases.NewGetExchangeRateUseCase(rateFinder, margins, validator)
+		usdEUR, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.80, date.AddDate(0, -1, 0), clock.System())
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.EUR, date).Return(usdEUR, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.GetExchangeRateRequest{From: entities.EUR, To: entities.USD, Date: date})
+
+		// Assert
+		require.NoError(t, err)
+		assert.InDelta(t, 1.25, response.RawExchangeRate, 0.000001)
+		assert.Equal(t, usdEUR.EffectiveDate, response.EffectiveDate)
+	})
+}
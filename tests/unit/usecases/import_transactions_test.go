@@ -0,0 +1,136 @@
+package usecases_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingGuard refuses every import, like a database over its quota
+type blockingGuard struct{}
+
+func (blockingGuard) AllowImport() error {
+	return errs.Newf(errs.ErrQuotaExceeded, "quota exceeded")
+}
+
+func TestImportTransactionsUseCase_Execute(t *testing.T) {
+	validator := validation.NewValidator()
+
+	t.Run("Valid rows are stored and invalid ones reported by line", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewTransactionRepository()
+		usecase := usecases.NewImportTransactionsUseCase(repo, nil, validator)
+		file := strings.Join([]string{
+			"Date,Description,Amount,Category,Notes",
+			"2024-01-15,Office supplies,42.50,office,ignored",
+			"2024-01-16T10:30:00Z,\"Lunch, team\",18,,",
+			"not-a-date,Taxi,abc,travel,",
+			",,0,,",
+			"2024-01-17,Hotel,-5,travel,",
+		}, "\n")
+
+		// Act
+		response, err := usecase.Execute(strings.NewReader(file))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 5, response.Rows)
+		assert.Equal(t, 2, response.Imported)
+		assert.Equal(t, 3, response.Rejected)
+		assert.Equal(t, []dto.ImportRowError{
+			{Line: 4, Messages: []string{`date "not-a-date" must be YYYY-MM-DD or RFC 3339`, `amount "abc" must be a number`}},
+			{Line: 5, Messages: []string{"description is required", "date is required", "amount is required"}},
+			{Line: 6, Messages: []string{"amount must be greater than 0"}},
+		}, response.Errors)
+
+		stored, err := repo.GetAll()
+		require.NoError(t, err)
+		require.Len(t, stored, 2)
+		byDescription := map[string]float64{}
+		for _, transaction := range stored {
+			byDescription[transaction.Description] = transaction.Amount.Dollars()
+		}
+		assert.Equal(t, map[string]float64{"Office supplies": 42.5, "Lunch, team": 18}, byDescription)
+	})
+
+	t.Run("Excel exports with a byte order mark, semicolons and decimal commas", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewTransactionRepository()
+		usecase := usecases.NewImportTransactionsUseCase(repo, nil, validator)
+		file := "\uFEFFdescription;date;amount\r\nStationery;2024-02-01;12,75\r\n"
+
+		// Act
+		response, err := usecase.Execute(strings.NewReader(file))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, response.Imported)
+		stored, err := repo.GetAll()
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.Equal(t, 12.75, stored[0].Amount.Dollars())
+		assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), stored[0].Date)
+	})
+
+	t.Run("Files without the required columns are rejected", func(t *testing.T) {
+		// Arrange
+		usecase := usecases.NewImportTransactionsUseCase(memory.NewTransactionRepository(), nil, validator)
+
+		for name, file := range map[string]string{
+			"empty":          "",
+			"missing amount": "description,date\nCoffee,2024-01-01\n",
+			"duplicate date": "description,date,amount,date\nCoffee,2024-01-01,3,2024-01-02\n",
+		} {
+			// Act
+			_, err := usecase.Execute(strings.NewReader(file))
+
+			// Assert
+			assert.ErrorIs(t, err, errs.ErrValidation, name)
+		}
+	})
+
+	t.Run("Files over the row limit are rejected without storing anything", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewTransactionRepository()
+		usecase := usecases.NewImportTransactionsUseCase(repo, nil, validator)
+		var file strings.Builder
+		file.WriteString("description,date,amount\n")
+		for i := 0; i <= usecases.MaxImportRows; i++ {
+			fmt.Fprintf(&file, "Row %d,2024-01-01,1\n", i)
+		}
+
+		// Act
+		_, err := usecase.Execute(strings.NewReader(file.String()))
+
+		// Assert
+		assert.ErrorIs(t, err, errs.ErrValidation)
+		count, err := repo.Count()
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("The import guard can refuse the import", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewTransactionRepository()
+		usecase := usecases.NewImportTransactionsUseCase(repo, blockingGuard{}, validator)
+
+		// Act
+		_, err := usecase.Execute(strings.NewReader("description,date,amount\nCoffee,2024-01-01,3\n"))
+
+		// Assert
+		assert.True(t, errors.Is(err, errs.ErrQuotaExceeded))
+		count, err := repo.Count()
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
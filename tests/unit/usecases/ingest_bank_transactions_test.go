@@ -0,0 +1,142 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestBankTransactionsUseCase_Execute(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	posted := time.Date(2024, 5, 3, 14, 30, 0, 0, time.FixedZone("EST", -5*3600))
+	connection := dto.BankConnection{Name: "corporate-card", AccessToken: "access-token", LookbackDays: 7}
+
+	newAggregator := func(transactions []entities.BankTransaction) *mocks.MockBankAggregator {
+		aggregator := new(mocks.MockBankAggregator)
+		aggregator.On("ProviderName").Return("plaid")
+		aggregator.On("FetchTransactions", ctx, "access-token", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return(transactions, nil).Once()
+		return aggregator
+	}
+
+	t.Run("Posted USD purchases are imported once", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewTransactionRepository()
+		bankTransactions := []entities.BankTransaction{
+			{ID: "tx-1", AccountID: "acc-1", Description: "  Coffee   Shop ", Amount: 4.5, Currency: "USD", Date: posted},
+		}
+		usecase := usecases.NewIngestBankTransactionsUseCase(repo, newAggregator(bankTransactions))
+
+		// Act
+		first, err := usecase.Execute(ctx, connection)
+		require.NoError(t, err)
+		usecase = usecases.NewIngestBankTransactionsUseCase(repo, newAggregator(bankTransactions))
+		second, err := usecase.Execute(ctx, connection)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, dto.BankSyncResult{Connection: "corporate-card", Fetched: 1, Created: 1}, *first)
+		assert.Equal(t, dto.BankSyncResult{Connection: "corporate-card", Fetched: 1, Duplicates: 1}, *second)
+
+		imported, err := repo.GetByExternalID("plaid:tx-1")
+		require.NoError(t, err)
+		require.NotNil(t, imported)
+		assert.Equal(t, "Coffee Shop", imported.Description)
+		assert.Equal(t, 4.5, imported.Amount.Dollars())
+		assert.Equal(t, time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC), imported.Date)
+	})
+
+	t.Run("Looks back from the current time of the clock", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+		aggregator := new(mocks.MockBankAggregator)
+		aggregator.On("FetchTransactions", ctx, "access-token", now.AddDate(0, 0, -7), now).
+			Return([]entities.BankTransaction{}, nil).Once()
+		usecase := usecases.NewIngestBankTransactionsUseCase(memory.NewTransactionRepository(), aggregator).
+			WithClock(clock.NewFake(now))
+
+		// Act
+		_, err := usecase.Execute(ctx, connection)
+
+		// Assert
+		require.NoError(t, err)
+		aggregator.AssertExpectations(t)
+	})
+
+	t.Run("Pending, credits, non-USD and unselected accounts are skipped", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewTransactionRepository()
+		aggregator := newAggregator([]entities.BankTransaction{
+			{ID: "pending", AccountID: "acc-1", Description: "Hotel", Amount: 120, Currency: "USD", Date: posted, Pending: true},
+			{ID: "refund", AccountID: "acc-1", Description: "Refund", Amount: -20, Currency: "USD", Date: posted},
+			{ID: "euro", AccountID: "acc-1", Description: "Cafe", Amount: 3, Currency: "EUR", Date: posted},
+			{ID: "other", AccountID: "acc-2", Description: "Books", Amount: 15, Currency: "USD", Date: posted},
+			{ID: "kept", AccountID: "acc-1", Description: "Taxi", Amount: 30, Currency: "usd", Date: posted},
+		})
+		usecase := usecases.NewIngestBankTransactionsUseCase(repo, aggregator)
+		selected := connection
+		selected.AccountIDs = []string{"acc-1"}
+
+		// Act
+		result, err := usecase.Execute(ctx, selected)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.Fetched)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 4, result.Skipped)
+	})
+
+	t.Run("Long descriptions are truncated and invalid amounts fail individually", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewTransactionRepository()
+		aggregator := newAggregator([]entities.BankTransaction{
+			{ID: "long", AccountID: "acc-1", Description: strings.Repeat("é", 40), Amount: 10, Currency: "USD", Date: posted},
+			{ID: "dust", AccountID: "acc-1", Description: "Rounding", Amount: 0.001, Currency: "USD", Date: posted},
+		})
+		usecase := usecases.NewIngestBankTransactionsUseCase(repo, aggregator)
+
+		// Act
+		result, err := usecase.Execute(ctx, connection)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 1, result.Failed)
+
+		imported, err := repo.GetByExternalID("plaid:long")
+		require.NoError(t, err)
+		require.NotNil(t, imported)
+		assert.LessOrEqual(t, len(imported.Description), 50)
+		assert.NoError(t, imported.Validate())
+	})
+
+	t.Run("Aggregator failure is returned", func(t *testing.T) {
+		// Arrange
+		aggregator := new(mocks.MockBankAggregator)
+		aggregator.On("FetchTransactions", ctx, "access-token", mock.Anything, mock.Anything).
+			Return(nil, errors.New("ITEM_LOGIN_REQUIRED")).Once()
+		usecase := usecases.NewIngestBankTransactionsUseCase(memory.NewTransactionRepository(), aggregator)
+
+		// Act
+		result, err := usecase.Execute(ctx, connection)
+
+		// Assert
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "corporate-card")
+		aggregator.AssertExpectations(t)
+	})
+}
@@ -1,24 +1,28 @@
 package usecases_test
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
 	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
 	"github.com/rafaelreis-se/purchase-transaction-api/tests/fixtures"
 	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
 func TestListTransactionsUseCase_Execute(t *testing.T) {
 	// Setup
 	mockRepo := new(mocks.MockTransactionRepository)
-	validator := validator.New()
-	usecase := usecases.NewListTransactionsUseCase(mockRepo, validator)
+	validator := validation.NewValidator()
+	usecase := usecases.NewListTransactionsUseCase(mockRepo, nil, validator)
 
 	t.Run("Successful pagination - first page", func(t *testing.T) {
 		// Arrange
@@ -38,7 +42,7 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 		mockRepo.On("GetAllPaginated", 1, 20).Return(transactions, total, nil).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.NoError(t, err)
@@ -75,7 +79,7 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 		mockRepo.On("GetAllPaginated", 2, 10).Return(transactions, total, nil).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.NoError(t, err)
@@ -103,7 +107,7 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 		mockRepo.On("GetAllPaginated", 1, 20).Return(emptyTransactions, total, nil).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.NoError(t, err)
@@ -132,7 +136,7 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 		mockRepo.On("GetAllPaginated", 1, 20).Return(transactions, total, nil).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.NoError(t, err)
@@ -146,7 +150,7 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 
 	t.Run("Nil request", func(t *testing.T) {
 		// Act
-		response, err := usecase.Execute(nil)
+		response, err := usecase.Execute(context.Background(), nil)
 
 		// Assert
 		assert.Error(t, err)
@@ -163,7 +167,7 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 		}
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -180,7 +184,7 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 		}
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -197,7 +201,7 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 		}
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -217,7 +221,7 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 		mockRepo.On("GetAllPaginated", 1, 20).Return(nil, int64(0), repositoryError).Once()
 
 		// Act
-		response, err := usecase.Execute(request)
+		response, err := usecase.Execute(context.Background(), request)
 
 		// Assert
 		assert.Error(t, err)
@@ -258,7 +262,7 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 				mockRepo.On("GetAllPaginated", tc.expected.page, tc.expected.size).Return(transactions, total, nil).Once()
 
 				// Act
-				response, err := usecase.Execute(request)
+				response, err := usecase.Execute(context.Background(), request)
 
 				// Assert
 				assert.NoError(t, err)
@@ -294,7 +298,7 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 				mockRepo.On("GetAllPaginated", 1, tc.size).Return(transactions, tc.total, nil).Once()
 
 				// Act
-				response, err := usecase.Execute(request)
+				response, err := usecase.Execute(context.Background(), request)
 
 				// Assert
 				assert.NoError(t, err)
@@ -308,14 +312,182 @@ func TestListTransactionsUseCase_Execute(t *testing.T) {
 	})
 }
 
+func TestListTransactionsUseCase_Cursor(t *testing.T) {
+	// Setup
+	mockRepo := new(mocks.MockTransactionRepository)
+	usecase := usecases.NewListTransactionsUseCase(mockRepo, nil, validation.NewValidator())
+
+	first, second, third := fixtures.ValidTransaction(), fixtures.ValidTransaction(), fixtures.ValidTransaction()
+	cursor := entities.CursorOf(first)
+
+	t.Run("Fetches one extra row to hand out the next cursor", func(t *testing.T) {
+		// Arrange
+		mockRepo.On("FindAfter", entities.TransactionFilter{}, &cursor, 3).
+			Return([]entities.Transaction{first, second, third}, nil).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ListTransactionsRequest{Size: 2, Cursor: &cursor})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.True(t, response.IsCursorPage())
+		assert.Zero(t, response.Total)
+		assert.Equal(t, entities.CursorOf(second).Encode(), response.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Last page has no next cursor", func(t *testing.T) {
+		mockRepo.On("FindAfter", entities.TransactionFilter{}, &cursor, 3).Return([]entities.Transaction{second}, nil).Once()
+
+		response, err := usecase.Execute(context.Background(), &dto.ListTransactionsRequest{Size: 2, Cursor: &cursor})
+
+		require.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Empty(t, response.NextCursor)
+	})
+
+	t.Run("ByCursor starts a cursor listing without a cursor", func(t *testing.T) {
+		mockRepo.On("FindAfter", entities.TransactionFilter{}, (*entities.TransactionCursor)(nil), 3).
+			Return([]entities.Transaction{first, second, third}, nil).Once()
+
+		response, err := usecase.Execute(context.Background(), &dto.ListTransactionsRequest{Size: 2, ByCursor: true})
+
+		require.NoError(t, err)
+		assert.True(t, response.IsCursorPage())
+		assert.Equal(t, entities.CursorOf(second).Encode(), response.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Numbered pages also hand out a cursor while more follow", func(t *testing.T) {
+		mockRepo.On("GetAllPaginated", 1, 2).Return([]entities.Transaction{first, second}, int64(3), nil).Once()
+
+		response, err := usecase.Execute(context.Background(), &dto.ListTransactionsRequest{Page: 1, Size: 2})
+
+		require.NoError(t, err)
+		assert.False(t, response.IsCursorPage())
+		assert.Equal(t, entities.CursorOf(second).Encode(), response.NextCursor)
+	})
+
+	t.Run("Cursor cannot be combined with a page, the trash or archived transactions", func(t *testing.T) {
+		for _, request := range []*dto.ListTransactionsRequest{
+			{Page: 2, Cursor: &cursor},
+			{Trash: true, Cursor: &cursor},
+			{IncludeArchived: true, Cursor: &cursor},
+			{Trash: true, ByCursor: true},
+		} {
+			_, err := usecase.Execute(context.Background(), request)
+			assert.ErrorIs(t, err, errs.ErrValidation)
+		}
+	})
+}
+
+func TestListTransactionsUseCase_WithCurrency(t *testing.T) {
+	// Setup - real conversion use case backed by mocks acts as the rate finder
+	mockRepo := new(mocks.MockTransactionRepository)
+	mockExchangeRateRepo := new(mocks.MockExchangeRateRepository)
+	mockTreasury := new(mocks.MockTreasuryService)
+	validator := validation.NewValidator()
+	converter := usecases.NewConvertTransactionUseCase(mockRepo, mockExchangeRateRepo, new(mocks.MockQuoteRepository), mockTreasury, nil, validator)
+	usecase := usecases.NewListTransactionsUseCase(mockRepo, converter, validator)
+
+	t.Run("Converts items and reports per-item errors", func(t *testing.T) {
+		// Arrange - two transactions share a date, one has no rate available
+		withRate := fixtures.TransactionWithAmount(100.00)
+		sameDate := fixtures.TransactionWithAmount(10.00)
+		noRate := fixtures.TransactionWithDate(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC))
+		transactions := []entities.Transaction{withRate, sameDate, noRate}
+
+		rate := fixtures.ExchangeRateWithCurrencies(entities.USD, entities.EUR)
+		rate.Rate = 0.9
+
+		mockTreasury.On("SupportsCurrency", entities.EUR).Return(true).Once()
+		mockRepo.On("GetAllPaginated", 1, 20).Return(transactions, int64(3), nil).Once()
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.EUR, withRate.Date).Return(&rate, nil).Once()
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.EUR, noRate.Date).Return(nil, nil).Once()
+		mockTreasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.EUR, noRate.Date).Return(nil, errors.New("no suitable exchange rate found")).Once()
+
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.ListTransactionsRequest{Page: 1, Size: 20, Currency: entities.EUR})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, response.Data, 3)
+		assert.Equal(t, entities.EUR, response.Currency)
+
+		require.NotNil(t, response.Data[0].ConvertedAmount)
+		assert.Equal(t, 90.00, *response.Data[0].ConvertedAmount)
+		assert.Equal(t, 0.9, *response.Data[0].ExchangeRate)
+		require.NotNil(t, response.Data[1].ConvertedAmount)
+		assert.Equal(t, 9.00, *response.Data[1].ConvertedAmount)
+
+		assert.Nil(t, response.Data[2].ConvertedAmount)
+		assert.Contains(t, response.Data[2].ConversionError, "no suitable exchange rate found")
+
+		// Rate for the shared date was looked up only once
+		mockRepo.AssertExpectations(t)
+		mockExchangeRateRepo.AssertExpectations(t)
+		mockTreasury.AssertExpectations(t)
+	})
+
+	t.Run("Unsupported currency", func(t *testing.T) {
+		mockTreasury.On("SupportsCurrency", entities.GBP).Return(false).Once()
+
+		response, err := usecase.Execute(context.Background(), &dto.ListTransactionsRequest{Page: 1, Size: 20, Currency: entities.GBP})
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "validation failed")
+		assert.Contains(t, err.Error(), "not supported for conversion")
+	})
+
+	t.Run("Currency without rate finder", func(t *testing.T) {
+		plain := usecases.NewListTransactionsUseCase(mockRepo, nil, validator)
+
+		response, err := plain.Execute(context.Background(), &dto.ListTransactionsRequest{Page: 1, Size: 20, Currency: entities.EUR})
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+
+	t.Run("Converted items carry the caller's margin like a conversion", func(t *testing.T) {
+		// Arrange
+		margins, err := usecases.NewMarginPolicy(0, map[string]int{"partner-key": 100})
+		require.NoError(t, err)
+		priced := usecases.NewConvertTransactionUseCase(mockRepo, mockExchangeRateRepo, new(mocks.MockQuoteRepository), mockTreasury, margins, validator)
+		list := usecases.NewListTransactionsUseCase(mockRepo, priced, validator).WithMargins(margins)
+		transaction := fixtures.TransactionWithAmount(100.00)
+		rate := fixtures.ExchangeRateWithCurrencies(entities.USD, entities.EUR)
+		rate.Rate = 0.9
+
+		mockTreasury.On("SupportsCurrency", entities.EUR).Return(true).Twice()
+		mockRepo.On("GetAllPaginated", 1, 20).Return([]entities.Transaction{transaction}, int64(1), nil).Once()
+		mockRepo.On("GetByID", transaction.ID).Return(&transaction, nil).Once()
+		mockExchangeRateRepo.On("FindRateForConversion", entities.USD, entities.EUR, transaction.Date).Return(&rate, nil).Twice()
+
+		// Act
+		response, err := list.Execute(context.Background(), &dto.ListTransactionsRequest{Page: 1, Size: 20, Currency: entities.EUR, APIKey: "partner-key"})
+		require.NoError(t, err)
+		converted, err := priced.Execute(context.Background(), &dto.ConvertTransactionRequest{TransactionID: transaction.ID, TargetCurrency: entities.EUR, APIKey: "partner-key"})
+		require.NoError(t, err)
+
+		// Assert
+		require.NotNil(t, response.Data[0].ConvertedAmount)
+		assert.Equal(t, 0.891, *response.Data[0].ExchangeRate)
+		assert.Equal(t, 89.10, *response.Data[0].ConvertedAmount)
+		assert.Equal(t, converted.ConvertedAmount, *response.Data[0].ConvertedAmount)
+		assert.Equal(t, converted.ExchangeRate, *response.Data[0].ExchangeRate)
+	})
+}
+
 func TestListTransactionsUseCase_Constructor(t *testing.T) {
 	t.Run("Valid constructor", func(t *testing.T) {
 		// Arrange
 		mockRepo := new(mocks.MockTransactionRepository)
-		validator := validator.New()
+		validator := validation.NewValidator()
 
 		// Act
-		usecase := usecases.NewListTransactionsUseCase(mockRepo, validator)
+		usecase := usecases.NewListTransactionsUseCase(mockRepo, nil, validator)
 
 		// Assert
 		assert.NotNil(t, usecase)
@@ -0,0 +1,176 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/repositories"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManageCategoriesUseCase(t *testing.T) {
+	validator := validation.NewValidator()
+
+	t.Run("Names are trimmed and unique ignoring case", func(t *testing.T) {
+		// Arrange
+		usecase := usecases.NewManageCategoriesUseCase(memory.NewCategoryRepository(), memory.NewTransactionRepository(),
+			memory.NewBudgetRepository(), validator)
+
+		// Act
+		created, err := usecase.Create(&dto.CategoryRequest{Name: "  Travel ", Description: "Trips"})
+		require.NoError(t, err)
+		_, duplicateErr := usecase.Create(&dto.CategoryRequest{Name: "TRAVEL"})
+		_, emptyErr := usecase.Create(&dto.CategoryRequest{Name: "   "})
+
+		// Assert
+		assert.Equal(t, "Travel", created.Name)
+		assert.ErrorIs(t, duplicateErr, errs.ErrConflict)
+		assert.ErrorIs(t, emptyErr, errs.ErrValidation)
+	})
+
+	t.Run("Renaming a category moves its transactions and budgets", func(t *testing.T) {
+		// Arrange
+		transactionRepo := memory.NewTransactionRepository()
+		budgetRepo := memory.NewBudgetRepository()
+		usecase := usecases.NewManageCategoriesUseCase(memory.NewCategoryRepository(), transactionRepo, budgetRepo, validator)
+
+		category, err := usecase.Create(&dto.CategoryRequest{Name: "travel"})
+		require.NoError(t, err)
+		transaction := &entities.Transaction{ID: uuid.New(), Description: "Hotel", Date: time.Now(), Amount: 12000, Category: "travel"}
+		require.NoError(t, transactionRepo.Save(transaction))
+		budget := &entities.Budget{ID: uuid.New(), Category: "travel", Period: entities.BudgetMonthly, Limit: 50000, Currency: entities.USD}
+		require.NoError(t, budgetRepo.Save(budget))
+
+		// Act
+		renamed, err := usecase.Update(category.ID, &dto.CategoryRequest{Name: "Business travel"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Business travel", renamed.Name)
+		stored, err := transactionRepo.GetByID(transaction.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Business travel", stored.Category)
+		budgets, err := budgetRepo.FindByCategory("Business travel")
+		require.NoError(t, err)
+		assert.Len(t, budgets, 1)
+	})
+
+	t.Run("Categories in use cannot be deleted", func(t *testing.T) {
+		// Arrange
+		transactionRepo := memory.NewTransactionRepository()
+		usecase := usecases.NewManageCategoriesUseCase(memory.NewCategoryRepository(), transactionRepo,
+			memory.NewBudgetRepository(), validator)
+
+		used, err := usecase.Create(&dto.CategoryRequest{Name: "office"})
+		require.NoError(t, err)
+		unused, err := usecase.Create(&dto.CategoryRequest{Name: "misc"})
+		require.NoError(t, err)
+		transaction := &entities.Transaction{ID: uuid.New(), Description: "Desk", Date: time.Now(), Amount: 30000, Category: "office"}
+		require.NoError(t, transactionRepo.Save(transaction))
+		require.NoError(t, transactionRepo.Delete(transaction.ID)) // Trashed transactions can still be restored
+
+		// Act
+		usedErr := usecase.Delete(used.ID)
+		unusedErr := usecase.Delete(unused.ID)
+		missingErr := usecase.Delete(unused.ID)
+
+		// Assert
+		assert.ErrorIs(t, usedErr, errs.ErrConflict)
+		assert.Contains(t, usedErr.Error(), "used by 1 transactions and 0 budgets")
+		assert.NoError(t, unusedErr)
+		assert.ErrorIs(t, missingErr, errs.ErrNotFound)
+	})
+}
+
+func TestCreateTransactionUseCase_WithCategories(t *testing.T) {
+	// Setup
+	categoryRepo := memory.NewCategoryRepository()
+	require.NoError(t, categoryRepo.Save(&entities.Category{ID: uuid.New(), Name: "Groceries"}))
+	usecase := usecases.NewCreateTransactionUseCase(memory.NewTransactionRepository(), validation.NewValidator()).
+		WithCategories(categoryRepo)
+
+	request := func(category string) *dto.CreateTransactionRequest {
+		return &dto.CreateTransactionRequest{
+			Description: "Market",
+			Date:        time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			Amount:      42,
+			Category:    category,
+			Tags:        []string{" Weekly ", "weekly", "Family"},
+		}
+	}
+
+	t.Run("The stored spelling of the category is used", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(request("groceries"))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Groceries", response.Category)
+		assert.Equal(t, []string{"weekly", "family"}, response.Tags)
+	})
+
+	t.Run("Unknown categories are rejected", func(t *testing.T) {
+		// Act
+		_, err := usecase.Execute(request("Gadgets"))
+
+		// Assert
+		assert.ErrorIs(t, err, errs.ErrValidation)
+		assert.Contains(t, err.Error(), `category "Gadgets" does not exist`)
+	})
+
+	t.Run("Transactions without a category need none", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(request(""))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, response.Category)
+	})
+}
+
+// failingUnitOfWork runs units of work on its repositories and reports failure with the given error
+type failingUnitOfWork struct {
+	repos repositories.Repositories
+	err   error
+	calls int
+}
+
+func (u *failingUnitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	u.calls++
+	if err := fn(u.repos); err != nil {
+		return err
+	}
+	return u.err
+}
+
+func TestManageCategoriesUseCase_UnitOfWork(t *testing.T) {
+	// Arrange
+	categoryRepo := memory.NewCategoryRepository()
+	transactionRepo := memory.NewTransactionRepository()
+	budgetRepo := memory.NewBudgetRepository()
+	unitOfWork := &failingUnitOfWork{
+		repos: repositories.Repositories{Categories: categoryRepo, Transactions: transactionRepo, Budgets: budgetRepo},
+		err:   errors.New("commit failed"),
+	}
+	usecase := usecases.NewManageCategoriesUseCase(categoryRepo, transactionRepo, budgetRepo, validation.NewValidator()).
+		WithUnitOfWork(unitOfWork)
+
+	category, err := usecase.Create(&dto.CategoryRequest{Name: "travel"})
+	require.NoError(t, err)
+
+	// Act
+	_, err = usecase.Update(category.ID, &dto.CategoryRequest{Name: "Business travel"})
+
+	// Assert
+	assert.ErrorIs(t, err, unitOfWork.err)
+	assert.Equal(t, 1, unitOfWork.calls)
+}
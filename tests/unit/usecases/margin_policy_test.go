@@ -0,0 +1,36 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarginPolicy(t *testing.T) {
+	t.Run("Per API key override and global fallback", func(t *testing.T) {
+		// Arrange
+		policy, err := usecases.NewMarginPolicy(50, map[string]int{"partner": 10, "internal": 0})
+		require.NoError(t, err)
+
+		// Act & Assert
+		assert.Equal(t, 10, policy.For("partner"))
+		assert.Equal(t, 0, policy.For("internal"))
+		assert.Equal(t, 50, policy.For("unknown"))
+		assert.Equal(t, 50, policy.For(""))
+	})
+
+	t.Run("Nil policy applies no margin", func(t *testing.T) {
+		var policy *usecases.MarginPolicy
+		assert.Equal(t, 0, policy.For("partner"))
+	})
+
+	t.Run("Out of range margins are rejected", func(t *testing.T) {
+		_, err := usecases.NewMarginPolicy(-1, nil)
+		assert.Error(t, err)
+
+		_, err = usecases.NewMarginPolicy(0, map[string]int{"partner": 10000})
+		assert.Error(t, err)
+	})
+}
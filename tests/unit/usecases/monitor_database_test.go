@@ -0,0 +1,125 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedStats reports a preset database measurement
+type fixedStats struct {
+	stats dto.DatabaseStats
+	err   error
+}
+
+func (f *fixedStats) Stats(context.Context) (dto.DatabaseStats, error) {
+	return f.stats, f.err
+}
+
+// pooledStats also reports connection pools, like SQL storage
+type pooledStats struct {
+	fixedStats
+	pools []dto.ConnectionPoolStats
+}
+
+func (p *pooledStats) PoolStats() []dto.ConnectionPoolStats {
+	return p.pools
+}
+
+func TestMonitorDatabaseUseCase(t *testing.T) {
+	const quota = 1000
+
+	t.Run("Quota status follows usage", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			size     int64
+			expected string
+		}{
+			{"Below warning threshold", 500, dto.QuotaOK},
+			{"At warning threshold", 800, dto.QuotaWarning},
+			{"Over quota", 1200, dto.QuotaExceeded},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// Arrange
+				stats := &fixedStats{stats: dto.DatabaseStats{SizeBytes: tc.size, RowCounts: map[string]int64{"transactions": 3}}}
+				usecase := usecases.NewMonitorDatabaseUseCase(stats, quota, 80, false)
+
+				// Act
+				usage, err := usecase.Execute(context.Background())
+
+				// Assert
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, usage.QuotaStatus)
+				assert.Equal(t, int64(quota), usage.QuotaBytes)
+				assert.InDelta(t, float64(tc.size)*100/quota, usage.UsagePercent, 0.001)
+				assert.Equal(t, int64(3), usage.RowCounts["transactions"])
+				assert.False(t, usage.ImportsBlocked)
+			})
+		}
+	})
+
+	t.Run("No quota configured", func(t *testing.T) {
+		// Arrange
+		stats := &fixedStats{stats: dto.DatabaseStats{SizeBytes: 5000}}
+		usecase := usecases.NewMonitorDatabaseUseCase(stats, 0, 80, true)
+
+		// Act
+		usage, err := usecase.Execute(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, dto.QuotaDisabled, usage.QuotaStatus)
+		assert.NoError(t, usecase.AllowImport())
+	})
+
+	t.Run("Imports are blocked over quota when enabled", func(t *testing.T) {
+		// Arrange
+		stats := &fixedStats{stats: dto.DatabaseStats{SizeBytes: 1500}}
+		blocking := usecases.NewMonitorDatabaseUseCase(stats, quota, 80, true)
+		advisory := usecases.NewMonitorDatabaseUseCase(stats, quota, 80, false)
+
+		// Act
+		blockedErr := blocking.AllowImport()
+		advisoryErr := advisory.AllowImport()
+
+		// Assert
+		require.Error(t, blockedErr)
+		assert.Contains(t, blockedErr.Error(), "quota exceeded")
+		assert.NoError(t, advisoryErr)
+	})
+
+	t.Run("Imports proceed when the database cannot be measured", func(t *testing.T) {
+		// Arrange
+		stats := &fixedStats{err: errors.New("disk unavailable")}
+		usecase := usecases.NewMonitorDatabaseUseCase(stats, quota, 80, true)
+
+		// Act
+		err := usecase.AllowImport()
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Connection pools are reported when the storage has them", func(t *testing.T) {
+		// Arrange
+		pools := []dto.ConnectionPoolStats{{Pool: "primary", MaxOpen: 25, Open: 3, InUse: 2, Idle: 1}}
+		pooled := usecases.NewMonitorDatabaseUseCase(&pooledStats{pools: pools}, 0, 80, false)
+		unpooled := usecases.NewMonitorDatabaseUseCase(&fixedStats{}, 0, 80, false)
+
+		// Act
+		usage, err := pooled.Execute(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, pools, usage.Pools)
+		assert.Equal(t, pools, pooled.ConnectionPools())
+		assert.Nil(t, unpooled.ConnectionPools())
+	})
+}
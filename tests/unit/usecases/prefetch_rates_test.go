@@ -0,0 +1,92 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefetchRatesUseCase(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	t.Run("Stores the rates published since the newest stored one", func(t *testing.T) {
+		// Arrange
+		exchangeRateRepo := memory.NewExchangeRateRepository()
+		treasury := &mocks.MockTreasuryService{}
+
+		storedEUR, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.9, today.AddDate(0, 0, -10), clock.System())
+		require.NoError(t, exchangeRateRepo.Save(storedEUR))
+		olderCAD, _ := entities.NewExchangeRate(entities.USD, entities.CAD, 1.3, today.AddDate(0, -3, 0), clock.System())
+		newCAD, _ := entities.NewExchangeRate(entities.USD, entities.CAD, 1.35, today.AddDate(0, 0, -3), clock.System())
+		treasury.On("FetchExchangeRates", mock.Anything, entities.USD, entities.EUR, today.AddDate(0, 0, -9), mock.Anything).Return([]entities.ExchangeRate{}, nil).Once()
+		treasury.On("FetchExchangeRates", mock.Anything, entities.USD, entities.CAD, mock.Anything, mock.Anything).Return([]entities.ExchangeRate{*newCAD, *olderCAD}, nil).Once()
+		treasury.On("FetchExchangeRates", mock.Anything, entities.USD, entities.BRL, mock.Anything, mock.Anything).Return(nil, errors.New("Treasury API returned status 503")).Once()
+		treasury.On("FetchExchangeRates", mock.Anything, entities.USD, entities.JPY, mock.Anything, mock.Anything).Return([]entities.ExchangeRate{}, nil).Once()
+
+		useCase := usecases.NewPrefetchRatesUseCase(exchangeRateRepo, treasury, []entities.CurrencyCode{entities.EUR, entities.CAD, entities.BRL, entities.JPY})
+
+		// Act
+		result, err := useCase.Execute(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Currencies)
+		assert.Equal(t, 1, result.Stored)
+		assert.Equal(t, 1, result.UpToDate)
+		assert.Equal(t, 2, result.Failed)
+		assert.Contains(t, result.Errors["BRL"], "status 503")
+		assert.Contains(t, result.Errors["JPY"], "no exchange rate found")
+
+		cad, err := exchangeRateRepo.FindRateForConversion(entities.USD, entities.CAD, today, entities.RateWindow{})
+		require.NoError(t, err)
+		require.NotNil(t, cad)
+		assert.Equal(t, 1.35, cad.Rate)
+		gapCAD, err := exchangeRateRepo.FindRateForConversion(entities.USD, entities.CAD, today.AddDate(0, 0, -20), entities.RateWindow{})
+		require.NoError(t, err)
+		require.NotNil(t, gapCAD, "older rates in the range are stored too")
+		assert.Equal(t, 1.3, gapCAD.Rate)
+		treasury.AssertExpectations(t)
+	})
+
+	t.Run("Rates from fallback providers are not stored", func(t *testing.T) {
+		// Arrange
+		exchangeRateRepo := memory.NewExchangeRateRepository()
+		treasury := &mocks.MockTreasuryService{}
+		fixedEUR, _ := entities.NewExchangeRate(entities.USD, entities.EUR, 0.92, today, clock.System())
+		fixedEUR.Source = "fixed"
+		treasury.On("FetchExchangeRates", mock.Anything, entities.USD, entities.EUR, mock.Anything, mock.Anything).Return([]entities.ExchangeRate{*fixedEUR}, nil).Once()
+
+		useCase := usecases.NewPrefetchRatesUseCase(exchangeRateRepo, treasury, []entities.CurrencyCode{entities.EUR})
+
+		// Act
+		result, err := useCase.Execute(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Stored)
+		assert.Equal(t, 1, result.Failed)
+		eur, err := exchangeRateRepo.FindRateForConversion(entities.USD, entities.EUR, today, entities.RateWindow{})
+		require.NoError(t, err)
+		assert.Nil(t, eur)
+	})
+
+	t.Run("Stops when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		useCase := usecases.NewPrefetchRatesUseCase(memory.NewExchangeRateRepository(), &mocks.MockTreasuryService{}, []entities.CurrencyCode{entities.EUR})
+		_, err := useCase.Execute(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,43 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeTransactionsUseCase(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Purges transactions deleted before the retention period", func(t *testing.T) {
+		// Arrange
+		transactionRepo := &mocks.MockTransactionRepository{}
+		cutoff := now.AddDate(0, 0, -30)
+		transactionRepo.On("PurgeDeletedBefore", cutoff).Return(int64(4), nil).Once()
+
+		useCase := usecases.NewPurgeTransactionsUseCase(transactionRepo).WithClock(clock.NewFake(now))
+
+		// Act
+		result, err := useCase.Execute(30 * 24 * time.Hour)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, cutoff, result.Cutoff)
+		assert.Equal(t, int64(4), result.Purged)
+		transactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects a negative retention", func(t *testing.T) {
+		useCase := usecases.NewPurgeTransactionsUseCase(&mocks.MockTransactionRepository{})
+
+		_, err := useCase.Execute(-time.Hour)
+
+		assert.ErrorIs(t, err, errs.ErrValidation)
+	})
+}
@@ -0,0 +1,146 @@
+package usecases_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher captures published events
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []entities.ConversionSupersededEvent
+	err    error
+}
+
+func (p *recordingPublisher) PublishConversionSuperseded(event entities.ConversionSupersededEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return p.err
+}
+
+func (p *recordingPublisher) published() []entities.ConversionSupersededEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]entities.ConversionSupersededEvent(nil), p.events...)
+}
+
+// storedConversion builds a conversion record for a transaction on date using rate
+func storedConversion(t *testing.T, date time.Time, amount float64, rate *entities.ExchangeRate) entities.ConversionRecord {
+	t.Helper()
+
+	converted, err := entities.NewConvertedTransaction(entities.Transaction{
+		ID:          uuid.New(),
+		Description: "Stored purchase",
+		Date:        date,
+		Amount:      entities.NewMoney(amount),
+	}, rate.ToCurrency, rate, entities.RateWindow{}, entities.RoundHalfUp)
+	require.NoError(t, err)
+
+	record, err := entities.NewConversionRecord(converted, nil, clock.System())
+	require.NoError(t, err)
+	return *record
+}
+
+func TestRefreshConversionsUseCase(t *testing.T) {
+	oldRate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.90, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+	closerRate, err := entities.NewExchangeRate(entities.USD, entities.EUR, 0.95, time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), clock.System())
+	require.NoError(t, err)
+
+	t.Run("Supersedes records the closer rate improves", func(t *testing.T) {
+		// Arrange
+		recordRepo := memory.NewConversionRecordRepository()
+		publisher := &recordingPublisher{}
+		usecase := usecases.NewRefreshConversionsUseCase(recordRepo, publisher)
+
+		affected := storedConversion(t, time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), 100, oldRate)
+		tooEarly := storedConversion(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), 100, oldRate) // Rate is after the purchase
+		require.NoError(t, recordRepo.SaveAll([]entities.ConversionRecord{affected, tooEarly}))
+
+		// Act
+		refreshed, err := usecase.Execute(closerRate)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, refreshed)
+
+		events := publisher.published()
+		require.Len(t, events, 1)
+		assert.Equal(t, affected.ID, events[0].Previous.ID)
+		assert.Equal(t, affected.TransactionID, events[0].Replacement.TransactionID)
+		assert.Equal(t, 0.95, events[0].Replacement.ExchangeRate)
+		assert.Equal(t, entities.NewMoney(95), events[0].Replacement.ConvertedAmount)
+		assert.True(t, events[0].Replacement.EffectiveDate.Equal(closerRate.EffectiveDate))
+
+		remaining, err := recordRepo.FindSupersedable(entities.EUR, closerRate.EffectiveDate)
+		require.NoError(t, err)
+		assert.Empty(t, remaining, "superseded records are not refreshed twice")
+	})
+
+	t.Run("Ignores rates for other currencies", func(t *testing.T) {
+		// Arrange
+		recordRepo := memory.NewConversionRecordRepository()
+		publisher := &recordingPublisher{}
+		usecase := usecases.NewRefreshConversionsUseCase(recordRepo, publisher)
+		require.NoError(t, recordRepo.SaveAll([]entities.ConversionRecord{
+			storedConversion(t, time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), 100, oldRate),
+		}))
+
+		brlRate, err := entities.NewExchangeRate(entities.USD, entities.BRL, 5.0, closerRate.EffectiveDate, clock.System())
+		require.NoError(t, err)
+
+		// Act
+		refreshed, err := usecase.Execute(brlRate)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Zero(t, refreshed)
+		assert.Empty(t, publisher.published())
+	})
+
+	t.Run("Publish failure does not undo the refresh", func(t *testing.T) {
+		// Arrange
+		recordRepo := memory.NewConversionRecordRepository()
+		publisher := &recordingPublisher{err: errors.New("broker unavailable")}
+		usecase := usecases.NewRefreshConversionsUseCase(recordRepo, publisher)
+		require.NoError(t, recordRepo.SaveAll([]entities.ConversionRecord{
+			storedConversion(t, time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), 100, oldRate),
+		}))
+
+		// Act
+		refreshed, err := usecase.Execute(closerRate)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, refreshed)
+	})
+
+	t.Run("Saving a rate through the notifying repository triggers a refresh", func(t *testing.T) {
+		// Arrange
+		recordRepo := memory.NewConversionRecordRepository()
+		publisher := &recordingPublisher{}
+		usecase := usecases.NewRefreshConversionsUseCase(recordRepo, publisher)
+		exchangeRateRepo := usecases.NewNotifyingExchangeRateRepository(memory.NewExchangeRateRepository(), usecase)
+		require.NoError(t, recordRepo.SaveAll([]entities.ConversionRecord{
+			storedConversion(t, time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), 100, oldRate),
+		}))
+
+		// Act
+		require.NoError(t, exchangeRateRepo.Save(closerRate))
+		usecase.Wait()
+
+		// Assert
+		assert.Len(t, publisher.published(), 1)
+	})
+}
@@ -0,0 +1,165 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/events"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingBroker captures published messages, failing while down is set
+type recordingBroker struct {
+	mu       sync.Mutex
+	down     bool
+	attempts int
+	messages []brokerMessage
+}
+
+type brokerMessage struct {
+	topic   string
+	id      string
+	payload []byte
+}
+
+func (b *recordingBroker) Publish(ctx context.Context, topic string, id string, payload []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.attempts++
+	if b.down {
+		return errors.New("connection refused")
+	}
+	b.messages = append(b.messages, brokerMessage{topic: topic, id: id, payload: payload})
+	return nil
+}
+
+func (b *recordingBroker) setDown(down bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.down = down
+}
+
+func (b *recordingBroker) published() []brokerMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]brokerMessage(nil), b.messages...)
+}
+
+func TestRelayOutboxUseCase(t *testing.T) {
+	// Setup
+	transaction := entities.Transaction{
+		ID:          uuid.New(),
+		Description: "Flight",
+		Date:        time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC),
+		Amount:      entities.NewMoney(120),
+	}
+
+	t.Run("Published events are stored in the outbox before reaching subscribers", func(t *testing.T) {
+		// Arrange
+		outboxRepo := memory.NewOutboxRepository()
+		bus := events.NewBus()
+		received := make(chan entities.DomainEvent, 1)
+		bus.Subscribe(entities.EventTransactionDeleted, func(ctx context.Context, event entities.DomainEvent) error {
+			received <- event
+			return nil
+		})
+		publisher := usecases.NewOutboxEventPublisher(bus, outboxRepo, "transactions.events")
+		transactionRepo := usecases.NewPublishingTransactionRepository(memory.NewTransactionRepository(), publisher, clock.System())
+		stored := transaction
+		require.NoError(t, transactionRepo.Save(&stored))
+
+		// Act
+		require.NoError(t, transactionRepo.Delete(transaction.ID))
+		bus.Wait()
+
+		// Assert
+		pending, err := outboxRepo.CountPending()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), pending)
+		assert.Equal(t, entities.EventTransactionDeleted, (<-received).EventName())
+	})
+
+	t.Run("Due messages are published oldest first and purged after the retention", func(t *testing.T) {
+		// Arrange
+		outboxRepo := memory.NewOutboxRepository()
+		broker := &recordingBroker{}
+		publisher := usecases.NewOutboxEventPublisher(events.NewBus(), outboxRepo, "transactions.events")
+		created := entities.TransactionCreatedEvent{EventMeta: entities.NewEventMeta(clock.System()), Transaction: transaction}
+		updated := entities.TransactionUpdatedEvent{EventMeta: entities.NewEventMeta(clock.System()), Transaction: transaction}
+		require.NoError(t, publisher.Publish(context.Background(), created))
+		require.NoError(t, publisher.Publish(context.Background(), updated))
+
+		// Act
+		result, err := usecases.NewRelayOutboxUseCase(outboxRepo, broker, time.Hour).Execute(context.Background())
+		require.NoError(t, err)
+		again, err := usecases.NewRelayOutboxUseCase(outboxRepo, broker, time.Hour).Execute(context.Background())
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+		purge, err := usecases.NewRelayOutboxUseCase(outboxRepo, broker, time.Millisecond).Execute(context.Background())
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, dto.OutboxRelayResult{Published: 2}, *result)
+		assert.Equal(t, dto.OutboxRelayResult{}, *again)
+		assert.Equal(t, dto.OutboxRelayResult{Purged: 2}, *purge)
+
+		messages := broker.published()
+		require.Len(t, messages, 2)
+		assert.Equal(t, "transactions.events", messages[0].topic)
+		assert.Equal(t, created.ID.String(), messages[0].id)
+		assert.Equal(t, updated.ID.String(), messages[1].id)
+
+		var message struct {
+			dto.EventMessage
+			Data dto.GetTransactionResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(messages[1].payload, &message))
+		assert.Equal(t, entities.EventTransactionUpdated, message.Event)
+		assert.Equal(t, updated.ID, message.ID)
+		assert.Equal(t, transaction.ID, message.Data.ID)
+	})
+
+	t.Run("A broker outage delays messages with backoff instead of losing them", func(t *testing.T) {
+		// Arrange
+		outboxRepo := memory.NewOutboxRepository()
+		broker := &recordingBroker{down: true}
+		usecase := usecases.NewRelayOutboxUseCase(outboxRepo, broker, time.Hour)
+		publisher := usecases.NewOutboxEventPublisher(events.NewBus(), outboxRepo, "transactions.events")
+		for i := 0; i < 3; i++ {
+			require.NoError(t, publisher.Publish(context.Background(), entities.TransactionDeletedEvent{
+				EventMeta:     entities.NewEventMeta(clock.System()),
+				TransactionID: uuid.New(),
+			}))
+		}
+
+		// Act
+		failed, err := usecase.Execute(context.Background())
+		require.NoError(t, err)
+		waiting, err := usecase.Execute(context.Background())
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, dto.OutboxRelayResult{Failed: 1, Pending: 3}, *failed)
+		assert.Equal(t, dto.OutboxRelayResult{}, *waiting)
+		assert.Equal(t, 1, broker.attempts, "the run stops at the first failure and the rest wait for the backoff")
+
+		broker.setDown(false)
+		due, err := outboxRepo.ClaimDue(time.Now().Add(3*time.Second), time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, due, 3)
+		assert.Equal(t, 1, due[0].Attempts)
+		assert.Equal(t, "connection refused", due[0].LastError)
+		assert.Zero(t, due[1].Attempts)
+	})
+}
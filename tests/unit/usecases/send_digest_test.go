@@ -0,0 +1,85 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/email"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/activity"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendDigestUseCase_Execute(t *testing.T) {
+	// Setup
+	renderer, err := email.NewDigestRenderer("")
+	require.NoError(t, err)
+
+	from := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	recipients := []string{"finance@example.com"}
+
+	t.Run("Digest includes summary and drained activity", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(mocks.MockTransactionRepository)
+		mockSender := new(mocks.MockEmailSender)
+		recorder := activity.NewRecorder()
+		recorder.RecordConversion()
+		recorder.RecordConversion()
+		recorder.RecordTreasuryFailure()
+
+		usecase := usecases.NewSendDigestUseCase(mockRepo, recorder, renderer, mockSender, recipients)
+
+		mockRepo.On("SummarizeCreatedBetween", from, to).
+			Return(entities.TransactionSummary{Count: 3, Total: entities.NewMoney(150.25)}, nil).Once()
+		mockSender.On("Send", recipients, mock.AnythingOfType("string"), mock.MatchedBy(func(body string) bool {
+			return assert.Contains(t, body, "150.25") && assert.Contains(t, body, "Conversions performed:   2")
+		})).Return(nil).Once()
+
+		// Act
+		report, err := usecase.Execute("daily", from, to)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), report.NewTransactions)
+		assert.Equal(t, 150.25, report.TotalSpend)
+		assert.Equal(t, int64(2), report.Conversions)
+		assert.Equal(t, int64(1), report.TreasuryFailures)
+		assert.Equal(t, activity.Snapshot{}, recorder.Drain())
+
+		mockRepo.AssertExpectations(t)
+		mockSender.AssertExpectations(t)
+	})
+
+	t.Run("Send failure is reported", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(mocks.MockTransactionRepository)
+		mockSender := new(mocks.MockEmailSender)
+		usecase := usecases.NewSendDigestUseCase(mockRepo, activity.NewRecorder(), renderer, mockSender, recipients)
+
+		mockRepo.On("SummarizeCreatedBetween", from, to).Return(entities.TransactionSummary{}, nil).Once()
+		mockSender.On("Send", recipients, mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+
+		// Act
+		report, err := usecase.Execute("daily", from, to)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, report)
+		assert.Contains(t, err.Error(), "failed to send digest")
+	})
+
+	t.Run("No recipients", func(t *testing.T) {
+		usecase := usecases.NewSendDigestUseCase(new(mocks.MockTransactionRepository), activity.NewRecorder(), renderer, new(mocks.MockEmailSender), nil)
+
+		_, err := usecase.Execute("daily", from, to)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed")
+	})
+}
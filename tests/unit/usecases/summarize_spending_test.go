@@ -0,0 +1,154 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/dto"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/errs"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeSpendingUseCase(t *testing.T) {
+	// Setup
+	transactionRepo := memory.NewTransactionRepository()
+	usecase := usecases.NewSummarizeSpendingUseCase(memory.NewReportRepository(transactionRepo), fixedRateFinder{rate: 0.5}, validation.NewValidator())
+
+	for _, purchase := range []struct {
+		date   string
+		amount entities.Money
+	}{
+		{"2024-01-05", 1000},
+		{"2024-01-31", 2001},
+		{"2024-02-10", 500},
+		{"2024-04-01", 7000},
+		{"2025-01-01", 100},
+	} {
+		date, err := time.Parse(time.DateOnly, purchase.date)
+		require.NoError(t, err)
+		require.NoError(t, transactionRepo.Save(&entities.Transaction{
+			ID: uuid.New(), Description: "Purchase", Date: date, Amount: purchase.amount,
+		}))
+	}
+
+	day := func(value string) *time.Time {
+		date, err := time.Parse(time.DateOnly, value)
+		require.NoError(t, err)
+		return &date
+	}
+
+	t.Run("Months without purchases are left out", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.SummaryReportRequest{From: day("2024-01-01"), To: day("2024-12-31")})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, entities.GroupByMonth, response.GroupBy)
+		assert.Equal(t, entities.USD, response.Currency)
+		require.Len(t, response.Periods, 3)
+		assert.Equal(t, dto.PeriodSummaryResponse{Period: "2024-01", Count: 2, Total: 30.01, Average: 15.01, Min: 10, Max: 20.01}, response.Periods[0])
+		assert.Equal(t, "2024-02", response.Periods[1].Period)
+		assert.Equal(t, "2024-04", response.Periods[2].Period)
+		assert.Equal(t, dto.PeriodSummaryResponse{Count: 4, Total: 105.01, Average: 26.25, Min: 5, Max: 70}, response.Totals)
+	})
+
+	t.Run("Both bounds are inclusive", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.SummaryReportRequest{From: day("2024-01-31"), To: day("2024-04-01"), GroupBy: entities.GroupByDay})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, response.Periods, 3)
+		assert.Equal(t, "2024-01-31", response.Periods[0].Period)
+		assert.Equal(t, "2024-04-01", response.Periods[2].Period)
+	})
+
+	t.Run("Years", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.SummaryReportRequest{From: day("2020-01-01"), To: day("2025-12-31"), GroupBy: entities.GroupByYear})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, response.Periods, 2)
+		assert.Equal(t, "2024", response.Periods[0].Period)
+		assert.Equal(t, int64(4), response.Periods[0].Count)
+		assert.Equal(t, "2025", response.Periods[1].Period)
+	})
+
+	t.Run("An empty range has zero totals", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.SummaryReportRequest{From: day("2023-01-01"), To: day("2023-12-31")})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, response.Periods)
+		assert.Equal(t, dto.PeriodSummaryResponse{}, response.Totals)
+	})
+
+	t.Run("The default range ends today and spans 12 months", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.SummaryReportRequest{})
+
+		// Assert
+		require.NoError(t, err)
+		today := time.Now().UTC()
+		assert.Equal(t, today.Format(time.DateOnly), response.To)
+		assert.Equal(t, time.Date(today.Year(), today.Month()-11, 1, 0, 0, 0, 0, time.UTC).Format(time.DateOnly), response.From)
+	})
+
+	t.Run("Amounts are converted at the rate of the last day", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.SummaryReportRequest{From: day("2024-01-01"), To: day("2024-12-31"), Currency: "EUR"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, entities.CurrencyCode("EUR"), response.Currency)
+		require.NotNil(t, response.ExchangeRate)
+		assert.Equal(t, 0.5, *response.ExchangeRate)
+		assert.Equal(t, "2024-12-31", response.EffectiveDate.Format(time.DateOnly))
+		assert.Equal(t, dto.PeriodSummaryResponse{Period: "2024-01", Count: 2, Total: 15.01, Average: 7.51, Min: 5, Max: 10.01}, response.Periods[0])
+		assert.Equal(t, dto.PeriodSummaryResponse{Count: 4, Total: 52.51, Average: 13.13, Min: 2.5, Max: 35}, response.Totals)
+	})
+
+	t.Run("USD needs no conversion", func(t *testing.T) {
+		// Act
+		response, err := usecase.Execute(context.Background(), &dto.SummaryReportRequest{From: day("2024-01-01"), To: day("2024-12-31"), Currency: entities.USD})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, response.ExchangeRate)
+		assert.Equal(t, 105.01, response.Totals.Total)
+	})
+
+	t.Run("A missing rate fails the report", func(t *testing.T) {
+		// Arrange
+		noRates := usecases.NewSummarizeSpendingUseCase(memory.NewReportRepository(transactionRepo),
+			fixedRateFinder{err: errs.Newf(errs.ErrRateUnavailable, "no rate")}, validation.NewValidator())
+
+		// Act
+		_, err := noRates.Execute(context.Background(), &dto.SummaryReportRequest{Currency: "EUR"})
+
+		// Assert
+		assert.ErrorIs(t, err, errs.ErrRateUnavailable)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		// Act
+		_, reversedErr := usecase.Execute(context.Background(), &dto.SummaryReportRequest{From: day("2024-02-01"), To: day("2024-01-01")})
+		_, groupingErr := usecase.Execute(context.Background(), &dto.SummaryReportRequest{GroupBy: "week"})
+		_, currencyErr := usecases.NewSummarizeSpendingUseCase(memory.NewReportRepository(transactionRepo), nil, validation.NewValidator()).
+			Execute(context.Background(), &dto.SummaryReportRequest{Currency: "EUR"})
+
+		// Assert
+		assert.ErrorIs(t, reversedErr, errs.ErrValidation)
+		assert.ErrorIs(t, groupingErr, errs.ErrValidation)
+		assert.ErrorIs(t, currencyErr, errs.ErrValidation)
+	})
+}
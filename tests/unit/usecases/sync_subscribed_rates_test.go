@@ -0,0 +1,156 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/application/usecases"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/clock"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/infrastructure/memory"
+	"github.com/rafaelreis-se/purchase-transaction-api/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncSubscribedRatesUseCase(t *testing.T) {
+	// Setup
+	freshFor := 100 * 24 * time.Hour
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	subscribe := func(t *testing.T, repo interface {
+		Save(*entities.RateSubscription) error
+	}, currencies ...entities.CurrencyCode) {
+		t.Helper()
+		for _, currency := range currencies {
+			require.NoError(t, repo.Save(&entities.RateSubscription{ID: uuid.New(), Subscriber: "client", Currency: currency}))
+		}
+	}
+
+	cacheRate := func(t *testing.T, repo interface {
+		Save(*entities.ExchangeRate) error
+	}, currency entities.CurrencyCode, rate float64, effective time.Time) {
+		t.Helper()
+		exchangeRate, err := entities.NewExchangeRate(entities.USD, currency, rate, effective, clock.System())
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(exchangeRate))
+	}
+
+	t.Run("Skips fresh currencies and refreshes stale and missing ones", func(t *testing.T) {
+		// Arrange
+		subscriptionRepo := memory.NewRateSubscriptionRepository()
+		exchangeRateRepo := memory.NewExchangeRateRepository()
+		treasury := &mocks.MockTreasuryService{}
+		subscribe(t, subscriptionRepo, "EUR", "BRL", "CAD")
+		cacheRate(t, exchangeRateRepo, "EUR", 0.9, today.AddDate(0, 0, -10))
+		cacheRate(t, exchangeRateRepo, "BRL", 5.0, today.AddDate(0, 0, -150))
+
+		brl, _ := entities.NewExchangeRate(entities.USD, "BRL", 5.2, today.AddDate(0, 0, -5), clock.System())
+		cad, _ := entities.NewExchangeRate(entities.USD, "CAD", 1.35, today.AddDate(0, 0, -5), clock.System())
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.CurrencyCode("BRL"), mock.Anything).Return(brl, nil).Once()
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.CurrencyCode("CAD"), mock.Anything).Return(cad, nil).Once()
+
+		useCase := usecases.NewSyncSubscribedRatesUseCase(subscriptionRepo, exchangeRateRepo, treasury, freshFor, 10)
+
+		// Act
+		result, err := useCase.Execute(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Subscribed)
+		assert.Equal(t, 1, result.Fresh)
+		assert.Equal(t, 2, result.Checked)
+		assert.Equal(t, 2, result.Refreshed)
+		assert.Equal(t, 0, result.Failed)
+		treasury.AssertExpectations(t)
+
+		latest, err := exchangeRateRepo.FindRateForConversion(entities.USD, "BRL", time.Now(), entities.RateWindow{})
+		require.NoError(t, err)
+		assert.Equal(t, 5.2, latest.Rate)
+
+		subscriptions, err := subscriptionRepo.FindBySubscriber("client")
+		require.NoError(t, err)
+		for _, subscription := range subscriptions {
+			if subscription.Currency == "EUR" {
+				assert.Nil(t, subscription.LastSyncedAt)
+			} else {
+				assert.NotNil(t, subscription.LastSyncedAt)
+			}
+		}
+	})
+
+	t.Run("Fetches missing rates first and defers the rest beyond the per-run limit", func(t *testing.T) {
+		// Arrange
+		subscriptionRepo := memory.NewRateSubscriptionRepository()
+		exchangeRateRepo := memory.NewExchangeRateRepository()
+		treasury := &mocks.MockTreasuryService{}
+		subscribe(t, subscriptionRepo, "AUD", "JPY")
+		cacheRate(t, exchangeRateRepo, "AUD", 1.5, today.AddDate(0, 0, -150))
+
+		jpy, _ := entities.NewExchangeRate(entities.USD, "JPY", 150, today.AddDate(0, 0, -5), clock.System())
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.CurrencyCode("JPY"), mock.Anything).Return(jpy, nil).Once()
+
+		useCase := usecases.NewSyncSubscribedRatesUseCase(subscriptionRepo, exchangeRateRepo, treasury, freshFor, 1)
+
+		// Act
+		result, err := useCase.Execute(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Checked)
+		assert.Equal(t, 1, result.Deferred)
+		treasury.AssertExpectations(t)
+	})
+
+	t.Run("Keeps the cached rate when the provider has nothing newer", func(t *testing.T) {
+		// Arrange
+		subscriptionRepo := memory.NewRateSubscriptionRepository()
+		exchangeRateRepo := memory.NewExchangeRateRepository()
+		treasury := &mocks.MockTreasuryService{}
+		subscribe(t, subscriptionRepo, "MXN")
+		cached := today.AddDate(0, 0, -120)
+		cacheRate(t, exchangeRateRepo, "MXN", 17, cached)
+
+		same, _ := entities.NewExchangeRate(entities.USD, "MXN", 17, cached, clock.System())
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.CurrencyCode("MXN"), mock.Anything).Return(same, nil).Once()
+
+		useCase := usecases.NewSyncSubscribedRatesUseCase(subscriptionRepo, exchangeRateRepo, treasury, freshFor, 10)
+
+		// Act
+		result, err := useCase.Execute(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Checked)
+		assert.Equal(t, 0, result.Refreshed)
+	})
+
+	t.Run("Records provider failures on the subscription", func(t *testing.T) {
+		// Arrange
+		subscriptionRepo := memory.NewRateSubscriptionRepository()
+		exchangeRateRepo := memory.NewExchangeRateRepository()
+		treasury := &mocks.MockTreasuryService{}
+		subscribe(t, subscriptionRepo, "GBP")
+		treasury.On("FetchExchangeRate", mock.Anything, entities.USD, entities.CurrencyCode("GBP"), mock.Anything).
+			Return(nil, errors.New("treasury unavailable")).Once()
+
+		useCase := usecases.NewSyncSubscribedRatesUseCase(subscriptionRepo, exchangeRateRepo, treasury, freshFor, 10)
+
+		// Act
+		result, err := useCase.Execute(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Failed)
+
+		subscriptions, err := subscriptionRepo.FindBySubscriber("client")
+		require.NoError(t, err)
+		require.Len(t, subscriptions, 1)
+		assert.Contains(t, subscriptions[0].LastError, "treasury unavailable")
+		assert.NotNil(t, subscriptions[0].LastSyncedAt)
+	})
+}
@@ -0,0 +1,101 @@
+package validation_test
+
+import (
+	"math"
+	"testing"
+
+	govalidator "github.com/go-playground/validator/v10"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/domain/entities"
+	"github.com/rafaelreis-se/purchase-transaction-api/internal/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyTag(t *testing.T) {
+	validator := validation.NewValidator()
+
+	type request struct {
+		Currency entities.CurrencyCode `validate:"required,currency"`
+	}
+
+	testCases := []struct {
+		name       string
+		currency   entities.CurrencyCode
+		shouldPass bool
+	}{
+		{"Known currency", entities.EUR, true},
+		{"Base currency", entities.USD, true},
+		{"Unknown currency", "XYZ", false},
+		{"Lowercase code", "eur", false},
+		{"Wrong length", "EURO", false},
+		{"Empty", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validator.Struct(request{Currency: tc.currency})
+
+			if tc.shouldPass {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	t.Run("Plain string fields", func(t *testing.T) {
+		type plain struct {
+			Currency string `validate:"currency"`
+		}
+
+		assert.NoError(t, validator.Struct(plain{Currency: "BRL"}))
+		assert.ErrorContains(t, validator.Struct(plain{Currency: "ABC"}), "'currency' tag")
+	})
+
+	t.Run("Request bodies may send codes in any case", func(t *testing.T) {
+		// Arrange
+		binding := govalidator.New()
+		require.NoError(t, validation.RegisterBindingValidations(binding))
+		type body struct {
+			Currency string `validate:"required,currency"`
+		}
+
+		// Act & Assert
+		assert.NoError(t, binding.Struct(body{Currency: "eur"}))
+		assert.NoError(t, binding.Struct(body{Currency: " BRL "}))
+		assert.Error(t, binding.Struct(body{Currency: "xyz"}))
+		assert.Error(t, binding.Struct(body{Currency: "EURO"}))
+	})
+}
+
+func TestMoneyTag(t *testing.T) {
+	validator := validation.NewValidator()
+
+	type request struct {
+		Amount float64 `validate:"money"`
+	}
+
+	testCases := []struct {
+		name       string
+		amount     float64
+		shouldPass bool
+	}{
+		{"Ordinary amount", 99.99, true},
+		{"Largest whole amount", 90_000_000_000_000_000, true},
+		{"Beyond the range of Money", 1e17, false},
+		{"Infinity", math.Inf(1), false},
+		{"Not a number", math.NaN(), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validator.Struct(request{Amount: tc.amount})
+
+			if tc.shouldPass {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, "'money' tag")
+			}
+		})
+	}
+}
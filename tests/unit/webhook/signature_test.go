@@ -0,0 +1,85 @@
+package webhook_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaelreis-se/purchase-transaction-api/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret := "whsec_test"
+	payload := []byte(`{"event":"transaction.created","id":"42"}`)
+	sentAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	header := webhook.Sign(secret, payload, sentAt)
+
+	t.Run("Accepts a fresh, untampered delivery", func(t *testing.T) {
+		assert.True(t, strings.HasPrefix(header, "t=1740830400,v1="))
+		assert.NoError(t, webhook.Verify(secret, header, payload, time.Minute, sentAt.Add(30*time.Second)))
+	})
+
+	t.Run("Rejects tampered payloads and wrong secrets", func(t *testing.T) {
+		tampered := []byte(`{"event":"transaction.created","id":"43"}`)
+		assert.ErrorIs(t, webhook.Verify(secret, header, tampered, time.Minute, sentAt), webhook.ErrNoMatch)
+		assert.ErrorIs(t, webhook.Verify("whsec_other", header, payload, time.Minute, sentAt), webhook.ErrNoMatch)
+	})
+
+	t.Run("Rejects deliveries outside the replay window", func(t *testing.T) {
+		assert.ErrorIs(t, webhook.Verify(secret, header, payload, time.Minute, sentAt.Add(2*time.Minute)), webhook.ErrTimestampOutside)
+		assert.ErrorIs(t, webhook.Verify(secret, header, payload, time.Minute, sentAt.Add(-2*time.Minute)), webhook.ErrTimestampOutside)
+
+		// A replayed signature cannot be moved to a new timestamp
+		signature := header[strings.Index(header, "v1="):]
+		replayed := "t=1740830500," + signature
+		assert.ErrorIs(t, webhook.Verify(secret, replayed, payload, time.Minute, sentAt.Add(100*time.Second)), webhook.ErrNoMatch)
+	})
+
+	t.Run("Accepts any matching signature during secret rotation", func(t *testing.T) {
+		oldHeader := webhook.Sign("whsec_old", payload, sentAt)
+		combined := header + "," + oldHeader[strings.Index(oldHeader, "v1="):]
+		assert.NoError(t, webhook.Verify("whsec_old", combined, payload, 0, sentAt))
+		assert.NoError(t, webhook.Verify(secret, combined, payload, 0, sentAt))
+	})
+
+	t.Run("Rejects missing and malformed headers", func(t *testing.T) {
+		assert.ErrorIs(t, webhook.Verify(secret, "", payload, 0, sentAt), webhook.ErrMissingSignature)
+		assert.ErrorIs(t, webhook.Verify(secret, "v1=abc", payload, 0, sentAt), webhook.ErrInvalidHeader)
+		assert.ErrorIs(t, webhook.Verify(secret, "t=abc,v1=abc", payload, 0, sentAt), webhook.ErrInvalidHeader)
+		assert.ErrorIs(t, webhook.Verify(secret, "garbage", payload, 0, sentAt), webhook.ErrInvalidHeader)
+	})
+}
+
+func TestVerifyRequest(t *testing.T) {
+	secret, err := webhook.NewSecret()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, "whsec_"))
+
+	payload := []byte(`{"event":"transaction.converted"}`)
+
+	t.Run("Returns the payload of a valid delivery", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("POST", "/hooks", bytes.NewReader(payload))
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(secret, payload, time.Now()))
+
+		// Act
+		body, err := webhook.VerifyRequest(secret, req, 0)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, payload, body)
+	})
+
+	t.Run("Returns no payload for an unsigned delivery", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/hooks", bytes.NewReader(payload))
+
+		body, err := webhook.VerifyRequest(secret, req, 0)
+
+		assert.ErrorIs(t, err, webhook.ErrMissingSignature)
+		assert.Nil(t, body)
+	})
+}